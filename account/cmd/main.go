@@ -12,6 +12,7 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/p12s/furniture-store/account/internal/broker"
 	"github.com/p12s/furniture-store/account/internal/config"
+	"github.com/p12s/furniture-store/account/internal/mailer"
 	"github.com/p12s/furniture-store/account/internal/repository"
 	"github.com/p12s/furniture-store/account/internal/service"
 	handler "github.com/p12s/furniture-store/account/internal/transport/rest"
@@ -30,20 +31,35 @@ func main() {
 		logrus.Fatalf("error loading env variables: %s\n", err.Error())
 	}
 
-	db, err := repository.NewSqlite3DB(repository.Config{Driver: cfg.DB.Driver})
+	repoConfig := repository.Config{Driver: cfg.DB.Driver, LoginAttempts: cfg.Login.AttemptsDriver}
+	db, err := repository.NewSqlite3DB(repoConfig)
 	if err != nil {
 		logrus.Fatalf("failed to initialize db: %s\n", err.Error())
 	}
 
-	repos := repository.NewRepository(db)
+	repos, err := repository.NewRepository(db, repoConfig)
 	if err != nil {
-		logrus.Fatalf("failed to initialize authentication token ttl: %s\n", err.Error())
+		logrus.Fatalf("failed to initialize repository: %s\n", err.Error())
 	}
-	services := service.NewService(repos, &cfg.Auth)
+	mail, err := mailer.New(&cfg.Mail)
+	if err != nil {
+		logrus.Fatalf("failed to initialize mailer: %s\n", err.Error())
+	}
+	oidcKey, err := service.LoadOIDCKey(cfg.OIDC.KeyFile)
+	if err != nil {
+		logrus.Fatalf("failed to load oidc key: %s\n", err.Error())
+	}
+	services := service.NewService(repos, mail, &cfg.Auth, &cfg.Verify, &cfg.Reset, &cfg.TwoFactor, &cfg.Login,
+		&cfg.Privacy, &cfg.OIDC, oidcKey, &cfg.APIKey)
 	broker, err := broker.NewBroker(services, &cfg.Broker)
 	if err != nil {
 		logrus.Fatalf("kafka error: %s\n", err.Error())
 	}
+	go func() {
+		if err := broker.Subscribe(); err != nil {
+			logrus.Fatalf("broker subscribe fail: %s\n", err.Error())
+		}
+	}()
 	handlers := handler.NewHandler(services, broker)
 
 	srv := new(Server)
@@ -19,6 +19,7 @@ type Broker struct {
 	TopicOrderBE, TopicOrderCUD       string
 	TopicDeliveryBE, TopicDeliveryCUD string
 	TopicBillingBE, TopicBillingCUD   string
+	TopicPrivacy                      string
 }
 
 // NewBroker - constructor
@@ -45,5 +46,6 @@ func NewBroker(service *service.Service, config *config.Broker) (*Broker, error)
 		TopicDeliveryCUD: config.TopicDeliveryCUD,
 		TopicBillingBE:   config.TopicBillingBE,
 		TopicBillingCUD:  config.TopicBillingCUD,
+		TopicPrivacy:     config.TopicPrivacy,
 	}, nil
 }
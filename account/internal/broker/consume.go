@@ -35,6 +35,7 @@ type BrokerConsume struct {
 	TopicOrderBE, TopicOrderCUD       string
 	TopicDeliveryBE, TopicDeliveryCUD string
 	TopicBillingBE, TopicBillingCUD   string
+	TopicPrivacy                      string
 }
 
 /*
@@ -71,6 +72,7 @@ func NewConsumer(service *service.Service, conf *config.Broker) (*BrokerConsume,
 		TopicDeliveryCUD: conf.TopicDeliveryBE,
 		TopicBillingBE:   conf.TopicBillingBE,
 		TopicBillingCUD:  conf.TopicBillingCUD,
+		TopicPrivacy:     conf.TopicPrivacy,
 	}, nil
 }
 
@@ -78,12 +80,9 @@ func (k *BrokerConsume) Subscribe() error {
 	sigchan := make(chan os.Signal, 1)
 	signal.Notify(sigchan, syscall.SIGINT, syscall.SIGTERM)
 
-	err := k.connection.SubscribeTopics([]string{
-		k.TopicAccountBE, k.TopicAccountCUD,
-		k.TopicProductBE, k.TopicProductCUD,
-		k.TopicOrderBE, k.TopicOrderCUD,
-		k.TopicDeliveryBE, k.TopicDeliveryCUD,
-	}, nil)
+	// the account events are produced here, consuming them again would repeat the changes.
+	// Only the api key usage is read from the product topic
+	err := k.connection.SubscribeTopics([]string{k.TopicPrivacy, k.TopicProductBE}, nil)
 	if err != nil {
 		return fmt.Errorf("subscribe broker topics fail: %w", err)
 	}
@@ -145,6 +144,21 @@ func (k *BrokerConsume) ProcessEvent(event domain.Event) {
 		if err != nil {
 			logrus.Errorf("process 'delete account' event fail: %s/n", err.Error())
 		}
+	case domain.EVENT_PRIVACY_DATA_EXPORTED:
+		err := k.answerPrivacyRequest(domain.PRIVACY_EXPORT, event.Value)
+		if err != nil {
+			logrus.Errorf("process 'data exported' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_PRIVACY_ERASURE_COMPLETED:
+		err := k.answerPrivacyRequest(domain.PRIVACY_ERASURE, event.Value)
+		if err != nil {
+			logrus.Errorf("process 'erasure completed' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_API_KEY_USED:
+		err := k.touchAPIKey(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'api key used' event fail: %s/n", err.Error())
+		}
 	default:
 		fmt.Printf("unknown event type: %v/n", event.Value)
 	}
@@ -157,13 +171,14 @@ func (k *BrokerConsume) createAccount(payload interface{}) error {
 		return fmt.Errorf("account-create payload fail: %w/n", err)
 	}
 
-	return k.service.CreateAccount(domain.Account{
+	_, err = k.service.CreateAccount(domain.Account{
 		PublicId: account.PublicId,
 		Name:     account.Name,
 		Username: account.Username,
 		Email:    account.Email,
 		Address:  account.Address,
 	})
+	return err
 }
 
 func (k *BrokerConsume) updateAccountInfo(payload interface{}) error {
@@ -190,10 +205,29 @@ func (k *BrokerConsume) updateAccountRole(payload interface{}) error {
 		return fmt.Errorf("account-role update payload fail: %w/n", err)
 	}
 
-	return k.service.UpdateAccountRole(domain.UpdateAccountRoleInput{
+	err = k.service.UpdateAccountRole(domain.UpdateAccountRoleInput{
 		PublicId: data.PublicId,
 		Role:     data.Role,
 	})
+	k.auditRoleChange(data, err)
+	return err
+}
+
+// auditRoleChange - the role comes by the event, so the actor is unknown
+func (k *BrokerConsume) auditRoleChange(data domain.UpdateAccountRoleInput, err error) {
+	entry := domain.AuditEntry{
+		TargetPublicId: &data.PublicId,
+		Action:         domain.AUDIT_ROLE_CHANGED,
+		Outcome:        domain.AUDIT_SUCCESS,
+		Details:        fmt.Sprintf("role %d", data.Role),
+	}
+	if err != nil {
+		entry.Outcome = domain.AUDIT_FAILURE
+		entry.Details = err.Error()
+	}
+	if err := k.service.RecordAudit(entry); err != nil {
+		logrus.Errorf("record %s audit fail: %s/n", entry.Action, err.Error())
+	}
 }
 
 func (k *BrokerConsume) updateAccountToken(payload interface{}) error {
@@ -219,6 +253,34 @@ func (k *BrokerConsume) deleteAccount(payload interface{}) error {
 	return k.service.DeleteAccount(data.PublicId)
 }
 
+func (k *BrokerConsume) touchAPIKey(payload interface{}) error {
+	var data domain.APIKeyUsedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("api-key used payload fail: %w/n", err)
+	}
+
+	return k.service.TouchAPIKey(data)
+}
+
+// answerPrivacyRequest - the part of the service is added to the export or the erasure
+func (k *BrokerConsume) answerPrivacyRequest(kind domain.PrivacyKind, payload interface{}) error {
+	var data domain.PrivacyAnsweredEvent
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("privacy answer payload fail: %w/n", err)
+	}
+
+	request, err := k.service.AnswerPrivacyRequest(kind, data)
+	if err != nil {
+		return err
+	}
+	if request.Status == domain.PRIVACY_COMPLETED {
+		logrus.Printf("privacy %s %s completed/n", request.Kind, request.PublicId)
+	}
+	return nil
+}
+
 func readPayload(payload interface{}, target interface{}) error {
 	jsonString, err := json.Marshal(payload)
 	if err != nil {
@@ -4,11 +4,19 @@ import "github.com/kelseyhightower/envconfig"
 
 // Config
 type Config struct {
-	DB     DB
-	Server Server
-	Auth   Auth
-	Broker Broker
-	Env    Env
+	DB        DB
+	Server    Server
+	Auth      Auth
+	Mail      Mail
+	Verify    Verify
+	Reset     Reset
+	TwoFactor TwoFactor
+	Login     Login
+	Privacy   Privacy
+	OIDC      OIDC
+	APIKey    APIKey
+	Broker    Broker
+	Env       Env
 }
 
 // DB
@@ -28,6 +36,79 @@ type Auth struct {
 	SigningKey string `envconfig:"AUTH_SIGNING_KEY" required:"true"`
 }
 
+// Mail - outgoing mail, driver is smtp or fake. Fake keeps the mails in memory and logs them,
+// for local and offline testing only. Empty username means no smtp authentication
+type Mail struct {
+	Driver   string `envconfig:"MAIL_DRIVER" required:"true"`
+	Host     string `envconfig:"MAIL_HOST"`
+	Port     int    `envconfig:"MAIL_PORT"`
+	Username string `envconfig:"MAIL_USERNAME"`
+	Password string `envconfig:"MAIL_PASSWORD"`
+	From     string `envconfig:"MAIL_FROM" required:"true"`
+}
+
+// Verify - email verification, the signing key must differ from the auth one,
+// so a verification token can't be used as an access token. Url gets the token query parameter
+type Verify struct {
+	SigningKey     string `envconfig:"VERIFY_SIGNING_KEY" required:"true"`
+	TokenTTL       int    `envconfig:"VERIFY_TOKEN_TTL" required:"true"`
+	URL            string `envconfig:"VERIFY_URL" required:"true"`
+	ResendInterval int    `envconfig:"VERIFY_RESEND_INTERVAL" required:"true"`
+	MaxPerHour     int    `envconfig:"VERIFY_MAX_PER_HOUR" required:"true"`
+}
+
+// Reset - password reset, url gets the token query parameter. Only the token hash is stored
+type Reset struct {
+	TokenTTL       int    `envconfig:"RESET_TOKEN_TTL" required:"true"`
+	URL            string `envconfig:"RESET_URL" required:"true"`
+	ResendInterval int    `envconfig:"RESET_RESEND_INTERVAL" required:"true"`
+	MaxPerHour     int    `envconfig:"RESET_MAX_PER_HOUR" required:"true"`
+}
+
+// TwoFactor - totp, the signing key of the sign-in challenge must differ from the auth one,
+// so a challenge token can't be used as an access token. Roles can't sign in without two-factor
+type TwoFactor struct {
+	SigningKey   string `envconfig:"TWO_FACTOR_SIGNING_KEY" required:"true"`
+	Issuer       string `envconfig:"TWO_FACTOR_ISSUER" required:"true"`
+	ChallengeTTL int    `envconfig:"TWO_FACTOR_CHALLENGE_TTL" required:"true"`
+	Roles        []int  `envconfig:"TWO_FACTOR_ROLES"`
+}
+
+// Login - failed sign-in counters by the email and by the ip, attempts driver is sqlite or memory.
+// Memory counters are not shared between instances. Lockout seconds double with every failure over the limit,
+// failures older than the window are forgotten
+type Login struct {
+	AttemptsDriver string `envconfig:"LOGIN_ATTEMPTS_DRIVER" required:"true"`
+	MaxFailures    int    `envconfig:"LOGIN_MAX_FAILURES" required:"true"`
+	MaxIPFailures  int    `envconfig:"LOGIN_MAX_IP_FAILURES" required:"true"`
+	Lockout        int    `envconfig:"LOGIN_LOCKOUT" required:"true"`
+	MaxLockout     int    `envconfig:"LOGIN_MAX_LOCKOUT" required:"true"`
+	FailureWindow  int    `envconfig:"LOGIN_FAILURE_WINDOW" required:"true"`
+}
+
+// Privacy - the services answering the data export and the erasure requests of an account,
+// the request is completed when all of them answered
+type Privacy struct {
+	Services []string `envconfig:"PRIVACY_SERVICES" required:"true"`
+}
+
+// OIDC - the account service as the OpenID Connect provider, the issuer is its public base url.
+// The key file is the pem rsa private key signing the id tokens, without it a new key is generated
+// on every start and the issued id tokens can't be verified after a restart, for local testing only
+type OIDC struct {
+	Issuer  string `envconfig:"OIDC_ISSUER" required:"true"`
+	KeyFile string `envconfig:"OIDC_KEY_FILE"`
+	CodeTTL int    `envconfig:"OIDC_CODE_TTL" required:"true"`
+}
+
+// APIKey - the keys of the dealer integrations, ttl is the default expiration, max ttl is the longest one.
+// Seconds
+type APIKey struct {
+	TTL     int `envconfig:"API_KEY_TTL" required:"true"`
+	MaxTTL  int `envconfig:"API_KEY_MAX_TTL" required:"true"`
+	MaxKeys int `envconfig:"API_KEY_MAX_KEYS" required:"true"`
+}
+
 // Broker
 type Broker struct {
 	// TopicPrefix      string `envconfig:"BROKER_TOPIC_PREFIX" required:"true"`
@@ -44,6 +125,7 @@ type Broker struct {
 	TopicDeliveryCUD string `envconfig:"BROKER_TOPIC_DELIVERY_CUD" required:"true"`
 	TopicBillingBE   string `envconfig:"BROKER_TOPIC_BILLING_BE" required:"true"`
 	TopicBillingCUD  string `envconfig:"BROKER_TOPIC_BILLING_CUD" required:"true"`
+	TopicPrivacy     string `envconfig:"BROKER_TOPIC_PRIVACY" required:"true"`
 	GroupId          string `envconfig:"BROKER_GROUP_ID" required:"true"`
 }
 
@@ -71,6 +153,38 @@ func New() (*Config, error) {
 		return nil, err
 	}
 
+	if err := envconfig.Process("mail", &cfg.Mail); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("verify", &cfg.Verify); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("reset", &cfg.Reset); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("two_factor", &cfg.TwoFactor); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("login", &cfg.Login); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("privacy", &cfg.Privacy); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("oidc", &cfg.OIDC); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("api_key", &cfg.APIKey); err != nil {
+		return nil, err
+	}
+
 	if err := envconfig.Process("broker", &cfg.Broker); err != nil {
 		return nil, err
 	}
@@ -18,16 +18,20 @@ const (
 
 // Account
 type Account struct {
-	Id        int        `json:"id,omitempty" db:"id"`
-	PublicId  uuid.UUID  `json:"public_id" db:"public_id"`
-	Name      string     `json:"name" db:"name" binding:"required"`
-	Username  string     `json:"username" db:"username" binding:"required"`
-	Password  string     `json:"password,omitempty" db:"password_hash" binding:"required"`
-	Email     string     `json:"email" db:"email" binding:"required"`
-	Address   string     `json:"address" db:"address" binding:"required"` // TODO should be different columns - Country, City, Street, etc.
-	Token     string     `json:"token,omitempty"`
-	Role      Role       `json:"role" db:"role"`
-	CreatedAt *time.Time `json:"created_at,omitempty" db:"created_at"` // nolint
+	Id            int        `json:"id,omitempty" db:"id"`
+	PublicId      uuid.UUID  `json:"public_id" db:"public_id"`
+	Name          string     `json:"name" db:"name" binding:"required"`
+	Username      string     `json:"username" db:"username" binding:"required"`
+	Password      string     `json:"password,omitempty" db:"password_hash" binding:"required"`
+	Email         string     `json:"email" db:"email" binding:"required"`
+	EmailVerified bool       `json:"email_verified" db:"email_verified"`      // by the verification link only
+	Address       string     `json:"address" db:"address" binding:"required"` // line of the default address, see Addresses
+	Addresses     []Address  `json:"addresses,omitempty" db:"-"`
+	Token         string     `json:"token,omitempty"`
+	Role          Role       `json:"role" db:"role"`
+	CreatedAt     *time.Time `json:"created_at,omitempty" db:"created_at"` // nolint
+	// TokensValidAfter - tokens issued before are revoked, it is set by the password reset
+	TokensValidAfter *time.Time `json:"-" db:"tokens_valid_after"`
 }
 
 // SignInInput
@@ -72,6 +76,27 @@ const (
 	EVENT_ACCOUNT_ROLE_UPDATED  EventType = "auth.role_updated"
 	EVENT_ACCOUNT_DELETED       EventType = "auth.deleted"
 	EVENT_ACCOUNT_TOKEN_UPDATED EventType = "auth.token_updated" // nolint
+	EVENT_ADDRESS_CREATED       EventType = "auth.address_created"
+	EVENT_ADDRESS_UPDATED       EventType = "auth.address_updated"
+	EVENT_ADDRESS_DELETED       EventType = "auth.address_deleted"
+	EVENT_SESSIONS_REVOKED      EventType = "auth.sessions_revoked"
+	EVENT_API_KEY_CREATED       EventType = "auth.api_key_created"
+	EVENT_API_KEY_REVOKED       EventType = "auth.api_key_revoked"
+
+	EVENT_ACCOUNT_EMAIL_VERIFIED EventType = "Account.EmailVerified"
+	EVENT_ACCOUNT_PASSWORD_RESET EventType = "Account.PasswordReset"
+	EVENT_ACCOUNT_LOGIN_FAILED   EventType = "Account.LoginFailed"
+	EVENT_ACCOUNT_LOCKED         EventType = "Account.Locked"
+
+	EVENT_ACCOUNT_EXPORT_REQUESTED  EventType = "Account.ExportRequested"
+	EVENT_ACCOUNT_ERASURE_REQUESTED EventType = "Account.ErasureRequested"
+
+	// answers of the services on the privacy topic, only the account service reads it
+	EVENT_PRIVACY_DATA_EXPORTED     EventType = "Privacy.DataExported"
+	EVENT_PRIVACY_ERASURE_COMPLETED EventType = "Privacy.ErasureCompleted"
+
+	// the product service reports the api key usage, it is the only event read from the product topic
+	EVENT_API_KEY_USED EventType = "Product.ApiKeyUsed"
 )
 
 // Event
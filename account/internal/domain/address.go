@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAddressNotFound  = errors.New("address not found")
+	ErrInvalidAddress   = errors.New("invalid address")
+	ErrTooManyAddresses = errors.New("too many addresses")
+)
+
+const (
+	// MAX_ADDRESSES - per account
+	MAX_ADDRESSES = 10
+)
+
+// Address - delivery address of the account, one of the account addresses is the default.
+// Latitude and longitude are set together or not set
+type Address struct {
+	Id              int64     `json:"-" db:"id"`
+	PublicId        uuid.UUID `json:"public_id" db:"public_id"`
+	AccountPublicId uuid.UUID `json:"account_public_id" db:"account_public_id"`
+	Country         string    `json:"country" db:"country"`
+	City            string    `json:"city" db:"city"`
+	Street          string    `json:"street" db:"street"`
+	Building        string    `json:"building" db:"building"`
+	Apartment       string    `json:"apartment" db:"apartment"`
+	PostalCode      string    `json:"postal_code" db:"postal_code"`
+	Notes           string    `json:"notes" db:"notes"`
+	Latitude        *float64  `json:"latitude,omitempty" db:"latitude"`
+	Longitude       *float64  `json:"longitude,omitempty" db:"longitude"`
+	IsDefault       bool      `json:"is_default" db:"is_default"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// AddressInput - create or full update of the address
+type AddressInput struct {
+	Country    string   `json:"country" binding:"required"`
+	City       string   `json:"city" binding:"required"`
+	Street     string   `json:"street" binding:"required"`
+	Building   string   `json:"building" binding:"required"`
+	Apartment  string   `json:"apartment"`
+	PostalCode string   `json:"postal_code"`
+	Notes      string   `json:"notes"`
+	Latitude   *float64 `json:"latitude"`
+	Longitude  *float64 `json:"longitude"`
+	IsDefault  bool     `json:"is_default"`
+}
+
+// Validate - coordinates are checked, the required fields are checked by the binding
+func (i AddressInput) Validate() error {
+	if (i.Latitude == nil) != (i.Longitude == nil) {
+		return fmt.Errorf("%w: latitude and longitude go together", ErrInvalidAddress)
+	}
+	if i.Latitude != nil && (*i.Latitude < -90 || *i.Latitude > 90) {
+		return fmt.Errorf("%w: latitude must be from -90 to 90", ErrInvalidAddress)
+	}
+	if i.Longitude != nil && (*i.Longitude < -180 || *i.Longitude > 180) {
+		return fmt.Errorf("%w: longitude must be from -180 to 180", ErrInvalidAddress)
+	}
+	return nil
+}
+
+// Apply - input fields to the address, the default flag is set by the caller
+func (a *Address) Apply(input AddressInput) {
+	a.Country = strings.TrimSpace(input.Country)
+	a.City = strings.TrimSpace(input.City)
+	a.Street = strings.TrimSpace(input.Street)
+	a.Building = strings.TrimSpace(input.Building)
+	a.Apartment = strings.TrimSpace(input.Apartment)
+	a.PostalCode = strings.TrimSpace(input.PostalCode)
+	a.Notes = strings.TrimSpace(input.Notes)
+	a.Latitude = input.Latitude
+	a.Longitude = input.Longitude
+}
+
+// Line - one line address, it is kept in the account address for the old clients
+func (a Address) Line() string {
+	parts := make([]string, 0, 6)
+	for _, part := range []string{a.PostalCode, a.Country, a.City, a.Street, a.Building, a.Apartment} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// AddressDeleted - the deleted address with the address that became the default instead of it
+type AddressDeleted struct {
+	Deleted    Address
+	NewDefault *Address
+}
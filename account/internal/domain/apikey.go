@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// API_KEY_PREFIX - the keys are recognized by it, the product service takes them as the bearer token
+	API_KEY_PREFIX = "fsk_"
+)
+
+// API key scopes, the product service checks them by the route
+const (
+	API_KEY_SCOPE_PRODUCTS_READ  = "products:read"
+	API_KEY_SCOPE_PRODUCTS_WRITE = "products:write"
+	API_KEY_SCOPE_STOCK_READ     = "stock:read"
+	API_KEY_SCOPE_STOCK_WRITE    = "stock:write"
+)
+
+var (
+	ErrAPIKeyNotFound   = errors.New("api key not found")
+	ErrInvalidAPIKey    = errors.New("invalid api key")
+	ErrAPIKeyNotAllowed = errors.New("api keys are for the dealer accounts")
+	ErrTooManyAPIKeys   = errors.New("too many api keys")
+)
+
+// APIKey - the key of a dealer integration acting as the dealer account with the scopes only.
+// The key is shown once on the creation, only its hash is kept. The prefix is the visible part
+// of the key, it identifies the key in the list and in the requests
+type APIKey struct {
+	Prefix          string     `json:"prefix" db:"prefix"`
+	Key             string     `json:"key,omitempty" db:"-"`
+	KeyHash         string     `json:"-" db:"key_hash"`
+	AccountPublicId uuid.UUID  `json:"-" db:"account_public_id"`
+	Name            string     `json:"name" db:"name"`
+	Scopes          []string   `json:"scopes" db:"-"`
+	ExpiresAt       time.Time  `json:"expires_at" db:"expires_at"`
+	LastUsedAt      *time.Time `json:"last_used_at" db:"last_used_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	RevokedAt       *time.Time `json:"-" db:"revoked_at"`
+}
+
+// CreateAPIKeyInput - the default expiration is set without expires at
+type CreateAPIKeyInput struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyCreatedEvent - auth.api_key_created payload, the product service keeps the copy to check the keys
+type APIKeyCreatedEvent struct {
+	Prefix    string    `json:"prefix"`
+	KeyHash   string    `json:"key_hash"`
+	PublicId  uuid.UUID `json:"public_id"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// APIKeyRevokedEvent - auth.api_key_revoked payload
+type APIKeyRevokedEvent struct {
+	Prefix    string    `json:"prefix"`
+	PublicId  uuid.UUID `json:"public_id"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+// APIKeyUsedInput - Product.ApiKeyUsed payload, it comes not more often than once a minute for a key
+type APIKeyUsedInput struct {
+	Prefix string    `json:"prefix" binding:"required"`
+	UsedAt time.Time `json:"used_at" binding:"required"`
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AUDIT_LIMIT - entries of a page by default
+	AUDIT_LIMIT = 50
+	// AUDIT_MAX_LIMIT - entries of a page at most
+	AUDIT_MAX_LIMIT = 200
+)
+
+var (
+	ErrInvalidAuditFilter = errors.New("invalid audit filter")
+)
+
+// AuditAction
+type AuditAction string
+
+const (
+	AUDIT_SIGN_UP                    AuditAction = "sign_up"
+	AUDIT_SIGN_IN                    AuditAction = "sign_in"
+	AUDIT_SIGN_IN_TWO_FACTOR         AuditAction = "sign_in_two_factor"
+	AUDIT_ACCOUNT_UPDATED            AuditAction = "account_updated"
+	AUDIT_PASSWORD_CHANGED           AuditAction = "password_changed"
+	AUDIT_EMAIL_CHANGED              AuditAction = "email_changed"
+	AUDIT_EMAIL_VERIFIED             AuditAction = "email_verified"
+	AUDIT_EMAIL_VERIFICATION_SENT    AuditAction = "email_verification_sent"
+	AUDIT_PASSWORD_FORGOT            AuditAction = "password_forgot"
+	AUDIT_PASSWORD_RESET             AuditAction = "password_reset"
+	AUDIT_TWO_FACTOR_ENROLLED        AuditAction = "two_factor_enrolled"
+	AUDIT_TWO_FACTOR_ENABLED         AuditAction = "two_factor_enabled"
+	AUDIT_TWO_FACTOR_DISABLED        AuditAction = "two_factor_disabled"
+	AUDIT_RECOVERY_CODES_REGENERATED AuditAction = "recovery_codes_regenerated"
+	AUDIT_SESSION_REVOKED            AuditAction = "session_revoked"
+	AUDIT_OTHER_SESSIONS_REVOKED     AuditAction = "other_sessions_revoked"
+	AUDIT_ROLE_CHANGED               AuditAction = "role_changed"
+	AUDIT_ACCOUNT_DELETED            AuditAction = "account_deleted"
+	AUDIT_DATA_EXPORT_REQUESTED      AuditAction = "data_export_requested"
+	AUDIT_OAUTH_AUTHORIZED           AuditAction = "oauth_authorized"
+	AUDIT_OAUTH_TOKEN_ISSUED         AuditAction = "oauth_token_issued"
+	AUDIT_OAUTH_CLIENT_CREATED       AuditAction = "oauth_client_created"
+	AUDIT_OAUTH_CLIENT_DELETED       AuditAction = "oauth_client_deleted"
+	AUDIT_API_KEY_CREATED            AuditAction = "api_key_created"
+	AUDIT_API_KEY_REVOKED            AuditAction = "api_key_revoked"
+)
+
+// Audit outcomes, challenge is the password step followed by the two-factor one
+const (
+	AUDIT_SUCCESS   = "success"
+	AUDIT_FAILURE   = "failure"
+	AUDIT_CHALLENGE = "challenge"
+)
+
+// AuditEntry - who did what to which account, from where and how it ended. Actor is empty for
+// the anonymous actions and the broker events, target is empty when the account is unknown.
+// Details are the failure reason or the action details
+type AuditEntry struct {
+	Id             int         `json:"id" db:"id"`
+	ActorPublicId  *uuid.UUID  `json:"actor_public_id,omitempty" db:"actor_public_id"`
+	TargetPublicId *uuid.UUID  `json:"target_public_id,omitempty" db:"target_public_id"`
+	Email          string      `json:"email,omitempty" db:"email"`
+	Action         AuditAction `json:"action" db:"action"`
+	Outcome        string      `json:"outcome" db:"outcome"`
+	Details        string      `json:"details,omitempty" db:"details"`
+	IP             string      `json:"ip" db:"ip"`
+	UserAgent      string      `json:"user_agent" db:"user_agent"`
+	CreatedAt      time.Time   `json:"created_at" db:"created_at"`
+}
+
+// AuditFilter - all set fields must match, account is the actor or the target. Newest go first
+type AuditFilter struct {
+	Account string     `form:"account"`
+	Actor   string     `form:"actor"`
+	Target  string     `form:"target"`
+	Email   string     `form:"email"`
+	Action  string     `form:"action"`
+	Outcome string     `form:"outcome"`
+	IP      string     `form:"ip"`
+	From    *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To      *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit   int        `form:"limit"`
+	Offset  int        `form:"offset"`
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrLoginLocked        = errors.New("too many failed sign-in attempts, try later")
+)
+
+// Login failure reasons
+const (
+	LOGIN_FAILED_PASSWORD   = "password"
+	LOGIN_FAILED_TWO_FACTOR = "two_factor"
+)
+
+// Login lock scopes
+const (
+	LOGIN_SCOPE_EMAIL = "email"
+	LOGIN_SCOPE_IP    = "ip"
+)
+
+// LoginAttempts - failed sign-in counter by the email or by the ip, the key has the scope prefix
+type LoginAttempts struct {
+	Key           string     `db:"attempt_key"`
+	Failures      int        `db:"failures"`
+	LastFailureAt time.Time  `db:"last_failure_at"`
+	LockedUntil   *time.Time `db:"locked_until"`
+}
+
+// IsLocked
+func (a LoginAttempts) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+// LoginFailedEvent - Account.LoginFailed payload, the public id is unknown for a wrong password
+type LoginFailedEvent struct {
+	PublicId *uuid.UUID `json:"public_id,omitempty"`
+	Email    string     `json:"email"`
+	IP       string     `json:"ip"`
+	Reason   string     `json:"reason"`
+	Failures int        `json:"failures"`
+	FailedAt time.Time  `json:"failed_at"`
+}
+
+// AccountLockedEvent - Account.Locked payload, sign-in is locked for the email or for the ip
+type AccountLockedEvent struct {
+	Scope       string     `json:"scope"`
+	PublicId    *uuid.UUID `json:"public_id,omitempty"`
+	Email       string     `json:"email,omitempty"`
+	IP          string     `json:"ip,omitempty"`
+	Failures    int        `json:"failures"`
+	LockedUntil time.Time  `json:"locked_until"`
+}
+
+// LoginFailedError - ErrInvalidCredentials or ErrInvalidTwoFactorCode with the events to publish
+type LoginFailedError struct {
+	Err    error
+	Failed LoginFailedEvent
+	Locked []AccountLockedEvent
+}
+
+// Error
+func (e *LoginFailedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap
+func (e *LoginFailedError) Unwrap() error {
+	return e.Err
+}
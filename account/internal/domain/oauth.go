@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OAuth scopes, openid is required for the id token and the userinfo
+const (
+	SCOPE_OPENID  = "openid"
+	SCOPE_PROFILE = "profile"
+	SCOPE_EMAIL   = "email"
+	SCOPE_ROLE    = "role"
+)
+
+// OAuth grant types
+const (
+	GRANT_AUTHORIZATION_CODE = "authorization_code"
+	GRANT_CLIENT_CREDENTIALS = "client_credentials"
+)
+
+const (
+	// RESPONSE_TYPE_CODE - the only supported response type of the authorization
+	RESPONSE_TYPE_CODE = "code"
+	// PKCE_S256 - the only supported code challenge method, plain is not accepted
+	PKCE_S256 = "S256"
+	// TOKEN_TYPE_BEARER
+	TOKEN_TYPE_BEARER = "Bearer"
+)
+
+// OAuth error codes of the authorization and the token endpoints
+const (
+	OAUTH_INVALID_REQUEST           = "invalid_request"
+	OAUTH_INVALID_CLIENT            = "invalid_client"
+	OAUTH_INVALID_GRANT             = "invalid_grant"
+	OAUTH_INVALID_SCOPE             = "invalid_scope"
+	OAUTH_UNAUTHORIZED_CLIENT       = "unauthorized_client"
+	OAUTH_UNSUPPORTED_GRANT_TYPE    = "unsupported_grant_type"
+	OAUTH_UNSUPPORTED_RESPONSE_TYPE = "unsupported_response_type"
+	OAUTH_ACCESS_DENIED             = "access_denied"
+	OAUTH_INSUFFICIENT_SCOPE        = "insufficient_scope"
+)
+
+var (
+	ErrOAuthClientNotFound = errors.New("oauth client not found")
+	ErrInvalidOAuthClient  = errors.New("invalid oauth client")
+	ErrInvalidOAuthGrant   = errors.New("invalid or expired authorization code")
+)
+
+// OAuthError - the error answer of the oauth endpoints. The authorization error with the redirect uri
+// is sent to the client by the redirect, the others are shown to the user
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	RedirectURI string `json:"-"`
+	State       string `json:"-"`
+}
+
+// Error
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// OAuthClient - the application signing in the accounts, registered by an admin. The public client
+// (mobile or browser app) has no secret and uses pkce only. Client credentials grant is for the confidential
+// client acting as its account, the tokens have the role of the account. Empty roles allow all of them.
+// Only the secret hash is kept, the secret is shown once on the registration
+type OAuthClient struct {
+	ClientId        string     `json:"client_id" db:"client_id"`
+	Secret          string     `json:"client_secret,omitempty" db:"-"`
+	SecretHash      string     `json:"-" db:"secret_hash"`
+	Name            string     `json:"name" db:"name"`
+	Public          bool       `json:"public" db:"public"`
+	RedirectURIs    []string   `json:"redirect_uris" db:"-"`
+	GrantTypes      []string   `json:"grant_types" db:"-"`
+	Scopes          []string   `json:"scopes" db:"-"`
+	Roles           []Role     `json:"roles" db:"-"`
+	AccountPublicId *uuid.UUID `json:"account_public_id,omitempty" db:"account_public_id"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreateOAuthClientInput - the openid scope is added to the scopes of the authorization code client
+type CreateOAuthClientInput struct {
+	Name            string     `json:"name" binding:"required"`
+	Public          bool       `json:"public"`
+	RedirectURIs    []string   `json:"redirect_uris"`
+	GrantTypes      []string   `json:"grant_types" binding:"required"`
+	Scopes          []string   `json:"scopes"`
+	Roles           []Role     `json:"roles"`
+	AccountPublicId *uuid.UUID `json:"account_public_id"`
+}
+
+// AuthorizeInput - the authorization request of the signed-in account
+type AuthorizeInput struct {
+	ResponseType        string `form:"response_type"`
+	ClientId            string `form:"client_id"`
+	RedirectURI         string `form:"redirect_uri"`
+	Scope               string `form:"scope"`
+	State               string `form:"state"`
+	Nonce               string `form:"nonce"`
+	CodeChallenge       string `form:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method"`
+}
+
+// ConsentInput - the authorization request with the decision of the account on the consent page
+type ConsentInput struct {
+	AuthorizeInput
+	Approve bool `form:"approve"`
+}
+
+// ConsentRequest - the client and the scopes the account is asked to allow, no code is issued before
+// the account approves them
+type ConsentRequest struct {
+	ClientId    string   `json:"client_id"`
+	ClientName  string   `json:"client_name"`
+	RedirectURI string   `json:"redirect_uri"`
+	Scopes      []string `json:"scopes"`
+}
+
+// AuthorizationCode - issued code, it works once and shortly. Only the code hash is kept
+type AuthorizationCode struct {
+	CodeHash        string     `db:"code_hash"`
+	ClientId        string     `db:"client_id"`
+	AccountPublicId uuid.UUID  `db:"account_public_id"`
+	RedirectURI     string     `db:"redirect_uri"`
+	Scope           string     `db:"scope"`
+	Nonce           string     `db:"nonce"`
+	CodeChallenge   string     `db:"code_challenge"`
+	ExpiresAt       time.Time  `db:"expires_at"`
+	UsedAt          *time.Time `db:"used_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+// TokenInput - the token request, the client secret comes in the basic auth header or in the form
+type TokenInput struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	ClientId     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	Scope        string `form:"scope"`
+}
+
+// TokenResult - the access token is for the oauth audience with the granted scope, it is accepted
+// by the userinfo only. The id token is issued for the openid scope of the authorization code only
+type TokenResult struct {
+	AccountPublicId uuid.UUID `json:"-"`
+	ClientId        string    `json:"-"`
+	AccessToken     string    `json:"access_token"`
+	TokenType       string    `json:"token_type"`
+	ExpiresIn       int64     `json:"expires_in"`
+	IdToken         string    `json:"id_token,omitempty"`
+	Scope           string    `json:"scope"`
+}
+
+// UserInfo - the claims of the account allowed by the scope of the token
+type UserInfo struct {
+	Subject       string `json:"sub"`
+	Name          string `json:"name,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+	Role          *Role  `json:"role,omitempty"`
+}
+
+// OIDCDiscovery - the provider metadata
+type OIDCDiscovery struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IdTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// JSONWebKeySet - the public keys of the id token signatures
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey - rsa public key
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyId     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// OAuthClientDeleted - the sessions of the client are revoked, by the account
+type OAuthClientDeleted struct {
+	Client   OAuthClient
+	Sessions []SessionsRevokedEvent
+}
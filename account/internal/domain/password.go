@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+)
+
+// PasswordReset - sent reset token, only the token hash is kept
+type PasswordReset struct {
+	TokenHash       string     `json:"-" db:"token_hash"`
+	AccountPublicId uuid.UUID  `json:"account_public_id" db:"account_public_id"`
+	ExpiresAt       time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt          *time.Time `json:"used_at" db:"used_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ForgotPasswordInput
+type ForgotPasswordInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordInput
+type ResetPasswordInput struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+// PasswordResetEvent - Account.PasswordReset payload, tokens issued before reset at are not valid.
+// Sessions are all the account sessions revoked by the reset
+type PasswordResetEvent struct {
+	PublicId uuid.UUID            `json:"public_id"`
+	ResetAt  time.Time            `json:"reset_at"`
+	Sessions SessionsRevokedEvent `json:"-"`
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PRIVACY_SERVICE - the account service part of an export or erasure
+	PRIVACY_SERVICE = "account"
+	// PRIVACY_LIMIT - requests of a page by default
+	PRIVACY_LIMIT = 50
+	// PRIVACY_MAX_LIMIT - requests of a page at most
+	PRIVACY_MAX_LIMIT = 200
+)
+
+var (
+	ErrPrivacyRequestNotFound = errors.New("privacy request not found")
+	ErrInvalidPrivacyFilter   = errors.New("invalid privacy filter")
+)
+
+// PrivacyKind
+type PrivacyKind string
+
+const (
+	PRIVACY_EXPORT  PrivacyKind = "export"
+	PRIVACY_ERASURE PrivacyKind = "erasure"
+)
+
+// Privacy request statuses, completed when every service answered
+const (
+	PRIVACY_PENDING   = "pending"
+	PRIVACY_COMPLETED = "completed"
+)
+
+// PrivacyRequest - data export or erasure of the account, answered by every service in Services
+type PrivacyRequest struct {
+	PublicId        uuid.UUID     `json:"public_id" db:"public_id"`
+	AccountPublicId uuid.UUID     `json:"account_public_id" db:"account_public_id"`
+	Kind            PrivacyKind   `json:"kind" db:"kind"`
+	Status          string        `json:"status" db:"status"`
+	Services        []string      `json:"services" db:"-"`
+	Parts           []PrivacyPart `json:"parts,omitempty" db:"-"`
+	CreatedAt       time.Time     `json:"created_at" db:"created_at"`
+	CompletedAt     *time.Time    `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// PrivacyPart - the answer of a service, data is its export and is empty for the erasure
+type PrivacyPart struct {
+	Service     string          `json:"service" db:"service"`
+	Data        json.RawMessage `json:"data,omitempty" db:"data"`
+	CompletedAt time.Time       `json:"completed_at" db:"completed_at"`
+}
+
+// PrivacyFilter - erasures search by the admin
+type PrivacyFilter struct {
+	Status string `form:"status"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+// PrivacyRequestedEvent - Account.ExportRequested and Account.ErasureRequested payload
+type PrivacyRequestedEvent struct {
+	RequestId uuid.UUID `json:"request_id"`
+	PublicId  uuid.UUID `json:"public_id"`
+}
+
+// PrivacyAnsweredEvent - Privacy.DataExported and Privacy.ErasureCompleted payload of a service
+type PrivacyAnsweredEvent struct {
+	RequestId uuid.UUID       `json:"request_id"`
+	PublicId  uuid.UUID       `json:"public_id"`
+	Service   string          `json:"service"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// AccountData - the account service part of the export
+type AccountData struct {
+	Account  Account      `json:"account"`
+	Sessions []Session    `json:"sessions"`
+	Activity []AuditEntry `json:"activity"`
+}
+
+// AccountErased - the erasure request and the revoked sessions of the erased account
+type AccountErased struct {
+	Request  PrivacyRequest
+	Sessions SessionsRevokedEvent
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// Client - where the request comes from
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// Session - signed-in device or oauth client, the access token id is the session public id.
+// Only the sessions not revoked and not expired are active
+type Session struct {
+	PublicId        uuid.UUID  `json:"public_id" db:"public_id"`
+	AccountPublicId uuid.UUID  `json:"-" db:"account_public_id"`
+	Device          string     `json:"device" db:"device"`
+	UserAgent       string     `json:"user_agent" db:"user_agent"`
+	IP              string     `json:"ip" db:"ip"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	LastSeenAt      time.Time  `json:"last_seen_at" db:"last_seen_at"`
+	ExpiresAt       time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt       *time.Time `json:"-" db:"revoked_at"`
+	ClientId        string     `json:"client_id,omitempty" db:"client_id"` // oauth client, empty for the sign-in
+	Scope           string     `json:"scope,omitempty" db:"scope"`         // oauth scope, empty one allows all
+	Current         bool       `json:"current" db:"-"`
+}
+
+// SessionsRevokedEvent - auth.sessions_revoked payload. The access tokens of the sessions
+// are not valid anymore, they expire by themselves not later than expires at
+type SessionsRevokedEvent struct {
+	PublicId   uuid.UUID   `json:"public_id"`
+	SessionIds []uuid.UUID `json:"session_ids"`
+	RevokedAt  time.Time   `json:"revoked_at"`
+	ExpiresAt  time.Time   `json:"expires_at"`
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RECOVERY_CODES - recovery codes of an account, each works once instead of the totp code
+const RECOVERY_CODES = 10
+
+var (
+	ErrInvalidTwoFactorCode     = errors.New("invalid two-factor code")
+	ErrInvalidChallenge         = errors.New("invalid or expired sign-in challenge")
+	ErrTwoFactorNotEnabled      = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorAlreadyEnabled  = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled     = errors.New("two-factor enrollment is not started")
+	ErrTwoFactorRequiredForRole = errors.New("two-factor authentication is required for the account role")
+)
+
+// TwoFactor - totp secret of the account, it works after the enrollment is confirmed by a code
+type TwoFactor struct {
+	AccountPublicId uuid.UUID  `json:"-" db:"account_public_id"`
+	Secret          string     `json:"-" db:"secret"`
+	Enabled         bool       `json:"enabled" db:"enabled"`
+	LastStep        int64      `json:"-" db:"last_step"` // the last used time step, so a code works once
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	EnabledAt       *time.Time `json:"enabled_at" db:"enabled_at"`
+}
+
+// TwoFactorEnrollment - the uri is shown as a qr code, the secret is for manual input
+type TwoFactorEnrollment struct {
+	AccountPublicId uuid.UUID `json:"-"`
+	Secret          string    `json:"secret"`
+	URI             string    `json:"uri"`
+}
+
+// TwoFactorCodeInput - totp code, or a recovery code where it is allowed
+type TwoFactorCodeInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// RecoveryCodes - shown once, only the hashes are kept
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+// SignInChallengeInput - the second sign-in step, code is totp or recovery one
+type SignInChallengeInput struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// SignInEnrollInput - the enrollment of the account role requiring two-factor
+type SignInEnrollInput struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+}
+
+// Sign-in steps
+const (
+	SIGN_IN_STEP_TWO_FACTOR = "two_factor"
+	SIGN_IN_STEP_ENROLL     = "two_factor_enroll"
+)
+
+// SignInResult - token when the sign-in is done, otherwise the challenge token for the next step.
+// Recovery codes are there after the enrollment while signing in
+type SignInResult struct {
+	AccountPublicId uuid.UUID `json:"-"`
+	Token           string    `json:"token,omitempty"`
+	ChallengeToken  string    `json:"challenge_token,omitempty"`
+	NextStep        string    `json:"next_step,omitempty"`
+	RecoveryCodes   []string  `json:"recovery_codes,omitempty"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	ErrEmailAlreadyVerified     = errors.New("email is already verified")
+	ErrVerificationThrottled    = errors.New("verification email was sent recently, try later")
+)
+
+// EmailVerification - sent verification token, used at is set when the email is verified by it.
+// Email is the account email of the send time, a changed email needs a new token
+type EmailVerification struct {
+	TokenId         uuid.UUID  `json:"token_id" db:"token_id"`
+	AccountPublicId uuid.UUID  `json:"account_public_id" db:"account_public_id"`
+	Email           string     `json:"email" db:"email"`
+	ExpiresAt       time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt          *time.Time `json:"used_at" db:"used_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// VerifyEmailInput
+type VerifyEmailInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// EmailVerifiedEvent - Account.EmailVerified payload
+type EmailVerifiedEvent struct {
+	PublicId   uuid.UUID `json:"public_id"`
+	Email      string    `json:"email"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Mail - sent by the fake mailer
+type Mail struct {
+	From    string
+	To      string
+	Subject string
+	Text    string
+}
+
+// Fake - keeps the mails in memory and logs them, nothing is sent
+type Fake struct {
+	from string
+
+	mu    sync.Mutex
+	mails []Mail
+}
+
+// NewFake - constructor
+func NewFake(from string) *Fake {
+	return &Fake{from: from}
+}
+
+// Send
+func (m *Fake) Send(ctx context.Context, to, subject, text string) error {
+	mail := Mail{From: m.from, To: to, Subject: subject, Text: text}
+	logrus.Printf("📧 fake mail to %s: %s\n%s", to, subject, text)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mails = append(m.mails, mail)
+	return nil
+}
+
+// Mails - sent mails copy
+func (m *Fake) Mails() []Mail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mails := make([]Mail, len(m.mails))
+	copy(mails, m.mails)
+	return mails
+}
@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/p12s/furniture-store/account/internal/config"
+)
+
+// Mail drivers
+const (
+	DRIVER_SMTP = "smtp"
+	DRIVER_FAKE = "fake"
+)
+
+// Mailer - plain text mail to one recipient
+type Mailer interface {
+	Send(ctx context.Context, to, subject, text string) error
+}
+
+var (
+	_ Mailer = (*SMTP)(nil)
+	_ Mailer = (*Fake)(nil)
+)
+
+// New - mailer by the configured driver
+func New(config *config.Mail) (Mailer, error) {
+	switch config.Driver {
+	case DRIVER_SMTP:
+		return NewSMTP(config.Host, config.Port, config.Username, config.Password, config.From), nil
+	case DRIVER_FAKE:
+		return NewFake(config.From), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", config.Driver)
+	}
+}
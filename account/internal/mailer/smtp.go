@@ -0,0 +1,71 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+)
+
+// SMTP - mail through an smtp server
+type SMTP struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTP - constructor, without username the server is used without authentication
+func NewSMTP(host string, port int, username, password, from string) *SMTP {
+	m := &SMTP{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		from: from,
+	}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m
+}
+
+// Send
+func (m *SMTP) Send(ctx context.Context, to, subject, text string) error {
+	data := message(m.from, to, subject, text)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(m.addr, m.auth, address(m.from), []string{to}, data)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("smtp send fail: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// message - utf-8 plain text mail with headers
+func message(from, to, subject, text string) []byte {
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", from)
+	fmt.Fprintf(&message, "To: %s\r\n", to)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	message.WriteString(text)
+	return message.Bytes()
+}
+
+// address - email of the "Name <email>" sender
+func address(from string) string {
+	parsed, err := mail.ParseAddress(from)
+	if err != nil {
+		return from
+	}
+	return parsed.Address
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -18,6 +20,7 @@ type Accounter interface {
 	UpdateAccountRole(input domain.UpdateAccountRoleInput) error
 	DeleteAccount(accountPublicId string) error
 	GetByCredentials(email, password string) (domain.Account, error)
+	GetByEmail(email string) ([]domain.Account, error)
 }
 
 // Account
@@ -82,6 +85,8 @@ func (r *Account) UpdateAccountInfo(input domain.UpdateAccountInput) error {
 	}
 
 	if input.Email != nil {
+		// the set email needs a new verification, billing resets its copy by the same event
+		setValues = append(setValues, "email_verified=FALSE")
 		setValues = append(setValues, fmt.Sprintf("email=$%d", argId))
 		args = append(args, *input.Email)
 		argId++
@@ -99,7 +104,7 @@ func (r *Account) UpdateAccountInfo(input domain.UpdateAccountInput) error {
 		accountTable, setQuery, argId)
 	args = append(args, input.PublicId.String())
 
-	_, err = r.db.Exec(query, args...)
+	_, err = tx.Exec(query, args...)
 	if err != nil {
 		rollbackErr := tx.Rollback()
 		if rollbackErr != nil {
@@ -118,11 +123,23 @@ func (r *Account) UpdateAccountRole(input domain.UpdateAccountRoleInput) error {
 	return err
 }
 
-// DeleteAccount
+// DeleteAccount - with the account addresses
 func (r *Account) DeleteAccount(accountPublicId string) error {
-	query := fmt.Sprintf(`DELETE FROM %s WHERE public_id = $1`, accountTable)
-	_, err := r.db.Exec(query, accountPublicId)
-	return err
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE account_public_id = $1`, addressTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return err
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE public_id = $1`, accountTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // GetByCredentials
@@ -131,9 +148,23 @@ func (r *Account) GetByCredentials(email, password string) (domain.Account, erro
 
 	query := fmt.Sprintf(`SELECT * FROM %s WHERE email=$1 AND password_hash=$2`, accountTable)
 	err := r.db.Get(&account, query, email, password)
+	if errors.Is(err, sql.ErrNoRows) {
+		return account, domain.ErrInvalidCredentials
+	}
 	if err != nil {
 		return account, fmt.Errorf("get account: %w", err)
 	}
 
 	return account, err
 }
+
+// GetByEmail - the email is not unique, all accounts with it
+func (r *Account) GetByEmail(email string) ([]domain.Account, error) {
+	accounts := []domain.Account{}
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE email=$1 COLLATE NOCASE`, accountTable)
+	err := r.db.Select(&accounts, query, email)
+	if err != nil {
+		return accounts, fmt.Errorf("get accounts by email: %w", err)
+	}
+	return accounts, nil
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+var _ Addresser = (*Address)(nil)
+
+// Addresser - repository interface
+type Addresser interface {
+	CountAddresses(accountPublicId uuid.UUID) (int, error)
+	CreateAddress(address domain.Address) error
+	GetAddresses(accountPublicId uuid.UUID) ([]domain.Address, error)
+	GetAddress(accountPublicId, publicId uuid.UUID) (domain.Address, error)
+	UpdateAddress(address domain.Address) error
+	DeleteAddress(accountPublicId, publicId uuid.UUID) (*domain.Address, error)
+}
+
+// Address
+type Address struct {
+	db *sqlx.DB
+}
+
+// NewAddress - constructor
+func NewAddress(db *sqlx.DB) *Address {
+	return &Address{db: db}
+}
+
+// CountAddresses
+func (r *Address) CountAddresses(accountPublicId uuid.UUID) (int, error) {
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE account_public_id=$1`, addressTable)
+	err := r.db.Get(&count, query, accountPublicId)
+	return count, err
+}
+
+// CreateAddress - the default address takes the default from the other account addresses
+func (r *Address) CreateAddress(address domain.Address) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, account_public_id, country, city, street, building,
+		apartment, postal_code, notes, latitude, longitude, is_default, created_at, updated_at)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`, addressTable)
+	_, err = tx.Exec(query, address.PublicId, address.AccountPublicId, address.Country, address.City,
+		address.Street, address.Building, address.Apartment, address.PostalCode, address.Notes,
+		address.Latitude, address.Longitude, address.IsDefault, address.CreatedAt, address.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("create address: %w", err)
+	}
+
+	if address.IsDefault {
+		if err := setDefaultAddress(tx, address); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetAddresses - the default goes first
+func (r *Address) GetAddresses(accountPublicId uuid.UUID) ([]domain.Address, error) {
+	addresses := []domain.Address{}
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE account_public_id=$1 ORDER BY is_default DESC, id`, addressTable)
+	err := r.db.Select(&addresses, query, accountPublicId)
+	if err != nil {
+		return addresses, fmt.Errorf("get addresses: %w", err)
+	}
+	return addresses, nil
+}
+
+// GetAddress - only the account address
+func (r *Address) GetAddress(accountPublicId, publicId uuid.UUID) (domain.Address, error) {
+	return getAddress(r.db, accountPublicId, publicId)
+}
+
+// UpdateAddress - all fields, the default address takes the default from the other account addresses
+func (r *Address) UpdateAddress(address domain.Address) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`UPDATE %s SET country=$1, city=$2, street=$3, building=$4, apartment=$5,
+		postal_code=$6, notes=$7, latitude=$8, longitude=$9, is_default=$10, updated_at=$11
+		WHERE public_id=$12 AND account_public_id=$13`, addressTable)
+	result, err := tx.Exec(query, address.Country, address.City, address.Street, address.Building,
+		address.Apartment, address.PostalCode, address.Notes, address.Latitude, address.Longitude,
+		address.IsDefault, address.UpdatedAt, address.PublicId, address.AccountPublicId)
+	if err != nil {
+		return fmt.Errorf("update address: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrAddressNotFound
+	}
+
+	if address.IsDefault {
+		if err := setDefaultAddress(tx, address); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteAddress - when the default is deleted, the oldest of the rest becomes the default.
+// The new default is returned, nil when the default is not changed or no addresses left
+func (r *Address) DeleteAddress(accountPublicId, publicId uuid.UUID) (*domain.Address, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() // nolint
+
+	address, err := getAddress(tx, accountPublicId, publicId)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, addressTable)
+	if _, err := tx.Exec(query, publicId); err != nil {
+		return nil, fmt.Errorf("delete address: %w", err)
+	}
+	if !address.IsDefault {
+		return nil, tx.Commit()
+	}
+
+	var newDefault *domain.Address
+	var oldest domain.Address
+	query = fmt.Sprintf(`SELECT * FROM %s WHERE account_public_id=$1 ORDER BY id LIMIT 1`, addressTable)
+	err = tx.Get(&oldest, query, accountPublicId)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		query = fmt.Sprintf(`UPDATE %s SET address='' WHERE public_id=$1`, accountTable)
+		if _, err := tx.Exec(query, accountPublicId); err != nil {
+			return nil, fmt.Errorf("clear account address: %w", err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("get address: %w", err)
+	default:
+		oldest.IsDefault = true
+		if err := setDefaultAddress(tx, oldest); err != nil {
+			return nil, err
+		}
+		newDefault = &oldest
+	}
+	return newDefault, tx.Commit()
+}
+
+// getAddress
+func getAddress(db sqlx.Queryer, accountPublicId, publicId uuid.UUID) (domain.Address, error) {
+	var address domain.Address
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE public_id=$1 AND account_public_id=$2`, addressTable)
+	err := sqlx.Get(db, &address, query, publicId, accountPublicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return address, domain.ErrAddressNotFound
+	}
+	if err != nil {
+		return address, fmt.Errorf("get address: %w", err)
+	}
+	return address, nil
+}
+
+// setDefaultAddress - the only default of the account, its line goes to the account address
+func setDefaultAddress(tx *sqlx.Tx, address domain.Address) error {
+	query := fmt.Sprintf(`UPDATE %s SET is_default=(public_id=$1) WHERE account_public_id=$2`, addressTable)
+	if _, err := tx.Exec(query, address.PublicId, address.AccountPublicId); err != nil {
+		return fmt.Errorf("set default address: %w", err)
+	}
+	query = fmt.Sprintf(`UPDATE %s SET address=$1 WHERE public_id=$2`, accountTable)
+	if _, err := tx.Exec(query, address.Line(), address.AccountPublicId); err != nil {
+		return fmt.Errorf("set account address: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestAddress_CreateAddress(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewAddress(db)
+	now := time.Now().UTC()
+
+	type args struct {
+		address domain.Address
+	}
+	type mockBehavior func(args args)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		args         args
+		wantErr      bool
+	}{
+		{
+			name: "Can create the default address and set the account address line",
+			mockBehavior: func(args args) {
+				a := args.address
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO "+addressTable).WithArgs(a.PublicId, a.AccountPublicId,
+					a.Country, a.City, a.Street, a.Building, a.Apartment, a.PostalCode, a.Notes,
+					a.Latitude, a.Longitude, a.IsDefault, a.CreatedAt, a.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE "+addressTable+" SET is_default").WithArgs(a.PublicId, a.AccountPublicId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE "+accountTable+" SET address").WithArgs("Russia, Moscow, Tverskaya, 1", a.AccountPublicId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			args: args{
+				address: domain.Address{
+					PublicId:        uuid.New(),
+					AccountPublicId: uuid.New(),
+					Country:         "Russia",
+					City:            "Moscow",
+					Street:          "Tverskaya",
+					Building:        "1",
+					IsDefault:       true,
+					CreatedAt:       now,
+					UpdatedAt:       now,
+				},
+			},
+		},
+		{
+			name: "Can create not default address without touching the others",
+			mockBehavior: func(args args) {
+				a := args.address
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO "+addressTable).WithArgs(a.PublicId, a.AccountPublicId,
+					a.Country, a.City, a.Street, a.Building, a.Apartment, a.PostalCode, a.Notes,
+					a.Latitude, a.Longitude, a.IsDefault, a.CreatedAt, a.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+			args: args{
+				address: domain.Address{
+					PublicId:        uuid.New(),
+					AccountPublicId: uuid.New(),
+					Country:         "Russia",
+					City:            "Moscow",
+					Street:          "Arbat",
+					Building:        "10",
+					CreatedAt:       now,
+					UpdatedAt:       now,
+				},
+			},
+		},
+		{
+			name: "Can't create address when the insert fails",
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO " + addressTable).WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			args: args{
+				address: domain.Address{IsDefault: true},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior(tt.args)
+
+			err := repo.CreateAddress(tt.args.address)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAddress_GetAddress(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewAddress(db)
+	accountPublicId := uuid.New()
+	publicId := uuid.New()
+
+	t.Run("Can't get address of another account", func(t *testing.T) {
+		mock.ExpectQuery("^SELECT (.+) FROM "+addressTable+" WHERE public_id=").
+			WithArgs(publicId, accountPublicId).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "public_id"}))
+
+		_, err := repo.GetAddress(accountPublicId, publicId)
+		assert.ErrorIs(t, err, domain.ErrAddressNotFound)
+	})
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+var _ APIKeyer = (*APIKey)(nil)
+
+// APIKeyer - repository interface
+type APIKeyer interface {
+	CreateAPIKey(key domain.APIKey) error
+	GetAPIKeys(accountPublicId uuid.UUID) ([]domain.APIKey, error)
+	CountActiveAPIKeys(accountPublicId uuid.UUID, now time.Time) (int, error)
+	RevokeAPIKey(accountPublicId uuid.UUID, prefix string, now time.Time) (domain.APIKey, error)
+	TouchAPIKey(prefix string, usedAt time.Time) error
+}
+
+// APIKey
+type APIKey struct {
+	db *sqlx.DB
+}
+
+// NewAPIKey - constructor
+func NewAPIKey(db *sqlx.DB) *APIKey {
+	return &APIKey{db: db}
+}
+
+const apiKeyColumns = `prefix, key_hash, account_public_id, name, scopes, expires_at, last_used_at,
+	created_at, revoked_at`
+
+// apiKeyRow - the scopes are kept space separated
+type apiKeyRow struct {
+	domain.APIKey
+	Scopes string `db:"scopes"`
+}
+
+// key - with the scopes
+func (r apiKeyRow) key() domain.APIKey {
+	key := r.APIKey
+	key.Scopes = strings.Fields(r.Scopes)
+	return key
+}
+
+// CreateAPIKey
+func (r *APIKey) CreateAPIKey(key domain.APIKey) error {
+	query := fmt.Sprintf(`INSERT INTO %s (prefix, key_hash, account_public_id, name, scopes, expires_at, created_at)
+		values ($1, $2, $3, $4, $5, $6, $7)`, apiKeyTable)
+	_, err := r.db.Exec(query, key.Prefix, key.KeyHash, key.AccountPublicId, key.Name,
+		strings.Join(key.Scopes, " "), key.ExpiresAt, key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create api key: %w", err)
+	}
+	return nil
+}
+
+// GetAPIKeys - not revoked, the expired ones too, newest first
+func (r *APIKey) GetAPIKeys(accountPublicId uuid.UUID) ([]domain.APIKey, error) {
+	var rows []apiKeyRow
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE account_public_id=$1 AND revoked_at IS NULL
+		ORDER BY created_at DESC, id DESC`, apiKeyColumns, apiKeyTable)
+	if err := r.db.Select(&rows, query, accountPublicId); err != nil {
+		return nil, fmt.Errorf("get api keys: %w", err)
+	}
+	keys := make([]domain.APIKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, row.key())
+	}
+	return keys, nil
+}
+
+// CountActiveAPIKeys - not revoked and not expired
+func (r *APIKey) CountActiveAPIKeys(accountPublicId uuid.UUID, now time.Time) (int, error) {
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE account_public_id=$1 AND revoked_at IS NULL
+		AND expires_at > $2`, apiKeyTable)
+	if err := r.db.Get(&count, query, accountPublicId, now); err != nil {
+		return 0, fmt.Errorf("count api keys: %w", err)
+	}
+	return count, nil
+}
+
+// RevokeAPIKey - the key of the account not revoked yet, ErrAPIKeyNotFound otherwise
+func (r *APIKey) RevokeAPIKey(accountPublicId uuid.UUID, prefix string, now time.Time) (domain.APIKey, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+	defer tx.Rollback() // nolint
+
+	var row apiKeyRow
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE prefix=$1 AND account_public_id=$2 AND revoked_at IS NULL`,
+		apiKeyColumns, apiKeyTable)
+	err = tx.Get(&row, query, prefix, accountPublicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.APIKey{}, domain.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return domain.APIKey{}, fmt.Errorf("get api key: %w", err)
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET revoked_at=$1 WHERE prefix=$2`, apiKeyTable)
+	if _, err := tx.Exec(query, now, prefix); err != nil {
+		return domain.APIKey{}, fmt.Errorf("revoke api key: %w", err)
+	}
+
+	key := row.key()
+	key.RevokedAt = &now
+	return key, tx.Commit()
+}
+
+// TouchAPIKey - the usage reports can come out of order, the latest one is kept
+func (r *APIKey) TouchAPIKey(prefix string, usedAt time.Time) error {
+	query := fmt.Sprintf(`UPDATE %s SET last_used_at=$1 WHERE prefix=$2
+		AND (last_used_at IS NULL OR last_used_at < $3)`, apiKeyTable)
+	if _, err := r.db.Exec(query, usedAt, prefix, usedAt); err != nil {
+		return fmt.Errorf("touch api key: %w", err)
+	}
+	return nil
+}
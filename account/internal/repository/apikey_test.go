@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestAPIKey_RevokeAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewAPIKey(db)
+	accountPublicId := uuid.New()
+	now := time.Now().UTC()
+	columns := []string{"prefix", "key_hash", "account_public_id", "name", "scopes", "expires_at",
+		"last_used_at", "created_at", "revoked_at"}
+
+	tests := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Can revoke the own key with its scopes",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM "+apiKeyTable).WithArgs("fsk_0a1b2c3d4e5f", accountPublicId).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("fsk_0a1b2c3d4e5f", "hash", accountPublicId,
+						"ERP sync", "products:write stock:write", now.Add(time.Hour), nil, now, nil))
+				mock.ExpectExec("UPDATE "+apiKeyTable+" SET revoked_at").WithArgs(now, "fsk_0a1b2c3d4e5f").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Can't revoke the key of another account or already revoked",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM "+apiKeyTable).WithArgs("fsk_0a1b2c3d4e5f", accountPublicId).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrAPIKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			key, err := repo.RevokeAPIKey(accountPublicId, "fsk_0a1b2c3d4e5f", now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &now, key.RevokedAt)
+				assert.Equal(t, []string{domain.API_KEY_SCOPE_PRODUCTS_WRITE, domain.API_KEY_SCOPE_STOCK_WRITE}, key.Scopes)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAPIKey_TouchAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewAPIKey(db)
+	usedAt := time.Now().UTC()
+
+	t.Run("Can keep the latest usage only", func(t *testing.T) {
+		mock.ExpectExec("UPDATE "+apiKeyTable+" SET last_used_at").WithArgs(usedAt, "fsk_0a1b2c3d4e5f", usedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.TouchAPIKey("fsk_0a1b2c3d4e5f", usedAt))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
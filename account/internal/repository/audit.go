@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+var _ Auditor = (*Audit)(nil)
+
+// Auditor - repository interface
+type Auditor interface {
+	CreateAuditEntry(entry domain.AuditEntry) error
+	GetAuditEntries(filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+// Audit
+type Audit struct {
+	db *sqlx.DB
+}
+
+// NewAudit - constructor
+func NewAudit(db *sqlx.DB) *Audit {
+	return &Audit{db: db}
+}
+
+// CreateAuditEntry
+func (r *Audit) CreateAuditEntry(entry domain.AuditEntry) error {
+	query := fmt.Sprintf(`INSERT INTO %s (actor_public_id, target_public_id, email, action, outcome, details,
+		ip, user_agent, created_at) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)`, auditTable)
+	_, err := r.db.Exec(query, entry.ActorPublicId, entry.TargetPublicId, entry.Email, entry.Action,
+		entry.Outcome, entry.Details, entry.IP, entry.UserAgent, entry.CreatedAt)
+	return err
+}
+
+// GetAuditEntries - the filter uuids are checked by the caller
+func (r *Audit) GetAuditEntries(filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	whereValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if filter.Account != "" {
+		whereValues = append(whereValues, fmt.Sprintf("(actor_public_id=$%d OR target_public_id=$%d)", argId, argId))
+		args = append(args, filter.Account)
+		argId++
+	}
+	for _, field := range []struct {
+		column, value string
+	}{
+		{"actor_public_id", filter.Actor},
+		{"target_public_id", filter.Target},
+		{"email", filter.Email},
+		{"action", filter.Action},
+		{"outcome", filter.Outcome},
+		{"ip", filter.IP},
+	} {
+		if field.value == "" {
+			continue
+		}
+		whereValues = append(whereValues, fmt.Sprintf("%s=$%d", field.column, argId))
+		args = append(args, field.value)
+		argId++
+	}
+	if filter.From != nil {
+		whereValues = append(whereValues, fmt.Sprintf("created_at >= $%d", argId))
+		args = append(args, *filter.From)
+		argId++
+	}
+	if filter.To != nil {
+		whereValues = append(whereValues, fmt.Sprintf("created_at < $%d", argId))
+		args = append(args, *filter.To)
+		argId++
+	}
+
+	whereQuery := ""
+	if len(whereValues) > 0 {
+		whereQuery = "WHERE " + strings.Join(whereValues, " AND ")
+	}
+	query := fmt.Sprintf(`SELECT * FROM %s %s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d`,
+		auditTable, whereQuery, argId, argId+1)
+	args = append(args, filter.Limit, filter.Offset)
+
+	entries := []domain.AuditEntry{}
+	if err := r.db.Select(&entries, query, args...); err != nil {
+		return entries, fmt.Errorf("get audit entries: %w", err)
+	}
+	return entries, nil
+}
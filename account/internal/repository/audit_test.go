@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestAudit_GetAuditEntries(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewAudit(db)
+	from := time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+
+	tests := []struct {
+		name         string
+		filter       domain.AuditFilter
+		mockBehavior func()
+		wantErr      bool
+	}{
+		{
+			name:   "Can get the entries of the account as the actor or the target",
+			filter: domain.AuditFilter{Account: accountPublicId, Limit: 50},
+			mockBehavior: func() {
+				mock.ExpectQuery(`^SELECT (.+) FROM `+auditTable+` WHERE \(actor_public_id=\$1 OR target_public_id=\$1\) ORDER BY`).
+					WithArgs(accountPublicId, 50, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "action"}).AddRow(1, "sign_in"))
+			},
+		},
+		{
+			name:   "Can get the entries by all the filters",
+			filter: domain.AuditFilter{Action: "sign_in", Outcome: "failure", IP: "192.0.2.1", From: &from, Limit: 10, Offset: 20},
+			mockBehavior: func() {
+				mock.ExpectQuery(`^SELECT (.+) FROM `+auditTable+
+					` WHERE action=\$1 AND outcome=\$2 AND ip=\$3 AND created_at >= \$4 ORDER BY (.+) LIMIT \$5 OFFSET \$6`).
+					WithArgs("sign_in", "failure", "192.0.2.1", from, 10, 20).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "action"}))
+			},
+		},
+		{
+			name:   "Can return the error of the query",
+			filter: domain.AuditFilter{Limit: 50},
+			mockBehavior: func() {
+				mock.ExpectQuery(`^SELECT (.+) FROM ` + auditTable + ` ORDER BY`).WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			_, err := repo.GetAuditEntries(tt.filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+// Login attempts drivers
+const (
+	LOGIN_ATTEMPTS_SQLITE = "sqlite"
+	LOGIN_ATTEMPTS_MEMORY = "memory"
+)
+
+var (
+	_ LoginAttempter = (*LoginAttempts)(nil)
+	_ LoginAttempter = (*MemoryLoginAttempts)(nil)
+)
+
+// LoginAttempter - repository interface, failed sign-in counters
+type LoginAttempter interface {
+	GetLoginAttempts(key string) (domain.LoginAttempts, error)
+	AddLoginFailure(key string, now time.Time, window time.Duration) (domain.LoginAttempts, error)
+	LockLogin(key string, until time.Time) error
+	ResetLoginAttempts(key string) error
+}
+
+// NewLoginAttempter - counters by the configured driver
+func NewLoginAttempter(driver string, db *sqlx.DB) (LoginAttempter, error) {
+	switch driver {
+	case LOGIN_ATTEMPTS_SQLITE:
+		createLoginAttemptTable(db)
+		return NewLoginAttempts(db), nil
+	case LOGIN_ATTEMPTS_MEMORY:
+		return NewMemoryLoginAttempts(), nil
+	}
+	return nil, fmt.Errorf("unknown login attempts driver: %s", driver)
+}
+
+// LoginAttempts - sqlite counters
+type LoginAttempts struct {
+	db *sqlx.DB
+}
+
+// NewLoginAttempts - constructor
+func NewLoginAttempts(db *sqlx.DB) *LoginAttempts {
+	return &LoginAttempts{db: db}
+}
+
+// GetLoginAttempts - no failures for the unknown key
+func (r *LoginAttempts) GetLoginAttempts(key string) (domain.LoginAttempts, error) {
+	return getLoginAttempts(r.db, key)
+}
+
+// AddLoginFailure - the failures older than the window are forgotten, the lock is kept
+func (r *LoginAttempts) AddLoginFailure(key string, now time.Time, window time.Duration) (domain.LoginAttempts, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return domain.LoginAttempts{}, err
+	}
+	defer tx.Rollback() // nolint
+
+	attempts, err := getLoginAttempts(tx, key)
+	if err != nil {
+		return attempts, err
+	}
+	attempts = addLoginFailure(attempts, now, window)
+
+	query := fmt.Sprintf(`INSERT INTO %s (attempt_key, failures, last_failure_at) values ($1, $2, $3)
+		ON CONFLICT (attempt_key) DO UPDATE SET failures=excluded.failures, last_failure_at=excluded.last_failure_at`,
+		loginAttemptTable)
+	if _, err := tx.Exec(query, key, attempts.Failures, attempts.LastFailureAt); err != nil {
+		return attempts, fmt.Errorf("add login failure: %w", err)
+	}
+
+	return attempts, tx.Commit()
+}
+
+// LockLogin
+func (r *LoginAttempts) LockLogin(key string, until time.Time) error {
+	query := fmt.Sprintf(`UPDATE %s SET locked_until=$1 WHERE attempt_key=$2`, loginAttemptTable)
+	_, err := r.db.Exec(query, until, key)
+	return err
+}
+
+// ResetLoginAttempts - after the successful sign-in
+func (r *LoginAttempts) ResetLoginAttempts(key string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE attempt_key=$1`, loginAttemptTable)
+	_, err := r.db.Exec(query, key)
+	return err
+}
+
+// getLoginAttempts - by the db or the transaction
+func getLoginAttempts(q sqlx.Queryer, key string) (domain.LoginAttempts, error) {
+	attempts := domain.LoginAttempts{Key: key}
+	query := fmt.Sprintf(`SELECT attempt_key, failures, last_failure_at, locked_until FROM %s WHERE attempt_key=$1`,
+		loginAttemptTable)
+	err := sqlx.Get(q, &attempts, query, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.LoginAttempts{Key: key}, nil
+	}
+	if err != nil {
+		return attempts, fmt.Errorf("get login attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// addLoginFailure - the same counting for all drivers
+func addLoginFailure(attempts domain.LoginAttempts, now time.Time, window time.Duration) domain.LoginAttempts {
+	if attempts.Failures > 0 && now.Sub(attempts.LastFailureAt) > window {
+		attempts.Failures = 0
+	}
+	attempts.Failures++
+	attempts.LastFailureAt = now
+	return attempts
+}
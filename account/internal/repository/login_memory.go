@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+// PRUNE_EVERY - failures between removals of the forgotten counters, so the map doesn't grow under attack
+const PRUNE_EVERY = 1000
+
+// MemoryLoginAttempts - in-memory counters, for a single instance
+type MemoryLoginAttempts struct {
+	mu       sync.Mutex
+	attempts map[string]domain.LoginAttempts
+	added    int
+}
+
+// NewMemoryLoginAttempts - constructor
+func NewMemoryLoginAttempts() *MemoryLoginAttempts {
+	return &MemoryLoginAttempts{attempts: make(map[string]domain.LoginAttempts)}
+}
+
+// GetLoginAttempts - no failures for the unknown key
+func (r *MemoryLoginAttempts) GetLoginAttempts(key string) (domain.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts, ok := r.attempts[key]
+	if !ok {
+		return domain.LoginAttempts{Key: key}, nil
+	}
+	return attempts, nil
+}
+
+// AddLoginFailure - the failures older than the window are forgotten, the lock is kept
+func (r *MemoryLoginAttempts) AddLoginFailure(key string, now time.Time,
+	window time.Duration) (domain.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.added++
+	if r.added%PRUNE_EVERY == 0 {
+		r.prune(now, window)
+	}
+
+	attempts, ok := r.attempts[key]
+	if !ok {
+		attempts = domain.LoginAttempts{Key: key}
+	}
+	attempts = addLoginFailure(attempts, now, window)
+	r.attempts[key] = attempts
+	return attempts, nil
+}
+
+// LockLogin
+func (r *MemoryLoginAttempts) LockLogin(key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attempts, ok := r.attempts[key]; ok {
+		attempts.LockedUntil = &until
+		r.attempts[key] = attempts
+	}
+	return nil
+}
+
+// ResetLoginAttempts - after the successful sign-in
+func (r *MemoryLoginAttempts) ResetLoginAttempts(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+// prune - forgotten and not locked counters, under the lock
+func (r *MemoryLoginAttempts) prune(now time.Time, window time.Duration) {
+	for key, attempts := range r.attempts {
+		if now.Sub(attempts.LastFailureAt) > window && !attempts.IsLocked(now) {
+			delete(r.attempts, key)
+		}
+	}
+}
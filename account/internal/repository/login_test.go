@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestLoginAttempts_AddLoginFailure(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewLoginAttempts(db)
+	now := time.Now().UTC()
+	columns := []string{"attempt_key", "failures", "last_failure_at", "locked_until"}
+
+	tests := []struct {
+		name         string
+		mockBehavior func()
+		wantFailures int
+	}{
+		{
+			name: "Can count the first failure",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + loginAttemptTable).WithArgs("ip:192.0.2.1").
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectExec("INSERT INTO "+loginAttemptTable).WithArgs("ip:192.0.2.1", 1, now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantFailures: 1,
+		},
+		{
+			name: "Can count the next failure in the window",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + loginAttemptTable).WithArgs("ip:192.0.2.1").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("ip:192.0.2.1", 4, now.Add(-time.Minute), nil))
+				mock.ExpectExec("INSERT INTO "+loginAttemptTable).WithArgs("ip:192.0.2.1", 5, now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantFailures: 5,
+		},
+		{
+			name: "Can forget the failures older than the window",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + loginAttemptTable).WithArgs("ip:192.0.2.1").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("ip:192.0.2.1", 4, now.Add(-2*time.Hour), nil))
+				mock.ExpectExec("INSERT INTO "+loginAttemptTable).WithArgs("ip:192.0.2.1", 1, now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantFailures: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			attempts, err := repo.AddLoginFailure("ip:192.0.2.1", now, time.Hour)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFailures, attempts.Failures)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMemoryLoginAttempts(t *testing.T) {
+	repo := NewMemoryLoginAttempts()
+	now := time.Now().UTC()
+
+	t.Run("Can count, lock and reset the failures", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := repo.AddLoginFailure("email:test@test.ru", now, time.Hour)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, repo.LockLogin("email:test@test.ru", now.Add(time.Minute)))
+
+		attempts, err := repo.GetLoginAttempts("email:test@test.ru")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts.Failures)
+		assert.True(t, attempts.IsLocked(now))
+		assert.False(t, attempts.IsLocked(now.Add(time.Minute)))
+
+		assert.NoError(t, repo.ResetLoginAttempts("email:test@test.ru"))
+		attempts, err = repo.GetLoginAttempts("email:test@test.ru")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, attempts.Failures)
+	})
+
+	t.Run("Can forget the failures older than the window", func(t *testing.T) {
+		_, err := repo.AddLoginFailure("ip:192.0.2.1", now.Add(-2*time.Hour), time.Hour)
+		assert.NoError(t, err)
+
+		attempts, err := repo.AddLoginFailure("ip:192.0.2.1", now, time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, attempts.Failures)
+	})
+}
@@ -1,13 +1,15 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/p12s/furniture-store/account/internal/repository (interfaces: Accounter)
+// Source: github.com/p12s/furniture-store/account/internal/repository (interfaces: Accounter,Addresser,Verifier,Resetter,TwoFactorer,LoginAttempter,Auditor,Sessioner,Privacier,OAuther,APIKeyer)
 
 // Package repository is a generated GoMock package.
 package repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	domain "github.com/p12s/furniture-store/account/internal/domain"
 )
 
@@ -92,6 +94,21 @@ func (mr *MockAccounterMockRecorder) GetByCredentials(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCredentials", reflect.TypeOf((*MockAccounter)(nil).GetByCredentials), arg0, arg1)
 }
 
+// GetByEmail mocks base method.
+func (m *MockAccounter) GetByEmail(arg0 string) ([]domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", arg0)
+	ret0, _ := ret[0].([]domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockAccounterMockRecorder) GetByEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockAccounter)(nil).GetByEmail), arg0)
+}
+
 // UpdateAccountInfo mocks base method.
 func (m *MockAccounter) UpdateAccountInfo(arg0 domain.UpdateAccountInput) error {
 	m.ctrl.T.Helper()
@@ -119,3 +136,919 @@ func (mr *MockAccounterMockRecorder) UpdateAccountRole(arg0 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountRole", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountRole), arg0)
 }
+
+// MockAddresser is a mock of Addresser interface.
+type MockAddresser struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddresserMockRecorder
+}
+
+// MockAddresserMockRecorder is the mock recorder for MockAddresser.
+type MockAddresserMockRecorder struct {
+	mock *MockAddresser
+}
+
+// NewMockAddresser creates a new mock instance.
+func NewMockAddresser(ctrl *gomock.Controller) *MockAddresser {
+	mock := &MockAddresser{ctrl: ctrl}
+	mock.recorder = &MockAddresserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddresser) EXPECT() *MockAddresserMockRecorder {
+	return m.recorder
+}
+
+// CountAddresses mocks base method.
+func (m *MockAddresser) CountAddresses(arg0 uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAddresses", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAddresses indicates an expected call of CountAddresses.
+func (mr *MockAddresserMockRecorder) CountAddresses(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAddresses", reflect.TypeOf((*MockAddresser)(nil).CountAddresses), arg0)
+}
+
+// CreateAddress mocks base method.
+func (m *MockAddresser) CreateAddress(arg0 domain.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAddress", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAddress indicates an expected call of CreateAddress.
+func (mr *MockAddresserMockRecorder) CreateAddress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAddress", reflect.TypeOf((*MockAddresser)(nil).CreateAddress), arg0)
+}
+
+// DeleteAddress mocks base method.
+func (m *MockAddresser) DeleteAddress(arg0, arg1 uuid.UUID) (*domain.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockAddresserMockRecorder) DeleteAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockAddresser)(nil).DeleteAddress), arg0, arg1)
+}
+
+// GetAddress mocks base method.
+func (m *MockAddresser) GetAddress(arg0, arg1 uuid.UUID) (domain.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddress", arg0, arg1)
+	ret0, _ := ret[0].(domain.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddress indicates an expected call of GetAddress.
+func (mr *MockAddresserMockRecorder) GetAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddress", reflect.TypeOf((*MockAddresser)(nil).GetAddress), arg0, arg1)
+}
+
+// GetAddresses mocks base method.
+func (m *MockAddresser) GetAddresses(arg0 uuid.UUID) ([]domain.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddresses", arg0)
+	ret0, _ := ret[0].([]domain.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddresses indicates an expected call of GetAddresses.
+func (mr *MockAddresserMockRecorder) GetAddresses(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddresses", reflect.TypeOf((*MockAddresser)(nil).GetAddresses), arg0)
+}
+
+// UpdateAddress mocks base method.
+func (m *MockAddresser) UpdateAddress(arg0 domain.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAddress", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAddress indicates an expected call of UpdateAddress.
+func (mr *MockAddresserMockRecorder) UpdateAddress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAddress", reflect.TypeOf((*MockAddresser)(nil).UpdateAddress), arg0)
+}
+
+// MockVerifier is a mock of Verifier interface.
+type MockVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerifierMockRecorder
+}
+
+// MockVerifierMockRecorder is the mock recorder for MockVerifier.
+type MockVerifierMockRecorder struct {
+	mock *MockVerifier
+}
+
+// NewMockVerifier creates a new mock instance.
+func NewMockVerifier(ctrl *gomock.Controller) *MockVerifier {
+	mock := &MockVerifier{ctrl: ctrl}
+	mock.recorder = &MockVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerifier) EXPECT() *MockVerifierMockRecorder {
+	return m.recorder
+}
+
+// CountVerifications mocks base method.
+func (m *MockVerifier) CountVerifications(arg0 uuid.UUID, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountVerifications", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountVerifications indicates an expected call of CountVerifications.
+func (mr *MockVerifierMockRecorder) CountVerifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountVerifications", reflect.TypeOf((*MockVerifier)(nil).CountVerifications), arg0, arg1)
+}
+
+// CreateVerification mocks base method.
+func (m *MockVerifier) CreateVerification(arg0 domain.EmailVerification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVerification", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVerification indicates an expected call of CreateVerification.
+func (mr *MockVerifierMockRecorder) CreateVerification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerification", reflect.TypeOf((*MockVerifier)(nil).CreateVerification), arg0)
+}
+
+// UseVerification mocks base method.
+func (m *MockVerifier) UseVerification(arg0 uuid.UUID, arg1 time.Time) (domain.EmailVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseVerification", arg0, arg1)
+	ret0, _ := ret[0].(domain.EmailVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseVerification indicates an expected call of UseVerification.
+func (mr *MockVerifierMockRecorder) UseVerification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseVerification", reflect.TypeOf((*MockVerifier)(nil).UseVerification), arg0, arg1)
+}
+
+// MockResetter is a mock of Resetter interface.
+type MockResetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockResetterMockRecorder
+}
+
+// MockResetterMockRecorder is the mock recorder for MockResetter.
+type MockResetterMockRecorder struct {
+	mock *MockResetter
+}
+
+// NewMockResetter creates a new mock instance.
+func NewMockResetter(ctrl *gomock.Controller) *MockResetter {
+	mock := &MockResetter{ctrl: ctrl}
+	mock.recorder = &MockResetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResetter) EXPECT() *MockResetterMockRecorder {
+	return m.recorder
+}
+
+// CountPasswordResets mocks base method.
+func (m *MockResetter) CountPasswordResets(arg0 uuid.UUID, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPasswordResets", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPasswordResets indicates an expected call of CountPasswordResets.
+func (mr *MockResetterMockRecorder) CountPasswordResets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPasswordResets", reflect.TypeOf((*MockResetter)(nil).CountPasswordResets), arg0, arg1)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockResetter) CreatePasswordReset(arg0 domain.PasswordReset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockResetterMockRecorder) CreatePasswordReset(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockResetter)(nil).CreatePasswordReset), arg0)
+}
+
+// ResetPassword mocks base method.
+func (m *MockResetter) ResetPassword(arg0, arg1 string, arg2 time.Time) (uuid.UUID, []domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].([]domain.Session)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockResetterMockRecorder) ResetPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockResetter)(nil).ResetPassword), arg0, arg1, arg2)
+}
+
+// MockTwoFactorer is a mock of TwoFactorer interface.
+type MockTwoFactorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorerMockRecorder
+}
+
+// MockTwoFactorerMockRecorder is the mock recorder for MockTwoFactorer.
+type MockTwoFactorerMockRecorder struct {
+	mock *MockTwoFactorer
+}
+
+// NewMockTwoFactorer creates a new mock instance.
+func NewMockTwoFactorer(ctrl *gomock.Controller) *MockTwoFactorer {
+	mock := &MockTwoFactorer{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorer) EXPECT() *MockTwoFactorerMockRecorder {
+	return m.recorder
+}
+
+// DisableTwoFactor mocks base method.
+func (m *MockTwoFactorer) DisableTwoFactor(arg0 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockTwoFactorerMockRecorder) DisableTwoFactor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockTwoFactorer)(nil).DisableTwoFactor), arg0)
+}
+
+// EnableTwoFactor mocks base method.
+func (m *MockTwoFactorer) EnableTwoFactor(arg0 uuid.UUID, arg1 int64, arg2 []string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTwoFactor", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTwoFactor indicates an expected call of EnableTwoFactor.
+func (mr *MockTwoFactorerMockRecorder) EnableTwoFactor(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactor", reflect.TypeOf((*MockTwoFactorer)(nil).EnableTwoFactor), arg0, arg1, arg2, arg3)
+}
+
+// GetTwoFactor mocks base method.
+func (m *MockTwoFactorer) GetTwoFactor(arg0 uuid.UUID) (domain.TwoFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwoFactor", arg0)
+	ret0, _ := ret[0].(domain.TwoFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactor indicates an expected call of GetTwoFactor.
+func (mr *MockTwoFactorerMockRecorder) GetTwoFactor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactor", reflect.TypeOf((*MockTwoFactorer)(nil).GetTwoFactor), arg0)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockTwoFactorer) ReplaceRecoveryCodes(arg0 uuid.UUID, arg1 []string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockTwoFactorerMockRecorder) ReplaceRecoveryCodes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockTwoFactorer)(nil).ReplaceRecoveryCodes), arg0, arg1, arg2)
+}
+
+// StartTwoFactor mocks base method.
+func (m *MockTwoFactorer) StartTwoFactor(arg0 uuid.UUID, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTwoFactor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartTwoFactor indicates an expected call of StartTwoFactor.
+func (mr *MockTwoFactorerMockRecorder) StartTwoFactor(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTwoFactor", reflect.TypeOf((*MockTwoFactorer)(nil).StartTwoFactor), arg0, arg1, arg2)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTwoFactorer) UseRecoveryCode(arg0 uuid.UUID, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTwoFactorerMockRecorder) UseRecoveryCode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTwoFactorer)(nil).UseRecoveryCode), arg0, arg1, arg2)
+}
+
+// UseTwoFactorStep mocks base method.
+func (m *MockTwoFactorer) UseTwoFactorStep(arg0 uuid.UUID, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTwoFactorStep", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTwoFactorStep indicates an expected call of UseTwoFactorStep.
+func (mr *MockTwoFactorerMockRecorder) UseTwoFactorStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTwoFactorStep", reflect.TypeOf((*MockTwoFactorer)(nil).UseTwoFactorStep), arg0, arg1)
+}
+
+// MockLoginAttempter is a mock of LoginAttempter interface.
+type MockLoginAttempter struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttempterMockRecorder
+}
+
+// MockLoginAttempterMockRecorder is the mock recorder for MockLoginAttempter.
+type MockLoginAttempterMockRecorder struct {
+	mock *MockLoginAttempter
+}
+
+// NewMockLoginAttempter creates a new mock instance.
+func NewMockLoginAttempter(ctrl *gomock.Controller) *MockLoginAttempter {
+	mock := &MockLoginAttempter{ctrl: ctrl}
+	mock.recorder = &MockLoginAttempterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttempter) EXPECT() *MockLoginAttempterMockRecorder {
+	return m.recorder
+}
+
+// AddLoginFailure mocks base method.
+func (m *MockLoginAttempter) AddLoginFailure(arg0 string, arg1 time.Time, arg2 time.Duration) (domain.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLoginFailure", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLoginFailure indicates an expected call of AddLoginFailure.
+func (mr *MockLoginAttempterMockRecorder) AddLoginFailure(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLoginFailure", reflect.TypeOf((*MockLoginAttempter)(nil).AddLoginFailure), arg0, arg1, arg2)
+}
+
+// GetLoginAttempts mocks base method.
+func (m *MockLoginAttempter) GetLoginAttempts(arg0 string) (domain.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginAttempts", arg0)
+	ret0, _ := ret[0].(domain.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginAttempts indicates an expected call of GetLoginAttempts.
+func (mr *MockLoginAttempterMockRecorder) GetLoginAttempts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAttempts", reflect.TypeOf((*MockLoginAttempter)(nil).GetLoginAttempts), arg0)
+}
+
+// LockLogin mocks base method.
+func (m *MockLoginAttempter) LockLogin(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLogin", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockLoginAttempterMockRecorder) LockLogin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockLoginAttempter)(nil).LockLogin), arg0, arg1)
+}
+
+// ResetLoginAttempts mocks base method.
+func (m *MockLoginAttempter) ResetLoginAttempts(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLoginAttempts", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetLoginAttempts indicates an expected call of ResetLoginAttempts.
+func (mr *MockLoginAttempterMockRecorder) ResetLoginAttempts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginAttempts", reflect.TypeOf((*MockLoginAttempter)(nil).ResetLoginAttempts), arg0)
+}
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// CreateAuditEntry mocks base method.
+func (m *MockAuditor) CreateAuditEntry(arg0 domain.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEntry", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditEntry indicates an expected call of CreateAuditEntry.
+func (mr *MockAuditorMockRecorder) CreateAuditEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEntry", reflect.TypeOf((*MockAuditor)(nil).CreateAuditEntry), arg0)
+}
+
+// GetAuditEntries mocks base method.
+func (m *MockAuditor) GetAuditEntries(arg0 domain.AuditFilter) ([]domain.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEntries", arg0)
+	ret0, _ := ret[0].([]domain.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEntries indicates an expected call of GetAuditEntries.
+func (mr *MockAuditorMockRecorder) GetAuditEntries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEntries", reflect.TypeOf((*MockAuditor)(nil).GetAuditEntries), arg0)
+}
+
+// MockSessioner is a mock of Sessioner interface.
+type MockSessioner struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionerMockRecorder
+}
+
+// MockSessionerMockRecorder is the mock recorder for MockSessioner.
+type MockSessionerMockRecorder struct {
+	mock *MockSessioner
+}
+
+// NewMockSessioner creates a new mock instance.
+func NewMockSessioner(ctrl *gomock.Controller) *MockSessioner {
+	mock := &MockSessioner{ctrl: ctrl}
+	mock.recorder = &MockSessionerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessioner) EXPECT() *MockSessionerMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockSessioner) CreateSession(arg0 domain.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockSessionerMockRecorder) CreateSession(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessioner)(nil).CreateSession), arg0)
+}
+
+// GetSession mocks base method.
+func (m *MockSessioner) GetSession(arg0 uuid.UUID) (domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0)
+	ret0, _ := ret[0].(domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockSessionerMockRecorder) GetSession(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockSessioner)(nil).GetSession), arg0)
+}
+
+// GetSessions mocks base method.
+func (m *MockSessioner) GetSessions(arg0 uuid.UUID, arg1 time.Time) ([]domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessions", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockSessionerMockRecorder) GetSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockSessioner)(nil).GetSessions), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessioner) RevokeSession(arg0, arg1 uuid.UUID, arg2 time.Time) (domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionerMockRecorder) RevokeSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessioner)(nil).RevokeSession), arg0, arg1, arg2)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockSessioner) RevokeSessions(arg0, arg1 uuid.UUID, arg2 time.Time) ([]domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockSessionerMockRecorder) RevokeSessions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockSessioner)(nil).RevokeSessions), arg0, arg1, arg2)
+}
+
+// TouchSession mocks base method.
+func (m *MockSessioner) TouchSession(arg0 uuid.UUID, arg1, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSession indicates an expected call of TouchSession.
+func (mr *MockSessionerMockRecorder) TouchSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockSessioner)(nil).TouchSession), arg0, arg1, arg2)
+}
+
+// MockPrivacier is a mock of Privacier interface.
+type MockPrivacier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacierMockRecorder
+}
+
+// MockPrivacierMockRecorder is the mock recorder for MockPrivacier.
+type MockPrivacierMockRecorder struct {
+	mock *MockPrivacier
+}
+
+// NewMockPrivacier creates a new mock instance.
+func NewMockPrivacier(ctrl *gomock.Controller) *MockPrivacier {
+	mock := &MockPrivacier{ctrl: ctrl}
+	mock.recorder = &MockPrivacierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacier) EXPECT() *MockPrivacierMockRecorder {
+	return m.recorder
+}
+
+// AddPrivacyPart mocks base method.
+func (m *MockPrivacier) AddPrivacyPart(arg0 uuid.UUID, arg1 domain.PrivacyPart) (domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPrivacyPart", arg0, arg1)
+	ret0, _ := ret[0].(domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPrivacyPart indicates an expected call of AddPrivacyPart.
+func (mr *MockPrivacierMockRecorder) AddPrivacyPart(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPrivacyPart", reflect.TypeOf((*MockPrivacier)(nil).AddPrivacyPart), arg0, arg1)
+}
+
+// CreatePrivacyRequest mocks base method.
+func (m *MockPrivacier) CreatePrivacyRequest(arg0 domain.PrivacyRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePrivacyRequest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePrivacyRequest indicates an expected call of CreatePrivacyRequest.
+func (mr *MockPrivacierMockRecorder) CreatePrivacyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePrivacyRequest", reflect.TypeOf((*MockPrivacier)(nil).CreatePrivacyRequest), arg0)
+}
+
+// EraseAccount mocks base method.
+func (m *MockPrivacier) EraseAccount(arg0 domain.PrivacyRequest, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseAccount indicates an expected call of EraseAccount.
+func (mr *MockPrivacierMockRecorder) EraseAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseAccount", reflect.TypeOf((*MockPrivacier)(nil).EraseAccount), arg0, arg1)
+}
+
+// GetPrivacyRequest mocks base method.
+func (m *MockPrivacier) GetPrivacyRequest(arg0 uuid.UUID) (domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivacyRequest", arg0)
+	ret0, _ := ret[0].(domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivacyRequest indicates an expected call of GetPrivacyRequest.
+func (mr *MockPrivacierMockRecorder) GetPrivacyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivacyRequest", reflect.TypeOf((*MockPrivacier)(nil).GetPrivacyRequest), arg0)
+}
+
+// GetPrivacyRequests mocks base method.
+func (m *MockPrivacier) GetPrivacyRequests(arg0 domain.PrivacyKind, arg1 domain.PrivacyFilter) ([]domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivacyRequests", arg0, arg1)
+	ret0, _ := ret[0].([]domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivacyRequests indicates an expected call of GetPrivacyRequests.
+func (mr *MockPrivacierMockRecorder) GetPrivacyRequests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivacyRequests", reflect.TypeOf((*MockPrivacier)(nil).GetPrivacyRequests), arg0, arg1)
+}
+
+// MockOAuther is a mock of OAuther interface.
+type MockOAuther struct {
+	ctrl     *gomock.Controller
+	recorder *MockOAutherMockRecorder
+}
+
+// MockOAutherMockRecorder is the mock recorder for MockOAuther.
+type MockOAutherMockRecorder struct {
+	mock *MockOAuther
+}
+
+// NewMockOAuther creates a new mock instance.
+func NewMockOAuther(ctrl *gomock.Controller) *MockOAuther {
+	mock := &MockOAuther{ctrl: ctrl}
+	mock.recorder = &MockOAutherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOAuther) EXPECT() *MockOAutherMockRecorder {
+	return m.recorder
+}
+
+// CreateAuthorizationCode mocks base method.
+func (m *MockOAuther) CreateAuthorizationCode(arg0 domain.AuthorizationCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthorizationCode", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuthorizationCode indicates an expected call of CreateAuthorizationCode.
+func (mr *MockOAutherMockRecorder) CreateAuthorizationCode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthorizationCode", reflect.TypeOf((*MockOAuther)(nil).CreateAuthorizationCode), arg0)
+}
+
+// CreateOAuthClient mocks base method.
+func (m *MockOAuther) CreateOAuthClient(arg0 domain.OAuthClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthClient", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOAuthClient indicates an expected call of CreateOAuthClient.
+func (mr *MockOAutherMockRecorder) CreateOAuthClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthClient", reflect.TypeOf((*MockOAuther)(nil).CreateOAuthClient), arg0)
+}
+
+// DeleteOAuthClient mocks base method.
+func (m *MockOAuther) DeleteOAuthClient(arg0 string, arg1 time.Time) (domain.OAuthClient, []domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuthClient", arg0, arg1)
+	ret0, _ := ret[0].(domain.OAuthClient)
+	ret1, _ := ret[1].([]domain.Session)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteOAuthClient indicates an expected call of DeleteOAuthClient.
+func (mr *MockOAutherMockRecorder) DeleteOAuthClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuthClient", reflect.TypeOf((*MockOAuther)(nil).DeleteOAuthClient), arg0, arg1)
+}
+
+// GetOAuthClient mocks base method.
+func (m *MockOAuther) GetOAuthClient(arg0 string) (domain.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthClient", arg0)
+	ret0, _ := ret[0].(domain.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthClient indicates an expected call of GetOAuthClient.
+func (mr *MockOAutherMockRecorder) GetOAuthClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthClient", reflect.TypeOf((*MockOAuther)(nil).GetOAuthClient), arg0)
+}
+
+// GetOAuthClients mocks base method.
+func (m *MockOAuther) GetOAuthClients() ([]domain.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthClients")
+	ret0, _ := ret[0].([]domain.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthClients indicates an expected call of GetOAuthClients.
+func (mr *MockOAutherMockRecorder) GetOAuthClients() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthClients", reflect.TypeOf((*MockOAuther)(nil).GetOAuthClients))
+}
+
+// UseAuthorizationCode mocks base method.
+func (m *MockOAuther) UseAuthorizationCode(arg0 string, arg1 time.Time) (domain.AuthorizationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseAuthorizationCode", arg0, arg1)
+	ret0, _ := ret[0].(domain.AuthorizationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseAuthorizationCode indicates an expected call of UseAuthorizationCode.
+func (mr *MockOAutherMockRecorder) UseAuthorizationCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseAuthorizationCode", reflect.TypeOf((*MockOAuther)(nil).UseAuthorizationCode), arg0, arg1)
+}
+
+// MockAPIKeyer is a mock of APIKeyer interface.
+type MockAPIKeyer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyerMockRecorder
+}
+
+// MockAPIKeyerMockRecorder is the mock recorder for MockAPIKeyer.
+type MockAPIKeyerMockRecorder struct {
+	mock *MockAPIKeyer
+}
+
+// NewMockAPIKeyer creates a new mock instance.
+func NewMockAPIKeyer(ctrl *gomock.Controller) *MockAPIKeyer {
+	mock := &MockAPIKeyer{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyer) EXPECT() *MockAPIKeyerMockRecorder {
+	return m.recorder
+}
+
+// CountActiveAPIKeys mocks base method.
+func (m *MockAPIKeyer) CountActiveAPIKeys(arg0 uuid.UUID, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveAPIKeys", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveAPIKeys indicates an expected call of CountActiveAPIKeys.
+func (mr *MockAPIKeyerMockRecorder) CountActiveAPIKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveAPIKeys", reflect.TypeOf((*MockAPIKeyer)(nil).CountActiveAPIKeys), arg0, arg1)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyer) CreateAPIKey(arg0 domain.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyerMockRecorder) CreateAPIKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyer)(nil).CreateAPIKey), arg0)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockAPIKeyer) GetAPIKeys(arg0 uuid.UUID) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", arg0)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockAPIKeyerMockRecorder) GetAPIKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockAPIKeyer)(nil).GetAPIKeys), arg0)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyer) RevokeAPIKey(arg0 uuid.UUID, arg1 string, arg2 time.Time) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyerMockRecorder) RevokeAPIKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyer)(nil).RevokeAPIKey), arg0, arg1, arg2)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockAPIKeyer) TouchAPIKey(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockAPIKeyerMockRecorder) TouchAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockAPIKeyer)(nil).TouchAPIKey), arg0, arg1)
+}
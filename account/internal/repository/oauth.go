@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+var _ OAuther = (*OAuth)(nil)
+
+// OAuther - repository interface
+type OAuther interface {
+	CreateOAuthClient(client domain.OAuthClient) error
+	GetOAuthClient(clientId string) (domain.OAuthClient, error)
+	GetOAuthClients() ([]domain.OAuthClient, error)
+	DeleteOAuthClient(clientId string, now time.Time) (domain.OAuthClient, []domain.Session, error)
+	CreateAuthorizationCode(code domain.AuthorizationCode) error
+	UseAuthorizationCode(codeHash string, now time.Time) (domain.AuthorizationCode, error)
+}
+
+// OAuth
+type OAuth struct {
+	db *sqlx.DB
+}
+
+// NewOAuth - constructor
+func NewOAuth(db *sqlx.DB) *OAuth {
+	return &OAuth{db: db}
+}
+
+const oauthClientColumns = `client_id, secret_hash, name, public, redirect_uris, grant_types, scopes, roles,
+	account_public_id, created_at`
+
+// oauthClientRow - the lists are kept space separated
+type oauthClientRow struct {
+	domain.OAuthClient
+	RedirectURIs string `db:"redirect_uris"`
+	GrantTypes   string `db:"grant_types"`
+	Scopes       string `db:"scopes"`
+	Roles        string `db:"roles"`
+}
+
+// client - with the lists
+func (r oauthClientRow) client() domain.OAuthClient {
+	client := r.OAuthClient
+	client.RedirectURIs = strings.Fields(r.RedirectURIs)
+	client.GrantTypes = strings.Fields(r.GrantTypes)
+	client.Scopes = strings.Fields(r.Scopes)
+	client.Roles = make([]domain.Role, 0)
+	for _, role := range strings.Fields(r.Roles) {
+		if value, err := strconv.Atoi(role); err == nil {
+			client.Roles = append(client.Roles, domain.Role(value))
+		}
+	}
+	return client
+}
+
+// CreateOAuthClient
+func (r *OAuth) CreateOAuthClient(client domain.OAuthClient) error {
+	roles := make([]string, 0, len(client.Roles))
+	for _, role := range client.Roles {
+		roles = append(roles, strconv.Itoa(int(role)))
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (%s) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		oauthClientTable, oauthClientColumns)
+	_, err := r.db.Exec(query, client.ClientId, client.SecretHash, client.Name, client.Public,
+		strings.Join(client.RedirectURIs, " "), strings.Join(client.GrantTypes, " "),
+		strings.Join(client.Scopes, " "), strings.Join(roles, " "), client.AccountPublicId, client.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create oauth client: %w", err)
+	}
+	return nil
+}
+
+// GetOAuthClient
+func (r *OAuth) GetOAuthClient(clientId string) (domain.OAuthClient, error) {
+	var row oauthClientRow
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE client_id=$1`, oauthClientColumns, oauthClientTable)
+	err := r.db.Get(&row, query, clientId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.OAuthClient{}, domain.ErrOAuthClientNotFound
+	}
+	if err != nil {
+		return domain.OAuthClient{}, fmt.Errorf("get oauth client: %w", err)
+	}
+	return row.client(), nil
+}
+
+// GetOAuthClients - in the registration order
+func (r *OAuth) GetOAuthClients() ([]domain.OAuthClient, error) {
+	var rows []oauthClientRow
+	query := fmt.Sprintf(`SELECT %s FROM %s ORDER BY id`, oauthClientColumns, oauthClientTable)
+	if err := r.db.Select(&rows, query); err != nil {
+		return nil, fmt.Errorf("get oauth clients: %w", err)
+	}
+	clients := make([]domain.OAuthClient, 0, len(rows))
+	for _, row := range rows {
+		clients = append(clients, row.client())
+	}
+	return clients, nil
+}
+
+// DeleteOAuthClient - with its codes, the active sessions of the client are revoked and returned
+func (r *OAuth) DeleteOAuthClient(clientId string, now time.Time) (domain.OAuthClient, []domain.Session, error) {
+	sessions := make([]domain.Session, 0)
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return domain.OAuthClient{}, sessions, err
+	}
+	defer tx.Rollback() // nolint
+
+	var row oauthClientRow
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE client_id=$1`, oauthClientColumns, oauthClientTable)
+	err = tx.Get(&row, query, clientId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.OAuthClient{}, sessions, domain.ErrOAuthClientNotFound
+	}
+	if err != nil {
+		return domain.OAuthClient{}, sessions, fmt.Errorf("get oauth client: %w", err)
+	}
+
+	for _, table := range []string{oauthClientTable, oauthCodeTable} {
+		query = fmt.Sprintf(`DELETE FROM %s WHERE client_id=$1`, table)
+		if _, err := tx.Exec(query, clientId); err != nil {
+			return domain.OAuthClient{}, sessions, fmt.Errorf("delete %s: %w", table, err)
+		}
+	}
+
+	query = fmt.Sprintf(`SELECT public_id, account_public_id, device, user_agent, ip, created_at,
+		last_seen_at, expires_at, revoked_at, client_id, scope FROM %s
+		WHERE client_id=$1 AND revoked_at IS NULL AND expires_at > $2`, sessionTable)
+	if err := tx.Select(&sessions, query, clientId, now); err != nil {
+		return domain.OAuthClient{}, sessions, fmt.Errorf("get client sessions: %w", err)
+	}
+	query = fmt.Sprintf(`UPDATE %s SET revoked_at=$1 WHERE client_id=$2 AND revoked_at IS NULL AND expires_at > $3`,
+		sessionTable)
+	if _, err := tx.Exec(query, now, clientId, now); err != nil {
+		return domain.OAuthClient{}, sessions, fmt.Errorf("revoke client sessions: %w", err)
+	}
+	for i := range sessions {
+		sessions[i].RevokedAt = &now
+	}
+
+	return row.client(), sessions, tx.Commit()
+}
+
+// CreateAuthorizationCode
+func (r *OAuth) CreateAuthorizationCode(code domain.AuthorizationCode) error {
+	query := fmt.Sprintf(`INSERT INTO %s (code_hash, client_id, account_public_id, redirect_uri, scope, nonce,
+		code_challenge, expires_at, created_at) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)`, oauthCodeTable)
+	_, err := r.db.Exec(query, code.CodeHash, code.ClientId, code.AccountPublicId, code.RedirectURI, code.Scope,
+		code.Nonce, code.CodeChallenge, code.ExpiresAt, code.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create authorization code: %w", err)
+	}
+	return nil
+}
+
+// UseAuthorizationCode - the code works once and before it expires, ErrInvalidOAuthGrant otherwise
+func (r *OAuth) UseAuthorizationCode(codeHash string, now time.Time) (domain.AuthorizationCode, error) {
+	var code domain.AuthorizationCode
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return code, err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`SELECT code_hash, client_id, account_public_id, redirect_uri, scope, nonce,
+		code_challenge, expires_at, used_at, created_at FROM %s
+		WHERE code_hash=$1 AND used_at IS NULL AND expires_at > $2`, oauthCodeTable)
+	err = tx.Get(&code, query, codeHash, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		return code, domain.ErrInvalidOAuthGrant
+	}
+	if err != nil {
+		return code, fmt.Errorf("get authorization code: %w", err)
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET used_at=$1 WHERE code_hash=$2 AND used_at IS NULL`, oauthCodeTable)
+	result, err := tx.Exec(query, now, codeHash)
+	if err != nil {
+		return code, fmt.Errorf("use authorization code: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return code, err
+	}
+	if affected == 0 {
+		return code, domain.ErrInvalidOAuthGrant
+	}
+
+	code.UsedAt = &now
+	return code, tx.Commit()
+}
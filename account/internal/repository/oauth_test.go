@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestOAuth_GetOAuthClient(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewOAuth(db)
+	createdAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	columns := []string{"client_id", "secret_hash", "name", "public", "redirect_uris", "grant_types", "scopes",
+		"roles", "account_public_id", "created_at"}
+
+	t.Run("Can get the client with its lists", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM " + oauthClientTable).WithArgs("courier-app").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("courier-app", "", "Courier app", true,
+				"courier://callback", "authorization_code", "openid profile", "2", nil, createdAt))
+
+		client, err := repo.GetOAuthClient("courier-app")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"courier://callback"}, client.RedirectURIs)
+		assert.Equal(t, []string{domain.GRANT_AUTHORIZATION_CODE}, client.GrantTypes)
+		assert.Equal(t, []string{domain.SCOPE_OPENID, domain.SCOPE_PROFILE}, client.Scopes)
+		assert.Equal(t, []domain.Role{domain.ROLE_DELIVERY}, client.Roles)
+		assert.Nil(t, client.AccountPublicId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Can't get the unknown client", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM " + oauthClientTable).WithArgs("unknown").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := repo.GetOAuthClient("unknown")
+		assert.ErrorIs(t, err, domain.ErrOAuthClientNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOAuth_DeleteOAuthClient(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewOAuth(db)
+	accountPublicId := uuid.New()
+	sessionPublicId := uuid.New()
+	now := time.Now().UTC()
+	clientColumns := []string{"client_id", "secret_hash", "name", "public", "redirect_uris", "grant_types",
+		"scopes", "roles", "account_public_id", "created_at"}
+	sessionColumns := []string{"public_id", "account_public_id", "expires_at", "client_id"}
+
+	t.Run("Can delete the client with its codes and revoke its sessions", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM " + oauthClientTable).WithArgs("courier-app").
+			WillReturnRows(sqlmock.NewRows(clientColumns).AddRow("courier-app", "", "Courier app", true,
+				"courier://callback", "authorization_code", "openid", "", nil, now))
+		mock.ExpectExec("DELETE FROM " + oauthClientTable).WithArgs("courier-app").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM " + oauthCodeTable).WithArgs("courier-app").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery("SELECT (.+) FROM "+sessionTable).WithArgs("courier-app", now).
+			WillReturnRows(sqlmock.NewRows(sessionColumns).
+				AddRow(sessionPublicId, accountPublicId, now.Add(time.Hour), "courier-app"))
+		mock.ExpectExec("UPDATE "+sessionTable+" SET revoked_at").WithArgs(now, "courier-app", now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		client, sessions, err := repo.DeleteOAuthClient("courier-app", now)
+		assert.NoError(t, err)
+		assert.Equal(t, "courier-app", client.ClientId)
+		assert.Len(t, sessions, 1)
+		assert.Equal(t, &now, sessions[0].RevokedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Can't delete the unknown client", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM " + oauthClientTable).WithArgs("unknown").
+			WillReturnRows(sqlmock.NewRows(clientColumns))
+		mock.ExpectRollback()
+
+		_, _, err := repo.DeleteOAuthClient("unknown", now)
+		assert.ErrorIs(t, err, domain.ErrOAuthClientNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOAuth_UseAuthorizationCode(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewOAuth(db)
+	now := time.Now().UTC()
+	columns := []string{"code_hash", "client_id", "account_public_id", "redirect_uri", "scope", "nonce",
+		"code_challenge", "expires_at", "used_at", "created_at"}
+
+	tests := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Can use the code once",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM "+oauthCodeTable).WithArgs("hash", now).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("hash", "courier-app", uuid.New(),
+						"courier://callback", "openid", "", "challenge", now.Add(time.Minute), nil, now))
+				mock.ExpectExec("UPDATE "+oauthCodeTable+" SET used_at").WithArgs(now, "hash").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Can't use the code used, expired or unknown",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM "+oauthCodeTable).WithArgs("hash", now).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrInvalidOAuthGrant,
+		},
+		{
+			name: "Can't use the code used by the concurrent exchange",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM "+oauthCodeTable).WithArgs("hash", now).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("hash", "courier-app", uuid.New(),
+						"courier://callback", "openid", "", "challenge", now.Add(time.Minute), nil, now))
+				mock.ExpectExec("UPDATE "+oauthCodeTable+" SET used_at").WithArgs(now, "hash").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrInvalidOAuthGrant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			code, err := repo.UseAuthorizationCode("hash", now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &now, code.UsedAt)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+var _ Resetter = (*PasswordReset)(nil)
+
+// Resetter - repository interface
+type Resetter interface {
+	CreatePasswordReset(reset domain.PasswordReset) error
+	CountPasswordResets(accountPublicId uuid.UUID, since time.Time) (int, error)
+	ResetPassword(tokenHash, passwordHash string, now time.Time) (uuid.UUID, []domain.Session, error)
+}
+
+// PasswordReset
+type PasswordReset struct {
+	db *sqlx.DB
+}
+
+// NewPasswordReset - constructor
+func NewPasswordReset(db *sqlx.DB) *PasswordReset {
+	return &PasswordReset{db: db}
+}
+
+// CreatePasswordReset
+func (r *PasswordReset) CreatePasswordReset(reset domain.PasswordReset) error {
+	query := fmt.Sprintf(`INSERT INTO %s (token_hash, account_public_id, expires_at, created_at)
+		values ($1, $2, $3, $4)`, passwordResetTable)
+	_, err := r.db.Exec(query, reset.TokenHash, reset.AccountPublicId, reset.ExpiresAt, reset.CreatedAt)
+	return err
+}
+
+// CountPasswordResets - sent to the account since the time
+func (r *PasswordReset) CountPasswordResets(accountPublicId uuid.UUID, since time.Time) (int, error) {
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE account_public_id=$1 AND created_at > $2`,
+		passwordResetTable)
+	err := r.db.Get(&count, query, accountPublicId, since)
+	return count, err
+}
+
+// ResetPassword - the token works once and before it expires. All the account reset tokens are used up,
+// the tokens issued before now and all the account sessions are revoked in the same transaction.
+// The account public id and the revoked sessions are returned
+func (r *PasswordReset) ResetPassword(tokenHash, passwordHash string, now time.Time) (uuid.UUID, []domain.Session, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	defer tx.Rollback() // nolint
+
+	var reset domain.PasswordReset
+	query := fmt.Sprintf(`SELECT token_hash, account_public_id, expires_at, used_at, created_at
+		FROM %s WHERE token_hash=$1`, passwordResetTable)
+	err = tx.Get(&reset, query, tokenHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, nil, domain.ErrInvalidResetToken
+	}
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("get password reset: %w", err)
+	}
+	if reset.UsedAt != nil || !now.Before(reset.ExpiresAt) {
+		return uuid.Nil, nil, domain.ErrInvalidResetToken
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET used_at=$1 WHERE account_public_id=$2 AND used_at IS NULL`,
+		passwordResetTable)
+	if _, err := tx.Exec(query, now, reset.AccountPublicId); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("use password reset: %w", err)
+	}
+	query = fmt.Sprintf(`UPDATE %s SET password_hash=$1, tokens_valid_after=$2 WHERE public_id=$3`, accountTable)
+	result, err := tx.Exec(query, passwordHash, now, reset.AccountPublicId)
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("reset password: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	if affected == 0 {
+		return uuid.Nil, nil, domain.ErrInvalidResetToken
+	}
+
+	sessions, err := revokeSessions(tx, reset.AccountPublicId, uuid.Nil, now)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	return reset.AccountPublicId, sessions, tx.Commit()
+}
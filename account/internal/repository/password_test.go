@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestPasswordReset_ResetPassword(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewPasswordReset(db)
+	tokenHash := "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"
+	accountPublicId := uuid.New()
+	now := time.Now().UTC()
+	errSessions := errors.New("sessions")
+	columns := []string{"token_hash", "account_public_id", "expires_at", "used_at", "created_at"}
+	sessionColumns := []string{"public_id", "account_public_id", "expires_at", "revoked_at"}
+
+	tests := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+		wantSessions int
+	}{
+		{
+			name: "Can reset the password and revoke the issued tokens and the sessions",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + passwordResetTable).WithArgs(tokenHash).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tokenHash, accountPublicId, now.Add(time.Hour), nil, now))
+				mock.ExpectExec("UPDATE "+passwordResetTable+" SET used_at").WithArgs(now, accountPublicId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE "+accountTable+" SET password_hash").WithArgs("hash", now, accountPublicId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("^SELECT (.+) FROM "+sessionTable).WithArgs(accountPublicId, uuid.Nil, now).
+					WillReturnRows(sqlmock.NewRows(sessionColumns).
+						AddRow(uuid.New(), accountPublicId, now.Add(time.Hour), nil))
+				mock.ExpectExec("UPDATE "+sessionTable+" SET revoked_at").WithArgs(now, accountPublicId, uuid.Nil, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantSessions: 1,
+		},
+		{
+			name: "Can't reset the password when the sessions are not revoked",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + passwordResetTable).WithArgs(tokenHash).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tokenHash, accountPublicId, now.Add(time.Hour), nil, now))
+				mock.ExpectExec("UPDATE "+passwordResetTable+" SET used_at").WithArgs(now, accountPublicId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE "+accountTable+" SET password_hash").WithArgs("hash", now, accountPublicId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("^SELECT (.+) FROM "+sessionTable).WithArgs(accountPublicId, uuid.Nil, now).
+					WillReturnError(errSessions)
+				mock.ExpectRollback()
+			},
+			wantErr: errSessions,
+		},
+		{
+			name: "Can't use the token twice",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + passwordResetTable).WithArgs(tokenHash).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tokenHash, accountPublicId, now.Add(time.Hour), now, now))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrInvalidResetToken,
+		},
+		{
+			name: "Can't use the expired token",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + passwordResetTable).WithArgs(tokenHash).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tokenHash, accountPublicId, now, nil, now.Add(-time.Hour)))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrInvalidResetToken,
+		},
+		{
+			name: "Can't use the unknown token",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + passwordResetTable).WithArgs(tokenHash).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrInvalidResetToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			publicId, sessions, err := repo.ResetPassword(tokenHash, "hash", now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, accountPublicId, publicId)
+				assert.Len(t, sessions, tt.wantSessions)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
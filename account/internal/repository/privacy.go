@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+var _ Privacier = (*Privacy)(nil)
+
+// Privacier - repository interface
+type Privacier interface {
+	CreatePrivacyRequest(request domain.PrivacyRequest) error
+	GetPrivacyRequest(publicId uuid.UUID) (domain.PrivacyRequest, error)
+	GetPrivacyRequests(kind domain.PrivacyKind, filter domain.PrivacyFilter) ([]domain.PrivacyRequest, error)
+	AddPrivacyPart(requestPublicId uuid.UUID, part domain.PrivacyPart) (domain.PrivacyRequest, error)
+	EraseAccount(request domain.PrivacyRequest, email string) error
+}
+
+// Privacy
+type Privacy struct {
+	db *sqlx.DB
+}
+
+// NewPrivacy - constructor
+func NewPrivacy(db *sqlx.DB) *Privacy {
+	return &Privacy{db: db}
+}
+
+// privacyRequestRow - services are kept comma separated
+type privacyRequestRow struct {
+	domain.PrivacyRequest
+	Services string `db:"services"`
+}
+
+// privacyPartRow - data is kept as text, empty for the erasure
+type privacyPartRow struct {
+	domain.PrivacyPart
+	Data string `db:"data"`
+}
+
+// request - with the parts
+func (r privacyRequestRow) request(parts []domain.PrivacyPart) domain.PrivacyRequest {
+	request := r.PrivacyRequest
+	request.Services = strings.Split(r.Services, ",")
+	request.Parts = parts
+	return request
+}
+
+// CreatePrivacyRequest - with the parts already done
+func (r *Privacy) CreatePrivacyRequest(request domain.PrivacyRequest) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	if err := createPrivacyRequest(tx, request); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetPrivacyRequest - with the parts
+func (r *Privacy) GetPrivacyRequest(publicId uuid.UUID) (domain.PrivacyRequest, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return domain.PrivacyRequest{}, err
+	}
+	defer tx.Rollback() // nolint
+
+	request, err := getPrivacyRequest(tx, publicId)
+	if err != nil {
+		return request, err
+	}
+	return request, tx.Commit()
+}
+
+// GetPrivacyRequests - of the kind, newest first, without the parts
+func (r *Privacy) GetPrivacyRequests(kind domain.PrivacyKind, filter domain.PrivacyFilter) ([]domain.PrivacyRequest, error) {
+	conditions := []string{"kind=$1"}
+	args := []interface{}{kind}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status=$%d", len(args)))
+	}
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT public_id, account_public_id, kind, status, services, created_at, completed_at
+		FROM %s WHERE %s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d`,
+		privacyRequestTable, strings.Join(conditions, " AND "), len(args)-1, len(args))
+
+	var rows []privacyRequestRow
+	if err := r.db.Select(&rows, query, args...); err != nil {
+		return nil, fmt.Errorf("get privacy requests: %w", err)
+	}
+	requests := make([]domain.PrivacyRequest, 0, len(rows))
+	for _, row := range rows {
+		requests = append(requests, row.request(nil))
+	}
+	return requests, nil
+}
+
+// AddPrivacyPart - the answer of a service is kept once, the answers of unknown services are ignored.
+// The request is completed by the last expected answer
+func (r *Privacy) AddPrivacyPart(requestPublicId uuid.UUID, part domain.PrivacyPart) (domain.PrivacyRequest, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return domain.PrivacyRequest{}, err
+	}
+	defer tx.Rollback() // nolint
+
+	request, err := getPrivacyRequest(tx, requestPublicId)
+	if err != nil {
+		return request, err
+	}
+	if !containsService(request.Services, part.Service) {
+		return request, tx.Commit()
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (request_public_id, service, data, completed_at) values ($1, $2, $3, $4)
+		ON CONFLICT(request_public_id, service) DO NOTHING`, privacyPartTable)
+	if _, err := tx.Exec(query, requestPublicId, part.Service, string(part.Data), part.CompletedAt); err != nil {
+		return request, fmt.Errorf("add privacy part: %w", err)
+	}
+
+	request, err = getPrivacyRequest(tx, requestPublicId)
+	if err != nil {
+		return request, err
+	}
+	if request.Status == domain.PRIVACY_PENDING && len(request.Parts) >= len(request.Services) {
+		query = fmt.Sprintf(`UPDATE %s SET status=$1, completed_at=$2 WHERE public_id=$3`, privacyRequestTable)
+		if _, err := tx.Exec(query, domain.PRIVACY_COMPLETED, part.CompletedAt, requestPublicId); err != nil {
+			return request, fmt.Errorf("complete privacy request: %w", err)
+		}
+		request.Status = domain.PRIVACY_COMPLETED
+		request.CompletedAt = &part.CompletedAt
+	}
+
+	return request, tx.Commit()
+}
+
+// EraseAccount - the account, its addresses, tokens, authorization codes, api keys and two-factor are deleted, the sessions
+// and the audit entries lose the email, ip and user agent. The erasure request is created with it
+func (r *Privacy) EraseAccount(request domain.PrivacyRequest, email string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	accountPublicId := request.AccountPublicId
+	for _, table := range []string{addressTable, verificationTable, passwordResetTable,
+		twoFactorTable, recoveryCodeTable, oauthCodeTable, apiKeyTable} {
+		query := fmt.Sprintf(`DELETE FROM %s WHERE account_public_id=$1`, table)
+		if _, err := tx.Exec(query, accountPublicId); err != nil {
+			return fmt.Errorf("erase %s: %w", table, err)
+		}
+	}
+	query := fmt.Sprintf(`UPDATE %s SET device='', user_agent='', ip='' WHERE account_public_id=$1`, sessionTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase sessions: %w", err)
+	}
+	query = fmt.Sprintf(`UPDATE %s SET email='', ip='', user_agent=''
+		WHERE actor_public_id=$1 OR target_public_id=$1 OR (email<>'' AND email=$2)`, auditTable)
+	if _, err := tx.Exec(query, accountPublicId, email); err != nil {
+		return fmt.Errorf("erase audit: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, accountTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase account: %w", err)
+	}
+
+	if err := createPrivacyRequest(tx, request); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// createPrivacyRequest
+func createPrivacyRequest(tx *sqlx.Tx, request domain.PrivacyRequest) error {
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, account_public_id, kind, status, services, created_at, completed_at)
+		values ($1, $2, $3, $4, $5, $6, $7)`, privacyRequestTable)
+	_, err := tx.Exec(query, request.PublicId, request.AccountPublicId, request.Kind, request.Status,
+		strings.Join(request.Services, ","), request.CreatedAt, request.CompletedAt)
+	if err != nil {
+		return fmt.Errorf("create privacy request: %w", err)
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (request_public_id, service, data, completed_at) values ($1, $2, $3, $4)`,
+		privacyPartTable)
+	for _, part := range request.Parts {
+		if _, err := tx.Exec(query, request.PublicId, part.Service, string(part.Data), part.CompletedAt); err != nil {
+			return fmt.Errorf("create privacy part: %w", err)
+		}
+	}
+	return nil
+}
+
+// getPrivacyRequest - with the parts
+func getPrivacyRequest(tx *sqlx.Tx, publicId uuid.UUID) (domain.PrivacyRequest, error) {
+	var row privacyRequestRow
+	query := fmt.Sprintf(`SELECT public_id, account_public_id, kind, status, services, created_at, completed_at
+		FROM %s WHERE public_id=$1`, privacyRequestTable)
+	err := tx.Get(&row, query, publicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.PrivacyRequest{}, domain.ErrPrivacyRequestNotFound
+	}
+	if err != nil {
+		return domain.PrivacyRequest{}, fmt.Errorf("get privacy request: %w", err)
+	}
+
+	var partRows []privacyPartRow
+	query = fmt.Sprintf(`SELECT service, data, completed_at FROM %s WHERE request_public_id=$1 ORDER BY id`,
+		privacyPartTable)
+	if err := tx.Select(&partRows, query, publicId); err != nil {
+		return domain.PrivacyRequest{}, fmt.Errorf("get privacy parts: %w", err)
+	}
+	parts := make([]domain.PrivacyPart, 0, len(partRows))
+	for _, partRow := range partRows {
+		part := partRow.PrivacyPart
+		if partRow.Data != "" {
+			part.Data = json.RawMessage(partRow.Data)
+		}
+		parts = append(parts, part)
+	}
+	return row.request(parts), nil
+}
+
+// containsService
+func containsService(services []string, service string) bool {
+	for _, s := range services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
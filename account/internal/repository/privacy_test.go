@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestPrivacy_AddPrivacyPart(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewPrivacy(db)
+	requestPublicId := uuid.New()
+	accountPublicId := uuid.New()
+	now := time.Now().UTC()
+	requestColumns := []string{"public_id", "account_public_id", "kind", "status", "services", "created_at", "completed_at"}
+	partColumns := []string{"service", "data", "completed_at"}
+	request := func() *sqlmock.Rows {
+		return sqlmock.NewRows(requestColumns).AddRow(requestPublicId, accountPublicId, domain.PRIVACY_EXPORT,
+			domain.PRIVACY_PENDING, "account,product", now, nil)
+	}
+
+	tests := []struct {
+		name         string
+		part         domain.PrivacyPart
+		mockBehavior func()
+		wantStatus   string
+		wantParts    int
+		wantErr      error
+	}{
+		{
+			name: "Can complete the request by the last expected answer",
+			part: domain.PrivacyPart{Service: "product", Data: json.RawMessage(`{"reviews":[]}`), CompletedAt: now},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + privacyRequestTable).WithArgs(requestPublicId).WillReturnRows(request())
+				mock.ExpectQuery("^SELECT (.+) FROM " + privacyPartTable).WithArgs(requestPublicId).
+					WillReturnRows(sqlmock.NewRows(partColumns).AddRow("account", `{}`, now))
+				mock.ExpectExec("INSERT INTO "+privacyPartTable).
+					WithArgs(requestPublicId, "product", `{"reviews":[]}`, now).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectQuery("^SELECT (.+) FROM " + privacyRequestTable).WithArgs(requestPublicId).WillReturnRows(request())
+				mock.ExpectQuery("^SELECT (.+) FROM " + privacyPartTable).WithArgs(requestPublicId).
+					WillReturnRows(sqlmock.NewRows(partColumns).AddRow("account", `{}`, now).AddRow("product", `{"reviews":[]}`, now))
+				mock.ExpectExec("UPDATE "+privacyRequestTable+" SET status").
+					WithArgs(domain.PRIVACY_COMPLETED, now, requestPublicId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantStatus: domain.PRIVACY_COMPLETED,
+			wantParts:  2,
+		},
+		{
+			name: "Can ignore the answer of the service out of the request",
+			part: domain.PrivacyPart{Service: "unknown", CompletedAt: now},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + privacyRequestTable).WithArgs(requestPublicId).WillReturnRows(request())
+				mock.ExpectQuery("^SELECT (.+) FROM " + privacyPartTable).WithArgs(requestPublicId).
+					WillReturnRows(sqlmock.NewRows(partColumns).AddRow("account", `{}`, now))
+				mock.ExpectCommit()
+			},
+			wantStatus: domain.PRIVACY_PENDING,
+			wantParts:  1,
+		},
+		{
+			name: "Can't answer unknown request",
+			part: domain.PrivacyPart{Service: "product", CompletedAt: now},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + privacyRequestTable).WithArgs(requestPublicId).
+					WillReturnRows(sqlmock.NewRows(requestColumns))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrPrivacyRequestNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			got, err := repo.AddPrivacyPart(requestPublicId, tt.part)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, got.Status)
+				assert.Len(t, got.Parts, tt.wantParts)
+				assert.Equal(t, []string{"account", "product"}, got.Services)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPrivacy_EraseAccount(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewPrivacy(db)
+	now := time.Now().UTC()
+	request := domain.PrivacyRequest{
+		PublicId:        uuid.New(),
+		AccountPublicId: uuid.New(),
+		Kind:            domain.PRIVACY_ERASURE,
+		Status:          domain.PRIVACY_PENDING,
+		Services:        []string{"account", "product"},
+		Parts:           []domain.PrivacyPart{{Service: "account", CompletedAt: now}},
+		CreatedAt:       now,
+	}
+
+	t.Run("Can delete the account data, anonymize the kept records and create the request", func(t *testing.T) {
+		mock.ExpectBegin()
+		for _, table := range []string{addressTable, verificationTable, passwordResetTable, twoFactorTable, recoveryCodeTable,
+			oauthCodeTable, apiKeyTable} {
+			mock.ExpectExec("DELETE FROM " + table).WithArgs(request.AccountPublicId).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectExec("UPDATE " + sessionTable + " SET device=''").WithArgs(request.AccountPublicId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE "+auditTable+" SET email=''").WithArgs(request.AccountPublicId, "user@mail.com").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec("DELETE FROM " + accountTable).WithArgs(request.AccountPublicId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO "+privacyRequestTable).
+			WithArgs(request.PublicId, request.AccountPublicId, request.Kind, request.Status, "account,product", now, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO "+privacyPartTable).WithArgs(request.PublicId, "account", "", now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.EraseAccount(request, "user@mail.com"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
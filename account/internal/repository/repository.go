@@ -8,20 +8,54 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-//go:generate mockgen -destination mocks/mock.go -package repository github.com/p12s/furniture-store/account/internal/repository Accounter
+//go:generate mockgen -destination mocks/mock.go -package repository github.com/p12s/furniture-store/account/internal/repository Accounter,Addresser,Verifier,Resetter,TwoFactorer,LoginAttempter,Auditor,Sessioner,Privacier,OAuther,APIKeyer
 
 // Repository - repo
 type Repository struct {
 	Accounter
+	Addresser
+	Verifier
+	Resetter
+	TwoFactorer
+	LoginAttempter
+	Auditor
+	Sessioner
+	Privacier
+	OAuther
+	APIKeyer
 }
 
 // NewRepository - constructor
-func NewRepository(db *sqlx.DB) *Repository {
+func NewRepository(db *sqlx.DB, cfg Config) (*Repository, error) {
 	createAccountTable(db)
+	createAddressTable(db)
+	createVerificationTable(db)
+	createPasswordResetTable(db)
+	createTwoFactorTables(db)
+	createAuditTable(db)
+	createSessionTable(db)
+	createPrivacyTables(db)
+	createOAuthTables(db)
+	createAPIKeyTable(db)
 
-	return &Repository{
-		Accounter: NewAccount(db),
+	loginAttempts, err := NewLoginAttempter(cfg.LoginAttempts, db)
+	if err != nil {
+		return nil, err
 	}
+
+	return &Repository{
+		Accounter:      NewAccount(db),
+		Addresser:      NewAddress(db),
+		Verifier:       NewVerification(db),
+		Resetter:       NewPasswordReset(db),
+		TwoFactorer:    NewTwoFactor(db),
+		LoginAttempter: loginAttempts,
+		Auditor:        NewAudit(db),
+		Sessioner:      NewSession(db),
+		Privacier:      NewPrivacy(db),
+		OAuther:        NewOAuth(db),
+		APIKeyer:       NewAPIKey(db),
+	}, nil
 }
 
 // Deliberately removed the obligation of important fields (name, username, password_hash, ...),
@@ -37,9 +71,11 @@ func createAccountTable(db *sqlx.DB) {
 		"username" TEXT,		
 		"password_hash" TEXT,
 		"email" TEXT,
+		"email_verified" BOOLEAN DEFAULT FALSE NOT NULL,
 		"address" TEXT,
 		"role" INTEGER DEFAULT 0,
-		"created_at" DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL
+		"created_at" DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL,
+		"tokens_valid_after" DATETIME
 	  );`
 	statement, err := db.Prepare(query)
 	defer statement.Close() // nolint
@@ -54,3 +90,242 @@ func createAccountTable(db *sqlx.DB) {
 
 	fmt.Println("account.account table created 🗂")
 }
+
+// createAddressTable
+func createAddressTable(db *sqlx.DB) {
+	query := `CREATE TABLE IF NOT EXISTS address (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"account_public_id" TEXT NOT NULL,
+		"country" TEXT NOT NULL,
+		"city" TEXT NOT NULL,
+		"street" TEXT NOT NULL,
+		"building" TEXT NOT NULL,
+		"apartment" TEXT NOT NULL DEFAULT '',
+		"postal_code" TEXT NOT NULL DEFAULT '',
+		"notes" TEXT NOT NULL DEFAULT '',
+		"latitude" REAL,
+		"longitude" REAL,
+		"is_default" BOOLEAN NOT NULL DEFAULT FALSE,
+		"created_at" DATETIME NOT NULL,
+		"updated_at" DATETIME NOT NULL
+	  );
+	  CREATE INDEX IF NOT EXISTS address_account ON address (account_public_id);`
+	if _, err := db.Exec(query); err != nil {
+		logrus.Fatal("create account.address table fail: ", err.Error())
+	}
+
+	fmt.Println("account.address table created 🗂")
+}
+
+// createVerificationTable
+func createVerificationTable(db *sqlx.DB) {
+	query := `CREATE TABLE IF NOT EXISTS email_verification (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"token_id" TEXT NOT NULL UNIQUE,
+		"account_public_id" TEXT NOT NULL,
+		"email" TEXT NOT NULL,
+		"expires_at" DATETIME NOT NULL,
+		"used_at" DATETIME,
+		"created_at" DATETIME NOT NULL
+	  );
+	  CREATE INDEX IF NOT EXISTS email_verification_account ON email_verification (account_public_id, created_at);`
+	if _, err := db.Exec(query); err != nil {
+		logrus.Fatal("create account.email_verification table fail: ", err.Error())
+	}
+
+	fmt.Println("account.email_verification table created 🗂")
+}
+
+// createPasswordResetTable
+func createPasswordResetTable(db *sqlx.DB) {
+	query := `CREATE TABLE IF NOT EXISTS password_reset (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"token_hash" TEXT NOT NULL UNIQUE,
+		"account_public_id" TEXT NOT NULL,
+		"expires_at" DATETIME NOT NULL,
+		"used_at" DATETIME,
+		"created_at" DATETIME NOT NULL
+	  );
+	  CREATE INDEX IF NOT EXISTS password_reset_account ON password_reset (account_public_id, created_at);`
+	if _, err := db.Exec(query); err != nil {
+		logrus.Fatal("create account.password_reset table fail: ", err.Error())
+	}
+
+	fmt.Println("account.password_reset table created 🗂")
+}
+
+// createTwoFactorTables - totp secret and recovery codes
+func createTwoFactorTables(db *sqlx.DB) {
+	query := `CREATE TABLE IF NOT EXISTS two_factor (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"account_public_id" TEXT NOT NULL UNIQUE,
+		"secret" TEXT NOT NULL,
+		"enabled" BOOLEAN DEFAULT FALSE NOT NULL,
+		"last_step" INTEGER DEFAULT 0 NOT NULL,
+		"created_at" DATETIME NOT NULL,
+		"enabled_at" DATETIME
+	  );
+	  CREATE TABLE IF NOT EXISTS recovery_code (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"account_public_id" TEXT NOT NULL,
+		"code_hash" TEXT NOT NULL,
+		"used_at" DATETIME,
+		"created_at" DATETIME NOT NULL
+	  );
+	  CREATE INDEX IF NOT EXISTS recovery_code_account ON recovery_code (account_public_id, code_hash);`
+	if _, err := db.Exec(query); err != nil {
+		logrus.Fatal("create account.two_factor tables fail: ", err.Error())
+	}
+
+	fmt.Println("account.two_factor and account.recovery_code tables created 🗂")
+}
+
+// createLoginAttemptTable
+func createLoginAttemptTable(db *sqlx.DB) {
+	query := `CREATE TABLE IF NOT EXISTS login_attempt (
+		"attempt_key" TEXT NOT NULL PRIMARY KEY,
+		"failures" INTEGER NOT NULL,
+		"last_failure_at" DATETIME NOT NULL,
+		"locked_until" DATETIME
+	  );`
+	if _, err := db.Exec(query); err != nil {
+		logrus.Fatal("create account.login_attempt table fail: ", err.Error())
+	}
+
+	fmt.Println("account.login_attempt table created 🗂")
+}
+
+// createAuditTable - append only
+func createAuditTable(db *sqlx.DB) {
+	query := `CREATE TABLE IF NOT EXISTS audit (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"actor_public_id" TEXT,
+		"target_public_id" TEXT,
+		"email" TEXT NOT NULL,
+		"action" TEXT NOT NULL,
+		"outcome" TEXT NOT NULL,
+		"details" TEXT NOT NULL,
+		"ip" TEXT NOT NULL,
+		"user_agent" TEXT NOT NULL,
+		"created_at" DATETIME NOT NULL
+	  );
+	  CREATE INDEX IF NOT EXISTS audit_actor ON audit (actor_public_id, created_at);
+	  CREATE INDEX IF NOT EXISTS audit_target ON audit (target_public_id, created_at);
+	  CREATE INDEX IF NOT EXISTS audit_created ON audit (created_at);`
+	if _, err := db.Exec(query); err != nil {
+		logrus.Fatal("create account.audit table fail: ", err.Error())
+	}
+
+	fmt.Println("account.audit table created 🗂")
+}
+
+// createSessionTable
+func createSessionTable(db *sqlx.DB) {
+	query := `CREATE TABLE IF NOT EXISTS session (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"account_public_id" TEXT NOT NULL,
+		"device" TEXT NOT NULL,
+		"user_agent" TEXT NOT NULL,
+		"ip" TEXT NOT NULL,
+		"created_at" DATETIME NOT NULL,
+		"last_seen_at" DATETIME NOT NULL,
+		"expires_at" DATETIME NOT NULL,
+		"revoked_at" DATETIME,
+		"client_id" TEXT DEFAULT '' NOT NULL,
+		"scope" TEXT DEFAULT '' NOT NULL
+	  );
+	  CREATE INDEX IF NOT EXISTS session_account ON session (account_public_id, expires_at);`
+	if _, err := db.Exec(query); err != nil {
+		logrus.Fatal("create account.session table fail: ", err.Error())
+	}
+
+	fmt.Println("account.session table created 🗂")
+}
+
+// createPrivacyTables - data export and erasure requests with the answers of the services,
+// services of the request are comma separated
+func createPrivacyTables(db *sqlx.DB) {
+	query := `CREATE TABLE IF NOT EXISTS privacy_request (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"account_public_id" TEXT NOT NULL,
+		"kind" TEXT NOT NULL,
+		"status" TEXT NOT NULL,
+		"services" TEXT NOT NULL,
+		"created_at" DATETIME NOT NULL,
+		"completed_at" DATETIME
+	  );
+	  CREATE INDEX IF NOT EXISTS privacy_request_kind ON privacy_request (kind, created_at);
+	  CREATE TABLE IF NOT EXISTS privacy_part (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"request_public_id" TEXT NOT NULL,
+		"service" TEXT NOT NULL,
+		"data" TEXT NOT NULL,
+		"completed_at" DATETIME NOT NULL,
+		UNIQUE (request_public_id, service)
+	  );`
+	if _, err := db.Exec(query); err != nil {
+		logrus.Fatal("create account.privacy_request tables fail: ", err.Error())
+	}
+
+	fmt.Println("account.privacy_request and account.privacy_part tables created 🗂")
+}
+
+// createOAuthTables - registered clients and issued authorization codes, the lists of the client
+// are space separated
+func createOAuthTables(db *sqlx.DB) {
+	query := `CREATE TABLE IF NOT EXISTS oauth_client (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"client_id" TEXT NOT NULL UNIQUE,
+		"secret_hash" TEXT DEFAULT '' NOT NULL,
+		"name" TEXT NOT NULL,
+		"public" BOOLEAN DEFAULT FALSE NOT NULL,
+		"redirect_uris" TEXT DEFAULT '' NOT NULL,
+		"grant_types" TEXT NOT NULL,
+		"scopes" TEXT DEFAULT '' NOT NULL,
+		"roles" TEXT DEFAULT '' NOT NULL,
+		"account_public_id" TEXT,
+		"created_at" DATETIME NOT NULL
+	  );
+	  CREATE TABLE IF NOT EXISTS oauth_code (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"code_hash" TEXT NOT NULL UNIQUE,
+		"client_id" TEXT NOT NULL,
+		"account_public_id" TEXT NOT NULL,
+		"redirect_uri" TEXT NOT NULL,
+		"scope" TEXT NOT NULL,
+		"nonce" TEXT DEFAULT '' NOT NULL,
+		"code_challenge" TEXT NOT NULL,
+		"expires_at" DATETIME NOT NULL,
+		"used_at" DATETIME,
+		"created_at" DATETIME NOT NULL
+	  );`
+	if _, err := db.Exec(query); err != nil {
+		logrus.Fatal("create account.oauth_client tables fail: ", err.Error())
+	}
+
+	fmt.Println("account.oauth_client and account.oauth_code tables created 🗂")
+}
+
+// createAPIKeyTable
+func createAPIKeyTable(db *sqlx.DB) {
+	query := `CREATE TABLE IF NOT EXISTS api_key (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"prefix" TEXT NOT NULL UNIQUE,
+		"key_hash" TEXT NOT NULL,
+		"account_public_id" TEXT NOT NULL,
+		"name" TEXT NOT NULL,
+		"scopes" TEXT NOT NULL,
+		"expires_at" DATETIME NOT NULL,
+		"last_used_at" DATETIME,
+		"created_at" DATETIME NOT NULL,
+		"revoked_at" DATETIME
+	  );`
+	if _, err := db.Exec(query); err != nil {
+		logrus.Fatal("create account.api_key table fail: ", err.Error())
+	}
+
+	fmt.Println("account.api_key table created 🗂")
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+var _ Sessioner = (*Session)(nil)
+
+// Sessioner - repository interface
+type Sessioner interface {
+	CreateSession(session domain.Session) error
+	GetSession(publicId uuid.UUID) (domain.Session, error)
+	GetSessions(accountPublicId uuid.UUID, now time.Time) ([]domain.Session, error)
+	TouchSession(publicId uuid.UUID, now, before time.Time) error
+	RevokeSession(accountPublicId, publicId uuid.UUID, now time.Time) (domain.Session, error)
+	RevokeSessions(accountPublicId, except uuid.UUID, now time.Time) ([]domain.Session, error)
+}
+
+// Session
+type Session struct {
+	db *sqlx.DB
+}
+
+// NewSession - constructor
+func NewSession(db *sqlx.DB) *Session {
+	return &Session{db: db}
+}
+
+// CreateSession
+func (r *Session) CreateSession(session domain.Session) error {
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, account_public_id, device, user_agent, ip,
+		created_at, last_seen_at, expires_at, client_id, scope) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		sessionTable)
+	_, err := r.db.Exec(query, session.PublicId, session.AccountPublicId, session.Device,
+		session.UserAgent, session.IP, session.CreatedAt, session.LastSeenAt, session.ExpiresAt,
+		session.ClientId, session.Scope)
+	return err
+}
+
+// GetSession - revoked and expired too
+func (r *Session) GetSession(publicId uuid.UUID) (domain.Session, error) {
+	var session domain.Session
+	query := fmt.Sprintf(`SELECT public_id, account_public_id, device, user_agent, ip, created_at,
+		last_seen_at, expires_at, revoked_at, client_id, scope FROM %s WHERE public_id=$1`, sessionTable)
+	err := r.db.Get(&session, query, publicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return session, domain.ErrSessionNotFound
+	}
+	if err != nil {
+		return session, fmt.Errorf("get session: %w", err)
+	}
+	return session, nil
+}
+
+// GetSessions - active sessions of the account, recently seen first
+func (r *Session) GetSessions(accountPublicId uuid.UUID, now time.Time) ([]domain.Session, error) {
+	sessions := make([]domain.Session, 0)
+	query := fmt.Sprintf(`SELECT public_id, account_public_id, device, user_agent, ip, created_at,
+		last_seen_at, expires_at, revoked_at, client_id, scope FROM %s
+		WHERE account_public_id=$1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_seen_at DESC, id DESC`, sessionTable)
+	err := r.db.Select(&sessions, query, accountPublicId, now)
+	return sessions, err
+}
+
+// TouchSession - the last seen time, only if it is older than before, so not every request writes
+func (r *Session) TouchSession(publicId uuid.UUID, now, before time.Time) error {
+	query := fmt.Sprintf(`UPDATE %s SET last_seen_at=$1 WHERE public_id=$2 AND last_seen_at < $3`,
+		sessionTable)
+	_, err := r.db.Exec(query, now, publicId, before)
+	return err
+}
+
+// RevokeSession - the active session of the account
+func (r *Session) RevokeSession(accountPublicId, publicId uuid.UUID, now time.Time) (domain.Session, error) {
+	var session domain.Session
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return session, err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`SELECT public_id, account_public_id, device, user_agent, ip, created_at,
+		last_seen_at, expires_at, revoked_at, client_id, scope FROM %s
+		WHERE public_id=$1 AND account_public_id=$2 AND revoked_at IS NULL AND expires_at > $3`,
+		sessionTable)
+	err = tx.Get(&session, query, publicId, accountPublicId, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		return session, domain.ErrSessionNotFound
+	}
+	if err != nil {
+		return session, fmt.Errorf("get session: %w", err)
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET revoked_at=$1 WHERE public_id=$2`, sessionTable)
+	if _, err := tx.Exec(query, now, publicId); err != nil {
+		return session, fmt.Errorf("revoke session: %w", err)
+	}
+
+	session.RevokedAt = &now
+	return session, tx.Commit()
+}
+
+// RevokeSessions - all the active sessions of the account except one, uuid.Nil to revoke all.
+// The revoked sessions are returned
+func (r *Session) RevokeSessions(accountPublicId, except uuid.UUID, now time.Time) ([]domain.Session, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return make([]domain.Session, 0), err
+	}
+	defer tx.Rollback() // nolint
+
+	sessions, err := revokeSessions(tx, accountPublicId, except, now)
+	if err != nil || len(sessions) == 0 {
+		return sessions, err
+	}
+	return sessions, tx.Commit()
+}
+
+// revokeSessions - in the transaction of the caller, shared with the password reset
+func revokeSessions(tx *sqlx.Tx, accountPublicId, except uuid.UUID, now time.Time) ([]domain.Session, error) {
+	sessions := make([]domain.Session, 0)
+	query := fmt.Sprintf(`SELECT public_id, account_public_id, device, user_agent, ip, created_at,
+		last_seen_at, expires_at, revoked_at, client_id, scope FROM %s
+		WHERE account_public_id=$1 AND public_id<>$2 AND revoked_at IS NULL AND expires_at > $3`,
+		sessionTable)
+	if err := tx.Select(&sessions, query, accountPublicId, except, now); err != nil {
+		return sessions, fmt.Errorf("get sessions: %w", err)
+	}
+	if len(sessions) == 0 {
+		return sessions, nil
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET revoked_at=$1
+		WHERE account_public_id=$2 AND public_id<>$3 AND revoked_at IS NULL AND expires_at > $4`,
+		sessionTable)
+	if _, err := tx.Exec(query, now, accountPublicId, except, now); err != nil {
+		return sessions, fmt.Errorf("revoke sessions: %w", err)
+	}
+
+	for i := range sessions {
+		sessions[i].RevokedAt = &now
+	}
+	return sessions, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestSession_RevokeSession(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewSession(db)
+	accountPublicId := uuid.New()
+	publicId := uuid.New()
+	now := time.Now().UTC()
+	columns := []string{"public_id", "account_public_id", "expires_at", "revoked_at"}
+
+	tests := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Can revoke the active session of the account",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM "+sessionTable).WithArgs(publicId, accountPublicId, now).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(publicId, accountPublicId, now.Add(time.Hour), nil))
+				mock.ExpectExec("UPDATE "+sessionTable+" SET revoked_at").WithArgs(now, publicId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Can't revoke the session of another account, revoked or expired",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM "+sessionTable).WithArgs(publicId, accountPublicId, now).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrSessionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			session, err := repo.RevokeSession(accountPublicId, publicId, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &now, session.RevokedAt)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSession_RevokeSessions(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewSession(db)
+	accountPublicId := uuid.New()
+	current := uuid.New()
+	now := time.Now().UTC()
+	columns := []string{"public_id", "account_public_id", "expires_at", "revoked_at"}
+
+	t.Run("Can revoke all the active sessions except the current one", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("^SELECT (.+) FROM "+sessionTable).WithArgs(accountPublicId, current, now).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(uuid.New(), accountPublicId, now.Add(time.Hour), nil).
+				AddRow(uuid.New(), accountPublicId, now.Add(2*time.Hour), nil))
+		mock.ExpectExec("UPDATE "+sessionTable+" SET revoked_at").WithArgs(now, accountPublicId, current, now).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		sessions, err := repo.RevokeSessions(accountPublicId, current, now)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Can revoke nothing without the update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("^SELECT (.+) FROM "+sessionTable).WithArgs(accountPublicId, current, now).
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectRollback()
+
+		sessions, err := repo.RevokeSessions(accountPublicId, current, now)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 0)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
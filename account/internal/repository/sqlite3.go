@@ -6,12 +6,26 @@ import (
 )
 
 const (
-	accountTable = "account"
+	accountTable        = "account"
+	addressTable        = "address"
+	verificationTable   = "email_verification"
+	passwordResetTable  = "password_reset"
+	twoFactorTable      = "two_factor"
+	recoveryCodeTable   = "recovery_code"
+	loginAttemptTable   = "login_attempt"
+	auditTable          = "audit"
+	sessionTable        = "session"
+	privacyRequestTable = "privacy_request"
+	privacyPartTable    = "privacy_part"
+	oauthClientTable    = "oauth_client"
+	oauthCodeTable      = "oauth_code"
+	apiKeyTable         = "api_key"
 )
 
-// Config - db
+// Config - db, login attempts driver is sqlite or memory
 type Config struct {
-	Driver string
+	Driver        string
+	LoginAttempts string
 }
 
 // NewSqlite3DB - open connect and ping trying
@@ -20,6 +34,9 @@ func NewSqlite3DB(cfg Config) (*sqlx.DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	// every new connection to ":memory:" gets its own empty database,
+	// the consumer and http handlers must share the only one
+	db.SetMaxOpenConns(1)
 
 	err = db.Ping()
 	if err != nil {
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+var _ TwoFactorer = (*TwoFactor)(nil)
+
+// TwoFactorer - repository interface
+type TwoFactorer interface {
+	GetTwoFactor(accountPublicId uuid.UUID) (domain.TwoFactor, error)
+	StartTwoFactor(accountPublicId uuid.UUID, secret string, now time.Time) error
+	EnableTwoFactor(accountPublicId uuid.UUID, step int64, recoveryCodeHashes []string, now time.Time) error
+	DisableTwoFactor(accountPublicId uuid.UUID) error
+	UseTwoFactorStep(accountPublicId uuid.UUID, step int64) error
+	ReplaceRecoveryCodes(accountPublicId uuid.UUID, recoveryCodeHashes []string, now time.Time) error
+	UseRecoveryCode(accountPublicId uuid.UUID, recoveryCodeHash string, now time.Time) error
+}
+
+// TwoFactor
+type TwoFactor struct {
+	db *sqlx.DB
+}
+
+// NewTwoFactor - constructor
+func NewTwoFactor(db *sqlx.DB) *TwoFactor {
+	return &TwoFactor{db: db}
+}
+
+// GetTwoFactor - enabled or started one
+func (r *TwoFactor) GetTwoFactor(accountPublicId uuid.UUID) (domain.TwoFactor, error) {
+	var twoFactor domain.TwoFactor
+	query := fmt.Sprintf(`SELECT account_public_id, secret, enabled, last_step, created_at, enabled_at
+		FROM %s WHERE account_public_id=$1`, twoFactorTable)
+	err := r.db.Get(&twoFactor, query, accountPublicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return twoFactor, domain.ErrTwoFactorNotEnabled
+	}
+	if err != nil {
+		return twoFactor, fmt.Errorf("get two-factor: %w", err)
+	}
+	return twoFactor, nil
+}
+
+// StartTwoFactor - the new secret replaces the started one, but not the enabled one
+func (r *TwoFactor) StartTwoFactor(accountPublicId uuid.UUID, secret string, now time.Time) error {
+	query := fmt.Sprintf(`INSERT INTO %s (account_public_id, secret, enabled, last_step, created_at)
+		values ($1, $2, FALSE, 0, $3)
+		ON CONFLICT (account_public_id) DO UPDATE SET secret=excluded.secret, last_step=0, created_at=excluded.created_at
+		WHERE enabled=FALSE`, twoFactorTable)
+	result, err := r.db.Exec(query, accountPublicId, secret, now)
+	if err != nil {
+		return fmt.Errorf("start two-factor: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrTwoFactorAlreadyEnabled
+	}
+	return nil
+}
+
+// EnableTwoFactor - the started one with its first used step, the recovery codes are replaced
+func (r *TwoFactor) EnableTwoFactor(accountPublicId uuid.UUID, step int64, recoveryCodeHashes []string,
+	now time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`UPDATE %s SET enabled=TRUE, enabled_at=$1, last_step=$2
+		WHERE account_public_id=$3 AND enabled=FALSE`, twoFactorTable)
+	result, err := tx.Exec(query, now, step, accountPublicId)
+	if err != nil {
+		return fmt.Errorf("enable two-factor: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrTwoFactorAlreadyEnabled
+	}
+	if err := replaceRecoveryCodes(tx, accountPublicId, recoveryCodeHashes, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DisableTwoFactor - with the recovery codes
+func (r *TwoFactor) DisableTwoFactor(accountPublicId uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE account_public_id=$1`, recoveryCodeTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("delete recovery codes: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE account_public_id=$1`, twoFactorTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("delete two-factor: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// UseTwoFactorStep - the step must be after the last used one, so a code can't be replayed
+func (r *TwoFactor) UseTwoFactorStep(accountPublicId uuid.UUID, step int64) error {
+	query := fmt.Sprintf(`UPDATE %s SET last_step=$1 WHERE account_public_id=$2 AND enabled=TRUE AND last_step < $1`,
+		twoFactorTable)
+	result, err := r.db.Exec(query, step, accountPublicId)
+	if err != nil {
+		return fmt.Errorf("use two-factor step: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes - the old codes stop working
+func (r *TwoFactor) ReplaceRecoveryCodes(accountPublicId uuid.UUID, recoveryCodeHashes []string, now time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	if err := replaceRecoveryCodes(tx, accountPublicId, recoveryCodeHashes, now); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UseRecoveryCode - each code works once
+func (r *TwoFactor) UseRecoveryCode(accountPublicId uuid.UUID, recoveryCodeHash string, now time.Time) error {
+	query := fmt.Sprintf(`UPDATE %s SET used_at=$1 WHERE account_public_id=$2 AND code_hash=$3 AND used_at IS NULL`,
+		recoveryCodeTable)
+	result, err := r.db.Exec(query, now, accountPublicId, recoveryCodeHash)
+	if err != nil {
+		return fmt.Errorf("use recovery code: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// replaceRecoveryCodes - inside the caller transaction
+func replaceRecoveryCodes(tx *sqlx.Tx, accountPublicId uuid.UUID, recoveryCodeHashes []string, now time.Time) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE account_public_id=$1`, recoveryCodeTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("delete recovery codes: %w", err)
+	}
+	query = fmt.Sprintf(`INSERT INTO %s (account_public_id, code_hash, created_at) values ($1, $2, $3)`,
+		recoveryCodeTable)
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.Exec(query, accountPublicId, hash, now); err != nil {
+			return fmt.Errorf("create recovery code: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestTwoFactor_EnableTwoFactor(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewTwoFactor(db)
+	accountPublicId := uuid.New()
+	now := time.Now().UTC()
+
+	t.Run("Can enable the started two-factor with the recovery codes", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE "+twoFactorTable+" SET enabled=TRUE").WithArgs(now, int64(100), accountPublicId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM " + recoveryCodeTable).WithArgs(accountPublicId).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO "+recoveryCodeTable).WithArgs(accountPublicId, "hash1", now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO "+recoveryCodeTable).WithArgs(accountPublicId, "hash2", now).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		err := repo.EnableTwoFactor(accountPublicId, 100, []string{"hash1", "hash2"}, now)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Can't enable the enabled two-factor", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE "+twoFactorTable+" SET enabled=TRUE").WithArgs(now, int64(100), accountPublicId).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.EnableTwoFactor(accountPublicId, 100, []string{"hash1"}, now)
+		assert.ErrorIs(t, err, domain.ErrTwoFactorAlreadyEnabled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTwoFactor_UseTwoFactorStep(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewTwoFactor(db)
+	accountPublicId := uuid.New()
+
+	t.Run("Can't use the code of the used step twice", func(t *testing.T) {
+		mock.ExpectExec("UPDATE "+twoFactorTable+" SET last_step").WithArgs(int64(100), accountPublicId).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UseTwoFactorStep(accountPublicId, 100)
+		assert.ErrorIs(t, err, domain.ErrInvalidTwoFactorCode)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTwoFactor_UseRecoveryCode(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewTwoFactor(db)
+	accountPublicId := uuid.New()
+	now := time.Now().UTC()
+
+	t.Run("Can use the recovery code once", func(t *testing.T) {
+		mock.ExpectExec("UPDATE "+recoveryCodeTable+" SET used_at").WithArgs(now, accountPublicId, "hash").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE "+recoveryCodeTable+" SET used_at").WithArgs(now, accountPublicId, "hash").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.NoError(t, repo.UseRecoveryCode(accountPublicId, "hash", now))
+		assert.ErrorIs(t, repo.UseRecoveryCode(accountPublicId, "hash", now), domain.ErrInvalidTwoFactorCode)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
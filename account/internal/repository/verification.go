@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+var _ Verifier = (*Verification)(nil)
+
+// Verifier - repository interface
+type Verifier interface {
+	CreateVerification(verification domain.EmailVerification) error
+	CountVerifications(accountPublicId uuid.UUID, since time.Time) (int, error)
+	UseVerification(tokenId uuid.UUID, now time.Time) (domain.EmailVerification, error)
+}
+
+// Verification
+type Verification struct {
+	db *sqlx.DB
+}
+
+// NewVerification - constructor
+func NewVerification(db *sqlx.DB) *Verification {
+	return &Verification{db: db}
+}
+
+// CreateVerification
+func (r *Verification) CreateVerification(verification domain.EmailVerification) error {
+	query := fmt.Sprintf(`INSERT INTO %s (token_id, account_public_id, email, expires_at, created_at)
+		values ($1, $2, $3, $4, $5)`, verificationTable)
+	_, err := r.db.Exec(query, verification.TokenId, verification.AccountPublicId,
+		verification.Email, verification.ExpiresAt, verification.CreatedAt)
+	return err
+}
+
+// CountVerifications - sent to the account since the time
+func (r *Verification) CountVerifications(accountPublicId uuid.UUID, since time.Time) (int, error) {
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE account_public_id=$1 AND created_at > $2`,
+		verificationTable)
+	err := r.db.Get(&count, query, accountPublicId, since)
+	return count, err
+}
+
+// UseVerification - the token works once, before it expires and while the account email is the same.
+// The account email becomes verified
+func (r *Verification) UseVerification(tokenId uuid.UUID, now time.Time) (domain.EmailVerification, error) {
+	var verification domain.EmailVerification
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return verification, err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`SELECT token_id, account_public_id, email, expires_at, used_at, created_at
+		FROM %s WHERE token_id=$1`, verificationTable)
+	err = tx.Get(&verification, query, tokenId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return verification, domain.ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return verification, fmt.Errorf("get verification: %w", err)
+	}
+	if verification.UsedAt != nil || !now.Before(verification.ExpiresAt) {
+		return verification, domain.ErrInvalidVerificationToken
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET used_at=$1 WHERE token_id=$2`, verificationTable)
+	if _, err := tx.Exec(query, now, tokenId); err != nil {
+		return verification, fmt.Errorf("use verification: %w", err)
+	}
+	query = fmt.Sprintf(`UPDATE %s SET email_verified=TRUE WHERE public_id=$1 AND email=$2`, accountTable)
+	result, err := tx.Exec(query, verification.AccountPublicId, verification.Email)
+	if err != nil {
+		return verification, fmt.Errorf("verify account email: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return verification, err
+	}
+	if affected == 0 {
+		return verification, domain.ErrInvalidVerificationToken
+	}
+
+	verification.UsedAt = &now
+	return verification, tx.Commit()
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestVerification_UseVerification(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewVerification(db)
+	tokenId := uuid.New()
+	accountPublicId := uuid.New()
+	now := time.Now().UTC()
+	columns := []string{"token_id", "account_public_id", "email", "expires_at", "used_at", "created_at"}
+
+	tests := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Can verify the account email by the token",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + verificationTable).WithArgs(tokenId).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tokenId, accountPublicId, "test@test.ru", now.Add(time.Hour), nil, now))
+				mock.ExpectExec("UPDATE "+verificationTable+" SET used_at").WithArgs(now, tokenId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE "+accountTable+" SET email_verified").WithArgs(accountPublicId, "test@test.ru").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Can't use the token twice",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + verificationTable).WithArgs(tokenId).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tokenId, accountPublicId, "test@test.ru", now.Add(time.Hour), now, now))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrInvalidVerificationToken,
+		},
+		{
+			name: "Can't use the expired token",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + verificationTable).WithArgs(tokenId).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tokenId, accountPublicId, "test@test.ru", now, nil, now.Add(-time.Hour)))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrInvalidVerificationToken,
+		},
+		{
+			name: "Can't use the token after the email is changed",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^SELECT (.+) FROM " + verificationTable).WithArgs(tokenId).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(tokenId, accountPublicId, "old@test.ru", now.Add(time.Hour), nil, now))
+				mock.ExpectExec("UPDATE "+verificationTable+" SET used_at").WithArgs(now, tokenId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE "+accountTable+" SET email_verified").WithArgs(accountPublicId, "old@test.ru").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrInvalidVerificationToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			verification, err := repo.UseVerification(tokenId, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &now, verification.UsedAt)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -16,48 +16,61 @@ var _ Accounter = (*AccountService)(nil)
 
 // Accounter - service interface
 type Accounter interface {
-	CreateAccount(account domain.Account) error
+	CreateAccount(account domain.Account) (domain.Account, error)
 	GetAccount(publicId string) (domain.Account, error)
 	UpdateAccountInfo(input domain.UpdateAccountInput) error
 	UpdateAccountRole(input domain.UpdateAccountRoleInput) error
 	DeleteAccount(accountPublicId string) error
-	GenerateTokenByCreds(email, password string) (string, error)
-	ParseToken(token string) (string, error)
+	ParseToken(token string) (string, string, error)
+	ParseOAuthToken(token string) (string, string, error)
 }
 
 // AccountService - service
 type AccountService struct {
 	repo       repository.Accounter
+	addresses  repository.Addresser
+	sessions   repository.Sessioner
 	salt       string
-	tokenTTL   time.Duration
 	signingKey string
 }
 
 // NewAccountService - constructor
-func NewAccountService(repo repository.Accounter, config *config.Auth) *AccountService {
+func NewAccountService(repo repository.Accounter, addresses repository.Addresser,
+	sessions repository.Sessioner, config *config.Auth) *AccountService {
 	return &AccountService{
 		repo:       repo,
+		addresses:  addresses,
+		sessions:   sessions,
 		salt:       config.Salt,
-		tokenTTL:   time.Duration(config.TokenTTL * 1000000),
 		signingKey: config.SigningKey,
 	}
 }
 
-// CreateAccount
-func (s *AccountService) CreateAccount(account domain.Account) error {
+// CreateAccount - the email starts unverified, the password is stored hashed and not returned
+func (s *AccountService) CreateAccount(account domain.Account) (domain.Account, error) {
 	account.PublicId = uuid.New()
 	account.Role = domain.ROLE_CUSTOMER
+	account.EmailVerified = false
 	passwordHash, err := s.generatePasswordHash(account.Password)
 	if err != nil {
-		return fmt.Errorf("generate password: %w", err)
+		return account, fmt.Errorf("generate password: %w", err)
 	}
 	account.Password = passwordHash
-	return s.repo.CreateAccount(account)
+	if err := s.repo.CreateAccount(account); err != nil {
+		return account, err
+	}
+	account.Password = ""
+	return account, nil
 }
 
-// GetAccount
+// GetAccount - with the delivery addresses
 func (s *AccountService) GetAccount(publicId string) (domain.Account, error) {
-	return s.repo.GetAccount(publicId)
+	account, err := s.repo.GetAccount(publicId)
+	if err != nil {
+		return account, err
+	}
+	account.Addresses, err = s.addresses.GetAddresses(account.PublicId)
+	return account, err
 }
 
 // UpdateAccountInfo
@@ -82,28 +95,19 @@ func (s *AccountService) DeleteAccount(accountPublicId string) error {
 	return s.repo.DeleteAccount(accountPublicId)
 }
 
-// GenerateTokenByCreds
-func (s *AccountService) GenerateTokenByCreds(email, password string) (string, error) {
-	passwordHash, err := s.generatePasswordHash(password)
-	if err != nil {
-		return "", fmt.Errorf("generate password: %w", err)
-	}
-	account, err := s.repo.GetByCredentials(email, passwordHash)
-	if err != nil {
-		return "", fmt.Errorf("user creds wrong: %w", err)
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{ //nolint
-		Subject:   account.PublicId.String(),
-		IssuedAt:  time.Now().Unix(),
-		ExpiresAt: time.Now().Add(s.tokenTTL).Unix(),
-	})
+// ParseToken - the account and the session public id of the sign-in access token. The session must be active,
+// its last seen time is updated. The oauth token of a client is not accepted
+func (s *AccountService) ParseToken(accessToken string) (string, string, error) {
+	return s.parseToken(accessToken, false)
+}
 
-	return token.SignedString([]byte(s.signingKey))
+// ParseOAuthToken - as ParseToken, the oauth token of a client is accepted too. Its scope is the one of the session
+func (s *AccountService) ParseOAuthToken(accessToken string) (string, string, error) {
+	return s.parseToken(accessToken, true)
 }
 
-// ParseToken
-func (s *AccountService) ParseToken(accessToken string) (string, error) {
+// parseToken - the access token has no audience, the oauth one has the oauth audience and the client session
+func (s *AccountService) parseToken(accessToken string, oauth bool) (string, string, error) {
 	t, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -112,31 +116,82 @@ func (s *AccountService) ParseToken(accessToken string) (string, error) {
 		return []byte(s.signingKey), nil
 	})
 	if err != nil {
-		return "", fmt.Errorf("unexpected signing method: %w/n", err)
+		return "", "", fmt.Errorf("unexpected signing method: %w/n", err)
 	}
 
 	if !t.Valid {
-		return "", fmt.Errorf("invalid token")
+		return "", "", fmt.Errorf("invalid token")
 	}
 
 	claims, ok := t.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", fmt.Errorf("invalid claims")
+		return "", "", fmt.Errorf("invalid claims")
 	}
 
 	subject, ok := claims["sub"].(string)
 	if !ok {
-		return "", fmt.Errorf("invalid subject")
+		return "", "", fmt.Errorf("invalid subject")
+	}
+	_, hasAudience := claims["aud"]
+	isOAuth := hasAudience && claims.VerifyAudience(OAUTH_AUDIENCE, true)
+	if hasAudience && !isOAuth || isOAuth && !oauth {
+		return "", "", fmt.Errorf("invalid audience")
+	}
+
+	account, err := s.repo.GetAccount(subject)
+	if err != nil {
+		return "", "", fmt.Errorf("token account: %w", err)
+	}
+	issuedAt, _ := claims["iat"].(float64)
+	if account.TokensValidAfter != nil && int64(issuedAt) < account.TokensValidAfter.Unix() {
+		return "", "", fmt.Errorf("revoked token")
 	}
 
-	return subject, nil
+	tokenId, _ := claims["jti"].(string)
+	sessionPublicId, err := uuid.Parse(tokenId)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid session")
+	}
+	session, err := s.sessions.GetSession(sessionPublicId)
+	if err != nil {
+		return "", "", fmt.Errorf("token session: %w", err)
+	}
+	now := time.Now().UTC()
+	if session.AccountPublicId != account.PublicId || session.RevokedAt != nil || !now.Before(session.ExpiresAt) {
+		return "", "", fmt.Errorf("revoked session")
+	}
+	if isOAuth != (session.ClientId != "") {
+		return "", "", fmt.Errorf("invalid session")
+	}
+	if err := s.sessions.TouchSession(session.PublicId, now, now.Add(-SESSION_TOUCH_INTERVAL)); err != nil {
+		return "", "", fmt.Errorf("touch session: %w", err)
+	}
+
+	return subject, tokenId, nil
 }
 
 // generatePasswordHash
 func (s *AccountService) generatePasswordHash(password string) (string, error) {
+	return generatePasswordHash(s.salt, password)
+}
+
+// generatePasswordHash - the same for all services storing a password
+func generatePasswordHash(salt, password string) (string, error) {
 	hash := sha1.New() // #nosec
 	if _, err := hash.Write([]byte(password)); err != nil {
 		return "", fmt.Errorf("hash write: %w", err)
 	}
-	return fmt.Sprintf("%x", hash.Sum([]byte(s.salt))), nil
+	return fmt.Sprintf("%x", hash.Sum([]byte(salt))), nil
+}
+
+// generateAccessToken - the full access token of the session, it is issued only after all sign-in steps
+func generateAccessToken(session domain.Session, signingKey string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{ //nolint
+		Id:        session.PublicId.String(),
+		Subject:   session.AccountPublicId.String(),
+		IssuedAt:  session.CreatedAt.Unix(),
+		ExpiresAt: session.ExpiresAt.Unix(),
+	})
+
+	return token.SignedString([]byte(signingKey))
 }
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/repository"
+)
+
+var _ Addresser = (*AddressService)(nil)
+
+// Addresser - service interface
+type Addresser interface {
+	CreateAddress(accountPublicId string, input domain.AddressInput) (domain.Address, error)
+	GetAddresses(accountPublicId string) ([]domain.Address, error)
+	GetAddress(accountPublicId string, publicId uuid.UUID) (domain.Address, error)
+	UpdateAddress(accountPublicId string, publicId uuid.UUID, input domain.AddressInput) (domain.Address, error)
+	DeleteAddress(accountPublicId string, publicId uuid.UUID) (domain.AddressDeleted, error)
+}
+
+// AddressService - delivery addresses of the account. The account has the only default address
+// while it has any, the first address becomes the default
+type AddressService struct {
+	repo repository.Addresser
+}
+
+// NewAddressService - constructor
+func NewAddressService(repo repository.Addresser) *AddressService {
+	return &AddressService{repo: repo}
+}
+
+// CreateAddress
+func (s *AddressService) CreateAddress(accountPublicId string, input domain.AddressInput) (domain.Address, error) {
+	account, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return domain.Address{}, err
+	}
+	if err := input.Validate(); err != nil {
+		return domain.Address{}, err
+	}
+
+	count, err := s.repo.CountAddresses(account)
+	if err != nil {
+		return domain.Address{}, err
+	}
+	if count >= domain.MAX_ADDRESSES {
+		return domain.Address{}, fmt.Errorf("%w: %d max", domain.ErrTooManyAddresses, domain.MAX_ADDRESSES)
+	}
+
+	now := time.Now().UTC()
+	address := domain.Address{
+		PublicId:        uuid.New(),
+		AccountPublicId: account,
+		IsDefault:       input.IsDefault || count == 0,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	address.Apply(input)
+	return address, s.repo.CreateAddress(address)
+}
+
+// GetAddresses - the default goes first
+func (s *AddressService) GetAddresses(accountPublicId string) ([]domain.Address, error) {
+	account, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetAddresses(account)
+}
+
+// GetAddress
+func (s *AddressService) GetAddress(accountPublicId string, publicId uuid.UUID) (domain.Address, error) {
+	account, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return domain.Address{}, err
+	}
+	return s.repo.GetAddress(account, publicId)
+}
+
+// UpdateAddress - the default can't be unset, another address is made the default instead
+func (s *AddressService) UpdateAddress(accountPublicId string, publicId uuid.UUID,
+	input domain.AddressInput) (domain.Address, error) {
+	address, err := s.GetAddress(accountPublicId, publicId)
+	if err != nil {
+		return address, err
+	}
+	if err := input.Validate(); err != nil {
+		return address, err
+	}
+
+	address.Apply(input)
+	address.IsDefault = address.IsDefault || input.IsDefault
+	address.UpdatedAt = time.Now().UTC()
+	return address, s.repo.UpdateAddress(address)
+}
+
+// DeleteAddress - the oldest of the rest becomes the default instead of the deleted default
+func (s *AddressService) DeleteAddress(accountPublicId string, publicId uuid.UUID) (domain.AddressDeleted, error) {
+	address, err := s.GetAddress(accountPublicId, publicId)
+	if err != nil {
+		return domain.AddressDeleted{}, err
+	}
+
+	newDefault, err := s.repo.DeleteAddress(address.AccountPublicId, publicId)
+	if err != nil {
+		return domain.AddressDeleted{}, err
+	}
+	return domain.AddressDeleted{Deleted: address, NewDefault: newDefault}, nil
+}
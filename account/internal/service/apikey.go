@@ -0,0 +1,156 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/config"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/repository"
+)
+
+const (
+	// API_KEY_PREFIX_SIZE - random bytes of the visible prefix
+	API_KEY_PREFIX_SIZE = 6
+	// API_KEY_SECRET_SIZE - random bytes of the secret part
+	API_KEY_SECRET_SIZE = 32
+)
+
+// API_KEY_SCOPES - supported scopes
+var API_KEY_SCOPES = []string{domain.API_KEY_SCOPE_PRODUCTS_READ, domain.API_KEY_SCOPE_PRODUCTS_WRITE,
+	domain.API_KEY_SCOPE_STOCK_READ, domain.API_KEY_SCOPE_STOCK_WRITE}
+
+var _ APIKeyer = (*APIKeyService)(nil)
+
+// APIKeyer - service interface
+type APIKeyer interface {
+	CreateAPIKey(accountPublicId string, input domain.CreateAPIKeyInput) (domain.APIKey, error)
+	GetAPIKeys(accountPublicId string) ([]domain.APIKey, error)
+	RevokeAPIKey(accountPublicId, prefix string) (domain.APIKey, error)
+	TouchAPIKey(input domain.APIKeyUsedInput) error
+}
+
+// APIKeyService - the keys of the dealer integrations, they are checked by the product service
+// by the copies sent in the events
+type APIKeyService struct {
+	repo     repository.APIKeyer
+	accounts repository.Accounter
+	ttl      time.Duration
+	maxTTL   time.Duration
+	maxKeys  int
+}
+
+// NewAPIKeyService - constructor
+func NewAPIKeyService(repo repository.APIKeyer, accounts repository.Accounter, config *config.APIKey) *APIKeyService {
+	return &APIKeyService{
+		repo:     repo,
+		accounts: accounts,
+		ttl:      time.Duration(config.TTL) * time.Second,
+		maxTTL:   time.Duration(config.MaxTTL) * time.Second,
+		maxKeys:  config.MaxKeys,
+	}
+}
+
+// CreateAPIKey - for the dealer account only, the key is in the result only
+func (s *APIKeyService) CreateAPIKey(accountPublicId string, input domain.CreateAPIKeyInput) (domain.APIKey, error) {
+	account, err := s.accounts.GetAccount(accountPublicId)
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+	if account.Role != domain.ROLE_DEALER {
+		return domain.APIKey{}, domain.ErrAPIKeyNotAllowed
+	}
+
+	now := time.Now().UTC()
+	scopes, err := apiKeyScopes(input.Scopes)
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+	expiresAt := now.Add(s.ttl)
+	if input.ExpiresAt != nil {
+		expiresAt = input.ExpiresAt.UTC()
+	}
+	if !expiresAt.After(now) || expiresAt.After(now.Add(s.maxTTL)) {
+		return domain.APIKey{}, fmt.Errorf("%w: expiration must be in the future, %s at most",
+			domain.ErrInvalidAPIKey, s.maxTTL)
+	}
+	if strings.TrimSpace(input.Name) == "" {
+		return domain.APIKey{}, fmt.Errorf("%w: name is required", domain.ErrInvalidAPIKey)
+	}
+
+	count, err := s.repo.CountActiveAPIKeys(account.PublicId, now)
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+	if count >= s.maxKeys {
+		return domain.APIKey{}, fmt.Errorf("%w: %d active keys at most", domain.ErrTooManyAPIKeys, s.maxKeys)
+	}
+
+	rawPrefix := make([]byte, API_KEY_PREFIX_SIZE)
+	if _, err := rand.Read(rawPrefix); err != nil {
+		return domain.APIKey{}, fmt.Errorf("generate api key prefix: %w", err)
+	}
+	secret, err := randomToken(API_KEY_SECRET_SIZE)
+	if err != nil {
+		return domain.APIKey{}, fmt.Errorf("generate api key: %w", err)
+	}
+	prefix := domain.API_KEY_PREFIX + hex.EncodeToString(rawPrefix)
+	key := domain.APIKey{
+		Prefix:          prefix,
+		Key:             prefix + "_" + secret,
+		AccountPublicId: account.PublicId,
+		Name:            strings.TrimSpace(input.Name),
+		Scopes:          scopes,
+		ExpiresAt:       expiresAt,
+		CreatedAt:       now,
+	}
+	key.KeyHash = hashToken(key.Key)
+	if err := s.repo.CreateAPIKey(key); err != nil {
+		return domain.APIKey{}, err
+	}
+	return key, nil
+}
+
+// GetAPIKeys - own keys not revoked, without the secrets
+func (s *APIKeyService) GetAPIKeys(accountPublicId string) ([]domain.APIKey, error) {
+	publicId, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetAPIKeys(publicId)
+}
+
+// RevokeAPIKey - own key, the product service stops accepting it by the event
+func (s *APIKeyService) RevokeAPIKey(accountPublicId, prefix string) (domain.APIKey, error) {
+	publicId, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+	return s.repo.RevokeAPIKey(publicId, prefix, time.Now().UTC())
+}
+
+// TouchAPIKey - the usage reported by the product service
+func (s *APIKeyService) TouchAPIKey(input domain.APIKeyUsedInput) error {
+	return s.repo.TouchAPIKey(input.Prefix, input.UsedAt.UTC())
+}
+
+// apiKeyScopes - known ones without the repeats, at least one
+func apiKeyScopes(requested []string) ([]string, error) {
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		if !contains(API_KEY_SCOPES, scope) {
+			return nil, fmt.Errorf("%w: unsupported scope %s", domain.ErrInvalidAPIKey, scope)
+		}
+		if !contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: scopes are required", domain.ErrInvalidAPIKey)
+	}
+	return scopes, nil
+}
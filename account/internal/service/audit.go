@@ -0,0 +1,63 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/repository"
+)
+
+var _ Auditor = (*AuditService)(nil)
+
+// Auditor - service interface
+type Auditor interface {
+	RecordAudit(entry domain.AuditEntry) error
+	GetActivity(accountPublicId string, limit, offset int) ([]domain.AuditEntry, error)
+	SearchAudit(filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+
+// AuditService - security audit log of the auth and admin actions
+type AuditService struct {
+	repo repository.Auditor
+}
+
+// NewAuditService - constructor
+func NewAuditService(repo repository.Auditor) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// RecordAudit - the entry time is now
+func (s *AuditService) RecordAudit(entry domain.AuditEntry) error {
+	entry.CreatedAt = time.Now().UTC()
+	return s.repo.CreateAuditEntry(entry)
+}
+
+// GetActivity - recent entries of own account, as the actor or as the target
+func (s *AuditService) GetActivity(accountPublicId string, limit, offset int) ([]domain.AuditEntry, error) {
+	return s.SearchAudit(domain.AuditFilter{Account: accountPublicId, Limit: limit, Offset: offset})
+}
+
+// SearchAudit - across the accounts, the page is limited
+func (s *AuditService) SearchAudit(filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	for _, id := range []*string{&filter.Account, &filter.Actor, &filter.Target} {
+		if *id == "" {
+			continue
+		}
+		publicId, err := uuid.Parse(*id)
+		if err != nil {
+			return nil, domain.ErrInvalidAuditFilter
+		}
+		*id = publicId.String()
+	}
+	if filter.Offset < 0 {
+		return nil, domain.ErrInvalidAuditFilter
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = domain.AUDIT_LIMIT
+	}
+	if filter.Limit > domain.AUDIT_MAX_LIMIT {
+		filter.Limit = domain.AUDIT_MAX_LIMIT
+	}
+	return s.repo.GetAuditEntries(filter)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/config"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/repository"
+)
+
+// loginGuard - failed sign-in counters by the email and by the ip with the exponential lockout
+type loginGuard struct {
+	repo          repository.LoginAttempter
+	maxFailures   int
+	maxIPFailures int
+	lockout       time.Duration
+	maxLockout    time.Duration
+	window        time.Duration
+}
+
+// newLoginGuard - constructor
+func newLoginGuard(repo repository.LoginAttempter, config *config.Login) *loginGuard {
+	return &loginGuard{
+		repo:          repo,
+		maxFailures:   config.MaxFailures,
+		maxIPFailures: config.MaxIPFailures,
+		lockout:       time.Duration(config.Lockout) * time.Second,
+		maxLockout:    time.Duration(config.MaxLockout) * time.Second,
+		window:        time.Duration(config.FailureWindow) * time.Second,
+	}
+}
+
+// check - ErrLoginLocked while the email or the ip is locked
+func (g *loginGuard) check(email, ip string) error {
+	now := time.Now().UTC()
+	for _, key := range []string{emailLoginKey(email), ipLoginKey(ip)} {
+		attempts, err := g.repo.GetLoginAttempts(key)
+		if err != nil {
+			return err
+		}
+		if attempts.IsLocked(now) {
+			return domain.ErrLoginLocked
+		}
+	}
+	return nil
+}
+
+// fail - counts the failure, the result is the LoginFailedError with the events to publish
+func (g *loginGuard) fail(cause error, reason, email string, accountPublicId *uuid.UUID, ip string) error {
+	now := time.Now().UTC()
+	failed := &domain.LoginFailedError{
+		Err: cause,
+		Failed: domain.LoginFailedEvent{
+			PublicId: accountPublicId,
+			Email:    email,
+			IP:       ip,
+			Reason:   reason,
+			FailedAt: now,
+		},
+	}
+
+	emailAttempts, err := g.repo.AddLoginFailure(emailLoginKey(email), now, g.window)
+	if err != nil {
+		return err
+	}
+	failed.Failed.Failures = emailAttempts.Failures
+	if until, ok := g.lockUntil(emailAttempts.Failures, g.maxFailures, now); ok {
+		if err := g.repo.LockLogin(emailAttempts.Key, until); err != nil {
+			return err
+		}
+		failed.Locked = append(failed.Locked, domain.AccountLockedEvent{
+			Scope:       domain.LOGIN_SCOPE_EMAIL,
+			PublicId:    accountPublicId,
+			Email:       email,
+			Failures:    emailAttempts.Failures,
+			LockedUntil: until,
+		})
+	}
+
+	ipAttempts, err := g.repo.AddLoginFailure(ipLoginKey(ip), now, g.window)
+	if err != nil {
+		return err
+	}
+	if until, ok := g.lockUntil(ipAttempts.Failures, g.maxIPFailures, now); ok {
+		if err := g.repo.LockLogin(ipAttempts.Key, until); err != nil {
+			return err
+		}
+		failed.Locked = append(failed.Locked, domain.AccountLockedEvent{
+			Scope:       domain.LOGIN_SCOPE_IP,
+			IP:          ip,
+			Failures:    ipAttempts.Failures,
+			LockedUntil: until,
+		})
+	}
+
+	return failed
+}
+
+// failOn - counts the failure for the cause errors only, the other errors are returned as is
+func (g *loginGuard) failOn(err error, reason, email string, accountPublicId *uuid.UUID, ip string) error {
+	if errors.Is(err, domain.ErrInvalidCredentials) || errors.Is(err, domain.ErrInvalidTwoFactorCode) {
+		return g.fail(err, reason, email, accountPublicId, ip)
+	}
+	return err
+}
+
+// succeed - the email failures are forgotten, the ip ones are kept
+func (g *loginGuard) succeed(email string) error {
+	return g.repo.ResetLoginAttempts(emailLoginKey(email))
+}
+
+// lockUntil - the lockout doubles with every failure over the limit, up to the max one
+func (g *loginGuard) lockUntil(failures, limit int, now time.Time) (time.Time, bool) {
+	if limit <= 0 || failures < limit {
+		return time.Time{}, false
+	}
+	lockout := g.lockout
+	for i := limit; i < failures && lockout < g.maxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > g.maxLockout {
+		lockout = g.maxLockout
+	}
+	return now.Add(lockout), true
+}
+
+// emailLoginKey - the email is case insensitive
+func emailLoginKey(email string) string {
+	return domain.LOGIN_SCOPE_EMAIL + ":" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// ipLoginKey
+func ipLoginKey(ip string) string {
+	return domain.LOGIN_SCOPE_IP + ":" + ip
+}
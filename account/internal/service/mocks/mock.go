@@ -1,13 +1,15 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/p12s/furniture-store/account/internal/service (interfaces: Accounter)
+// Source: github.com/p12s/furniture-store/account/internal/service (interfaces: Accounter,Addresser,Verifier,PasswordResetter,TwoFactorer,Auditor,Sessioner,Privacier,OAuther,APIKeyer)
 
 // Package service is a generated GoMock package.
 package service
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	domain "github.com/p12s/furniture-store/account/internal/domain"
 )
 
@@ -35,11 +37,12 @@ func (m *MockAccounter) EXPECT() *MockAccounterMockRecorder {
 }
 
 // CreateAccount mocks base method.
-func (m *MockAccounter) CreateAccount(arg0 domain.Account) error {
+func (m *MockAccounter) CreateAccount(arg0 domain.Account) (domain.Account, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateAccount", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CreateAccount indicates an expected call of CreateAccount.
@@ -62,21 +65,6 @@ func (mr *MockAccounterMockRecorder) DeleteAccount(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccounter)(nil).DeleteAccount), arg0)
 }
 
-// GenerateTokenByCreds mocks base method.
-func (m *MockAccounter) GenerateTokenByCreds(arg0, arg1 string) (string, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateTokenByCreds", arg0, arg1)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GenerateTokenByCreds indicates an expected call of GenerateTokenByCreds.
-func (mr *MockAccounterMockRecorder) GenerateTokenByCreds(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTokenByCreds", reflect.TypeOf((*MockAccounter)(nil).GenerateTokenByCreds), arg0, arg1)
-}
-
 // GetAccount mocks base method.
 func (m *MockAccounter) GetAccount(arg0 string) (domain.Account, error) {
 	m.ctrl.T.Helper()
@@ -92,13 +80,30 @@ func (mr *MockAccounterMockRecorder) GetAccount(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccounter)(nil).GetAccount), arg0)
 }
 
+// ParseOAuthToken mocks base method.
+func (m *MockAccounter) ParseOAuthToken(arg0 string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseOAuthToken", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ParseOAuthToken indicates an expected call of ParseOAuthToken.
+func (mr *MockAccounterMockRecorder) ParseOAuthToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseOAuthToken", reflect.TypeOf((*MockAccounter)(nil).ParseOAuthToken), arg0)
+}
+
 // ParseToken mocks base method.
-func (m *MockAccounter) ParseToken(arg0 string) (string, error) {
+func (m *MockAccounter) ParseToken(arg0 string) (string, string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ParseToken", arg0)
 	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // ParseToken indicates an expected call of ParseToken.
@@ -134,3 +139,833 @@ func (mr *MockAccounterMockRecorder) UpdateAccountRole(arg0 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountRole", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountRole), arg0)
 }
+
+// MockAddresser is a mock of Addresser interface.
+type MockAddresser struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddresserMockRecorder
+}
+
+// MockAddresserMockRecorder is the mock recorder for MockAddresser.
+type MockAddresserMockRecorder struct {
+	mock *MockAddresser
+}
+
+// NewMockAddresser creates a new mock instance.
+func NewMockAddresser(ctrl *gomock.Controller) *MockAddresser {
+	mock := &MockAddresser{ctrl: ctrl}
+	mock.recorder = &MockAddresserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddresser) EXPECT() *MockAddresserMockRecorder {
+	return m.recorder
+}
+
+// CreateAddress mocks base method.
+func (m *MockAddresser) CreateAddress(arg0 string, arg1 domain.AddressInput) (domain.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAddress", arg0, arg1)
+	ret0, _ := ret[0].(domain.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAddress indicates an expected call of CreateAddress.
+func (mr *MockAddresserMockRecorder) CreateAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAddress", reflect.TypeOf((*MockAddresser)(nil).CreateAddress), arg0, arg1)
+}
+
+// DeleteAddress mocks base method.
+func (m *MockAddresser) DeleteAddress(arg0 string, arg1 uuid.UUID) (domain.AddressDeleted, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", arg0, arg1)
+	ret0, _ := ret[0].(domain.AddressDeleted)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockAddresserMockRecorder) DeleteAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockAddresser)(nil).DeleteAddress), arg0, arg1)
+}
+
+// GetAddress mocks base method.
+func (m *MockAddresser) GetAddress(arg0 string, arg1 uuid.UUID) (domain.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddress", arg0, arg1)
+	ret0, _ := ret[0].(domain.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddress indicates an expected call of GetAddress.
+func (mr *MockAddresserMockRecorder) GetAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddress", reflect.TypeOf((*MockAddresser)(nil).GetAddress), arg0, arg1)
+}
+
+// GetAddresses mocks base method.
+func (m *MockAddresser) GetAddresses(arg0 string) ([]domain.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddresses", arg0)
+	ret0, _ := ret[0].([]domain.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddresses indicates an expected call of GetAddresses.
+func (mr *MockAddresserMockRecorder) GetAddresses(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddresses", reflect.TypeOf((*MockAddresser)(nil).GetAddresses), arg0)
+}
+
+// UpdateAddress mocks base method.
+func (m *MockAddresser) UpdateAddress(arg0 string, arg1 uuid.UUID, arg2 domain.AddressInput) (domain.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAddress", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAddress indicates an expected call of UpdateAddress.
+func (mr *MockAddresserMockRecorder) UpdateAddress(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAddress", reflect.TypeOf((*MockAddresser)(nil).UpdateAddress), arg0, arg1, arg2)
+}
+
+// MockVerifier is a mock of Verifier interface.
+type MockVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerifierMockRecorder
+}
+
+// MockVerifierMockRecorder is the mock recorder for MockVerifier.
+type MockVerifierMockRecorder struct {
+	mock *MockVerifier
+}
+
+// NewMockVerifier creates a new mock instance.
+func NewMockVerifier(ctrl *gomock.Controller) *MockVerifier {
+	mock := &MockVerifier{ctrl: ctrl}
+	mock.recorder = &MockVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerifier) EXPECT() *MockVerifierMockRecorder {
+	return m.recorder
+}
+
+// SendEmailVerification mocks base method.
+func (m *MockVerifier) SendEmailVerification(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailVerification", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailVerification indicates an expected call of SendEmailVerification.
+func (mr *MockVerifierMockRecorder) SendEmailVerification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailVerification", reflect.TypeOf((*MockVerifier)(nil).SendEmailVerification), arg0, arg1)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockVerifier) VerifyEmail(arg0 string) (domain.EmailVerifiedEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", arg0)
+	ret0, _ := ret[0].(domain.EmailVerifiedEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockVerifierMockRecorder) VerifyEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockVerifier)(nil).VerifyEmail), arg0)
+}
+
+// MockPasswordResetter is a mock of PasswordResetter interface.
+type MockPasswordResetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetterMockRecorder
+}
+
+// MockPasswordResetterMockRecorder is the mock recorder for MockPasswordResetter.
+type MockPasswordResetterMockRecorder struct {
+	mock *MockPasswordResetter
+}
+
+// NewMockPasswordResetter creates a new mock instance.
+func NewMockPasswordResetter(ctrl *gomock.Controller) *MockPasswordResetter {
+	mock := &MockPasswordResetter{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetter) EXPECT() *MockPasswordResetterMockRecorder {
+	return m.recorder
+}
+
+// ForgotPassword mocks base method.
+func (m *MockPasswordResetter) ForgotPassword(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockPasswordResetterMockRecorder) ForgotPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockPasswordResetter)(nil).ForgotPassword), arg0, arg1)
+}
+
+// ResetPassword mocks base method.
+func (m *MockPasswordResetter) ResetPassword(arg0 domain.ResetPasswordInput) (domain.PasswordResetEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0)
+	ret0, _ := ret[0].(domain.PasswordResetEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockPasswordResetterMockRecorder) ResetPassword(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockPasswordResetter)(nil).ResetPassword), arg0)
+}
+
+// MockTwoFactorer is a mock of TwoFactorer interface.
+type MockTwoFactorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorerMockRecorder
+}
+
+// MockTwoFactorerMockRecorder is the mock recorder for MockTwoFactorer.
+type MockTwoFactorerMockRecorder struct {
+	mock *MockTwoFactorer
+}
+
+// NewMockTwoFactorer creates a new mock instance.
+func NewMockTwoFactorer(ctrl *gomock.Controller) *MockTwoFactorer {
+	mock := &MockTwoFactorer{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorer) EXPECT() *MockTwoFactorerMockRecorder {
+	return m.recorder
+}
+
+// DisableTwoFactor mocks base method.
+func (m *MockTwoFactorer) DisableTwoFactor(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockTwoFactorerMockRecorder) DisableTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockTwoFactorer)(nil).DisableTwoFactor), arg0, arg1)
+}
+
+// EnableTwoFactor mocks base method.
+func (m *MockTwoFactorer) EnableTwoFactor(arg0, arg1 string) (domain.RecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(domain.RecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableTwoFactor indicates an expected call of EnableTwoFactor.
+func (mr *MockTwoFactorerMockRecorder) EnableTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactor", reflect.TypeOf((*MockTwoFactorer)(nil).EnableTwoFactor), arg0, arg1)
+}
+
+// EnrollTwoFactor mocks base method.
+func (m *MockTwoFactorer) EnrollTwoFactor(arg0 string) (domain.TwoFactorEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTwoFactor", arg0)
+	ret0, _ := ret[0].(domain.TwoFactorEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor.
+func (mr *MockTwoFactorerMockRecorder) EnrollTwoFactor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockTwoFactorer)(nil).EnrollTwoFactor), arg0)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockTwoFactorer) RegenerateRecoveryCodes(arg0, arg1 string) (domain.RecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(domain.RecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockTwoFactorerMockRecorder) RegenerateRecoveryCodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockTwoFactorer)(nil).RegenerateRecoveryCodes), arg0, arg1)
+}
+
+// SignIn mocks base method.
+func (m *MockTwoFactorer) SignIn(arg0, arg1 string, arg2 domain.Client) (domain.SignInResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.SignInResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockTwoFactorerMockRecorder) SignIn(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockTwoFactorer)(nil).SignIn), arg0, arg1, arg2)
+}
+
+// SignInEnroll mocks base method.
+func (m *MockTwoFactorer) SignInEnroll(arg0 string) (domain.TwoFactorEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignInEnroll", arg0)
+	ret0, _ := ret[0].(domain.TwoFactorEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignInEnroll indicates an expected call of SignInEnroll.
+func (mr *MockTwoFactorerMockRecorder) SignInEnroll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignInEnroll", reflect.TypeOf((*MockTwoFactorer)(nil).SignInEnroll), arg0)
+}
+
+// SignInTwoFactor mocks base method.
+func (m *MockTwoFactorer) SignInTwoFactor(arg0 domain.SignInChallengeInput, arg1 domain.Client) (domain.SignInResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignInTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(domain.SignInResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignInTwoFactor indicates an expected call of SignInTwoFactor.
+func (mr *MockTwoFactorerMockRecorder) SignInTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignInTwoFactor", reflect.TypeOf((*MockTwoFactorer)(nil).SignInTwoFactor), arg0, arg1)
+}
+
+// MockAuditor is a mock of Auditor interface.
+type MockAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditorMockRecorder
+}
+
+// MockAuditorMockRecorder is the mock recorder for MockAuditor.
+type MockAuditorMockRecorder struct {
+	mock *MockAuditor
+}
+
+// NewMockAuditor creates a new mock instance.
+func NewMockAuditor(ctrl *gomock.Controller) *MockAuditor {
+	mock := &MockAuditor{ctrl: ctrl}
+	mock.recorder = &MockAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditor) EXPECT() *MockAuditorMockRecorder {
+	return m.recorder
+}
+
+// GetActivity mocks base method.
+func (m *MockAuditor) GetActivity(arg0 string, arg1, arg2 int) ([]domain.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivity", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivity indicates an expected call of GetActivity.
+func (mr *MockAuditorMockRecorder) GetActivity(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivity", reflect.TypeOf((*MockAuditor)(nil).GetActivity), arg0, arg1, arg2)
+}
+
+// RecordAudit mocks base method.
+func (m *MockAuditor) RecordAudit(arg0 domain.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAudit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAudit indicates an expected call of RecordAudit.
+func (mr *MockAuditorMockRecorder) RecordAudit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAudit", reflect.TypeOf((*MockAuditor)(nil).RecordAudit), arg0)
+}
+
+// SearchAudit mocks base method.
+func (m *MockAuditor) SearchAudit(arg0 domain.AuditFilter) ([]domain.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAudit", arg0)
+	ret0, _ := ret[0].([]domain.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAudit indicates an expected call of SearchAudit.
+func (mr *MockAuditorMockRecorder) SearchAudit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAudit", reflect.TypeOf((*MockAuditor)(nil).SearchAudit), arg0)
+}
+
+// MockSessioner is a mock of Sessioner interface.
+type MockSessioner struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionerMockRecorder
+}
+
+// MockSessionerMockRecorder is the mock recorder for MockSessioner.
+type MockSessionerMockRecorder struct {
+	mock *MockSessioner
+}
+
+// NewMockSessioner creates a new mock instance.
+func NewMockSessioner(ctrl *gomock.Controller) *MockSessioner {
+	mock := &MockSessioner{ctrl: ctrl}
+	mock.recorder = &MockSessionerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessioner) EXPECT() *MockSessionerMockRecorder {
+	return m.recorder
+}
+
+// GetSessions mocks base method.
+func (m *MockSessioner) GetSessions(arg0, arg1 string) ([]domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessions", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockSessionerMockRecorder) GetSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockSessioner)(nil).GetSessions), arg0, arg1)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockSessioner) RevokeOtherSessions(arg0, arg1 string) (domain.SessionsRevokedEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", arg0, arg1)
+	ret0, _ := ret[0].(domain.SessionsRevokedEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockSessionerMockRecorder) RevokeOtherSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockSessioner)(nil).RevokeOtherSessions), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessioner) RevokeSession(arg0 string, arg1 uuid.UUID) (domain.SessionsRevokedEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1)
+	ret0, _ := ret[0].(domain.SessionsRevokedEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionerMockRecorder) RevokeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessioner)(nil).RevokeSession), arg0, arg1)
+}
+
+// MockPrivacier is a mock of Privacier interface.
+type MockPrivacier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacierMockRecorder
+}
+
+// MockPrivacierMockRecorder is the mock recorder for MockPrivacier.
+type MockPrivacierMockRecorder struct {
+	mock *MockPrivacier
+}
+
+// NewMockPrivacier creates a new mock instance.
+func NewMockPrivacier(ctrl *gomock.Controller) *MockPrivacier {
+	mock := &MockPrivacier{ctrl: ctrl}
+	mock.recorder = &MockPrivacierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacier) EXPECT() *MockPrivacierMockRecorder {
+	return m.recorder
+}
+
+// AnswerPrivacyRequest mocks base method.
+func (m *MockPrivacier) AnswerPrivacyRequest(arg0 domain.PrivacyKind, arg1 domain.PrivacyAnsweredEvent) (domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnswerPrivacyRequest", arg0, arg1)
+	ret0, _ := ret[0].(domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnswerPrivacyRequest indicates an expected call of AnswerPrivacyRequest.
+func (mr *MockPrivacierMockRecorder) AnswerPrivacyRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnswerPrivacyRequest", reflect.TypeOf((*MockPrivacier)(nil).AnswerPrivacyRequest), arg0, arg1)
+}
+
+// EraseAccount mocks base method.
+func (m *MockPrivacier) EraseAccount(arg0 string) (domain.AccountErased, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseAccount", arg0)
+	ret0, _ := ret[0].(domain.AccountErased)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseAccount indicates an expected call of EraseAccount.
+func (mr *MockPrivacierMockRecorder) EraseAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseAccount", reflect.TypeOf((*MockPrivacier)(nil).EraseAccount), arg0)
+}
+
+// GetErasure mocks base method.
+func (m *MockPrivacier) GetErasure(arg0 uuid.UUID) (domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErasure", arg0)
+	ret0, _ := ret[0].(domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetErasure indicates an expected call of GetErasure.
+func (mr *MockPrivacierMockRecorder) GetErasure(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErasure", reflect.TypeOf((*MockPrivacier)(nil).GetErasure), arg0)
+}
+
+// GetErasures mocks base method.
+func (m *MockPrivacier) GetErasures(arg0 domain.PrivacyFilter) ([]domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErasures", arg0)
+	ret0, _ := ret[0].([]domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetErasures indicates an expected call of GetErasures.
+func (mr *MockPrivacierMockRecorder) GetErasures(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErasures", reflect.TypeOf((*MockPrivacier)(nil).GetErasures), arg0)
+}
+
+// GetExport mocks base method.
+func (m *MockPrivacier) GetExport(arg0 string, arg1 uuid.UUID) (domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", arg0, arg1)
+	ret0, _ := ret[0].(domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockPrivacierMockRecorder) GetExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockPrivacier)(nil).GetExport), arg0, arg1)
+}
+
+// RequestExport mocks base method.
+func (m *MockPrivacier) RequestExport(arg0 string) (domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestExport", arg0)
+	ret0, _ := ret[0].(domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestExport indicates an expected call of RequestExport.
+func (mr *MockPrivacierMockRecorder) RequestExport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestExport", reflect.TypeOf((*MockPrivacier)(nil).RequestExport), arg0)
+}
+
+// MockOAuther is a mock of OAuther interface.
+type MockOAuther struct {
+	ctrl     *gomock.Controller
+	recorder *MockOAutherMockRecorder
+}
+
+// MockOAutherMockRecorder is the mock recorder for MockOAuther.
+type MockOAutherMockRecorder struct {
+	mock *MockOAuther
+}
+
+// NewMockOAuther creates a new mock instance.
+func NewMockOAuther(ctrl *gomock.Controller) *MockOAuther {
+	mock := &MockOAuther{ctrl: ctrl}
+	mock.recorder = &MockOAutherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOAuther) EXPECT() *MockOAutherMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockOAuther) Authorize(arg0 string, arg1 domain.ConsentInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockOAutherMockRecorder) Authorize(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockOAuther)(nil).Authorize), arg0, arg1)
+}
+
+// Consent mocks base method.
+func (m *MockOAuther) Consent(arg0 string, arg1 domain.AuthorizeInput) (domain.ConsentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consent", arg0, arg1)
+	ret0, _ := ret[0].(domain.ConsentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consent indicates an expected call of Consent.
+func (mr *MockOAutherMockRecorder) Consent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consent", reflect.TypeOf((*MockOAuther)(nil).Consent), arg0, arg1)
+}
+
+// CreateOAuthClient mocks base method.
+func (m *MockOAuther) CreateOAuthClient(arg0 domain.CreateOAuthClientInput) (domain.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthClient", arg0)
+	ret0, _ := ret[0].(domain.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOAuthClient indicates an expected call of CreateOAuthClient.
+func (mr *MockOAutherMockRecorder) CreateOAuthClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthClient", reflect.TypeOf((*MockOAuther)(nil).CreateOAuthClient), arg0)
+}
+
+// DeleteOAuthClient mocks base method.
+func (m *MockOAuther) DeleteOAuthClient(arg0 string) (domain.OAuthClientDeleted, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuthClient", arg0)
+	ret0, _ := ret[0].(domain.OAuthClientDeleted)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOAuthClient indicates an expected call of DeleteOAuthClient.
+func (mr *MockOAutherMockRecorder) DeleteOAuthClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuthClient", reflect.TypeOf((*MockOAuther)(nil).DeleteOAuthClient), arg0)
+}
+
+// Discovery mocks base method.
+func (m *MockOAuther) Discovery() domain.OIDCDiscovery {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Discovery")
+	ret0, _ := ret[0].(domain.OIDCDiscovery)
+	return ret0
+}
+
+// Discovery indicates an expected call of Discovery.
+func (mr *MockOAutherMockRecorder) Discovery() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discovery", reflect.TypeOf((*MockOAuther)(nil).Discovery))
+}
+
+// GetOAuthClient mocks base method.
+func (m *MockOAuther) GetOAuthClient(arg0 string) (domain.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthClient", arg0)
+	ret0, _ := ret[0].(domain.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthClient indicates an expected call of GetOAuthClient.
+func (mr *MockOAutherMockRecorder) GetOAuthClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthClient", reflect.TypeOf((*MockOAuther)(nil).GetOAuthClient), arg0)
+}
+
+// GetOAuthClients mocks base method.
+func (m *MockOAuther) GetOAuthClients() ([]domain.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthClients")
+	ret0, _ := ret[0].([]domain.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthClients indicates an expected call of GetOAuthClients.
+func (mr *MockOAutherMockRecorder) GetOAuthClients() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthClients", reflect.TypeOf((*MockOAuther)(nil).GetOAuthClients))
+}
+
+// JWKS mocks base method.
+func (m *MockOAuther) JWKS() domain.JSONWebKeySet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(domain.JSONWebKeySet)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockOAutherMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockOAuther)(nil).JWKS))
+}
+
+// Token mocks base method.
+func (m *MockOAuther) Token(arg0 domain.TokenInput, arg1 domain.Client) (domain.TokenResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token", arg0, arg1)
+	ret0, _ := ret[0].(domain.TokenResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockOAutherMockRecorder) Token(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockOAuther)(nil).Token), arg0, arg1)
+}
+
+// UserInfo mocks base method.
+func (m *MockOAuther) UserInfo(arg0, arg1 string) (domain.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserInfo", arg0, arg1)
+	ret0, _ := ret[0].(domain.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserInfo indicates an expected call of UserInfo.
+func (mr *MockOAutherMockRecorder) UserInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserInfo", reflect.TypeOf((*MockOAuther)(nil).UserInfo), arg0, arg1)
+}
+
+// MockAPIKeyer is a mock of APIKeyer interface.
+type MockAPIKeyer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyerMockRecorder
+}
+
+// MockAPIKeyerMockRecorder is the mock recorder for MockAPIKeyer.
+type MockAPIKeyerMockRecorder struct {
+	mock *MockAPIKeyer
+}
+
+// NewMockAPIKeyer creates a new mock instance.
+func NewMockAPIKeyer(ctrl *gomock.Controller) *MockAPIKeyer {
+	mock := &MockAPIKeyer{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyer) EXPECT() *MockAPIKeyerMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyer) CreateAPIKey(arg0 string, arg1 domain.CreateAPIKeyInput) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyerMockRecorder) CreateAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyer)(nil).CreateAPIKey), arg0, arg1)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockAPIKeyer) GetAPIKeys(arg0 string) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", arg0)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockAPIKeyerMockRecorder) GetAPIKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockAPIKeyer)(nil).GetAPIKeys), arg0)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyer) RevokeAPIKey(arg0, arg1 string) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyerMockRecorder) RevokeAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyer)(nil).RevokeAPIKey), arg0, arg1)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockAPIKeyer) TouchAPIKey(arg0 domain.APIKeyUsedInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockAPIKeyerMockRecorder) TouchAPIKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockAPIKeyer)(nil).TouchAPIKey), arg0)
+}
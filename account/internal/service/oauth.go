@@ -0,0 +1,640 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/config"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/repository"
+)
+
+const (
+	// OAUTH_CODE_SIZE - random bytes of the authorization code
+	OAUTH_CODE_SIZE = 32
+	// OAUTH_SECRET_SIZE - random bytes of the client secret
+	OAUTH_SECRET_SIZE = 32
+	// OIDC_KEY_BITS - size of the rsa key generated without the key file
+	OIDC_KEY_BITS = 2048
+	// OAUTH_AUDIENCE - audience of the client access token, only the userinfo accepts it
+	OAUTH_AUDIENCE = "oauth"
+)
+
+// OIDC_SCOPES - supported scopes, the claims of the userinfo and the id token depend on them
+var OIDC_SCOPES = []string{domain.SCOPE_OPENID, domain.SCOPE_PROFILE, domain.SCOPE_EMAIL, domain.SCOPE_ROLE}
+
+var _ OAuther = (*OAuthService)(nil)
+
+// OAuther - service interface
+type OAuther interface {
+	Discovery() domain.OIDCDiscovery
+	JWKS() domain.JSONWebKeySet
+	Consent(accountPublicId string, input domain.AuthorizeInput) (domain.ConsentRequest, error)
+	Authorize(accountPublicId string, input domain.ConsentInput) (string, error)
+	Token(input domain.TokenInput, client domain.Client) (domain.TokenResult, error)
+	UserInfo(accountPublicId, sessionPublicId string) (domain.UserInfo, error)
+	CreateOAuthClient(input domain.CreateOAuthClientInput) (domain.OAuthClient, error)
+	GetOAuthClients() ([]domain.OAuthClient, error)
+	GetOAuthClient(clientId string) (domain.OAuthClient, error)
+	DeleteOAuthClient(clientId string) (domain.OAuthClientDeleted, error)
+}
+
+// OAuthService - OpenID Connect provider with the authorization code (pkce) and the client credentials grants.
+// Every token is the access token of a new session, it is revoked like the signed-in devices. Its oauth audience
+// and scope keep it off the account routes and the other services. The id tokens are signed by the rsa key
+// published as jwks
+type OAuthService struct {
+	repo       repository.OAuther
+	accounts   repository.Accounter
+	sessions   repository.Sessioner
+	key        *rsa.PrivateKey
+	keyId      string
+	issuer     string
+	codeTTL    time.Duration
+	tokenTTL   time.Duration
+	signingKey string
+}
+
+// NewOAuthService - constructor
+func NewOAuthService(repo repository.OAuther, accounts repository.Accounter, sessions repository.Sessioner,
+	key *rsa.PrivateKey, auth *config.Auth, config *config.OIDC) *OAuthService {
+	return &OAuthService{
+		repo:       repo,
+		accounts:   accounts,
+		sessions:   sessions,
+		key:        key,
+		keyId:      oidcKeyId(&key.PublicKey),
+		issuer:     strings.TrimSuffix(config.Issuer, "/"),
+		codeTTL:    time.Duration(config.CodeTTL) * time.Second,
+		tokenTTL:   time.Duration(auth.TokenTTL * 1000000),
+		signingKey: auth.SigningKey,
+	}
+}
+
+// oauthClaims - the access token of the client session with the granted scope
+type oauthClaims struct {
+	Scope string `json:"scope"`
+	jwt.StandardClaims
+}
+
+// authorization - the checked authorization request of the account
+type authorization struct {
+	client      domain.OAuthClient
+	account     domain.Account
+	redirectURI string
+	scope       string
+}
+
+// LoadOIDCKey - the pem rsa private key of the file, a new one without the file
+func LoadOIDCKey(keyFile string) (*rsa.PrivateKey, error) {
+	if keyFile == "" {
+		return rsa.GenerateKey(rand.Reader, OIDC_KEY_BITS)
+	}
+	data, err := os.ReadFile(keyFile) // #nosec
+	if err != nil {
+		return nil, fmt.Errorf("read oidc key: %w", err)
+	}
+	return jwt.ParseRSAPrivateKeyFromPEM(data)
+}
+
+// Discovery - the provider metadata, the endpoints are under the issuer
+func (s *OAuthService) Discovery() domain.OIDCDiscovery {
+	return domain.OIDCDiscovery{
+		Issuer:                            s.issuer,
+		AuthorizationEndpoint:             s.issuer + "/oauth/authorize",
+		TokenEndpoint:                     s.issuer + "/oauth/token",
+		UserInfoEndpoint:                  s.issuer + "/oauth/userinfo",
+		JWKSURI:                           s.issuer + "/.well-known/jwks.json",
+		ScopesSupported:                   OIDC_SCOPES,
+		ResponseTypesSupported:            []string{domain.RESPONSE_TYPE_CODE},
+		GrantTypesSupported:               []string{domain.GRANT_AUTHORIZATION_CODE, domain.GRANT_CLIENT_CREDENTIALS},
+		SubjectTypesSupported:             []string{"public"},
+		IdTokenSigningAlgValuesSupported:  []string{jwt.SigningMethodRS256.Alg()},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{domain.PKCE_S256},
+		ClaimsSupported: []string{"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce",
+			"name", "email", "email_verified", "role"},
+	}
+}
+
+// JWKS - the public key of the id tokens
+func (s *OAuthService) JWKS() domain.JSONWebKeySet {
+	return domain.JSONWebKeySet{Keys: []domain.JSONWebKey{{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: jwt.SigningMethodRS256.Alg(),
+		KeyId:     s.keyId,
+		Modulus:   base64.RawURLEncoding.EncodeToString(s.key.PublicKey.N.Bytes()),
+		Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.PublicKey.E)).Bytes()),
+	}}}
+}
+
+// Consent - the client and the scopes to show the account before it approves them
+func (s *OAuthService) Consent(accountPublicId string, input domain.AuthorizeInput) (domain.ConsentRequest, error) {
+	auth, err := s.checkAuthorization(accountPublicId, input)
+	if err != nil {
+		return domain.ConsentRequest{}, err
+	}
+	return domain.ConsentRequest{
+		ClientId:    auth.client.ClientId,
+		ClientName:  auth.client.Name,
+		RedirectURI: auth.redirectURI,
+		Scopes:      strings.Fields(auth.scope),
+	}, nil
+}
+
+// Authorize - the redirect location with the code for the account approved the consent, access denied
+// without the approval
+func (s *OAuthService) Authorize(accountPublicId string, input domain.ConsentInput) (string, error) {
+	auth, err := s.checkAuthorization(accountPublicId, input.AuthorizeInput)
+	if err != nil {
+		return "", err
+	}
+	if !input.Approve {
+		return "", &domain.OAuthError{Code: domain.OAUTH_ACCESS_DENIED, Description: "consent is not given",
+			RedirectURI: auth.redirectURI, State: input.State}
+	}
+
+	code, err := randomToken(OAUTH_CODE_SIZE)
+	if err != nil {
+		return "", fmt.Errorf("generate authorization code: %w", err)
+	}
+	now := time.Now().UTC()
+	err = s.repo.CreateAuthorizationCode(domain.AuthorizationCode{
+		CodeHash:        hashToken(code),
+		ClientId:        auth.client.ClientId,
+		AccountPublicId: auth.account.PublicId,
+		RedirectURI:     auth.redirectURI,
+		Scope:           auth.scope,
+		Nonce:           input.Nonce,
+		CodeChallenge:   input.CodeChallenge,
+		ExpiresAt:       now.Add(s.codeTTL),
+		CreatedAt:       now,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	location, err := url.Parse(auth.redirectURI)
+	if err != nil {
+		return "", err
+	}
+	query := location.Query()
+	query.Set("code", code)
+	if input.State != "" {
+		query.Set("state", input.State)
+	}
+	location.RawQuery = query.Encode()
+	return location.String(), nil
+}
+
+// Token - by the grant type, the client is authenticated by its secret, the public one by the pkce verifier
+func (s *OAuthService) Token(input domain.TokenInput, client domain.Client) (domain.TokenResult, error) {
+	switch input.GrantType {
+	case domain.GRANT_AUTHORIZATION_CODE:
+		return s.exchangeCode(input, client)
+	case domain.GRANT_CLIENT_CREDENTIALS:
+		return s.clientCredentials(input, client)
+	}
+	return domain.TokenResult{}, &domain.OAuthError{Code: domain.OAUTH_UNSUPPORTED_GRANT_TYPE}
+}
+
+// UserInfo - the claims allowed by the scope of the session, the sign-in session allows all of them
+func (s *OAuthService) UserInfo(accountPublicId, sessionPublicId string) (domain.UserInfo, error) {
+	publicId, err := uuid.Parse(sessionPublicId)
+	if err != nil {
+		return domain.UserInfo{}, domain.ErrSessionNotFound
+	}
+	session, err := s.sessions.GetSession(publicId)
+	if err != nil {
+		return domain.UserInfo{}, err
+	}
+	if session.ClientId != "" && !contains(strings.Fields(session.Scope), domain.SCOPE_OPENID) {
+		return domain.UserInfo{}, &domain.OAuthError{Code: domain.OAUTH_INSUFFICIENT_SCOPE,
+			Description: "openid scope is required"}
+	}
+	account, err := s.accounts.GetAccount(accountPublicId)
+	if err != nil {
+		return domain.UserInfo{}, err
+	}
+	return userInfo(account, session.Scope), nil
+}
+
+// CreateOAuthClient - the secret of the confidential client is in the result only
+func (s *OAuthService) CreateOAuthClient(input domain.CreateOAuthClientInput) (domain.OAuthClient, error) {
+	if err := s.validateClient(&input); err != nil {
+		return domain.OAuthClient{}, err
+	}
+
+	client := domain.OAuthClient{
+		ClientId:        uuid.New().String(),
+		Name:            input.Name,
+		Public:          input.Public,
+		RedirectURIs:    input.RedirectURIs,
+		GrantTypes:      input.GrantTypes,
+		Scopes:          input.Scopes,
+		Roles:           input.Roles,
+		AccountPublicId: input.AccountPublicId,
+		CreatedAt:       time.Now().UTC(),
+	}
+	if !client.Public {
+		secret, err := randomToken(OAUTH_SECRET_SIZE)
+		if err != nil {
+			return domain.OAuthClient{}, fmt.Errorf("generate client secret: %w", err)
+		}
+		client.Secret = secret
+		client.SecretHash = hashToken(secret)
+	}
+	if err := s.repo.CreateOAuthClient(client); err != nil {
+		return domain.OAuthClient{}, err
+	}
+	return client, nil
+}
+
+// GetOAuthClients
+func (s *OAuthService) GetOAuthClients() ([]domain.OAuthClient, error) {
+	return s.repo.GetOAuthClients()
+}
+
+// GetOAuthClient
+func (s *OAuthService) GetOAuthClient(clientId string) (domain.OAuthClient, error) {
+	return s.repo.GetOAuthClient(clientId)
+}
+
+// DeleteOAuthClient - the tokens of the client stop working, the revoked sessions are grouped by the account
+func (s *OAuthService) DeleteOAuthClient(clientId string) (domain.OAuthClientDeleted, error) {
+	now := time.Now().UTC()
+	client, sessions, err := s.repo.DeleteOAuthClient(clientId, now)
+	if err != nil {
+		return domain.OAuthClientDeleted{}, err
+	}
+
+	deleted := domain.OAuthClientDeleted{Client: client, Sessions: make([]domain.SessionsRevokedEvent, 0)}
+	accounts := make([]uuid.UUID, 0)
+	byAccount := make(map[uuid.UUID][]domain.Session)
+	for _, session := range sessions {
+		if _, ok := byAccount[session.AccountPublicId]; !ok {
+			accounts = append(accounts, session.AccountPublicId)
+		}
+		byAccount[session.AccountPublicId] = append(byAccount[session.AccountPublicId], session)
+	}
+	for _, accountPublicId := range accounts {
+		deleted.Sessions = append(deleted.Sessions, sessionsRevoked(accountPublicId, byAccount[accountPublicId], now))
+	}
+	return deleted, nil
+}
+
+// checkAuthorization - the client, the redirect uri, the pkce, the scope and the account role.
+// The unknown client and the redirect uri not registered are not redirected, the other errors are
+func (s *OAuthService) checkAuthorization(accountPublicId string, input domain.AuthorizeInput) (authorization, error) {
+	client, err := s.repo.GetOAuthClient(input.ClientId)
+	if errors.Is(err, domain.ErrOAuthClientNotFound) {
+		return authorization{}, &domain.OAuthError{Code: domain.OAUTH_INVALID_REQUEST, Description: "unknown client"}
+	}
+	if err != nil {
+		return authorization{}, err
+	}
+	redirectURI := input.RedirectURI
+	if redirectURI == "" && len(client.RedirectURIs) == 1 {
+		redirectURI = client.RedirectURIs[0]
+	}
+	if !contains(client.RedirectURIs, redirectURI) {
+		return authorization{}, &domain.OAuthError{Code: domain.OAUTH_INVALID_REQUEST,
+			Description: "redirect uri is not registered"}
+	}
+
+	fail := func(code, description string) error {
+		return &domain.OAuthError{Code: code, Description: description, RedirectURI: redirectURI, State: input.State}
+	}
+	if input.ResponseType != domain.RESPONSE_TYPE_CODE {
+		return authorization{}, fail(domain.OAUTH_UNSUPPORTED_RESPONSE_TYPE, "only code response type is supported")
+	}
+	if !contains(client.GrantTypes, domain.GRANT_AUTHORIZATION_CODE) {
+		return authorization{}, fail(domain.OAUTH_UNAUTHORIZED_CLIENT, "authorization code grant is not allowed")
+	}
+	if input.CodeChallenge == "" || input.CodeChallengeMethod != domain.PKCE_S256 {
+		return authorization{}, fail(domain.OAUTH_INVALID_REQUEST, "S256 code challenge is required")
+	}
+	scope, ok := grantedScope(input.Scope, client.Scopes)
+	if !ok {
+		return authorization{}, fail(domain.OAUTH_INVALID_SCOPE, "scope is not allowed for the client")
+	}
+	account, err := s.accounts.GetAccount(accountPublicId)
+	if err != nil {
+		return authorization{}, err
+	}
+	if !roleAllowed(client.Roles, account.Role) {
+		return authorization{}, fail(domain.OAUTH_ACCESS_DENIED, "account role is not allowed for the client")
+	}
+	return authorization{client: client, account: account, redirectURI: redirectURI, scope: scope}, nil
+}
+
+// exchangeCode - the code works once, for the client, the redirect uri and the verifier it was issued for
+func (s *OAuthService) exchangeCode(input domain.TokenInput, client domain.Client) (domain.TokenResult, error) {
+	oauthClient, err := s.authenticateClient(input)
+	if err != nil {
+		return domain.TokenResult{}, err
+	}
+	if !contains(oauthClient.GrantTypes, domain.GRANT_AUTHORIZATION_CODE) {
+		return domain.TokenResult{}, &domain.OAuthError{Code: domain.OAUTH_UNAUTHORIZED_CLIENT}
+	}
+
+	invalidGrant := &domain.OAuthError{Code: domain.OAUTH_INVALID_GRANT, Description: domain.ErrInvalidOAuthGrant.Error()}
+	code, err := s.repo.UseAuthorizationCode(hashToken(input.Code), time.Now().UTC())
+	if errors.Is(err, domain.ErrInvalidOAuthGrant) {
+		return domain.TokenResult{}, invalidGrant
+	}
+	if err != nil {
+		return domain.TokenResult{}, err
+	}
+	if code.ClientId != oauthClient.ClientId || code.RedirectURI != input.RedirectURI ||
+		!verifyCodeChallenge(code.CodeChallenge, input.CodeVerifier) {
+		return domain.TokenResult{}, invalidGrant
+	}
+
+	account, err := s.accounts.GetAccount(code.AccountPublicId.String())
+	if err != nil {
+		return domain.TokenResult{}, invalidGrant
+	}
+	if account.TokensValidAfter != nil && code.CreatedAt.Before(*account.TokensValidAfter) ||
+		!roleAllowed(oauthClient.Roles, account.Role) {
+		return domain.TokenResult{}, invalidGrant
+	}
+	return s.issueTokens(account, oauthClient, code.Scope, code.Nonce, client)
+}
+
+// clientCredentials - the confidential client gets the token of its account, there is no id token
+func (s *OAuthService) clientCredentials(input domain.TokenInput, client domain.Client) (domain.TokenResult, error) {
+	oauthClient, err := s.authenticateClient(input)
+	if err != nil {
+		return domain.TokenResult{}, err
+	}
+	if oauthClient.Public || oauthClient.AccountPublicId == nil ||
+		!contains(oauthClient.GrantTypes, domain.GRANT_CLIENT_CREDENTIALS) {
+		return domain.TokenResult{}, &domain.OAuthError{Code: domain.OAUTH_UNAUTHORIZED_CLIENT}
+	}
+
+	scopes := make([]string, 0, len(oauthClient.Scopes))
+	for _, scope := range oauthClient.Scopes {
+		if scope != domain.SCOPE_OPENID {
+			scopes = append(scopes, scope)
+		}
+	}
+	scope, ok := grantedScope(input.Scope, scopes)
+	if !ok {
+		return domain.TokenResult{}, &domain.OAuthError{Code: domain.OAUTH_INVALID_SCOPE}
+	}
+	account, err := s.accounts.GetAccount(oauthClient.AccountPublicId.String())
+	if err != nil {
+		return domain.TokenResult{}, &domain.OAuthError{Code: domain.OAUTH_UNAUTHORIZED_CLIENT,
+			Description: "client account not found"}
+	}
+	return s.issueTokens(account, oauthClient, scope, "", client)
+}
+
+// authenticateClient - by the secret, the public client by its id only
+func (s *OAuthService) authenticateClient(input domain.TokenInput) (domain.OAuthClient, error) {
+	invalidClient := &domain.OAuthError{Code: domain.OAUTH_INVALID_CLIENT}
+	client, err := s.repo.GetOAuthClient(input.ClientId)
+	if errors.Is(err, domain.ErrOAuthClientNotFound) {
+		return client, invalidClient
+	}
+	if err != nil {
+		return client, err
+	}
+	if client.Public {
+		return client, nil
+	}
+	if input.ClientSecret == "" ||
+		subtle.ConstantTimeCompare([]byte(hashToken(input.ClientSecret)), []byte(client.SecretHash)) != 1 {
+		return client, invalidClient
+	}
+	return client, nil
+}
+
+// issueTokens - the oauth access token of the new session of the client, the id token for the openid scope
+func (s *OAuthService) issueTokens(account domain.Account, oauthClient domain.OAuthClient, scope, nonce string,
+	client domain.Client) (domain.TokenResult, error) {
+	session := newSession(account.PublicId, client, s.tokenTTL)
+	session.Device = oauthClient.Name
+	session.ClientId = oauthClient.ClientId
+	session.Scope = scope
+	if err := s.sessions.CreateSession(session); err != nil {
+		return domain.TokenResult{}, fmt.Errorf("create session: %w", err)
+	}
+	accessToken, err := generateOAuthToken(session, scope, s.signingKey)
+	if err != nil {
+		return domain.TokenResult{}, err
+	}
+
+	result := domain.TokenResult{
+		AccountPublicId: account.PublicId,
+		ClientId:        oauthClient.ClientId,
+		AccessToken:     accessToken,
+		TokenType:       domain.TOKEN_TYPE_BEARER,
+		ExpiresIn:       int64(session.ExpiresAt.Sub(session.CreatedAt).Seconds()),
+		Scope:           scope,
+	}
+	if contains(strings.Fields(scope), domain.SCOPE_OPENID) {
+		result.IdToken, err = s.generateIdToken(account, oauthClient.ClientId, scope, nonce, session)
+	}
+	return result, err
+}
+
+// generateOAuthToken - the access token of the client session. It has the oauth audience, so ParseToken
+// of the services doesn't accept it, and the scope of the userinfo claims
+func generateOAuthToken(session domain.Session, scope, signingKey string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, oauthClaims{
+		Scope: scope,
+		StandardClaims: jwt.StandardClaims{
+			Id:        session.PublicId.String(),
+			Subject:   session.AccountPublicId.String(),
+			Audience:  OAUTH_AUDIENCE,
+			IssuedAt:  session.CreatedAt.Unix(),
+			ExpiresAt: session.ExpiresAt.Unix(),
+		},
+	})
+	return token.SignedString([]byte(signingKey))
+}
+
+// generateIdToken - rs256 signed, the key id is in the header
+func (s *OAuthService) generateIdToken(account domain.Account, clientId, scope, nonce string,
+	session domain.Session) (string, error) {
+	claims := jwt.MapClaims{
+		"iss":       s.issuer,
+		"sub":       account.PublicId.String(),
+		"aud":       clientId,
+		"iat":       session.CreatedAt.Unix(),
+		"exp":       session.ExpiresAt.Unix(),
+		"auth_time": session.CreatedAt.Unix(),
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	info := userInfo(account, scope)
+	if info.Name != "" {
+		claims["name"] = info.Name
+	}
+	if info.Email != "" {
+		claims["email"] = info.Email
+	}
+	if info.EmailVerified != nil {
+		claims["email_verified"] = *info.EmailVerified
+	}
+	if info.Role != nil {
+		claims["role"] = *info.Role
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.keyId
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("sign id token: %w", err)
+	}
+	return signed, nil
+}
+
+// validateClient - the grant types, redirect uris, scopes, roles and the account of the client.
+// The openid scope is added for the authorization code
+func (s *OAuthService) validateClient(input *domain.CreateOAuthClientInput) error {
+	if len(input.GrantTypes) == 0 {
+		return fmt.Errorf("%w: grant types are required", domain.ErrInvalidOAuthClient)
+	}
+	for _, grantType := range input.GrantTypes {
+		switch grantType {
+		case domain.GRANT_AUTHORIZATION_CODE:
+			if len(input.RedirectURIs) == 0 {
+				return fmt.Errorf("%w: redirect uris are required", domain.ErrInvalidOAuthClient)
+			}
+			if !contains(input.Scopes, domain.SCOPE_OPENID) {
+				input.Scopes = append([]string{domain.SCOPE_OPENID}, input.Scopes...)
+			}
+		case domain.GRANT_CLIENT_CREDENTIALS:
+			if input.Public || input.AccountPublicId == nil {
+				return fmt.Errorf("%w: client credentials are for the confidential client with the account",
+					domain.ErrInvalidOAuthClient)
+			}
+			if _, err := s.accounts.GetAccount(input.AccountPublicId.String()); err != nil {
+				return fmt.Errorf("%w: account not found", domain.ErrInvalidOAuthClient)
+			}
+		default:
+			return fmt.Errorf("%w: unsupported grant type %s", domain.ErrInvalidOAuthClient, grantType)
+		}
+	}
+	for _, redirectURI := range input.RedirectURIs {
+		location, err := url.Parse(redirectURI)
+		if err != nil || location.Scheme == "" || location.Fragment != "" || strings.ContainsAny(redirectURI, " \t") {
+			return fmt.Errorf("%w: invalid redirect uri %s", domain.ErrInvalidOAuthClient, redirectURI)
+		}
+	}
+	for _, scope := range input.Scopes {
+		if !contains(OIDC_SCOPES, scope) {
+			return fmt.Errorf("%w: unsupported scope %s", domain.ErrInvalidOAuthClient, scope)
+		}
+	}
+	for _, role := range input.Roles {
+		if role < domain.ROLE_CUSTOMER || role > domain.ROLE_DEALER {
+			return fmt.Errorf("%w: unknown role %d", domain.ErrInvalidOAuthClient, role)
+		}
+	}
+	if input.Roles == nil {
+		input.Roles = make([]domain.Role, 0)
+	}
+	if input.RedirectURIs == nil {
+		input.RedirectURIs = make([]string, 0)
+	}
+	return nil
+}
+
+// userInfo - the claims of the scope, the empty scope of the sign-in session allows all of them
+func userInfo(account domain.Account, scope string) domain.UserInfo {
+	scopes := strings.Fields(scope)
+	all := scope == ""
+	info := domain.UserInfo{Subject: account.PublicId.String()}
+	if all || contains(scopes, domain.SCOPE_PROFILE) {
+		info.Name = account.Name
+	}
+	if all || contains(scopes, domain.SCOPE_EMAIL) {
+		info.Email = account.Email
+		info.EmailVerified = &account.EmailVerified
+	}
+	if all || contains(scopes, domain.SCOPE_ROLE) {
+		info.Role = &account.Role
+	}
+	return info
+}
+
+// grantedScope - the requested scopes must be allowed, all allowed ones without the request
+func grantedScope(requested string, allowed []string) (string, bool) {
+	scopes := strings.Fields(requested)
+	if len(scopes) == 0 {
+		return strings.Join(allowed, " "), true
+	}
+	for _, scope := range scopes {
+		if !contains(allowed, scope) {
+			return "", false
+		}
+	}
+	return strings.Join(scopes, " "), true
+}
+
+// verifyCodeChallenge - S256: base64url of the verifier sha256
+func verifyCodeChallenge(challenge, verifier string) bool {
+	if verifier == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// roleAllowed - empty roles allow all of them
+func roleAllowed(roles []domain.Role, role domain.Role) bool {
+	if len(roles) == 0 {
+		return true
+	}
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// randomToken - url safe
+func randomToken(size int) (string, error) {
+	raw := make([]byte, size)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// oidcKeyId - stable for the key, the first bytes of the modulus hash
+func oidcKeyId(key *rsa.PublicKey) string {
+	sum := sha256.Sum256(key.N.Bytes())
+	return hex.EncodeToString(sum[:8])
+}
+
+// contains
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/p12s/furniture-store/account/internal/config"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/mailer"
+	"github.com/p12s/furniture-store/account/internal/repository"
+)
+
+const (
+	// RESET_SUBJECT - password reset mail subject
+	RESET_SUBJECT = "Reset your password"
+	// RESET_TOKEN_SIZE - random bytes of the reset token
+	RESET_TOKEN_SIZE = 32
+)
+
+var _ PasswordResetter = (*PasswordService)(nil)
+
+// PasswordResetter - service interface
+type PasswordResetter interface {
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(input domain.ResetPasswordInput) (domain.PasswordResetEvent, error)
+}
+
+// PasswordService - password reset by a random single-use token sent by mail
+type PasswordService struct {
+	repo           repository.Resetter
+	accounts       repository.Accounter
+	mailer         mailer.Mailer
+	salt           string
+	tokenTTL       time.Duration
+	url            string
+	resendInterval time.Duration
+	maxPerHour     int
+}
+
+// NewPasswordService - constructor
+func NewPasswordService(repo repository.Resetter, accounts repository.Accounter, mailer mailer.Mailer,
+	auth *config.Auth, config *config.Reset) *PasswordService {
+	return &PasswordService{
+		repo:           repo,
+		accounts:       accounts,
+		mailer:         mailer,
+		salt:           auth.Salt,
+		tokenTTL:       time.Duration(config.TokenTTL) * time.Second,
+		url:            config.URL,
+		resendInterval: time.Duration(config.ResendInterval) * time.Second,
+		maxPerHour:     config.MaxPerHour,
+	}
+}
+
+// ForgotPassword - a reset link to every account with the email. Unknown email and throttling
+// are not errors, so the caller can't find out which emails are registered
+func (s *PasswordService) ForgotPassword(ctx context.Context, email string) error {
+	accounts, err := s.accounts.GetByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range accounts {
+		if err := s.sendPasswordReset(ctx, account); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendPasswordReset - throttled by the interval and the hourly limit
+func (s *PasswordService) sendPasswordReset(ctx context.Context, account domain.Account) error {
+	now := time.Now().UTC()
+	recent, err := s.repo.CountPasswordResets(account.PublicId, now.Add(-s.resendInterval))
+	if err != nil {
+		return err
+	}
+	hourly, err := s.repo.CountPasswordResets(account.PublicId, now.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if recent > 0 || hourly >= s.maxPerHour {
+		return nil
+	}
+
+	raw := make([]byte, RESET_TOKEN_SIZE)
+	if _, err := rand.Read(raw); err != nil {
+		return fmt.Errorf("generate reset token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	reset := domain.PasswordReset{
+		TokenHash:       hashToken(token),
+		AccountPublicId: account.PublicId,
+		ExpiresAt:       now.Add(s.tokenTTL),
+		CreatedAt:       now,
+	}
+	if err := s.repo.CreatePasswordReset(reset); err != nil {
+		return err
+	}
+
+	link := s.url + "?token=" + url.QueryEscape(token)
+	text := fmt.Sprintf("Hello, %s!\n\nReset your password by the link:\n%s\n\n"+
+		"The link works once and expires at %s. If you didn't ask for it, just ignore this mail.\n",
+		account.Name, link, reset.ExpiresAt.Format(time.RFC1123))
+	return s.mailer.Send(ctx, account.Email, RESET_SUBJECT, text)
+}
+
+// ResetPassword - sets the new password, the tokens issued before are not accepted anymore
+// and all the account sessions are revoked
+func (s *PasswordService) ResetPassword(input domain.ResetPasswordInput) (domain.PasswordResetEvent, error) {
+	passwordHash, err := generatePasswordHash(s.salt, input.Password)
+	if err != nil {
+		return domain.PasswordResetEvent{}, fmt.Errorf("generate password: %w", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	accountPublicId, sessions, err := s.repo.ResetPassword(hashToken(input.Token), passwordHash, now)
+	if err != nil {
+		return domain.PasswordResetEvent{}, err
+	}
+
+	return domain.PasswordResetEvent{
+		PublicId: accountPublicId,
+		ResetAt:  now,
+		Sessions: sessionsRevoked(accountPublicId, sessions, now),
+	}, nil
+}
+
+// hashToken - sha256 stored instead of the secret: reset tokens, OAuth codes and client secrets,
+// API keys and recovery codes
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
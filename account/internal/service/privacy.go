@@ -0,0 +1,213 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/config"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/repository"
+)
+
+var _ Privacier = (*PrivacyService)(nil)
+
+// Privacier - service interface
+type Privacier interface {
+	RequestExport(accountPublicId string) (domain.PrivacyRequest, error)
+	GetExport(accountPublicId string, publicId uuid.UUID) (domain.PrivacyRequest, error)
+	EraseAccount(accountPublicId string) (domain.AccountErased, error)
+	GetErasure(publicId uuid.UUID) (domain.PrivacyRequest, error)
+	GetErasures(filter domain.PrivacyFilter) ([]domain.PrivacyRequest, error)
+	AnswerPrivacyRequest(kind domain.PrivacyKind, answer domain.PrivacyAnsweredEvent) (domain.PrivacyRequest, error)
+}
+
+// PrivacyService - personal data export and erasure, every service answers the request by the event
+type PrivacyService struct {
+	repo      repository.Privacier
+	accounts  repository.Accounter
+	addresses repository.Addresser
+	sessions  repository.Sessioner
+	audit     repository.Auditor
+	config    *config.Privacy
+}
+
+// NewPrivacyService - constructor
+func NewPrivacyService(repo repository.Privacier, accounts repository.Accounter, addresses repository.Addresser,
+	sessions repository.Sessioner, audit repository.Auditor, config *config.Privacy) *PrivacyService {
+	return &PrivacyService{
+		repo:      repo,
+		accounts:  accounts,
+		addresses: addresses,
+		sessions:  sessions,
+		audit:     audit,
+		config:    config,
+	}
+}
+
+// RequestExport - the account part is collected at once, the other services answer later
+func (s *PrivacyService) RequestExport(accountPublicId string) (domain.PrivacyRequest, error) {
+	publicId, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return domain.PrivacyRequest{}, err
+	}
+	now := time.Now().UTC()
+
+	data, err := s.accountData(publicId, now)
+	if err != nil {
+		return domain.PrivacyRequest{}, err
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return domain.PrivacyRequest{}, fmt.Errorf("marshal account data: %w", err)
+	}
+
+	request := s.newRequest(publicId, domain.PRIVACY_EXPORT, raw, now)
+	if err := s.repo.CreatePrivacyRequest(request); err != nil {
+		return domain.PrivacyRequest{}, err
+	}
+	return request, nil
+}
+
+// GetExport - of own account only
+func (s *PrivacyService) GetExport(accountPublicId string, publicId uuid.UUID) (domain.PrivacyRequest, error) {
+	request, err := s.repo.GetPrivacyRequest(publicId)
+	if err != nil {
+		return request, err
+	}
+	if request.Kind != domain.PRIVACY_EXPORT || request.AccountPublicId.String() != accountPublicId {
+		return domain.PrivacyRequest{}, domain.ErrPrivacyRequestNotFound
+	}
+	return request, nil
+}
+
+// EraseAccount - the sessions are revoked, the account is deleted and its kept records are anonymized,
+// the other services anonymize their copies later
+func (s *PrivacyService) EraseAccount(accountPublicId string) (domain.AccountErased, error) {
+	publicId, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return domain.AccountErased{}, err
+	}
+	account, err := s.accounts.GetAccount(publicId.String())
+	if err != nil {
+		return domain.AccountErased{}, err
+	}
+	now := time.Now().UTC()
+
+	sessions, err := s.sessions.RevokeSessions(publicId, uuid.Nil, now)
+	if err != nil {
+		return domain.AccountErased{}, err
+	}
+
+	request := s.newRequest(publicId, domain.PRIVACY_ERASURE, nil, now)
+	if err := s.repo.EraseAccount(request, account.Email); err != nil {
+		return domain.AccountErased{}, err
+	}
+	return domain.AccountErased{
+		Request:  request,
+		Sessions: sessionsRevoked(publicId, sessions, now),
+	}, nil
+}
+
+// GetErasure - with the answers of the services
+func (s *PrivacyService) GetErasure(publicId uuid.UUID) (domain.PrivacyRequest, error) {
+	request, err := s.repo.GetPrivacyRequest(publicId)
+	if err != nil {
+		return request, err
+	}
+	if request.Kind != domain.PRIVACY_ERASURE {
+		return domain.PrivacyRequest{}, domain.ErrPrivacyRequestNotFound
+	}
+	return request, nil
+}
+
+// GetErasures - newest first, the page is limited
+func (s *PrivacyService) GetErasures(filter domain.PrivacyFilter) ([]domain.PrivacyRequest, error) {
+	switch filter.Status {
+	case "", domain.PRIVACY_PENDING, domain.PRIVACY_COMPLETED:
+	default:
+		return nil, domain.ErrInvalidPrivacyFilter
+	}
+	if filter.Offset < 0 {
+		return nil, domain.ErrInvalidPrivacyFilter
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = domain.PRIVACY_LIMIT
+	}
+	if filter.Limit > domain.PRIVACY_MAX_LIMIT {
+		filter.Limit = domain.PRIVACY_MAX_LIMIT
+	}
+	return s.repo.GetPrivacyRequests(domain.PRIVACY_ERASURE, filter)
+}
+
+// AnswerPrivacyRequest - the part of the service, the erasure answer has no data
+func (s *PrivacyService) AnswerPrivacyRequest(kind domain.PrivacyKind, answer domain.PrivacyAnsweredEvent) (domain.PrivacyRequest, error) {
+	request, err := s.repo.GetPrivacyRequest(answer.RequestId)
+	if err != nil {
+		return request, err
+	}
+	if request.Kind != kind || request.AccountPublicId != answer.PublicId {
+		return domain.PrivacyRequest{}, domain.ErrPrivacyRequestNotFound
+	}
+
+	part := domain.PrivacyPart{
+		Service:     answer.Service,
+		Data:        answer.Data,
+		CompletedAt: time.Now().UTC(),
+	}
+	if kind == domain.PRIVACY_ERASURE {
+		part.Data = nil
+	}
+	return s.repo.AddPrivacyPart(answer.RequestId, part)
+}
+
+// accountData - the account with the addresses, the active sessions and the audit entries
+func (s *PrivacyService) accountData(publicId uuid.UUID, now time.Time) (domain.AccountData, error) {
+	account, err := s.accounts.GetAccount(publicId.String())
+	if err != nil {
+		return domain.AccountData{}, err
+	}
+	account.Password = ""
+	account.Addresses, err = s.addresses.GetAddresses(publicId)
+	if err != nil {
+		return domain.AccountData{}, err
+	}
+
+	sessions, err := s.sessions.GetSessions(publicId, now)
+	if err != nil {
+		return domain.AccountData{}, err
+	}
+	activity, err := s.audit.GetAuditEntries(domain.AuditFilter{
+		Account: publicId.String(),
+		Limit:   domain.AUDIT_MAX_LIMIT,
+	})
+	if err != nil {
+		return domain.AccountData{}, err
+	}
+
+	return domain.AccountData{
+		Account:  account,
+		Sessions: sessions,
+		Activity: activity,
+	}, nil
+}
+
+// newRequest - the account part is done already, the configured services are pending
+func (s *PrivacyService) newRequest(accountPublicId uuid.UUID, kind domain.PrivacyKind,
+	data json.RawMessage, now time.Time) domain.PrivacyRequest {
+	request := domain.PrivacyRequest{
+		PublicId:        uuid.New(),
+		AccountPublicId: accountPublicId,
+		Kind:            kind,
+		Status:          domain.PRIVACY_PENDING,
+		Services:        append([]string{domain.PRIVACY_SERVICE}, s.config.Services...),
+		Parts:           []domain.PrivacyPart{{Service: domain.PRIVACY_SERVICE, Data: data, CompletedAt: now}},
+		CreatedAt:       now,
+	}
+	if len(s.config.Services) == 0 {
+		request.Status = domain.PRIVACY_COMPLETED
+		request.CompletedAt = &now
+	}
+	return request
+}
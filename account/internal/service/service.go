@@ -1,22 +1,48 @@
 package service
 
 import (
+	"crypto/rsa"
+
 	_ "github.com/golang/mock/mockgen/model"
 
 	"github.com/p12s/furniture-store/account/internal/config"
+	"github.com/p12s/furniture-store/account/internal/mailer"
 	"github.com/p12s/furniture-store/account/internal/repository"
 )
 
-//go:generate mockgen -destination mocks/mock.go -package service github.com/p12s/furniture-store/account/internal/service Accounter
+//go:generate mockgen -destination mocks/mock.go -package service github.com/p12s/furniture-store/account/internal/service Accounter,Addresser,Verifier,PasswordResetter,TwoFactorer,Auditor,Sessioner,Privacier,OAuther,APIKeyer
 
 // Service - just service
 type Service struct {
 	Accounter
+	Addresser
+	Verifier
+	PasswordResetter
+	TwoFactorer
+	Auditor
+	Sessioner
+	Privacier
+	OAuther
+	APIKeyer
 }
 
 // NewService - constructor
-func NewService(repos *repository.Repository, config *config.Auth) *Service {
+func NewService(repos *repository.Repository, mailer mailer.Mailer,
+	config *config.Auth, verify *config.Verify, reset *config.Reset,
+	twoFactor *config.TwoFactor, login *config.Login, privacy *config.Privacy,
+	oidc *config.OIDC, key *rsa.PrivateKey, apiKey *config.APIKey) *Service {
 	return &Service{
-		Accounter: NewAccountService(repos.Accounter, config),
+		Accounter:        NewAccountService(repos.Accounter, repos.Addresser, repos.Sessioner, config),
+		Addresser:        NewAddressService(repos.Addresser),
+		Verifier:         NewVerificationService(repos.Verifier, repos.Accounter, mailer, verify),
+		PasswordResetter: NewPasswordService(repos.Resetter, repos.Accounter, mailer, config, reset),
+		TwoFactorer: NewTwoFactorService(repos.TwoFactorer, repos.Accounter, repos.Sessioner,
+			repos.LoginAttempter, config, twoFactor, login),
+		Auditor:   NewAuditService(repos.Auditor),
+		Sessioner: NewSessionService(repos.Sessioner),
+		Privacier: NewPrivacyService(repos.Privacier, repos.Accounter, repos.Addresser,
+			repos.Sessioner, repos.Auditor, privacy),
+		OAuther:  NewOAuthService(repos.OAuther, repos.Accounter, repos.Sessioner, key, config, oidc),
+		APIKeyer: NewAPIKeyService(repos.APIKeyer, repos.Accounter, apiKey),
 	}
 }
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/repository"
+)
+
+const (
+	// SESSION_TOUCH_INTERVAL - the last seen time is updated not more often
+	SESSION_TOUCH_INTERVAL = time.Minute
+)
+
+var _ Sessioner = (*SessionService)(nil)
+
+// Sessioner - service interface
+type Sessioner interface {
+	GetSessions(accountPublicId, currentSessionId string) ([]domain.Session, error)
+	RevokeSession(accountPublicId string, publicId uuid.UUID) (domain.SessionsRevokedEvent, error)
+	RevokeOtherSessions(accountPublicId, currentSessionId string) (domain.SessionsRevokedEvent, error)
+}
+
+// SessionService - signed-in devices of own account
+type SessionService struct {
+	repo repository.Sessioner
+}
+
+// NewSessionService - constructor
+func NewSessionService(repo repository.Sessioner) *SessionService {
+	return &SessionService{repo: repo}
+}
+
+// GetSessions - active sessions, the current one is marked
+func (s *SessionService) GetSessions(accountPublicId, currentSessionId string) ([]domain.Session, error) {
+	publicId, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.repo.GetSessions(publicId, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].PublicId.String() == currentSessionId
+	}
+	return sessions, nil
+}
+
+// RevokeSession - any active session, the current one too, that is sign-out
+func (s *SessionService) RevokeSession(accountPublicId string, publicId uuid.UUID) (domain.SessionsRevokedEvent, error) {
+	account, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return domain.SessionsRevokedEvent{}, err
+	}
+	now := time.Now().UTC()
+	session, err := s.repo.RevokeSession(account, publicId, now)
+	if err != nil {
+		return domain.SessionsRevokedEvent{}, err
+	}
+	return sessionsRevoked(account, []domain.Session{session}, now), nil
+}
+
+// RevokeOtherSessions - all active sessions except the current one
+func (s *SessionService) RevokeOtherSessions(accountPublicId, currentSessionId string) (domain.SessionsRevokedEvent, error) {
+	account, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return domain.SessionsRevokedEvent{}, err
+	}
+	current, err := uuid.Parse(currentSessionId)
+	if err != nil {
+		return domain.SessionsRevokedEvent{}, domain.ErrSessionNotFound
+	}
+	now := time.Now().UTC()
+	sessions, err := s.repo.RevokeSessions(account, current, now)
+	if err != nil {
+		return domain.SessionsRevokedEvent{}, err
+	}
+	return sessionsRevoked(account, sessions, now), nil
+}
+
+// newSession - the session of the access token being issued
+func newSession(accountPublicId uuid.UUID, client domain.Client, tokenTTL time.Duration) domain.Session {
+	now := time.Now().UTC()
+	return domain.Session{
+		PublicId:        uuid.New(),
+		AccountPublicId: accountPublicId,
+		Device:          sessionDevice(client.UserAgent),
+		UserAgent:       client.UserAgent,
+		IP:              client.IP,
+		CreatedAt:       now,
+		LastSeenAt:      now,
+		ExpiresAt:       now.Add(tokenTTL),
+	}
+}
+
+// sessionsRevoked - the event expires with the latest revoked token
+func sessionsRevoked(accountPublicId uuid.UUID, sessions []domain.Session, now time.Time) domain.SessionsRevokedEvent {
+	event := domain.SessionsRevokedEvent{
+		PublicId:   accountPublicId,
+		SessionIds: make([]uuid.UUID, 0, len(sessions)),
+		RevokedAt:  now,
+	}
+	for _, session := range sessions {
+		event.SessionIds = append(event.SessionIds, session.PublicId)
+		if session.ExpiresAt.After(event.ExpiresAt) {
+			event.ExpiresAt = session.ExpiresAt
+		}
+	}
+	return event
+}
+
+// sessionDevice - readable browser and os by the user agent, the first known names win
+func sessionDevice(userAgent string) string {
+	browsers := []struct{ token, name string }{
+		{"Edg/", "Edge"}, {"OPR/", "Opera"}, {"YaBrowser/", "Yandex Browser"},
+		{"Firefox/", "Firefox"}, {"Chrome/", "Chrome"}, {"Safari/", "Safari"},
+		{"curl/", "curl"}, {"PostmanRuntime/", "Postman"},
+	}
+	systems := []struct{ token, name string }{
+		{"Android", "Android"}, {"iPhone", "iOS"}, {"iPad", "iPadOS"},
+		{"Windows", "Windows"}, {"Mac OS X", "macOS"}, {"Linux", "Linux"},
+	}
+
+	var browser, system string
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+	for _, o := range systems {
+		if strings.Contains(userAgent, o.token) {
+			system = o.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	}
+	return "Unknown device"
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/config"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/repository"
+	"github.com/p12s/furniture-store/account/internal/totp"
+)
+
+const (
+	// CHALLENGE_AUDIENCE - audience of the sign-in challenge token
+	CHALLENGE_AUDIENCE = "sign-in"
+	// RECOVERY_CODE_SIZE - random bytes of a recovery code
+	RECOVERY_CODE_SIZE = 10
+)
+
+var _ TwoFactorer = (*TwoFactorService)(nil)
+
+// TwoFactorer - service interface
+type TwoFactorer interface {
+	SignIn(email, password string, client domain.Client) (domain.SignInResult, error)
+	SignInEnroll(challengeToken string) (domain.TwoFactorEnrollment, error)
+	SignInTwoFactor(input domain.SignInChallengeInput, client domain.Client) (domain.SignInResult, error)
+	EnrollTwoFactor(accountPublicId string) (domain.TwoFactorEnrollment, error)
+	EnableTwoFactor(accountPublicId, code string) (domain.RecoveryCodes, error)
+	DisableTwoFactor(accountPublicId, code string) error
+	RegenerateRecoveryCodes(accountPublicId, code string) (domain.RecoveryCodes, error)
+}
+
+// TwoFactorService - password sign-in with the optional totp step, mandatory for the configured roles.
+// Failed steps are counted by the email and by the ip
+type TwoFactorService struct {
+	repo         repository.TwoFactorer
+	accounts     repository.Accounter
+	sessions     repository.Sessioner
+	guard        *loginGuard
+	salt         string
+	tokenTTL     time.Duration
+	signingKey   string
+	challengeKey string
+	challengeTTL time.Duration
+	issuer       string
+	roles        map[domain.Role]bool
+}
+
+// NewTwoFactorService - constructor
+func NewTwoFactorService(repo repository.TwoFactorer, accounts repository.Accounter,
+	sessions repository.Sessioner, attempts repository.LoginAttempter, auth *config.Auth, config *config.TwoFactor,
+	login *config.Login) *TwoFactorService {
+	roles := make(map[domain.Role]bool, len(config.Roles))
+	for _, role := range config.Roles {
+		roles[domain.Role(role)] = true
+	}
+	return &TwoFactorService{
+		repo:         repo,
+		accounts:     accounts,
+		sessions:     sessions,
+		guard:        newLoginGuard(attempts, login),
+		salt:         auth.Salt,
+		tokenTTL:     time.Duration(auth.TokenTTL * 1000000),
+		signingKey:   auth.SigningKey,
+		challengeKey: config.SigningKey,
+		challengeTTL: time.Duration(config.ChallengeTTL) * time.Second,
+		issuer:       config.Issuer,
+		roles:        roles,
+	}
+}
+
+// challengeClaims - enroll is set for the role requiring two-factor without it
+type challengeClaims struct {
+	Enroll bool `json:"enroll"`
+	jwt.StandardClaims
+}
+
+// SignIn - the access token of a new session, or the challenge token when two-factor is enabled
+// or required. The failures are forgotten only when the access token is issued
+func (s *TwoFactorService) SignIn(email, password string, client domain.Client) (domain.SignInResult, error) {
+	if err := s.guard.check(email, client.IP); err != nil {
+		return domain.SignInResult{}, err
+	}
+	passwordHash, err := generatePasswordHash(s.salt, password)
+	if err != nil {
+		return domain.SignInResult{}, fmt.Errorf("generate password: %w", err)
+	}
+	account, err := s.accounts.GetByCredentials(email, passwordHash)
+	if err != nil {
+		return domain.SignInResult{}, s.guard.failOn(err, domain.LOGIN_FAILED_PASSWORD, email, nil, client.IP)
+	}
+
+	twoFactor, err := s.repo.GetTwoFactor(account.PublicId)
+	if err != nil && !errors.Is(err, domain.ErrTwoFactorNotEnabled) {
+		return domain.SignInResult{}, err
+	}
+
+	switch {
+	case twoFactor.Enabled:
+		return s.challenge(account.PublicId, false)
+	case s.roles[account.Role]:
+		return s.challenge(account.PublicId, true)
+	}
+	if err := s.guard.succeed(email); err != nil {
+		return domain.SignInResult{}, err
+	}
+	token, err := s.startSession(account.PublicId, client)
+	return domain.SignInResult{AccountPublicId: account.PublicId, Token: token}, err
+}
+
+// SignInEnroll - the secret for the account role requiring two-factor, it is confirmed by SignInTwoFactor
+func (s *TwoFactorService) SignInEnroll(challengeToken string) (domain.TwoFactorEnrollment, error) {
+	claims, account, err := s.parseChallenge(challengeToken)
+	if err != nil {
+		return domain.TwoFactorEnrollment{}, err
+	}
+	if !claims.Enroll {
+		return domain.TwoFactorEnrollment{}, domain.ErrTwoFactorAlreadyEnabled
+	}
+	return s.enroll(account)
+}
+
+// SignInTwoFactor - the second step, the enrollment is confirmed by the first code
+func (s *TwoFactorService) SignInTwoFactor(input domain.SignInChallengeInput, client domain.Client) (domain.SignInResult, error) {
+	claims, account, err := s.parseChallenge(input.ChallengeToken)
+	if err != nil {
+		return domain.SignInResult{}, err
+	}
+	if err := s.guard.check(account.Email, client.IP); err != nil {
+		return domain.SignInResult{}, err
+	}
+
+	result := domain.SignInResult{AccountPublicId: account.PublicId}
+	if claims.Enroll {
+		var codes domain.RecoveryCodes
+		codes, err = s.enable(account.PublicId, input.Code)
+		result.RecoveryCodes = codes.Codes
+	} else {
+		err = s.checkCode(account.PublicId, input.Code)
+	}
+	if err != nil {
+		return domain.SignInResult{}, s.guard.failOn(err, domain.LOGIN_FAILED_TWO_FACTOR,
+			account.Email, &account.PublicId, client.IP)
+	}
+	if err := s.guard.succeed(account.Email); err != nil {
+		return domain.SignInResult{}, err
+	}
+
+	result.Token, err = s.startSession(account.PublicId, client)
+	return result, err
+}
+
+// startSession - the access token of the new session
+func (s *TwoFactorService) startSession(accountPublicId uuid.UUID, client domain.Client) (string, error) {
+	session := newSession(accountPublicId, client, s.tokenTTL)
+	if err := s.sessions.CreateSession(session); err != nil {
+		return "", fmt.Errorf("create session: %w", err)
+	}
+	return generateAccessToken(session, s.signingKey)
+}
+
+// EnrollTwoFactor - the new secret of own account, it works after EnableTwoFactor
+func (s *TwoFactorService) EnrollTwoFactor(accountPublicId string) (domain.TwoFactorEnrollment, error) {
+	account, err := s.accounts.GetAccount(accountPublicId)
+	if err != nil {
+		return domain.TwoFactorEnrollment{}, err
+	}
+	return s.enroll(account)
+}
+
+// EnableTwoFactor - confirms the enrollment by the current code
+func (s *TwoFactorService) EnableTwoFactor(accountPublicId, code string) (domain.RecoveryCodes, error) {
+	publicId, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return domain.RecoveryCodes{}, err
+	}
+	return s.enable(publicId, code)
+}
+
+// DisableTwoFactor - by a code, not for the roles requiring two-factor
+func (s *TwoFactorService) DisableTwoFactor(accountPublicId, code string) error {
+	account, err := s.accounts.GetAccount(accountPublicId)
+	if err != nil {
+		return err
+	}
+	if s.roles[account.Role] {
+		return domain.ErrTwoFactorRequiredForRole
+	}
+	if err := s.checkCode(account.PublicId, code); err != nil {
+		return err
+	}
+	return s.repo.DisableTwoFactor(account.PublicId)
+}
+
+// RegenerateRecoveryCodes - by a code, the old recovery codes stop working
+func (s *TwoFactorService) RegenerateRecoveryCodes(accountPublicId, code string) (domain.RecoveryCodes, error) {
+	publicId, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return domain.RecoveryCodes{}, err
+	}
+	if err := s.checkCode(publicId, code); err != nil {
+		return domain.RecoveryCodes{}, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return domain.RecoveryCodes{}, err
+	}
+	if err := s.repo.ReplaceRecoveryCodes(publicId, hashes, time.Now().UTC()); err != nil {
+		return domain.RecoveryCodes{}, err
+	}
+	return domain.RecoveryCodes{Codes: codes}, nil
+}
+
+// challenge - short-lived token of the next sign-in step
+func (s *TwoFactorService) challenge(accountPublicId uuid.UUID, enroll bool) (domain.SignInResult, error) {
+	now := time.Now()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, challengeClaims{
+		Enroll: enroll,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   accountPublicId.String(),
+			Audience:  CHALLENGE_AUDIENCE,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(s.challengeTTL).Unix(),
+		},
+	}).SignedString([]byte(s.challengeKey))
+	if err != nil {
+		return domain.SignInResult{}, fmt.Errorf("sign challenge token: %w", err)
+	}
+
+	result := domain.SignInResult{
+		AccountPublicId: accountPublicId,
+		ChallengeToken:  token,
+		NextStep:        domain.SIGN_IN_STEP_TWO_FACTOR,
+	}
+	if enroll {
+		result.NextStep = domain.SIGN_IN_STEP_ENROLL
+	}
+	return result, nil
+}
+
+// parseChallenge - the challenge issued before the password reset is not valid
+func (s *TwoFactorService) parseChallenge(challengeToken string) (challengeClaims, domain.Account, error) {
+	var claims challengeClaims
+	_, err := jwt.ParseWithClaims(challengeToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(s.challengeKey), nil
+	})
+	if err != nil || !claims.VerifyAudience(CHALLENGE_AUDIENCE, true) {
+		return claims, domain.Account{}, domain.ErrInvalidChallenge
+	}
+
+	account, err := s.accounts.GetAccount(claims.Subject)
+	if err != nil {
+		return claims, account, domain.ErrInvalidChallenge
+	}
+	if account.TokensValidAfter != nil && claims.IssuedAt < account.TokensValidAfter.Unix() {
+		return claims, account, domain.ErrInvalidChallenge
+	}
+	return claims, account, nil
+}
+
+// enroll - the started secret is replaced, the enabled one is kept
+func (s *TwoFactorService) enroll(account domain.Account) (domain.TwoFactorEnrollment, error) {
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return domain.TwoFactorEnrollment{}, err
+	}
+	if err := s.repo.StartTwoFactor(account.PublicId, secret, time.Now().UTC()); err != nil {
+		return domain.TwoFactorEnrollment{}, err
+	}
+	return domain.TwoFactorEnrollment{
+		AccountPublicId: account.PublicId,
+		Secret:          secret,
+		URI:             totp.URI(s.issuer, account.Email, secret),
+	}, nil
+}
+
+// enable - the started secret by its totp code, recovery codes are generated
+func (s *TwoFactorService) enable(accountPublicId uuid.UUID, code string) (domain.RecoveryCodes, error) {
+	twoFactor, err := s.repo.GetTwoFactor(accountPublicId)
+	if errors.Is(err, domain.ErrTwoFactorNotEnabled) {
+		return domain.RecoveryCodes{}, domain.ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return domain.RecoveryCodes{}, err
+	}
+	if twoFactor.Enabled {
+		return domain.RecoveryCodes{}, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(twoFactor.Secret, code, time.Now())
+	if !ok {
+		return domain.RecoveryCodes{}, domain.ErrInvalidTwoFactorCode
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return domain.RecoveryCodes{}, err
+	}
+	if err := s.repo.EnableTwoFactor(accountPublicId, step, hashes, time.Now().UTC()); err != nil {
+		return domain.RecoveryCodes{}, err
+	}
+	return domain.RecoveryCodes{Codes: codes}, nil
+}
+
+// checkCode - totp code of the enabled two-factor, it works once, or an unused recovery code
+func (s *TwoFactorService) checkCode(accountPublicId uuid.UUID, code string) error {
+	twoFactor, err := s.repo.GetTwoFactor(accountPublicId)
+	if err != nil {
+		return err
+	}
+	if !twoFactor.Enabled {
+		return domain.ErrTwoFactorNotEnabled
+	}
+
+	if step, ok := totp.Validate(twoFactor.Secret, code, time.Now()); ok {
+		return s.repo.UseTwoFactorStep(accountPublicId, step)
+	}
+	return s.repo.UseRecoveryCode(accountPublicId, hashToken(normalizeRecoveryCode(code)), time.Now().UTC())
+}
+
+// newRecoveryCodes - the codes to show and their hashes to store
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, domain.RECOVERY_CODES)
+	hashes := make([]string, 0, domain.RECOVERY_CODES)
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	for i := 0; i < domain.RECOVERY_CODES; i++ {
+		raw := make([]byte, RECOVERY_CODE_SIZE)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, fmt.Errorf("generate recovery code: %w", err)
+		}
+		code := strings.ToLower(encoding.EncodeToString(raw))
+		codes = append(codes, code[:4]+"-"+code[4:8]+"-"+code[8:12]+"-"+code[12:])
+		hashes = append(hashes, hashToken(code))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode - case and dashes don't matter
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
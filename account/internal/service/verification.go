@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/config"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/mailer"
+	"github.com/p12s/furniture-store/account/internal/repository"
+)
+
+const (
+	// VERIFICATION_AUDIENCE - audience of the verification token
+	VERIFICATION_AUDIENCE = "verify-email"
+	// VERIFICATION_SUBJECT - verification mail subject
+	VERIFICATION_SUBJECT = "Confirm your email"
+)
+
+var _ Verifier = (*VerificationService)(nil)
+
+// Verifier - service interface
+type Verifier interface {
+	SendEmailVerification(ctx context.Context, accountPublicId string) error
+	VerifyEmail(token string) (domain.EmailVerifiedEvent, error)
+}
+
+// VerificationService - email verification by a signed single-use token sent by mail
+type VerificationService struct {
+	repo           repository.Verifier
+	accounts       repository.Accounter
+	mailer         mailer.Mailer
+	signingKey     string
+	tokenTTL       time.Duration
+	url            string
+	resendInterval time.Duration
+	maxPerHour     int
+}
+
+// NewVerificationService - constructor
+func NewVerificationService(repo repository.Verifier, accounts repository.Accounter,
+	mailer mailer.Mailer, config *config.Verify) *VerificationService {
+	return &VerificationService{
+		repo:           repo,
+		accounts:       accounts,
+		mailer:         mailer,
+		signingKey:     config.SigningKey,
+		tokenTTL:       time.Duration(config.TokenTTL) * time.Second,
+		url:            config.URL,
+		resendInterval: time.Duration(config.ResendInterval) * time.Second,
+		maxPerHour:     config.MaxPerHour,
+	}
+}
+
+// verificationClaims - id is the stored single-use token, email is informational
+type verificationClaims struct {
+	Email string `json:"email"`
+	jwt.StandardClaims
+}
+
+// SendEmailVerification - sign-up and resend, throttled by the interval and the hourly limit
+func (s *VerificationService) SendEmailVerification(ctx context.Context, accountPublicId string) error {
+	account, err := s.accounts.GetAccount(accountPublicId)
+	if err != nil {
+		return err
+	}
+	if account.EmailVerified {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	now := time.Now().UTC()
+	recent, err := s.repo.CountVerifications(account.PublicId, now.Add(-s.resendInterval))
+	if err != nil {
+		return err
+	}
+	hourly, err := s.repo.CountVerifications(account.PublicId, now.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if recent > 0 || hourly >= s.maxPerHour {
+		return domain.ErrVerificationThrottled
+	}
+
+	verification := domain.EmailVerification{
+		TokenId:         uuid.New(),
+		AccountPublicId: account.PublicId,
+		Email:           account.Email,
+		ExpiresAt:       now.Add(s.tokenTTL),
+		CreatedAt:       now,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, verificationClaims{
+		Email: verification.Email,
+		StandardClaims: jwt.StandardClaims{
+			Id:        verification.TokenId.String(),
+			Subject:   verification.AccountPublicId.String(),
+			Audience:  VERIFICATION_AUDIENCE,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: verification.ExpiresAt.Unix(),
+		},
+	}).SignedString([]byte(s.signingKey))
+	if err != nil {
+		return fmt.Errorf("sign verification token: %w", err)
+	}
+	if err := s.repo.CreateVerification(verification); err != nil {
+		return err
+	}
+
+	link := s.url + "?token=" + url.QueryEscape(token)
+	text := fmt.Sprintf("Hello, %s!\n\nConfirm your email by the link:\n%s\n\nThe link works once and expires at %s.\n",
+		account.Name, link, verification.ExpiresAt.Format(time.RFC1123))
+	return s.mailer.Send(ctx, account.Email, VERIFICATION_SUBJECT, text)
+}
+
+// VerifyEmail - checks the signature, then the stored token is used
+func (s *VerificationService) VerifyEmail(token string) (domain.EmailVerifiedEvent, error) {
+	var claims verificationClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(s.signingKey), nil
+	})
+	if err != nil || !claims.VerifyAudience(VERIFICATION_AUDIENCE, true) {
+		return domain.EmailVerifiedEvent{}, domain.ErrInvalidVerificationToken
+	}
+	tokenId, err := uuid.Parse(claims.Id)
+	if err != nil {
+		return domain.EmailVerifiedEvent{}, domain.ErrInvalidVerificationToken
+	}
+
+	verification, err := s.repo.UseVerification(tokenId, time.Now().UTC())
+	if err != nil {
+		return domain.EmailVerifiedEvent{}, err
+	}
+
+	return domain.EmailVerifiedEvent{
+		PublicId:   verification.AccountPublicId,
+		Email:      verification.Email,
+		VerifiedAt: *verification.UsedAt,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // nolint
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RFC 6238 defaults, the only ones all authenticator apps support
+const (
+	DIGITS      = 6
+	PERIOD      = 30
+	SECRET_SIZE = 20
+	// SKEW - accepted steps before and after the current one, for the clock drift
+	SKEW = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewSecret - random base32 secret
+func NewSecret() (string, error) {
+	raw := make([]byte, SECRET_SIZE)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate secret: %w", err)
+	}
+	return encoding.EncodeToString(raw), nil
+}
+
+// URI - otpauth provisioning uri, apps scan it from the qr code
+func URI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(DIGITS))
+	query.Set("period", fmt.Sprint(PERIOD))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Step - time step number of the moment
+func Step(t time.Time) int64 {
+	return t.Unix() / PERIOD
+}
+
+// Code - the code of the step
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("decode secret: %w", err)
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter) // nolint
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < DIGITS; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", DIGITS, value%mod), nil
+}
+
+// Validate - the matched step, the caller keeps the last used one so a code works once
+func Validate(secret, code string, t time.Time) (int64, bool) {
+	if len(code) != DIGITS {
+		return 0, false
+	}
+	current := Step(t)
+	for step := current - SKEW; step <= current+SKEW; step++ {
+		expected, err := Code(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
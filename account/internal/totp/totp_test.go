@@ -0,0 +1,51 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 appendix B, the last 6 digits of the sha1 vectors
+func TestCode(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := Code(secret, Step(time.Unix(tt.unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.code, code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := NewSecret()
+	assert.NoError(t, err)
+	now := time.Now()
+
+	previous, err := Code(secret, Step(now)-1)
+	assert.NoError(t, err)
+	step, ok := Validate(secret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now)-1, step)
+
+	old, err := Code(secret, Step(now)-3)
+	assert.NoError(t, err)
+	_, ok = Validate(secret, old, now)
+	assert.False(t, ok)
+
+	_, ok = Validate(secret, "12345", now)
+	assert.False(t, ok)
+}
@@ -10,7 +10,7 @@ import (
 
 // @Summary Update account
 // @Tags Account
-// @Description Update account info
+// @Description Update account info, a set email has to be verified again
 // @ID updateAccount
 // @Accept  json
 // @Param input body domain.UpdateAccountInput true "credentials"
@@ -24,6 +24,7 @@ func (h *Handler) updateAccount(c *gin.Context) { // nolint
 	}
 
 	err := h.services.UpdateAccountInfo(input)
+	h.auditAccountUpdate(c, input, err)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, "service failure")
 		return
@@ -35,39 +36,47 @@ func (h *Handler) updateAccount(c *gin.Context) { // nolint
 			logrus.Errorf("sent update account event fail: %s/n", err.Error())
 		}
 	}()
+	if input.Email != nil {
+		h.sendEmailVerification(input.PublicId.String())
+	}
 
 	c.Status(http.StatusOK)
 }
 
 // @Summary Delete account
 // @Tags Account
-// @Description Delete account
+// @Description Erase own account, its sessions are revoked and every service anonymizes its copy of the personal data.
+// @Description Financial records are kept. The erasure request is completed when all services answered
 // @ID deleteAccount
-// @Accept  json
-// @Param input body domain.DeleteAccountInput true "credentials"
-// @Success 200
+// @Produce  json
+// @Success 202 {object} domain.PrivacyRequest
 // @Router /account/ [delete]
 func (h *Handler) deleteAccount(c *gin.Context) {
-	var input domain.DeleteAccountInput
-	if err := c.BindJSON(&input); err != nil {
-		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
 		return
 	}
 
-	err := h.services.DeleteAccount(input.PublicId)
+	erased, err := h.services.EraseAccount(accountPublicId)
 	if err != nil {
+		h.auditOwn(c, domain.AUDIT_ACCOUNT_DELETED, err)
 		newErrorResponse(c, http.StatusInternalServerError, "service failure")
 		return
 	}
+	h.auditErasure(erased.Request)
 
 	go func() {
+		input := domain.DeleteAccountInput{PublicId: accountPublicId}
 		err := h.broker.Produce(domain.EVENT_ACCOUNT_DELETED, h.broker.TopicAccountCUD, input)
 		if err != nil {
 			logrus.Errorf("sent delete account event fail: %s/n", err.Error())
 		}
 	}()
+	h.producePrivacyRequested(domain.EVENT_ACCOUNT_ERASURE_REQUESTED, erased.Request)
+	h.produceSessionsRevoked(erased.Sessions)
 
-	c.Status(http.StatusOK)
+	c.JSON(http.StatusAccepted, erased.Request)
 }
 
 // @Summary Get account info
@@ -92,3 +101,17 @@ func (h *Handler) getAccountInfo(c *gin.Context) {
 
 	c.JSON(http.StatusOK, account)
 }
+
+// auditAccountUpdate - the password and the email changes are separate entries
+func (h *Handler) auditAccountUpdate(c *gin.Context, input domain.UpdateAccountInput, err error) {
+	target := auditTarget(input.PublicId)
+	if input.Password != nil {
+		h.audit(c, domain.AuditEntry{Action: domain.AUDIT_PASSWORD_CHANGED, TargetPublicId: target}, err)
+	}
+	if input.Email != nil {
+		h.audit(c, domain.AuditEntry{Action: domain.AUDIT_EMAIL_CHANGED, TargetPublicId: target, Email: *input.Email}, err)
+	}
+	if input.Name != nil || input.Username != nil || input.Address != nil {
+		h.audit(c, domain.AuditEntry{Action: domain.AUDIT_ACCOUNT_UPDATED, TargetPublicId: target}, err)
+	}
+}
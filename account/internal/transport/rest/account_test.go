@@ -62,7 +62,7 @@ func TestHandler_updateAccount(t *testing.T) {
 		expectedRequestBody string
 	}{
 		{
-			name:      "Can update account with correct input",
+			name:      "Can update account with correct input and send verification of the new email",
 			inputBody: `{"public_id": "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5", "name": "Ivan", "username": "ivan", "password": "qwerty", "email": "test@test.ru", "address": "Some-city, some-street, some-hause"}`,
 			inputAccount: domain.UpdateAccountInput{
 				PublicId: publicId,
@@ -135,7 +135,11 @@ func TestHandler_updateAccount(t *testing.T) {
 
 			acc := mock_service.NewMockAccounter(ctrl)
 			tt.accountMockBehavior(acc, tt.inputAccount)
-			serviceMock := &service.Service{Accounter: acc}
+			verifier := mock_service.NewMockVerifier(ctrl)
+			if tt.expectedStatusCode == http.StatusOK {
+				verifier.EXPECT().SendEmailVerification(gomock.Any(), tt.inputAccount.PublicId.String()).Return(nil)
+			}
+			serviceMock := &service.Service{Accounter: acc, Verifier: verifier, Auditor: anyAudit(ctrl)}
 
 			brokerProducer := mock_broker.NewMockProducer(ctrl)
 			var brokerMock *broker.Broker
@@ -162,64 +166,58 @@ func TestHandler_updateAccount(t *testing.T) {
 }
 
 func TestHandler_deleteAccount(t *testing.T) {
-	type accountMockBehavior func(s *mock_service.MockAccounter, account domain.DeleteAccountInput)
-	type brokerMockProducer func(s *mock_broker.MockProducer, event domain.EventType, topic string, input interface{})
+	type privacyMockBehavior func(s *mock_service.MockPrivacier, publicId string)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
 
 	publicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	createdAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	erased := domain.AccountErased{
+		Request: domain.PrivacyRequest{
+			PublicId:        uuid.MustParse("9c0e5d0a-3b8f-4f7e-a1f2-6d4c2b1a0e9f"),
+			AccountPublicId: uuid.MustParse(publicId),
+			Kind:            domain.PRIVACY_ERASURE,
+			Status:          domain.PRIVACY_PENDING,
+			Services:        []string{"account", "product"},
+			Parts:           []domain.PrivacyPart{{Service: "account", CompletedAt: createdAt}},
+			CreatedAt:       createdAt,
+		},
+		Sessions: domain.SessionsRevokedEvent{
+			PublicId:   uuid.MustParse(publicId),
+			SessionIds: []uuid.UUID{uuid.MustParse("0b6fb3a4-52ee-4f0e-9d7c-3f5e6c1d2a90")},
+			RevokedAt:  createdAt,
+			ExpiresAt:  createdAt.Add(12 * time.Hour),
+		},
+	}
 
 	tests := []struct {
 		name                string
-		inputBody           string
-		inputAccount        domain.DeleteAccountInput
-		accountMockBehavior accountMockBehavior
-		eventType           domain.EventType
-		eventData           interface{}
-		topic               string
+		privacyMockBehavior privacyMockBehavior
 		brokerMockProducer  brokerMockProducer
 		expectedStatusCode  int
 		expectedRequestBody string
 	}{
 		{
-			name:      "Can delete account with correct input",
-			inputBody: `{"public_id": "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"}`,
-			inputAccount: domain.DeleteAccountInput{
-				PublicId: publicId,
+			name: "Can erase own account and publish the deletion, the erasure and the revoked sessions",
+			privacyMockBehavior: func(s *mock_service.MockPrivacier, publicId string) {
+				s.EXPECT().EraseAccount(publicId).Return(erased, nil)
 			},
-			accountMockBehavior: func(s *mock_service.MockAccounter, account domain.DeleteAccountInput) {
-				s.EXPECT().DeleteAccount(account.PublicId).Return(nil)
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ACCOUNT_DELETED, "", domain.DeleteAccountInput{PublicId: publicId}).Return(nil)
+				s.EXPECT().Produce(domain.EVENT_ACCOUNT_ERASURE_REQUESTED, "", domain.PrivacyRequestedEvent{
+					RequestId: erased.Request.PublicId,
+					PublicId:  erased.Request.AccountPublicId,
+				}).Return(nil)
+				s.EXPECT().Produce(domain.EVENT_SESSIONS_REVOKED, "", erased.Sessions).Return(nil)
 			},
-			eventType: domain.EVENT_ACCOUNT_DELETED,
-			eventData: domain.DeleteAccountInput{
-				PublicId: publicId,
-			},
-			topic: "",
-			brokerMockProducer: func(s *mock_broker.MockProducer, event domain.EventType, topic string, input interface{}) {
-				s.EXPECT().Produce(event, topic, input).Return(nil)
-			},
-			expectedStatusCode:  http.StatusOK,
-			expectedRequestBody: ``,
+			expectedStatusCode:  http.StatusAccepted,
+			expectedRequestBody: `{"public_id":"9c0e5d0a-3b8f-4f7e-a1f2-6d4c2b1a0e9f","account_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","kind":"erasure","status":"pending","services":["account","product"],"parts":[{"service":"account","completed_at":"2022-01-02T03:04:05Z"}],"created_at":"2022-01-02T03:04:05Z"}`,
 		},
 		{
-			name:                "Can't delete account without required public account id",
-			inputBody:           `{}`,
-			inputAccount:        domain.DeleteAccountInput{},
-			accountMockBehavior: func(s *mock_service.MockAccounter, account domain.DeleteAccountInput) {},
-			eventType:           domain.EVENT_ACCOUNT_DELETED,
-			eventData:           domain.DeleteAccountInput{},
-			topic:               "",
-			brokerMockProducer:  func(s *mock_broker.MockProducer, event domain.EventType, topic string, input interface{}) {},
-			expectedStatusCode:  http.StatusBadRequest,
-			expectedRequestBody: `{"message":"invalid input body"}`,
-		},
-		{
-			name:      "Can return error response if service failure",
-			inputBody: `{"public_id": "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"}`,
-			inputAccount: domain.DeleteAccountInput{
-				PublicId: publicId,
-			},
-			accountMockBehavior: func(s *mock_service.MockAccounter, account domain.DeleteAccountInput) {
-				s.EXPECT().DeleteAccount(account.PublicId).Return(errors.New(""))
+			name: "Can return error response if service failure",
+			privacyMockBehavior: func(s *mock_service.MockPrivacier, publicId string) {
+				s.EXPECT().EraseAccount(publicId).Return(domain.AccountErased{}, errors.New(""))
 			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
 			expectedStatusCode:  http.StatusInternalServerError,
 			expectedRequestBody: `{"message":"service failure"}`,
 		},
@@ -230,24 +228,23 @@ func TestHandler_deleteAccount(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			acc := mock_service.NewMockAccounter(ctrl)
-			tt.accountMockBehavior(acc, tt.inputAccount)
-			serviceMock := &service.Service{Accounter: acc}
+			privacy := mock_service.NewMockPrivacier(ctrl)
+			tt.privacyMockBehavior(privacy, publicId)
+			serviceMock := &service.Service{Privacier: privacy, Auditor: anyAudit(ctrl)}
 
 			brokerProducer := mock_broker.NewMockProducer(ctrl)
-			var brokerMock *broker.Broker
-			if tt.brokerMockProducer != nil {
-				tt.brokerMockProducer(brokerProducer, tt.eventType, tt.topic, tt.eventData)
-				brokerMock = &broker.Broker{Producer: brokerProducer}
-			}
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
 
 			handler := NewHandler(serviceMock, brokerMock)
 			gin.SetMode(gin.ReleaseMode)
 			r := gin.New()
-			r.DELETE("/account/", handler.deleteAccount)
+			r.DELETE("/account/", func(c *gin.Context) {
+				c.Set(accountCtx, publicId)
+			}, handler.deleteAccount)
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("DELETE", "/account/", bytes.NewBufferString(tt.inputBody))
+			req := httptest.NewRequest("DELETE", "/account/", nil)
 
 			r.ServeHTTP(w, req)
 			time.Sleep(WAITING_GORUTINE_END_TIME)
@@ -292,7 +289,7 @@ func TestHandler_getAccountInfo(t *testing.T) {
 				s.EXPECT().GetAccount(publicId).Return(account, nil)
 			},
 			expectedStatusCode:  http.StatusOK,
-			expectedRequestBody: `{"id":1,"public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","name":"Ivan","username":"ivan","password":"qwerty","email":"test@test.ru","address":"address","role":0}`,
+			expectedRequestBody: `{"id":1,"public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","name":"Ivan","username":"ivan","password":"qwerty","email":"test@test.ru","email_verified":false,"address":"address","role":0}`,
 			isTokenExists:       true,
 		},
 		{
@@ -332,7 +329,7 @@ func TestHandler_getAccountInfo(t *testing.T) {
 
 			acc := mock_service.NewMockAccounter(ctrl)
 			tt.accountMockBehavior(acc, tt.publicId, tt.outputAccount)
-			serviceMock := &service.Service{Accounter: acc}
+			serviceMock := &service.Service{Accounter: acc, Auditor: anyAudit(ctrl)}
 			var brokerMock *broker.Broker
 
 			handler := NewHandler(serviceMock, brokerMock)
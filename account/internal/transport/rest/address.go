@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Create address
+// @Tags Address
+// @Description Delivery address of own account, the first address becomes the default
+// @ID createAddress
+// @Accept  json
+// @Produce  json
+// @Param input body domain.AddressInput true "address"
+// @Success 201 {object} domain.Address
+// @Router /account/addresses [post]
+func (h *Handler) createAddress(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	var input domain.AddressInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	address, err := h.services.CreateAddress(accountPublicId, input)
+	if !checkAddressError(c, err) {
+		return
+	}
+
+	h.produceAddressEvent(domain.EVENT_ADDRESS_CREATED, address)
+	c.JSON(http.StatusCreated, address)
+}
+
+// @Summary Get addresses
+// @Tags Address
+// @Description Delivery addresses of own account, the default goes first
+// @ID getAddresses
+// @Produce  json
+// @Success 200 {array} domain.Address
+// @Router /account/addresses [get]
+func (h *Handler) getAddresses(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	addresses, err := h.services.GetAddresses(accountPublicId)
+	if !checkAddressError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, addresses)
+}
+
+// @Summary Get address
+// @Tags Address
+// @Description Delivery address of own account
+// @ID getAddress
+// @Produce  json
+// @Param id path string true "address public_id"
+// @Success 200 {object} domain.Address
+// @Router /account/addresses/{id} [get]
+func (h *Handler) getAddress(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid address public id")
+		return
+	}
+
+	address, err := h.services.GetAddress(accountPublicId, publicId)
+	if !checkAddressError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, address)
+}
+
+// @Summary Update address
+// @Tags Address
+// @Description All fields of own address, the default can be changed by setting it on another address
+// @ID updateAddress
+// @Accept  json
+// @Produce  json
+// @Param id path string true "address public_id"
+// @Param input body domain.AddressInput true "address"
+// @Success 200 {object} domain.Address
+// @Router /account/addresses/{id} [put]
+func (h *Handler) updateAddress(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid address public id")
+		return
+	}
+
+	var input domain.AddressInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	address, err := h.services.UpdateAddress(accountPublicId, publicId, input)
+	if !checkAddressError(c, err) {
+		return
+	}
+
+	h.produceAddressEvent(domain.EVENT_ADDRESS_UPDATED, address)
+	c.JSON(http.StatusOK, address)
+}
+
+// @Summary Delete address
+// @Tags Address
+// @Description Own address, the oldest of the rest becomes the default instead of the deleted default
+// @ID deleteAddress
+// @Param id path string true "address public_id"
+// @Success 200
+// @Router /account/addresses/{id} [delete]
+func (h *Handler) deleteAddress(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid address public id")
+		return
+	}
+
+	deleted, err := h.services.DeleteAddress(accountPublicId, publicId)
+	if !checkAddressError(c, err) {
+		return
+	}
+
+	h.produceAddressEvent(domain.EVENT_ADDRESS_DELETED, deleted.Deleted)
+	if deleted.NewDefault != nil {
+		h.produceAddressEvent(domain.EVENT_ADDRESS_UPDATED, *deleted.NewDefault)
+	}
+	c.Status(http.StatusOK)
+}
+
+// produceAddressEvent - the full address, so delivery can keep its snapshot
+func (h *Handler) produceAddressEvent(eventType domain.EventType, address domain.Address) {
+	go func() {
+		err := h.broker.Produce(eventType, h.broker.TopicAccountCUD, address)
+		if err != nil {
+			logrus.Errorf("sent %s event fail: %s/n", eventType, err.Error())
+		}
+	}()
+}
+
+// checkAddressError - false when the error is sent
+func checkAddressError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrAddressNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return false
+	case errors.Is(err, domain.ErrInvalidAddress):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case errors.Is(err, domain.ErrTooManyAddresses):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
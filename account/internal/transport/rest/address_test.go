@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/broker"
+	mock_broker "github.com/p12s/furniture-store/account/internal/broker/mocks"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/service"
+	mock_service "github.com/p12s/furniture-store/account/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createAddress(t *testing.T) {
+	type addressMockBehavior func(s *mock_service.MockAddresser, accountPublicId string, input domain.AddressInput)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	address := domain.Address{
+		PublicId:        uuid.MustParse("7a8d9b36-5c33-4e47-a5e2-9c8f0a7b0f11"),
+		AccountPublicId: uuid.MustParse(accountPublicId),
+		Country:         "Russia",
+		City:            "Moscow",
+		Street:          "Tverskaya",
+		Building:        "1",
+		IsDefault:       true,
+	}
+
+	tests := []struct {
+		name                string
+		inputBody           string
+		input               domain.AddressInput
+		addressMockBehavior addressMockBehavior
+		brokerMockProducer  brokerMockProducer
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Can create address with correct input",
+			inputBody: `{"country": "Russia", "city": "Moscow", "street": "Tverskaya", "building": "1"}`,
+			input:     domain.AddressInput{Country: "Russia", City: "Moscow", Street: "Tverskaya", Building: "1"},
+			addressMockBehavior: func(s *mock_service.MockAddresser, accountPublicId string, input domain.AddressInput) {
+				s.EXPECT().CreateAddress(accountPublicId, input).Return(address, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ADDRESS_CREATED, "", address).Return(nil)
+			},
+			expectedStatusCode:  http.StatusCreated,
+			expectedRequestBody: `{"public_id":"7a8d9b36-5c33-4e47-a5e2-9c8f0a7b0f11","account_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","country":"Russia","city":"Moscow","street":"Tverskaya","building":"1","apartment":"","postal_code":"","notes":"","is_default":true,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:                "Can't create address without required street",
+			inputBody:           `{"country": "Russia", "city": "Moscow", "building": "1"}`,
+			addressMockBehavior: func(s *mock_service.MockAddresser, accountPublicId string, input domain.AddressInput) {},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Can't create address over the limit",
+			inputBody: `{"country": "Russia", "city": "Moscow", "street": "Tverskaya", "building": "1"}`,
+			input:     domain.AddressInput{Country: "Russia", City: "Moscow", Street: "Tverskaya", Building: "1"},
+			addressMockBehavior: func(s *mock_service.MockAddresser, accountPublicId string, input domain.AddressInput) {
+				s.EXPECT().CreateAddress(accountPublicId, input).Return(domain.Address{}, domain.ErrTooManyAddresses)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusConflict,
+			expectedRequestBody: `{"message":"too many addresses"}`,
+		},
+		{
+			name:      "Can return error response if service failure",
+			inputBody: `{"country": "Russia", "city": "Moscow", "street": "Tverskaya", "building": "1"}`,
+			input:     domain.AddressInput{Country: "Russia", City: "Moscow", Street: "Tverskaya", Building: "1"},
+			addressMockBehavior: func(s *mock_service.MockAddresser, accountPublicId string, input domain.AddressInput) {
+				s.EXPECT().CreateAddress(accountPublicId, input).Return(domain.Address{}, errors.New(""))
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			addresses := mock_service.NewMockAddresser(ctrl)
+			tt.addressMockBehavior(addresses, accountPublicId, tt.input)
+			serviceMock := &service.Service{Addresser: addresses}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/account/addresses", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.createAddress)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/account/addresses", bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteAddress(t *testing.T) {
+	type addressMockBehavior func(s *mock_service.MockAddresser, accountPublicId string, publicId uuid.UUID)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	deleted := domain.Address{
+		PublicId:        uuid.MustParse("7a8d9b36-5c33-4e47-a5e2-9c8f0a7b0f11"),
+		AccountPublicId: uuid.MustParse(accountPublicId),
+		IsDefault:       true,
+	}
+	newDefault := domain.Address{
+		PublicId:        uuid.MustParse("0b6fb3a4-52ee-4f0e-9d7c-3f5e6c1d2a90"),
+		AccountPublicId: uuid.MustParse(accountPublicId),
+		IsDefault:       true,
+	}
+
+	tests := []struct {
+		name                string
+		publicId            string
+		addressMockBehavior addressMockBehavior
+		brokerMockProducer  brokerMockProducer
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:     "Can delete the default address and publish the new default",
+			publicId: deleted.PublicId.String(),
+			addressMockBehavior: func(s *mock_service.MockAddresser, accountPublicId string, publicId uuid.UUID) {
+				s.EXPECT().DeleteAddress(accountPublicId, publicId).
+					Return(domain.AddressDeleted{Deleted: deleted, NewDefault: &newDefault}, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ADDRESS_DELETED, "", deleted).Return(nil)
+				s.EXPECT().Produce(domain.EVENT_ADDRESS_UPDATED, "", newDefault).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: ``,
+		},
+		{
+			name:                "Can't delete address with invalid public id",
+			publicId:            "invalid",
+			addressMockBehavior: func(s *mock_service.MockAddresser, accountPublicId string, publicId uuid.UUID) {},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid address public id"}`,
+		},
+		{
+			name:     "Can't delete address of another account",
+			publicId: deleted.PublicId.String(),
+			addressMockBehavior: func(s *mock_service.MockAddresser, accountPublicId string, publicId uuid.UUID) {
+				s.EXPECT().DeleteAddress(accountPublicId, publicId).Return(domain.AddressDeleted{}, domain.ErrAddressNotFound)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedRequestBody: `{"message":"address not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			addresses := mock_service.NewMockAddresser(ctrl)
+			publicId, _ := uuid.Parse(tt.publicId)
+			tt.addressMockBehavior(addresses, accountPublicId, publicId)
+			serviceMock := &service.Service{Addresser: addresses}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.DELETE("/account/addresses/:id", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.deleteAddress)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/account/addresses/"+tt.publicId, nil)
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
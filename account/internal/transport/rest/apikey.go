@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Create api key
+// @Tags ApiKey
+// @Description Key of a dealer integration with the scopes: products:read, products:write, stock:read, stock:write.
+// @Description The key is shown once, in this answer
+// @ID createAPIKey
+// @Accept  json
+// @Produce  json
+// @Param input body domain.CreateAPIKeyInput true "key name, scopes and expiration"
+// @Success 201 {object} domain.APIKey
+// @Router /account/api-keys [post]
+func (h *Handler) createAPIKey(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	var input domain.CreateAPIKeyInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	key, err := h.services.CreateAPIKey(accountPublicId, input)
+	h.audit(c, domain.AuditEntry{
+		Action:         domain.AUDIT_API_KEY_CREATED,
+		TargetPublicId: parseAuditTarget(accountPublicId),
+		Details:        key.Prefix,
+	}, err)
+	if !checkAPIKeyError(c, err) {
+		return
+	}
+
+	h.produceAPIKeyEvent(domain.EVENT_API_KEY_CREATED, domain.APIKeyCreatedEvent{
+		Prefix:    key.Prefix,
+		KeyHash:   key.KeyHash,
+		PublicId:  key.AccountPublicId,
+		Scopes:    key.Scopes,
+		ExpiresAt: key.ExpiresAt,
+	})
+	c.JSON(http.StatusCreated, key)
+}
+
+// @Summary Get api keys
+// @Tags ApiKey
+// @Description Own keys not revoked, newest first, without the secrets
+// @ID getAPIKeys
+// @Produce  json
+// @Success 200 {array} domain.APIKey
+// @Router /account/api-keys [get]
+func (h *Handler) getAPIKeys(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	keys, err := h.services.GetAPIKeys(accountPublicId)
+	if !checkAPIKeyError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// @Summary Revoke api key
+// @Tags ApiKey
+// @Description Own key by its prefix, the product service stops accepting it
+// @ID revokeAPIKey
+// @Param prefix path string true "key prefix"
+// @Success 200
+// @Router /account/api-keys/{prefix} [delete]
+func (h *Handler) revokeAPIKey(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	prefix := c.Param("prefix")
+	key, err := h.services.RevokeAPIKey(accountPublicId, prefix)
+	h.audit(c, domain.AuditEntry{
+		Action:         domain.AUDIT_API_KEY_REVOKED,
+		TargetPublicId: parseAuditTarget(accountPublicId),
+		Details:        prefix,
+	}, err)
+	if !checkAPIKeyError(c, err) {
+		return
+	}
+
+	h.produceAPIKeyEvent(domain.EVENT_API_KEY_REVOKED, domain.APIKeyRevokedEvent{
+		Prefix:    key.Prefix,
+		PublicId:  key.AccountPublicId,
+		RevokedAt: *key.RevokedAt,
+	})
+	c.Status(http.StatusOK)
+}
+
+// produceAPIKeyEvent - the product service keeps the copies of the keys to check them
+func (h *Handler) produceAPIKeyEvent(eventType domain.EventType, payload interface{}) {
+	go func() {
+		err := h.broker.Produce(eventType, h.broker.TopicAccountCUD, payload)
+		if err != nil {
+			logrus.Errorf("sent %s event fail: %s/n", eventType, err.Error())
+		}
+	}()
+}
+
+// checkAPIKeyError - false when the error is sent
+func checkAPIKeyError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrAPIKeyNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return false
+	case errors.Is(err, domain.ErrAPIKeyNotAllowed):
+		newErrorResponse(c, http.StatusForbidden, err.Error())
+		return false
+	case errors.Is(err, domain.ErrInvalidAPIKey), errors.Is(err, domain.ErrTooManyAPIKeys):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/broker"
+	mock_broker "github.com/p12s/furniture-store/account/internal/broker/mocks"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/service"
+	mock_service "github.com/p12s/furniture-store/account/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createAPIKey(t *testing.T) {
+	type apiKeyMockBehavior func(s *mock_service.MockAPIKeyer)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	createdAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	input := domain.CreateAPIKeyInput{Name: "ERP sync", Scopes: []string{"products:write", "stock:write"}}
+	key := domain.APIKey{
+		Prefix:          "fsk_0a1b2c3d4e5f",
+		Key:             "fsk_0a1b2c3d4e5f_secret",
+		KeyHash:         "hash",
+		AccountPublicId: uuid.MustParse(accountPublicId),
+		Name:            "ERP sync",
+		Scopes:          []string{"products:write", "stock:write"},
+		ExpiresAt:       createdAt.Add(90 * 24 * time.Hour),
+		CreatedAt:       createdAt,
+	}
+
+	tests := []struct {
+		name                string
+		inputBody           string
+		apiKeyMockBehavior  apiKeyMockBehavior
+		brokerMockProducer  brokerMockProducer
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Can create the key and publish its hash",
+			inputBody: `{"name":"ERP sync","scopes":["products:write","stock:write"]}`,
+			apiKeyMockBehavior: func(s *mock_service.MockAPIKeyer) {
+				s.EXPECT().CreateAPIKey(accountPublicId, input).Return(key, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_API_KEY_CREATED, "", domain.APIKeyCreatedEvent{
+					Prefix:    key.Prefix,
+					KeyHash:   "hash",
+					PublicId:  key.AccountPublicId,
+					Scopes:    key.Scopes,
+					ExpiresAt: key.ExpiresAt,
+				}).Return(nil)
+			},
+			expectedStatusCode:  http.StatusCreated,
+			expectedRequestBody: `{"prefix":"fsk_0a1b2c3d4e5f","key":"fsk_0a1b2c3d4e5f_secret","name":"ERP sync","scopes":["products:write","stock:write"],"expires_at":"2022-04-02T03:04:05Z","last_used_at":null,"created_at":"2022-01-02T03:04:05Z"}`,
+		},
+		{
+			name:      "Can't create the key for not a dealer",
+			inputBody: `{"name":"ERP sync","scopes":["products:write","stock:write"]}`,
+			apiKeyMockBehavior: func(s *mock_service.MockAPIKeyer) {
+				s.EXPECT().CreateAPIKey(accountPublicId, input).Return(domain.APIKey{}, domain.ErrAPIKeyNotAllowed)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusForbidden,
+			expectedRequestBody: `{"message":"api keys are for the dealer accounts"}`,
+		},
+		{
+			name:                "Can't create the key without scopes",
+			inputBody:           `{"name":"ERP sync"}`,
+			apiKeyMockBehavior:  func(s *mock_service.MockAPIKeyer) {},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeys := mock_service.NewMockAPIKeyer(ctrl)
+			tt.apiKeyMockBehavior(apiKeys)
+			serviceMock := &service.Service{APIKeyer: apiKeys, Auditor: anyAudit(ctrl)}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/account/api-keys", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.createAPIKey)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/account/api-keys", bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_revokeAPIKey(t *testing.T) {
+	type apiKeyMockBehavior func(s *mock_service.MockAPIKeyer)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	revokedAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		apiKeyMockBehavior  apiKeyMockBehavior
+		brokerMockProducer  brokerMockProducer
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Can revoke the own key and publish it",
+			apiKeyMockBehavior: func(s *mock_service.MockAPIKeyer) {
+				s.EXPECT().RevokeAPIKey(accountPublicId, "fsk_0a1b2c3d4e5f").Return(domain.APIKey{
+					Prefix:          "fsk_0a1b2c3d4e5f",
+					AccountPublicId: uuid.MustParse(accountPublicId),
+					RevokedAt:       &revokedAt,
+				}, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_API_KEY_REVOKED, "", domain.APIKeyRevokedEvent{
+					Prefix:    "fsk_0a1b2c3d4e5f",
+					PublicId:  uuid.MustParse(accountPublicId),
+					RevokedAt: revokedAt,
+				}).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: ``,
+		},
+		{
+			name: "Can't revoke the key of another account",
+			apiKeyMockBehavior: func(s *mock_service.MockAPIKeyer) {
+				s.EXPECT().RevokeAPIKey(accountPublicId, "fsk_0a1b2c3d4e5f").
+					Return(domain.APIKey{}, domain.ErrAPIKeyNotFound)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedRequestBody: `{"message":"api key not found"}`,
+		},
+		{
+			name: "Can return error response if service failure",
+			apiKeyMockBehavior: func(s *mock_service.MockAPIKeyer) {
+				s.EXPECT().RevokeAPIKey(accountPublicId, "fsk_0a1b2c3d4e5f").Return(domain.APIKey{}, errors.New(""))
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeys := mock_service.NewMockAPIKeyer(ctrl)
+			tt.apiKeyMockBehavior(apiKeys)
+			serviceMock := &service.Service{APIKeyer: apiKeys, Auditor: anyAudit(ctrl)}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.DELETE("/account/api-keys/:prefix", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.revokeAPIKey)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/account/api-keys/fsk_0a1b2c3d4e5f", nil)
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Get activity
+// @Tags Account
+// @Description Recent security activity of own account, newest first
+// @ID getActivity
+// @Produce  json
+// @Param limit query int false "page size, 50 by default, 200 at most"
+// @Param offset query int false "skipped entries"
+// @Success 200 {array} domain.AuditEntry
+// @Router /account/activity [get]
+func (h *Handler) getActivity(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	var page struct {
+		Limit  int `form:"limit"`
+		Offset int `form:"offset"`
+	}
+	if err := c.BindQuery(&page); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid query")
+		return
+	}
+
+	entries, err := h.services.GetActivity(accountPublicId, page.Limit, page.Offset)
+	if !checkAuditError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// @Summary Search audit
+// @Tags Admin
+// @Description Security audit log across the accounts, all set filters must match, newest first
+// @ID searchAudit
+// @Produce  json
+// @Param account query string false "actor or target public_id"
+// @Param actor query string false "actor public_id"
+// @Param target query string false "target public_id"
+// @Param email query string false "email"
+// @Param action query string false "action"
+// @Param outcome query string false "success, failure or challenge"
+// @Param ip query string false "ip"
+// @Param from query string false "RFC3339 time, inclusive"
+// @Param to query string false "RFC3339 time, exclusive"
+// @Param limit query int false "page size, 50 by default, 200 at most"
+// @Param offset query int false "skipped entries"
+// @Success 200 {array} domain.AuditEntry
+// @Router /admin/audit [get]
+func (h *Handler) searchAudit(c *gin.Context) {
+	var filter domain.AuditFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid query")
+		return
+	}
+
+	entries, err := h.services.SearchAudit(filter)
+	if !checkAuditError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// audit - the entry gets the request actor, ip and user agent, the outcome by the error if it is not set.
+// A failed record is logged only, it doesn't fail the action
+func (h *Handler) audit(c *gin.Context, entry domain.AuditEntry, err error) {
+	if actor, e := getAccountPublicId(c); e == nil {
+		if actorPublicId, e := uuid.Parse(actor); e == nil {
+			entry.ActorPublicId = &actorPublicId
+		}
+	}
+	entry.IP = c.ClientIP()
+	entry.UserAgent = c.Request.UserAgent()
+	if entry.Outcome == "" {
+		entry.Outcome = domain.AUDIT_SUCCESS
+		if err != nil {
+			entry.Outcome = domain.AUDIT_FAILURE
+			entry.Details = err.Error()
+		}
+	}
+
+	if err := h.services.RecordAudit(entry); err != nil {
+		logrus.Errorf("record %s audit fail: %s/n", entry.Action, err.Error())
+	}
+}
+
+// auditOwn - the action of the account on itself
+func (h *Handler) auditOwn(c *gin.Context, action domain.AuditAction, err error) {
+	entry := domain.AuditEntry{Action: action}
+	if accountPublicId, e := getAccountPublicId(c); e == nil {
+		if target, e := uuid.Parse(accountPublicId); e == nil {
+			entry.TargetPublicId = &target
+		}
+	}
+	h.audit(c, entry, err)
+}
+
+// auditTarget - nil for the unknown account
+func auditTarget(publicId uuid.UUID) *uuid.UUID {
+	if publicId == uuid.Nil {
+		return nil
+	}
+	return &publicId
+}
+
+// checkAuditError - false when the error is sent
+func checkAuditError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrInvalidAuditFilter):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
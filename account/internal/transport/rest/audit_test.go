@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/service"
+	mock_service "github.com/p12s/furniture-store/account/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// anyAudit - the audit mock for the tests not checking it
+func anyAudit(ctrl *gomock.Controller) *mock_service.MockAuditor {
+	auditor := mock_service.NewMockAuditor(ctrl)
+	auditor.EXPECT().RecordAudit(gomock.Any()).Return(nil).AnyTimes()
+	return auditor
+}
+
+func TestHandler_audit(t *testing.T) {
+	accountPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+
+	t.Run("Can record the failed sign-in with the request ip and user agent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		auditor := mock_service.NewMockAuditor(ctrl)
+		auditor.EXPECT().RecordAudit(domain.AuditEntry{
+			Email:     "test@test.ru",
+			Action:    domain.AUDIT_SIGN_IN,
+			Outcome:   domain.AUDIT_FAILURE,
+			Details:   "invalid email or password",
+			IP:        "192.0.2.1",
+			UserAgent: "test-agent",
+		}).Return(nil)
+		handler := NewHandler(&service.Service{Auditor: auditor}, nil)
+
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("POST", "/sign-in", nil)
+		c.Request.Header.Set("User-Agent", "test-agent")
+		handler.auditSignIn(c, domain.AUDIT_SIGN_IN, "test@test.ru", domain.SignInResult{}, domain.ErrInvalidCredentials)
+	})
+
+	t.Run("Can record the own action with the actor as the target", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		auditor := mock_service.NewMockAuditor(ctrl)
+		auditor.EXPECT().RecordAudit(domain.AuditEntry{
+			ActorPublicId:  &accountPublicId,
+			TargetPublicId: &accountPublicId,
+			Action:         domain.AUDIT_TWO_FACTOR_DISABLED,
+			Outcome:        domain.AUDIT_SUCCESS,
+			IP:             "192.0.2.1",
+		}).Return(nil)
+		handler := NewHandler(&service.Service{Auditor: auditor}, nil)
+
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("POST", "/account/2fa/disable", nil)
+		c.Set(accountCtx, accountPublicId.String())
+		handler.auditOwn(c, domain.AUDIT_TWO_FACTOR_DISABLED, nil)
+	})
+}
+
+func TestHandler_searchAudit(t *testing.T) {
+	type auditMockBehavior func(s *mock_service.MockAuditor)
+
+	from := time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)
+	entry := domain.AuditEntry{
+		Id:        1,
+		Email:     "test@test.ru",
+		Action:    domain.AUDIT_SIGN_IN,
+		Outcome:   domain.AUDIT_FAILURE,
+		IP:        "192.0.2.1",
+		UserAgent: "test-agent",
+		CreatedAt: from,
+	}
+
+	tests := []struct {
+		name                string
+		query               string
+		auditMockBehavior   auditMockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:  "Can search by the filters",
+			query: "?action=sign_in&outcome=failure&from=2022-01-02T00:00:00Z&limit=10",
+			auditMockBehavior: func(s *mock_service.MockAuditor) {
+				s.EXPECT().SearchAudit(domain.AuditFilter{
+					Action:  "sign_in",
+					Outcome: "failure",
+					From:    &from,
+					Limit:   10,
+				}).Return([]domain.AuditEntry{entry}, nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `[{"id":1,"email":"test@test.ru","action":"sign_in","outcome":"failure","ip":"192.0.2.1","user_agent":"test-agent","created_at":"2022-01-02T00:00:00Z"}]`,
+		},
+		{
+			name:                "Can't search by the invalid time",
+			query:               "?from=yesterday",
+			auditMockBehavior:   func(s *mock_service.MockAuditor) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid query"}`,
+		},
+		{
+			name:  "Can't search by the invalid account",
+			query: "?account=invalid",
+			auditMockBehavior: func(s *mock_service.MockAuditor) {
+				s.EXPECT().SearchAudit(domain.AuditFilter{Account: "invalid"}).Return(nil, domain.ErrInvalidAuditFilter)
+			},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid audit filter"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditor := mock_service.NewMockAuditor(ctrl)
+			tt.auditMockBehavior(auditor)
+			serviceMock := &service.Service{Auditor: auditor}
+
+			handler := NewHandler(serviceMock, nil)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/admin/audit", handler.searchAudit)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/admin/audit"+tt.query, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_adminIdentity(t *testing.T) {
+	type accountMockBehavior func(s *mock_service.MockAccounter, accountPublicId string)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+
+	tests := []struct {
+		name                string
+		accountMockBehavior accountMockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Can pass the admin",
+			accountMockBehavior: func(s *mock_service.MockAccounter, accountPublicId string) {
+				s.EXPECT().GetAccount(accountPublicId).Return(domain.Account{Role: domain.ROLE_ADMIN}, nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `ok`,
+		},
+		{
+			name: "Can't pass the customer",
+			accountMockBehavior: func(s *mock_service.MockAccounter, accountPublicId string) {
+				s.EXPECT().GetAccount(accountPublicId).Return(domain.Account{Role: domain.ROLE_CUSTOMER}, nil)
+			},
+			expectedStatusCode:  http.StatusForbidden,
+			expectedRequestBody: `{"message":"admin role required"}`,
+		},
+		{
+			name: "Can't pass the deleted account",
+			accountMockBehavior: func(s *mock_service.MockAccounter, accountPublicId string) {
+				s.EXPECT().GetAccount(accountPublicId).Return(domain.Account{}, errors.New(""))
+			},
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedRequestBody: `{"message":"account not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			acc := mock_service.NewMockAccounter(ctrl)
+			tt.accountMockBehavior(acc, accountPublicId)
+			serviceMock := &service.Service{Accounter: acc}
+
+			handler := NewHandler(serviceMock, nil)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/admin", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.adminIdentity, func(c *gin.Context) {
+				c.String(http.StatusOK, "ok")
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/admin", nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
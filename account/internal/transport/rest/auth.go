@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -10,7 +11,7 @@ import (
 
 // @Summary Sign up
 // @Tags Auth
-// @Description Create account
+// @Description Create account, the verification link is sent to the email
 // @ID signUp
 // @Accept  json
 // @Param input body domain.Account true "credentials"
@@ -23,31 +24,37 @@ func (h *Handler) signUp(c *gin.Context) {
 		return
 	}
 
-	err := h.services.CreateAccount(input)
+	account, err := h.services.CreateAccount(input)
+	h.audit(c, domain.AuditEntry{
+		Action:         domain.AUDIT_SIGN_UP,
+		TargetPublicId: auditTarget(account.PublicId),
+		Email:          input.Email,
+	}, err)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, "service failure")
 		return
 	}
 
-	input.Password = ""
 	go func() {
-		err := h.broker.Produce(domain.EVENT_ACCOUNT_CREATED, h.broker.TopicAccountCUD, input)
+		err := h.broker.Produce(domain.EVENT_ACCOUNT_CREATED, h.broker.TopicAccountCUD, account)
 		if err != nil {
 			logrus.Errorf("sent sign-up event fail: %s/n", err.Error())
 		}
 	}()
+	h.sendEmailVerification(account.PublicId.String())
 
 	c.Status(http.StatusCreated)
 }
 
 // @Summary Sign in
 // @Tags Auth
-// @Description Sending data to get authentication with jwt-token
+// @Description Sending data to get authentication with jwt-token. With two-factor enabled or required
+// @Description for the role there is the challenge token of the next step instead
 // @ID signIn
 // @Accept  json
 // @Produce  json
 // @Param input body domain.SignInInput true "credentials"
-// @Success 200
+// @Success 200 {object} domain.SignInResult
 // @Router /sign-in [post]
 func (h *Handler) signIn(c *gin.Context) {
 	var input domain.SignInInput
@@ -56,20 +63,69 @@ func (h *Handler) signIn(c *gin.Context) {
 		return
 	}
 
-	accountToken, err := h.services.Accounter.GenerateTokenByCreds(input.Email, input.Password)
-	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+	result, err := h.services.TwoFactorer.SignIn(input.Email, input.Password, getClient(c))
+	h.auditSignIn(c, domain.AUDIT_SIGN_IN, input.Email, result, err)
+	h.produceLoginFailed(err)
+	if !checkTwoFactorError(c, err) {
 		return
 	}
 
+	if result.Token != "" {
+		h.produceSignIn(result.Token)
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// getClient - ip and user agent of the request
+func getClient(c *gin.Context) domain.Client {
+	return domain.Client{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+}
+
+// produceSignIn - only the full access token, not the challenge one
+func (h *Handler) produceSignIn(accountToken string) {
 	go func() {
 		err := h.broker.Producer.Produce(domain.EVENT_ACCOUNT_TOKEN_UPDATED, h.broker.TopicAccountCUD, accountToken)
 		if err != nil {
 			logrus.Errorf("sent sign-in event fail: %s/n", err.Error())
 		}
 	}()
+}
+
+// produceLoginFailed - the failure and the locks it caused, so admins can see attacks
+func (h *Handler) produceLoginFailed(err error) {
+	var failed *domain.LoginFailedError
+	if !errors.As(err, &failed) {
+		return
+	}
+	go func() {
+		err := h.broker.Produce(domain.EVENT_ACCOUNT_LOGIN_FAILED, h.broker.TopicAccountBE, failed.Failed)
+		if err != nil {
+			logrus.Errorf("sent login failed event fail: %s/n", err.Error())
+		}
+		for _, locked := range failed.Locked {
+			err := h.broker.Produce(domain.EVENT_ACCOUNT_LOCKED, h.broker.TopicAccountBE, locked)
+			if err != nil {
+				logrus.Errorf("sent account locked event fail: %s/n", err.Error())
+			}
+		}
+	}()
+}
 
-	c.JSON(http.StatusOK, map[string]interface{}{
-		"token": accountToken,
-	})
+// auditSignIn - a step followed by the next one is the challenge
+func (h *Handler) auditSignIn(c *gin.Context, action domain.AuditAction, email string,
+	result domain.SignInResult, err error) {
+	entry := domain.AuditEntry{
+		Action:         action,
+		TargetPublicId: auditTarget(result.AccountPublicId),
+		Email:          email,
+	}
+	var failed *domain.LoginFailedError
+	if errors.As(err, &failed) && failed.Failed.PublicId != nil {
+		entry.TargetPublicId = failed.Failed.PublicId
+	}
+	if err == nil && result.Token == "" {
+		entry.Outcome = domain.AUDIT_CHALLENGE
+		entry.Details = result.NextStep
+	}
+	h.audit(c, entry, err)
 }
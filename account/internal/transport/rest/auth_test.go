@@ -13,6 +13,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"github.com/p12s/furniture-store/account/internal/broker"
 	mock_broker "github.com/p12s/furniture-store/account/internal/broker/mocks"
@@ -45,19 +46,23 @@ func init() {
 func TestHandler_signUp(t *testing.T) {
 
 	type accountMockBehavior func(s *mock_service.MockAccounter, account domain.Account)
+	type verifierMockBehavior func(s *mock_service.MockVerifier)
 	type brokerMockProducer func(s *mock_broker.MockProducer, event domain.EventType, topic string, input interface{})
 
+	publicId, _ := uuid.Parse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+
 	tests := []struct {
-		name                string
-		inputBody           string
-		inputAccount        domain.Account
-		accountMockBehavior accountMockBehavior
-		eventType           domain.EventType
-		topic               string
-		eventData           interface{}
-		brokerMockProducer  brokerMockProducer
-		expectedStatusCode  int
-		expectedRequestBody string
+		name                 string
+		inputBody            string
+		inputAccount         domain.Account
+		accountMockBehavior  accountMockBehavior
+		verifierMockBehavior verifierMockBehavior
+		eventType            domain.EventType
+		topic                string
+		eventData            interface{}
+		brokerMockProducer   brokerMockProducer
+		expectedStatusCode   int
+		expectedRequestBody  string
 	}{
 		{
 			name:      "Can sign up with correct input",
@@ -70,11 +75,17 @@ func TestHandler_signUp(t *testing.T) {
 				Address:  "Some-city, some-street, some-hause",
 			},
 			accountMockBehavior: func(s *mock_service.MockAccounter, account domain.Account) {
-				s.EXPECT().CreateAccount(account).Return(nil)
+				account.PublicId = publicId
+				account.Password = ""
+				s.EXPECT().CreateAccount(gomock.Any()).Return(account, nil)
+			},
+			verifierMockBehavior: func(s *mock_service.MockVerifier) {
+				s.EXPECT().SendEmailVerification(gomock.Any(), publicId.String()).Return(nil)
 			},
 			eventType: domain.EVENT_ACCOUNT_CREATED,
 			topic:     "",
 			eventData: domain.Account{
+				PublicId: publicId,
 				Name:     "Ivan",
 				Username: "ivan",
 				Password: "",
@@ -152,7 +163,7 @@ func TestHandler_signUp(t *testing.T) {
 				Address:  "Some-city, some-street, some-hause",
 			},
 			accountMockBehavior: func(s *mock_service.MockAccounter, account domain.Account) {
-				s.EXPECT().CreateAccount(account).Return(errors.New(""))
+				s.EXPECT().CreateAccount(account).Return(domain.Account{}, errors.New(""))
 			},
 			expectedStatusCode:  http.StatusInternalServerError,
 			expectedRequestBody: `{"message":"service failure"}`,
@@ -166,7 +177,11 @@ func TestHandler_signUp(t *testing.T) {
 
 			acc := mock_service.NewMockAccounter(ctrl)
 			tt.accountMockBehavior(acc, tt.inputAccount)
-			serviceMock := &service.Service{Accounter: acc}
+			verifier := mock_service.NewMockVerifier(ctrl)
+			if tt.verifierMockBehavior != nil {
+				tt.verifierMockBehavior(verifier)
+			}
+			serviceMock := &service.Service{Accounter: acc, Verifier: verifier, Auditor: anyAudit(ctrl)}
 
 			brokerProducer := mock_broker.NewMockProducer(ctrl)
 			var brokerMock *broker.Broker
@@ -193,8 +208,25 @@ func TestHandler_signUp(t *testing.T) {
 }
 
 func TestHandler_signIn(t *testing.T) {
+	failedAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	loginFailed := &domain.LoginFailedError{
+		Err: domain.ErrInvalidCredentials,
+		Failed: domain.LoginFailedEvent{
+			Email:    "test@test.ru",
+			IP:       "192.0.2.1",
+			Reason:   domain.LOGIN_FAILED_PASSWORD,
+			Failures: 5,
+			FailedAt: failedAt,
+		},
+		Locked: []domain.AccountLockedEvent{{
+			Scope:       domain.LOGIN_SCOPE_EMAIL,
+			Email:       "test@test.ru",
+			Failures:    5,
+			LockedUntil: failedAt.Add(time.Minute),
+		}},
+	}
 
-	type accountMockBehavior func(s *mock_service.MockAccounter, input domain.SignInInput)
+	type accountMockBehavior func(s *mock_service.MockTwoFactorer, input domain.SignInInput)
 	type brokerMockProducer func(s *mock_broker.MockProducer, event domain.EventType, topic string, input interface{})
 
 	tests := []struct {
@@ -216,8 +248,8 @@ func TestHandler_signIn(t *testing.T) {
 				Email:    "test@test.ru",
 				Password: "qwerty",
 			},
-			accountMockBehavior: func(s *mock_service.MockAccounter, input domain.SignInInput) {
-				s.EXPECT().GenerateTokenByCreds(input.Email, input.Password).Return("token", nil)
+			accountMockBehavior: func(s *mock_service.MockTwoFactorer, input domain.SignInInput) {
+				s.EXPECT().SignIn(input.Email, input.Password, domain.Client{IP: "192.0.2.1"}).Return(domain.SignInResult{Token: "token"}, nil)
 			},
 			eventType: domain.EVENT_ACCOUNT_TOKEN_UPDATED,
 			topic:     "",
@@ -228,13 +260,61 @@ func TestHandler_signIn(t *testing.T) {
 			expectedStatusCode:  http.StatusOK,
 			expectedRequestBody: `{"token":"token"}`,
 		},
+		{
+			name:      "Can get the challenge token without the sign-in event when two-factor is enabled",
+			inputBody: `{"email": "test@test.ru", "password": "qwerty"}`,
+			inputAccount: domain.SignInInput{
+				Email:    "test@test.ru",
+				Password: "qwerty",
+			},
+			accountMockBehavior: func(s *mock_service.MockTwoFactorer, input domain.SignInInput) {
+				s.EXPECT().SignIn(input.Email, input.Password, domain.Client{IP: "192.0.2.1"}).Return(domain.SignInResult{
+					ChallengeToken: "challenge",
+					NextStep:       domain.SIGN_IN_STEP_TWO_FACTOR,
+				}, nil)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer, event domain.EventType, topic string, input interface{}) {},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `{"challenge_token":"challenge","next_step":"two_factor"}`,
+		},
+		{
+			name:      "Can't sign in with wrong password and publish the failure with the lock",
+			inputBody: `{"email": "test@test.ru", "password": "wrong"}`,
+			inputAccount: domain.SignInInput{
+				Email:    "test@test.ru",
+				Password: "wrong",
+			},
+			accountMockBehavior: func(s *mock_service.MockTwoFactorer, input domain.SignInInput) {
+				s.EXPECT().SignIn(input.Email, input.Password, domain.Client{IP: "192.0.2.1"}).Return(domain.SignInResult{}, loginFailed)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer, event domain.EventType, topic string, input interface{}) {
+				s.EXPECT().Produce(domain.EVENT_ACCOUNT_LOGIN_FAILED, "", loginFailed.Failed).Return(nil)
+				s.EXPECT().Produce(domain.EVENT_ACCOUNT_LOCKED, "", loginFailed.Locked[0]).Return(nil)
+			},
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedRequestBody: `{"message":"invalid email or password"}`,
+		},
+		{
+			name:      "Can't sign in while locked",
+			inputBody: `{"email": "test@test.ru", "password": "qwerty"}`,
+			inputAccount: domain.SignInInput{
+				Email:    "test@test.ru",
+				Password: "qwerty",
+			},
+			accountMockBehavior: func(s *mock_service.MockTwoFactorer, input domain.SignInInput) {
+				s.EXPECT().SignIn(input.Email, input.Password, domain.Client{IP: "192.0.2.1"}).Return(domain.SignInResult{}, domain.ErrLoginLocked)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer, event domain.EventType, topic string, input interface{}) {},
+			expectedStatusCode:  http.StatusTooManyRequests,
+			expectedRequestBody: `{"message":"too many failed sign-in attempts, try later"}`,
+		},
 		{
 			name:      "Can't sign in with input without email",
 			inputBody: `{"password": "qwerty"}`,
 			inputAccount: domain.SignInInput{
 				Password: "qwerty",
 			},
-			accountMockBehavior: func(s *mock_service.MockAccounter, input domain.SignInInput) {},
+			accountMockBehavior: func(s *mock_service.MockTwoFactorer, input domain.SignInInput) {},
 			expectedStatusCode:  http.StatusBadRequest,
 			expectedRequestBody: `{"message":"invalid input body"}`,
 		},
@@ -244,7 +324,7 @@ func TestHandler_signIn(t *testing.T) {
 			inputAccount: domain.SignInInput{
 				Email: "test@test.ru",
 			},
-			accountMockBehavior: func(s *mock_service.MockAccounter, input domain.SignInInput) {},
+			accountMockBehavior: func(s *mock_service.MockTwoFactorer, input domain.SignInInput) {},
 			expectedStatusCode:  http.StatusBadRequest,
 			expectedRequestBody: `{"message":"invalid input body"}`,
 		},
@@ -255,8 +335,8 @@ func TestHandler_signIn(t *testing.T) {
 				Email:    "test@test.ru",
 				Password: "qwerty",
 			},
-			accountMockBehavior: func(s *mock_service.MockAccounter, input domain.SignInInput) {
-				s.EXPECT().GenerateTokenByCreds(input.Email, input.Password).Return("", errors.New(""))
+			accountMockBehavior: func(s *mock_service.MockTwoFactorer, input domain.SignInInput) {
+				s.EXPECT().SignIn(input.Email, input.Password, domain.Client{IP: "192.0.2.1"}).Return(domain.SignInResult{}, errors.New(""))
 			},
 			expectedStatusCode:  http.StatusInternalServerError,
 			expectedRequestBody: `{"message":"service failure"}`,
@@ -268,9 +348,9 @@ func TestHandler_signIn(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			acc := mock_service.NewMockAccounter(ctrl)
-			tt.accountMockBehavior(acc, tt.inputAccount)
-			serviceMock := &service.Service{Accounter: acc}
+			twoFactor := mock_service.NewMockTwoFactorer(ctrl)
+			tt.accountMockBehavior(twoFactor, tt.inputAccount)
+			serviceMock := &service.Service{TwoFactorer: twoFactor, Auditor: anyAudit(ctrl)}
 
 			brokerProducer := mock_broker.NewMockProducer(ctrl)
 			var brokerMock *broker.Broker
@@ -28,12 +28,78 @@ func (h *Handler) InitRoutes() *gin.Engine {
 	router.GET("/health", h.health)
 	router.POST("/sign-up", h.signUp)
 	router.POST("/sign-in", h.signIn)
+	router.POST("/sign-in/2fa", h.signInTwoFactor)
+	router.POST("/sign-in/2fa/enroll", h.signInEnroll)
+	router.POST("/verify-email", h.verifyEmail)
+	router.POST("/password/forgot", h.forgotPassword)
+	router.POST("/password/reset", h.resetPassword)
+	router.GET("/.well-known/openid-configuration", h.discovery)
+	router.GET("/.well-known/jwks.json", h.jwks)
+	router.POST("/oauth/token", h.token)
+
+	oauth := router.Group("/oauth")
+	{
+		oauth.GET("/authorize", h.userIdentity, h.consent)
+		oauth.POST("/authorize", h.userIdentity, h.authorize)
+		oauth.GET("/userinfo", h.oauthIdentity, h.userInfo)
+		oauth.POST("/userinfo", h.oauthIdentity, h.userInfo)
+	}
 
 	account := router.Group("/account", h.userIdentity)
 	{
 		account.GET("/", h.getAccountInfo)
 		account.PUT("/info", h.updateAccount)
 		account.DELETE("/", h.deleteAccount)
+		account.POST("/verify-email/resend", h.resendEmailVerification)
+		account.GET("/activity", h.getActivity)
+		account.POST("/export", h.requestExport)
+		account.GET("/export/:id", h.getExport)
+
+		sessions := account.Group("/sessions")
+		{
+			sessions.GET("", h.getSessions)
+			sessions.DELETE("", h.deleteOtherSessions)
+			sessions.DELETE("/:id", h.deleteSession)
+		}
+
+		apiKeys := account.Group("/api-keys")
+		{
+			apiKeys.GET("", h.getAPIKeys)
+			apiKeys.POST("", h.createAPIKey)
+			apiKeys.DELETE("/:prefix", h.revokeAPIKey)
+		}
+
+		twoFactor := account.Group("/2fa")
+		{
+			twoFactor.POST("/enroll", h.enrollTwoFactor)
+			twoFactor.POST("/enable", h.enableTwoFactor)
+			twoFactor.POST("/disable", h.disableTwoFactor)
+			twoFactor.POST("/recovery-codes", h.regenerateRecoveryCodes)
+		}
+
+		addresses := account.Group("/addresses")
+		{
+			addresses.GET("", h.getAddresses)
+			addresses.POST("", h.createAddress)
+			addresses.GET("/:id", h.getAddress)
+			addresses.PUT("/:id", h.updateAddress)
+			addresses.DELETE("/:id", h.deleteAddress)
+		}
+	}
+
+	admin := router.Group("/admin", h.userIdentity, h.adminIdentity)
+	{
+		admin.GET("/audit", h.searchAudit)
+		admin.GET("/erasures", h.getErasures)
+		admin.GET("/erasures/:id", h.getErasure)
+
+		oauthClients := admin.Group("/oauth/clients")
+		{
+			oauthClients.POST("", h.createOAuthClient)
+			oauthClients.GET("", h.getOAuthClients)
+			oauthClients.GET("/:id", h.getOAuthClient)
+			oauthClients.DELETE("/:id", h.deleteOAuthClient)
+		}
 	}
 
 	return router
@@ -45,7 +111,7 @@ func CORSMiddleware() gin.HandlerFunc {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Credentials", "true")
 		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Header("Access-Control-Allow-Methods", "POST,HEAD,PATCH,OPTIONS,GET,PUT")
+		c.Header("Access-Control-Allow-Methods", "POST,HEAD,PATCH,OPTIONS,GET,PUT,DELETE")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
 			return
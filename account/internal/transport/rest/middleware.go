@@ -6,15 +6,27 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/account/internal/domain"
 )
 
 const (
 	authorizationHandler = "Authorization"
 	accountCtx           = "accountPublicId"
+	sessionCtx           = "sessionPublicId"
 )
 
-// userIdentity - checking token
+// userIdentity - checking the sign-in token, the account and the session of it. The oauth token is refused
 func (h *Handler) userIdentity(c *gin.Context) {
+	h.identity(c, h.services.Accounter.ParseToken)
+}
+
+// oauthIdentity - checking the sign-in or the oauth token, only for the userinfo limited by the token scope
+func (h *Handler) oauthIdentity(c *gin.Context) {
+	h.identity(c, h.services.Accounter.ParseOAuthToken)
+}
+
+// identity - the bearer token parsed to the account and the session
+func (h *Handler) identity(c *gin.Context, parseToken func(token string) (string, string, error)) {
 	header := c.GetHeader(authorizationHandler)
 	if header == "" {
 		newErrorResponse(c, http.StatusUnauthorized, "empty auth header")
@@ -32,13 +44,33 @@ func (h *Handler) userIdentity(c *gin.Context) {
 		return
 	}
 
-	accountId, err := h.services.Accounter.ParseToken(headerParts[1])
+	accountId, sessionId, err := parseToken(headerParts[1])
 	if err != nil {
 		newErrorResponse(c, http.StatusUnauthorized, "invalid token")
 		return
 	}
 
 	c.Set(accountCtx, accountId)
+	c.Set(sessionCtx, sessionId)
+}
+
+// adminIdentity - only for the admin role, after userIdentity
+func (h *Handler) adminIdentity(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, "account public id not found")
+		return
+	}
+
+	account, err := h.services.Accounter.GetAccount(accountPublicId)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, "account not found")
+		return
+	}
+	if account.Role != domain.ROLE_ADMIN {
+		newErrorResponse(c, http.StatusForbidden, "admin role required")
+		return
+	}
 }
 
 // getAccountPublicId - getting current account public_id
@@ -55,3 +87,18 @@ func getAccountPublicId(c *gin.Context) (string, error) {
 
 	return idString, nil
 }
+
+// getSessionPublicId - getting current session public_id
+func getSessionPublicId(c *gin.Context) (string, error) {
+	id, ok := c.Get(sessionCtx)
+	if !ok {
+		return "", errors.New("session public_id not found")
+	}
+
+	idString, ok := id.(string)
+	if !ok {
+		return "", errors.New("session id is of invalid type")
+	}
+
+	return idString, nil
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -8,9 +10,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/p12s/furniture-store/account/internal/broker"
 	mock_broker "github.com/p12s/furniture-store/account/internal/broker/mocks"
+	"github.com/p12s/furniture-store/account/internal/config"
 	"github.com/p12s/furniture-store/account/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/account/internal/repository/mocks"
 	"github.com/p12s/furniture-store/account/internal/service"
 	mock_service "github.com/p12s/furniture-store/account/internal/service/mocks"
 	"github.com/stretchr/testify/assert"
@@ -39,10 +44,11 @@ func TestHandler_userIdentity(t *testing.T) {
 			headerValue: "Bearer " + token,
 			token:       token,
 			accountMockBehavior: func(s *mock_service.MockAccounter, token string) {
-				s.EXPECT().ParseToken(token).Return("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5", nil)
+				s.EXPECT().ParseToken(token).Return("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5",
+					"0b6fb3a4-52ee-4f0e-9d7c-3f5e6c1d2a90", nil)
 			},
 			expectedStatusCode:  http.StatusOK,
-			expectedRequestBody: "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5",
+			expectedRequestBody: "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5 0b6fb3a4-52ee-4f0e-9d7c-3f5e6c1d2a90",
 		},
 		{
 			name:                "Can't identify empty header",
@@ -84,7 +90,7 @@ func TestHandler_userIdentity(t *testing.T) {
 			headerValue: "Bearer " + token,
 			token:       token,
 			accountMockBehavior: func(s *mock_service.MockAccounter, token string) {
-				s.EXPECT().ParseToken(token).Return("", errors.New(""))
+				s.EXPECT().ParseToken(token).Return("", "", errors.New(""))
 			},
 			expectedStatusCode:  http.StatusUnauthorized,
 			expectedRequestBody: `{"message":"invalid token"}`,
@@ -106,7 +112,8 @@ func TestHandler_userIdentity(t *testing.T) {
 			r := gin.New()
 			r.POST("/protected", handler.userIdentity, func(c *gin.Context) {
 				id, _ := c.Get(accountCtx)
-				c.String(http.StatusOK, id.(string))
+				session, _ := c.Get(sessionCtx)
+				c.String(http.StatusOK, id.(string)+" "+session.(string))
 			})
 
 			w := httptest.NewRecorder()
@@ -120,3 +127,74 @@ func TestHandler_userIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_oauthToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	account := domain.Account{PublicId: accountPublicId, Name: "Alex", Email: "alex@mail.com"}
+	secret := "dealer-tool-secret"
+	secretHash := sha256.Sum256([]byte(secret))
+	client := domain.OAuthClient{ClientId: "dealer-tool", Name: "Dealer tool", SecretHash: hex.EncodeToString(secretHash[:]),
+		GrantTypes: []string{domain.GRANT_CLIENT_CREDENTIALS}, Scopes: []string{domain.SCOPE_EMAIL},
+		AccountPublicId: &accountPublicId}
+
+	var session domain.Session
+	accounts := mock_repository.NewMockAccounter(ctrl)
+	accounts.EXPECT().GetAccount(accountPublicId.String()).Return(account, nil).AnyTimes()
+	sessions := mock_repository.NewMockSessioner(ctrl)
+	sessions.EXPECT().CreateSession(gomock.Any()).DoAndReturn(func(s domain.Session) error {
+		session = s
+		return nil
+	})
+	sessions.EXPECT().GetSession(gomock.Any()).DoAndReturn(func(uuid.UUID) (domain.Session, error) {
+		return session, nil
+	}).AnyTimes()
+	sessions.EXPECT().TouchSession(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	clients := mock_repository.NewMockOAuther(ctrl)
+	clients.EXPECT().GetOAuthClient(client.ClientId).Return(client, nil)
+
+	key, err := service.LoadOIDCKey("")
+	assert.NoError(t, err)
+	auth := &config.Auth{TokenTTL: 3600000, SigningKey: "key"}
+	oauth := service.NewOAuthService(clients, accounts, sessions, key, auth, &config.OIDC{Issuer: "http://localhost"})
+	result, err := oauth.Token(domain.TokenInput{GrantType: domain.GRANT_CLIENT_CREDENTIALS, ClientId: client.ClientId,
+		ClientSecret: secret, Scope: domain.SCOPE_EMAIL}, domain.Client{})
+	assert.NoError(t, err)
+
+	handler := NewHandler(&service.Service{
+		Accounter: service.NewAccountService(accounts, nil, sessions, auth),
+		OAuther:   oauth,
+	}, nil)
+	r := handler.InitRoutes()
+
+	for _, route := range []struct{ method, target string }{
+		{"GET", "/account/"},
+		{"PUT", "/account/info"},
+		{"DELETE", "/account/"},
+		{"GET", "/account/sessions"},
+		{"POST", "/account/api-keys"},
+		{"GET", "/oauth/authorize"},
+	} {
+		t.Run("Can't use the scoped token on "+route.method+" "+route.target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(route.method, route.target, nil)
+			req.Header.Set("Authorization", "Bearer "+result.AccessToken)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Equal(t, `{"message":"invalid token"}`, w.Body.String())
+		})
+	}
+
+	t.Run("Can reach the userinfo only, limited by the token scope", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/oauth/userinfo", nil)
+		req.Header.Set("Authorization", "Bearer "+result.AccessToken)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, `{"error":"insufficient_scope","error_description":"openid scope is required"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,314 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+// @Summary OpenID configuration
+// @Tags OAuth
+// @Description Provider metadata: endpoints, scopes, grants and the id token algorithm
+// @ID discovery
+// @Produce  json
+// @Success 200 {object} domain.OIDCDiscovery
+// @Router /.well-known/openid-configuration [get]
+func (h *Handler) discovery(c *gin.Context) {
+	c.JSON(http.StatusOK, h.services.Discovery())
+}
+
+// @Summary JSON web keys
+// @Tags OAuth
+// @Description Public keys of the id token signatures
+// @ID jwks
+// @Produce  json
+// @Success 200 {object} domain.JSONWebKeySet
+// @Router /.well-known/jwks.json [get]
+func (h *Handler) jwks(c *gin.Context) {
+	c.JSON(http.StatusOK, h.services.JWKS())
+}
+
+// @Summary Authorization consent
+// @Tags OAuth
+// @Description The client and the scopes the signed-in account is asked to allow, the consent page shows them.
+// @Description No code is issued yet. The unknown client and the redirect uri not registered are not redirected
+// @ID consent
+// @Produce  json
+// @Param input query domain.AuthorizeInput true "authorization request"
+// @Success 200 {object} domain.ConsentRequest
+// @Router /oauth/authorize [get]
+func (h *Handler) consent(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	var input domain.AuthorizeInput
+	if err := c.BindQuery(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid query")
+		return
+	}
+
+	consent, err := h.services.Consent(accountPublicId, input)
+	if !checkOAuthError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, consent)
+}
+
+// @Summary Authorize
+// @Tags OAuth
+// @Description The consent page posts the authorization request with the decision of the account.
+// @Description The approved one gives the client the authorization code, it is sent by the redirect with the state,
+// @Description the denied one is redirected with access_denied. S256 code challenge is required
+// @ID authorize
+// @Accept  x-www-form-urlencoded
+// @Param input formData domain.ConsentInput true "authorization request and the decision"
+// @Success 302
+// @Router /oauth/authorize [post]
+func (h *Handler) authorize(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	var input domain.ConsentInput
+	if err := c.ShouldBind(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	location, err := h.services.Authorize(accountPublicId, input)
+	h.audit(c, domain.AuditEntry{
+		Action:         domain.AUDIT_OAUTH_AUTHORIZED,
+		TargetPublicId: parseAuditTarget(accountPublicId),
+		Details:        "client " + input.ClientId,
+	}, err)
+	if !checkOAuthError(c, err) {
+		return
+	}
+
+	c.Redirect(http.StatusFound, location)
+}
+
+// @Summary Token
+// @Tags OAuth
+// @Description The access token of authorization_code or client_credentials grant, the id token for openid scope.
+// @Description Client credentials are in the basic auth header or in the form
+// @ID token
+// @Accept  x-www-form-urlencoded
+// @Produce  json
+// @Param input formData domain.TokenInput true "token request"
+// @Success 200 {object} domain.TokenResult
+// @Router /oauth/token [post]
+func (h *Handler) token(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	var input domain.TokenInput
+	if err := c.ShouldBind(&input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, domain.OAuthError{Code: domain.OAUTH_INVALID_REQUEST})
+		return
+	}
+	if clientId, clientSecret, ok := c.Request.BasicAuth(); ok {
+		input.ClientId, _ = url.QueryUnescape(clientId)
+		input.ClientSecret, _ = url.QueryUnescape(clientSecret)
+	}
+
+	result, err := h.services.Token(input, getClient(c))
+	h.audit(c, domain.AuditEntry{
+		Action:         domain.AUDIT_OAUTH_TOKEN_ISSUED,
+		TargetPublicId: auditTarget(result.AccountPublicId),
+		Details:        input.GrantType + " client " + input.ClientId,
+	}, err)
+	if !checkOAuthError(c, err) {
+		return
+	}
+
+	h.produceSignIn(result.AccessToken)
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary User info
+// @Tags OAuth
+// @Description Claims of the token account allowed by its scope, the oauth token needs openid scope.
+// @Description The only route accepting the oauth token
+// @ID userInfo
+// @Produce  json
+// @Success 200 {object} domain.UserInfo
+// @Router /oauth/userinfo [get]
+func (h *Handler) userInfo(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+	sessionPublicId, _ := getSessionPublicId(c)
+
+	info, err := h.services.UserInfo(accountPublicId, sessionPublicId)
+	if !checkOAuthError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
+// @Summary Create oauth client
+// @Tags Admin
+// @Description The client secret of the confidential client is shown once, in this answer
+// @ID createOAuthClient
+// @Accept  json
+// @Produce  json
+// @Param input body domain.CreateOAuthClientInput true "client"
+// @Success 201 {object} domain.OAuthClient
+// @Router /admin/oauth/clients [post]
+func (h *Handler) createOAuthClient(c *gin.Context) {
+	var input domain.CreateOAuthClientInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	client, err := h.services.CreateOAuthClient(input)
+	h.audit(c, domain.AuditEntry{
+		Action:  domain.AUDIT_OAUTH_CLIENT_CREATED,
+		Details: "client " + client.ClientId + " " + input.Name,
+	}, err)
+	if !checkOAuthClientError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, client)
+}
+
+// @Summary Get oauth clients
+// @Tags Admin
+// @Description Registered clients without the secrets
+// @ID getOAuthClients
+// @Produce  json
+// @Success 200 {array} domain.OAuthClient
+// @Router /admin/oauth/clients [get]
+func (h *Handler) getOAuthClients(c *gin.Context) {
+	clients, err := h.services.GetOAuthClients()
+	if !checkOAuthClientError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, clients)
+}
+
+// @Summary Get oauth client
+// @Tags Admin
+// @Description Registered client without the secret
+// @ID getOAuthClient
+// @Produce  json
+// @Param id path string true "client_id"
+// @Success 200 {object} domain.OAuthClient
+// @Router /admin/oauth/clients/{id} [get]
+func (h *Handler) getOAuthClient(c *gin.Context) {
+	client, err := h.services.GetOAuthClient(c.Param("id"))
+	if !checkOAuthClientError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, client)
+}
+
+// @Summary Delete oauth client
+// @Tags Admin
+// @Description The client can't sign in anymore, its access tokens stop working
+// @ID deleteOAuthClient
+// @Param id path string true "client_id"
+// @Success 200
+// @Router /admin/oauth/clients/{id} [delete]
+func (h *Handler) deleteOAuthClient(c *gin.Context) {
+	clientId := c.Param("id")
+	deleted, err := h.services.DeleteOAuthClient(clientId)
+	h.audit(c, domain.AuditEntry{
+		Action:  domain.AUDIT_OAUTH_CLIENT_DELETED,
+		Details: "client " + clientId,
+	}, err)
+	if !checkOAuthClientError(c, err) {
+		return
+	}
+
+	for _, revoked := range deleted.Sessions {
+		h.produceSessionsRevoked(revoked)
+	}
+	c.Status(http.StatusOK)
+}
+
+// parseAuditTarget - nil for the invalid public id
+func parseAuditTarget(publicId string) *uuid.UUID {
+	target, err := uuid.Parse(publicId)
+	if err != nil {
+		return nil
+	}
+	return auditTarget(target)
+}
+
+// checkOAuthError - false when the error is sent. The authorization error with the redirect uri
+// is sent to the client by the redirect, the others as the oauth error answer
+func checkOAuthError(c *gin.Context, err error) bool {
+	var oauthErr *domain.OAuthError
+	switch {
+	case errors.As(err, &oauthErr) && oauthErr.RedirectURI != "":
+		location, e := url.Parse(oauthErr.RedirectURI)
+		if e != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, oauthErr)
+			return false
+		}
+		query := location.Query()
+		query.Set("error", oauthErr.Code)
+		if oauthErr.Description != "" {
+			query.Set("error_description", oauthErr.Description)
+		}
+		if oauthErr.State != "" {
+			query.Set("state", oauthErr.State)
+		}
+		location.RawQuery = query.Encode()
+		c.Redirect(http.StatusFound, location.String())
+		c.Abort()
+		return false
+	case errors.As(err, &oauthErr) && oauthErr.Code == domain.OAUTH_INVALID_CLIENT:
+		c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, oauthErr)
+		return false
+	case errors.As(err, &oauthErr) && oauthErr.Code == domain.OAUTH_INSUFFICIENT_SCOPE:
+		c.AbortWithStatusJSON(http.StatusForbidden, oauthErr)
+		return false
+	case errors.As(err, &oauthErr):
+		c.AbortWithStatusJSON(http.StatusBadRequest, oauthErr)
+		return false
+	case errors.Is(err, domain.ErrSessionNotFound):
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
+
+// checkOAuthClientError - false when the error is sent
+func checkOAuthClientError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrOAuthClientNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return false
+	case errors.Is(err, domain.ErrInvalidOAuthClient):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,457 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/broker"
+	mock_broker "github.com/p12s/furniture-store/account/internal/broker/mocks"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/service"
+	mock_service "github.com/p12s/furniture-store/account/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_consent(t *testing.T) {
+	type oauthMockBehavior func(s *mock_service.MockOAuther)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	query := "response_type=code&client_id=courier-app&redirect_uri=courier%3A%2F%2Fcallback&scope=openid+email" +
+		"&state=xyz&code_challenge=E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM&code_challenge_method=S256"
+	input := domain.AuthorizeInput{
+		ResponseType:        "code",
+		ClientId:            "courier-app",
+		RedirectURI:         "courier://callback",
+		Scope:               "openid email",
+		State:               "xyz",
+		CodeChallenge:       "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		CodeChallengeMethod: "S256",
+	}
+
+	tests := []struct {
+		name                string
+		oauthMockBehavior   oauthMockBehavior
+		expectedStatusCode  int
+		expectedLocation    string
+		expectedRequestBody string
+	}{
+		{
+			name: "Can show the consent without the code",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().Consent(accountPublicId, input).Return(domain.ConsentRequest{ClientId: "courier-app",
+					ClientName: "Courier", RedirectURI: "courier://callback", Scopes: []string{"openid", "email"}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedRequestBody: `{"client_id":"courier-app","client_name":"Courier","redirect_uri":"courier://callback",` +
+				`"scopes":["openid","email"]}`,
+		},
+		{
+			name: "Can redirect the error of the known client with the state",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().Consent(accountPublicId, input).Return(domain.ConsentRequest{}, &domain.OAuthError{
+					Code:        domain.OAUTH_INVALID_SCOPE,
+					RedirectURI: "courier://callback",
+					State:       "xyz",
+				})
+			},
+			expectedStatusCode: http.StatusFound,
+			expectedLocation:   "courier://callback?error=invalid_scope&state=xyz",
+		},
+		{
+			name: "Can't redirect to the uri not registered",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().Consent(accountPublicId, input).Return(domain.ConsentRequest{}, &domain.OAuthError{
+					Code:        domain.OAUTH_INVALID_REQUEST,
+					Description: "redirect uri is not registered",
+				})
+			},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"error":"invalid_request","error_description":"redirect uri is not registered"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			oauth := mock_service.NewMockOAuther(ctrl)
+			tt.oauthMockBehavior(oauth)
+			serviceMock := &service.Service{OAuther: oauth}
+			var brokerMock *broker.Broker
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/oauth/authorize", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.consent)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/oauth/authorize?"+query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+			if tt.expectedRequestBody != "" {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_authorize(t *testing.T) {
+	type oauthMockBehavior func(s *mock_service.MockOAuther)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	query := "response_type=code&client_id=courier-app&redirect_uri=courier%3A%2F%2Fcallback&scope=openid&state=xyz" +
+		"&code_challenge=E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM&code_challenge_method=S256"
+	input := domain.AuthorizeInput{
+		ResponseType:        "code",
+		ClientId:            "courier-app",
+		RedirectURI:         "courier://callback",
+		Scope:               "openid",
+		State:               "xyz",
+		CodeChallenge:       "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		CodeChallengeMethod: "S256",
+	}
+	approved := domain.ConsentInput{AuthorizeInput: input, Approve: true}
+	denied := domain.ConsentInput{AuthorizeInput: input}
+
+	tests := []struct {
+		name                string
+		approve             string
+		oauthMockBehavior   oauthMockBehavior
+		expectedStatusCode  int
+		expectedLocation    string
+		expectedRequestBody string
+	}{
+		{
+			name:    "Can redirect with the code and the state after the consent",
+			approve: "&approve=true",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().Authorize(accountPublicId, approved).Return("courier://callback?code=abc&state=xyz", nil)
+			},
+			expectedStatusCode: http.StatusFound,
+			expectedLocation:   "courier://callback?code=abc&state=xyz",
+		},
+		{
+			name: "Can redirect access denied without the consent",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().Authorize(accountPublicId, denied).Return("", &domain.OAuthError{
+					Code:        domain.OAUTH_ACCESS_DENIED,
+					RedirectURI: "courier://callback",
+					State:       "xyz",
+				})
+			},
+			expectedStatusCode: http.StatusFound,
+			expectedLocation:   "courier://callback?error=access_denied&state=xyz",
+		},
+		{
+			name:    "Can't redirect to the uri not registered",
+			approve: "&approve=true",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().Authorize(accountPublicId, approved).Return("", &domain.OAuthError{
+					Code:        domain.OAUTH_INVALID_REQUEST,
+					Description: "redirect uri is not registered",
+				})
+			},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"error":"invalid_request","error_description":"redirect uri is not registered"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			oauth := mock_service.NewMockOAuther(ctrl)
+			tt.oauthMockBehavior(oauth)
+			serviceMock := &service.Service{OAuther: oauth, Auditor: anyAudit(ctrl)}
+			var brokerMock *broker.Broker
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/oauth/authorize", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.authorize)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/oauth/authorize", strings.NewReader(query+tt.approve))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+			if tt.expectedRequestBody != "" {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_token(t *testing.T) {
+	type oauthMockBehavior func(s *mock_service.MockOAuther)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	accountPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	client := domain.Client{IP: "192.0.2.1"}
+	result := domain.TokenResult{
+		AccountPublicId: accountPublicId,
+		ClientId:        "dealer-tool",
+		AccessToken:     "access",
+		TokenType:       domain.TOKEN_TYPE_BEARER,
+		ExpiresIn:       43200,
+		Scope:           "profile",
+	}
+
+	tests := []struct {
+		name                string
+		body                string
+		basicAuth           bool
+		oauthMockBehavior   oauthMockBehavior
+		brokerMockProducer  brokerMockProducer
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Can issue the client credentials token by the basic auth",
+			body:      "grant_type=client_credentials&scope=profile",
+			basicAuth: true,
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().Token(domain.TokenInput{
+					GrantType:    domain.GRANT_CLIENT_CREDENTIALS,
+					Scope:        "profile",
+					ClientId:     "dealer-tool",
+					ClientSecret: "secret",
+				}, client).Return(result, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ACCOUNT_TOKEN_UPDATED, "", "access").Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `{"access_token":"access","token_type":"Bearer","expires_in":43200,"scope":"profile"}`,
+		},
+		{
+			name: "Can't issue the token for the wrong client secret",
+			body: "grant_type=client_credentials&client_id=dealer-tool&client_secret=wrong",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().Token(domain.TokenInput{
+					GrantType:    domain.GRANT_CLIENT_CREDENTIALS,
+					ClientId:     "dealer-tool",
+					ClientSecret: "wrong",
+				}, client).Return(domain.TokenResult{}, &domain.OAuthError{Code: domain.OAUTH_INVALID_CLIENT})
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedRequestBody: `{"error":"invalid_client"}`,
+		},
+		{
+			name: "Can't issue the token for the used code",
+			body: "grant_type=authorization_code&code=used&client_id=courier-app&redirect_uri=courier%3A%2F%2Fcallback" +
+				"&code_verifier=verifier",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().Token(domain.TokenInput{
+					GrantType:    domain.GRANT_AUTHORIZATION_CODE,
+					Code:         "used",
+					RedirectURI:  "courier://callback",
+					CodeVerifier: "verifier",
+					ClientId:     "courier-app",
+				}, client).Return(domain.TokenResult{}, &domain.OAuthError{Code: domain.OAUTH_INVALID_GRANT})
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"error":"invalid_grant"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			oauth := mock_service.NewMockOAuther(ctrl)
+			tt.oauthMockBehavior(oauth)
+			serviceMock := &service.Service{OAuther: oauth, Auditor: anyAudit(ctrl)}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/oauth/token", handler.token)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/oauth/token", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.RemoteAddr = "192.0.2.1:1234"
+			if tt.basicAuth {
+				req.SetBasicAuth("dealer-tool", "secret")
+			}
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func TestHandler_userInfo(t *testing.T) {
+	type oauthMockBehavior func(s *mock_service.MockOAuther)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	sessionPublicId := "0b6fb3a4-52ee-4f0e-9d7c-3f5e6c1d2a90"
+	role := domain.ROLE_DELIVERY
+
+	tests := []struct {
+		name                string
+		oauthMockBehavior   oauthMockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Can get the claims of the scope",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().UserInfo(accountPublicId, sessionPublicId).
+					Return(domain.UserInfo{Subject: accountPublicId, Name: "Alex", Role: &role}, nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `{"sub":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","name":"Alex","role":2}`,
+		},
+		{
+			name: "Can't get the claims without openid scope",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().UserInfo(accountPublicId, sessionPublicId).Return(domain.UserInfo{},
+					&domain.OAuthError{Code: domain.OAUTH_INSUFFICIENT_SCOPE, Description: "openid scope is required"})
+			},
+			expectedStatusCode:  http.StatusForbidden,
+			expectedRequestBody: `{"error":"insufficient_scope","error_description":"openid scope is required"}`,
+		},
+		{
+			name: "Can return error response if service failure",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().UserInfo(accountPublicId, sessionPublicId).Return(domain.UserInfo{}, errors.New(""))
+			},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			oauth := mock_service.NewMockOAuther(ctrl)
+			tt.oauthMockBehavior(oauth)
+			serviceMock := &service.Service{OAuther: oauth}
+			var brokerMock *broker.Broker
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/oauth/userinfo", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+				c.Set(sessionCtx, sessionPublicId)
+			}, handler.userInfo)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/oauth/userinfo", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteOAuthClient(t *testing.T) {
+	type oauthMockBehavior func(s *mock_service.MockOAuther)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	revoked := domain.SessionsRevokedEvent{
+		PublicId:   uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"),
+		SessionIds: []uuid.UUID{uuid.MustParse("7a8d9b36-5c33-4e47-a5e2-9c8f0a7b0f11")},
+		RevokedAt:  time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+		ExpiresAt:  time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name                string
+		oauthMockBehavior   oauthMockBehavior
+		brokerMockProducer  brokerMockProducer
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Can delete the client and publish its revoked sessions",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().DeleteOAuthClient("courier-app").Return(domain.OAuthClientDeleted{
+					Client:   domain.OAuthClient{ClientId: "courier-app"},
+					Sessions: []domain.SessionsRevokedEvent{revoked},
+				}, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_SESSIONS_REVOKED, "", revoked).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: ``,
+		},
+		{
+			name: "Can't delete the unknown client",
+			oauthMockBehavior: func(s *mock_service.MockOAuther) {
+				s.EXPECT().DeleteOAuthClient("courier-app").
+					Return(domain.OAuthClientDeleted{}, domain.ErrOAuthClientNotFound)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedRequestBody: `{"message":"oauth client not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			oauth := mock_service.NewMockOAuther(ctrl)
+			tt.oauthMockBehavior(oauth)
+			serviceMock := &service.Service{OAuther: oauth, Auditor: anyAudit(ctrl)}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.DELETE("/admin/oauth/clients/:id", handler.deleteOAuthClient)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/admin/oauth/clients/courier-app", nil)
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Forgot password
+// @Tags Auth
+// @Description Reset link to the email, the answer is the same for unknown emails
+// @ID forgotPassword
+// @Accept  json
+// @Param input body domain.ForgotPasswordInput true "account email"
+// @Success 202
+// @Router /password/forgot [post]
+func (h *Handler) forgotPassword(c *gin.Context) {
+	var input domain.ForgotPasswordInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	err := h.services.ForgotPassword(c.Request.Context(), input.Email)
+	h.audit(c, domain.AuditEntry{Action: domain.AUDIT_PASSWORD_FORGOT, Email: input.Email}, err)
+	if !checkPasswordError(c, err) {
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// @Summary Reset password
+// @Tags Auth
+// @Description Token of the reset link, it works once. Existing access tokens and sessions are revoked
+// @ID resetPassword
+// @Accept  json
+// @Param input body domain.ResetPasswordInput true "reset token and new password"
+// @Success 200
+// @Router /password/reset [post]
+func (h *Handler) resetPassword(c *gin.Context) {
+	var input domain.ResetPasswordInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	reset, err := h.services.ResetPassword(input)
+	h.audit(c, domain.AuditEntry{
+		Action:         domain.AUDIT_PASSWORD_RESET,
+		TargetPublicId: auditTarget(reset.PublicId),
+	}, err)
+	if !checkPasswordError(c, err) {
+		return
+	}
+
+	go func() {
+		err := h.broker.Produce(domain.EVENT_ACCOUNT_PASSWORD_RESET, h.broker.TopicAccountBE, reset)
+		if err != nil {
+			logrus.Errorf("sent password reset event fail: %s/n", err.Error())
+		}
+	}()
+	h.produceSessionsRevoked(reset.Sessions)
+
+	c.Status(http.StatusOK)
+}
+
+// checkPasswordError - false when the error is sent
+func checkPasswordError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrInvalidResetToken):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
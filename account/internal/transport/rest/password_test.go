@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/broker"
+	mock_broker "github.com/p12s/furniture-store/account/internal/broker/mocks"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/service"
+	mock_service "github.com/p12s/furniture-store/account/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_forgotPassword(t *testing.T) {
+	type passwordMockBehavior func(s *mock_service.MockPasswordResetter)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		passwordMockBehavior passwordMockBehavior
+		expectedStatusCode   int
+		expectedRequestBody  string
+	}{
+		{
+			name:      "Can ask for the reset link",
+			inputBody: `{"email": "test@test.ru"}`,
+			passwordMockBehavior: func(s *mock_service.MockPasswordResetter) {
+				s.EXPECT().ForgotPassword(gomock.Any(), "test@test.ru").Return(nil)
+			},
+			expectedStatusCode:  http.StatusAccepted,
+			expectedRequestBody: ``,
+		},
+		{
+			name:                 "Can't ask for the reset link with invalid email",
+			inputBody:            `{"email": "test"}`,
+			passwordMockBehavior: func(s *mock_service.MockPasswordResetter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedRequestBody:  `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Can return error response if service failure",
+			inputBody: `{"email": "test@test.ru"}`,
+			passwordMockBehavior: func(s *mock_service.MockPasswordResetter) {
+				s.EXPECT().ForgotPassword(gomock.Any(), "test@test.ru").Return(errors.New(""))
+			},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			password := mock_service.NewMockPasswordResetter(ctrl)
+			tt.passwordMockBehavior(password)
+			serviceMock := &service.Service{PasswordResetter: password, Auditor: anyAudit(ctrl)}
+
+			handler := NewHandler(serviceMock, nil)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/password/forgot", handler.forgotPassword)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/password/forgot", bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_resetPassword(t *testing.T) {
+	type passwordMockBehavior func(s *mock_service.MockPasswordResetter)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	reset := domain.PasswordResetEvent{
+		PublicId: uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"),
+		ResetAt:  time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	resetWithSessions := reset
+	resetWithSessions.Sessions = domain.SessionsRevokedEvent{
+		PublicId:   reset.PublicId,
+		SessionIds: []uuid.UUID{uuid.MustParse("0b6fb3a4-52ee-4f0e-9d7c-3f5e6c1d2a90")},
+		RevokedAt:  reset.ResetAt,
+		ExpiresAt:  reset.ResetAt.Add(12 * time.Hour),
+	}
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		passwordMockBehavior passwordMockBehavior
+		brokerMockProducer   brokerMockProducer
+		expectedStatusCode   int
+		expectedRequestBody  string
+	}{
+		{
+			name:      "Can reset password and publish the event",
+			inputBody: `{"token": "valid", "password": "qwerty"}`,
+			passwordMockBehavior: func(s *mock_service.MockPasswordResetter) {
+				s.EXPECT().ResetPassword(domain.ResetPasswordInput{Token: "valid", Password: "qwerty"}).Return(reset, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ACCOUNT_PASSWORD_RESET, "", reset).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: ``,
+		},
+		{
+			name:      "Can reset password and publish the revoked sessions",
+			inputBody: `{"token": "valid", "password": "qwerty"}`,
+			passwordMockBehavior: func(s *mock_service.MockPasswordResetter) {
+				s.EXPECT().ResetPassword(domain.ResetPasswordInput{Token: "valid", Password: "qwerty"}).
+					Return(resetWithSessions, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ACCOUNT_PASSWORD_RESET, "", resetWithSessions).Return(nil)
+				s.EXPECT().Produce(domain.EVENT_SESSIONS_REVOKED, "", resetWithSessions.Sessions).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: ``,
+		},
+		{
+			name:                 "Can't reset password to a short one",
+			inputBody:            `{"token": "valid", "password": "qwe"}`,
+			passwordMockBehavior: func(s *mock_service.MockPasswordResetter) {},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedRequestBody:  `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Can't reset password with used or expired token",
+			inputBody: `{"token": "used", "password": "qwerty"}`,
+			passwordMockBehavior: func(s *mock_service.MockPasswordResetter) {
+				s.EXPECT().ResetPassword(domain.ResetPasswordInput{Token: "used", Password: "qwerty"}).
+					Return(domain.PasswordResetEvent{}, domain.ErrInvalidResetToken)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid or expired password reset token"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			password := mock_service.NewMockPasswordResetter(ctrl)
+			tt.passwordMockBehavior(password)
+			serviceMock := &service.Service{PasswordResetter: password, Auditor: anyAudit(ctrl)}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/password/reset", handler.resetPassword)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/password/reset", bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
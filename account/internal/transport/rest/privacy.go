@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Request data export
+// @Tags Account
+// @Description Personal data of own account from every service. The account part is ready at once,
+// @Description the others are added as the services answer, the export is completed when all of them answered
+// @ID requestExport
+// @Produce  json
+// @Success 202 {object} domain.PrivacyRequest
+// @Router /account/export [post]
+func (h *Handler) requestExport(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	request, err := h.services.RequestExport(accountPublicId)
+	h.auditOwn(c, domain.AUDIT_DATA_EXPORT_REQUESTED, err)
+	if !checkPrivacyError(c, err) {
+		return
+	}
+
+	h.producePrivacyRequested(domain.EVENT_ACCOUNT_EXPORT_REQUESTED, request)
+	c.JSON(http.StatusAccepted, request)
+}
+
+// @Summary Get data export
+// @Tags Account
+// @Description Data export of own account with the parts of the answered services
+// @ID getExport
+// @Produce  json
+// @Param id path string true "export public_id"
+// @Success 200 {object} domain.PrivacyRequest
+// @Router /account/export/{id} [get]
+func (h *Handler) getExport(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid export public id")
+		return
+	}
+
+	request, err := h.services.GetExport(accountPublicId, publicId)
+	if !checkPrivacyError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// @Summary Get erasures
+// @Tags Admin
+// @Description Account erasure requests, newest first, the services answers are not included
+// @ID getErasures
+// @Produce  json
+// @Param status query string false "pending or completed"
+// @Param limit query int false "page size, 50 by default, 200 at most"
+// @Param offset query int false "skipped requests"
+// @Success 200 {array} domain.PrivacyRequest
+// @Router /admin/erasures [get]
+func (h *Handler) getErasures(c *gin.Context) {
+	var filter domain.PrivacyFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid query")
+		return
+	}
+
+	requests, err := h.services.GetErasures(filter)
+	if !checkPrivacyError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Get erasure
+// @Tags Admin
+// @Description Account erasure request with the services that answered
+// @ID getErasure
+// @Produce  json
+// @Param id path string true "erasure public_id"
+// @Success 200 {object} domain.PrivacyRequest
+// @Router /admin/erasures/{id} [get]
+func (h *Handler) getErasure(c *gin.Context) {
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid erasure public id")
+		return
+	}
+
+	request, err := h.services.GetErasure(publicId)
+	if !checkPrivacyError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// producePrivacyRequested - every service answers on the privacy topic
+func (h *Handler) producePrivacyRequested(event domain.EventType, request domain.PrivacyRequest) {
+	go func() {
+		err := h.broker.Produce(event, h.broker.TopicAccountBE, domain.PrivacyRequestedEvent{
+			RequestId: request.PublicId,
+			PublicId:  request.AccountPublicId,
+		})
+		if err != nil {
+			logrus.Errorf("sent %s event fail: %s/n", event, err.Error())
+		}
+	}()
+}
+
+// auditErasure - the entry has no email, ip and user agent, the erased account must not be recognized by them
+func (h *Handler) auditErasure(request domain.PrivacyRequest) {
+	entry := domain.AuditEntry{
+		ActorPublicId:  &request.AccountPublicId,
+		TargetPublicId: &request.AccountPublicId,
+		Action:         domain.AUDIT_ACCOUNT_DELETED,
+		Outcome:        domain.AUDIT_SUCCESS,
+		Details:        "erasure " + request.PublicId.String(),
+	}
+	if err := h.services.RecordAudit(entry); err != nil {
+		logrus.Errorf("record %s audit fail: %s/n", entry.Action, err.Error())
+	}
+}
+
+// checkPrivacyError - false when the error is sent
+func checkPrivacyError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrPrivacyRequestNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return false
+	case errors.Is(err, domain.ErrInvalidPrivacyFilter):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
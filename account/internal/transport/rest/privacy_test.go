@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/broker"
+	mock_broker "github.com/p12s/furniture-store/account/internal/broker/mocks"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/service"
+	mock_service "github.com/p12s/furniture-store/account/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_requestExport(t *testing.T) {
+	type privacyMockBehavior func(s *mock_service.MockPrivacier)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	createdAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	request := domain.PrivacyRequest{
+		PublicId:        uuid.MustParse("4e1f7c2a-8b3d-4a6e-9f0c-5d2b8a7e1c3f"),
+		AccountPublicId: uuid.MustParse(accountPublicId),
+		Kind:            domain.PRIVACY_EXPORT,
+		Status:          domain.PRIVACY_PENDING,
+		Services:        []string{"account", "product"},
+		Parts: []domain.PrivacyPart{{
+			Service:     "account",
+			Data:        json.RawMessage(`{"account":{"name":"Alex"}}`),
+			CompletedAt: createdAt,
+		}},
+		CreatedAt: createdAt,
+	}
+
+	tests := []struct {
+		name                string
+		privacyMockBehavior privacyMockBehavior
+		brokerMockProducer  brokerMockProducer
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Can request the export with the account part and ask the services",
+			privacyMockBehavior: func(s *mock_service.MockPrivacier) {
+				s.EXPECT().RequestExport(accountPublicId).Return(request, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ACCOUNT_EXPORT_REQUESTED, "", domain.PrivacyRequestedEvent{
+					RequestId: request.PublicId,
+					PublicId:  request.AccountPublicId,
+				}).Return(nil)
+			},
+			expectedStatusCode:  http.StatusAccepted,
+			expectedRequestBody: `{"public_id":"4e1f7c2a-8b3d-4a6e-9f0c-5d2b8a7e1c3f","account_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","kind":"export","status":"pending","services":["account","product"],"parts":[{"service":"account","data":{"account":{"name":"Alex"}},"completed_at":"2022-01-02T03:04:05Z"}],"created_at":"2022-01-02T03:04:05Z"}`,
+		},
+		{
+			name: "Can return error response if service failure",
+			privacyMockBehavior: func(s *mock_service.MockPrivacier) {
+				s.EXPECT().RequestExport(accountPublicId).Return(domain.PrivacyRequest{}, errors.New(""))
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			privacy := mock_service.NewMockPrivacier(ctrl)
+			tt.privacyMockBehavior(privacy)
+			serviceMock := &service.Service{Privacier: privacy, Auditor: anyAudit(ctrl)}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/account/export", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.requestExport)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/account/export", nil)
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getExport(t *testing.T) {
+	type privacyMockBehavior func(s *mock_service.MockPrivacier, publicId uuid.UUID)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+
+	tests := []struct {
+		name                string
+		publicId            string
+		privacyMockBehavior privacyMockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:     "Can get own export",
+			publicId: "4e1f7c2a-8b3d-4a6e-9f0c-5d2b8a7e1c3f",
+			privacyMockBehavior: func(s *mock_service.MockPrivacier, publicId uuid.UUID) {
+				s.EXPECT().GetExport(accountPublicId, publicId).Return(domain.PrivacyRequest{
+					PublicId:        publicId,
+					AccountPublicId: uuid.MustParse(accountPublicId),
+					Kind:            domain.PRIVACY_EXPORT,
+					Status:          domain.PRIVACY_PENDING,
+					Services:        []string{"account"},
+					CreatedAt:       time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+				}, nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `{"public_id":"4e1f7c2a-8b3d-4a6e-9f0c-5d2b8a7e1c3f","account_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","kind":"export","status":"pending","services":["account"],"created_at":"2022-01-02T03:04:05Z"}`,
+		},
+		{
+			name:                "Can't get export with invalid public id",
+			publicId:            "invalid",
+			privacyMockBehavior: func(s *mock_service.MockPrivacier, publicId uuid.UUID) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid export public id"}`,
+		},
+		{
+			name:     "Can't get export of another account",
+			publicId: "4e1f7c2a-8b3d-4a6e-9f0c-5d2b8a7e1c3f",
+			privacyMockBehavior: func(s *mock_service.MockPrivacier, publicId uuid.UUID) {
+				s.EXPECT().GetExport(accountPublicId, publicId).
+					Return(domain.PrivacyRequest{}, domain.ErrPrivacyRequestNotFound)
+			},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedRequestBody: `{"message":"privacy request not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			privacy := mock_service.NewMockPrivacier(ctrl)
+			publicId, _ := uuid.Parse(tt.publicId)
+			tt.privacyMockBehavior(privacy, publicId)
+			serviceMock := &service.Service{Privacier: privacy}
+			var brokerMock *broker.Broker
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/account/export/:id", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.getExport)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/account/export/"+tt.publicId, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getErasures(t *testing.T) {
+	type privacyMockBehavior func(s *mock_service.MockPrivacier)
+
+	tests := []struct {
+		name                string
+		query               string
+		privacyMockBehavior privacyMockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:  "Can get pending erasures",
+			query: "?status=pending&limit=10",
+			privacyMockBehavior: func(s *mock_service.MockPrivacier) {
+				s.EXPECT().GetErasures(domain.PrivacyFilter{Status: domain.PRIVACY_PENDING, Limit: 10}).
+					Return([]domain.PrivacyRequest{{
+						PublicId:        uuid.MustParse("9c0e5d0a-3b8f-4f7e-a1f2-6d4c2b1a0e9f"),
+						AccountPublicId: uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"),
+						Kind:            domain.PRIVACY_ERASURE,
+						Status:          domain.PRIVACY_PENDING,
+						Services:        []string{"account", "billing"},
+						CreatedAt:       time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+					}}, nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `[{"public_id":"9c0e5d0a-3b8f-4f7e-a1f2-6d4c2b1a0e9f","account_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","kind":"erasure","status":"pending","services":["account","billing"],"created_at":"2022-01-02T03:04:05Z"}]`,
+		},
+		{
+			name:  "Can't get erasures by unknown status",
+			query: "?status=lost",
+			privacyMockBehavior: func(s *mock_service.MockPrivacier) {
+				s.EXPECT().GetErasures(domain.PrivacyFilter{Status: "lost"}).
+					Return(nil, domain.ErrInvalidPrivacyFilter)
+			},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid privacy filter"}`,
+		},
+		{
+			name:                "Can't get erasures with invalid query",
+			query:               "?limit=ten",
+			privacyMockBehavior: func(s *mock_service.MockPrivacier) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid query"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			privacy := mock_service.NewMockPrivacier(ctrl)
+			tt.privacyMockBehavior(privacy)
+			serviceMock := &service.Service{Privacier: privacy}
+			var brokerMock *broker.Broker
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/admin/erasures", handler.getErasures)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/admin/erasures"+tt.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Get sessions
+// @Tags Session
+// @Description Active sessions of own account, recently seen first. The session of the request is current
+// @ID getSessions
+// @Produce  json
+// @Success 200 {array} domain.Session
+// @Router /account/sessions [get]
+func (h *Handler) getSessions(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+	sessionPublicId, _ := getSessionPublicId(c)
+
+	sessions, err := h.services.GetSessions(accountPublicId, sessionPublicId)
+	if !checkSessionError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// @Summary Revoke session
+// @Tags Session
+// @Description Active session of own account, the current one too, its access token stops working
+// @ID deleteSession
+// @Param id path string true "session public_id"
+// @Success 200
+// @Router /account/sessions/{id} [delete]
+func (h *Handler) deleteSession(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid session public id")
+		return
+	}
+
+	revoked, err := h.services.RevokeSession(accountPublicId, publicId)
+	h.auditOwn(c, domain.AUDIT_SESSION_REVOKED, err)
+	if !checkSessionError(c, err) {
+		return
+	}
+
+	h.produceSessionsRevoked(revoked)
+	c.Status(http.StatusOK)
+}
+
+// @Summary Revoke other sessions
+// @Tags Session
+// @Description All active sessions of own account except the current one
+// @ID deleteOtherSessions
+// @Success 200
+// @Router /account/sessions [delete]
+func (h *Handler) deleteOtherSessions(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+	sessionPublicId, _ := getSessionPublicId(c)
+
+	revoked, err := h.services.RevokeOtherSessions(accountPublicId, sessionPublicId)
+	h.auditOwn(c, domain.AUDIT_OTHER_SESSIONS_REVOKED, err)
+	if !checkSessionError(c, err) {
+		return
+	}
+
+	h.produceSessionsRevoked(revoked)
+	c.Status(http.StatusOK)
+}
+
+// produceSessionsRevoked - the other services stop accepting the tokens of the sessions
+func (h *Handler) produceSessionsRevoked(revoked domain.SessionsRevokedEvent) {
+	if len(revoked.SessionIds) == 0 {
+		return
+	}
+	go func() {
+		err := h.broker.Produce(domain.EVENT_SESSIONS_REVOKED, h.broker.TopicAccountCUD, revoked)
+		if err != nil {
+			logrus.Errorf("sent sessions revoked event fail: %s/n", err.Error())
+		}
+	}()
+}
+
+// checkSessionError - false when the error is sent
+func checkSessionError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrSessionNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/broker"
+	mock_broker "github.com/p12s/furniture-store/account/internal/broker/mocks"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/service"
+	mock_service "github.com/p12s/furniture-store/account/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_getSessions(t *testing.T) {
+	type sessionMockBehavior func(s *mock_service.MockSessioner)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	sessionPublicId := "0b6fb3a4-52ee-4f0e-9d7c-3f5e6c1d2a90"
+	createdAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		sessionMockBehavior sessionMockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Can get own sessions with the current one marked",
+			sessionMockBehavior: func(s *mock_service.MockSessioner) {
+				s.EXPECT().GetSessions(accountPublicId, sessionPublicId).Return([]domain.Session{{
+					PublicId:   uuid.MustParse(sessionPublicId),
+					Device:     "Firefox on Linux",
+					UserAgent:  "Mozilla/5.0 (X11; Linux x86_64; rv:95.0) Gecko/20100101 Firefox/95.0",
+					IP:         "192.0.2.1",
+					CreatedAt:  createdAt,
+					LastSeenAt: createdAt,
+					ExpiresAt:  createdAt.Add(12 * time.Hour),
+					Current:    true,
+				}}, nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `[{"public_id":"0b6fb3a4-52ee-4f0e-9d7c-3f5e6c1d2a90","device":"Firefox on Linux","user_agent":"Mozilla/5.0 (X11; Linux x86_64; rv:95.0) Gecko/20100101 Firefox/95.0","ip":"192.0.2.1","created_at":"2022-01-02T03:04:05Z","last_seen_at":"2022-01-02T03:04:05Z","expires_at":"2022-01-02T15:04:05Z","current":true}]`,
+		},
+		{
+			name: "Can return error response if service failure",
+			sessionMockBehavior: func(s *mock_service.MockSessioner) {
+				s.EXPECT().GetSessions(accountPublicId, sessionPublicId).Return(nil, errors.New(""))
+			},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessions := mock_service.NewMockSessioner(ctrl)
+			tt.sessionMockBehavior(sessions)
+			serviceMock := &service.Service{Sessioner: sessions}
+			var brokerMock *broker.Broker
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/account/sessions", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+				c.Set(sessionCtx, sessionPublicId)
+			}, handler.getSessions)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/account/sessions", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteSession(t *testing.T) {
+	type sessionMockBehavior func(s *mock_service.MockSessioner, publicId uuid.UUID)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	revoked := domain.SessionsRevokedEvent{
+		PublicId:   uuid.MustParse(accountPublicId),
+		SessionIds: []uuid.UUID{uuid.MustParse("7a8d9b36-5c33-4e47-a5e2-9c8f0a7b0f11")},
+		RevokedAt:  time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+		ExpiresAt:  time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name                string
+		publicId            string
+		sessionMockBehavior sessionMockBehavior
+		brokerMockProducer  brokerMockProducer
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:     "Can revoke own session and publish it",
+			publicId: "7a8d9b36-5c33-4e47-a5e2-9c8f0a7b0f11",
+			sessionMockBehavior: func(s *mock_service.MockSessioner, publicId uuid.UUID) {
+				s.EXPECT().RevokeSession(accountPublicId, publicId).Return(revoked, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_SESSIONS_REVOKED, "", revoked).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: ``,
+		},
+		{
+			name:                "Can't revoke session with invalid public id",
+			publicId:            "invalid",
+			sessionMockBehavior: func(s *mock_service.MockSessioner, publicId uuid.UUID) {},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid session public id"}`,
+		},
+		{
+			name:     "Can't revoke session of another account or already revoked",
+			publicId: "7a8d9b36-5c33-4e47-a5e2-9c8f0a7b0f11",
+			sessionMockBehavior: func(s *mock_service.MockSessioner, publicId uuid.UUID) {
+				s.EXPECT().RevokeSession(accountPublicId, publicId).
+					Return(domain.SessionsRevokedEvent{}, domain.ErrSessionNotFound)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedRequestBody: `{"message":"session not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessions := mock_service.NewMockSessioner(ctrl)
+			publicId, _ := uuid.Parse(tt.publicId)
+			tt.sessionMockBehavior(sessions, publicId)
+			serviceMock := &service.Service{Sessioner: sessions, Auditor: anyAudit(ctrl)}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.DELETE("/account/sessions/:id", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.deleteSession)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/account/sessions/"+tt.publicId, nil)
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteOtherSessions(t *testing.T) {
+	type sessionMockBehavior func(s *mock_service.MockSessioner)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	sessionPublicId := "0b6fb3a4-52ee-4f0e-9d7c-3f5e6c1d2a90"
+	revoked := domain.SessionsRevokedEvent{
+		PublicId: uuid.MustParse(accountPublicId),
+		SessionIds: []uuid.UUID{
+			uuid.MustParse("7a8d9b36-5c33-4e47-a5e2-9c8f0a7b0f11"),
+			uuid.MustParse("3f1c2d4e-6a7b-4c8d-9e0f-1a2b3c4d5e6f"),
+		},
+		RevokedAt: time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+		ExpiresAt: time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name                string
+		sessionMockBehavior sessionMockBehavior
+		brokerMockProducer  brokerMockProducer
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Can revoke the other sessions and publish them",
+			sessionMockBehavior: func(s *mock_service.MockSessioner) {
+				s.EXPECT().RevokeOtherSessions(accountPublicId, sessionPublicId).Return(revoked, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_SESSIONS_REVOKED, "", revoked).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: ``,
+		},
+		{
+			name: "Can revoke nothing without the event when there are no other sessions",
+			sessionMockBehavior: func(s *mock_service.MockSessioner) {
+				s.EXPECT().RevokeOtherSessions(accountPublicId, sessionPublicId).
+					Return(domain.SessionsRevokedEvent{PublicId: revoked.PublicId}, nil)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessions := mock_service.NewMockSessioner(ctrl)
+			tt.sessionMockBehavior(sessions)
+			serviceMock := &service.Service{Sessioner: sessions, Auditor: anyAudit(ctrl)}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.DELETE("/account/sessions", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+				c.Set(sessionCtx, sessionPublicId)
+			}, handler.deleteOtherSessions)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/account/sessions", nil)
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
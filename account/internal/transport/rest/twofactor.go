@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/account/internal/domain"
+)
+
+// @Summary Sign in two-factor step
+// @Tags Auth
+// @Description Totp or recovery code for the challenge token of sign-in. The first code of the enrollment
+// @Description enables two-factor, then the recovery codes are in the answer
+// @ID signInTwoFactor
+// @Accept  json
+// @Produce  json
+// @Param input body domain.SignInChallengeInput true "challenge token and code"
+// @Success 200 {object} domain.SignInResult
+// @Router /sign-in/2fa [post]
+func (h *Handler) signInTwoFactor(c *gin.Context) {
+	var input domain.SignInChallengeInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	result, err := h.services.SignInTwoFactor(input, getClient(c))
+	h.auditSignIn(c, domain.AUDIT_SIGN_IN_TWO_FACTOR, "", result, err)
+	h.produceLoginFailed(err)
+	if !checkTwoFactorError(c, err) {
+		return
+	}
+
+	h.produceSignIn(result.Token)
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Sign in two-factor enrollment
+// @Tags Auth
+// @Description The secret for the role requiring two-factor, the challenge token must be of the enroll step
+// @ID signInEnroll
+// @Accept  json
+// @Produce  json
+// @Param input body domain.SignInEnrollInput true "challenge token"
+// @Success 200 {object} domain.TwoFactorEnrollment
+// @Router /sign-in/2fa/enroll [post]
+func (h *Handler) signInEnroll(c *gin.Context) {
+	var input domain.SignInEnrollInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	enrollment, err := h.services.SignInEnroll(input.ChallengeToken)
+	h.audit(c, domain.AuditEntry{
+		Action:         domain.AUDIT_TWO_FACTOR_ENROLLED,
+		TargetPublicId: auditTarget(enrollment.AccountPublicId),
+	}, err)
+	if !checkTwoFactorError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// @Summary Enroll two-factor
+// @Tags Account
+// @Description New totp secret of own account, it works after the enable
+// @ID enrollTwoFactor
+// @Produce  json
+// @Success 200 {object} domain.TwoFactorEnrollment
+// @Router /account/2fa/enroll [post]
+func (h *Handler) enrollTwoFactor(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	enrollment, err := h.services.EnrollTwoFactor(accountPublicId)
+	h.auditOwn(c, domain.AUDIT_TWO_FACTOR_ENROLLED, err)
+	if !checkTwoFactorError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// @Summary Enable two-factor
+// @Tags Account
+// @Description The enrollment is confirmed by the current totp code, the recovery codes are shown once
+// @ID enableTwoFactor
+// @Accept  json
+// @Produce  json
+// @Param input body domain.TwoFactorCodeInput true "totp code"
+// @Success 200 {object} domain.RecoveryCodes
+// @Router /account/2fa/enable [post]
+func (h *Handler) enableTwoFactor(c *gin.Context) {
+	accountPublicId, input, ok := h.twoFactorCodeInput(c)
+	if !ok {
+		return
+	}
+
+	codes, err := h.services.EnableTwoFactor(accountPublicId, input.Code)
+	h.auditOwn(c, domain.AUDIT_TWO_FACTOR_ENABLED, err)
+	if !checkTwoFactorError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+// @Summary Disable two-factor
+// @Tags Account
+// @Description By totp or recovery code, the roles requiring two-factor can't disable it
+// @ID disableTwoFactor
+// @Accept  json
+// @Param input body domain.TwoFactorCodeInput true "totp or recovery code"
+// @Success 200
+// @Router /account/2fa/disable [post]
+func (h *Handler) disableTwoFactor(c *gin.Context) {
+	accountPublicId, input, ok := h.twoFactorCodeInput(c)
+	if !ok {
+		return
+	}
+
+	err := h.services.DisableTwoFactor(accountPublicId, input.Code)
+	h.auditOwn(c, domain.AUDIT_TWO_FACTOR_DISABLED, err)
+	if !checkTwoFactorError(c, err) {
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Regenerate recovery codes
+// @Tags Account
+// @Description By totp or recovery code, the old recovery codes stop working
+// @ID regenerateRecoveryCodes
+// @Accept  json
+// @Produce  json
+// @Param input body domain.TwoFactorCodeInput true "totp or recovery code"
+// @Success 200 {object} domain.RecoveryCodes
+// @Router /account/2fa/recovery-codes [post]
+func (h *Handler) regenerateRecoveryCodes(c *gin.Context) {
+	accountPublicId, input, ok := h.twoFactorCodeInput(c)
+	if !ok {
+		return
+	}
+
+	codes, err := h.services.RegenerateRecoveryCodes(accountPublicId, input.Code)
+	h.auditOwn(c, domain.AUDIT_RECOVERY_CODES_REGENERATED, err)
+	if !checkTwoFactorError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+// twoFactorCodeInput - false when the error is sent
+func (h *Handler) twoFactorCodeInput(c *gin.Context) (string, domain.TwoFactorCodeInput, bool) {
+	var input domain.TwoFactorCodeInput
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return "", input, false
+	}
+
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return "", input, false
+	}
+	return accountPublicId, input, true
+}
+
+// checkTwoFactorError - false when the error is sent
+func checkTwoFactorError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCredentials), errors.Is(err, domain.ErrInvalidTwoFactorCode),
+		errors.Is(err, domain.ErrInvalidChallenge):
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return false
+	case errors.Is(err, domain.ErrLoginLocked):
+		newErrorResponse(c, http.StatusTooManyRequests, err.Error())
+		return false
+	case errors.Is(err, domain.ErrTwoFactorRequiredForRole):
+		newErrorResponse(c, http.StatusForbidden, err.Error())
+		return false
+	case errors.Is(err, domain.ErrTwoFactorNotEnabled), errors.Is(err, domain.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, domain.ErrTwoFactorNotEnrolled):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/p12s/furniture-store/account/internal/broker"
+	mock_broker "github.com/p12s/furniture-store/account/internal/broker/mocks"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/service"
+	mock_service "github.com/p12s/furniture-store/account/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_signInTwoFactor(t *testing.T) {
+	type twoFactorMockBehavior func(s *mock_service.MockTwoFactorer)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	tests := []struct {
+		name                  string
+		inputBody             string
+		twoFactorMockBehavior twoFactorMockBehavior
+		brokerMockProducer    brokerMockProducer
+		expectedStatusCode    int
+		expectedRequestBody   string
+	}{
+		{
+			name:      "Can sign in by the code of the challenge",
+			inputBody: `{"challenge_token": "challenge", "code": "123456"}`,
+			twoFactorMockBehavior: func(s *mock_service.MockTwoFactorer) {
+				s.EXPECT().SignInTwoFactor(domain.SignInChallengeInput{ChallengeToken: "challenge", Code: "123456"}, domain.Client{IP: "192.0.2.1"}).
+					Return(domain.SignInResult{Token: "token"}, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ACCOUNT_TOKEN_UPDATED, "", "token").Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `{"token":"token"}`,
+		},
+		{
+			name:      "Can sign in by the first code of the enrollment and get the recovery codes",
+			inputBody: `{"challenge_token": "challenge", "code": "123456"}`,
+			twoFactorMockBehavior: func(s *mock_service.MockTwoFactorer) {
+				s.EXPECT().SignInTwoFactor(domain.SignInChallengeInput{ChallengeToken: "challenge", Code: "123456"}, domain.Client{IP: "192.0.2.1"}).
+					Return(domain.SignInResult{Token: "token", RecoveryCodes: []string{"abcd-efgh-ijkl-mnop"}}, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ACCOUNT_TOKEN_UPDATED, "", "token").Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `{"token":"token","recovery_codes":["abcd-efgh-ijkl-mnop"]}`,
+		},
+		{
+			name:                  "Can't sign in without the code",
+			inputBody:             `{"challenge_token": "challenge"}`,
+			twoFactorMockBehavior: func(s *mock_service.MockTwoFactorer) {},
+			brokerMockProducer:    func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:    http.StatusBadRequest,
+			expectedRequestBody:   `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Can't sign in by the wrong code",
+			inputBody: `{"challenge_token": "challenge", "code": "000000"}`,
+			twoFactorMockBehavior: func(s *mock_service.MockTwoFactorer) {
+				s.EXPECT().SignInTwoFactor(domain.SignInChallengeInput{ChallengeToken: "challenge", Code: "000000"}, domain.Client{IP: "192.0.2.1"}).
+					Return(domain.SignInResult{}, domain.ErrInvalidTwoFactorCode)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedRequestBody: `{"message":"invalid two-factor code"}`,
+		},
+		{
+			name:      "Can't sign in by the expired challenge",
+			inputBody: `{"challenge_token": "expired", "code": "123456"}`,
+			twoFactorMockBehavior: func(s *mock_service.MockTwoFactorer) {
+				s.EXPECT().SignInTwoFactor(domain.SignInChallengeInput{ChallengeToken: "expired", Code: "123456"}, domain.Client{IP: "192.0.2.1"}).
+					Return(domain.SignInResult{}, domain.ErrInvalidChallenge)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedRequestBody: `{"message":"invalid or expired sign-in challenge"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			twoFactor := mock_service.NewMockTwoFactorer(ctrl)
+			tt.twoFactorMockBehavior(twoFactor)
+			serviceMock := &service.Service{TwoFactorer: twoFactor, Auditor: anyAudit(ctrl)}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/sign-in/2fa", handler.signInTwoFactor)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/sign-in/2fa", bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_disableTwoFactor(t *testing.T) {
+	type twoFactorMockBehavior func(s *mock_service.MockTwoFactorer, accountPublicId string)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+
+	tests := []struct {
+		name                  string
+		inputBody             string
+		twoFactorMockBehavior twoFactorMockBehavior
+		expectedStatusCode    int
+		expectedRequestBody   string
+	}{
+		{
+			name:      "Can disable two-factor by the code",
+			inputBody: `{"code": "123456"}`,
+			twoFactorMockBehavior: func(s *mock_service.MockTwoFactorer, accountPublicId string) {
+				s.EXPECT().DisableTwoFactor(accountPublicId, "123456").Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: ``,
+		},
+		{
+			name:      "Can't disable two-factor required for the role",
+			inputBody: `{"code": "123456"}`,
+			twoFactorMockBehavior: func(s *mock_service.MockTwoFactorer, accountPublicId string) {
+				s.EXPECT().DisableTwoFactor(accountPublicId, "123456").Return(domain.ErrTwoFactorRequiredForRole)
+			},
+			expectedStatusCode:  http.StatusForbidden,
+			expectedRequestBody: `{"message":"two-factor authentication is required for the account role"}`,
+		},
+		{
+			name:      "Can't disable not enabled two-factor",
+			inputBody: `{"code": "123456"}`,
+			twoFactorMockBehavior: func(s *mock_service.MockTwoFactorer, accountPublicId string) {
+				s.EXPECT().DisableTwoFactor(accountPublicId, "123456").Return(domain.ErrTwoFactorNotEnabled)
+			},
+			expectedStatusCode:  http.StatusConflict,
+			expectedRequestBody: `{"message":"two-factor authentication is not enabled"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			twoFactor := mock_service.NewMockTwoFactorer(ctrl)
+			tt.twoFactorMockBehavior(twoFactor, accountPublicId)
+			serviceMock := &service.Service{TwoFactorer: twoFactor, Auditor: anyAudit(ctrl)}
+
+			handler := NewHandler(serviceMock, nil)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/account/2fa/disable", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.disableTwoFactor)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/account/2fa/disable", bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
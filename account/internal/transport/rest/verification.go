@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Verify email
+// @Tags Auth
+// @Description Token of the verification link, it works once
+// @ID verifyEmail
+// @Accept  json
+// @Param input body domain.VerifyEmailInput true "verification token"
+// @Success 200
+// @Router /verify-email [post]
+func (h *Handler) verifyEmail(c *gin.Context) {
+	var input domain.VerifyEmailInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	verified, err := h.services.VerifyEmail(input.Token)
+	h.audit(c, domain.AuditEntry{
+		Action:         domain.AUDIT_EMAIL_VERIFIED,
+		TargetPublicId: auditTarget(verified.PublicId),
+		Email:          verified.Email,
+	}, err)
+	if !checkVerificationError(c, err) {
+		return
+	}
+
+	go func() {
+		err := h.broker.Produce(domain.EVENT_ACCOUNT_EMAIL_VERIFIED, h.broker.TopicAccountBE, verified)
+		if err != nil {
+			logrus.Errorf("sent email verified event fail: %s/n", err.Error())
+		}
+	}()
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Resend verification email
+// @Tags Account
+// @Description New verification link to the own email, it can't be sent too often
+// @ID resendEmailVerification
+// @Success 202
+// @Router /account/verify-email/resend [post]
+func (h *Handler) resendEmailVerification(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	err = h.services.SendEmailVerification(c.Request.Context(), accountPublicId)
+	h.auditOwn(c, domain.AUDIT_EMAIL_VERIFICATION_SENT, err)
+	if !checkVerificationError(c, err) {
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// sendEmailVerification - in the background, the caller doesn't wait for the mail
+func (h *Handler) sendEmailVerification(accountPublicId string) {
+	go func() {
+		err := h.services.SendEmailVerification(context.Background(), accountPublicId)
+		if err != nil {
+			logrus.Errorf("send verification email fail: %s/n", err.Error())
+		}
+	}()
+}
+
+// checkVerificationError - false when the error is sent
+func checkVerificationError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrInvalidVerificationToken):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case errors.Is(err, domain.ErrEmailAlreadyVerified):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return false
+	case errors.Is(err, domain.ErrVerificationThrottled):
+		newErrorResponse(c, http.StatusTooManyRequests, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
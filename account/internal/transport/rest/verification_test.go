@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/account/internal/broker"
+	mock_broker "github.com/p12s/furniture-store/account/internal/broker/mocks"
+	"github.com/p12s/furniture-store/account/internal/domain"
+	"github.com/p12s/furniture-store/account/internal/service"
+	mock_service "github.com/p12s/furniture-store/account/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_verifyEmail(t *testing.T) {
+	type verifierMockBehavior func(s *mock_service.MockVerifier)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	verified := domain.EmailVerifiedEvent{
+		PublicId:   uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"),
+		Email:      "test@test.ru",
+		VerifiedAt: time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		verifierMockBehavior verifierMockBehavior
+		brokerMockProducer   brokerMockProducer
+		expectedStatusCode   int
+		expectedRequestBody  string
+	}{
+		{
+			name:      "Can verify email and publish the event",
+			inputBody: `{"token": "valid"}`,
+			verifierMockBehavior: func(s *mock_service.MockVerifier) {
+				s.EXPECT().VerifyEmail("valid").Return(verified, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ACCOUNT_EMAIL_VERIFIED, "", verified).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: ``,
+		},
+		{
+			name:                 "Can't verify email without token",
+			inputBody:            `{}`,
+			verifierMockBehavior: func(s *mock_service.MockVerifier) {},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedRequestBody:  `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Can't verify email with used or expired token",
+			inputBody: `{"token": "used"}`,
+			verifierMockBehavior: func(s *mock_service.MockVerifier) {
+				s.EXPECT().VerifyEmail("used").Return(domain.EmailVerifiedEvent{}, domain.ErrInvalidVerificationToken)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid or expired verification token"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			verifier := mock_service.NewMockVerifier(ctrl)
+			tt.verifierMockBehavior(verifier)
+			serviceMock := &service.Service{Verifier: verifier, Auditor: anyAudit(ctrl)}
+
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+			brokerMock := &broker.Broker{Producer: brokerProducer}
+
+			handler := NewHandler(serviceMock, brokerMock)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/verify-email", handler.verifyEmail)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/verify-email", bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_resendEmailVerification(t *testing.T) {
+	type verifierMockBehavior func(s *mock_service.MockVerifier, accountPublicId string)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+
+	tests := []struct {
+		name                 string
+		verifierMockBehavior verifierMockBehavior
+		expectedStatusCode   int
+		expectedRequestBody  string
+	}{
+		{
+			name: "Can resend verification email",
+			verifierMockBehavior: func(s *mock_service.MockVerifier, accountPublicId string) {
+				s.EXPECT().SendEmailVerification(gomock.Any(), accountPublicId).Return(nil)
+			},
+			expectedStatusCode:  http.StatusAccepted,
+			expectedRequestBody: ``,
+		},
+		{
+			name: "Can't resend verification email too often",
+			verifierMockBehavior: func(s *mock_service.MockVerifier, accountPublicId string) {
+				s.EXPECT().SendEmailVerification(gomock.Any(), accountPublicId).Return(domain.ErrVerificationThrottled)
+			},
+			expectedStatusCode:  http.StatusTooManyRequests,
+			expectedRequestBody: `{"message":"verification email was sent recently, try later"}`,
+		},
+		{
+			name: "Can't resend verification of verified email",
+			verifierMockBehavior: func(s *mock_service.MockVerifier, accountPublicId string) {
+				s.EXPECT().SendEmailVerification(gomock.Any(), accountPublicId).Return(domain.ErrEmailAlreadyVerified)
+			},
+			expectedStatusCode:  http.StatusConflict,
+			expectedRequestBody: `{"message":"email is already verified"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			verifier := mock_service.NewMockVerifier(ctrl)
+			tt.verifierMockBehavior(verifier, accountPublicId)
+			serviceMock := &service.Service{Verifier: verifier, Auditor: anyAudit(ctrl)}
+
+			handler := NewHandler(serviceMock, nil)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/account/verify-email/resend", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.resendEmailVerification)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/account/verify-email/resend", nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType
+type EventType string
+
+const (
+	EVENT_ACCOUNT_CREATED          EventType = "auth.created"
+	EVENT_ACCOUNT_ROLE_UPDATED     EventType = "auth.role_updated"
+	EVENT_ACCOUNT_DELETED          EventType = "auth.deleted"
+	EVENT_ACCOUNT_SESSIONS_REVOKED EventType = "auth.sessions_revoked"
+	EVENT_ACCOUNT_TOKEN_UPDATED    EventType = "auth.token_updated" // nolint
+
+	EVENT_ACCOUNT_EXPORT_REQUESTED  EventType = "Account.ExportRequested"
+	EVENT_ACCOUNT_ERASURE_REQUESTED EventType = "Account.ErasureRequested"
+
+	EVENT_PRIVACY_DATA_EXPORTED     EventType = "Privacy.DataExported"
+	EVENT_PRIVACY_ERASURE_COMPLETED EventType = "Privacy.ErasureCompleted"
+
+	EVENT_ORDER_PRODUCT_ADDED EventType = "Order.ProductAdded"
+	EVENT_ORDER_CHECKED_OUT   EventType = "Order.CheckedOut"
+	EVENT_ORDER_PAYED         EventType = "Order.Payed"
+	EVENT_ORDER_DELIVERED     EventType = "Order.Delivered"
+
+	EVENT_BILLING_PAYMENT_RECEIVED EventType = "Billing.PaymentReceived"
+	EVENT_BILLING_REFUND_ISSUED    EventType = "Billing.RefundIssued"
+)
+
+// CREDENTIAL_EVENTS - the payload is a credential itself (the access token of the sign-in), they are not stored
+var CREDENTIAL_EVENTS = []EventType{EVENT_ACCOUNT_TOKEN_UPDATED}
+
+// CREDENTIAL_FIELDS - payload fields with credentials or their hashes, they are removed before the event is stored
+var CREDENTIAL_FIELDS = []string{"password", "token", "access_token", "id_token", "challenge_token",
+	"secret", "client_secret", "key_hash", "card_token"}
+
+// Event
+type Event struct {
+	Type  EventType
+	Value interface{}
+}
+
+// RawEvent - envelope as it came from the broker without the credentials, never changed after it is stored
+// but the payload is emptied when the account it mentions is erased.
+// Topic, partition and offset identify the message, so a redelivered one is stored once
+type RawEvent struct {
+	Id         int64           `json:"id" db:"id"`
+	Topic      string          `json:"topic" db:"topic"`
+	Partition  int32           `json:"partition" db:"topic_partition"`
+	Offset     int64           `json:"offset" db:"topic_offset"`
+	Type       EventType       `json:"type" db:"type"`
+	Payload    json.RawMessage `json:"payload" db:"payload"`
+	OccurredAt time.Time       `json:"occurred_at" db:"occurred_at"`
+	ReceivedAt time.Time       `json:"received_at" db:"received_at"`
+}
+
+// RawEventFilter - raw events page
+type RawEventFilter struct {
+	Type    EventType
+	From    time.Time
+	To      time.Time
+	AfterId int64
+	Limit   int
+}
+
+// AccountEvent - auth.created payload
+type AccountEvent struct {
+	PublicId uuid.UUID `json:"public_id"`
+}
+
+// OrderEvent - Order.ProductAdded / Order.CheckedOut / Order.Payed payload, Order.ProductAdded has the cart lines
+type OrderEvent struct {
+	PublicId        uuid.UUID   `json:"public_id"`
+	AccountPublicId uuid.UUID   `json:"account_public_id"`
+	Items           []OrderItem `json:"items"`
+	Total           int64       `json:"total"`
+}
+
+// OrderItem - Price is the effective unit price, VariantPublicId is set when the line is a variant of the product
+type OrderItem struct {
+	ProductPublicId uuid.UUID `json:"product_public_id"`
+	VariantPublicId uuid.UUID `json:"variant_public_id"`
+	DealerPublicId  uuid.UUID `json:"dealer_public_id"`
+	Quantity        int64     `json:"quantity"`
+	Price           int64     `json:"price"`
+}
+
+// OrderRefEvent - Billing.* / Order.Delivered payload, only the order reference is used
+type OrderRefEvent struct {
+	OrderPublicId   uuid.UUID `json:"order_public_id"`
+	AccountPublicId uuid.UUID `json:"account_public_id"`
+}
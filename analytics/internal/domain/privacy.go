@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+const (
+	// PRIVACY_SERVICE - the service name in the privacy answers
+	PRIVACY_SERVICE = "analytics"
+)
+
+// PrivacyRequestedInput - Account.ExportRequested and Account.ErasureRequested payload
+type PrivacyRequestedInput struct {
+	RequestId string `json:"request_id" binding:"required"`
+	PublicId  string `json:"public_id" binding:"required"`
+}
+
+// PrivacyAnswer - Privacy.DataExported and Privacy.ErasureCompleted payload, the erasure has no data
+type PrivacyAnswer struct {
+	RequestId string      `json:"request_id"`
+	PublicId  string      `json:"public_id"`
+	Service   string      `json:"service"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// AccountData - the sign-up time is nil if auth.created hasn't come, the events are the raw ones
+// mentioning the account
+type AccountData struct {
+	SignedUpAt *time.Time `json:"signed_up_at"`
+	Events     []RawEvent `json:"events"`
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidGroupBy = errors.New("invalid group by")
+	ErrInvalidRange   = errors.New("invalid date range")
+)
+
+// GroupBy - report period
+type GroupBy string
+
+const (
+	GROUP_BY_DAY   GroupBy = "day"
+	GROUP_BY_WEEK  GroupBy = "week"
+	GROUP_BY_MONTH GroupBy = "month"
+)
+
+const (
+	DATE_LAYOUT = "2006-01-02"
+	// DEFAULT_REPORT_DAYS - range when it is not set
+	DEFAULT_REPORT_DAYS = 30
+	// MAX_REPORT_DAYS - the longest range of one report
+	MAX_REPORT_DAYS = 366
+)
+
+// OrderStage - order funnel step, the first time the order reached it is kept
+type OrderStage string
+
+const (
+	ORDER_STAGE_CART        OrderStage = "cart"
+	ORDER_STAGE_CHECKED_OUT OrderStage = "checked_out"
+	ORDER_STAGE_PAYED       OrderStage = "payed"
+	ORDER_STAGE_DELIVERED   OrderStage = "delivered"
+	ORDER_STAGE_REFUNDED    OrderStage = "refunded"
+)
+
+// ReportInput - From is included, To is excluded
+type ReportInput struct {
+	From    time.Time
+	To      time.Time
+	GroupBy GroupBy
+}
+
+// Validate
+func (i ReportInput) Validate() error {
+	switch i.GroupBy {
+	case GROUP_BY_DAY, GROUP_BY_WEEK, GROUP_BY_MONTH:
+	default:
+		return ErrInvalidGroupBy
+	}
+	if !i.From.Before(i.To) || i.To.Sub(i.From) > MAX_REPORT_DAYS*24*time.Hour {
+		return ErrInvalidRange
+	}
+	return nil
+}
+
+// SignupRow - sign-ups per period
+type SignupRow struct {
+	Period   string `json:"period" db:"period"`
+	Accounts int64  `json:"accounts" db:"accounts"`
+}
+
+// ConversionRow - orders by the period their cart was started, and how many of them were payed
+type ConversionRow struct {
+	Period     string  `json:"period" db:"period"`
+	Carts      int64   `json:"carts" db:"carts"`
+	CheckedOut int64   `json:"checked_out" db:"checked_out"`
+	Payed      int64   `json:"payed" db:"payed"`
+	Rate       float64 `json:"rate" db:"-"`
+}
+
+// DealerRevenueRow - payed goods of a dealer per period, refunded orders are shown separately
+type DealerRevenueRow struct {
+	Period         string    `json:"period" db:"period"`
+	DealerPublicId uuid.UUID `json:"dealer_public_id" db:"dealer_public_id"`
+	Orders         int64     `json:"orders" db:"orders"`
+	Revenue        int64     `json:"revenue" db:"revenue"`
+	Refunded       int64     `json:"refunded" db:"refunded"`
+}
+
+// LeadTimeRow - time from payment to delivery by the period of delivery
+type LeadTimeRow struct {
+	Period    string  `json:"period" db:"period"`
+	Delivered int64   `json:"delivered" db:"delivered"`
+	AvgHours  float64 `json:"avg_hours" db:"avg_hours"`
+	MaxHours  float64 `json:"max_hours" db:"max_hours"`
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+)
+
+var _ Accounter = (*Account)(nil)
+
+// Accounter - repository interface
+type Accounter interface {
+	CreateAccount(account domain.Account) error
+	GetAccount(publicId string) (domain.Account, error)
+	UpdateAccountRole(input domain.UpdateAccountRoleInput) error
+	DeleteAccount(accountPublicId string) error
+	RevokeSessions(input domain.SessionsRevokedInput, now time.Time) error
+	IsSessionRevoked(sessionPublicId string) (bool, error)
+}
+
+// Account
+type Account struct {
+	db *sqlx.DB
+}
+
+// NewAccount - constructor
+func NewAccount(db *sqlx.DB) *Account {
+	return &Account{db: db}
+}
+
+// CreateAccount - role update can come before the created-event, so it is an upsert
+func (r *Account) CreateAccount(account domain.Account) error {
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, role) values ($1, $2)
+		ON CONFLICT(public_id) DO UPDATE SET role=excluded.role`, accountTable)
+	_, err := r.db.Exec(query, account.PublicId, account.Role)
+	return err
+}
+
+// GetAccount
+func (r *Account) GetAccount(publicId string) (domain.Account, error) {
+	var account domain.Account
+
+	query := fmt.Sprintf(`SELECT public_id, role FROM %s WHERE public_id=$1`, accountTable)
+	err := r.db.Get(&account, query, publicId)
+	if err != nil {
+		return account, fmt.Errorf("get account: %w", err)
+	}
+
+	return account, err
+}
+
+// UpdateAccountRole
+func (r *Account) UpdateAccountRole(input domain.UpdateAccountRoleInput) error {
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, role) values ($1, $2)
+		ON CONFLICT(public_id) DO UPDATE SET role=excluded.role`, accountTable)
+	_, err := r.db.Exec(query, input.PublicId, input.Role)
+	return err
+}
+
+// DeleteAccount - only the account copy is removed, orders are kept
+func (r *Account) DeleteAccount(accountPublicId string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE public_id = $1`, accountTable)
+	_, err := r.db.Exec(query, accountPublicId)
+	return err
+}
+
+// RevokeSessions - kept until the tokens expire, the expired ones are removed
+func (r *Account) RevokeSessions(input domain.SessionsRevokedInput, now time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`INSERT INTO %s (session_public_id, account_public_id, expires_at) values ($1, $2, $3)
+		ON CONFLICT(session_public_id) DO NOTHING`, revokedSessionTable)
+	for _, sessionPublicId := range input.SessionIds {
+		if _, err := tx.Exec(query, sessionPublicId, input.PublicId, input.ExpiresAt); err != nil {
+			return fmt.Errorf("revoke session: %w", err)
+		}
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE expires_at < $1`, revokedSessionTable)
+	if _, err := tx.Exec(query, now); err != nil {
+		return fmt.Errorf("remove expired sessions: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// IsSessionRevoked
+func (r *Account) IsSessionRevoked(sessionPublicId string) (bool, error) {
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE session_public_id=$1`, revokedSessionTable)
+	err := r.db.Get(&count, query, sessionPublicId)
+	return count > 0, err
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+)
+
+var _ Aggregator = (*Aggregate)(nil)
+
+// stageColumns - order_fact column of the funnel step
+var stageColumns = map[domain.OrderStage]string{
+	domain.ORDER_STAGE_CART:        "cart_at",
+	domain.ORDER_STAGE_CHECKED_OUT: "checked_out_at",
+	domain.ORDER_STAGE_PAYED:       "payed_at",
+	domain.ORDER_STAGE_DELIVERED:   "delivered_at",
+	domain.ORDER_STAGE_REFUNDED:    "refunded_at",
+}
+
+// Aggregator - fact tables the reports are built from, every write is idempotent
+type Aggregator interface {
+	RecordSignup(accountPublicId uuid.UUID, at time.Time) error
+	RecordOrderStage(orderPublicId uuid.UUID, stage domain.OrderStage, at time.Time) error
+	RecordOrderItems(orderPublicId uuid.UUID, items []domain.OrderItem) error
+}
+
+// Aggregate
+type Aggregate struct {
+	db *sqlx.DB
+}
+
+// NewAggregate - constructor
+func NewAggregate(db *sqlx.DB) *Aggregate {
+	return &Aggregate{db: db}
+}
+
+// RecordSignup
+func (r *Aggregate) RecordSignup(accountPublicId uuid.UUID, at time.Time) error {
+	query := fmt.Sprintf(`INSERT INTO %s (account_public_id, created_at) values ($1, $2)
+		ON CONFLICT(account_public_id) DO NOTHING`, signupTable)
+	_, err := r.db.Exec(query, accountPublicId, at.UTC())
+	return err
+}
+
+// RecordOrderStage - events come from different topics and can be late,
+// so the earliest time of the step is kept, not the last one
+func (r *Aggregate) RecordOrderStage(orderPublicId uuid.UUID, stage domain.OrderStage, at time.Time) error {
+	column, ok := stageColumns[stage]
+	if !ok {
+		return fmt.Errorf("unknown order stage: %s", stage)
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %[1]s (order_public_id, %[2]s) values ($1, $2)
+		ON CONFLICT(order_public_id) DO UPDATE SET %[2]s=min(coalesce(%[2]s, excluded.%[2]s), excluded.%[2]s)`,
+		orderFactTable, column)
+	_, err := r.db.Exec(query, orderPublicId, at.UTC())
+	return err
+}
+
+// RecordOrderItems - the order lines don't change after checkout, repeated ones are skipped
+func (r *Aggregate) RecordOrderItems(orderPublicId uuid.UUID, items []domain.OrderItem) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`INSERT INTO %s (order_public_id, product_public_id, variant_public_id, dealer_public_id,
+		quantity, price) values ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT(order_public_id, product_public_id, variant_public_id) DO NOTHING`, orderItemTable)
+	for _, item := range items {
+		_, err := tx.Exec(query, orderPublicId, item.ProductPublicId, item.VariantPublicId, item.DealerPublicId,
+			item.Quantity, item.Price)
+		if err != nil {
+			return fmt.Errorf("create order item: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
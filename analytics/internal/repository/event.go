@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+)
+
+var _ Eventer = (*Event)(nil)
+
+// Eventer - append-only raw event store interface
+type Eventer interface {
+	AppendEvent(event domain.RawEvent) (domain.RawEvent, bool, error)
+	GetEvents(filter domain.RawEventFilter) ([]domain.RawEvent, error)
+}
+
+// Event
+type Event struct {
+	db *sqlx.DB
+}
+
+// NewEvent - constructor
+func NewEvent(db *sqlx.DB) *Event {
+	return &Event{db: db}
+}
+
+// AppendEvent - false if the message is already stored
+func (r *Event) AppendEvent(event domain.RawEvent) (domain.RawEvent, bool, error) {
+	query := fmt.Sprintf(`INSERT INTO %s (topic, topic_partition, topic_offset, type, payload, occurred_at, received_at)
+		values ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT(topic, topic_partition, topic_offset) DO NOTHING`, rawEventTable)
+	result, err := r.db.Exec(query, event.Topic, event.Partition, event.Offset, event.Type, string(event.Payload),
+		event.OccurredAt, event.ReceivedAt)
+	if err != nil {
+		return event, false, fmt.Errorf("append event: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return event, false, fmt.Errorf("append event: %w", err)
+	}
+	if affected == 0 {
+		return event, false, nil
+	}
+
+	event.Id, err = result.LastInsertId()
+	if err != nil {
+		return event, false, fmt.Errorf("append event: %w", err)
+	}
+	return event, true, nil
+}
+
+// GetEvents - page of raw events in the store order
+func (r *Event) GetEvents(filter domain.RawEventFilter) ([]domain.RawEvent, error) {
+	events := make([]domain.RawEvent, 0)
+
+	conditions := []string{"id>?"}
+	args := []interface{}{filter.AfterId}
+	if filter.Type != "" {
+		conditions = append(conditions, "type=?")
+		args = append(args, filter.Type)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "occurred_at>=?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "occurred_at<?")
+		args = append(args, filter.To)
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`SELECT id, topic, topic_partition, topic_offset, type, CAST(payload AS BLOB) AS payload,
+		occurred_at, received_at FROM %s WHERE %s ORDER BY id LIMIT ?`,
+		rawEventTable, strings.Join(conditions, " AND "))
+	err := r.db.Select(&events, query, args...)
+	if err != nil {
+		return events, fmt.Errorf("get events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_purgeCredentialEvents(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	occurredAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	events := []domain.RawEvent{
+		{Type: domain.EVENT_ACCOUNT_TOKEN_UPDATED, Payload: json.RawMessage(`"eyJhbGciOiJIUzI1NiJ9.e30.c2ln"`)},
+		{Type: domain.EVENT_ACCOUNT_CREATED, Payload: json.RawMessage(`{"public_id":"` + uuid.NewString() + `"}`)},
+	}
+	for offset, event := range events {
+		event.Topic, event.Offset = "fur-account-cud", int64(offset)
+		event.OccurredAt, event.ReceivedAt = occurredAt, occurredAt
+		_, stored, err := repos.AppendEvent(event)
+		assert.NoError(t, err)
+		assert.True(t, stored)
+	}
+
+	NewRepository(db)
+	stored, err := repos.GetEvents(domain.RawEventFilter{Limit: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, stored, 1) {
+		assert.Equal(t, domain.EVENT_ACCOUNT_CREATED, stored[0].Type)
+	}
+}
@@ -0,0 +1,377 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/p12s/furniture-store/analytics/internal/repository (interfaces: Accounter,Eventer,Aggregator,Reporter,Privacier)
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	domain "github.com/p12s/furniture-store/analytics/internal/domain"
+)
+
+// MockAccounter is a mock of Accounter interface.
+type MockAccounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccounterMockRecorder
+}
+
+// MockAccounterMockRecorder is the mock recorder for MockAccounter.
+type MockAccounterMockRecorder struct {
+	mock *MockAccounter
+}
+
+// NewMockAccounter creates a new mock instance.
+func NewMockAccounter(ctrl *gomock.Controller) *MockAccounter {
+	mock := &MockAccounter{ctrl: ctrl}
+	mock.recorder = &MockAccounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccounter) EXPECT() *MockAccounterMockRecorder {
+	return m.recorder
+}
+
+// CreateAccount mocks base method.
+func (m *MockAccounter) CreateAccount(arg0 domain.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockAccounterMockRecorder) CreateAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAccounter)(nil).CreateAccount), arg0)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccounter) DeleteAccount(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccounterMockRecorder) DeleteAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccounter)(nil).DeleteAccount), arg0)
+}
+
+// GetAccount mocks base method.
+func (m *MockAccounter) GetAccount(arg0 string) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccounterMockRecorder) GetAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccounter)(nil).GetAccount), arg0)
+}
+
+// IsSessionRevoked mocks base method.
+func (m *MockAccounter) IsSessionRevoked(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSessionRevoked", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSessionRevoked indicates an expected call of IsSessionRevoked.
+func (mr *MockAccounterMockRecorder) IsSessionRevoked(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSessionRevoked", reflect.TypeOf((*MockAccounter)(nil).IsSessionRevoked), arg0)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockAccounter) RevokeSessions(arg0 domain.SessionsRevokedInput, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAccounterMockRecorder) RevokeSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockAccounter)(nil).RevokeSessions), arg0, arg1)
+}
+
+// UpdateAccountRole mocks base method.
+func (m *MockAccounter) UpdateAccountRole(arg0 domain.UpdateAccountRoleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountRole", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountRole indicates an expected call of UpdateAccountRole.
+func (mr *MockAccounterMockRecorder) UpdateAccountRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountRole", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountRole), arg0)
+}
+
+// MockEventer is a mock of Eventer interface.
+type MockEventer struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventerMockRecorder
+}
+
+// MockEventerMockRecorder is the mock recorder for MockEventer.
+type MockEventerMockRecorder struct {
+	mock *MockEventer
+}
+
+// NewMockEventer creates a new mock instance.
+func NewMockEventer(ctrl *gomock.Controller) *MockEventer {
+	mock := &MockEventer{ctrl: ctrl}
+	mock.recorder = &MockEventerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventer) EXPECT() *MockEventerMockRecorder {
+	return m.recorder
+}
+
+// AppendEvent mocks base method.
+func (m *MockEventer) AppendEvent(arg0 domain.RawEvent) (domain.RawEvent, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendEvent", arg0)
+	ret0, _ := ret[0].(domain.RawEvent)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AppendEvent indicates an expected call of AppendEvent.
+func (mr *MockEventerMockRecorder) AppendEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendEvent", reflect.TypeOf((*MockEventer)(nil).AppendEvent), arg0)
+}
+
+// GetEvents mocks base method.
+func (m *MockEventer) GetEvents(arg0 domain.RawEventFilter) ([]domain.RawEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", arg0)
+	ret0, _ := ret[0].([]domain.RawEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockEventerMockRecorder) GetEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockEventer)(nil).GetEvents), arg0)
+}
+
+// MockAggregator is a mock of Aggregator interface.
+type MockAggregator struct {
+	ctrl     *gomock.Controller
+	recorder *MockAggregatorMockRecorder
+}
+
+// MockAggregatorMockRecorder is the mock recorder for MockAggregator.
+type MockAggregatorMockRecorder struct {
+	mock *MockAggregator
+}
+
+// NewMockAggregator creates a new mock instance.
+func NewMockAggregator(ctrl *gomock.Controller) *MockAggregator {
+	mock := &MockAggregator{ctrl: ctrl}
+	mock.recorder = &MockAggregatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAggregator) EXPECT() *MockAggregatorMockRecorder {
+	return m.recorder
+}
+
+// RecordOrderItems mocks base method.
+func (m *MockAggregator) RecordOrderItems(arg0 uuid.UUID, arg1 []domain.OrderItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOrderItems", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordOrderItems indicates an expected call of RecordOrderItems.
+func (mr *MockAggregatorMockRecorder) RecordOrderItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOrderItems", reflect.TypeOf((*MockAggregator)(nil).RecordOrderItems), arg0, arg1)
+}
+
+// RecordOrderStage mocks base method.
+func (m *MockAggregator) RecordOrderStage(arg0 uuid.UUID, arg1 domain.OrderStage, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOrderStage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordOrderStage indicates an expected call of RecordOrderStage.
+func (mr *MockAggregatorMockRecorder) RecordOrderStage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOrderStage", reflect.TypeOf((*MockAggregator)(nil).RecordOrderStage), arg0, arg1, arg2)
+}
+
+// RecordSignup mocks base method.
+func (m *MockAggregator) RecordSignup(arg0 uuid.UUID, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSignup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordSignup indicates an expected call of RecordSignup.
+func (mr *MockAggregatorMockRecorder) RecordSignup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSignup", reflect.TypeOf((*MockAggregator)(nil).RecordSignup), arg0, arg1)
+}
+
+// MockReporter is a mock of Reporter interface.
+type MockReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockReporterMockRecorder
+}
+
+// MockReporterMockRecorder is the mock recorder for MockReporter.
+type MockReporterMockRecorder struct {
+	mock *MockReporter
+}
+
+// NewMockReporter creates a new mock instance.
+func NewMockReporter(ctrl *gomock.Controller) *MockReporter {
+	mock := &MockReporter{ctrl: ctrl}
+	mock.recorder = &MockReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReporter) EXPECT() *MockReporterMockRecorder {
+	return m.recorder
+}
+
+// GetConversion mocks base method.
+func (m *MockReporter) GetConversion(arg0 domain.ReportInput) ([]domain.ConversionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversion", arg0)
+	ret0, _ := ret[0].([]domain.ConversionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversion indicates an expected call of GetConversion.
+func (mr *MockReporterMockRecorder) GetConversion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversion", reflect.TypeOf((*MockReporter)(nil).GetConversion), arg0)
+}
+
+// GetDealerRevenue mocks base method.
+func (m *MockReporter) GetDealerRevenue(arg0 domain.ReportInput) ([]domain.DealerRevenueRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDealerRevenue", arg0)
+	ret0, _ := ret[0].([]domain.DealerRevenueRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDealerRevenue indicates an expected call of GetDealerRevenue.
+func (mr *MockReporterMockRecorder) GetDealerRevenue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDealerRevenue", reflect.TypeOf((*MockReporter)(nil).GetDealerRevenue), arg0)
+}
+
+// GetDeliveryLeadTime mocks base method.
+func (m *MockReporter) GetDeliveryLeadTime(arg0 domain.ReportInput) ([]domain.LeadTimeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryLeadTime", arg0)
+	ret0, _ := ret[0].([]domain.LeadTimeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryLeadTime indicates an expected call of GetDeliveryLeadTime.
+func (mr *MockReporterMockRecorder) GetDeliveryLeadTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryLeadTime", reflect.TypeOf((*MockReporter)(nil).GetDeliveryLeadTime), arg0)
+}
+
+// GetSignups mocks base method.
+func (m *MockReporter) GetSignups(arg0 domain.ReportInput) ([]domain.SignupRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignups", arg0)
+	ret0, _ := ret[0].([]domain.SignupRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignups indicates an expected call of GetSignups.
+func (mr *MockReporterMockRecorder) GetSignups(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignups", reflect.TypeOf((*MockReporter)(nil).GetSignups), arg0)
+}
+
+// MockPrivacier is a mock of Privacier interface.
+type MockPrivacier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacierMockRecorder
+}
+
+// MockPrivacierMockRecorder is the mock recorder for MockPrivacier.
+type MockPrivacierMockRecorder struct {
+	mock *MockPrivacier
+}
+
+// NewMockPrivacier creates a new mock instance.
+func NewMockPrivacier(ctrl *gomock.Controller) *MockPrivacier {
+	mock := &MockPrivacier{ctrl: ctrl}
+	mock.recorder = &MockPrivacierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacier) EXPECT() *MockPrivacierMockRecorder {
+	return m.recorder
+}
+
+// EraseAccountData mocks base method.
+func (m *MockPrivacier) EraseAccountData(arg0 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseAccountData", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseAccountData indicates an expected call of EraseAccountData.
+func (mr *MockPrivacierMockRecorder) EraseAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseAccountData", reflect.TypeOf((*MockPrivacier)(nil).EraseAccountData), arg0)
+}
+
+// ExportAccountData mocks base method.
+func (m *MockPrivacier) ExportAccountData(arg0 uuid.UUID) (domain.AccountData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccountData", arg0)
+	ret0, _ := ret[0].(domain.AccountData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccountData indicates an expected call of ExportAccountData.
+func (mr *MockPrivacierMockRecorder) ExportAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccountData", reflect.TypeOf((*MockPrivacier)(nil).ExportAccountData), arg0)
+}
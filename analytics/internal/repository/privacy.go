@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+)
+
+var _ Privacier = (*Privacy)(nil)
+
+// Privacier - repository interface
+type Privacier interface {
+	ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error)
+	EraseAccountData(accountPublicId uuid.UUID) error
+}
+
+// Privacy - personal data of the account kept here
+type Privacy struct {
+	db *sqlx.DB
+}
+
+// NewPrivacy - constructor
+func NewPrivacy(db *sqlx.DB) *Privacy {
+	return &Privacy{db: db}
+}
+
+// ExportAccountData - the raw events are found by the account public id in the payload
+func (r *Privacy) ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error) {
+	data := domain.AccountData{
+		Events: make([]domain.RawEvent, 0),
+	}
+
+	var signedUpAt time.Time
+	query := fmt.Sprintf(`SELECT created_at FROM %s WHERE account_public_id=$1`, signupTable)
+	err := r.db.Get(&signedUpAt, query, accountPublicId)
+	switch {
+	case err == nil:
+		data.SignedUpAt = &signedUpAt
+	case !errors.Is(err, sql.ErrNoRows):
+		return data, fmt.Errorf("export signup: %w", err)
+	}
+
+	query = fmt.Sprintf(`SELECT id, topic, topic_partition, topic_offset, type, CAST(payload AS BLOB) AS payload,
+		occurred_at, received_at FROM %s WHERE payload LIKE $1 ORDER BY id`, rawEventTable)
+	if err := r.db.Select(&data.Events, query, "%"+accountPublicId.String()+"%"); err != nil {
+		return data, fmt.Errorf("export events: %w", err)
+	}
+
+	return data, nil
+}
+
+// EraseAccountData - the payloads mentioning the account are emptied, the event types and times
+// are kept as well as the facts, they have no personal data but the public ids and the reports are built from them.
+// The account copy is removed
+func (r *Privacy) EraseAccountData(accountPublicId uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`UPDATE %s SET payload='{}' WHERE payload LIKE $1`, rawEventTable)
+	if _, err := tx.Exec(query, "%"+accountPublicId.String()+"%"); err != nil {
+		return fmt.Errorf("erase events: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, accountTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase account: %w", err)
+	}
+
+	return tx.Commit()
+}
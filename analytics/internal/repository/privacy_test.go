@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivacy(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	signedUpAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	customer, other := uuid.New(), uuid.New()
+	assert.NoError(t, repos.RecordSignup(customer, signedUpAt))
+	for offset, publicId := range []uuid.UUID{customer, other} {
+		_, _, err := repos.AppendEvent(domain.RawEvent{
+			Topic:      "fur-account-cud",
+			Offset:     int64(offset),
+			Type:       domain.EVENT_ACCOUNT_CREATED,
+			Payload:    json.RawMessage(`{"public_id":"` + publicId.String() + `","email":"alex@mail.com"}`),
+			OccurredAt: signedUpAt,
+			ReceivedAt: signedUpAt,
+		})
+		assert.NoError(t, err)
+	}
+
+	t.Run("Can export the sign-up and the events mentioning the account", func(t *testing.T) {
+		data, err := repos.ExportAccountData(customer)
+		assert.NoError(t, err)
+		assert.Equal(t, signedUpAt, data.SignedUpAt.UTC())
+		assert.Len(t, data.Events, 1)
+		assert.Contains(t, string(data.Events[0].Payload), customer.String())
+	})
+
+	t.Run("Can empty the payloads keeping the events and the facts", func(t *testing.T) {
+		assert.NoError(t, repos.EraseAccountData(customer))
+
+		events, err := repos.GetEvents(domain.RawEventFilter{Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, `{}`, string(events[0].Payload))
+		assert.Contains(t, string(events[1].Payload), other.String())
+
+		data, err := repos.ExportAccountData(customer)
+		assert.NoError(t, err)
+		assert.NotNil(t, data.SignedUpAt)
+		assert.Empty(t, data.Events)
+	})
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+)
+
+var _ Reporter = (*Report)(nil)
+
+// periodFormats - strftime format of the report period
+var periodFormats = map[domain.GroupBy]string{
+	domain.GROUP_BY_DAY:   "%Y-%m-%d",
+	domain.GROUP_BY_WEEK:  "%Y-W%W",
+	domain.GROUP_BY_MONTH: "%Y-%m",
+}
+
+// Reporter - reports over the fact tables
+type Reporter interface {
+	GetSignups(input domain.ReportInput) ([]domain.SignupRow, error)
+	GetConversion(input domain.ReportInput) ([]domain.ConversionRow, error)
+	GetDealerRevenue(input domain.ReportInput) ([]domain.DealerRevenueRow, error)
+	GetDeliveryLeadTime(input domain.ReportInput) ([]domain.LeadTimeRow, error)
+}
+
+// Report
+type Report struct {
+	db *sqlx.DB
+}
+
+// NewReport - constructor
+func NewReport(db *sqlx.DB) *Report {
+	return &Report{db: db}
+}
+
+// GetSignups - accounts created in the period
+func (r *Report) GetSignups(input domain.ReportInput) ([]domain.SignupRow, error) {
+	rows := make([]domain.SignupRow, 0)
+
+	query := fmt.Sprintf(`SELECT strftime($1, created_at) AS period, COUNT(*) AS accounts FROM %s
+		WHERE created_at>=$2 AND created_at<$3 GROUP BY period ORDER BY period`, signupTable)
+	err := r.db.Select(&rows, query, periodFormats[input.GroupBy], input.From.UTC(), input.To.UTC())
+	if err != nil {
+		return rows, fmt.Errorf("get signups: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetConversion - the order is counted in the period it was started,
+// an order without the cart event started with the checkout
+func (r *Report) GetConversion(input domain.ReportInput) ([]domain.ConversionRow, error) {
+	rows := make([]domain.ConversionRow, 0)
+
+	query := fmt.Sprintf(`SELECT strftime($1, started_at) AS period, COUNT(*) AS carts,
+		COUNT(coalesce(checked_out_at, payed_at)) AS checked_out, COUNT(payed_at) AS payed
+		FROM (SELECT coalesce(cart_at, checked_out_at, payed_at) AS started_at, checked_out_at, payed_at FROM %s)
+		WHERE started_at>=$2 AND started_at<$3 GROUP BY period ORDER BY period`, orderFactTable)
+	err := r.db.Select(&rows, query, periodFormats[input.GroupBy], input.From.UTC(), input.To.UTC())
+	if err != nil {
+		return rows, fmt.Errorf("get conversion: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetDealerRevenue - order lines by the period of payment
+func (r *Report) GetDealerRevenue(input domain.ReportInput) ([]domain.DealerRevenueRow, error) {
+	rows := make([]domain.DealerRevenueRow, 0)
+
+	query := fmt.Sprintf(`SELECT strftime($1, o.payed_at) AS period, i.dealer_public_id,
+		COUNT(DISTINCT o.order_public_id) AS orders, SUM(i.price*i.quantity) AS revenue,
+		SUM(CASE WHEN o.refunded_at IS NULL THEN 0 ELSE i.price*i.quantity END) AS refunded
+		FROM %s i INNER JOIN %s o ON o.order_public_id=i.order_public_id
+		WHERE o.payed_at>=$2 AND o.payed_at<$3
+		GROUP BY period, i.dealer_public_id ORDER BY period, revenue DESC`, orderItemTable, orderFactTable)
+	err := r.db.Select(&rows, query, periodFormats[input.GroupBy], input.From.UTC(), input.To.UTC())
+	if err != nil {
+		return rows, fmt.Errorf("get dealer revenue: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetDeliveryLeadTime - hours from payment to delivery by the period of delivery
+func (r *Report) GetDeliveryLeadTime(input domain.ReportInput) ([]domain.LeadTimeRow, error) {
+	rows := make([]domain.LeadTimeRow, 0)
+
+	query := fmt.Sprintf(`SELECT strftime($1, delivered_at) AS period, COUNT(*) AS delivered,
+		AVG((julianday(delivered_at)-julianday(payed_at))*24) AS avg_hours,
+		MAX((julianday(delivered_at)-julianday(payed_at))*24) AS max_hours
+		FROM %s WHERE delivered_at>=$2 AND delivered_at<$3 AND payed_at IS NOT NULL
+		GROUP BY period ORDER BY period`, orderFactTable)
+	err := r.db.Select(&rows, query, periodFormats[input.GroupBy], input.From.UTC(), input.To.UTC())
+	if err != nil {
+		return rows, fmt.Errorf("get delivery lead time: %w", err)
+	}
+
+	return rows, nil
+}
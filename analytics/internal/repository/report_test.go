@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_AppendEvent(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	occurredAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	event := domain.RawEvent{
+		Topic:      "fur-order-be",
+		Partition:  0,
+		Offset:     42,
+		Type:       domain.EVENT_ORDER_PAYED,
+		Payload:    json.RawMessage(`{"public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"}`),
+		OccurredAt: occurredAt,
+		ReceivedAt: occurredAt.Add(time.Second),
+	}
+
+	stored, ok, err := repos.AppendEvent(event)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NotZero(t, stored.Id)
+
+	_, ok, err = repos.AppendEvent(event)
+	assert.NoError(t, err)
+	assert.False(t, ok, "redelivered message is stored once")
+
+	event.Offset = 43
+	event.Type = domain.EVENT_ORDER_DELIVERED
+	_, ok, err = repos.AppendEvent(event)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	events, err := repos.GetEvents(domain.RawEventFilter{Type: domain.EVENT_ORDER_PAYED, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.JSONEq(t, string(event.Payload), string(events[0].Payload))
+	assert.Equal(t, int64(42), events[0].Offset)
+	assert.True(t, occurredAt.Equal(events[0].OccurredAt))
+
+	events, err = repos.GetEvents(domain.RawEventFilter{AfterId: stored.Id, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.EVENT_ORDER_DELIVERED, events[0].Type)
+
+	events, err = repos.GetEvents(domain.RawEventFilter{From: occurredAt.Add(time.Hour), Limit: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestReport(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	day := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	dealer := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+	otherDealer := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+
+	assert.NoError(t, repos.RecordSignup(uuid.New(), day.Add(time.Hour)))
+	assert.NoError(t, repos.RecordSignup(uuid.New(), day.Add(2*time.Hour)))
+	assert.NoError(t, repos.RecordSignup(uuid.New(), day.AddDate(0, 0, 1)))
+
+	// payed and delivered in 6 hours, refunded after
+	first := uuid.New()
+	assert.NoError(t, repos.RecordOrderStage(first, domain.ORDER_STAGE_CART, day.Add(time.Hour)))
+	assert.NoError(t, repos.RecordOrderItems(first, []domain.OrderItem{
+		{ProductPublicId: uuid.New(), DealerPublicId: dealer, Quantity: 2, Price: 1000},
+		{ProductPublicId: uuid.New(), DealerPublicId: otherDealer, Quantity: 1, Price: 500},
+	}))
+	assert.NoError(t, repos.RecordOrderStage(first, domain.ORDER_STAGE_CHECKED_OUT, day.Add(2*time.Hour)))
+	assert.NoError(t, repos.RecordOrderStage(first, domain.ORDER_STAGE_PAYED, day.Add(4*time.Hour)))
+	// the same payment from the other topic came later, the first time is kept
+	assert.NoError(t, repos.RecordOrderStage(first, domain.ORDER_STAGE_PAYED, day.Add(5*time.Hour)))
+	assert.NoError(t, repos.RecordOrderStage(first, domain.ORDER_STAGE_DELIVERED, day.Add(10*time.Hour)))
+	assert.NoError(t, repos.RecordOrderStage(first, domain.ORDER_STAGE_REFUNDED, day.AddDate(0, 0, 2)))
+
+	// payed without the cart event, two variants of one product
+	second := uuid.New()
+	sofa := uuid.New()
+	items := []domain.OrderItem{
+		{ProductPublicId: sofa, VariantPublicId: uuid.New(), DealerPublicId: dealer, Quantity: 1, Price: 3000},
+		{ProductPublicId: sofa, VariantPublicId: uuid.New(), DealerPublicId: dealer, Quantity: 1, Price: 1000},
+	}
+	assert.NoError(t, repos.RecordOrderItems(second, items))
+	assert.NoError(t, repos.RecordOrderItems(second, items), "repeated lines are skipped")
+	assert.NoError(t, repos.RecordOrderStage(second, domain.ORDER_STAGE_CHECKED_OUT, day.Add(3*time.Hour)))
+	assert.NoError(t, repos.RecordOrderStage(second, domain.ORDER_STAGE_PAYED, day.Add(3*time.Hour)))
+
+	// abandoned cart
+	assert.NoError(t, repos.RecordOrderStage(uuid.New(), domain.ORDER_STAGE_CART, day.Add(20*time.Hour)))
+
+	assert.Error(t, repos.RecordOrderStage(uuid.New(), "unknown", day))
+
+	month := domain.ReportInput{From: day, To: day.AddDate(0, 1, 0), GroupBy: domain.GROUP_BY_DAY}
+
+	t.Run("Can get signups per day and month", func(t *testing.T) {
+		rows, err := repos.GetSignups(month)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.SignupRow{{Period: "2021-12-01", Accounts: 2}, {Period: "2021-12-02", Accounts: 1}}, rows)
+
+		input := month
+		input.GroupBy = domain.GROUP_BY_MONTH
+		rows, err = repos.GetSignups(input)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.SignupRow{{Period: "2021-12", Accounts: 3}}, rows)
+
+		input.From = day.AddDate(0, 0, 1)
+		rows, err = repos.GetSignups(input)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.SignupRow{{Period: "2021-12", Accounts: 1}}, rows, "range is applied")
+	})
+
+	t.Run("Can get conversion", func(t *testing.T) {
+		rows, err := repos.GetConversion(month)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ConversionRow{{Period: "2021-12-01", Carts: 3, CheckedOut: 2, Payed: 2}}, rows)
+	})
+
+	t.Run("Can get revenue per dealer", func(t *testing.T) {
+		rows, err := repos.GetDealerRevenue(month)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.DealerRevenueRow{
+			{Period: "2021-12-01", DealerPublicId: dealer, Orders: 2, Revenue: 6000, Refunded: 2000},
+			{Period: "2021-12-01", DealerPublicId: otherDealer, Orders: 1, Revenue: 500, Refunded: 500},
+		}, rows)
+	})
+
+	t.Run("Can get delivery lead time", func(t *testing.T) {
+		rows, err := repos.GetDeliveryLeadTime(month)
+		assert.NoError(t, err)
+		assert.Len(t, rows, 1)
+		assert.Equal(t, int64(1), rows[0].Delivered)
+		assert.InDelta(t, 6, rows[0].AvgHours, 0.001)
+		assert.InDelta(t, 6, rows[0].MaxHours, 0.001)
+	})
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"fmt"
+
+	_ "github.com/golang/mock/mockgen/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -destination mocks/mock.go -package repository github.com/p12s/furniture-store/analytics/internal/repository Accounter,Eventer,Aggregator,Reporter,Privacier
+
+// Repository - repo
+type Repository struct {
+	Accounter
+	Eventer
+	Aggregator
+	Reporter
+	Privacier
+}
+
+// NewRepository - constructor
+func NewRepository(db *sqlx.DB) *Repository {
+	createSchema(db, accountTable, `CREATE TABLE IF NOT EXISTS account (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"role" INTEGER DEFAULT 0
+	  );`)
+	createSchema(db, revokedSessionTable, `CREATE TABLE IF NOT EXISTS revoked_session (
+		"session_public_id" TEXT NOT NULL PRIMARY KEY,
+		"account_public_id" TEXT NOT NULL,
+		"expires_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, rawEventTable, `CREATE TABLE IF NOT EXISTS raw_event (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"topic" TEXT NOT NULL,
+		"topic_partition" INTEGER NOT NULL,
+		"topic_offset" INTEGER NOT NULL,
+		"type" TEXT NOT NULL,
+		"payload" TEXT NOT NULL,
+		"occurred_at" DATETIME NOT NULL,
+		"received_at" DATETIME NOT NULL,
+		UNIQUE (topic, topic_partition, topic_offset)
+	  );`)
+	purgeEvents(db, domain.CREDENTIAL_EVENTS)
+	createSchema(db, "raw_event_occurred index", `CREATE INDEX IF NOT EXISTS raw_event_occurred
+		ON raw_event (occurred_at);`)
+	createSchema(db, signupTable, `CREATE TABLE IF NOT EXISTS signup (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"account_public_id" TEXT NOT NULL UNIQUE,
+		"created_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, orderFactTable, `CREATE TABLE IF NOT EXISTS order_fact (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"order_public_id" TEXT NOT NULL UNIQUE,
+		"cart_at" DATETIME,
+		"checked_out_at" DATETIME,
+		"payed_at" DATETIME,
+		"delivered_at" DATETIME,
+		"refunded_at" DATETIME
+	  );`)
+	createSchema(db, orderItemTable, `CREATE TABLE IF NOT EXISTS order_item (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"order_public_id" TEXT NOT NULL,
+		"product_public_id" TEXT NOT NULL,
+		"variant_public_id" TEXT NOT NULL,
+		"dealer_public_id" TEXT NOT NULL,
+		"quantity" INTEGER NOT NULL,
+		"price" INTEGER NOT NULL,
+		UNIQUE (order_public_id, product_public_id, variant_public_id)
+	  );`)
+
+	return &Repository{
+		Accounter:  NewAccount(db),
+		Eventer:    NewEvent(db),
+		Aggregator: NewAggregate(db),
+		Reporter:   NewReport(db),
+		Privacier:  NewPrivacy(db),
+	}
+}
+
+// Deliberately removed the obligation of important fields,
+// because the architecture is asynchronous, the business-event with only role (role)
+// can come before a CUD-event with all other data.
+
+// purgeEvents - the credential events stored before they were dropped by the collect
+func purgeEvents(db *sqlx.DB, types []domain.EventType) {
+	query, args, err := sqlx.In(fmt.Sprintf(`DELETE FROM %s WHERE type IN (?)`, rawEventTable), types)
+	if err != nil {
+		logrus.Fatalf("purge analytics.%s fail: %s", rawEventTable, err.Error())
+	}
+	if _, err := db.Exec(query, args...); err != nil {
+		logrus.Fatalf("exec purging analytics.%s fail: %s", rawEventTable, err.Error())
+	}
+}
+
+// createSchema - table, index
+func createSchema(db *sqlx.DB, name, query string) {
+	statement, err := db.Prepare(query)
+	if err != nil {
+		logrus.Fatalf("create analytics.%s fail: %s", name, err.Error())
+	}
+	defer statement.Close() // nolint
+
+	_, err = statement.Exec()
+	if err != nil {
+		logrus.Fatalf("exec creating analytics.%s fail: %s", name, err.Error())
+	}
+
+	fmt.Printf("analytics.%s created 🗂\n", name)
+}
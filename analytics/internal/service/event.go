@@ -0,0 +1,187 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+	"github.com/p12s/furniture-store/analytics/internal/repository"
+)
+
+const (
+	DEFAULT_EVENTS_LIMIT = 100
+	MAX_EVENTS_LIMIT     = 1000
+)
+
+var _ Eventer = (*EventService)(nil)
+
+// Eventer - service interface
+type Eventer interface {
+	Collect(event domain.RawEvent) error
+	GetEvents(filter domain.RawEventFilter) ([]domain.RawEvent, error)
+}
+
+// EventService - every event is stored without the credentials, the known ones also go to the fact tables
+type EventService struct {
+	repo       repository.Eventer
+	aggregator repository.Aggregator
+}
+
+// NewEventService - constructor
+func NewEventService(repo repository.Eventer, aggregator repository.Aggregator) *EventService {
+	return &EventService{
+		repo:       repo,
+		aggregator: aggregator,
+	}
+}
+
+// Collect - a redelivered message is stored once and is not counted again. The credential events are dropped,
+// the credential fields of the others are removed before the event is stored, so admins never see them
+func (s *EventService) Collect(event domain.RawEvent) error {
+	for _, eventType := range domain.CREDENTIAL_EVENTS {
+		if event.Type == eventType {
+			return nil
+		}
+	}
+	payload, err := redactPayload(event.Payload)
+	if err != nil {
+		return fmt.Errorf("%s payload fail: %w", event.Type, err)
+	}
+	event.Payload = payload
+
+	_, stored, err := s.repo.AppendEvent(event)
+	if err != nil {
+		return err
+	}
+	if !stored {
+		return nil
+	}
+
+	return s.aggregate(event)
+}
+
+// GetEvents - raw events page
+func (s *EventService) GetEvents(filter domain.RawEventFilter) ([]domain.RawEvent, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DEFAULT_EVENTS_LIMIT
+	}
+	if filter.Limit > MAX_EVENTS_LIMIT {
+		filter.Limit = MAX_EVENTS_LIMIT
+	}
+	return s.repo.GetEvents(filter)
+}
+
+// aggregate - facts of the event, the broker time is the time it happened
+func (s *EventService) aggregate(event domain.RawEvent) error {
+	switch event.Type {
+	case domain.EVENT_ACCOUNT_CREATED:
+		var account domain.AccountEvent
+		if err := readEvent(event, &account); err != nil {
+			return err
+		}
+		if account.PublicId == uuid.Nil {
+			return fmt.Errorf("%s: account public id is empty", event.Type)
+		}
+		return s.aggregator.RecordSignup(account.PublicId, event.OccurredAt)
+
+	case domain.EVENT_ORDER_PRODUCT_ADDED:
+		return s.recordOrder(event, domain.ORDER_STAGE_CART)
+	case domain.EVENT_ORDER_CHECKED_OUT:
+		return s.recordOrder(event, domain.ORDER_STAGE_CHECKED_OUT)
+	case domain.EVENT_ORDER_PAYED:
+		return s.recordOrder(event, domain.ORDER_STAGE_PAYED)
+
+	case domain.EVENT_BILLING_PAYMENT_RECEIVED:
+		return s.recordOrderRef(event, domain.ORDER_STAGE_PAYED)
+	case domain.EVENT_ORDER_DELIVERED:
+		return s.recordOrderRef(event, domain.ORDER_STAGE_DELIVERED)
+	case domain.EVENT_BILLING_REFUND_ISSUED:
+		return s.recordOrderRef(event, domain.ORDER_STAGE_REFUNDED)
+	}
+
+	return nil
+}
+
+// recordOrder - order payload, the lines are known from the checkout
+func (s *EventService) recordOrder(event domain.RawEvent, stage domain.OrderStage) error {
+	var order domain.OrderEvent
+	if err := readEvent(event, &order); err != nil {
+		return err
+	}
+	if order.PublicId == uuid.Nil {
+		return fmt.Errorf("%s: order public id is empty", event.Type)
+	}
+
+	// the cart lines still change, the order lines are the checked out ones
+	if len(order.Items) > 0 && stage != domain.ORDER_STAGE_CART {
+		if err := s.aggregator.RecordOrderItems(order.PublicId, order.Items); err != nil {
+			return err
+		}
+	}
+	return s.aggregator.RecordOrderStage(order.PublicId, stage, event.OccurredAt)
+}
+
+// recordOrderRef - payload with the order reference only
+func (s *EventService) recordOrderRef(event domain.RawEvent, stage domain.OrderStage) error {
+	var ref domain.OrderRefEvent
+	if err := readEvent(event, &ref); err != nil {
+		return err
+	}
+	if ref.OrderPublicId == uuid.Nil {
+		return fmt.Errorf("%s: order public id is empty", event.Type)
+	}
+
+	return s.aggregator.RecordOrderStage(ref.OrderPublicId, stage, event.OccurredAt)
+}
+
+// redactPayload - without the credential fields at any depth, the payload without them is kept as it came
+func redactPayload(payload json.RawMessage) (json.RawMessage, error) {
+	var value interface{}
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return payload, err
+	}
+	if !redact(value) {
+		return payload, nil
+	}
+	return json.Marshal(value)
+}
+
+// redact - true if a credential field is removed
+func redact(value interface{}) bool {
+	removed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isCredentialField(key) {
+				delete(v, key)
+				removed = true
+				continue
+			}
+			removed = redact(field) || removed
+		}
+	case []interface{}:
+		for _, item := range v {
+			removed = redact(item) || removed
+		}
+	}
+	return removed
+}
+
+// isCredentialField - case insensitive
+func isCredentialField(key string) bool {
+	for _, field := range domain.CREDENTIAL_FIELDS {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func readEvent(event domain.RawEvent, target interface{}) error {
+	if err := json.Unmarshal(event.Payload, target); err != nil {
+		return fmt.Errorf("%s payload fail: %w", event.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+	"github.com/p12s/furniture-store/analytics/internal/repository"
+)
+
+var _ Reporter = (*ReportService)(nil)
+
+// Reporter - service interface
+type Reporter interface {
+	GetSignups(input domain.ReportInput) ([]domain.SignupRow, error)
+	GetConversion(input domain.ReportInput) ([]domain.ConversionRow, error)
+	GetDealerRevenue(input domain.ReportInput) ([]domain.DealerRevenueRow, error)
+	GetDeliveryLeadTime(input domain.ReportInput) ([]domain.LeadTimeRow, error)
+}
+
+// ReportService - service
+type ReportService struct {
+	repo repository.Reporter
+}
+
+// NewReportService - constructor
+func NewReportService(repo repository.Reporter) *ReportService {
+	return &ReportService{repo: repo}
+}
+
+// GetSignups
+func (s *ReportService) GetSignups(input domain.ReportInput) ([]domain.SignupRow, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSignups(input)
+}
+
+// GetConversion - rate is payed orders of the started ones
+func (s *ReportService) GetConversion(input domain.ReportInput) ([]domain.ConversionRow, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.GetConversion(input)
+	if err != nil {
+		return rows, err
+	}
+	for i := range rows {
+		if rows[i].Carts > 0 {
+			rows[i].Rate = float64(rows[i].Payed) / float64(rows[i].Carts)
+		}
+	}
+	return rows, nil
+}
+
+// GetDealerRevenue
+func (s *ReportService) GetDealerRevenue(input domain.ReportInput) ([]domain.DealerRevenueRow, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDealerRevenue(input)
+}
+
+// GetDeliveryLeadTime
+func (s *ReportService) GetDeliveryLeadTime(input domain.ReportInput) ([]domain.LeadTimeRow, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDeliveryLeadTime(input)
+}
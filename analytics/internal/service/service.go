@@ -0,0 +1,28 @@
+package service
+
+import (
+	_ "github.com/golang/mock/mockgen/model"
+
+	"github.com/p12s/furniture-store/analytics/internal/config"
+	"github.com/p12s/furniture-store/analytics/internal/repository"
+)
+
+//go:generate mockgen -destination mocks/mock.go -package service github.com/p12s/furniture-store/analytics/internal/service Accounter,Eventer,Reporter,Privacier
+
+// Service - just service
+type Service struct {
+	Accounter
+	Eventer
+	Reporter
+	Privacier
+}
+
+// NewService - constructor
+func NewService(repos *repository.Repository, auth *config.Auth) *Service {
+	return &Service{
+		Accounter: NewAccountService(repos.Accounter, auth),
+		Eventer:   NewEventService(repos.Eventer, repos.Aggregator),
+		Reporter:  NewReportService(repos.Reporter),
+		Privacier: NewPrivacyService(repos.Privacier),
+	}
+}
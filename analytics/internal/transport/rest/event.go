@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+)
+
+// @Summary Get raw events
+// @Tags Event
+// @Description Stored broker events in the order they came, page by page with after_id
+// @ID getEvents
+// @Produce  json
+// @Param type query string false "event type, e.g. Order.Payed"
+// @Param from query string false "first day, YYYY-MM-DD"
+// @Param to query string false "last day, YYYY-MM-DD"
+// @Param after_id query int false "last id of the previous page"
+// @Param limit query int false "page size, 100 by default"
+// @Success 200 {array} domain.RawEvent
+// @Router /events [get]
+func (h *Handler) getEvents(c *gin.Context) {
+	filter := domain.RawEventFilter{Type: domain.EventType(c.Query("type"))}
+
+	var err error
+	if from := c.Query("from"); from != "" {
+		filter.From, err = time.Parse(domain.DATE_LAYOUT, from)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid from date")
+			return
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		filter.To, err = time.Parse(domain.DATE_LAYOUT, to)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid to date")
+			return
+		}
+		filter.To = filter.To.AddDate(0, 0, 1)
+	}
+	if filter.AfterId, err = strconv.ParseInt(c.DefaultQuery("after_id", "0"), 10, 64); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid after id")
+		return
+	}
+	if filter.Limit, err = strconv.Atoi(c.DefaultQuery("limit", "0")); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	events, err := h.services.GetEvents(filter)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/analytics/internal/broker"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+	"github.com/p12s/furniture-store/analytics/internal/service"
+)
+
+// Handler
+type Handler struct {
+	services *service.Service
+	broker   *broker.Broker
+}
+
+// NewHandler - constructor
+func NewHandler(services *service.Service, broker *broker.Broker) *Handler {
+	return &Handler{services: services, broker: broker}
+}
+
+// InitRoutes - routes
+func (h *Handler) InitRoutes() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(CORSMiddleware())
+
+	router.GET("/health", h.health)
+
+	reports := router.Group("/reports", h.userIdentity, h.roleIdentity(domain.ROLE_ADMIN))
+	{
+		reports.GET("/signups", h.getSignups)
+		reports.GET("/conversion", h.getConversion)
+		reports.GET("/dealer-revenue", h.getDealerRevenue)
+		reports.GET("/delivery-lead-time", h.getDeliveryLeadTime)
+	}
+
+	events := router.Group("/events", h.userIdentity, h.roleIdentity(domain.ROLE_ADMIN))
+	{
+		events.GET("", h.getEvents)
+	}
+
+	return router
+}
+
+// CORSMiddleware - cross site work
+func CORSMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Header("Access-Control-Allow-Credentials", "true")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Methods", "POST,HEAD,PATCH,OPTIONS,GET,PUT")
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/analytics/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_health(t *testing.T) {
+	handler := NewHandler(&service.Service{}, nil)
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.GET("/health", handler.health)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/health", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"service":"analytics","status":"OK"}`, w.Body.String())
+}
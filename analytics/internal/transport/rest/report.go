@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+)
+
+// @Summary Sign-ups report
+// @Tags Report
+// @Description Accounts created per period
+// @ID getSignups
+// @Produce  json
+// @Param from query string false "first day, YYYY-MM-DD, 30 days ago by default"
+// @Param to query string false "last day, YYYY-MM-DD, today by default"
+// @Param group_by query string false "day (default), week or month"
+// @Success 200 {array} domain.SignupRow
+// @Router /reports/signups [get]
+func (h *Handler) getSignups(c *gin.Context) {
+	input, ok := getReportInput(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.services.GetSignups(input)
+	sendReport(c, rows, err)
+}
+
+// @Summary Conversion report
+// @Tags Report
+// @Description Orders started per period, how many were checked out and payed
+// @ID getConversion
+// @Produce  json
+// @Param from query string false "first day, YYYY-MM-DD, 30 days ago by default"
+// @Param to query string false "last day, YYYY-MM-DD, today by default"
+// @Param group_by query string false "day (default), week or month"
+// @Success 200 {array} domain.ConversionRow
+// @Router /reports/conversion [get]
+func (h *Handler) getConversion(c *gin.Context) {
+	input, ok := getReportInput(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.services.GetConversion(input)
+	sendReport(c, rows, err)
+}
+
+// @Summary Dealer revenue report
+// @Tags Report
+// @Description Payed goods per dealer and period
+// @ID getDealerRevenue
+// @Produce  json
+// @Param from query string false "first day, YYYY-MM-DD, 30 days ago by default"
+// @Param to query string false "last day, YYYY-MM-DD, today by default"
+// @Param group_by query string false "day (default), week or month"
+// @Success 200 {array} domain.DealerRevenueRow
+// @Router /reports/dealer-revenue [get]
+func (h *Handler) getDealerRevenue(c *gin.Context) {
+	input, ok := getReportInput(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.services.GetDealerRevenue(input)
+	sendReport(c, rows, err)
+}
+
+// @Summary Delivery lead time report
+// @Tags Report
+// @Description Hours from payment to delivery per period
+// @ID getDeliveryLeadTime
+// @Produce  json
+// @Param from query string false "first day, YYYY-MM-DD, 30 days ago by default"
+// @Param to query string false "last day, YYYY-MM-DD, today by default"
+// @Param group_by query string false "day (default), week or month"
+// @Success 200 {array} domain.LeadTimeRow
+// @Router /reports/delivery-lead-time [get]
+func (h *Handler) getDeliveryLeadTime(c *gin.Context) {
+	input, ok := getReportInput(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.services.GetDeliveryLeadTime(input)
+	sendReport(c, rows, err)
+}
+
+// getReportInput - both days are included, the error response is already sent if not ok
+func getReportInput(c *gin.Context) (domain.ReportInput, bool) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	input := domain.ReportInput{
+		From:    today.AddDate(0, 0, -domain.DEFAULT_REPORT_DAYS),
+		To:      today.AddDate(0, 0, 1),
+		GroupBy: domain.GroupBy(c.DefaultQuery("group_by", string(domain.GROUP_BY_DAY))),
+	}
+
+	var err error
+	if from := c.Query("from"); from != "" {
+		input.From, err = time.Parse(domain.DATE_LAYOUT, from)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid from date")
+			return input, false
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		input.To, err = time.Parse(domain.DATE_LAYOUT, to)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid to date")
+			return input, false
+		}
+		input.To = input.To.AddDate(0, 0, 1)
+	}
+
+	return input, true
+}
+
+// sendReport - report rows or the error
+func sendReport(c *gin.Context, rows interface{}, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidGroupBy):
+		newErrorResponse(c, http.StatusBadRequest, "invalid group by")
+		return
+	case errors.Is(err, domain.ErrInvalidRange):
+		newErrorResponse(c, http.StatusBadRequest, "invalid date range")
+		return
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, rows)
+}
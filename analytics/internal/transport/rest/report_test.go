@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/p12s/furniture-store/analytics/internal/domain"
+	"github.com/p12s/furniture-store/analytics/internal/service"
+	mock_service "github.com/p12s/furniture-store/analytics/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_getSignups(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockReporter)
+
+	december := domain.ReportInput{
+		From:    time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		GroupBy: domain.GROUP_BY_WEEK,
+	}
+
+	tests := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Can get report with range and group by",
+			query: "?from=2021-12-01&to=2021-12-31&group_by=week",
+			mockBehavior: func(s *mock_service.MockReporter) {
+				s.EXPECT().GetSignups(december).Return([]domain.SignupRow{{Period: "2021-W48", Accounts: 3}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `[{"period":"2021-W48","accounts":3}]`,
+		},
+		{
+			name:                 "Can't get report with invalid date",
+			query:                "?from=01.12.2021",
+			mockBehavior:         func(s *mock_service.MockReporter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid from date"}`,
+		},
+		{
+			name:  "Can't get report with invalid group by",
+			query: "?group_by=year",
+			mockBehavior: func(s *mock_service.MockReporter) {
+				s.EXPECT().GetSignups(gomock.Any()).Return(nil, domain.ErrInvalidGroupBy)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid group by"}`,
+		},
+		{
+			name:  "Can't get report with reversed range",
+			query: "?from=2021-12-31&to=2021-12-01",
+			mockBehavior: func(s *mock_service.MockReporter) {
+				s.EXPECT().GetSignups(gomock.Any()).Return(nil, domain.ErrInvalidRange)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid date range"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			reporter := mock_service.NewMockReporter(c)
+			tt.mockBehavior(reporter)
+
+			handler := NewHandler(&service.Service{Reporter: reporter}, nil)
+
+			r := gin.New()
+			r.GET("/reports/signups", handler.getSignups)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/reports/signups"+tt.query, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
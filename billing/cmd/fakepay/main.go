@@ -0,0 +1,39 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/p12s/furniture-store/billing/internal/payment/fakepay"
+	"github.com/sirupsen/logrus"
+)
+
+// Config - fake payment gateway, for local and offline checkout testing only
+type Config struct {
+	Port          int           `envconfig:"FAKEPAY_PORT" default:"8090"`
+	WebhookSecret string        `envconfig:"FAKEPAY_WEBHOOK_SECRET" default:"fakepay-webhook-secret"`
+	Delay         time.Duration `envconfig:"FAKEPAY_DELAY" default:"3s"`
+}
+
+func main() {
+	logrus.SetFormatter(new(logrus.JSONFormatter))
+
+	var cfg Config
+	if err := envconfig.Process("fakepay", &cfg); err != nil {
+		logrus.Fatalf("error loading env variables: %s\n", err.Error())
+	}
+
+	srv := &http.Server{
+		Addr:           ":" + strconv.Itoa(cfg.Port),
+		Handler:        fakepay.NewServer(cfg.WebhookSecret, cfg.Delay).Routes(),
+		MaxHeaderBytes: 1 << 20, // 1 MB
+		ReadTimeout:    10 * time.Second,
+		WriteTimeout:   10 * time.Second,
+	}
+	logrus.Print("💳 fakepay gateway started with port: ", cfg.Port)
+	if err := srv.ListenAndServe(); err != nil {
+		logrus.Fatalf("error while running http server: %s\n", err.Error())
+	}
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidGroupBy = errors.New("invalid group by")
+
+// GroupBy - accounting report period
+type GroupBy string
+
+const (
+	GROUP_BY_DAY   GroupBy = "day"
+	GROUP_BY_WEEK  GroupBy = "week"
+	GROUP_BY_MONTH GroupBy = "month"
+)
+
+// GoodsFilter - [From, To), all dealers when DealerPublicId is not set
+type GoodsFilter struct {
+	From           time.Time
+	To             time.Time
+	GroupBy        GroupBy
+	DealerPublicId uuid.UUID
+}
+
+// Validate
+func (f GoodsFilter) Validate() error {
+	switch f.GroupBy {
+	case GROUP_BY_DAY, GROUP_BY_WEEK, GROUP_BY_MONTH:
+		return nil
+	}
+	return ErrInvalidGroupBy
+}
+
+// GoodsReceipt - dealer stock growth
+type GoodsReceipt struct {
+	ProductPublicId uuid.UUID `json:"product_public_id" db:"product_public_id"`
+	DealerPublicId  uuid.UUID `json:"dealer_public_id" db:"dealer_public_id"`
+	Quantity        int64     `json:"quantity" db:"quantity"`
+	ReceivedAt      time.Time `json:"received_at" db:"received_at"`
+}
+
+// GoodsSale - payed order line
+type GoodsSale struct {
+	OrderPublicId   uuid.UUID `json:"order_public_id" db:"order_public_id"`
+	ProductPublicId uuid.UUID `json:"product_public_id" db:"product_public_id"`
+	DealerPublicId  uuid.UUID `json:"dealer_public_id" db:"dealer_public_id"`
+	Quantity        int64     `json:"quantity" db:"quantity"`
+	Amount          int64     `json:"amount" db:"amount"`
+	PayedAt         time.Time `json:"payed_at" db:"payed_at"`
+}
+
+// GoodsReceivedRow - goods received from a dealer in the period
+type GoodsReceivedRow struct {
+	Period         string    `json:"period" db:"period"`
+	DealerPublicId uuid.UUID `json:"dealer_public_id" db:"dealer_public_id"`
+	Products       int64     `json:"products" db:"products"`
+	Quantity       int64     `json:"quantity" db:"quantity"`
+}
+
+// GoodsBoughtRow - goods of a dealer payed in the period
+type GoodsBoughtRow struct {
+	Period         string    `json:"period" db:"period"`
+	DealerPublicId uuid.UUID `json:"dealer_public_id" db:"dealer_public_id"`
+	Orders         int64     `json:"orders" db:"orders"`
+	Quantity       int64     `json:"quantity" db:"quantity"`
+	Amount         int64     `json:"amount" db:"amount"`
+}
+
+// GoodsDeliveryRow - goods payed in the period by their delivery state now
+type GoodsDeliveryRow struct {
+	Period         string    `json:"period" db:"period"`
+	DealerPublicId uuid.UUID `json:"dealer_public_id" db:"dealer_public_id"`
+	Delivered      int64     `json:"delivered" db:"delivered"`
+	Awaiting       int64     `json:"awaiting" db:"awaiting"`
+	Refunded       int64     `json:"refunded" db:"refunded"`
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTransactionExists     = errors.New("transaction with the same reference already exists")
+	ErrTransactionNotFound   = errors.New("transaction not found")
+	ErrTransactionUnbalanced = errors.New("transaction debit and credit are not equal")
+)
+
+// LedgerAccount - chart of accounts of the double-entry ledger
+type LedgerAccount string
+
+const (
+	LEDGER_CASH           LedgerAccount = "cash"           // money received from customers
+	LEDGER_DEALER_PAYABLE LedgerAccount = "dealer_payable" // owed to a dealer (owner) for sold goods
+	LEDGER_REVENUE        LedgerAccount = "revenue"        // store commission
+	LEDGER_DISCOUNT       LedgerAccount = "discount"       // coupon discounts paid by the store
+)
+
+// TransactionStatus
+type TransactionStatus string
+
+const (
+	TRANSACTION_PAYMENT TransactionStatus = "payment"
+	TRANSACTION_REFUND  TransactionStatus = "refund"
+)
+
+// Transaction - billing audit log record, keyed by account and order public_ids.
+// Every transaction is a balanced set of ledger entries and is never updated or deleted,
+// corrections are made with new (reversal) transactions.
+type Transaction struct {
+	Id              int               `json:"-" db:"id"`
+	PublicId        uuid.UUID         `json:"public_id" db:"public_id"`
+	Reference       string            `json:"reference" db:"reference"`
+	Status          TransactionStatus `json:"status" db:"status"`
+	AccountPublicId uuid.UUID         `json:"account_public_id" db:"account_public_id"`
+	OrderPublicId   uuid.UUID         `json:"order_public_id" db:"order_public_id"`
+	Price           int64             `json:"price" db:"price"`
+	Entries         []Entry           `json:"entries,omitempty" db:"-"`
+	CreatedAt       time.Time         `json:"created_at" db:"created_at"`
+}
+
+// Balanced - sum of debits equals sum of credits and is not zero
+func (t Transaction) Balanced() bool {
+	var debit, credit int64
+	for _, entry := range t.Entries {
+		debit += entry.Debit
+		credit += entry.Credit
+	}
+	return debit > 0 && debit == credit
+}
+
+// Entry - one side of a ledger posting, owner is set for per-party accounts (dealer payables)
+type Entry struct {
+	Id            int           `json:"-" db:"id"`
+	TransactionId int           `json:"-" db:"transaction_id"`
+	LedgerAccount LedgerAccount `json:"ledger_account" db:"ledger_account"`
+	OwnerPublicId uuid.UUID     `json:"owner_public_id" db:"owner_public_id"`
+	Debit         int64         `json:"debit" db:"debit"`
+	Credit        int64         `json:"credit" db:"credit"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+}
+
+// Balance - ledger account turnover, Balance = Debit - Credit
+type Balance struct {
+	LedgerAccount LedgerAccount `json:"ledger_account" db:"ledger_account"`
+	OwnerPublicId uuid.UUID     `json:"owner_public_id" db:"owner_public_id"`
+	Debit         int64         `json:"debit" db:"debit"`
+	Credit        int64         `json:"credit" db:"credit"`
+	Balance       int64         `json:"balance" db:"balance"`
+}
+
+// StatusTotal - transactions count and sum by status
+type StatusTotal struct {
+	Status TransactionStatus `json:"status" db:"status"`
+	Count  int64             `json:"count" db:"count"`
+	Price  int64             `json:"price" db:"price"`
+}
+
+// Summary - accounting report for a period
+type Summary struct {
+	From     time.Time     `json:"from"`
+	To       time.Time     `json:"to"`
+	Statuses []StatusTotal `json:"statuses"`
+	Accounts []Balance     `json:"accounts"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+// EventType
+type EventType string
+
+const (
+	EVENT_ACCOUNT_CREATED          EventType = "auth.created"
+	EVENT_ACCOUNT_INFO_UPDATED     EventType = "auth.info_updated"
+	EVENT_ACCOUNT_ROLE_UPDATED     EventType = "auth.role_updated"
+	EVENT_ACCOUNT_DELETED          EventType = "auth.deleted"
+	EVENT_ACCOUNT_SESSIONS_REVOKED EventType = "auth.sessions_revoked"
+	EVENT_ACCOUNT_EMAIL_VERIFIED   EventType = "Account.EmailVerified"
+
+	EVENT_ACCOUNT_EXPORT_REQUESTED  EventType = "Account.ExportRequested"
+	EVENT_ACCOUNT_ERASURE_REQUESTED EventType = "Account.ErasureRequested"
+
+	EVENT_PRIVACY_DATA_EXPORTED     EventType = "Privacy.DataExported"
+	EVENT_PRIVACY_ERASURE_COMPLETED EventType = "Privacy.ErasureCompleted"
+
+	EVENT_PRODUCT_CREATED EventType = "Product.Created"
+	EVENT_PRODUCT_UPDATED EventType = "Product.Updated"
+
+	EVENT_ORDER_CHECKED_OUT EventType = "Order.CheckedOut"
+	EVENT_ORDER_PAYED       EventType = "Order.Payed"
+	EVENT_ORDER_REFUNDED    EventType = "Order.Refunded"
+	EVENT_ORDER_DELIVERED   EventType = "Order.Delivered"
+
+	EVENT_BILLING_PAYMENT_RECEIVED EventType = "Billing.PaymentReceived"
+	EVENT_BILLING_PAYMENT_DECLINED EventType = "Billing.PaymentDeclined"
+	EVENT_BILLING_REFUND_ISSUED    EventType = "Billing.RefundIssued"
+)
+
+// Event
+type Event struct {
+	Type  EventType
+	Value interface{}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// Order - copy, "reduced version" of the Ordering domain
+// Total is what the customer actually pays, coupon discounts included
+type Order struct {
+	PublicId        uuid.UUID   `json:"public_id"`
+	AccountPublicId uuid.UUID   `json:"account_public_id"`
+	Items           []OrderItem `json:"items"`
+	Total           int64       `json:"total"`
+}
+
+// OrderItem - Price is the effective unit price at the checkout, product discount applied.
+// CartPrice is the effective unit price snapshot at the add-to-cart, made by ordering,
+// PayedPrice is the one at the payment.
+// VariantPublicId is set when the line is a variant of the product
+type OrderItem struct {
+	ProductPublicId uuid.UUID `json:"product_public_id"`
+	VariantPublicId uuid.UUID `json:"variant_public_id"`
+	DealerPublicId  uuid.UUID `json:"dealer_public_id"`
+	Quantity        int64     `json:"quantity"`
+	Price           int64     `json:"price"`
+	CartPrice       int64     `json:"cart_price,omitempty"`
+	PayedPrice      int64     `json:"payed_price,omitempty"`
+}
+
+// SKU - the variant of the line or the product itself, a variant has its own product copy
+func (i OrderItem) SKU() uuid.UUID {
+	if i.VariantPublicId != uuid.Nil {
+		return i.VariantPublicId
+	}
+	return i.ProductPublicId
+}
+
+// PayedUnitPrice - the payment snapshot, the checkout price if the line has no snapshot yet
+func (i OrderItem) PayedUnitPrice() int64 {
+	if i.PayedPrice != 0 {
+		return i.PayedPrice
+	}
+	return i.Price
+}
+
+// ItemsTotal - sum of all order lines
+func (o Order) ItemsTotal() int64 {
+	var total int64
+	for _, item := range o.Items {
+		total += item.Price * item.Quantity
+	}
+	return total
+}
+
+// PayedItemsTotal - sum of all order lines at the payment price
+func (o Order) PayedItemsTotal() int64 {
+	var total int64
+	for _, item := range o.Items {
+		total += item.PayedUnitPrice() * item.Quantity
+	}
+	return total
+}
+
+// DeliveredOrder - Order.Delivered payload, only the order reference is used
+type DeliveredOrder struct {
+	OrderPublicId uuid.UUID `json:"order_public_id"`
+}
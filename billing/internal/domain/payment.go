@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOrderNotFound     = errors.New("order not found")
+	ErrOrderAlreadyPaid  = errors.New("order is already paid or payment is in progress")
+	ErrPaymentNotFound   = errors.New("payment not found")
+	ErrPaymentDeclined   = errors.New("payment declined")
+	ErrWebhookSignature  = errors.New("invalid webhook signature")
+	ErrUnknownProvider   = errors.New("unknown payment provider")
+	ErrPaymentNotAllowed = errors.New("payment operation is not allowed in the current status")
+	ErrEmailNotVerified  = errors.New("email is not verified")
+)
+
+// PaymentStatus
+type PaymentStatus string
+
+const (
+	PAYMENT_PENDING    PaymentStatus = "pending"    // provider answers later with a webhook
+	PAYMENT_AUTHORIZED PaymentStatus = "authorized" // money is held, not charged yet
+	PAYMENT_CAPTURED   PaymentStatus = "captured"
+	PAYMENT_DECLINED   PaymentStatus = "declined"
+	PAYMENT_REFUNDED   PaymentStatus = "refunded"
+)
+
+// Payment - order payment attempt through a payment provider
+type Payment struct {
+	Id                int           `json:"-" db:"id"`
+	ProviderPaymentId string        `json:"provider_payment_id" db:"provider_payment_id"`
+	OrderPublicId     uuid.UUID     `json:"order_public_id" db:"order_public_id"`
+	AccountPublicId   uuid.UUID     `json:"account_public_id" db:"account_public_id"`
+	Amount            int64         `json:"amount" db:"amount"`
+	Status            PaymentStatus `json:"status" db:"status"`
+	Transaction       *Transaction  `json:"transaction,omitempty" db:"-"` // ledger posting, set once captured
+	CreatedAt         time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// PayInput - card token is issued to the storefront by the payment provider
+type PayInput struct {
+	CardToken string `json:"card_token" binding:"required"`
+}
+
+// AuthorizeInput
+type AuthorizeInput struct {
+	OrderPublicId uuid.UUID `json:"order_id"`
+	Amount        int64     `json:"amount"`
+	CardToken     string    `json:"card_token"`
+	CallbackURL   string    `json:"callback_url"`
+}
+
+// ProviderPayment - payment as the provider sees it
+type ProviderPayment struct {
+	Id            string        `json:"id"`
+	OrderPublicId uuid.UUID     `json:"order_id"`
+	Amount        int64         `json:"amount"`
+	Status        PaymentStatus `json:"status"`
+}
+
+// WebhookEvent - verified provider callback
+type WebhookEvent struct {
+	Type    string          `json:"type"`
+	Payment ProviderPayment `json:"payment"`
+}
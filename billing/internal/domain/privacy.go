@@ -0,0 +1,26 @@
+package domain
+
+const (
+	// PRIVACY_SERVICE - the service name in the privacy answers
+	PRIVACY_SERVICE = "billing"
+)
+
+// PrivacyRequestedInput - Account.ExportRequested and Account.ErasureRequested payload
+type PrivacyRequestedInput struct {
+	RequestId string `json:"request_id" binding:"required"`
+	PublicId  string `json:"public_id" binding:"required"`
+}
+
+// PrivacyAnswer - Privacy.DataExported and Privacy.ErasureCompleted payload, the erasure has no data
+type PrivacyAnswer struct {
+	RequestId string      `json:"request_id"`
+	PublicId  string      `json:"public_id"`
+	Service   string      `json:"service"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// AccountData - the ledger transactions and the payments of the account
+type AccountData struct {
+	Transactions []Transaction `json:"transactions"`
+	Payments     []Payment     `json:"payments"`
+}
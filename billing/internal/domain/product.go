@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrPriceNotFound = errors.New("price not found")
+
+// EffectivePrice - price with the discount, rounded down to the minor unit as the product service does
+func EffectivePrice(price, discount int64) int64 {
+	return price * (100 - discount) / 100
+}
+
+// Product - copy, "reduced version" of the Product domain
+// Price is kept in minor currency units (kopecks), discount in percents,
+// Quantity is the dealer stock - its growth is counted as goods received.
+// UpdatedAt is the time of the price change in the product service
+type Product struct {
+	PublicId       uuid.UUID `json:"public_id" db:"public_id"`
+	DealerPublicId uuid.UUID `json:"dealer_public_id" db:"dealer_public_id"`
+	Name           string    `json:"name" db:"name"`
+	Price          int64     `json:"price" db:"price"`
+	Discount       int64     `json:"discount" db:"discount"`
+	Quantity       int64     `json:"quantity" db:"quantity"`
+	UpdatedAt      time.Time `json:"updated_at" db:"-"`
+}
@@ -0,0 +1,99 @@
+package fakepay
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/p12s/furniture-store/billing/internal/domain"
+)
+
+const (
+	CLIENT_TIMEOUT = 10 * time.Second
+)
+
+// Client - payment provider client of the fake gateway
+type Client struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewClient - constructor
+func NewClient(url, secret string) *Client {
+	return &Client{
+		url:    strings.TrimRight(url, "/"),
+		secret: secret,
+		client: &http.Client{Timeout: CLIENT_TIMEOUT},
+	}
+}
+
+// Authorize - holds the amount on the customer card
+func (c *Client) Authorize(ctx context.Context, input domain.AuthorizeInput) (domain.ProviderPayment, error) {
+	return c.do(ctx, "/v1/payments", input)
+}
+
+// Capture - charges the authorized amount
+func (c *Client) Capture(ctx context.Context, paymentId string, amount int64) (domain.ProviderPayment, error) {
+	return c.do(ctx, "/v1/payments/"+paymentId+"/capture", amountInput{Amount: amount})
+}
+
+// Refund - returns the captured amount
+func (c *Client) Refund(ctx context.Context, paymentId string, amount int64) (domain.ProviderPayment, error) {
+	return c.do(ctx, "/v1/payments/"+paymentId+"/refund", amountInput{Amount: amount})
+}
+
+// VerifyWebhook - checks the body signature and decodes the event
+func (c *Client) VerifyWebhook(header http.Header, payload []byte) (domain.WebhookEvent, error) {
+	var event domain.WebhookEvent
+
+	expected := Sign(c.secret, payload)
+	if !hmac.Equal([]byte(expected), []byte(header.Get(SIGNATURE_HEADER))) {
+		return event, domain.ErrWebhookSignature
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return event, fmt.Errorf("webhook decode fail: %w", err)
+	}
+	return event, nil
+}
+
+func (c *Client) do(ctx context.Context, path string, input interface{}) (domain.ProviderPayment, error) {
+	var payment domain.ProviderPayment
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return payment, fmt.Errorf("fakepay encode fail: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return payment, fmt.Errorf("fakepay request fail: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return payment, fmt.Errorf("fakepay send fail: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var message errorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&message)
+		if resp.StatusCode == http.StatusConflict {
+			return payment, fmt.Errorf("fakepay: %s: %w", message.Message, domain.ErrPaymentNotAllowed)
+		}
+		return payment, fmt.Errorf("fakepay status %d: %s", resp.StatusCode, message.Message)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payment); err != nil {
+		return payment, fmt.Errorf("fakepay decode fail: %w", err)
+	}
+	return payment, nil
+}
@@ -0,0 +1,135 @@
+package fakepay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	TEST_SECRET = "secret"
+	TEST_DELAY  = 10 * time.Millisecond
+)
+
+func TestClient_Authorize(t *testing.T) {
+	server := httptest.NewServer(NewServer(TEST_SECRET, TEST_DELAY).Routes())
+	defer server.Close()
+	client := NewClient(server.URL, TEST_SECRET)
+
+	tests := []struct {
+		name           string
+		cardToken      string
+		expectedStatus domain.PaymentStatus
+	}{
+		{name: "Can authorize with success token", cardToken: TOKEN_SUCCESS, expectedStatus: domain.PAYMENT_AUTHORIZED},
+		{name: "Can decline with decline token", cardToken: TOKEN_DECLINE, expectedStatus: domain.PAYMENT_DECLINED},
+		{name: "Can leave payment pending with delay token", cardToken: TOKEN_DELAY, expectedStatus: domain.PAYMENT_PENDING},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment, err := client.Authorize(context.Background(), domain.AuthorizeInput{
+				OrderPublicId: uuid.New(),
+				Amount:        1000,
+				CardToken:     tt.cardToken,
+			})
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, payment.Id)
+			assert.Equal(t, int64(1000), payment.Amount)
+			assert.Equal(t, tt.expectedStatus, payment.Status)
+		})
+	}
+}
+
+func TestClient_CaptureAndRefund(t *testing.T) {
+	server := httptest.NewServer(NewServer(TEST_SECRET, TEST_DELAY).Routes())
+	defer server.Close()
+	client := NewClient(server.URL, TEST_SECRET)
+	ctx := context.Background()
+
+	authorized, err := client.Authorize(ctx, domain.AuthorizeInput{Amount: 1000, CardToken: TOKEN_SUCCESS})
+	assert.NoError(t, err)
+
+	_, err = client.Refund(ctx, authorized.Id, 1000)
+	assert.True(t, errors.Is(err, domain.ErrPaymentNotAllowed), "not captured payment can't be refunded")
+
+	_, err = client.Capture(ctx, authorized.Id, 2000)
+	assert.Error(t, err, "can't capture more than authorized")
+
+	captured, err := client.Capture(ctx, authorized.Id, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PAYMENT_CAPTURED, captured.Status)
+
+	refunded, err := client.Refund(ctx, authorized.Id, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PAYMENT_REFUNDED, refunded.Status)
+
+	_, err = client.Capture(ctx, "pay_unknown", 1000)
+	assert.Error(t, err)
+}
+
+func TestClient_VerifyWebhook(t *testing.T) {
+	webhooks := make(chan domain.WebhookEvent, 1)
+	client := NewClient("", TEST_SECRET)
+
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		event, err := client.VerifyWebhook(r.Header, payload)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		webhooks <- event
+	}))
+	defer callback.Close()
+
+	server := httptest.NewServer(NewServer(TEST_SECRET, TEST_DELAY).Routes())
+	defer server.Close()
+	client.url = server.URL
+
+	tests := []struct {
+		name           string
+		cardToken      string
+		expectedStatus domain.PaymentStatus
+	}{
+		{name: "Can authorize pending payment with webhook", cardToken: TOKEN_DELAY, expectedStatus: domain.PAYMENT_AUTHORIZED},
+		{name: "Can decline pending payment with webhook", cardToken: TOKEN_DELAY_DECLINE, expectedStatus: domain.PAYMENT_DECLINED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending, err := client.Authorize(context.Background(), domain.AuthorizeInput{
+				Amount:      1000,
+				CardToken:   tt.cardToken,
+				CallbackURL: callback.URL,
+			})
+			assert.NoError(t, err)
+
+			select {
+			case event := <-webhooks:
+				assert.Equal(t, pending.Id, event.Payment.Id)
+				assert.Equal(t, tt.expectedStatus, event.Payment.Status)
+				assert.Equal(t, "payment."+string(tt.expectedStatus), event.Type)
+			case <-time.After(time.Second):
+				t.Fatal("webhook was not sent")
+			}
+		})
+	}
+
+	t.Run("Can't verify webhook with wrong signature", func(t *testing.T) {
+		header := http.Header{}
+		header.Set(SIGNATURE_HEADER, Sign("other-secret", []byte(`{}`)))
+
+		_, err := client.VerifyWebhook(header, []byte(`{}`))
+		assert.True(t, errors.Is(err, domain.ErrWebhookSignature))
+	})
+}
@@ -0,0 +1,212 @@
+package fakepay
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// Card tokens drive the fake gateway behaviour, any other token is a success
+const (
+	TOKEN_SUCCESS       = "tok_success"
+	TOKEN_DECLINE       = "tok_decline"
+	TOKEN_DELAY         = "tok_delay"         // pending, authorized later with a webhook
+	TOKEN_DELAY_DECLINE = "tok_delay_decline" // pending, declined later with a webhook
+
+	SIGNATURE_HEADER = "X-Fakepay-Signature"
+	WEBHOOK_TIMEOUT  = 5 * time.Second
+)
+
+// Server - local payment provider stand-in, keeps payments in memory
+type Server struct {
+	secret   string
+	delay    time.Duration
+	mu       sync.Mutex
+	payments map[string]*domain.ProviderPayment
+	client   *http.Client
+}
+
+// NewServer - constructor, delay is the time before pending payments are resolved
+func NewServer(secret string, delay time.Duration) *Server {
+	return &Server{
+		secret:   secret,
+		delay:    delay,
+		payments: make(map[string]*domain.ProviderPayment),
+		client:   &http.Client{Timeout: WEBHOOK_TIMEOUT},
+	}
+}
+
+// Routes
+func (s *Server) Routes() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+
+	v1 := router.Group("/v1/payments")
+	{
+		v1.POST("", s.authorize)
+		v1.GET("/:id", s.get)
+		v1.POST("/:id/capture", s.capture)
+		v1.POST("/:id/refund", s.refund)
+	}
+
+	return router
+}
+
+type amountInput struct {
+	Amount int64 `json:"amount"`
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func (s *Server) authorize(c *gin.Context) {
+	var input domain.AuthorizeInput
+	if err := c.BindJSON(&input); err != nil || input.Amount <= 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse{"invalid input body"})
+		return
+	}
+
+	payment := domain.ProviderPayment{
+		Id:            "pay_" + uuid.NewString(),
+		OrderPublicId: input.OrderPublicId,
+		Amount:        input.Amount,
+	}
+	switch input.CardToken {
+	case TOKEN_DECLINE:
+		payment.Status = domain.PAYMENT_DECLINED
+	case TOKEN_DELAY:
+		payment.Status = domain.PAYMENT_PENDING
+		go s.resolveLater(payment.Id, domain.PAYMENT_AUTHORIZED, input.CallbackURL)
+	case TOKEN_DELAY_DECLINE:
+		payment.Status = domain.PAYMENT_PENDING
+		go s.resolveLater(payment.Id, domain.PAYMENT_DECLINED, input.CallbackURL)
+	default:
+		payment.Status = domain.PAYMENT_AUTHORIZED
+	}
+
+	s.mu.Lock()
+	s.payments[payment.Id] = &payment
+	s.mu.Unlock()
+
+	c.JSON(http.StatusCreated, payment)
+}
+
+func (s *Server) get(c *gin.Context) {
+	s.mu.Lock()
+	payment, ok := s.payments[c.Param("id")]
+	var result domain.ProviderPayment
+	if ok {
+		result = *payment
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, errorResponse{"payment not found"})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (s *Server) capture(c *gin.Context) {
+	s.transit(c, domain.PAYMENT_AUTHORIZED, domain.PAYMENT_CAPTURED)
+}
+
+func (s *Server) refund(c *gin.Context) {
+	s.transit(c, domain.PAYMENT_CAPTURED, domain.PAYMENT_REFUNDED)
+}
+
+// transit - moves payment from one status to another, amount must not exceed the payment
+func (s *Server) transit(c *gin.Context, from, to domain.PaymentStatus) {
+	var input amountInput
+	if err := c.BindJSON(&input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse{"invalid input body"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payment, ok := s.payments[c.Param("id")]
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, errorResponse{"payment not found"})
+		return
+	}
+	if payment.Status != from {
+		c.AbortWithStatusJSON(http.StatusConflict, errorResponse{
+			fmt.Sprintf("payment is %s, expected %s", payment.Status, from)})
+		return
+	}
+	if input.Amount <= 0 || input.Amount > payment.Amount {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse{"invalid amount"})
+		return
+	}
+
+	payment.Status = to
+	c.JSON(http.StatusOK, *payment)
+}
+
+// resolveLater - simulates the asynchronous bank answer
+func (s *Server) resolveLater(paymentId string, status domain.PaymentStatus, callbackURL string) {
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	payment := s.payments[paymentId]
+	payment.Status = status
+	event := domain.WebhookEvent{Type: "payment." + string(status), Payment: *payment}
+	s.mu.Unlock()
+
+	if callbackURL == "" {
+		return
+	}
+	if err := s.sendWebhook(callbackURL, event); err != nil {
+		logrus.Errorf("fakepay webhook fail: %s/n", err.Error())
+	}
+}
+
+func (s *Server) sendWebhook(callbackURL string, event domain.WebhookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("webhook encode fail: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), WEBHOOK_TIMEOUT)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook request fail: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SIGNATURE_HEADER, Sign(s.secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook send fail: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook rejected with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign - hex HMAC-SHA256 of the webhook body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) // nolint
+	return hex.EncodeToString(mac.Sum(nil))
+}
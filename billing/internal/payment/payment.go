@@ -0,0 +1,32 @@
+package payment
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/p12s/furniture-store/billing/internal/config"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/p12s/furniture-store/billing/internal/payment/fakepay"
+)
+
+const (
+	PROVIDER_FAKEPAY = "fakepay"
+)
+
+// PaymentProvider - payment gateway, real providers are added behind the same interface
+type PaymentProvider interface {
+	Authorize(ctx context.Context, input domain.AuthorizeInput) (domain.ProviderPayment, error)
+	Capture(ctx context.Context, paymentId string, amount int64) (domain.ProviderPayment, error)
+	Refund(ctx context.Context, paymentId string, amount int64) (domain.ProviderPayment, error)
+	VerifyWebhook(header http.Header, payload []byte) (domain.WebhookEvent, error)
+}
+
+// NewProvider - constructor, provider is chosen by config
+func NewProvider(config *config.Payment) (PaymentProvider, error) {
+	switch config.Provider {
+	case PROVIDER_FAKEPAY:
+		return fakepay.NewClient(config.URL, config.WebhookSecret), nil
+	default:
+		return nil, domain.ErrUnknownProvider
+	}
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+)
+
+var _ Accountant = (*Accounting)(nil)
+
+// periodFormats - strftime format of the report period
+var periodFormats = map[domain.GroupBy]string{
+	domain.GROUP_BY_DAY:   "%Y-%m-%d",
+	domain.GROUP_BY_WEEK:  "%Y-W%W",
+	domain.GROUP_BY_MONTH: "%Y-%m",
+}
+
+// Accountant - goods read models, repository interface
+type Accountant interface {
+	AddReceipt(product domain.Product, at time.Time) (int64, error)
+	AddSales(sales []domain.GoodsSale) error
+	MarkOrderDelivered(orderPublicId uuid.UUID, at time.Time) error
+	MarkOrderRefunded(orderPublicId uuid.UUID, at time.Time) error
+	GetGoodsReceived(filter domain.GoodsFilter) ([]domain.GoodsReceivedRow, error)
+	GetGoodsBought(filter domain.GoodsFilter) ([]domain.GoodsBoughtRow, error)
+	GetGoodsDelivery(filter domain.GoodsFilter) ([]domain.GoodsDeliveryRow, error)
+}
+
+// Accounting
+type Accounting struct {
+	db *sqlx.DB
+}
+
+// NewAccounting - constructor
+func NewAccounting(db *sqlx.DB) *Accounting {
+	return &Accounting{db: db}
+}
+
+// AddReceipt - the stock growth against the product copy, must be called before the copy is saved.
+// Returns the received quantity, 0 if the stock didn't grow
+func (r *Accounting) AddReceipt(product domain.Product, at time.Time) (int64, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	var current int64
+	query := fmt.Sprintf(`SELECT quantity FROM %s WHERE public_id=$1`, productTable)
+	err = tx.Get(&current, query, product.PublicId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("get product quantity: %w", err)
+	}
+
+	received := product.Quantity - current
+	if received <= 0 {
+		return 0, nil
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (product_public_id, dealer_public_id, quantity, received_at)
+		values ($1, $2, $3, $4)`, goodsReceiptTable)
+	_, err = tx.Exec(query, product.PublicId, product.DealerPublicId, received, at)
+	if err != nil {
+		return 0, fmt.Errorf("create goods receipt: %w", err)
+	}
+
+	return received, tx.Commit()
+}
+
+// AddSales - repeated order lines are skipped
+func (r *Accounting) AddSales(sales []domain.GoodsSale) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`INSERT INTO %s (order_public_id, product_public_id, dealer_public_id, quantity, amount, payed_at)
+		values ($1, $2, $3, $4, $5, $6) ON CONFLICT(order_public_id, product_public_id) DO NOTHING`, goodsSaleTable)
+	for _, sale := range sales {
+		_, err := tx.Exec(query, sale.OrderPublicId, sale.ProductPublicId, sale.DealerPublicId,
+			sale.Quantity, sale.Amount, sale.PayedAt)
+		if err != nil {
+			return fmt.Errorf("create goods sale: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// MarkOrderDelivered - the first time is kept
+func (r *Accounting) MarkOrderDelivered(orderPublicId uuid.UUID, at time.Time) error {
+	return r.markOrder(orderPublicId, "delivered_at", at)
+}
+
+// MarkOrderRefunded - the first time is kept
+func (r *Accounting) MarkOrderRefunded(orderPublicId uuid.UUID, at time.Time) error {
+	return r.markOrder(orderPublicId, "refunded_at", at)
+}
+
+// GetGoodsReceived - by the period of receipt
+func (r *Accounting) GetGoodsReceived(filter domain.GoodsFilter) ([]domain.GoodsReceivedRow, error) {
+	rows := make([]domain.GoodsReceivedRow, 0)
+
+	query := fmt.Sprintf(`SELECT strftime($1, received_at) AS period, dealer_public_id,
+		COUNT(DISTINCT product_public_id) AS products, SUM(quantity) AS quantity
+		FROM %s WHERE received_at>=$2 AND received_at<$3 AND ($4=$5 OR dealer_public_id=$4)
+		GROUP BY period, dealer_public_id ORDER BY period, dealer_public_id`, goodsReceiptTable)
+	err := r.db.Select(&rows, query, periodFormats[filter.GroupBy], filter.From, filter.To,
+		filter.DealerPublicId, uuid.Nil)
+	if err != nil {
+		return rows, fmt.Errorf("get goods received: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetGoodsBought - by the period of payment
+func (r *Accounting) GetGoodsBought(filter domain.GoodsFilter) ([]domain.GoodsBoughtRow, error) {
+	rows := make([]domain.GoodsBoughtRow, 0)
+
+	query := fmt.Sprintf(`SELECT strftime($1, payed_at) AS period, dealer_public_id,
+		COUNT(DISTINCT order_public_id) AS orders, SUM(quantity) AS quantity, SUM(amount) AS amount
+		FROM %s WHERE payed_at>=$2 AND payed_at<$3 AND ($4=$5 OR dealer_public_id=$4)
+		GROUP BY period, dealer_public_id ORDER BY period, dealer_public_id`, goodsSaleTable)
+	err := r.db.Select(&rows, query, periodFormats[filter.GroupBy], filter.From, filter.To,
+		filter.DealerPublicId, uuid.Nil)
+	if err != nil {
+		return rows, fmt.Errorf("get goods bought: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetGoodsDelivery - goods by the period of payment, refunded ones are not awaiting delivery
+func (r *Accounting) GetGoodsDelivery(filter domain.GoodsFilter) ([]domain.GoodsDeliveryRow, error) {
+	rows := make([]domain.GoodsDeliveryRow, 0)
+
+	query := fmt.Sprintf(`SELECT strftime($1, s.payed_at) AS period, s.dealer_public_id,
+		SUM(CASE WHEN o.delivered_at IS NOT NULL THEN s.quantity ELSE 0 END) AS delivered,
+		SUM(CASE WHEN o.delivered_at IS NULL AND o.refunded_at IS NULL THEN s.quantity ELSE 0 END) AS awaiting,
+		SUM(CASE WHEN o.delivered_at IS NULL AND o.refunded_at IS NOT NULL THEN s.quantity ELSE 0 END) AS refunded
+		FROM %s s LEFT JOIN %s o ON o.order_public_id=s.order_public_id
+		WHERE s.payed_at>=$2 AND s.payed_at<$3 AND ($4=$5 OR s.dealer_public_id=$4)
+		GROUP BY period, s.dealer_public_id ORDER BY period, s.dealer_public_id`, goodsSaleTable, goodsOrderTable)
+	err := r.db.Select(&rows, query, periodFormats[filter.GroupBy], filter.From, filter.To,
+		filter.DealerPublicId, uuid.Nil)
+	if err != nil {
+		return rows, fmt.Errorf("get goods delivery: %w", err)
+	}
+
+	return rows, nil
+}
+
+func (r *Accounting) markOrder(orderPublicId uuid.UUID, column string, at time.Time) error {
+	query := fmt.Sprintf(`INSERT INTO %[1]s (order_public_id, %[2]s) values ($1, $2)
+		ON CONFLICT(order_public_id) DO UPDATE SET %[2]s=coalesce(%[2]s, excluded.%[2]s)`, goodsOrderTable, column)
+	_, err := r.db.Exec(query, orderPublicId, at)
+	return err
+}
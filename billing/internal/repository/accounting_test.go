@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccounting(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	day := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	dealer := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+	otherDealer := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	table := domain.Product{PublicId: uuid.New(), DealerPublicId: dealer, Name: "Table", Quantity: 10}
+	chair := domain.Product{PublicId: uuid.New(), DealerPublicId: otherDealer, Name: "Chair", Quantity: 4}
+
+	t.Run("Can count only stock growth as received", func(t *testing.T) {
+		for _, tt := range []struct {
+			product          domain.Product
+			at               time.Time
+			expectedReceived int64
+		}{
+			{product: table, at: day, expectedReceived: 10},
+			{product: chair, at: day, expectedReceived: 4},
+			{product: domain.Product{PublicId: table.PublicId, DealerPublicId: dealer, Quantity: 7}, at: day.AddDate(0, 0, 1)},
+			{product: domain.Product{PublicId: table.PublicId, DealerPublicId: dealer, Quantity: 12}, at: day.AddDate(0, 0, 2),
+				expectedReceived: 5},
+		} {
+			received, err := repos.AddReceipt(tt.product, tt.at)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedReceived, received)
+			assert.NoError(t, repos.SaveProduct(tt.product))
+		}
+
+		rows, err := repos.GetGoodsReceived(domain.GoodsFilter{From: day, To: day.AddDate(0, 1, 0), GroupBy: domain.GROUP_BY_MONTH})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.GoodsReceivedRow{
+			{Period: "2021-12", DealerPublicId: dealer, Products: 1, Quantity: 15},
+			{Period: "2021-12", DealerPublicId: otherDealer, Products: 1, Quantity: 4},
+		}, rows)
+
+		rows, err = repos.GetGoodsReceived(domain.GoodsFilter{From: day, To: day.AddDate(0, 0, 1),
+			GroupBy: domain.GROUP_BY_DAY, DealerPublicId: dealer})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.GoodsReceivedRow{{Period: "2021-12-01", DealerPublicId: dealer, Products: 1, Quantity: 10}}, rows)
+	})
+
+	t.Run("Can split bought goods by delivery state", func(t *testing.T) {
+		delivered, awaiting, refunded := uuid.New(), uuid.New(), uuid.New()
+		sale := func(order uuid.UUID, product domain.Product, quantity int64) domain.GoodsSale {
+			return domain.GoodsSale{OrderPublicId: order, ProductPublicId: product.PublicId, DealerPublicId: product.DealerPublicId,
+				Quantity: quantity, Amount: quantity * 1000, PayedAt: day.Add(time.Hour)}
+		}
+
+		// delivery came before the payment
+		assert.NoError(t, repos.MarkOrderDelivered(delivered, day.Add(2*time.Hour)))
+		sales := []domain.GoodsSale{sale(delivered, table, 2), sale(delivered, chair, 4)}
+		assert.NoError(t, repos.AddSales(sales))
+		assert.NoError(t, repos.AddSales(sales), "repeated order lines are skipped")
+		assert.NoError(t, repos.AddSales([]domain.GoodsSale{sale(awaiting, table, 1)}))
+		assert.NoError(t, repos.AddSales([]domain.GoodsSale{sale(refunded, table, 3)}))
+		assert.NoError(t, repos.MarkOrderRefunded(refunded, day.Add(3*time.Hour)))
+
+		filter := domain.GoodsFilter{From: day, To: day.AddDate(0, 0, 1), GroupBy: domain.GROUP_BY_DAY}
+		bought, err := repos.GetGoodsBought(filter)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.GoodsBoughtRow{
+			{Period: "2021-12-01", DealerPublicId: dealer, Orders: 3, Quantity: 6, Amount: 6000},
+			{Period: "2021-12-01", DealerPublicId: otherDealer, Orders: 1, Quantity: 4, Amount: 4000},
+		}, bought)
+
+		delivery, err := repos.GetGoodsDelivery(filter)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.GoodsDeliveryRow{
+			{Period: "2021-12-01", DealerPublicId: dealer, Delivered: 2, Awaiting: 1, Refunded: 3},
+			{Period: "2021-12-01", DealerPublicId: otherDealer, Delivered: 4},
+		}, delivery)
+
+		filter.From = day.AddDate(0, 0, 1)
+		filter.To = day.AddDate(0, 0, 2)
+		bought, err = repos.GetGoodsBought(filter)
+		assert.NoError(t, err)
+		assert.Empty(t, bought)
+	})
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+)
+
+var _ Biller = (*Billing)(nil)
+
+// Biller - append-only ledger repository interface
+type Biller interface {
+	AddTransaction(transaction domain.Transaction) (domain.Transaction, error)
+	GetTransactionByReference(reference string) (domain.Transaction, error)
+	GetOrderTransactions(orderPublicId uuid.UUID) ([]domain.Transaction, error)
+	GetAccountTransactions(accountPublicId uuid.UUID) ([]domain.Transaction, error)
+	GetBalances() ([]domain.Balance, error)
+	GetSummary(from, to time.Time) (domain.Summary, error)
+}
+
+// Billing
+type Billing struct {
+	db *sqlx.DB
+}
+
+// NewBilling - constructor
+func NewBilling(db *sqlx.DB) *Billing {
+	return &Billing{db: db}
+}
+
+// AddTransaction - writes transaction with all its entries at once
+func (r *Billing) AddTransaction(transaction domain.Transaction) (domain.Transaction, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return transaction, err
+	}
+	defer tx.Rollback() // nolint
+
+	var exists int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE reference=$1`, transactionTable)
+	if err = tx.Get(&exists, query, transaction.Reference); err != nil {
+		return transaction, fmt.Errorf("check transaction reference: %w", err)
+	}
+	if exists > 0 {
+		return transaction, domain.ErrTransactionExists
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (public_id, reference, status, account_public_id, order_public_id, price, created_at)
+		values ($1, $2, $3, $4, $5, $6, $7)`, transactionTable)
+	result, err := tx.Exec(query, transaction.PublicId, transaction.Reference, transaction.Status,
+		transaction.AccountPublicId, transaction.OrderPublicId, transaction.Price, transaction.CreatedAt)
+	if err != nil {
+		return transaction, fmt.Errorf("insert transaction: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return transaction, fmt.Errorf("transaction id: %w", err)
+	}
+	transaction.Id = int(id)
+
+	query = fmt.Sprintf(`INSERT INTO %s (transaction_id, ledger_account, owner_public_id, debit, credit, created_at)
+		values ($1, $2, $3, $4, $5, $6)`, entryTable)
+	for i := range transaction.Entries {
+		entry := &transaction.Entries[i]
+		entry.TransactionId = transaction.Id
+		entry.CreatedAt = transaction.CreatedAt
+		_, err = tx.Exec(query, entry.TransactionId, entry.LedgerAccount, entry.OwnerPublicId,
+			entry.Debit, entry.Credit, entry.CreatedAt)
+		if err != nil {
+			return transaction, fmt.Errorf("insert entry: %w", err)
+		}
+	}
+
+	return transaction, tx.Commit()
+}
+
+// GetTransactionByReference
+func (r *Billing) GetTransactionByReference(reference string) (domain.Transaction, error) {
+	var transaction domain.Transaction
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE reference=$1`, transactionTable)
+	err := r.db.Get(&transaction, query, reference)
+	if errors.Is(err, sql.ErrNoRows) {
+		return transaction, domain.ErrTransactionNotFound
+	}
+	if err != nil {
+		return transaction, fmt.Errorf("get transaction: %w", err)
+	}
+
+	transaction.Entries, err = r.getEntries(transaction.Id)
+	return transaction, err
+}
+
+// GetOrderTransactions
+func (r *Billing) GetOrderTransactions(orderPublicId uuid.UUID) ([]domain.Transaction, error) {
+	return r.getTransactions("order_public_id", orderPublicId)
+}
+
+// GetAccountTransactions
+func (r *Billing) GetAccountTransactions(accountPublicId uuid.UUID) ([]domain.Transaction, error) {
+	return r.getTransactions("account_public_id", accountPublicId)
+}
+
+// GetBalances - turnover of every ledger account for all the time
+func (r *Billing) GetBalances() ([]domain.Balance, error) {
+	var balances []domain.Balance
+
+	query := fmt.Sprintf(`SELECT ledger_account, owner_public_id,
+		SUM(debit) AS debit, SUM(credit) AS credit, SUM(debit) - SUM(credit) AS balance
+		FROM %s GROUP BY ledger_account, owner_public_id ORDER BY ledger_account, owner_public_id`, entryTable)
+	if err := r.db.Select(&balances, query); err != nil {
+		return nil, fmt.Errorf("get balances: %w", err)
+	}
+
+	return balances, nil
+}
+
+// GetSummary - transactions and ledger turnover in [from, to)
+func (r *Billing) GetSummary(from, to time.Time) (domain.Summary, error) {
+	summary := domain.Summary{From: from, To: to}
+
+	query := fmt.Sprintf(`SELECT status, COUNT(*) AS count, SUM(price) AS price
+		FROM %s WHERE created_at >= $1 AND created_at < $2 GROUP BY status ORDER BY status`, transactionTable)
+	if err := r.db.Select(&summary.Statuses, query, from, to); err != nil {
+		return summary, fmt.Errorf("get summary statuses: %w", err)
+	}
+
+	query = fmt.Sprintf(`SELECT ledger_account, owner_public_id,
+		SUM(debit) AS debit, SUM(credit) AS credit, SUM(debit) - SUM(credit) AS balance
+		FROM %s WHERE created_at >= $1 AND created_at < $2
+		GROUP BY ledger_account, owner_public_id ORDER BY ledger_account, owner_public_id`, entryTable)
+	if err := r.db.Select(&summary.Accounts, query, from, to); err != nil {
+		return summary, fmt.Errorf("get summary accounts: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (r *Billing) getTransactions(column string, publicId uuid.UUID) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE %s=$1 ORDER BY id`, transactionTable, column)
+	if err := r.db.Select(&transactions, query, publicId); err != nil {
+		return nil, fmt.Errorf("get transactions: %w", err)
+	}
+
+	for i := range transactions {
+		entries, err := r.getEntries(transactions[i].Id)
+		if err != nil {
+			return nil, err
+		}
+		transactions[i].Entries = entries
+	}
+
+	return transactions, nil
+}
+
+func (r *Billing) getEntries(transactionId int) ([]domain.Entry, error) {
+	var entries []domain.Entry
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE transaction_id=$1 ORDER BY id`, entryTable)
+	if err := r.db.Select(&entries, query, transactionId); err != nil {
+		return nil, fmt.Errorf("get entries: %w", err)
+	}
+
+	return entries, nil
+}
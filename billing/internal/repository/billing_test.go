@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestBilling_AddTransaction(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewBilling(db)
+
+	transaction := domain.Transaction{
+		PublicId:        uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"),
+		Reference:       "payment:8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1",
+		Status:          domain.TRANSACTION_PAYMENT,
+		AccountPublicId: uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"),
+		OrderPublicId:   uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1"),
+		Price:           100,
+		Entries: []domain.Entry{
+			{LedgerAccount: domain.LEDGER_CASH, Debit: 100},
+			{LedgerAccount: domain.LEDGER_REVENUE, Credit: 100},
+		},
+		CreatedAt: time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Can add transaction with all entries",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(.+) FROM " + transactionTable).WithArgs(transaction.Reference).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec("INSERT INTO "+transactionTable).WithArgs(transaction.PublicId, transaction.Reference,
+					transaction.Status, transaction.AccountPublicId, transaction.OrderPublicId, transaction.Price,
+					transaction.CreatedAt).WillReturnResult(sqlmock.NewResult(7, 1))
+				for _, entry := range transaction.Entries {
+					mock.ExpectExec("INSERT INTO "+entryTable).WithArgs(7, entry.LedgerAccount, entry.OwnerPublicId,
+						entry.Debit, entry.Credit, transaction.CreatedAt).WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Can't add transaction with existing reference",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(.+) FROM " + transactionTable).WithArgs(transaction.Reference).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrTransactionExists,
+		},
+		{
+			name: "Can't add transaction if entry insert failed",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT(.+) FROM " + transactionTable).WithArgs(transaction.Reference).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec("INSERT INTO " + transactionTable).WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectExec("INSERT INTO " + entryTable).WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("insert entry: some error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			added, err := repo.AddTransaction(transaction)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 7, added.Id)
+				assert.Equal(t, 7, added.Entries[1].TransactionId)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
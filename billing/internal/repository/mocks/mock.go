@@ -0,0 +1,632 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/p12s/furniture-store/billing/internal/repository (interfaces: Accounter,Producter,Biller,Payer,Accountant,Privacier)
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	domain "github.com/p12s/furniture-store/billing/internal/domain"
+)
+
+// MockAccounter is a mock of Accounter interface.
+type MockAccounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccounterMockRecorder
+}
+
+// MockAccounterMockRecorder is the mock recorder for MockAccounter.
+type MockAccounterMockRecorder struct {
+	mock *MockAccounter
+}
+
+// NewMockAccounter creates a new mock instance.
+func NewMockAccounter(ctrl *gomock.Controller) *MockAccounter {
+	mock := &MockAccounter{ctrl: ctrl}
+	mock.recorder = &MockAccounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccounter) EXPECT() *MockAccounterMockRecorder {
+	return m.recorder
+}
+
+// CreateAccount mocks base method.
+func (m *MockAccounter) CreateAccount(arg0 domain.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockAccounterMockRecorder) CreateAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAccounter)(nil).CreateAccount), arg0)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccounter) DeleteAccount(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccounterMockRecorder) DeleteAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccounter)(nil).DeleteAccount), arg0)
+}
+
+// GetAccount mocks base method.
+func (m *MockAccounter) GetAccount(arg0 string) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccounterMockRecorder) GetAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccounter)(nil).GetAccount), arg0)
+}
+
+// IsSessionRevoked mocks base method.
+func (m *MockAccounter) IsSessionRevoked(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSessionRevoked", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSessionRevoked indicates an expected call of IsSessionRevoked.
+func (mr *MockAccounterMockRecorder) IsSessionRevoked(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSessionRevoked", reflect.TypeOf((*MockAccounter)(nil).IsSessionRevoked), arg0)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockAccounter) RevokeSessions(arg0 domain.SessionsRevokedInput, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAccounterMockRecorder) RevokeSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockAccounter)(nil).RevokeSessions), arg0, arg1)
+}
+
+// SetEmailVerified mocks base method.
+func (m *MockAccounter) SetEmailVerified(arg0 uuid.UUID, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailVerified", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailVerified indicates an expected call of SetEmailVerified.
+func (mr *MockAccounterMockRecorder) SetEmailVerified(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerified", reflect.TypeOf((*MockAccounter)(nil).SetEmailVerified), arg0, arg1)
+}
+
+// UpdateAccountRole mocks base method.
+func (m *MockAccounter) UpdateAccountRole(arg0 domain.UpdateAccountRoleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountRole", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountRole indicates an expected call of UpdateAccountRole.
+func (mr *MockAccounterMockRecorder) UpdateAccountRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountRole", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountRole), arg0)
+}
+
+// MockProducter is a mock of Producter interface.
+type MockProducter struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducterMockRecorder
+}
+
+// MockProducterMockRecorder is the mock recorder for MockProducter.
+type MockProducterMockRecorder struct {
+	mock *MockProducter
+}
+
+// NewMockProducter creates a new mock instance.
+func NewMockProducter(ctrl *gomock.Controller) *MockProducter {
+	mock := &MockProducter{ctrl: ctrl}
+	mock.recorder = &MockProducterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducter) EXPECT() *MockProducterMockRecorder {
+	return m.recorder
+}
+
+// GetEffectivePrice mocks base method.
+func (m *MockProducter) GetEffectivePrice(arg0 uuid.UUID, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectivePrice", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectivePrice indicates an expected call of GetEffectivePrice.
+func (mr *MockProducterMockRecorder) GetEffectivePrice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectivePrice", reflect.TypeOf((*MockProducter)(nil).GetEffectivePrice), arg0, arg1)
+}
+
+// GetProduct mocks base method.
+func (m *MockProducter) GetProduct(arg0 uuid.UUID) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", arg0)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockProducterMockRecorder) GetProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockProducter)(nil).GetProduct), arg0)
+}
+
+// SaveProduct mocks base method.
+func (m *MockProducter) SaveProduct(arg0 domain.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProduct", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProduct indicates an expected call of SaveProduct.
+func (mr *MockProducterMockRecorder) SaveProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProduct", reflect.TypeOf((*MockProducter)(nil).SaveProduct), arg0)
+}
+
+// MockBiller is a mock of Biller interface.
+type MockBiller struct {
+	ctrl     *gomock.Controller
+	recorder *MockBillerMockRecorder
+}
+
+// MockBillerMockRecorder is the mock recorder for MockBiller.
+type MockBillerMockRecorder struct {
+	mock *MockBiller
+}
+
+// NewMockBiller creates a new mock instance.
+func NewMockBiller(ctrl *gomock.Controller) *MockBiller {
+	mock := &MockBiller{ctrl: ctrl}
+	mock.recorder = &MockBillerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBiller) EXPECT() *MockBillerMockRecorder {
+	return m.recorder
+}
+
+// AddTransaction mocks base method.
+func (m *MockBiller) AddTransaction(arg0 domain.Transaction) (domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTransaction", arg0)
+	ret0, _ := ret[0].(domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTransaction indicates an expected call of AddTransaction.
+func (mr *MockBillerMockRecorder) AddTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransaction", reflect.TypeOf((*MockBiller)(nil).AddTransaction), arg0)
+}
+
+// GetAccountTransactions mocks base method.
+func (m *MockBiller) GetAccountTransactions(arg0 uuid.UUID) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountTransactions", arg0)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTransactions indicates an expected call of GetAccountTransactions.
+func (mr *MockBillerMockRecorder) GetAccountTransactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactions", reflect.TypeOf((*MockBiller)(nil).GetAccountTransactions), arg0)
+}
+
+// GetBalances mocks base method.
+func (m *MockBiller) GetBalances() ([]domain.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalances")
+	ret0, _ := ret[0].([]domain.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockBillerMockRecorder) GetBalances() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockBiller)(nil).GetBalances))
+}
+
+// GetOrderTransactions mocks base method.
+func (m *MockBiller) GetOrderTransactions(arg0 uuid.UUID) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderTransactions", arg0)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderTransactions indicates an expected call of GetOrderTransactions.
+func (mr *MockBillerMockRecorder) GetOrderTransactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderTransactions", reflect.TypeOf((*MockBiller)(nil).GetOrderTransactions), arg0)
+}
+
+// GetSummary mocks base method.
+func (m *MockBiller) GetSummary(arg0, arg1 time.Time) (domain.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummary", arg0, arg1)
+	ret0, _ := ret[0].(domain.Summary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummary indicates an expected call of GetSummary.
+func (mr *MockBillerMockRecorder) GetSummary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockBiller)(nil).GetSummary), arg0, arg1)
+}
+
+// GetTransactionByReference mocks base method.
+func (m *MockBiller) GetTransactionByReference(arg0 string) (domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByReference", arg0)
+	ret0, _ := ret[0].(domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByReference indicates an expected call of GetTransactionByReference.
+func (mr *MockBillerMockRecorder) GetTransactionByReference(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByReference", reflect.TypeOf((*MockBiller)(nil).GetTransactionByReference), arg0)
+}
+
+// MockPayer is a mock of Payer interface.
+type MockPayer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayerMockRecorder
+}
+
+// MockPayerMockRecorder is the mock recorder for MockPayer.
+type MockPayerMockRecorder struct {
+	mock *MockPayer
+}
+
+// NewMockPayer creates a new mock instance.
+func NewMockPayer(ctrl *gomock.Controller) *MockPayer {
+	mock := &MockPayer{ctrl: ctrl}
+	mock.recorder = &MockPayerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayer) EXPECT() *MockPayerMockRecorder {
+	return m.recorder
+}
+
+// CreatePayment mocks base method.
+func (m *MockPayer) CreatePayment(arg0 domain.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockPayerMockRecorder) CreatePayment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockPayer)(nil).CreatePayment), arg0)
+}
+
+// GetLastOrderPayment mocks base method.
+func (m *MockPayer) GetLastOrderPayment(arg0 uuid.UUID) (domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastOrderPayment", arg0)
+	ret0, _ := ret[0].(domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastOrderPayment indicates an expected call of GetLastOrderPayment.
+func (mr *MockPayerMockRecorder) GetLastOrderPayment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastOrderPayment", reflect.TypeOf((*MockPayer)(nil).GetLastOrderPayment), arg0)
+}
+
+// GetOrder mocks base method.
+func (m *MockPayer) GetOrder(arg0 uuid.UUID) (domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", arg0)
+	ret0, _ := ret[0].(domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockPayerMockRecorder) GetOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockPayer)(nil).GetOrder), arg0)
+}
+
+// GetPaymentByProviderId mocks base method.
+func (m *MockPayer) GetPaymentByProviderId(arg0 string) (domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByProviderId", arg0)
+	ret0, _ := ret[0].(domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByProviderId indicates an expected call of GetPaymentByProviderId.
+func (mr *MockPayerMockRecorder) GetPaymentByProviderId(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByProviderId", reflect.TypeOf((*MockPayer)(nil).GetPaymentByProviderId), arg0)
+}
+
+// SaveOrder mocks base method.
+func (m *MockPayer) SaveOrder(arg0 domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrder", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOrder indicates an expected call of SaveOrder.
+func (mr *MockPayerMockRecorder) SaveOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockPayer)(nil).SaveOrder), arg0)
+}
+
+// SetOrderItems mocks base method.
+func (m *MockPayer) SetOrderItems(arg0 uuid.UUID, arg1 []domain.OrderItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrderItems", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOrderItems indicates an expected call of SetOrderItems.
+func (mr *MockPayerMockRecorder) SetOrderItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrderItems", reflect.TypeOf((*MockPayer)(nil).SetOrderItems), arg0, arg1)
+}
+
+// SetProviderPayment mocks base method.
+func (m *MockPayer) SetProviderPayment(arg0, arg1 string, arg2 domain.PaymentStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProviderPayment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProviderPayment indicates an expected call of SetProviderPayment.
+func (mr *MockPayerMockRecorder) SetProviderPayment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderPayment", reflect.TypeOf((*MockPayer)(nil).SetProviderPayment), arg0, arg1, arg2)
+}
+
+// UpdatePaymentStatus mocks base method.
+func (m *MockPayer) UpdatePaymentStatus(arg0 string, arg1 domain.PaymentStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus.
+func (mr *MockPayerMockRecorder) UpdatePaymentStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockPayer)(nil).UpdatePaymentStatus), arg0, arg1)
+}
+
+// MockAccountant is a mock of Accountant interface.
+type MockAccountant struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountantMockRecorder
+}
+
+// MockAccountantMockRecorder is the mock recorder for MockAccountant.
+type MockAccountantMockRecorder struct {
+	mock *MockAccountant
+}
+
+// NewMockAccountant creates a new mock instance.
+func NewMockAccountant(ctrl *gomock.Controller) *MockAccountant {
+	mock := &MockAccountant{ctrl: ctrl}
+	mock.recorder = &MockAccountantMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountant) EXPECT() *MockAccountantMockRecorder {
+	return m.recorder
+}
+
+// AddReceipt mocks base method.
+func (m *MockAccountant) AddReceipt(arg0 domain.Product, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReceipt", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReceipt indicates an expected call of AddReceipt.
+func (mr *MockAccountantMockRecorder) AddReceipt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReceipt", reflect.TypeOf((*MockAccountant)(nil).AddReceipt), arg0, arg1)
+}
+
+// AddSales mocks base method.
+func (m *MockAccountant) AddSales(arg0 []domain.GoodsSale) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSales", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSales indicates an expected call of AddSales.
+func (mr *MockAccountantMockRecorder) AddSales(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSales", reflect.TypeOf((*MockAccountant)(nil).AddSales), arg0)
+}
+
+// GetGoodsBought mocks base method.
+func (m *MockAccountant) GetGoodsBought(arg0 domain.GoodsFilter) ([]domain.GoodsBoughtRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodsBought", arg0)
+	ret0, _ := ret[0].([]domain.GoodsBoughtRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodsBought indicates an expected call of GetGoodsBought.
+func (mr *MockAccountantMockRecorder) GetGoodsBought(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodsBought", reflect.TypeOf((*MockAccountant)(nil).GetGoodsBought), arg0)
+}
+
+// GetGoodsDelivery mocks base method.
+func (m *MockAccountant) GetGoodsDelivery(arg0 domain.GoodsFilter) ([]domain.GoodsDeliveryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodsDelivery", arg0)
+	ret0, _ := ret[0].([]domain.GoodsDeliveryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodsDelivery indicates an expected call of GetGoodsDelivery.
+func (mr *MockAccountantMockRecorder) GetGoodsDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodsDelivery", reflect.TypeOf((*MockAccountant)(nil).GetGoodsDelivery), arg0)
+}
+
+// GetGoodsReceived mocks base method.
+func (m *MockAccountant) GetGoodsReceived(arg0 domain.GoodsFilter) ([]domain.GoodsReceivedRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodsReceived", arg0)
+	ret0, _ := ret[0].([]domain.GoodsReceivedRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodsReceived indicates an expected call of GetGoodsReceived.
+func (mr *MockAccountantMockRecorder) GetGoodsReceived(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodsReceived", reflect.TypeOf((*MockAccountant)(nil).GetGoodsReceived), arg0)
+}
+
+// MarkOrderDelivered mocks base method.
+func (m *MockAccountant) MarkOrderDelivered(arg0 uuid.UUID, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOrderDelivered", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOrderDelivered indicates an expected call of MarkOrderDelivered.
+func (mr *MockAccountantMockRecorder) MarkOrderDelivered(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOrderDelivered", reflect.TypeOf((*MockAccountant)(nil).MarkOrderDelivered), arg0, arg1)
+}
+
+// MarkOrderRefunded mocks base method.
+func (m *MockAccountant) MarkOrderRefunded(arg0 uuid.UUID, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOrderRefunded", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOrderRefunded indicates an expected call of MarkOrderRefunded.
+func (mr *MockAccountantMockRecorder) MarkOrderRefunded(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOrderRefunded", reflect.TypeOf((*MockAccountant)(nil).MarkOrderRefunded), arg0, arg1)
+}
+
+// MockPrivacier is a mock of Privacier interface.
+type MockPrivacier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacierMockRecorder
+}
+
+// MockPrivacierMockRecorder is the mock recorder for MockPrivacier.
+type MockPrivacierMockRecorder struct {
+	mock *MockPrivacier
+}
+
+// NewMockPrivacier creates a new mock instance.
+func NewMockPrivacier(ctrl *gomock.Controller) *MockPrivacier {
+	mock := &MockPrivacier{ctrl: ctrl}
+	mock.recorder = &MockPrivacierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacier) EXPECT() *MockPrivacierMockRecorder {
+	return m.recorder
+}
+
+// EraseAccountData mocks base method.
+func (m *MockPrivacier) EraseAccountData(arg0 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseAccountData", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseAccountData indicates an expected call of EraseAccountData.
+func (mr *MockPrivacierMockRecorder) EraseAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseAccountData", reflect.TypeOf((*MockPrivacier)(nil).EraseAccountData), arg0)
+}
+
+// ExportAccountData mocks base method.
+func (m *MockPrivacier) ExportAccountData(arg0 uuid.UUID) (domain.AccountData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccountData", arg0)
+	ret0, _ := ret[0].(domain.AccountData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccountData indicates an expected call of ExportAccountData.
+func (mr *MockPrivacierMockRecorder) ExportAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccountData", reflect.TypeOf((*MockPrivacier)(nil).ExportAccountData), arg0)
+}
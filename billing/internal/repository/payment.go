@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+)
+
+var _ Payer = (*Payment)(nil)
+
+// Payer - orders waiting for payment and payment attempts repository interface
+type Payer interface {
+	SaveOrder(order domain.Order) error
+	GetOrder(publicId uuid.UUID) (domain.Order, error)
+	SetOrderItems(orderPublicId uuid.UUID, items []domain.OrderItem) error
+	CreatePayment(payment domain.Payment) error
+	SetProviderPayment(paymentId, providerPaymentId string, status domain.PaymentStatus) error
+	UpdatePaymentStatus(providerPaymentId string, status domain.PaymentStatus) error
+	GetLastOrderPayment(orderPublicId uuid.UUID) (domain.Payment, error)
+	GetPaymentByProviderId(providerPaymentId string) (domain.Payment, error)
+}
+
+// Payment
+type Payment struct {
+	db *sqlx.DB
+}
+
+// NewPayment - constructor
+func NewPayment(db *sqlx.DB) *Payment {
+	return &Payment{db: db}
+}
+
+// orderRow - order lines are kept as json, billing never queries them
+type orderRow struct {
+	PublicId        uuid.UUID `db:"public_id"`
+	AccountPublicId uuid.UUID `db:"account_public_id"`
+	Items           string    `db:"items"`
+	Total           int64     `db:"total"`
+}
+
+// SaveOrder - create or update checked out order copy
+func (r *Payment) SaveOrder(order domain.Order) error {
+	items, err := json.Marshal(order.Items)
+	if err != nil {
+		return fmt.Errorf("encode order items: %w", err)
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, account_public_id, items, total) values ($1, $2, $3, $4)
+		ON CONFLICT(public_id) DO UPDATE SET account_public_id=excluded.account_public_id,
+		items=excluded.items, total=excluded.total`, orderTable)
+	_, err = r.db.Exec(query, order.PublicId, order.AccountPublicId, string(items), order.Total)
+	return err
+}
+
+// GetOrder
+func (r *Payment) GetOrder(publicId uuid.UUID) (domain.Order, error) {
+	var row orderRow
+	var order domain.Order
+
+	query := fmt.Sprintf(`SELECT public_id, account_public_id, items, total FROM %s WHERE public_id=$1`, orderTable)
+	err := r.db.Get(&row, query, publicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return order, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return order, fmt.Errorf("get order: %w", err)
+	}
+
+	order = domain.Order{
+		PublicId:        row.PublicId,
+		AccountPublicId: row.AccountPublicId,
+		Total:           row.Total,
+	}
+	if err := json.Unmarshal([]byte(row.Items), &order.Items); err != nil {
+		return order, fmt.Errorf("decode order items: %w", err)
+	}
+
+	return order, nil
+}
+
+// SetOrderItems - order lines with the price snapshots
+func (r *Payment) SetOrderItems(orderPublicId uuid.UUID, items []domain.OrderItem) error {
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("encode order items: %w", err)
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET items=$1 WHERE public_id=$2`, orderTable)
+	_, err = r.db.Exec(query, string(encoded), orderPublicId)
+	return err
+}
+
+// CreatePayment - ErrOrderAlreadyPaid when the order has an attempt in progress or paid
+func (r *Payment) CreatePayment(payment domain.Payment) error {
+	query := fmt.Sprintf(`INSERT INTO %s (provider_payment_id, order_public_id, account_public_id,
+		amount, status, created_at, updated_at) values ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING`, paymentTable)
+	result, err := r.db.Exec(query, payment.ProviderPaymentId, payment.OrderPublicId, payment.AccountPublicId,
+		payment.Amount, payment.Status, payment.CreatedAt, payment.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	created, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if created == 0 {
+		return domain.ErrOrderAlreadyPaid
+	}
+	return nil
+}
+
+// SetProviderPayment - the attempt created before the provider call gets the provider payment id
+func (r *Payment) SetProviderPayment(paymentId, providerPaymentId string, status domain.PaymentStatus) error {
+	query := fmt.Sprintf(`UPDATE %s SET provider_payment_id=$1, status=$2, updated_at=$3
+		WHERE provider_payment_id=$4`, paymentTable)
+	_, err := r.db.Exec(query, providerPaymentId, status, time.Now().UTC(), paymentId)
+	return err
+}
+
+// UpdatePaymentStatus
+func (r *Payment) UpdatePaymentStatus(providerPaymentId string, status domain.PaymentStatus) error {
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, updated_at=$2 WHERE provider_payment_id=$3`, paymentTable)
+	_, err := r.db.Exec(query, status, time.Now().UTC(), providerPaymentId)
+	return err
+}
+
+// GetLastOrderPayment - an order can have several attempts, declined ones are followed by new
+func (r *Payment) GetLastOrderPayment(orderPublicId uuid.UUID) (domain.Payment, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE order_public_id=$1 ORDER BY id DESC LIMIT 1`, paymentTable)
+	return r.getPayment(query, orderPublicId)
+}
+
+// GetPaymentByProviderId
+func (r *Payment) GetPaymentByProviderId(providerPaymentId string) (domain.Payment, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE provider_payment_id=$1`, paymentTable)
+	return r.getPayment(query, providerPaymentId)
+}
+
+func (r *Payment) getPayment(query string, arg interface{}) (domain.Payment, error) {
+	var payment domain.Payment
+
+	err := r.db.Get(&payment, query, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		return payment, domain.ErrPaymentNotFound
+	}
+	if err != nil {
+		return payment, fmt.Errorf("get payment: %w", err)
+	}
+
+	return payment, nil
+}
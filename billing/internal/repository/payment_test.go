@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayment_CreatePayment(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	orderPublicId := uuid.New()
+	now := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	attempt := func() domain.Payment {
+		return domain.Payment{ProviderPaymentId: "local_" + uuid.NewString(), OrderPublicId: orderPublicId,
+			AccountPublicId: uuid.New(), Amount: 1000, Status: domain.PAYMENT_PENDING, CreatedAt: now, UpdatedAt: now}
+	}
+
+	first := attempt()
+	assert.NoError(t, repo.CreatePayment(first))
+
+	t.Run("Can't create a second attempt while the first is in progress", func(t *testing.T) {
+		assert.ErrorIs(t, repo.CreatePayment(attempt()), domain.ErrOrderAlreadyPaid)
+	})
+
+	t.Run("Can't create a second attempt of the captured order", func(t *testing.T) {
+		assert.NoError(t, repo.SetProviderPayment(first.ProviderPaymentId, "pay_1", domain.PAYMENT_CAPTURED))
+		assert.ErrorIs(t, repo.CreatePayment(attempt()), domain.ErrOrderAlreadyPaid)
+
+		paid, err := repo.GetLastOrderPayment(orderPublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, "pay_1", paid.ProviderPaymentId)
+	})
+
+	t.Run("Can create a new attempt after the refund", func(t *testing.T) {
+		assert.NoError(t, repo.UpdatePaymentStatus("pay_1", domain.PAYMENT_REFUNDED))
+		assert.NoError(t, repo.CreatePayment(attempt()))
+	})
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+)
+
+var _ Privacier = (*Privacy)(nil)
+
+// Privacier - repository interface
+type Privacier interface {
+	ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error)
+	EraseAccountData(accountPublicId uuid.UUID) error
+}
+
+// Privacy - personal data of the account kept here
+type Privacy struct {
+	db *sqlx.DB
+}
+
+// NewPrivacy - constructor
+func NewPrivacy(db *sqlx.DB) *Privacy {
+	return &Privacy{db: db}
+}
+
+// ExportAccountData
+func (r *Privacy) ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error) {
+	data := domain.AccountData{
+		Transactions: make([]domain.Transaction, 0),
+		Payments:     make([]domain.Payment, 0),
+	}
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE account_public_id=$1 ORDER BY id`, transactionTable)
+	if err := r.db.Select(&data.Transactions, query, accountPublicId); err != nil {
+		return data, fmt.Errorf("export transactions: %w", err)
+	}
+	query = fmt.Sprintf(`SELECT * FROM %s WHERE account_public_id=$1 ORDER BY id`, paymentTable)
+	if err := r.db.Select(&data.Payments, query, accountPublicId); err != nil {
+		return data, fmt.Errorf("export payments: %w", err)
+	}
+
+	return data, nil
+}
+
+// EraseAccountData - the ledger is append-only and the financial records must be kept,
+// they have no personal data but the account public id. Only the account copy is removed
+func (r *Privacy) EraseAccountData(accountPublicId uuid.UUID) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, accountTable)
+	if _, err := r.db.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase account: %w", err)
+	}
+	return nil
+}
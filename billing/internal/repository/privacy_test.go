@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestPrivacy_ExportAccountData(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewPrivacy(db)
+
+	accountPublicId := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+	orderPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	createdAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	transactionColumns := []string{"id", "public_id", "reference", "status", "account_public_id", "order_public_id",
+		"price", "created_at"}
+	paymentColumns := []string{"id", "provider_payment_id", "order_public_id", "account_public_id", "amount",
+		"status", "created_at", "updated_at"}
+
+	tests := []struct {
+		name         string
+		mockBehavior func()
+		want         domain.AccountData
+		wantErr      bool
+	}{
+		{
+			name: "Can export the transactions and the payments",
+			mockBehavior: func() {
+				mock.ExpectQuery("SELECT (.+) FROM " + transactionTable).WithArgs(accountPublicId).
+					WillReturnRows(sqlmock.NewRows(transactionColumns).AddRow(1, orderPublicId,
+						"payment:"+orderPublicId.String(), domain.TRANSACTION_PAYMENT, accountPublicId, orderPublicId,
+						100, createdAt))
+				mock.ExpectQuery("SELECT (.+) FROM " + paymentTable).WithArgs(accountPublicId).
+					WillReturnRows(sqlmock.NewRows(paymentColumns).AddRow(1, "pay_1", orderPublicId,
+						accountPublicId, 100, domain.PAYMENT_CAPTURED, createdAt, createdAt))
+			},
+			want: domain.AccountData{
+				Transactions: []domain.Transaction{{
+					Id:              1,
+					PublicId:        orderPublicId,
+					Reference:       "payment:" + orderPublicId.String(),
+					Status:          domain.TRANSACTION_PAYMENT,
+					AccountPublicId: accountPublicId,
+					OrderPublicId:   orderPublicId,
+					Price:           100,
+					CreatedAt:       createdAt,
+				}},
+				Payments: []domain.Payment{{
+					Id:                1,
+					ProviderPaymentId: "pay_1",
+					OrderPublicId:     orderPublicId,
+					AccountPublicId:   accountPublicId,
+					Amount:            100,
+					Status:            domain.PAYMENT_CAPTURED,
+					CreatedAt:         createdAt,
+					UpdatedAt:         createdAt,
+				}},
+			},
+		},
+		{
+			name: "Can't export if db failure",
+			mockBehavior: func() {
+				mock.ExpectQuery("SELECT (.+) FROM " + transactionTable).WithArgs(accountPublicId).
+					WillReturnError(errors.New("db failure"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			data, err := repo.ExportAccountData(accountPublicId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, data)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPrivacy_EraseAccountData(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewPrivacy(db)
+	accountPublicId := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+
+	mock.ExpectExec("DELETE FROM " + accountTable).WithArgs(accountPublicId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.EraseAccountData(accountPublicId))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
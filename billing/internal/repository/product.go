@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+)
+
+var _ Producter = (*Product)(nil)
+
+// Producter - repository interface
+type Producter interface {
+	SaveProduct(product domain.Product) error
+	GetProduct(publicId uuid.UUID) (domain.Product, error)
+	GetEffectivePrice(publicId uuid.UUID, at time.Time) (int64, error)
+}
+
+// Product
+type Product struct {
+	db *sqlx.DB
+}
+
+// NewProduct - constructor
+func NewProduct(db *sqlx.DB) *Product {
+	return &Product{db: db}
+}
+
+// SaveProduct - create or update product copy, a changed price or discount is added to the price history
+func (r *Product) SaveProduct(product domain.Product) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, dealer_public_id, name, price, discount, quantity)
+		values ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT(public_id) DO UPDATE SET dealer_public_id=excluded.dealer_public_id,
+		name=excluded.name, price=excluded.price, discount=excluded.discount, quantity=excluded.quantity`, productTable)
+	_, err = tx.Exec(query, product.PublicId, product.DealerPublicId,
+		product.Name, product.Price, product.Discount, product.Quantity)
+	if err != nil {
+		return err
+	}
+
+	changedAt := product.UpdatedAt.UTC()
+	if product.UpdatedAt.IsZero() {
+		changedAt = time.Now().UTC()
+	}
+	var last struct {
+		Price    int64 `db:"price"`
+		Discount int64 `db:"discount"`
+	}
+	query = fmt.Sprintf(`SELECT price, discount FROM %s WHERE product_public_id=$1 AND changed_at<=$2
+		ORDER BY changed_at DESC LIMIT 1`, priceHistoryTable)
+	err = tx.Get(&last, query, product.PublicId, changedAt)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("get last price: %w", err)
+	}
+	// the stock-only updates and the repeated events don't change the price
+	if err == nil && last.Price == product.Price && last.Discount == product.Discount {
+		return tx.Commit()
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (product_public_id, price, discount, changed_at) values ($1, $2, $3, $4)
+		ON CONFLICT(product_public_id, changed_at) DO NOTHING`, priceHistoryTable)
+	if _, err := tx.Exec(query, product.PublicId, product.Price, product.Discount, changedAt); err != nil {
+		return fmt.Errorf("add price change: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetProduct
+func (r *Product) GetProduct(publicId uuid.UUID) (domain.Product, error) {
+	var product domain.Product
+
+	query := fmt.Sprintf(`SELECT public_id, dealer_public_id, name, price, discount, quantity
+		FROM %s WHERE public_id=$1`, productTable)
+	err := r.db.Get(&product, query, publicId)
+	if err != nil {
+		return product, fmt.Errorf("get product: %w", err)
+	}
+
+	return product, nil
+}
+
+// GetEffectivePrice - the last price of the history at the time, with the discount applied
+func (r *Product) GetEffectivePrice(publicId uuid.UUID, at time.Time) (int64, error) {
+	var price struct {
+		Price    int64 `db:"price"`
+		Discount int64 `db:"discount"`
+	}
+
+	query := fmt.Sprintf(`SELECT price, discount FROM %s WHERE product_public_id=$1 AND changed_at<=$2
+		ORDER BY changed_at DESC LIMIT 1`, priceHistoryTable)
+	err := r.db.Get(&price, query, publicId, at.UTC())
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, domain.ErrPriceNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get effective price: %w", err)
+	}
+
+	return domain.EffectivePrice(price.Price, price.Discount), nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProduct_GetEffectivePrice(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	created := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	product := domain.Product{PublicId: uuid.New(), DealerPublicId: uuid.New(), Name: "Sofa",
+		Price: 10000, Discount: 10, Quantity: 5, UpdatedAt: created}
+
+	assert.NoError(t, repo.SaveProduct(product))
+	restocked := product
+	restocked.Quantity, restocked.UpdatedAt = 10, created.Add(time.Hour)
+	assert.NoError(t, repo.SaveProduct(restocked))
+	repriced := restocked
+	repriced.Price, repriced.UpdatedAt = 12000, created.Add(2*time.Hour)
+	assert.NoError(t, repo.SaveProduct(repriced))
+
+	t.Run("Can get the effective price at the time", func(t *testing.T) {
+		for _, tt := range []struct {
+			at    time.Time
+			price int64
+		}{
+			{at: created, price: 9000},
+			{at: created.Add(90 * time.Minute), price: 9000},
+			{at: created.Add(3 * time.Hour), price: 10800},
+		} {
+			price, err := repo.GetEffectivePrice(product.PublicId, tt.at)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.price, price, tt.at)
+		}
+	})
+
+	t.Run("Can't get the price before the product", func(t *testing.T) {
+		_, err := repo.GetEffectivePrice(product.PublicId, created.Add(-time.Minute))
+		assert.ErrorIs(t, err, domain.ErrPriceNotFound)
+		_, err = repo.GetEffectivePrice(uuid.New(), created)
+		assert.ErrorIs(t, err, domain.ErrPriceNotFound)
+	})
+
+	t.Run("Can keep one change of the stock-only update and the repeated event", func(t *testing.T) {
+		assert.NoError(t, repo.SaveProduct(repriced))
+
+		var count int
+		assert.NoError(t, db.Get(&count, `SELECT COUNT(*) FROM price_history WHERE product_public_id=$1`, product.PublicId))
+		assert.Equal(t, 2, count)
+	})
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"fmt"
+
+	_ "github.com/golang/mock/mockgen/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -destination mocks/mock.go -package repository github.com/p12s/furniture-store/billing/internal/repository Accounter,Producter,Biller,Payer,Accountant,Privacier
+
+// Repository - repo
+type Repository struct {
+	Accounter
+	Producter
+	Biller
+	Payer
+	Accountant
+	Privacier
+}
+
+// NewRepository - constructor
+func NewRepository(db *sqlx.DB) *Repository {
+	createSchema(db, accountTable, `CREATE TABLE IF NOT EXISTS account (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"role" INTEGER DEFAULT 0,
+		"email_verified" BOOLEAN DEFAULT FALSE NOT NULL
+	  );`)
+	createSchema(db, revokedSessionTable, `CREATE TABLE IF NOT EXISTS revoked_session (
+		"session_public_id" TEXT NOT NULL PRIMARY KEY,
+		"account_public_id" TEXT NOT NULL,
+		"expires_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, productTable, `CREATE TABLE IF NOT EXISTS product (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"dealer_public_id" TEXT,
+		"name" TEXT,
+		"price" INTEGER DEFAULT 0,
+		"discount" INTEGER DEFAULT 0,
+		"quantity" INTEGER DEFAULT 0
+	  );`)
+	// the prices of the product copy from its events, the payment takes the effective one
+	createSchema(db, priceHistoryTable, `CREATE TABLE IF NOT EXISTS price_history (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"product_public_id" TEXT NOT NULL,
+		"price" INTEGER NOT NULL,
+		"discount" INTEGER NOT NULL,
+		"changed_at" DATETIME NOT NULL,
+		UNIQUE (product_public_id, changed_at)
+	  );`)
+	createSchema(db, transactionTable, `CREATE TABLE IF NOT EXISTS billing_transaction (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL,
+		"reference" TEXT NOT NULL UNIQUE,
+		"status" TEXT NOT NULL,
+		"account_public_id" TEXT,
+		"order_public_id" TEXT,
+		"price" INTEGER NOT NULL,
+		"created_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, entryTable, `CREATE TABLE IF NOT EXISTS billing_entry (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"transaction_id" INTEGER NOT NULL REFERENCES billing_transaction(id),
+		"ledger_account" TEXT NOT NULL,
+		"owner_public_id" TEXT,
+		"debit" INTEGER DEFAULT 0 NOT NULL,
+		"credit" INTEGER DEFAULT 0 NOT NULL,
+		"created_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, orderTable, `CREATE TABLE IF NOT EXISTS billing_order (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"account_public_id" TEXT,
+		"items" TEXT,
+		"total" INTEGER DEFAULT 0
+	  );`)
+	createSchema(db, paymentTable, `CREATE TABLE IF NOT EXISTS payment (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"provider_payment_id" TEXT NOT NULL UNIQUE,
+		"order_public_id" TEXT NOT NULL,
+		"account_public_id" TEXT,
+		"amount" INTEGER NOT NULL,
+		"status" TEXT NOT NULL,
+		"created_at" DATETIME NOT NULL,
+		"updated_at" DATETIME NOT NULL
+	  );`)
+	// one attempt of an order is in progress or paid, concurrent attempts don't reach the provider twice
+	createSchema(db, "payment_order_active index", `CREATE UNIQUE INDEX IF NOT EXISTS payment_order_active
+		ON payment (order_public_id) WHERE status NOT IN ('declined', 'refunded');`)
+
+	createSchema(db, goodsReceiptTable, `CREATE TABLE IF NOT EXISTS goods_receipt (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"product_public_id" TEXT NOT NULL,
+		"dealer_public_id" TEXT,
+		"quantity" INTEGER NOT NULL,
+		"received_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, goodsSaleTable, `CREATE TABLE IF NOT EXISTS goods_sale (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"order_public_id" TEXT NOT NULL,
+		"product_public_id" TEXT NOT NULL,
+		"dealer_public_id" TEXT,
+		"quantity" INTEGER NOT NULL,
+		"amount" INTEGER NOT NULL,
+		"payed_at" DATETIME NOT NULL,
+		UNIQUE (order_public_id, product_public_id)
+	  );`)
+	// delivery and refund can come before the payment, so they are kept apart from the sold lines
+	createSchema(db, goodsOrderTable, `CREATE TABLE IF NOT EXISTS goods_order (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"order_public_id" TEXT NOT NULL UNIQUE,
+		"delivered_at" DATETIME,
+		"refunded_at" DATETIME
+	  );`)
+
+	// the ledger is append-only: corrections are made with new (reversal) transactions
+	for _, table := range []string{transactionTable, entryTable} {
+		for _, action := range []string{"update", "delete"} {
+			createSchema(db, fmt.Sprintf("%s_no_%s trigger", table, action), fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %[1]s_no_%[2]s
+				BEFORE %[2]s ON %[1]s
+				BEGIN SELECT RAISE(ABORT, '%[1]s is append-only'); END;`, table, action))
+		}
+	}
+
+	return &Repository{
+		Accounter:  NewAccount(db),
+		Producter:  NewProduct(db),
+		Biller:     NewBilling(db),
+		Payer:      NewPayment(db),
+		Accountant: NewAccounting(db),
+		Privacier:  NewPrivacy(db),
+	}
+}
+
+// Deliberately removed the obligation of important fields,
+// because the architecture is asynchronous, the business-event with only role (role)
+// can come before a CUD-event with all other data.
+
+// createSchema - table, trigger, index
+func createSchema(db *sqlx.DB, name, query string) {
+	statement, err := db.Prepare(query)
+	if err != nil {
+		logrus.Fatalf("create billing.%s fail: %s", name, err.Error())
+	}
+	defer statement.Close() // nolint
+
+	_, err = statement.Exec()
+	if err != nil {
+		logrus.Fatalf("exec creating billing.%s fail: %s", name, err.Error())
+	}
+
+	fmt.Printf("billing.%s created 🗂\n", name)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/p12s/furniture-store/billing/internal/repository"
+)
+
+var _ Accountant = (*AccountingService)(nil)
+
+// Accountant - service interface
+type Accountant interface {
+	RecordReceipt(product domain.Product) error
+	RecordPurchase(order domain.Order) error
+	RecordDelivery(orderPublicId uuid.UUID) error
+	RecordGoodsRefund(orderPublicId uuid.UUID) error
+	GetGoodsReceived(filter domain.GoodsFilter) ([]domain.GoodsReceivedRow, error)
+	GetGoodsBought(filter domain.GoodsFilter) ([]domain.GoodsBoughtRow, error)
+	GetGoodsDelivery(filter domain.GoodsFilter) ([]domain.GoodsDeliveryRow, error)
+}
+
+// AccountingService - goods received from dealers, bought and delivered
+type AccountingService struct {
+	repo     repository.Accountant
+	products repository.Producter
+}
+
+// NewAccountingService - constructor
+func NewAccountingService(repo repository.Accountant, products repository.Producter) *AccountingService {
+	return &AccountingService{
+		repo:     repo,
+		products: products,
+	}
+}
+
+// RecordReceipt - product event, must come before the product copy is saved
+func (s *AccountingService) RecordReceipt(product domain.Product) error {
+	_, err := s.repo.AddReceipt(product, time.Now().UTC())
+	return err
+}
+
+// RecordPurchase - payed order lines
+func (s *AccountingService) RecordPurchase(order domain.Order) error {
+	payedAt := time.Now().UTC()
+	sales := make([]domain.GoodsSale, 0, len(order.Items))
+	for _, item := range order.Items {
+		sales = append(sales, domain.GoodsSale{
+			OrderPublicId:   order.PublicId,
+			ProductPublicId: item.SKU(),
+			DealerPublicId:  itemDealer(s.products, item),
+			Quantity:        item.Quantity,
+			Amount:          item.Price * item.Quantity,
+			PayedAt:         payedAt,
+		})
+	}
+	return s.repo.AddSales(sales)
+}
+
+// RecordDelivery
+func (s *AccountingService) RecordDelivery(orderPublicId uuid.UUID) error {
+	return s.repo.MarkOrderDelivered(orderPublicId, time.Now().UTC())
+}
+
+// RecordGoodsRefund - refunded goods are not awaiting delivery any more
+func (s *AccountingService) RecordGoodsRefund(orderPublicId uuid.UUID) error {
+	return s.repo.MarkOrderRefunded(orderPublicId, time.Now().UTC())
+}
+
+// GetGoodsReceived
+func (s *AccountingService) GetGoodsReceived(filter domain.GoodsFilter) ([]domain.GoodsReceivedRow, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.GetGoodsReceived(filter)
+}
+
+// GetGoodsBought
+func (s *AccountingService) GetGoodsBought(filter domain.GoodsFilter) ([]domain.GoodsBoughtRow, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.GetGoodsBought(filter)
+}
+
+// GetGoodsDelivery
+func (s *AccountingService) GetGoodsDelivery(filter domain.GoodsFilter) ([]domain.GoodsDeliveryRow, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.GetGoodsDelivery(filter)
+}
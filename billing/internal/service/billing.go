@@ -0,0 +1,168 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/config"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/p12s/furniture-store/billing/internal/repository"
+)
+
+var _ Biller = (*BillingService)(nil)
+
+// Biller - service interface
+type Biller interface {
+	RecordPayment(order domain.Order) (domain.Transaction, error)
+	RecordRefund(order domain.Order) (domain.Transaction, error)
+	GetOrderTransactions(orderPublicId uuid.UUID) ([]domain.Transaction, error)
+	GetAccountTransactions(accountPublicId uuid.UUID) ([]domain.Transaction, error)
+	GetBalances() ([]domain.Balance, error)
+	GetSummary(from, to time.Time) (domain.Summary, error)
+}
+
+// BillingService - service
+type BillingService struct {
+	repo              repository.Biller
+	products          repository.Producter
+	commissionPercent int64
+}
+
+// NewBillingService - constructor
+func NewBillingService(repo repository.Biller, products repository.Producter, config *config.Billing) *BillingService {
+	return &BillingService{
+		repo:              repo,
+		products:          products,
+		commissionPercent: config.CommissionPercent,
+	}
+}
+
+// RecordPayment - customer money goes to cash, every order line at its payed price is split
+// between the dealer payable and the store commission (revenue).
+// Repeated events of the same order return domain.ErrTransactionExists.
+func (s *BillingService) RecordPayment(order domain.Order) (domain.Transaction, error) {
+	itemsTotal := order.PayedItemsTotal()
+	paid := order.Total
+	if paid == 0 {
+		paid = order.ItemsTotal()
+	}
+
+	transaction := s.newTransaction(paymentReference(order.PublicId), domain.TRANSACTION_PAYMENT, order, paid)
+	transaction.Entries = append(transaction.Entries, domain.Entry{
+		LedgerAccount: domain.LEDGER_CASH,
+		Debit:         paid,
+	})
+
+	var revenue int64
+	for _, item := range order.Items {
+		amount := item.PayedUnitPrice() * item.Quantity
+		commission := amount * s.commissionPercent / 100
+		revenue += commission
+		transaction.Entries = append(transaction.Entries, domain.Entry{
+			LedgerAccount: domain.LEDGER_DEALER_PAYABLE,
+			OwnerPublicId: itemDealer(s.products, item),
+			Credit:        amount - commission,
+		})
+	}
+
+	// a coupon discount or a price rise since the checkout is paid by the store, an overpayment is store income
+	switch {
+	case paid < itemsTotal:
+		transaction.Entries = append(transaction.Entries, domain.Entry{
+			LedgerAccount: domain.LEDGER_DISCOUNT,
+			Debit:         itemsTotal - paid,
+		})
+	case paid > itemsTotal:
+		revenue += paid - itemsTotal
+	}
+	if revenue > 0 {
+		transaction.Entries = append(transaction.Entries, domain.Entry{
+			LedgerAccount: domain.LEDGER_REVENUE,
+			Credit:        revenue,
+		})
+	}
+
+	return s.addTransaction(transaction)
+}
+
+// RecordRefund - full reversal of the order payment
+func (s *BillingService) RecordRefund(order domain.Order) (domain.Transaction, error) {
+	payment, err := s.repo.GetTransactionByReference(paymentReference(order.PublicId))
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("get order payment: %w", err)
+	}
+
+	order.AccountPublicId = payment.AccountPublicId
+	transaction := s.newTransaction(refundReference(order.PublicId), domain.TRANSACTION_REFUND, order, payment.Price)
+	for _, entry := range payment.Entries {
+		transaction.Entries = append(transaction.Entries, domain.Entry{
+			LedgerAccount: entry.LedgerAccount,
+			OwnerPublicId: entry.OwnerPublicId,
+			Debit:         entry.Credit,
+			Credit:        entry.Debit,
+		})
+	}
+
+	return s.addTransaction(transaction)
+}
+
+// GetOrderTransactions
+func (s *BillingService) GetOrderTransactions(orderPublicId uuid.UUID) ([]domain.Transaction, error) {
+	return s.repo.GetOrderTransactions(orderPublicId)
+}
+
+// GetAccountTransactions
+func (s *BillingService) GetAccountTransactions(accountPublicId uuid.UUID) ([]domain.Transaction, error) {
+	return s.repo.GetAccountTransactions(accountPublicId)
+}
+
+// GetBalances
+func (s *BillingService) GetBalances() ([]domain.Balance, error) {
+	return s.repo.GetBalances()
+}
+
+// GetSummary
+func (s *BillingService) GetSummary(from, to time.Time) (domain.Summary, error) {
+	return s.repo.GetSummary(from, to)
+}
+
+func (s *BillingService) newTransaction(reference string, status domain.TransactionStatus,
+	order domain.Order, price int64) domain.Transaction {
+	return domain.Transaction{
+		PublicId:        uuid.New(),
+		Reference:       reference,
+		Status:          status,
+		AccountPublicId: order.AccountPublicId,
+		OrderPublicId:   order.PublicId,
+		Price:           price,
+		CreatedAt:       time.Now().UTC(),
+	}
+}
+
+func (s *BillingService) addTransaction(transaction domain.Transaction) (domain.Transaction, error) {
+	if !transaction.Balanced() {
+		return transaction, domain.ErrTransactionUnbalanced
+	}
+	return s.repo.AddTransaction(transaction)
+}
+
+// itemDealer - order line dealer, falls back to the product copy
+func itemDealer(products repository.Producter, item domain.OrderItem) uuid.UUID {
+	if item.DealerPublicId != uuid.Nil {
+		return item.DealerPublicId
+	}
+	product, err := products.GetProduct(item.SKU())
+	if err != nil {
+		return uuid.Nil
+	}
+	return product.DealerPublicId
+}
+
+func paymentReference(orderPublicId uuid.UUID) string {
+	return "payment:" + orderPublicId.String()
+}
+
+func refundReference(orderPublicId uuid.UUID) string {
+	return "refund:" + orderPublicId.String()
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/config"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/billing/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBillingService_RecordPayment(t *testing.T) {
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	accountPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	dealerPublicId := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+	productPublicId := uuid.MustParse("a3a9fb6c-58e1-4c3b-8d2c-8b6a1d4f2b7e")
+
+	type mockBehavior func(b *mock_repository.MockBiller, p *mock_repository.MockProducter)
+
+	tests := []struct {
+		name            string
+		order           domain.Order
+		mockBehavior    mockBehavior
+		expectedEntries []domain.Entry
+		wantErr         error
+	}{
+		{
+			name: "Can split payment between dealer and store commission",
+			order: domain.Order{
+				PublicId:        orderPublicId,
+				AccountPublicId: accountPublicId,
+				Items: []domain.OrderItem{
+					{ProductPublicId: productPublicId, DealerPublicId: dealerPublicId, Quantity: 2, Price: 5000},
+				},
+			},
+			mockBehavior: func(b *mock_repository.MockBiller, p *mock_repository.MockProducter) {
+				b.EXPECT().AddTransaction(gomock.Any()).DoAndReturn(func(tx domain.Transaction) (domain.Transaction, error) {
+					return tx, nil
+				})
+			},
+			expectedEntries: []domain.Entry{
+				{LedgerAccount: domain.LEDGER_CASH, Debit: 10000},
+				{LedgerAccount: domain.LEDGER_DEALER_PAYABLE, OwnerPublicId: dealerPublicId, Credit: 9000},
+				{LedgerAccount: domain.LEDGER_REVENUE, Credit: 1000},
+			},
+		},
+		{
+			name: "Can take dealer from product copy and post coupon discount",
+			order: domain.Order{
+				PublicId:        orderPublicId,
+				AccountPublicId: accountPublicId,
+				Items: []domain.OrderItem{
+					{ProductPublicId: productPublicId, Quantity: 1, Price: 10000},
+				},
+				Total: 9500,
+			},
+			mockBehavior: func(b *mock_repository.MockBiller, p *mock_repository.MockProducter) {
+				p.EXPECT().GetProduct(productPublicId).Return(domain.Product{
+					PublicId:       productPublicId,
+					DealerPublicId: dealerPublicId,
+				}, nil)
+				b.EXPECT().AddTransaction(gomock.Any()).DoAndReturn(func(tx domain.Transaction) (domain.Transaction, error) {
+					return tx, nil
+				})
+			},
+			expectedEntries: []domain.Entry{
+				{LedgerAccount: domain.LEDGER_CASH, Debit: 9500},
+				{LedgerAccount: domain.LEDGER_DEALER_PAYABLE, OwnerPublicId: dealerPublicId, Credit: 9000},
+				{LedgerAccount: domain.LEDGER_DISCOUNT, Debit: 500},
+				{LedgerAccount: domain.LEDGER_REVENUE, Credit: 1000},
+			},
+		},
+		{
+			name: "Can post the payed price of the lines",
+			order: domain.Order{
+				PublicId:        orderPublicId,
+				AccountPublicId: accountPublicId,
+				Items: []domain.OrderItem{
+					{ProductPublicId: productPublicId, DealerPublicId: dealerPublicId, Quantity: 2, Price: 5000,
+						CartPrice: 6000, PayedPrice: 4000},
+				},
+			},
+			mockBehavior: func(b *mock_repository.MockBiller, p *mock_repository.MockProducter) {
+				b.EXPECT().AddTransaction(gomock.Any()).DoAndReturn(func(tx domain.Transaction) (domain.Transaction, error) {
+					return tx, nil
+				})
+			},
+			// the customer paid the checkout price, the price drop before the payment is store income
+			expectedEntries: []domain.Entry{
+				{LedgerAccount: domain.LEDGER_CASH, Debit: 10000},
+				{LedgerAccount: domain.LEDGER_DEALER_PAYABLE, OwnerPublicId: dealerPublicId, Credit: 7200},
+				{LedgerAccount: domain.LEDGER_REVENUE, Credit: 2800},
+			},
+		},
+		{
+			name: "Can't record the same payment twice",
+			order: domain.Order{
+				PublicId: orderPublicId,
+				Items: []domain.OrderItem{
+					{ProductPublicId: productPublicId, DealerPublicId: dealerPublicId, Quantity: 1, Price: 100},
+				},
+			},
+			mockBehavior: func(b *mock_repository.MockBiller, p *mock_repository.MockProducter) {
+				b.EXPECT().AddTransaction(gomock.Any()).Return(domain.Transaction{}, domain.ErrTransactionExists)
+			},
+			wantErr: domain.ErrTransactionExists,
+		},
+		{
+			name:         "Can't record payment of an empty order",
+			order:        domain.Order{PublicId: orderPublicId},
+			mockBehavior: func(b *mock_repository.MockBiller, p *mock_repository.MockProducter) {},
+			wantErr:      domain.ErrTransactionUnbalanced,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			biller := mock_repository.NewMockBiller(ctrl)
+			producter := mock_repository.NewMockProducter(ctrl)
+			tt.mockBehavior(biller, producter)
+
+			s := NewBillingService(biller, producter, &config.Billing{CommissionPercent: 10})
+			transaction, err := s.RecordPayment(tt.order)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, domain.TRANSACTION_PAYMENT, transaction.Status)
+			assert.Equal(t, "payment:"+orderPublicId.String(), transaction.Reference)
+			assert.Equal(t, tt.expectedEntries, transaction.Entries)
+			assert.True(t, transaction.Balanced())
+		})
+	}
+}
+
+func TestBillingService_RecordRefund(t *testing.T) {
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	accountPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	dealerPublicId := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+
+	type mockBehavior func(b *mock_repository.MockBiller)
+
+	tests := []struct {
+		name            string
+		mockBehavior    mockBehavior
+		expectedEntries []domain.Entry
+		wantErr         bool
+	}{
+		{
+			name: "Can reverse order payment",
+			mockBehavior: func(b *mock_repository.MockBiller) {
+				b.EXPECT().GetTransactionByReference("payment:"+orderPublicId.String()).Return(domain.Transaction{
+					AccountPublicId: accountPublicId,
+					OrderPublicId:   orderPublicId,
+					Price:           100,
+					Entries: []domain.Entry{
+						{LedgerAccount: domain.LEDGER_CASH, Debit: 100},
+						{LedgerAccount: domain.LEDGER_DEALER_PAYABLE, OwnerPublicId: dealerPublicId, Credit: 90},
+						{LedgerAccount: domain.LEDGER_REVENUE, Credit: 10},
+					},
+				}, nil)
+				b.EXPECT().AddTransaction(gomock.Any()).DoAndReturn(func(tx domain.Transaction) (domain.Transaction, error) {
+					return tx, nil
+				})
+			},
+			expectedEntries: []domain.Entry{
+				{LedgerAccount: domain.LEDGER_CASH, Credit: 100},
+				{LedgerAccount: domain.LEDGER_DEALER_PAYABLE, OwnerPublicId: dealerPublicId, Debit: 90},
+				{LedgerAccount: domain.LEDGER_REVENUE, Debit: 10},
+			},
+		},
+		{
+			name: "Can't refund not payed order",
+			mockBehavior: func(b *mock_repository.MockBiller) {
+				b.EXPECT().GetTransactionByReference("payment:"+orderPublicId.String()).
+					Return(domain.Transaction{}, domain.ErrTransactionNotFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			biller := mock_repository.NewMockBiller(ctrl)
+			tt.mockBehavior(biller)
+
+			s := NewBillingService(biller, mock_repository.NewMockProducter(ctrl), &config.Billing{CommissionPercent: 10})
+			transaction, err := s.RecordRefund(domain.Order{PublicId: orderPublicId})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, domain.TRANSACTION_REFUND, transaction.Status)
+			assert.Equal(t, accountPublicId, transaction.AccountPublicId)
+			assert.Equal(t, tt.expectedEntries, transaction.Entries)
+		})
+	}
+}
@@ -0,0 +1,562 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/p12s/furniture-store/billing/internal/service (interfaces: Accounter,Producter,Biller,Payer,Accountant,Privacier)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	domain "github.com/p12s/furniture-store/billing/internal/domain"
+)
+
+// MockAccounter is a mock of Accounter interface.
+type MockAccounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccounterMockRecorder
+}
+
+// MockAccounterMockRecorder is the mock recorder for MockAccounter.
+type MockAccounterMockRecorder struct {
+	mock *MockAccounter
+}
+
+// NewMockAccounter creates a new mock instance.
+func NewMockAccounter(ctrl *gomock.Controller) *MockAccounter {
+	mock := &MockAccounter{ctrl: ctrl}
+	mock.recorder = &MockAccounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccounter) EXPECT() *MockAccounterMockRecorder {
+	return m.recorder
+}
+
+// CreateAccount mocks base method.
+func (m *MockAccounter) CreateAccount(arg0 domain.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockAccounterMockRecorder) CreateAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAccounter)(nil).CreateAccount), arg0)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccounter) DeleteAccount(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccounterMockRecorder) DeleteAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccounter)(nil).DeleteAccount), arg0)
+}
+
+// GetAccount mocks base method.
+func (m *MockAccounter) GetAccount(arg0 string) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccounterMockRecorder) GetAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccounter)(nil).GetAccount), arg0)
+}
+
+// ParseToken mocks base method.
+func (m *MockAccounter) ParseToken(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockAccounterMockRecorder) ParseToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAccounter)(nil).ParseToken), arg0)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockAccounter) RevokeSessions(arg0 domain.SessionsRevokedInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAccounterMockRecorder) RevokeSessions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockAccounter)(nil).RevokeSessions), arg0)
+}
+
+// SetEmailVerified mocks base method.
+func (m *MockAccounter) SetEmailVerified(arg0 uuid.UUID, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailVerified", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailVerified indicates an expected call of SetEmailVerified.
+func (mr *MockAccounterMockRecorder) SetEmailVerified(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerified", reflect.TypeOf((*MockAccounter)(nil).SetEmailVerified), arg0, arg1)
+}
+
+// UpdateAccountRole mocks base method.
+func (m *MockAccounter) UpdateAccountRole(arg0 domain.UpdateAccountRoleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountRole", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountRole indicates an expected call of UpdateAccountRole.
+func (mr *MockAccounterMockRecorder) UpdateAccountRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountRole", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountRole), arg0)
+}
+
+// MockProducter is a mock of Producter interface.
+type MockProducter struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducterMockRecorder
+}
+
+// MockProducterMockRecorder is the mock recorder for MockProducter.
+type MockProducterMockRecorder struct {
+	mock *MockProducter
+}
+
+// NewMockProducter creates a new mock instance.
+func NewMockProducter(ctrl *gomock.Controller) *MockProducter {
+	mock := &MockProducter{ctrl: ctrl}
+	mock.recorder = &MockProducterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducter) EXPECT() *MockProducterMockRecorder {
+	return m.recorder
+}
+
+// SaveProduct mocks base method.
+func (m *MockProducter) SaveProduct(arg0 domain.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProduct", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProduct indicates an expected call of SaveProduct.
+func (mr *MockProducterMockRecorder) SaveProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProduct", reflect.TypeOf((*MockProducter)(nil).SaveProduct), arg0)
+}
+
+// MockBiller is a mock of Biller interface.
+type MockBiller struct {
+	ctrl     *gomock.Controller
+	recorder *MockBillerMockRecorder
+}
+
+// MockBillerMockRecorder is the mock recorder for MockBiller.
+type MockBillerMockRecorder struct {
+	mock *MockBiller
+}
+
+// NewMockBiller creates a new mock instance.
+func NewMockBiller(ctrl *gomock.Controller) *MockBiller {
+	mock := &MockBiller{ctrl: ctrl}
+	mock.recorder = &MockBillerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBiller) EXPECT() *MockBillerMockRecorder {
+	return m.recorder
+}
+
+// GetAccountTransactions mocks base method.
+func (m *MockBiller) GetAccountTransactions(arg0 uuid.UUID) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountTransactions", arg0)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTransactions indicates an expected call of GetAccountTransactions.
+func (mr *MockBillerMockRecorder) GetAccountTransactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactions", reflect.TypeOf((*MockBiller)(nil).GetAccountTransactions), arg0)
+}
+
+// GetBalances mocks base method.
+func (m *MockBiller) GetBalances() ([]domain.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalances")
+	ret0, _ := ret[0].([]domain.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockBillerMockRecorder) GetBalances() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockBiller)(nil).GetBalances))
+}
+
+// GetOrderTransactions mocks base method.
+func (m *MockBiller) GetOrderTransactions(arg0 uuid.UUID) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderTransactions", arg0)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderTransactions indicates an expected call of GetOrderTransactions.
+func (mr *MockBillerMockRecorder) GetOrderTransactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderTransactions", reflect.TypeOf((*MockBiller)(nil).GetOrderTransactions), arg0)
+}
+
+// GetSummary mocks base method.
+func (m *MockBiller) GetSummary(arg0, arg1 time.Time) (domain.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummary", arg0, arg1)
+	ret0, _ := ret[0].(domain.Summary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummary indicates an expected call of GetSummary.
+func (mr *MockBillerMockRecorder) GetSummary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockBiller)(nil).GetSummary), arg0, arg1)
+}
+
+// RecordPayment mocks base method.
+func (m *MockBiller) RecordPayment(arg0 domain.Order) (domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPayment", arg0)
+	ret0, _ := ret[0].(domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordPayment indicates an expected call of RecordPayment.
+func (mr *MockBillerMockRecorder) RecordPayment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPayment", reflect.TypeOf((*MockBiller)(nil).RecordPayment), arg0)
+}
+
+// RecordRefund mocks base method.
+func (m *MockBiller) RecordRefund(arg0 domain.Order) (domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRefund", arg0)
+	ret0, _ := ret[0].(domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordRefund indicates an expected call of RecordRefund.
+func (mr *MockBillerMockRecorder) RecordRefund(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRefund", reflect.TypeOf((*MockBiller)(nil).RecordRefund), arg0)
+}
+
+// MockPayer is a mock of Payer interface.
+type MockPayer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayerMockRecorder
+}
+
+// MockPayerMockRecorder is the mock recorder for MockPayer.
+type MockPayerMockRecorder struct {
+	mock *MockPayer
+}
+
+// NewMockPayer creates a new mock instance.
+func NewMockPayer(ctrl *gomock.Controller) *MockPayer {
+	mock := &MockPayer{ctrl: ctrl}
+	mock.recorder = &MockPayerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayer) EXPECT() *MockPayerMockRecorder {
+	return m.recorder
+}
+
+// GetOrderPayment mocks base method.
+func (m *MockPayer) GetOrderPayment(arg0 string, arg1 uuid.UUID) (domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderPayment", arg0, arg1)
+	ret0, _ := ret[0].(domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderPayment indicates an expected call of GetOrderPayment.
+func (mr *MockPayerMockRecorder) GetOrderPayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderPayment", reflect.TypeOf((*MockPayer)(nil).GetOrderPayment), arg0, arg1)
+}
+
+// HandleWebhook mocks base method.
+func (m *MockPayer) HandleWebhook(arg0 context.Context, arg1 http.Header, arg2 []byte) (domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebhook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleWebhook indicates an expected call of HandleWebhook.
+func (mr *MockPayerMockRecorder) HandleWebhook(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebhook", reflect.TypeOf((*MockPayer)(nil).HandleWebhook), arg0, arg1, arg2)
+}
+
+// Pay mocks base method.
+func (m *MockPayer) Pay(arg0 context.Context, arg1 string, arg2 uuid.UUID, arg3 domain.PayInput) (domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockPayerMockRecorder) Pay(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockPayer)(nil).Pay), arg0, arg1, arg2, arg3)
+}
+
+// RefundPayment mocks base method.
+func (m *MockPayer) RefundPayment(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPayment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefundPayment indicates an expected call of RefundPayment.
+func (mr *MockPayerMockRecorder) RefundPayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPayment", reflect.TypeOf((*MockPayer)(nil).RefundPayment), arg0, arg1)
+}
+
+// SaveOrder mocks base method.
+func (m *MockPayer) SaveOrder(arg0 domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrder", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOrder indicates an expected call of SaveOrder.
+func (mr *MockPayerMockRecorder) SaveOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockPayer)(nil).SaveOrder), arg0)
+}
+
+// MockAccountant is a mock of Accountant interface.
+type MockAccountant struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountantMockRecorder
+}
+
+// MockAccountantMockRecorder is the mock recorder for MockAccountant.
+type MockAccountantMockRecorder struct {
+	mock *MockAccountant
+}
+
+// NewMockAccountant creates a new mock instance.
+func NewMockAccountant(ctrl *gomock.Controller) *MockAccountant {
+	mock := &MockAccountant{ctrl: ctrl}
+	mock.recorder = &MockAccountantMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountant) EXPECT() *MockAccountantMockRecorder {
+	return m.recorder
+}
+
+// GetGoodsBought mocks base method.
+func (m *MockAccountant) GetGoodsBought(arg0 domain.GoodsFilter) ([]domain.GoodsBoughtRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodsBought", arg0)
+	ret0, _ := ret[0].([]domain.GoodsBoughtRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodsBought indicates an expected call of GetGoodsBought.
+func (mr *MockAccountantMockRecorder) GetGoodsBought(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodsBought", reflect.TypeOf((*MockAccountant)(nil).GetGoodsBought), arg0)
+}
+
+// GetGoodsDelivery mocks base method.
+func (m *MockAccountant) GetGoodsDelivery(arg0 domain.GoodsFilter) ([]domain.GoodsDeliveryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodsDelivery", arg0)
+	ret0, _ := ret[0].([]domain.GoodsDeliveryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodsDelivery indicates an expected call of GetGoodsDelivery.
+func (mr *MockAccountantMockRecorder) GetGoodsDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodsDelivery", reflect.TypeOf((*MockAccountant)(nil).GetGoodsDelivery), arg0)
+}
+
+// GetGoodsReceived mocks base method.
+func (m *MockAccountant) GetGoodsReceived(arg0 domain.GoodsFilter) ([]domain.GoodsReceivedRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodsReceived", arg0)
+	ret0, _ := ret[0].([]domain.GoodsReceivedRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodsReceived indicates an expected call of GetGoodsReceived.
+func (mr *MockAccountantMockRecorder) GetGoodsReceived(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodsReceived", reflect.TypeOf((*MockAccountant)(nil).GetGoodsReceived), arg0)
+}
+
+// RecordDelivery mocks base method.
+func (m *MockAccountant) RecordDelivery(arg0 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordDelivery", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordDelivery indicates an expected call of RecordDelivery.
+func (mr *MockAccountantMockRecorder) RecordDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDelivery", reflect.TypeOf((*MockAccountant)(nil).RecordDelivery), arg0)
+}
+
+// RecordGoodsRefund mocks base method.
+func (m *MockAccountant) RecordGoodsRefund(arg0 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordGoodsRefund", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordGoodsRefund indicates an expected call of RecordGoodsRefund.
+func (mr *MockAccountantMockRecorder) RecordGoodsRefund(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordGoodsRefund", reflect.TypeOf((*MockAccountant)(nil).RecordGoodsRefund), arg0)
+}
+
+// RecordPurchase mocks base method.
+func (m *MockAccountant) RecordPurchase(arg0 domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPurchase", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordPurchase indicates an expected call of RecordPurchase.
+func (mr *MockAccountantMockRecorder) RecordPurchase(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPurchase", reflect.TypeOf((*MockAccountant)(nil).RecordPurchase), arg0)
+}
+
+// RecordReceipt mocks base method.
+func (m *MockAccountant) RecordReceipt(arg0 domain.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordReceipt", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordReceipt indicates an expected call of RecordReceipt.
+func (mr *MockAccountantMockRecorder) RecordReceipt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordReceipt", reflect.TypeOf((*MockAccountant)(nil).RecordReceipt), arg0)
+}
+
+// MockPrivacier is a mock of Privacier interface.
+type MockPrivacier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacierMockRecorder
+}
+
+// MockPrivacierMockRecorder is the mock recorder for MockPrivacier.
+type MockPrivacierMockRecorder struct {
+	mock *MockPrivacier
+}
+
+// NewMockPrivacier creates a new mock instance.
+func NewMockPrivacier(ctrl *gomock.Controller) *MockPrivacier {
+	mock := &MockPrivacier{ctrl: ctrl}
+	mock.recorder = &MockPrivacierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacier) EXPECT() *MockPrivacierMockRecorder {
+	return m.recorder
+}
+
+// EraseAccountData mocks base method.
+func (m *MockPrivacier) EraseAccountData(arg0 domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseAccountData", arg0)
+	ret0, _ := ret[0].(domain.PrivacyAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseAccountData indicates an expected call of EraseAccountData.
+func (mr *MockPrivacierMockRecorder) EraseAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseAccountData", reflect.TypeOf((*MockPrivacier)(nil).EraseAccountData), arg0)
+}
+
+// ExportAccountData mocks base method.
+func (m *MockPrivacier) ExportAccountData(arg0 domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccountData", arg0)
+	ret0, _ := ret[0].(domain.PrivacyAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccountData indicates an expected call of ExportAccountData.
+func (mr *MockPrivacierMockRecorder) ExportAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccountData", reflect.TypeOf((*MockPrivacier)(nil).ExportAccountData), arg0)
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/config"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/p12s/furniture-store/billing/internal/payment"
+	"github.com/p12s/furniture-store/billing/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// LOCAL_PAYMENT_PREFIX - the payment id of the attempt until the provider answers
+	LOCAL_PAYMENT_PREFIX = "local_"
+)
+
+var _ Payer = (*PaymentService)(nil)
+
+// Payer - service interface
+type Payer interface {
+	SaveOrder(order domain.Order) error
+	Pay(ctx context.Context, accountPublicId string, orderPublicId uuid.UUID, input domain.PayInput) (domain.Payment, error)
+	GetOrderPayment(accountPublicId string, orderPublicId uuid.UUID) (domain.Payment, error)
+	HandleWebhook(ctx context.Context, header http.Header, payload []byte) (domain.Payment, error)
+	RefundPayment(ctx context.Context, orderPublicId uuid.UUID) error
+}
+
+// PaymentService - checkout through a payment provider,
+// a captured payment is posted to the ledger with the biller
+type PaymentService struct {
+	repo                 repository.Payer
+	accounts             repository.Accounter
+	products             repository.Producter
+	biller               Biller
+	provider             payment.PaymentProvider
+	callbackURL          string
+	requireVerifiedEmail bool
+}
+
+// NewPaymentService - constructor
+func NewPaymentService(repo repository.Payer, accounts repository.Accounter, products repository.Producter,
+	biller Biller, provider payment.PaymentProvider, config *config.Payment) *PaymentService {
+	return &PaymentService{
+		repo:                 repo,
+		accounts:             accounts,
+		products:             products,
+		biller:               biller,
+		provider:             provider,
+		callbackURL:          config.CallbackURL,
+		requireVerifiedEmail: config.RequireVerifiedEmail,
+	}
+}
+
+// SaveOrder - checked out order waits for payment
+func (s *PaymentService) SaveOrder(order domain.Order) error {
+	return s.repo.SaveOrder(order)
+}
+
+// Pay - authorizes and captures the order total. Pending payment is finished by the provider webhook.
+func (s *PaymentService) Pay(ctx context.Context, accountPublicId string, orderPublicId uuid.UUID,
+	input domain.PayInput) (domain.Payment, error) {
+	order, err := s.repo.GetOrder(orderPublicId)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+	if order.AccountPublicId.String() != accountPublicId {
+		return domain.Payment{}, domain.ErrOrderNotFound
+	}
+	if err := s.checkEmailVerified(accountPublicId); err != nil {
+		return domain.Payment{}, err
+	}
+
+	last, err := s.repo.GetLastOrderPayment(orderPublicId)
+	if err == nil && last.Status != domain.PAYMENT_DECLINED && last.Status != domain.PAYMENT_REFUNDED {
+		return last, domain.ErrOrderAlreadyPaid
+	}
+	if err != nil && !errors.Is(err, domain.ErrPaymentNotFound) {
+		return domain.Payment{}, err
+	}
+
+	amount := order.Total
+	if amount == 0 {
+		amount = order.ItemsTotal()
+	}
+
+	// the attempt is created before the provider call, so a concurrent one for the order fails
+	// with ErrOrderAlreadyPaid instead of charging twice
+	now := time.Now().UTC()
+	paid := domain.Payment{
+		ProviderPaymentId: LOCAL_PAYMENT_PREFIX + uuid.NewString(),
+		OrderPublicId:     order.PublicId,
+		AccountPublicId:   order.AccountPublicId,
+		Amount:            amount,
+		Status:            domain.PAYMENT_PENDING,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := s.repo.CreatePayment(paid); err != nil {
+		if errors.Is(err, domain.ErrOrderAlreadyPaid) {
+			last, _ := s.repo.GetLastOrderPayment(orderPublicId)
+			return last, err
+		}
+		return domain.Payment{}, fmt.Errorf("create payment: %w", err)
+	}
+	s.snapshotPrices(order, now)
+
+	authorized, err := s.provider.Authorize(ctx, domain.AuthorizeInput{
+		OrderPublicId: order.PublicId,
+		Amount:        amount,
+		CardToken:     input.CardToken,
+		CallbackURL:   s.callbackURL,
+	})
+	if err != nil {
+		// the failed attempt doesn't hold the order
+		if err := s.repo.UpdatePaymentStatus(paid.ProviderPaymentId, domain.PAYMENT_DECLINED); err != nil {
+			logrus.Errorf("release payment %s: %s", paid.ProviderPaymentId, err.Error())
+		}
+		return domain.Payment{}, fmt.Errorf("authorize payment: %w", err)
+	}
+
+	if err := s.repo.SetProviderPayment(paid.ProviderPaymentId, authorized.Id, authorized.Status); err != nil {
+		return paid, fmt.Errorf("update payment: %w", err)
+	}
+	paid.ProviderPaymentId, paid.Status = authorized.Id, authorized.Status
+
+	return s.proceed(ctx, paid)
+}
+
+// snapshotPrices - the order lines keep the effective price at the payment from the price history,
+// the checkout price is kept for the products without the history. The failed snapshot doesn't stop the payment
+func (s *PaymentService) snapshotPrices(order domain.Order, at time.Time) {
+	items := make([]domain.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		item.PayedPrice = item.Price
+		price, err := s.products.GetEffectivePrice(item.SKU(), at)
+		if err == nil {
+			item.PayedPrice = price
+		} else if !errors.Is(err, domain.ErrPriceNotFound) {
+			logrus.Errorf("order %s price snapshot: %s", order.PublicId, err.Error())
+		}
+		items[i] = item
+	}
+
+	if err := s.repo.SetOrderItems(order.PublicId, items); err != nil {
+		logrus.Errorf("order %s price snapshot: %s", order.PublicId, err.Error())
+	}
+}
+
+// checkEmailVerified - by the policy, an account copy that is not known yet is not verified
+func (s *PaymentService) checkEmailVerified(accountPublicId string) error {
+	if !s.requireVerifiedEmail {
+		return nil
+	}
+	account, err := s.accounts.GetAccount(accountPublicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrEmailNotVerified
+	}
+	if err != nil {
+		return err
+	}
+	if !account.EmailVerified {
+		return domain.ErrEmailNotVerified
+	}
+	return nil
+}
+
+// GetOrderPayment - last payment attempt of the caller order
+func (s *PaymentService) GetOrderPayment(accountPublicId string, orderPublicId uuid.UUID) (domain.Payment, error) {
+	paid, err := s.repo.GetLastOrderPayment(orderPublicId)
+	if err != nil {
+		return paid, err
+	}
+	if paid.AccountPublicId.String() != accountPublicId {
+		return domain.Payment{}, domain.ErrPaymentNotFound
+	}
+	return paid, nil
+}
+
+// HandleWebhook - asynchronous provider answer for a pending payment
+func (s *PaymentService) HandleWebhook(ctx context.Context, header http.Header, payload []byte) (domain.Payment, error) {
+	event, err := s.provider.VerifyWebhook(header, payload)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	paid, err := s.repo.GetPaymentByProviderId(event.Payment.Id)
+	if err != nil {
+		return paid, err
+	}
+	if paid.Status != domain.PAYMENT_PENDING {
+		// repeated webhook, already processed
+		return paid, nil
+	}
+
+	paid.Status = event.Payment.Status
+	if err := s.repo.UpdatePaymentStatus(paid.ProviderPaymentId, paid.Status); err != nil {
+		return paid, fmt.Errorf("update payment: %w", err)
+	}
+
+	return s.proceed(ctx, paid)
+}
+
+// RefundPayment - returns captured money through the provider, orders payed elsewhere are skipped
+func (s *PaymentService) RefundPayment(ctx context.Context, orderPublicId uuid.UUID) error {
+	paid, err := s.repo.GetLastOrderPayment(orderPublicId)
+	if errors.Is(err, domain.ErrPaymentNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if paid.Status != domain.PAYMENT_CAPTURED {
+		return nil
+	}
+
+	if _, err := s.provider.Refund(ctx, paid.ProviderPaymentId, paid.Amount); err != nil {
+		return fmt.Errorf("refund payment: %w", err)
+	}
+	return s.repo.UpdatePaymentStatus(paid.ProviderPaymentId, domain.PAYMENT_REFUNDED)
+}
+
+// proceed - captures authorized payment and posts it to the ledger
+func (s *PaymentService) proceed(ctx context.Context, paid domain.Payment) (domain.Payment, error) {
+	switch paid.Status {
+	case domain.PAYMENT_DECLINED:
+		return paid, domain.ErrPaymentDeclined
+	case domain.PAYMENT_AUTHORIZED:
+		captured, err := s.provider.Capture(ctx, paid.ProviderPaymentId, paid.Amount)
+		if err != nil {
+			return paid, fmt.Errorf("capture payment: %w", err)
+		}
+		paid.Status = captured.Status
+		if err := s.repo.UpdatePaymentStatus(paid.ProviderPaymentId, paid.Status); err != nil {
+			return paid, fmt.Errorf("update payment: %w", err)
+		}
+	case domain.PAYMENT_PENDING, domain.PAYMENT_CAPTURED, domain.PAYMENT_REFUNDED:
+	}
+
+	if paid.Status != domain.PAYMENT_CAPTURED {
+		return paid, nil
+	}
+
+	order, err := s.repo.GetOrder(paid.OrderPublicId)
+	if err != nil {
+		return paid, err
+	}
+	order.Total = paid.Amount
+	transaction, err := s.biller.RecordPayment(order)
+	if errors.Is(err, domain.ErrTransactionExists) {
+		return paid, nil
+	}
+	if err != nil {
+		return paid, fmt.Errorf("record payment: %w", err)
+	}
+	paid.Transaction = &transaction
+
+	return paid, nil
+}
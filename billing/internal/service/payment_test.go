@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/config"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/p12s/furniture-store/billing/internal/payment/fakepay"
+	mock_repository "github.com/p12s/furniture-store/billing/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	TEST_WEBHOOK_SECRET = "secret"
+	TEST_PROVIDER_DELAY = 10 * time.Millisecond
+)
+
+func TestPaymentService_Pay(t *testing.T) {
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	accountPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	dealerPublicId := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+	order := domain.Order{
+		PublicId:        orderPublicId,
+		AccountPublicId: accountPublicId,
+		Items: []domain.OrderItem{
+			{ProductPublicId: uuid.New(), VariantPublicId: uuid.New(), DealerPublicId: dealerPublicId, Quantity: 2, Price: 5000},
+		},
+	}
+
+	type mockBehavior func(p *mock_repository.MockPayer, b *mock_repository.MockBiller, pr *mock_repository.MockProducter)
+
+	tests := []struct {
+		name            string
+		accountPublicId string
+		cardToken       string
+		mockBehavior    mockBehavior
+		expectedStatus  domain.PaymentStatus
+		expectedPosting bool
+		wantErr         error
+	}{
+		{
+			name:            "Can capture payment with the price snapshot and post it to the ledger",
+			accountPublicId: accountPublicId.String(),
+			cardToken:       fakepay.TOKEN_SUCCESS,
+			mockBehavior: func(p *mock_repository.MockPayer, b *mock_repository.MockBiller, pr *mock_repository.MockProducter) {
+				p.EXPECT().GetOrder(orderPublicId).Return(order, nil).Times(2)
+				p.EXPECT().GetLastOrderPayment(orderPublicId).Return(domain.Payment{}, domain.ErrPaymentNotFound)
+				p.EXPECT().CreatePayment(gomock.Any()).Return(nil)
+				pr.EXPECT().GetEffectivePrice(order.Items[0].VariantPublicId, gomock.Any()).Return(int64(4500), nil)
+				snapshot := order.Items[0]
+				snapshot.PayedPrice = 4500
+				p.EXPECT().SetOrderItems(orderPublicId, []domain.OrderItem{snapshot}).Return(nil)
+				p.EXPECT().SetProviderPayment(gomock.Any(), gomock.Any(), domain.PAYMENT_AUTHORIZED).Return(nil)
+				p.EXPECT().UpdatePaymentStatus(gomock.Any(), domain.PAYMENT_CAPTURED).Return(nil)
+				b.EXPECT().AddTransaction(gomock.Any()).DoAndReturn(func(tx domain.Transaction) (domain.Transaction, error) {
+					return tx, nil
+				})
+			},
+			expectedStatus:  domain.PAYMENT_CAPTURED,
+			expectedPosting: true,
+		},
+		{
+			name:            "Can leave payment pending until the webhook",
+			accountPublicId: accountPublicId.String(),
+			cardToken:       fakepay.TOKEN_DELAY,
+			mockBehavior: func(p *mock_repository.MockPayer, b *mock_repository.MockBiller, pr *mock_repository.MockProducter) {
+				p.EXPECT().GetOrder(orderPublicId).Return(order, nil)
+				p.EXPECT().GetLastOrderPayment(orderPublicId).Return(domain.Payment{}, domain.ErrPaymentNotFound)
+				p.EXPECT().CreatePayment(gomock.Any()).Return(nil)
+				pr.EXPECT().GetEffectivePrice(gomock.Any(), gomock.Any()).Return(int64(0), domain.ErrPriceNotFound)
+				snapshot := order.Items[0]
+				snapshot.PayedPrice = snapshot.Price
+				p.EXPECT().SetOrderItems(orderPublicId, []domain.OrderItem{snapshot}).Return(nil)
+				p.EXPECT().SetProviderPayment(gomock.Any(), gomock.Any(), domain.PAYMENT_PENDING).Return(nil)
+			},
+			expectedStatus: domain.PAYMENT_PENDING,
+		},
+		{
+			name:            "Can retry after declined payment",
+			accountPublicId: accountPublicId.String(),
+			cardToken:       fakepay.TOKEN_DECLINE,
+			mockBehavior: func(p *mock_repository.MockPayer, b *mock_repository.MockBiller, pr *mock_repository.MockProducter) {
+				p.EXPECT().GetOrder(orderPublicId).Return(order, nil)
+				p.EXPECT().GetLastOrderPayment(orderPublicId).Return(domain.Payment{Status: domain.PAYMENT_DECLINED}, nil)
+				p.EXPECT().CreatePayment(gomock.Any()).Return(nil)
+				pr.EXPECT().GetEffectivePrice(gomock.Any(), gomock.Any()).Return(int64(0), domain.ErrPriceNotFound)
+				snapshot := order.Items[0]
+				snapshot.PayedPrice = snapshot.Price
+				p.EXPECT().SetOrderItems(orderPublicId, []domain.OrderItem{snapshot}).Return(nil)
+				p.EXPECT().SetProviderPayment(gomock.Any(), gomock.Any(), domain.PAYMENT_DECLINED).Return(nil)
+			},
+			expectedStatus: domain.PAYMENT_DECLINED,
+			wantErr:        domain.ErrPaymentDeclined,
+		},
+		{
+			name:            "Can't pay already paid order",
+			accountPublicId: accountPublicId.String(),
+			cardToken:       fakepay.TOKEN_SUCCESS,
+			mockBehavior: func(p *mock_repository.MockPayer, b *mock_repository.MockBiller, pr *mock_repository.MockProducter) {
+				p.EXPECT().GetOrder(orderPublicId).Return(order, nil)
+				p.EXPECT().GetLastOrderPayment(orderPublicId).Return(domain.Payment{Status: domain.PAYMENT_CAPTURED}, nil)
+			},
+			expectedStatus: domain.PAYMENT_CAPTURED,
+			wantErr:        domain.ErrOrderAlreadyPaid,
+		},
+		{
+			name:            "Can't pay the order with a concurrent attempt in progress",
+			accountPublicId: accountPublicId.String(),
+			cardToken:       fakepay.TOKEN_SUCCESS,
+			mockBehavior: func(p *mock_repository.MockPayer, b *mock_repository.MockBiller, pr *mock_repository.MockProducter) {
+				p.EXPECT().GetOrder(orderPublicId).Return(order, nil)
+				p.EXPECT().GetLastOrderPayment(orderPublicId).Return(domain.Payment{}, domain.ErrPaymentNotFound)
+				p.EXPECT().CreatePayment(gomock.Any()).Return(domain.ErrOrderAlreadyPaid)
+				p.EXPECT().GetLastOrderPayment(orderPublicId).Return(domain.Payment{Status: domain.PAYMENT_PENDING}, nil)
+			},
+			expectedStatus: domain.PAYMENT_PENDING,
+			wantErr:        domain.ErrOrderAlreadyPaid,
+		},
+		{
+			name:            "Can't pay someone else's order",
+			accountPublicId: uuid.NewString(),
+			cardToken:       fakepay.TOKEN_SUCCESS,
+			mockBehavior: func(p *mock_repository.MockPayer, b *mock_repository.MockBiller, pr *mock_repository.MockProducter) {
+				p.EXPECT().GetOrder(orderPublicId).Return(order, nil)
+			},
+			wantErr: domain.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := httptest.NewServer(fakepay.NewServer(TEST_WEBHOOK_SECRET, TEST_PROVIDER_DELAY).Routes())
+			defer server.Close()
+
+			payer := mock_repository.NewMockPayer(ctrl)
+			biller := mock_repository.NewMockBiller(ctrl)
+			products := mock_repository.NewMockProducter(ctrl)
+			tt.mockBehavior(payer, biller, products)
+
+			s := NewPaymentService(payer, mock_repository.NewMockAccounter(ctrl), products,
+				NewBillingService(biller, mock_repository.NewMockProducter(ctrl), &config.Billing{CommissionPercent: 10}),
+				fakepay.NewClient(server.URL, TEST_WEBHOOK_SECRET), &config.Payment{})
+			paid, err := s.Pay(context.Background(), tt.accountPublicId, orderPublicId, domain.PayInput{CardToken: tt.cardToken})
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedStatus, paid.Status)
+			if !tt.expectedPosting {
+				assert.Nil(t, paid.Transaction)
+				return
+			}
+			assert.Equal(t, int64(10000), paid.Amount)
+			assert.Equal(t, "payment:"+orderPublicId.String(), paid.Transaction.Reference)
+			assert.True(t, paid.Transaction.Balanced())
+		})
+	}
+}
+
+func TestPaymentService_PayVerifiedEmail(t *testing.T) {
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	accountPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	order := domain.Order{
+		PublicId:        orderPublicId,
+		AccountPublicId: accountPublicId,
+		Items:           []domain.OrderItem{{ProductPublicId: uuid.New(), Quantity: 1, Price: 5000}},
+	}
+
+	type mockBehavior func(p *mock_repository.MockPayer, a *mock_repository.MockAccounter)
+
+	tests := []struct {
+		name           string
+		mockBehavior   mockBehavior
+		expectedStatus domain.PaymentStatus
+		wantErr        error
+	}{
+		{
+			name: "Can't pay with not verified email",
+			mockBehavior: func(p *mock_repository.MockPayer, a *mock_repository.MockAccounter) {
+				p.EXPECT().GetOrder(orderPublicId).Return(order, nil)
+				a.EXPECT().GetAccount(accountPublicId.String()).Return(domain.Account{PublicId: accountPublicId}, nil)
+			},
+			wantErr: domain.ErrEmailNotVerified,
+		},
+		{
+			name: "Can't pay before the account copy comes",
+			mockBehavior: func(p *mock_repository.MockPayer, a *mock_repository.MockAccounter) {
+				p.EXPECT().GetOrder(orderPublicId).Return(order, nil)
+				a.EXPECT().GetAccount(accountPublicId.String()).Return(domain.Account{}, fmt.Errorf("get account: %w", sql.ErrNoRows))
+			},
+			wantErr: domain.ErrEmailNotVerified,
+		},
+		{
+			name: "Can pay with verified email",
+			mockBehavior: func(p *mock_repository.MockPayer, a *mock_repository.MockAccounter) {
+				p.EXPECT().GetOrder(orderPublicId).Return(order, nil)
+				a.EXPECT().GetAccount(accountPublicId.String()).
+					Return(domain.Account{PublicId: accountPublicId, EmailVerified: true}, nil)
+				p.EXPECT().GetLastOrderPayment(orderPublicId).Return(domain.Payment{}, domain.ErrPaymentNotFound)
+				p.EXPECT().CreatePayment(gomock.Any()).Return(nil)
+				p.EXPECT().SetOrderItems(orderPublicId, gomock.Any()).Return(nil)
+				p.EXPECT().SetProviderPayment(gomock.Any(), gomock.Any(), domain.PAYMENT_PENDING).Return(nil)
+			},
+			expectedStatus: domain.PAYMENT_PENDING,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := httptest.NewServer(fakepay.NewServer(TEST_WEBHOOK_SECRET, TEST_PROVIDER_DELAY).Routes())
+			defer server.Close()
+
+			payer := mock_repository.NewMockPayer(ctrl)
+			accounts := mock_repository.NewMockAccounter(ctrl)
+			products := mock_repository.NewMockProducter(ctrl)
+			products.EXPECT().GetEffectivePrice(gomock.Any(), gomock.Any()).Return(int64(0), domain.ErrPriceNotFound).AnyTimes()
+			tt.mockBehavior(payer, accounts)
+
+			s := NewPaymentService(payer, accounts, products,
+				NewBillingService(mock_repository.NewMockBiller(ctrl), mock_repository.NewMockProducter(ctrl), &config.Billing{CommissionPercent: 10}),
+				fakepay.NewClient(server.URL, TEST_WEBHOOK_SECRET), &config.Payment{RequireVerifiedEmail: true})
+			paid, err := s.Pay(context.Background(), accountPublicId.String(), orderPublicId, domain.PayInput{CardToken: fakepay.TOKEN_DELAY})
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedStatus, paid.Status)
+		})
+	}
+}
+
+func TestPaymentService_HandleWebhook(t *testing.T) {
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	accountPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	order := domain.Order{
+		PublicId:        orderPublicId,
+		AccountPublicId: accountPublicId,
+		Items: []domain.OrderItem{
+			{ProductPublicId: uuid.New(), DealerPublicId: uuid.New(), Quantity: 1, Price: 1000},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(fakepay.NewServer(TEST_WEBHOOK_SECRET, TEST_PROVIDER_DELAY).Routes())
+	defer server.Close()
+	client := fakepay.NewClient(server.URL, TEST_WEBHOOK_SECRET)
+
+	// pending payment, the provider authorizes it after the delay
+	pending, err := client.Authorize(context.Background(), domain.AuthorizeInput{
+		OrderPublicId: orderPublicId,
+		Amount:        1000,
+		CardToken:     fakepay.TOKEN_DELAY,
+	})
+	assert.NoError(t, err)
+	time.Sleep(5 * TEST_PROVIDER_DELAY)
+
+	payer := mock_repository.NewMockPayer(ctrl)
+	biller := mock_repository.NewMockBiller(ctrl)
+	s := NewPaymentService(payer, mock_repository.NewMockAccounter(ctrl), mock_repository.NewMockProducter(ctrl),
+		NewBillingService(biller, mock_repository.NewMockProducter(ctrl), &config.Billing{CommissionPercent: 10}),
+		client, &config.Payment{})
+
+	authorized := pending
+	authorized.Status = domain.PAYMENT_AUTHORIZED
+	payload, err := json.Marshal(domain.WebhookEvent{Type: "payment.authorized", Payment: authorized})
+	assert.NoError(t, err)
+
+	t.Run("Can't accept webhook with wrong signature", func(t *testing.T) {
+		header := http.Header{}
+		header.Set(fakepay.SIGNATURE_HEADER, fakepay.Sign("other-secret", payload))
+
+		_, err := s.HandleWebhook(context.Background(), header, payload)
+		assert.True(t, errors.Is(err, domain.ErrWebhookSignature))
+	})
+
+	t.Run("Can capture pending payment with webhook", func(t *testing.T) {
+		stored := domain.Payment{
+			ProviderPaymentId: pending.Id,
+			OrderPublicId:     orderPublicId,
+			AccountPublicId:   accountPublicId,
+			Amount:            1000,
+			Status:            domain.PAYMENT_PENDING,
+		}
+		payer.EXPECT().GetPaymentByProviderId(pending.Id).Return(stored, nil)
+		payer.EXPECT().UpdatePaymentStatus(pending.Id, domain.PAYMENT_AUTHORIZED).Return(nil)
+		payer.EXPECT().UpdatePaymentStatus(pending.Id, domain.PAYMENT_CAPTURED).Return(nil)
+		payer.EXPECT().GetOrder(orderPublicId).Return(order, nil)
+		biller.EXPECT().AddTransaction(gomock.Any()).DoAndReturn(func(tx domain.Transaction) (domain.Transaction, error) {
+			return tx, nil
+		})
+
+		header := http.Header{}
+		header.Set(fakepay.SIGNATURE_HEADER, fakepay.Sign(TEST_WEBHOOK_SECRET, payload))
+
+		paid, err := s.HandleWebhook(context.Background(), header, payload)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PAYMENT_CAPTURED, paid.Status)
+		assert.NotNil(t, paid.Transaction)
+	})
+
+	t.Run("Can ignore repeated webhook", func(t *testing.T) {
+		payer.EXPECT().GetPaymentByProviderId(pending.Id).Return(domain.Payment{
+			ProviderPaymentId: pending.Id,
+			Status:            domain.PAYMENT_CAPTURED,
+		}, nil)
+
+		header := http.Header{}
+		header.Set(fakepay.SIGNATURE_HEADER, fakepay.Sign(TEST_WEBHOOK_SECRET, payload))
+
+		paid, err := s.HandleWebhook(context.Background(), header, payload)
+		assert.NoError(t, err)
+		assert.Nil(t, paid.Transaction)
+	})
+}
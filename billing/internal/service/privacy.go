@@ -0,0 +1,60 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/p12s/furniture-store/billing/internal/repository"
+)
+
+var _ Privacier = (*PrivacyService)(nil)
+
+// Privacier - service interface
+type Privacier interface {
+	ExportAccountData(input domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error)
+	EraseAccountData(input domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error)
+}
+
+// PrivacyService - the billing part of the account data export and erasure
+type PrivacyService struct {
+	repo repository.Privacier
+}
+
+// NewPrivacyService - constructor
+func NewPrivacyService(repo repository.Privacier) *PrivacyService {
+	return &PrivacyService{repo: repo}
+}
+
+// ExportAccountData - the answer with the transactions and the payments
+func (s *PrivacyService) ExportAccountData(input domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error) {
+	publicId, err := uuid.Parse(input.PublicId)
+	if err != nil {
+		return domain.PrivacyAnswer{}, err
+	}
+	data, err := s.repo.ExportAccountData(publicId)
+	if err != nil {
+		return domain.PrivacyAnswer{}, err
+	}
+	return privacyAnswer(input, data), nil
+}
+
+// EraseAccountData - the answer is sent when the data is erased
+func (s *PrivacyService) EraseAccountData(input domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error) {
+	publicId, err := uuid.Parse(input.PublicId)
+	if err != nil {
+		return domain.PrivacyAnswer{}, err
+	}
+	if err := s.repo.EraseAccountData(publicId); err != nil {
+		return domain.PrivacyAnswer{}, err
+	}
+	return privacyAnswer(input, nil), nil
+}
+
+// privacyAnswer
+func privacyAnswer(input domain.PrivacyRequestedInput, data interface{}) domain.PrivacyAnswer {
+	return domain.PrivacyAnswer{
+		RequestId: input.RequestId,
+		PublicId:  input.PublicId,
+		Service:   domain.PRIVACY_SERVICE,
+		Data:      data,
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/p12s/furniture-store/billing/internal/repository"
+)
+
+var _ Producter = (*ProductService)(nil)
+
+// Producter - service interface
+type Producter interface {
+	SaveProduct(product domain.Product) error
+}
+
+// ProductService - service
+type ProductService struct {
+	repo repository.Producter
+}
+
+// NewProductService - constructor
+func NewProductService(repo repository.Producter) *ProductService {
+	return &ProductService{repo: repo}
+}
+
+// SaveProduct - product copy is never deleted: already placed orders still need its dealer
+func (s *ProductService) SaveProduct(product domain.Product) error {
+	return s.repo.SaveProduct(product)
+}
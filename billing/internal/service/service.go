@@ -0,0 +1,36 @@
+package service
+
+import (
+	_ "github.com/golang/mock/mockgen/model"
+
+	"github.com/p12s/furniture-store/billing/internal/config"
+	"github.com/p12s/furniture-store/billing/internal/payment"
+	"github.com/p12s/furniture-store/billing/internal/repository"
+)
+
+//go:generate mockgen -destination mocks/mock.go -package service github.com/p12s/furniture-store/billing/internal/service Accounter,Producter,Biller,Payer,Accountant,Privacier
+
+// Service - just service
+type Service struct {
+	Accounter
+	Producter
+	Biller
+	Payer
+	Accountant
+	Privacier
+}
+
+// NewService - constructor
+func NewService(repos *repository.Repository, provider payment.PaymentProvider,
+	auth *config.Auth, billing *config.Billing, paymentConfig *config.Payment) *Service {
+	biller := NewBillingService(repos.Biller, repos.Producter, billing)
+
+	return &Service{
+		Accounter:  NewAccountService(repos.Accounter, auth),
+		Producter:  NewProductService(repos.Producter),
+		Biller:     biller,
+		Payer:      NewPaymentService(repos.Payer, repos.Accounter, repos.Producter, biller, provider, paymentConfig),
+		Accountant: NewAccountingService(repos.Accountant, repos.Producter),
+		Privacier:  NewPrivacyService(repos.Privacier),
+	}
+}
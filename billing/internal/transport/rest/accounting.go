@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+const csvFormat = "csv"
+
+// @Summary Goods received
+// @Tags Accounting
+// @Description Goods received from dealers (stock growth) per dealer and period, dates are inclusive
+// @ID getGoodsReceived
+// @Produce  json,text/csv
+// @Param from query string false "from date, 2006-01-02"
+// @Param to query string false "to date, 2006-01-02"
+// @Param group_by query string false "day (default), week or month"
+// @Param dealer_id query string false "dealer public_id, all dealers by default"
+// @Param format query string false "csv to download the report"
+// @Success 200 {array} domain.GoodsReceivedRow
+// @Router /billing/goods/received [get]
+func (h *Handler) getGoodsReceived(c *gin.Context) {
+	filter, ok := getGoodsFilter(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.services.GetGoodsReceived(filter)
+	if !checkGoodsError(c, err) {
+		return
+	}
+
+	records := [][]string{{"period", "dealer_public_id", "products", "quantity"}}
+	for _, row := range rows {
+		records = append(records, []string{row.Period, row.DealerPublicId.String(),
+			strconv.FormatInt(row.Products, 10), strconv.FormatInt(row.Quantity, 10)})
+	}
+	sendGoodsReport(c, "goods-received", rows, records)
+}
+
+// @Summary Goods bought
+// @Tags Accounting
+// @Description Payed goods per dealer and period, dates are inclusive
+// @ID getGoodsBought
+// @Produce  json,text/csv
+// @Param from query string false "from date, 2006-01-02"
+// @Param to query string false "to date, 2006-01-02"
+// @Param group_by query string false "day (default), week or month"
+// @Param dealer_id query string false "dealer public_id, all dealers by default"
+// @Param format query string false "csv to download the report"
+// @Success 200 {array} domain.GoodsBoughtRow
+// @Router /billing/goods/bought [get]
+func (h *Handler) getGoodsBought(c *gin.Context) {
+	filter, ok := getGoodsFilter(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.services.GetGoodsBought(filter)
+	if !checkGoodsError(c, err) {
+		return
+	}
+
+	records := [][]string{{"period", "dealer_public_id", "orders", "quantity", "amount"}}
+	for _, row := range rows {
+		records = append(records, []string{row.Period, row.DealerPublicId.String(),
+			strconv.FormatInt(row.Orders, 10), strconv.FormatInt(row.Quantity, 10), strconv.FormatInt(row.Amount, 10)})
+	}
+	sendGoodsReport(c, "goods-bought", rows, records)
+}
+
+// @Summary Goods delivery
+// @Tags Accounting
+// @Description Goods payed in the period - delivered, awaiting delivery and refunded, dates are inclusive
+// @ID getGoodsDelivery
+// @Produce  json,text/csv
+// @Param from query string false "from date, 2006-01-02"
+// @Param to query string false "to date, 2006-01-02"
+// @Param group_by query string false "day (default), week or month"
+// @Param dealer_id query string false "dealer public_id, all dealers by default"
+// @Param format query string false "csv to download the report"
+// @Success 200 {array} domain.GoodsDeliveryRow
+// @Router /billing/goods/delivery [get]
+func (h *Handler) getGoodsDelivery(c *gin.Context) {
+	filter, ok := getGoodsFilter(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.services.GetGoodsDelivery(filter)
+	if !checkGoodsError(c, err) {
+		return
+	}
+
+	records := [][]string{{"period", "dealer_public_id", "delivered", "awaiting", "refunded"}}
+	for _, row := range rows {
+		records = append(records, []string{row.Period, row.DealerPublicId.String(),
+			strconv.FormatInt(row.Delivered, 10), strconv.FormatInt(row.Awaiting, 10), strconv.FormatInt(row.Refunded, 10)})
+	}
+	sendGoodsReport(c, "goods-delivery", rows, records)
+}
+
+// getGoodsFilter - the error response is already sent if not ok
+func getGoodsFilter(c *gin.Context) (domain.GoodsFilter, bool) {
+	from, to, err := parsePeriod(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid period")
+		return domain.GoodsFilter{}, false
+	}
+
+	filter := domain.GoodsFilter{
+		From:    from,
+		To:      to,
+		GroupBy: domain.GroupBy(c.DefaultQuery("group_by", string(domain.GROUP_BY_DAY))),
+	}
+	if value := c.Query("dealer_id"); value != "" {
+		filter.DealerPublicId, err = uuid.Parse(value)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid dealer public id")
+			return filter, false
+		}
+	}
+
+	return filter, true
+}
+
+// checkGoodsError - false if the error response is sent
+func checkGoodsError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrInvalidGroupBy):
+		newErrorResponse(c, http.StatusBadRequest, "invalid group by")
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
+
+// sendGoodsReport - json rows, or csv records with the header when format=csv
+func sendGoodsReport(c *gin.Context, name string, rows interface{}, records [][]string) {
+	if c.Query("format") != csvFormat {
+		c.JSON(http.StatusOK, rows)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, name))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	if err := w.WriteAll(records); err != nil {
+		logrus.Errorf("write %s csv fail: %s/n", name, err.Error())
+	}
+}
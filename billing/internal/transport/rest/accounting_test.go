@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/p12s/furniture-store/billing/internal/service"
+	mock_service "github.com/p12s/furniture-store/billing/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_getGoodsReport(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAccountant)
+
+	const token = "token"
+	adminPublicId := "5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"
+	dealerPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	filter := domain.GoodsFilter{
+		From:    time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2021, 12, 3, 0, 0, 0, 0, time.UTC),
+		GroupBy: domain.GROUP_BY_DAY,
+	}
+	dealerFilter := filter
+	dealerFilter.GroupBy, dealerFilter.DealerPublicId = domain.GROUP_BY_MONTH, dealerPublicId
+	const period = "from=2021-12-01&to=2021-12-02"
+
+	received := []domain.GoodsReceivedRow{{Period: "2021-12-01", DealerPublicId: dealerPublicId, Products: 2, Quantity: 5}}
+	bought := []domain.GoodsBoughtRow{{Period: "2021-12-01", DealerPublicId: dealerPublicId, Orders: 1, Quantity: 2, Amount: 6000000}}
+	delivery := []domain.GoodsDeliveryRow{{Period: "2021-12", DealerPublicId: dealerPublicId, Delivered: 1, Awaiting: 2, Refunded: 1}}
+
+	tests := []struct {
+		name                 string
+		target               string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+	}{
+		{
+			name:   "Can get the goods received",
+			target: "/billing/goods/received?" + period,
+			mockBehavior: func(s *mock_service.MockAccountant) {
+				s.EXPECT().GetGoodsReceived(filter).Return(received, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `[{"period":"2021-12-01","dealer_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","products":2,"quantity":5}]`,
+		},
+		{
+			name:   "Can download the goods received",
+			target: "/billing/goods/received?format=csv&" + period,
+			mockBehavior: func(s *mock_service.MockAccountant) {
+				s.EXPECT().GetGoodsReceived(filter).Return(received, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "text/csv; charset=utf-8",
+			expectedResponseBody: "period,dealer_public_id,products,quantity\n2021-12-01,265cee57-2ff9-4ed3-85e1-d3373fa2a1a5,2,5\n",
+		},
+		{
+			name:   "Can get the goods bought",
+			target: "/billing/goods/bought?" + period,
+			mockBehavior: func(s *mock_service.MockAccountant) {
+				s.EXPECT().GetGoodsBought(filter).Return(bought, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `[{"period":"2021-12-01","dealer_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","orders":1,"quantity":2,"amount":6000000}]`,
+		},
+		{
+			name:   "Can download the goods bought",
+			target: "/billing/goods/bought?format=csv&" + period,
+			mockBehavior: func(s *mock_service.MockAccountant) {
+				s.EXPECT().GetGoodsBought(filter).Return(bought, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "text/csv; charset=utf-8",
+			expectedResponseBody: "period,dealer_public_id,orders,quantity,amount\n2021-12-01,265cee57-2ff9-4ed3-85e1-d3373fa2a1a5,1,2,6000000\n",
+		},
+		{
+			name:   "Can get the goods delivery of the dealer by month",
+			target: "/billing/goods/delivery?group_by=month&dealer_id=265cee57-2ff9-4ed3-85e1-d3373fa2a1a5&" + period,
+			mockBehavior: func(s *mock_service.MockAccountant) {
+				s.EXPECT().GetGoodsDelivery(dealerFilter).Return(delivery, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `[{"period":"2021-12","dealer_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","delivered":1,"awaiting":2,"refunded":1}]`,
+		},
+		{
+			name:   "Can download the goods delivery",
+			target: "/billing/goods/delivery?format=csv&group_by=month&dealer_id=265cee57-2ff9-4ed3-85e1-d3373fa2a1a5&" + period,
+			mockBehavior: func(s *mock_service.MockAccountant) {
+				s.EXPECT().GetGoodsDelivery(dealerFilter).Return(delivery, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "text/csv; charset=utf-8",
+			expectedResponseBody: "period,dealer_public_id,delivered,awaiting,refunded\n2021-12,265cee57-2ff9-4ed3-85e1-d3373fa2a1a5,1,2,1\n",
+		},
+		{
+			name:   "Can download the empty report with the header only",
+			target: "/billing/goods/received?format=csv&" + period,
+			mockBehavior: func(s *mock_service.MockAccountant) {
+				s.EXPECT().GetGoodsReceived(filter).Return([]domain.GoodsReceivedRow{}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "text/csv; charset=utf-8",
+			expectedResponseBody: "period,dealer_public_id,products,quantity\n",
+		},
+		{
+			name:                 "Can't get the report by the invalid date",
+			target:               "/billing/goods/received?from=01.12.2021",
+			mockBehavior:         func(s *mock_service.MockAccountant) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"message":"invalid period"}`,
+		},
+		{
+			name:                 "Can't get the report from the date after to",
+			target:               "/billing/goods/bought?from=2021-12-03&to=2021-12-01",
+			mockBehavior:         func(s *mock_service.MockAccountant) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"message":"invalid period"}`,
+		},
+		{
+			name:   "Can't get the report by the invalid group by",
+			target: "/billing/goods/delivery?group_by=year&" + period,
+			mockBehavior: func(s *mock_service.MockAccountant) {
+				invalid := filter
+				invalid.GroupBy = "year"
+				s.EXPECT().GetGoodsDelivery(invalid).Return(nil, domain.ErrInvalidGroupBy)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"message":"invalid group by"}`,
+		},
+		{
+			name:                 "Can't get the report by the invalid dealer id",
+			target:               "/billing/goods/bought?dealer_id=123&" + period,
+			mockBehavior:         func(s *mock_service.MockAccountant) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"message":"invalid dealer public id"}`,
+		},
+		{
+			name:   "Can return error response if service failure",
+			target: "/billing/goods/received?format=csv&" + period,
+			mockBehavior: func(s *mock_service.MockAccountant) {
+				s.EXPECT().GetGoodsReceived(filter).Return(nil, errors.New(""))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accounter := mock_service.NewMockAccounter(ctrl)
+			accounter.EXPECT().ParseToken(token).Return(adminPublicId, nil)
+			accounter.EXPECT().GetAccount(adminPublicId).Return(domain.Account{Role: domain.ROLE_ADMIN}, nil)
+			accountant := mock_service.NewMockAccountant(ctrl)
+			tt.mockBehavior(accountant)
+
+			handler := NewHandler(&service.Service{Accounter: accounter, Accountant: accountant}, nil)
+			r := handler.InitRoutes()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.target, nil)
+			req.Header.Set(authorizationHandler, "Bearer "+token)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getGoodsReport_roles(t *testing.T) {
+	const token = "token"
+	accountPublicId := "5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"
+
+	for _, target := range []string{"/billing/goods/received", "/billing/goods/bought", "/billing/goods/delivery?format=csv"} {
+		for _, role := range []domain.Role{domain.ROLE_CUSTOMER, domain.ROLE_DEALER, domain.ROLE_DELIVERY} {
+			t.Run(fmt.Sprintf("Can't get %s by the role %d", target, role), func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				// the dealer can't read the reports even of its own goods
+				accounter := mock_service.NewMockAccounter(ctrl)
+				accounter.EXPECT().ParseToken(token).Return(accountPublicId, nil)
+				accounter.EXPECT().GetAccount(accountPublicId).Return(domain.Account{Role: role}, nil)
+				accountant := mock_service.NewMockAccountant(ctrl)
+
+				handler := NewHandler(&service.Service{Accounter: accounter, Accountant: accountant}, nil)
+				r := handler.InitRoutes()
+
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest("GET", target, nil)
+				req.Header.Set(authorizationHandler, "Bearer "+token)
+
+				r.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusForbidden, w.Code)
+				assert.Equal(t, `{"message":"access denied"}`, w.Body.String())
+			})
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	dateLayout        = "2006-01-02"
+	defaultPeriodDays = 30
+)
+
+// @Summary Ledger balances
+// @Tags Billing
+// @Description Turnover and balance of every ledger account
+// @ID getBalances
+// @Produce  json
+// @Success 200
+// @Router /billing/balances [get]
+func (h *Handler) getBalances(c *gin.Context) {
+	balances, err := h.services.GetBalances()
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+// @Summary Accounting summary
+// @Tags Billing
+// @Description Payments, refunds and ledger turnover for a period, dates are inclusive
+// @ID getSummary
+// @Produce  json
+// @Param from query string false "from date, 2006-01-02"
+// @Param to query string false "to date, 2006-01-02"
+// @Success 200
+// @Router /billing/summary [get]
+func (h *Handler) getSummary(c *gin.Context) {
+	from, to, err := parsePeriod(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid period")
+		return
+	}
+
+	summary, err := h.services.GetSummary(from, to)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// @Summary Order billing log
+// @Tags Billing
+// @Description All transactions of the order
+// @ID getOrderTransactions
+// @Produce  json
+// @Param id path string true "order public_id"
+// @Success 200
+// @Router /billing/orders/{id} [get]
+func (h *Handler) getOrderTransactions(c *gin.Context) {
+	orderPublicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid order public id")
+		return
+	}
+
+	transactions, err := h.services.GetOrderTransactions(orderPublicId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, transactions)
+}
+
+// @Summary Account billing log
+// @Tags Billing
+// @Description All transactions of the customer account
+// @ID getAccountTransactions
+// @Produce  json
+// @Param id path string true "account public_id"
+// @Success 200
+// @Router /billing/accounts/{id} [get]
+func (h *Handler) getAccountTransactions(c *gin.Context) {
+	accountPublicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid account public id")
+		return
+	}
+
+	transactions, err := h.services.GetAccountTransactions(accountPublicId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, transactions)
+}
+
+// parsePeriod - [from, to + 1 day), last 30 days by default, from must not be after to
+func parsePeriod(c *gin.Context) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = parsed
+	}
+	to = to.AddDate(0, 0, 1)
+
+	from := to.AddDate(0, 0, -defaultPeriodDays)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = parsed
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("period is empty")
+	}
+
+	return from, to, nil
+}
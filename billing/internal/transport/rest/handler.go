@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/billing/internal/broker"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/p12s/furniture-store/billing/internal/service"
+)
+
+// Handler
+type Handler struct {
+	services *service.Service
+	broker   *broker.Broker
+}
+
+// NewHandler - constructor
+func NewHandler(services *service.Service, broker *broker.Broker) *Handler {
+	return &Handler{services: services, broker: broker}
+}
+
+// InitRoutes - routes
+func (h *Handler) InitRoutes() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(CORSMiddleware())
+
+	router.GET("/health", h.health)
+
+	payments := router.Group("/payments")
+	{
+		payments.POST("/webhook", h.paymentWebhook)
+		payments.POST("/orders/:id", h.userIdentity, h.payOrder)
+		payments.GET("/orders/:id", h.userIdentity, h.getOrderPayment)
+	}
+
+	billing := router.Group("/billing", h.userIdentity, h.roleIdentity(domain.ROLE_ADMIN))
+	{
+		billing.GET("/balances", h.getBalances)
+		billing.GET("/summary", h.getSummary)
+		billing.GET("/orders/:id", h.getOrderTransactions)
+		billing.GET("/accounts/:id", h.getAccountTransactions)
+		billing.GET("/goods/received", h.getGoodsReceived)
+		billing.GET("/goods/bought", h.getGoodsBought)
+		billing.GET("/goods/delivery", h.getGoodsDelivery)
+	}
+
+	return router
+}
+
+// CORSMiddleware - cross site work
+func CORSMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Header("Access-Control-Allow-Credentials", "true")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Methods", "POST,HEAD,PATCH,OPTIONS,GET,PUT")
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
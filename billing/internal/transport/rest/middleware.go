@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+)
+
+const (
+	authorizationHandler = "Authorization"
+	accountCtx           = "accountPublicId"
+)
+
+// userIdentity - checking token
+func (h *Handler) userIdentity(c *gin.Context) {
+	header := c.GetHeader(authorizationHandler)
+	if header == "" {
+		newErrorResponse(c, http.StatusUnauthorized, "empty auth header")
+		return
+	}
+
+	headerParts := strings.Split(header, " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+		newErrorResponse(c, http.StatusUnauthorized, "invalid auth header")
+		return
+	}
+
+	if headerParts[1] == "" {
+		newErrorResponse(c, http.StatusUnauthorized, "token is empty")
+		return
+	}
+
+	accountId, err := h.services.Accounter.ParseToken(headerParts[1])
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, "invalid token")
+		return
+	}
+
+	c.Set(accountCtx, accountId)
+}
+
+// roleIdentity - checking account role by the local account copy
+func (h *Handler) roleIdentity(role domain.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accountPublicId, err := getAccountPublicId(c)
+		if err != nil {
+			newErrorResponse(c, http.StatusUnauthorized, "account public id not found")
+			return
+		}
+
+		account, err := h.services.Accounter.GetAccount(accountPublicId)
+		if err != nil || account.Role != role {
+			newErrorResponse(c, http.StatusForbidden, "access denied")
+			return
+		}
+	}
+}
+
+// getAccountPublicId - getting current account public_id
+func getAccountPublicId(c *gin.Context) (string, error) {
+	id, ok := c.Get(accountCtx)
+	if !ok {
+		return "", errors.New("account public_id not found")
+	}
+
+	idString, ok := id.(string)
+	if !ok {
+		return "", errors.New("account id is of invalid type")
+	}
+
+	return idString, nil
+}
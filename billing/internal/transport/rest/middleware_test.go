@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/p12s/furniture-store/billing/internal/service"
+	mock_service "github.com/p12s/furniture-store/billing/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_roleIdentity(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAccounter)
+
+	accountPublicId := "5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"
+	account := func(role domain.Role) domain.Account {
+		return domain.Account{PublicId: uuid.MustParse(accountPublicId), Role: role}
+	}
+
+	tests := []struct {
+		name                 string
+		withAccount          bool
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Can pass with the role",
+			withAccount: true,
+			mockBehavior: func(s *mock_service.MockAccounter) {
+				s.EXPECT().GetAccount(accountPublicId).Return(account(domain.ROLE_ADMIN), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:        "Can't pass with another role",
+			withAccount: true,
+			mockBehavior: func(s *mock_service.MockAccounter) {
+				s.EXPECT().GetAccount(accountPublicId).Return(account(domain.ROLE_DEALER), nil)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"access denied"}`,
+		},
+		{
+			name:        "Can't pass without the account copy",
+			withAccount: true,
+			mockBehavior: func(s *mock_service.MockAccounter) {
+				s.EXPECT().GetAccount(accountPublicId).Return(domain.Account{}, errors.New(""))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"access denied"}`,
+		},
+		{
+			name:                 "Can't pass without the account",
+			mockBehavior:         func(s *mock_service.MockAccounter) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"account public id not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accounter := mock_service.NewMockAccounter(ctrl)
+			tt.mockBehavior(accounter)
+
+			handler := NewHandler(&service.Service{Accounter: accounter}, nil)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/billing", func(c *gin.Context) {
+				if tt.withAccount {
+					c.Set(accountCtx, accountPublicId)
+				}
+			}, handler.roleIdentity(domain.ROLE_ADMIN), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/billing", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_userIdentity(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAccounter)
+
+	tests := []struct {
+		name                 string
+		header               string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Can't reach the admin routes as a customer",
+			header: "Bearer token",
+			mockBehavior: func(s *mock_service.MockAccounter) {
+				s.EXPECT().ParseToken("token").Return("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11", nil)
+				s.EXPECT().GetAccount("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11").Return(domain.Account{Role: domain.ROLE_CUSTOMER}, nil)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"access denied"}`,
+		},
+		{
+			name:   "Can't pass with the invalid token",
+			header: "Bearer token",
+			mockBehavior: func(s *mock_service.MockAccounter) {
+				s.EXPECT().ParseToken("token").Return("", errors.New("invalid audience"))
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"invalid token"}`,
+		},
+		{
+			name:                 "Can't pass without the header",
+			mockBehavior:         func(s *mock_service.MockAccounter) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"empty auth header"}`,
+		},
+		{
+			name:                 "Can't pass with the invalid header",
+			header:               "Basic token",
+			mockBehavior:         func(s *mock_service.MockAccounter) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"invalid auth header"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accounter := mock_service.NewMockAccounter(ctrl)
+			tt.mockBehavior(accounter)
+
+			handler := NewHandler(&service.Service{Accounter: accounter}, nil)
+			r := handler.InitRoutes()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/billing/balances", nil)
+			if tt.header != "" {
+				req.Header.Set(authorizationHandler, tt.header)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Pay order
+// @Tags Payment
+// @Description Authorize and capture the checked out order total
+// @ID payOrder
+// @Accept  json
+// @Produce  json
+// @Param id path string true "order public_id"
+// @Param input body domain.PayInput true "card token"
+// @Success 200
+// @Success 202 "payment is pending, result comes with the provider webhook"
+// @Failure 402 "payment declined"
+// @Router /payments/orders/{id} [post]
+func (h *Handler) payOrder(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	orderPublicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid order public id")
+		return
+	}
+
+	var input domain.PayInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	payment, err := h.services.Pay(c.Request.Context(), accountPublicId, orderPublicId, input)
+	h.producePaymentEvent(payment, err)
+	switch {
+	case errors.Is(err, domain.ErrOrderNotFound):
+		newErrorResponse(c, http.StatusNotFound, "order not found")
+		return
+	case errors.Is(err, domain.ErrOrderAlreadyPaid):
+		newErrorResponse(c, http.StatusConflict, "order is already paid")
+		return
+	case errors.Is(err, domain.ErrEmailNotVerified):
+		newErrorResponse(c, http.StatusForbidden, err.Error())
+		return
+	case errors.Is(err, domain.ErrPaymentDeclined):
+		newErrorResponse(c, http.StatusPaymentRequired, "payment declined")
+		return
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	if payment.Status == domain.PAYMENT_PENDING {
+		c.JSON(http.StatusAccepted, payment)
+		return
+	}
+	c.JSON(http.StatusOK, payment)
+}
+
+// @Summary Get order payment
+// @Tags Payment
+// @Description Last payment attempt of the caller order
+// @ID getOrderPayment
+// @Produce  json
+// @Param id path string true "order public_id"
+// @Success 200
+// @Router /payments/orders/{id} [get]
+func (h *Handler) getOrderPayment(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	orderPublicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid order public id")
+		return
+	}
+
+	payment, err := h.services.GetOrderPayment(accountPublicId, orderPublicId)
+	if errors.Is(err, domain.ErrPaymentNotFound) {
+		newErrorResponse(c, http.StatusNotFound, "payment not found")
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, payment)
+}
+
+// @Summary Payment provider webhook
+// @Tags Payment
+// @Description Asynchronous payment result, the body is signed by the provider
+// @ID paymentWebhook
+// @Accept  json
+// @Success 200
+// @Router /payments/webhook [post]
+func (h *Handler) paymentWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	payment, err := h.services.HandleWebhook(c.Request.Context(), c.Request.Header, payload)
+	h.producePaymentEvent(payment, err)
+	switch {
+	case errors.Is(err, domain.ErrWebhookSignature):
+		newErrorResponse(c, http.StatusUnauthorized, "invalid signature")
+		return
+	case errors.Is(err, domain.ErrPaymentNotFound):
+		newErrorResponse(c, http.StatusNotFound, "payment not found")
+		return
+	case err != nil && !errors.Is(err, domain.ErrPaymentDeclined):
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// producePaymentEvent - captured payment is a ledger posting, declined one is only reported.
+// The repeated webhook of the declined payment comes without the error and is not reported again
+func (h *Handler) producePaymentEvent(payment domain.Payment, err error) {
+	var eventType domain.EventType
+	var payload interface{}
+
+	switch {
+	case payment.Transaction != nil:
+		eventType, payload = domain.EVENT_BILLING_PAYMENT_RECEIVED, *payment.Transaction
+	case errors.Is(err, domain.ErrPaymentDeclined):
+		eventType, payload = domain.EVENT_BILLING_PAYMENT_DECLINED, payment
+	default:
+		return
+	}
+
+	go func() {
+		err := h.broker.Produce(eventType, h.broker.TopicBillingBE, payload)
+		if err != nil {
+			logrus.Errorf("sent payment event fail: %s/n", err.Error())
+		}
+	}()
+}
@@ -0,0 +1,316 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/billing/internal/broker"
+	mock_broker "github.com/p12s/furniture-store/billing/internal/broker/mocks"
+	"github.com/p12s/furniture-store/billing/internal/domain"
+	"github.com/p12s/furniture-store/billing/internal/service"
+	mock_service "github.com/p12s/furniture-store/billing/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	WAITING_GORUTINE_END_TIME = 100 * time.Millisecond
+)
+
+func TestHandler_payOrder(t *testing.T) {
+	type payerMockBehavior func(s *mock_service.MockPayer)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	customerPublicId := "5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	input := domain.PayInput{CardToken: "tok_visa"}
+	createdAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	payment := func(status domain.PaymentStatus) domain.Payment {
+		return domain.Payment{ProviderPaymentId: "pay_1", OrderPublicId: orderPublicId,
+			AccountPublicId: uuid.MustParse(customerPublicId), Amount: 3000000, Status: status,
+			CreatedAt: createdAt, UpdatedAt: createdAt}
+	}
+	paymentBody := func(status domain.PaymentStatus) string {
+		return `{"provider_payment_id":"pay_1","order_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5",` +
+			`"account_public_id":"5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11","amount":3000000,"status":"` + string(status) + `",` +
+			`"created_at":"2021-12-01T10:00:00Z","updated_at":"2021-12-01T10:00:00Z"}`
+	}
+	transaction := domain.Transaction{PublicId: uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1"),
+		Reference: "payment:265cee57-2ff9-4ed3-85e1-d3373fa2a1a5", Status: domain.TRANSACTION_PAYMENT,
+		AccountPublicId: uuid.MustParse(customerPublicId), OrderPublicId: orderPublicId, Price: 3000000, CreatedAt: createdAt}
+	captured := payment(domain.PAYMENT_CAPTURED)
+	captured.Transaction = &transaction
+
+	tests := []struct {
+		name                 string
+		orderId              string
+		inputBody            string
+		payerMockBehavior    payerMockBehavior
+		brokerMockProducer   brokerMockProducer
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Can pay the order and publish the ledger posting",
+			orderId:   orderPublicId.String(),
+			inputBody: `{"card_token":"tok_visa"}`,
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().Pay(gomock.Any(), customerPublicId, orderPublicId, input).Return(captured, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_BILLING_PAYMENT_RECEIVED, "", transaction).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"provider_payment_id":"pay_1","order_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5",` +
+				`"account_public_id":"5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11","amount":3000000,"status":"captured",` +
+				`"transaction":{"public_id":"8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1","reference":"payment:265cee57-2ff9-4ed3-85e1-d3373fa2a1a5",` +
+				`"status":"payment","account_public_id":"5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11","order_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5",` +
+				`"price":3000000,"created_at":"2021-12-01T10:00:00Z"},"created_at":"2021-12-01T10:00:00Z","updated_at":"2021-12-01T10:00:00Z"}`,
+		},
+		{
+			name:      "Can accept the pending payment without the posting",
+			orderId:   orderPublicId.String(),
+			inputBody: `{"card_token":"tok_visa"}`,
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().Pay(gomock.Any(), customerPublicId, orderPublicId, input).Return(payment(domain.PAYMENT_PENDING), nil)
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusAccepted,
+			expectedResponseBody: paymentBody(domain.PAYMENT_PENDING),
+		},
+		{
+			name:      "Can answer the payment captured before without the posting",
+			orderId:   orderPublicId.String(),
+			inputBody: `{"card_token":"tok_visa"}`,
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().Pay(gomock.Any(), customerPublicId, orderPublicId, input).Return(payment(domain.PAYMENT_CAPTURED), nil)
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: paymentBody(domain.PAYMENT_CAPTURED),
+		},
+		{
+			name:      "Can't pay by the declined card, the decline is published",
+			orderId:   orderPublicId.String(),
+			inputBody: `{"card_token":"tok_visa"}`,
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().Pay(gomock.Any(), customerPublicId, orderPublicId, input).
+					Return(payment(domain.PAYMENT_DECLINED), domain.ErrPaymentDeclined)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_BILLING_PAYMENT_DECLINED, "", payment(domain.PAYMENT_DECLINED)).Return(nil)
+			},
+			expectedStatusCode:   http.StatusPaymentRequired,
+			expectedResponseBody: `{"message":"payment declined"}`,
+		},
+		{
+			name:      "Can't pay unknown order",
+			orderId:   orderPublicId.String(),
+			inputBody: `{"card_token":"tok_visa"}`,
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().Pay(gomock.Any(), customerPublicId, orderPublicId, input).Return(domain.Payment{}, domain.ErrOrderNotFound)
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"order not found"}`,
+		},
+		{
+			name:      "Can't pay the order twice",
+			orderId:   orderPublicId.String(),
+			inputBody: `{"card_token":"tok_visa"}`,
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().Pay(gomock.Any(), customerPublicId, orderPublicId, input).
+					Return(payment(domain.PAYMENT_PENDING), domain.ErrOrderAlreadyPaid)
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"order is already paid"}`,
+		},
+		{
+			name:      "Can't pay without the verified email",
+			orderId:   orderPublicId.String(),
+			inputBody: `{"card_token":"tok_visa"}`,
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().Pay(gomock.Any(), customerPublicId, orderPublicId, input).Return(domain.Payment{}, domain.ErrEmailNotVerified)
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"email is not verified"}`,
+		},
+		{
+			name:      "Can return error response if service failure",
+			orderId:   orderPublicId.String(),
+			inputBody: `{"card_token":"tok_visa"}`,
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().Pay(gomock.Any(), customerPublicId, orderPublicId, input).Return(domain.Payment{}, errors.New(""))
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"service failure"}`,
+		},
+		{
+			name:                 "Can't pay the order by invalid id",
+			orderId:              "123",
+			inputBody:            `{"card_token":"tok_visa"}`,
+			payerMockBehavior:    func(s *mock_service.MockPayer) {},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid order public id"}`,
+		},
+		{
+			name:                 "Can't pay without the card token",
+			orderId:              orderPublicId.String(),
+			inputBody:            `{}`,
+			payerMockBehavior:    func(s *mock_service.MockPayer) {},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			payer := mock_service.NewMockPayer(ctrl)
+			tt.payerMockBehavior(payer)
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+
+			handler := NewHandler(&service.Service{Payer: payer}, &broker.Broker{Producer: brokerProducer})
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/payments/orders/:id", func(c *gin.Context) {
+				c.Set(accountCtx, customerPublicId)
+			}, handler.payOrder)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/payments/orders/"+tt.orderId, bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_paymentWebhook(t *testing.T) {
+	type payerMockBehavior func(s *mock_service.MockPayer)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	const payload = `{"type":"payment.updated","payment":{"id":"pay_1","status":"authorized"}}`
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	payment := func(status domain.PaymentStatus) domain.Payment {
+		return domain.Payment{ProviderPaymentId: "pay_1", OrderPublicId: orderPublicId, Amount: 3000000, Status: status}
+	}
+	transaction := domain.Transaction{PublicId: uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1"),
+		Status: domain.TRANSACTION_PAYMENT, OrderPublicId: orderPublicId, Price: 3000000}
+	captured := payment(domain.PAYMENT_CAPTURED)
+	captured.Transaction = &transaction
+
+	tests := []struct {
+		name                 string
+		payerMockBehavior    payerMockBehavior
+		brokerMockProducer   brokerMockProducer
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Can capture the authorized payment and publish the ledger posting",
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().HandleWebhook(gomock.Any(), gomock.Any(), []byte(payload)).Return(captured, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_BILLING_PAYMENT_RECEIVED, "", transaction).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Can accept the declined payment, the decline is published",
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().HandleWebhook(gomock.Any(), gomock.Any(), []byte(payload)).
+					Return(payment(domain.PAYMENT_DECLINED), domain.ErrPaymentDeclined)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_BILLING_PAYMENT_DECLINED, "", payment(domain.PAYMENT_DECLINED)).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Can accept the repeated webhook of the captured payment, nothing is published",
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().HandleWebhook(gomock.Any(), gomock.Any(), []byte(payload)).Return(payment(domain.PAYMENT_CAPTURED), nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Can accept the repeated webhook of the declined payment, nothing is published",
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().HandleWebhook(gomock.Any(), gomock.Any(), []byte(payload)).Return(payment(domain.PAYMENT_DECLINED), nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Can't accept the webhook with the bad signature",
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().HandleWebhook(gomock.Any(), gomock.Any(), []byte(payload)).Return(domain.Payment{}, domain.ErrWebhookSignature)
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"invalid signature"}`,
+		},
+		{
+			name: "Can't accept the webhook of unknown payment",
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().HandleWebhook(gomock.Any(), gomock.Any(), []byte(payload)).Return(domain.Payment{}, domain.ErrPaymentNotFound)
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"payment not found"}`,
+		},
+		{
+			name: "Can return error response if service failure",
+			payerMockBehavior: func(s *mock_service.MockPayer) {
+				s.EXPECT().HandleWebhook(gomock.Any(), gomock.Any(), []byte(payload)).Return(payment(domain.PAYMENT_AUTHORIZED), errors.New(""))
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			payer := mock_service.NewMockPayer(ctrl)
+			tt.payerMockBehavior(payer)
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+
+			handler := NewHandler(&service.Service{Payer: payer}, &broker.Broker{Producer: brokerProducer})
+			r := handler.InitRoutes()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/payments/webhook", bytes.NewBufferString(payload))
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
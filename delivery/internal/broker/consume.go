@@ -0,0 +1,326 @@
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	_ "github.com/golang/mock/mockgen/model"
+	"github.com/p12s/furniture-store/delivery/internal/config"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+	"github.com/p12s/furniture-store/delivery/internal/service"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	AUTO_OFFSET_RESET = "earliest"
+)
+
+var _ Consumer = (*BrokerConsume)(nil)
+
+type Consumer interface {
+	Subscribe() error
+	ProcessEvent(event domain.Event)
+}
+
+type BrokerConsume struct {
+	connection                        *kafka.Consumer
+	service                           *service.Service
+	producer                          Producer
+	TopicAccountBE, TopicAccountCUD   string
+	TopicProductBE, TopicProductCUD   string
+	TopicOrderBE, TopicOrderCUD       string
+	TopicDeliveryBE, TopicDeliveryCUD string
+	TopicBillingBE, TopicBillingCUD   string
+	TopicPrivacy                      string
+}
+
+func NewConsumer(service *service.Service, producer Producer, conf *config.Broker) (*BrokerConsume, error) {
+	connection, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"metadata.broker.list": conf.Brokers,
+		"security.protocol":    SECURITY_PROTOCOL,
+		"sasl.mechanisms":      SASL_MECHANISMS,
+		"sasl.username":        conf.Username,
+		"sasl.password":        conf.Password,
+		"group.id":             conf.GroupId,
+		"auto.offset.reset":    AUTO_OFFSET_RESET,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create kafka consumer fail: %w", err)
+	}
+
+	return &BrokerConsume{
+		connection:       connection,
+		service:          service,
+		producer:         producer,
+		TopicAccountBE:   conf.TopicAccountBE,
+		TopicAccountCUD:  conf.TopicAccountCUD,
+		TopicProductBE:   conf.TopicProductBE,
+		TopicProductCUD:  conf.TopicProductCUD,
+		TopicOrderBE:     conf.TopicOrderBE,
+		TopicOrderCUD:    conf.TopicOrderCUD,
+		TopicDeliveryBE:  conf.TopicDeliveryBE,
+		TopicDeliveryCUD: conf.TopicDeliveryCUD,
+		TopicBillingBE:   conf.TopicBillingBE,
+		TopicBillingCUD:  conf.TopicBillingCUD,
+		TopicPrivacy:     conf.TopicPrivacy,
+	}, nil
+}
+
+func (k *BrokerConsume) Subscribe() error {
+	sigchan := make(chan os.Signal, 1)
+	signal.Notify(sigchan, syscall.SIGINT, syscall.SIGTERM)
+
+	err := k.connection.SubscribeTopics([]string{
+		k.TopicAccountBE, k.TopicAccountCUD,
+		k.TopicOrderBE, k.TopicOrderCUD,
+		k.TopicBillingBE,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("subscribe broker topics fail: %w", err)
+	}
+
+	run := true
+	for run == true { // nolint
+		select {
+		case sig := <-sigchan:
+			logrus.Printf("Caught signal %v: terminating\n", sig)
+			run = false
+		default:
+			ev, err := k.connection.ReadMessage(1 * time.Second)
+			if err != nil {
+				continue
+			}
+			var eventData domain.Event
+			err = json.Unmarshal(ev.Value, &eventData)
+			if err != nil {
+				logrus.Errorf("Unmarshal error: %s\n", err.Error())
+				continue
+			}
+			k.ProcessEvent(eventData)
+		}
+	}
+
+	logrus.Println("closing consumer")
+	err = k.connection.Close()
+	if err != nil {
+		return fmt.Errorf("closing consumer fail: %w", err)
+	}
+	return nil
+}
+
+func (k *BrokerConsume) ProcessEvent(event domain.Event) {
+	switch event.Type {
+	case domain.EVENT_ACCOUNT_CREATED:
+		err := k.createAccount(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'create account' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_INFO_UPDATED:
+		err := k.updateAccountInfo(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'update account info' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_ROLE_UPDATED:
+		err := k.updateAccountRole(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'update account role' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_DELETED:
+		err := k.deleteAccount(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'delete account' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_SESSIONS_REVOKED:
+		err := k.revokeSessions(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'revoke sessions' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ADDRESS_CREATED, domain.EVENT_ADDRESS_UPDATED:
+		err := k.saveAddress(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'save address' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ADDRESS_DELETED:
+		err := k.deleteAddress(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'delete address' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_EXPORT_REQUESTED:
+		err := k.exportAccountData(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'export account data' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_ERASURE_REQUESTED:
+		err := k.eraseAccountData(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'erase account data' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ORDER_PAYED:
+		err := k.orderPayed(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'order payed' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_BILLING_PAYMENT_RECEIVED:
+		err := k.paymentReceived(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'payment received' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ORDER_REFUNDED:
+		err := k.orderRefunded(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'order refunded' event fail: %s/n", err.Error())
+		}
+	default:
+		fmt.Printf("unknown event type: %v/n", event.Value)
+	}
+}
+
+func (k *BrokerConsume) createAccount(payload interface{}) error {
+	var account domain.Account
+	err := readPayload(payload, &account)
+	if err != nil {
+		return fmt.Errorf("account-create payload fail: %w/n", err)
+	}
+
+	return k.service.CreateAccount(account)
+}
+
+func (k *BrokerConsume) updateAccountInfo(payload interface{}) error {
+	var data domain.UpdateAccountInfoInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("account-info update payload fail: %w/n", err)
+	}
+
+	return k.service.UpdateAccountInfo(data)
+}
+
+func (k *BrokerConsume) updateAccountRole(payload interface{}) error {
+	var data domain.UpdateAccountRoleInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("account-role update payload fail: %w/n", err)
+	}
+
+	return k.service.UpdateAccountRole(data)
+}
+
+func (k *BrokerConsume) deleteAccount(payload interface{}) error {
+	var data domain.DeleteAccountInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("delete-account payload fail: %w/n", err)
+	}
+
+	return k.service.DeleteAccount(data.PublicId)
+}
+
+func (k *BrokerConsume) revokeSessions(payload interface{}) error {
+	var data domain.SessionsRevokedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("revoke-sessions payload fail: %w/n", err)
+	}
+
+	return k.service.RevokeSessions(data)
+}
+
+func (k *BrokerConsume) saveAddress(payload interface{}) error {
+	var address domain.Address
+	err := readPayload(payload, &address)
+	if err != nil {
+		return fmt.Errorf("address-save payload fail: %w/n", err)
+	}
+
+	return k.service.SaveAddress(address)
+}
+
+func (k *BrokerConsume) deleteAddress(payload interface{}) error {
+	var address domain.Address
+	err := readPayload(payload, &address)
+	if err != nil {
+		return fmt.Errorf("address-delete payload fail: %w/n", err)
+	}
+
+	return k.service.DeleteAddress(address)
+}
+
+// exportAccountData - the answer goes to the privacy topic, only the account service reads it
+func (k *BrokerConsume) exportAccountData(payload interface{}) error {
+	var data domain.PrivacyRequestedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("export-account-data payload fail: %w/n", err)
+	}
+
+	answer, err := k.service.ExportAccountData(data)
+	if err != nil {
+		return err
+	}
+	return k.producer.Produce(domain.EVENT_PRIVACY_DATA_EXPORTED, k.TopicPrivacy, answer)
+}
+
+func (k *BrokerConsume) eraseAccountData(payload interface{}) error {
+	var data domain.PrivacyRequestedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("erase-account-data payload fail: %w/n", err)
+	}
+
+	answer, err := k.service.EraseAccountData(data)
+	if err != nil {
+		return err
+	}
+	return k.producer.Produce(domain.EVENT_PRIVACY_ERASURE_COMPLETED, k.TopicPrivacy, answer)
+}
+
+func (k *BrokerConsume) orderPayed(payload interface{}) error {
+	var order domain.Order
+	err := readPayload(payload, &order)
+	if err != nil {
+		return fmt.Errorf("order-payed payload fail: %w/n", err)
+	}
+
+	return k.service.SaveOrder(order)
+}
+
+// paymentReceived - the order payed through billing, the order can come with Order.Payed too,
+// the repeated one is ignored
+func (k *BrokerConsume) paymentReceived(payload interface{}) error {
+	var data domain.PaymentReceivedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("payment-received payload fail: %w/n", err)
+	}
+
+	return k.service.SaveOrder(domain.Order{PublicId: data.OrderPublicId, AccountPublicId: data.AccountPublicId})
+}
+
+func (k *BrokerConsume) orderRefunded(payload interface{}) error {
+	var order domain.Order
+	err := readPayload(payload, &order)
+	if err != nil {
+		return fmt.Errorf("order-refunded payload fail: %w/n", err)
+	}
+
+	return k.service.CancelDelivery(order.PublicId)
+}
+
+func readPayload(payload interface{}, target interface{}) error {
+	jsonString, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling event value to json string fail: %w", err)
+	}
+
+	err = json.Unmarshal(jsonString, &target)
+	if err != nil {
+		return fmt.Errorf("unmarshaling event value to []byte fail: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Address - copy of the account delivery address, auth.address_* payload.
+// One of the account addresses is the default, the deliveries take it
+type Address struct {
+	PublicId        uuid.UUID `json:"public_id" db:"public_id"`
+	AccountPublicId uuid.UUID `json:"account_public_id" db:"account_public_id"`
+	Country         string    `json:"country" db:"country"`
+	City            string    `json:"city" db:"city"`
+	Street          string    `json:"street" db:"street"`
+	Building        string    `json:"building" db:"building"`
+	Apartment       string    `json:"apartment" db:"apartment"`
+	PostalCode      string    `json:"postal_code" db:"postal_code"`
+	Notes           string    `json:"notes" db:"notes"`
+	Latitude        *float64  `json:"latitude,omitempty" db:"latitude"`
+	Longitude       *float64  `json:"longitude,omitempty" db:"longitude"`
+	IsDefault       bool      `json:"is_default" db:"is_default"`
+}
+
+// Line - one line address, the same as the account service makes
+func (a Address) Line() string {
+	parts := make([]string, 0, 6)
+	for _, part := range []string{a.PostalCode, a.Country, a.City, a.Street, a.Building, a.Apartment} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DeliveryAddress - snapshot of the customer default address taken when the order came to delivery,
+// later changes of the address don't move the order
+type DeliveryAddress struct {
+	PublicId   string   `json:"public_id,omitempty" db:"public_id"`
+	Country    string   `json:"country,omitempty" db:"country"`
+	City       string   `json:"city,omitempty" db:"city"`
+	Street     string   `json:"street,omitempty" db:"street"`
+	Building   string   `json:"building,omitempty" db:"building"`
+	Apartment  string   `json:"apartment,omitempty" db:"apartment"`
+	PostalCode string   `json:"postal_code,omitempty" db:"postal_code"`
+	Notes      string   `json:"notes,omitempty" db:"notes"`
+	Latitude   *float64 `json:"latitude,omitempty" db:"latitude"`
+	Longitude  *float64 `json:"longitude,omitempty" db:"longitude"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDeliveryNotFound     = errors.New("delivery not found")
+	ErrDeliveryAlreadyTaken = errors.New("delivery is already taken")
+	ErrDeliveryNotTaken     = errors.New("delivery is not taken by the courier")
+)
+
+// DeliveryStatus
+type DeliveryStatus string
+
+const (
+	DELIVERY_READY     DeliveryStatus = "ready"
+	DELIVERY_TAKEN     DeliveryStatus = "taken"
+	DELIVERY_DELIVERED DeliveryStatus = "delivered"
+	DELIVERY_CANCELLED DeliveryStatus = "cancelled" // refunded before it was taken
+)
+
+// Delivery - order to deliver, customer and courier names are joined from the account copies.
+// The address is the snapshot of the customer default address, CustomerAddress is its one line
+type Delivery struct {
+	Id               int             `json:"-" db:"id"`
+	OrderPublicId    uuid.UUID       `json:"order_public_id" db:"order_public_id"`
+	CustomerPublicId uuid.UUID       `json:"customer_public_id" db:"customer_public_id"`
+	CustomerName     string          `json:"customer_name" db:"customer_name"`
+	CustomerAddress  string          `json:"customer_address" db:"customer_address"`
+	Address          DeliveryAddress `json:"address" db:"address"`
+	Status           DeliveryStatus  `json:"status" db:"status"`
+	CourierPublicId  string          `json:"courier_public_id,omitempty" db:"courier_public_id"`
+	CourierName      string          `json:"courier_name,omitempty" db:"courier_name"`
+	CourierEmail     string          `json:"courier_email,omitempty" db:"courier_email"`
+	CreatedAt        time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// AssignmentAction
+type AssignmentAction string
+
+const (
+	ASSIGNMENT_TAKEN     AssignmentAction = "taken"
+	ASSIGNMENT_RELEASED  AssignmentAction = "released"
+	ASSIGNMENT_DELIVERED AssignmentAction = "delivered"
+)
+
+// Assignment - courier assignment history record
+type Assignment struct {
+	Id              int              `json:"id" db:"id"`
+	OrderPublicId   uuid.UUID        `json:"order_public_id" db:"order_public_id"`
+	CourierPublicId string           `json:"courier_public_id" db:"courier_public_id"`
+	Action          AssignmentAction `json:"action" db:"action"`
+	CreatedAt       time.Time        `json:"created_at" db:"created_at"`
+}
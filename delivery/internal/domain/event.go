@@ -0,0 +1,34 @@
+package domain
+
+// EventType
+type EventType string
+
+const (
+	EVENT_ACCOUNT_CREATED          EventType = "auth.created"
+	EVENT_ACCOUNT_INFO_UPDATED     EventType = "auth.info_updated"
+	EVENT_ACCOUNT_ROLE_UPDATED     EventType = "auth.role_updated"
+	EVENT_ACCOUNT_DELETED          EventType = "auth.deleted"
+	EVENT_ACCOUNT_SESSIONS_REVOKED EventType = "auth.sessions_revoked"
+	EVENT_ADDRESS_CREATED          EventType = "auth.address_created"
+	EVENT_ADDRESS_UPDATED          EventType = "auth.address_updated"
+	EVENT_ADDRESS_DELETED          EventType = "auth.address_deleted"
+
+	EVENT_ACCOUNT_EXPORT_REQUESTED  EventType = "Account.ExportRequested"
+	EVENT_ACCOUNT_ERASURE_REQUESTED EventType = "Account.ErasureRequested"
+
+	EVENT_PRIVACY_DATA_EXPORTED     EventType = "Privacy.DataExported"
+	EVENT_PRIVACY_ERASURE_COMPLETED EventType = "Privacy.ErasureCompleted"
+
+	EVENT_ORDER_PAYED            EventType = "Order.Payed"
+	EVENT_ORDER_REFUNDED         EventType = "Order.Refunded"
+	EVENT_ORDER_TAKED_TO_DELIVER EventType = "Order.TakedToDeliver"
+	EVENT_ORDER_DELIVERED        EventType = "Order.Delivered"
+
+	EVENT_BILLING_PAYMENT_RECEIVED EventType = "Billing.PaymentReceived"
+)
+
+// Event
+type Event struct {
+	Type  EventType
+	Value interface{}
+}
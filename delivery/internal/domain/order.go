@@ -0,0 +1,15 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Order - payed order, delivery only needs to know whom to deliver
+type Order struct {
+	PublicId        uuid.UUID `json:"public_id"`
+	AccountPublicId uuid.UUID `json:"account_public_id"`
+}
+
+// PaymentReceivedInput - Billing.PaymentReceived payload, the ledger transaction of the order payment
+type PaymentReceivedInput struct {
+	OrderPublicId   uuid.UUID `json:"order_public_id"`
+	AccountPublicId uuid.UUID `json:"account_public_id"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+const (
+	// PRIVACY_SERVICE - the service name in the privacy answers
+	PRIVACY_SERVICE = "delivery"
+)
+
+// PrivacyRequestedInput - Account.ExportRequested and Account.ErasureRequested payload
+type PrivacyRequestedInput struct {
+	RequestId string `json:"request_id" binding:"required"`
+	PublicId  string `json:"public_id" binding:"required"`
+}
+
+// PrivacyAnswer - Privacy.DataExported and Privacy.ErasureCompleted payload, the erasure has no data
+type PrivacyAnswer struct {
+	RequestId string      `json:"request_id"`
+	PublicId  string      `json:"public_id"`
+	Service   string      `json:"service"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// AccountData - the account copy with the addresses, the deliveries to the customer or by the courier
+// and the courier assignments
+type AccountData struct {
+	Account     *Account     `json:"account"`
+	Addresses   []Address    `json:"addresses"`
+	Deliveries  []Delivery   `json:"deliveries"`
+	Assignments []Assignment `json:"assignments"`
+}
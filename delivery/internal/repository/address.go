@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+)
+
+var _ Addresser = (*Address)(nil)
+
+// Addresser - account address copies repository interface
+type Addresser interface {
+	SaveAddress(address domain.Address) error
+	DeleteAddress(address domain.Address) error
+}
+
+// Address
+type Address struct {
+	db *sqlx.DB
+}
+
+// NewAddress - constructor
+func NewAddress(db *sqlx.DB) *Address {
+	return &Address{db: db}
+}
+
+// SaveAddress - auth.address_created / auth.address_updated. The new default takes the flag
+// from the other addresses of the account and goes to the ready deliveries without the address
+func (r *Address) SaveAddress(address domain.Address) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	if address.IsDefault {
+		query := fmt.Sprintf(`UPDATE %s SET is_default=0 WHERE account_public_id=$1 AND public_id<>$2`, addressTable)
+		if _, err := tx.Exec(query, address.AccountPublicId, address.PublicId); err != nil {
+			return fmt.Errorf("reset default address: %w", err)
+		}
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, account_public_id, country, city, street, building,
+		apartment, postal_code, notes, latitude, longitude, line, is_default)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT(public_id) DO UPDATE SET country=excluded.country, city=excluded.city,
+		street=excluded.street, building=excluded.building, apartment=excluded.apartment,
+		postal_code=excluded.postal_code, notes=excluded.notes, latitude=excluded.latitude,
+		longitude=excluded.longitude, line=excluded.line, is_default=excluded.is_default`, addressTable)
+	_, err = tx.Exec(query, address.PublicId, address.AccountPublicId, address.Country, address.City,
+		address.Street, address.Building, address.Apartment, address.PostalCode, address.Notes,
+		address.Latitude, address.Longitude, address.Line(), address.IsDefault)
+	if err != nil {
+		return fmt.Errorf("save address: %w", err)
+	}
+
+	if address.IsDefault {
+		if _, err := tx.Exec(snapshotAddress, address.AccountPublicId, domain.DELIVERY_READY); err != nil {
+			return fmt.Errorf("snapshot address: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// DeleteAddress - auth.address_deleted, the delivery snapshots of the address are kept
+func (r *Address) DeleteAddress(address domain.Address) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, addressTable)
+	_, err := r.db.Exec(query, address.PublicId)
+	return err
+}
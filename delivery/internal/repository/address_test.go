@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddress_snapshot(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	customerPublicId := uuid.New()
+	latitude, longitude := 55.76, 37.6
+	home := domain.Address{PublicId: uuid.New(), AccountPublicId: customerPublicId, Country: "Russia",
+		City: "Moscow", Street: "Tverskaya", Building: "1", Apartment: "12", PostalCode: "125009",
+		Notes: "ring twice", Latitude: &latitude, Longitude: &longitude, IsDefault: true}
+	office := domain.Address{PublicId: uuid.New(), AccountPublicId: customerPublicId, Country: "Russia",
+		City: "Moscow", Street: "Arbat", Building: "5"}
+	homeSnapshot := domain.DeliveryAddress{PublicId: home.PublicId.String(), Country: "Russia", City: "Moscow",
+		Street: "Tverskaya", Building: "1", Apartment: "12", PostalCode: "125009", Notes: "ring twice",
+		Latitude: &latitude, Longitude: &longitude}
+	officeSnapshot := domain.DeliveryAddress{PublicId: office.PublicId.String(), Country: "Russia", City: "Moscow",
+		Street: "Arbat", Building: "5"}
+
+	assert.NoError(t, repos.SaveAddress(home))
+	assert.NoError(t, repos.SaveAddress(office))
+
+	first := domain.Order{PublicId: uuid.New(), AccountPublicId: customerPublicId}
+	t.Run("Can snapshot the default address of the customer", func(t *testing.T) {
+		assert.NoError(t, repos.SaveOrder(first))
+
+		delivery, err := repos.GetDelivery(first.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, homeSnapshot, delivery.Address)
+		assert.Equal(t, "125009, Russia, Moscow, Tverskaya, 1, 12", delivery.CustomerAddress)
+	})
+
+	t.Run("Can keep the snapshot when the default changes", func(t *testing.T) {
+		office.IsDefault = true
+		assert.NoError(t, repos.SaveAddress(office))
+		assert.NoError(t, repos.DeleteAddress(home))
+
+		delivery, err := repos.GetDelivery(first.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, homeSnapshot, delivery.Address)
+
+		second := domain.Order{PublicId: uuid.New(), AccountPublicId: customerPublicId}
+		assert.NoError(t, repos.SaveOrder(second))
+		delivery, err = repos.GetDelivery(second.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, officeSnapshot, delivery.Address)
+	})
+
+	t.Run("Can snapshot the address that comes after the order", func(t *testing.T) {
+		order := domain.Order{PublicId: uuid.New(), AccountPublicId: uuid.New()}
+		assert.NoError(t, repos.SaveOrder(order))
+
+		delivery, err := repos.GetDelivery(order.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DeliveryAddress{}, delivery.Address)
+		assert.Empty(t, delivery.CustomerAddress)
+
+		// not the default one is not delivered to
+		assert.NoError(t, repos.SaveAddress(domain.Address{PublicId: uuid.New(), AccountPublicId: order.AccountPublicId,
+			City: "Tver"}))
+		delivery, err = repos.GetDelivery(order.PublicId)
+		assert.NoError(t, err)
+		assert.Empty(t, delivery.CustomerAddress)
+
+		assert.NoError(t, repos.SaveAddress(domain.Address{PublicId: uuid.New(), AccountPublicId: order.AccountPublicId,
+			City: "Kazan", IsDefault: true}))
+		delivery, err = repos.GetDelivery(order.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, "Kazan", delivery.CustomerAddress)
+	})
+
+	t.Run("Can erase the addresses and the snapshots", func(t *testing.T) {
+		data, err := repos.ExportAccountData(customerPublicId)
+		assert.NoError(t, err)
+		assert.Len(t, data.Addresses, 1)
+		assert.Len(t, data.Deliveries, 2)
+
+		assert.NoError(t, repos.EraseAccountData(customerPublicId))
+
+		data, err = repos.ExportAccountData(customerPublicId)
+		assert.NoError(t, err)
+		assert.Empty(t, data.Addresses)
+		for _, delivery := range data.Deliveries {
+			assert.Equal(t, domain.DeliveryAddress{}, delivery.Address)
+			assert.Empty(t, delivery.CustomerAddress)
+		}
+	})
+}
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+)
+
+var _ Deliverer = (*Delivery)(nil)
+
+// Deliverer - orders to deliver and courier assignments repository interface
+type Deliverer interface {
+	SaveOrder(order domain.Order) error
+	CancelDelivery(orderPublicId uuid.UUID) error
+	GetDelivery(orderPublicId uuid.UUID) (domain.Delivery, error)
+	GetReadyDeliveries() ([]domain.Delivery, error)
+	GetCourierDeliveries(courierPublicId string) ([]domain.Delivery, error)
+	TakeDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error)
+	ReleaseDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error)
+	CompleteDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error)
+	GetCourierAssignments(courierPublicId string) ([]domain.Assignment, error)
+	GetOrderAssignments(orderPublicId uuid.UUID) ([]domain.Assignment, error)
+}
+
+// Delivery
+type Delivery struct {
+	db *sqlx.DB
+}
+
+// NewDelivery - constructor
+func NewDelivery(db *sqlx.DB) *Delivery {
+	return &Delivery{db: db}
+}
+
+// selectDelivery - customer and courier names are taken from the account copies at read time,
+// the account events can come after the order one. The address is the delivery snapshot
+var selectDelivery = fmt.Sprintf(`SELECT d.id, d.order_public_id, d.customer_public_id,
+	COALESCE(c.name, '') AS customer_name, d.customer_address,
+	d.address_public_id AS "address.public_id", d.address_country AS "address.country",
+	d.address_city AS "address.city", d.address_street AS "address.street",
+	d.address_building AS "address.building", d.address_apartment AS "address.apartment",
+	d.address_postal_code AS "address.postal_code", d.address_notes AS "address.notes",
+	d.address_latitude AS "address.latitude", d.address_longitude AS "address.longitude",
+	d.status, d.courier_public_id, COALESCE(k.name, '') AS courier_name, COALESCE(k.email, '') AS courier_email,
+	d.created_at, d.updated_at
+	FROM %[1]s d
+	LEFT JOIN %[2]s c ON c.public_id = d.customer_public_id
+	LEFT JOIN %[2]s k ON k.public_id = d.courier_public_id`, deliveryTable, accountTable)
+
+// snapshotAddress - the customer default address goes to the ready deliveries of the customer
+// that have no address yet, the address event can come after the order one
+var snapshotAddress = fmt.Sprintf(`UPDATE %[1]s SET address_public_id=a.public_id, customer_address=a.line,
+	address_country=a.country, address_city=a.city, address_street=a.street, address_building=a.building,
+	address_apartment=a.apartment, address_postal_code=a.postal_code, address_notes=a.notes,
+	address_latitude=a.latitude, address_longitude=a.longitude
+	FROM %[2]s a WHERE a.account_public_id=%[1]s.customer_public_id AND a.is_default=1
+	AND %[1]s.customer_public_id=$1 AND %[1]s.status=$2 AND %[1]s.address_public_id=''`, deliveryTable, addressTable)
+
+// SaveOrder - payed order is ready to deliver with the customer default address, repeated event is ignored
+func (r *Delivery) SaveOrder(order domain.Order) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	now := time.Now().UTC()
+	query := fmt.Sprintf(`INSERT INTO %s (order_public_id, customer_public_id, status, created_at, updated_at)
+		values ($1, $2, $3, $4, $5) ON CONFLICT(order_public_id) DO NOTHING`, deliveryTable)
+	_, err = tx.Exec(query, order.PublicId, order.AccountPublicId, domain.DELIVERY_READY, now, now)
+	if err != nil {
+		return fmt.Errorf("save order: %w", err)
+	}
+	if _, err := tx.Exec(snapshotAddress, order.AccountPublicId, domain.DELIVERY_READY); err != nil {
+		return fmt.Errorf("snapshot address: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// CancelDelivery - only a not taken order can be cancelled
+func (r *Delivery) CancelDelivery(orderPublicId uuid.UUID) error {
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, updated_at=$2 WHERE order_public_id=$3 AND status=$4`, deliveryTable)
+	_, err := r.db.Exec(query, domain.DELIVERY_CANCELLED, time.Now().UTC(), orderPublicId, domain.DELIVERY_READY)
+	return err
+}
+
+// GetDelivery
+func (r *Delivery) GetDelivery(orderPublicId uuid.UUID) (domain.Delivery, error) {
+	return getDelivery(r.db, orderPublicId)
+}
+
+// GetReadyDeliveries - courier dashboard, the oldest orders first
+func (r *Delivery) GetReadyDeliveries() ([]domain.Delivery, error) {
+	deliveries := make([]domain.Delivery, 0)
+
+	query := selectDelivery + ` WHERE d.status=$1 ORDER BY d.id`
+	err := r.db.Select(&deliveries, query, domain.DELIVERY_READY)
+	if err != nil {
+		return deliveries, fmt.Errorf("get ready deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// GetCourierDeliveries - taken and delivered by the courier orders, the latest first
+func (r *Delivery) GetCourierDeliveries(courierPublicId string) ([]domain.Delivery, error) {
+	deliveries := make([]domain.Delivery, 0)
+
+	query := selectDelivery + ` WHERE d.courier_public_id=$1 ORDER BY d.updated_at DESC`
+	err := r.db.Select(&deliveries, query, courierPublicId)
+	if err != nil {
+		return deliveries, fmt.Errorf("get courier deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// TakeDelivery - exclusive claim: only one courier's update matches a ready order
+func (r *Delivery) TakeDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error) {
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, courier_public_id=$2, updated_at=$3
+		WHERE order_public_id=$4 AND status=$5`, deliveryTable)
+	return r.assign(orderPublicId, courierPublicId, domain.ASSIGNMENT_TAKEN, domain.ErrDeliveryAlreadyTaken,
+		query, domain.DELIVERY_TAKEN, courierPublicId, time.Now().UTC(), orderPublicId, domain.DELIVERY_READY)
+}
+
+// ReleaseDelivery - courier gives the order back, it is ready for others again
+func (r *Delivery) ReleaseDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error) {
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, courier_public_id='', updated_at=$2
+		WHERE order_public_id=$3 AND status=$4 AND courier_public_id=$5`, deliveryTable)
+	return r.assign(orderPublicId, courierPublicId, domain.ASSIGNMENT_RELEASED, domain.ErrDeliveryNotTaken,
+		query, domain.DELIVERY_READY, time.Now().UTC(), orderPublicId, domain.DELIVERY_TAKEN, courierPublicId)
+}
+
+// CompleteDelivery - only the courier who took the order can deliver it
+func (r *Delivery) CompleteDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error) {
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, updated_at=$2
+		WHERE order_public_id=$3 AND status=$4 AND courier_public_id=$5`, deliveryTable)
+	return r.assign(orderPublicId, courierPublicId, domain.ASSIGNMENT_DELIVERED, domain.ErrDeliveryNotTaken,
+		query, domain.DELIVERY_DELIVERED, time.Now().UTC(), orderPublicId, domain.DELIVERY_TAKEN, courierPublicId)
+}
+
+// GetCourierAssignments
+func (r *Delivery) GetCourierAssignments(courierPublicId string) ([]domain.Assignment, error) {
+	assignments := make([]domain.Assignment, 0)
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE courier_public_id=$1 ORDER BY id`, assignmentTable)
+	err := r.db.Select(&assignments, query, courierPublicId)
+	if err != nil {
+		return assignments, fmt.Errorf("get courier assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// GetOrderAssignments
+func (r *Delivery) GetOrderAssignments(orderPublicId uuid.UUID) ([]domain.Assignment, error) {
+	assignments := make([]domain.Assignment, 0)
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE order_public_id=$1 ORDER BY id`, assignmentTable)
+	err := r.db.Select(&assignments, query, orderPublicId)
+	if err != nil {
+		return assignments, fmt.Errorf("get order assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// assign - conditional status update and its history record in one transaction.
+// Nothing updated means the order is missing or in another state, it is reported with conflictErr.
+func (r *Delivery) assign(orderPublicId uuid.UUID, courierPublicId string, action domain.AssignmentAction,
+	conflictErr error, update string, args ...interface{}) (domain.Delivery, error) {
+	var delivery domain.Delivery
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return delivery, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	result, err := tx.Exec(update, args...)
+	if err != nil {
+		return delivery, fmt.Errorf("update delivery: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return delivery, fmt.Errorf("update delivery: %w", err)
+	}
+	if affected == 0 {
+		if _, err := getDelivery(tx, orderPublicId); err != nil {
+			return delivery, err
+		}
+		return delivery, conflictErr
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (order_public_id, courier_public_id, action, created_at)
+		values ($1, $2, $3, $4)`, assignmentTable)
+	_, err = tx.Exec(query, orderPublicId, courierPublicId, action, time.Now().UTC())
+	if err != nil {
+		return delivery, fmt.Errorf("create assignment: %w", err)
+	}
+
+	delivery, err = getDelivery(tx, orderPublicId)
+	if err != nil {
+		return delivery, err
+	}
+
+	return delivery, tx.Commit()
+}
+
+func getDelivery(q sqlx.Queryer, orderPublicId uuid.UUID) (domain.Delivery, error) {
+	var delivery domain.Delivery
+
+	err := sqlx.Get(q, &delivery, selectDelivery+` WHERE d.order_public_id=$1`, orderPublicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return delivery, domain.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return delivery, fmt.Errorf("get delivery: %w", err)
+	}
+
+	return delivery, nil
+}
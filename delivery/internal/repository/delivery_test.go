@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestDelivery_TakeDelivery(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewDelivery(db)
+
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	customerPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	courierPublicId := "5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"
+	createdAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "order_public_id", "customer_public_id", "customer_name", "customer_address",
+		"status", "courier_public_id", "courier_name", "courier_email", "created_at", "updated_at"}
+
+	tests := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Can take ready order",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE "+deliveryTable).WithArgs(domain.DELIVERY_TAKEN, courierPublicId,
+					sqlmock.AnyArg(), orderPublicId, domain.DELIVERY_READY).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO "+assignmentTable).WithArgs(orderPublicId, courierPublicId,
+					domain.ASSIGNMENT_TAKEN, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT (.+) FROM " + deliveryTable).WithArgs(orderPublicId).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, orderPublicId, customerPublicId, "Ivan",
+						"Some-city, some-street", domain.DELIVERY_TAKEN, courierPublicId, "Petr", "petr@test.ru",
+						createdAt, createdAt))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Can't take order already taken by another courier",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE "+deliveryTable).WithArgs(domain.DELIVERY_TAKEN, courierPublicId,
+					sqlmock.AnyArg(), orderPublicId, domain.DELIVERY_READY).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM " + deliveryTable).WithArgs(orderPublicId).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, orderPublicId, customerPublicId, "", "",
+						domain.DELIVERY_TAKEN, uuid.NewString(), "", "", createdAt, createdAt))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrDeliveryAlreadyTaken,
+		},
+		{
+			name: "Can't take unknown order",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE "+deliveryTable).WithArgs(domain.DELIVERY_TAKEN, courierPublicId,
+					sqlmock.AnyArg(), orderPublicId, domain.DELIVERY_READY).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM " + deliveryTable).WithArgs(orderPublicId).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrDeliveryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			delivery, err := repo.TakeDelivery(orderPublicId, courierPublicId)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.DELIVERY_TAKEN, delivery.Status)
+				assert.Equal(t, "Some-city, some-street", delivery.CustomerAddress)
+				assert.Equal(t, "petr@test.ru", delivery.CourierEmail)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDelivery_ExclusiveClaim(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	order := domain.Order{PublicId: uuid.New(), AccountPublicId: uuid.New()}
+	assert.NoError(t, repos.SaveOrder(order))
+	assert.NoError(t, repos.SaveOrder(order), "repeated order event is ignored")
+
+	// customer account copy and address come after the order
+	assert.NoError(t, repos.CreateAccount(domain.Account{PublicId: order.AccountPublicId, Name: "Ivan"}))
+	assert.NoError(t, repos.SaveAddress(domain.Address{PublicId: uuid.New(), AccountPublicId: order.AccountPublicId,
+		City: "Some-city", Street: "some-street", IsDefault: true}))
+
+	couriers := make([]string, 10)
+	for i := range couriers {
+		couriers[i] = uuid.NewString()
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan error, len(couriers))
+	for _, courier := range couriers {
+		wg.Add(1)
+		go func(courier string) {
+			defer wg.Done()
+			_, err := repos.TakeDelivery(order.PublicId, courier)
+			results <- err
+		}(courier)
+	}
+	wg.Wait()
+	close(results)
+
+	taken := 0
+	for err := range results {
+		if err == nil {
+			taken++
+			continue
+		}
+		assert.True(t, errors.Is(err, domain.ErrDeliveryAlreadyTaken))
+	}
+	assert.Equal(t, 1, taken)
+
+	delivery, err := repos.GetDelivery(order.PublicId)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DELIVERY_TAKEN, delivery.Status)
+	assert.Equal(t, "Ivan", delivery.CustomerName)
+	assert.Equal(t, "Some-city, some-street", delivery.CustomerAddress)
+
+	ready, err := repos.GetReadyDeliveries()
+	assert.NoError(t, err)
+	assert.Empty(t, ready)
+
+	_, err = repos.CompleteDelivery(order.PublicId, uuid.NewString())
+	assert.True(t, errors.Is(err, domain.ErrDeliveryNotTaken), "only the courier who took the order delivers it")
+
+	delivered, err := repos.CompleteDelivery(order.PublicId, delivery.CourierPublicId)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DELIVERY_DELIVERED, delivered.Status)
+
+	assignments, err := repos.GetOrderAssignments(order.PublicId)
+	assert.NoError(t, err)
+	assert.Len(t, assignments, 2)
+	assert.Equal(t, domain.ASSIGNMENT_TAKEN, assignments[0].Action)
+	assert.Equal(t, domain.ASSIGNMENT_DELIVERED, assignments[1].Action)
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+)
+
+var _ Privacier = (*Privacy)(nil)
+
+// Privacier - repository interface
+type Privacier interface {
+	ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error)
+	EraseAccountData(accountPublicId uuid.UUID) error
+}
+
+// Privacy - personal data of the account kept here
+type Privacy struct {
+	db *sqlx.DB
+}
+
+// NewPrivacy - constructor
+func NewPrivacy(db *sqlx.DB) *Privacy {
+	return &Privacy{db: db}
+}
+
+// ExportAccountData - the account copy is nil if the account events haven't come
+func (r *Privacy) ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error) {
+	data := domain.AccountData{
+		Addresses:   make([]domain.Address, 0),
+		Deliveries:  make([]domain.Delivery, 0),
+		Assignments: make([]domain.Assignment, 0),
+	}
+
+	var account domain.Account
+	query := fmt.Sprintf(`SELECT public_id, name, email, address, role FROM %s WHERE public_id=$1`, accountTable)
+	err := r.db.Get(&account, query, accountPublicId)
+	switch {
+	case err == nil:
+		data.Account = &account
+	case !errors.Is(err, sql.ErrNoRows):
+		return data, fmt.Errorf("export account: %w", err)
+	}
+
+	query = fmt.Sprintf(`SELECT public_id, account_public_id, country, city, street, building, apartment,
+		postal_code, notes, latitude, longitude, is_default FROM %s WHERE account_public_id=$1 ORDER BY rowid`, addressTable)
+	if err := r.db.Select(&data.Addresses, query, accountPublicId); err != nil {
+		return data, fmt.Errorf("export addresses: %w", err)
+	}
+	query = selectDelivery + ` WHERE d.customer_public_id=$1 OR d.courier_public_id=$1 ORDER BY d.id`
+	if err := r.db.Select(&data.Deliveries, query, accountPublicId.String()); err != nil {
+		return data, fmt.Errorf("export deliveries: %w", err)
+	}
+	query = fmt.Sprintf(`SELECT * FROM %s WHERE courier_public_id=$1 ORDER BY id`, assignmentTable)
+	if err := r.db.Select(&data.Assignments, query, accountPublicId.String()); err != nil {
+		return data, fmt.Errorf("export assignments: %w", err)
+	}
+
+	return data, nil
+}
+
+// EraseAccountData - the name and email are kept in the account copy only, the deliveries
+// take them at read time. So the copy and the addresses are removed, the address snapshots
+// of the deliveries are cleared, the deliveries and their history are kept
+func (r *Privacy) EraseAccountData(accountPublicId uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`UPDATE %s SET customer_address='', address_public_id='', address_country='',
+		address_city='', address_street='', address_building='', address_apartment='', address_postal_code='',
+		address_notes='', address_latitude=NULL, address_longitude=NULL WHERE customer_public_id=$1`, deliveryTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase delivery addresses: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE account_public_id=$1`, addressTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase addresses: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, accountTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase account: %w", err)
+	}
+
+	return tx.Commit()
+}
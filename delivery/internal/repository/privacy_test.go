@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestPrivacy_ExportAccountData(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	repo := NewPrivacy(db)
+
+	accountPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	createdAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	accountColumns := []string{"public_id", "name", "email", "address", "role"}
+	addressColumns := []string{"public_id", "account_public_id", "country", "city", "street", "building",
+		"apartment", "postal_code", "notes", "latitude", "longitude", "is_default"}
+	deliveryColumns := []string{"id", "order_public_id", "customer_public_id", "customer_name", "customer_address",
+		"status", "courier_public_id", "courier_name", "courier_email", "created_at", "updated_at"}
+	assignmentColumns := []string{"id", "order_public_id", "courier_public_id", "action", "created_at"}
+
+	tests := []struct {
+		name         string
+		mockBehavior func()
+		wantAccount  bool
+		wantAddress  bool
+	}{
+		{
+			name: "Can export the account copy with the deliveries to the customer",
+			mockBehavior: func() {
+				mock.ExpectQuery("SELECT (.+) FROM " + accountTable).WithArgs(accountPublicId).
+					WillReturnRows(sqlmock.NewRows(accountColumns).
+						AddRow(accountPublicId, "Alex", "alex@mail.com", "Main st. 1", domain.ROLE_CUSTOMER))
+				mock.ExpectQuery("SELECT (.+) FROM " + addressTable).WithArgs(accountPublicId).
+					WillReturnRows(sqlmock.NewRows(addressColumns).AddRow(uuid.NewString(), accountPublicId,
+						"Russia", "Moscow", "Main st.", "1", "", "", "", nil, nil, true))
+				mock.ExpectQuery("SELECT (.+) FROM " + deliveryTable).WithArgs(accountPublicId.String()).
+					WillReturnRows(sqlmock.NewRows(deliveryColumns).AddRow(1, orderPublicId, accountPublicId,
+						"Alex", "Main st. 1", domain.DELIVERY_READY, "", "", "", createdAt, createdAt))
+				mock.ExpectQuery("SELECT (.+) FROM " + assignmentTable).WithArgs(accountPublicId.String()).
+					WillReturnRows(sqlmock.NewRows(assignmentColumns))
+			},
+			wantAccount: true,
+			wantAddress: true,
+		},
+		{
+			name: "Can export without the account copy that hasn't come",
+			mockBehavior: func() {
+				mock.ExpectQuery("SELECT (.+) FROM " + accountTable).WithArgs(accountPublicId).
+					WillReturnRows(sqlmock.NewRows(accountColumns))
+				mock.ExpectQuery("SELECT (.+) FROM " + addressTable).WithArgs(accountPublicId).
+					WillReturnRows(sqlmock.NewRows(addressColumns))
+				mock.ExpectQuery("SELECT (.+) FROM " + deliveryTable).WithArgs(accountPublicId.String()).
+					WillReturnRows(sqlmock.NewRows(deliveryColumns).AddRow(1, orderPublicId, accountPublicId,
+						"", "", domain.DELIVERY_READY, "", "", "", createdAt, createdAt))
+				mock.ExpectQuery("SELECT (.+) FROM " + assignmentTable).WithArgs(accountPublicId.String()).
+					WillReturnRows(sqlmock.NewRows(assignmentColumns))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior()
+
+			data, err := repo.ExportAccountData(accountPublicId)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAccount, data.Account != nil)
+			assert.Equal(t, tt.wantAddress, len(data.Addresses) == 1)
+			assert.Len(t, data.Deliveries, 1)
+			assert.Empty(t, data.Assignments)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
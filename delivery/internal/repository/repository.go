@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"fmt"
+
+	_ "github.com/golang/mock/mockgen/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -destination mocks/mock.go -package repository github.com/p12s/furniture-store/delivery/internal/repository Accounter,Addresser,Deliverer,Privacier
+
+// Repository - repo
+type Repository struct {
+	Accounter
+	Addresser
+	Deliverer
+	Privacier
+}
+
+// NewRepository - constructor
+func NewRepository(db *sqlx.DB) *Repository {
+	createSchema(db, accountTable, `CREATE TABLE IF NOT EXISTS account (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"name" TEXT DEFAULT '',
+		"email" TEXT DEFAULT '',
+		"address" TEXT DEFAULT '',
+		"role" INTEGER DEFAULT 0
+	  );`)
+	createSchema(db, revokedSessionTable, `CREATE TABLE IF NOT EXISTS revoked_session (
+		"session_public_id" TEXT NOT NULL PRIMARY KEY,
+		"account_public_id" TEXT NOT NULL,
+		"expires_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, deliveryTable, `CREATE TABLE IF NOT EXISTS delivery (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"order_public_id" TEXT NOT NULL UNIQUE,
+		"customer_public_id" TEXT NOT NULL,
+		"status" TEXT NOT NULL,
+		"courier_public_id" TEXT DEFAULT '' NOT NULL,
+		"customer_address" TEXT DEFAULT '' NOT NULL,
+		"address_public_id" TEXT DEFAULT '' NOT NULL,
+		"address_country" TEXT DEFAULT '' NOT NULL,
+		"address_city" TEXT DEFAULT '' NOT NULL,
+		"address_street" TEXT DEFAULT '' NOT NULL,
+		"address_building" TEXT DEFAULT '' NOT NULL,
+		"address_apartment" TEXT DEFAULT '' NOT NULL,
+		"address_postal_code" TEXT DEFAULT '' NOT NULL,
+		"address_notes" TEXT DEFAULT '' NOT NULL,
+		"address_latitude" REAL,
+		"address_longitude" REAL,
+		"created_at" DATETIME NOT NULL,
+		"updated_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, "delivery_status index", `CREATE INDEX IF NOT EXISTS delivery_status ON delivery (status);`)
+	createSchema(db, assignmentTable, `CREATE TABLE IF NOT EXISTS delivery_assignment (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"order_public_id" TEXT NOT NULL,
+		"courier_public_id" TEXT NOT NULL,
+		"action" TEXT NOT NULL,
+		"created_at" DATETIME NOT NULL
+	  );`)
+
+	createSchema(db, addressTable, `CREATE TABLE IF NOT EXISTS address (
+		"public_id" TEXT NOT NULL PRIMARY KEY,
+		"account_public_id" TEXT NOT NULL,
+		"country" TEXT DEFAULT '',
+		"city" TEXT DEFAULT '',
+		"street" TEXT DEFAULT '',
+		"building" TEXT DEFAULT '',
+		"apartment" TEXT DEFAULT '',
+		"postal_code" TEXT DEFAULT '',
+		"notes" TEXT DEFAULT '',
+		"latitude" REAL,
+		"longitude" REAL,
+		"line" TEXT DEFAULT '',
+		"is_default" INTEGER DEFAULT 0
+	  );`)
+	createSchema(db, "address_account index", `CREATE INDEX IF NOT EXISTS address_account ON address (account_public_id);`)
+
+	return &Repository{
+		Accounter: NewAccount(db),
+		Addresser: NewAddress(db),
+		Deliverer: NewDelivery(db),
+		Privacier: NewPrivacy(db),
+	}
+}
+
+// Deliberately removed the obligation of important fields,
+// because the architecture is asynchronous, the business-event with only role (role)
+// can come before a CUD-event with all other data.
+
+// createSchema - table, index
+func createSchema(db *sqlx.DB, name, query string) {
+	statement, err := db.Prepare(query)
+	if err != nil {
+		logrus.Fatalf("create delivery.%s fail: %s", name, err.Error())
+	}
+	defer statement.Close() // nolint
+
+	_, err = statement.Exec()
+	if err != nil {
+		logrus.Fatalf("exec creating delivery.%s fail: %s", name, err.Error())
+	}
+
+	fmt.Printf("delivery.%s created 🗂\n", name)
+}
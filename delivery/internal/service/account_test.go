@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/p12s/furniture-store/delivery/internal/config"
+	mock_repository "github.com/p12s/furniture-store/delivery/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_SIGNING_KEY = "signing-key"
+
+func TestAccountService_ParseToken(t *testing.T) {
+	courierPublicId := "5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"
+	sign := func(method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		assert.NoError(t, err)
+		return token
+	}
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	type mockBehavior func(r *mock_repository.MockAccounter)
+
+	tests := []struct {
+		name         string
+		token        string
+		mockBehavior mockBehavior
+		expected     string
+		wantErr      bool
+	}{
+		{
+			name: "Can parse the subject of the active session",
+			token: sign(jwt.SigningMethodHS256, []byte(TEST_SIGNING_KEY),
+				jwt.MapClaims{"sub": courierPublicId, "jti": "session", "exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {
+				r.EXPECT().IsSessionRevoked("session").Return(false, nil)
+			},
+			expected: courierPublicId,
+		},
+		{
+			name: "Can't parse the token of the revoked session",
+			token: sign(jwt.SigningMethodHS256, []byte(TEST_SIGNING_KEY),
+				jwt.MapClaims{"sub": courierPublicId, "jti": "session", "exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {
+				r.EXPECT().IsSessionRevoked("session").Return(true, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Can't parse the token when the session is unknown to fail",
+			token: sign(jwt.SigningMethodHS256, []byte(TEST_SIGNING_KEY),
+				jwt.MapClaims{"sub": courierPublicId, "jti": "session", "exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {
+				r.EXPECT().IsSessionRevoked("session").Return(false, errors.New(""))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Can't parse the token without the session",
+			token: sign(jwt.SigningMethodHS256, []byte(TEST_SIGNING_KEY),
+				jwt.MapClaims{"sub": courierPublicId, "exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {},
+			wantErr:      true,
+		},
+		{
+			name: "Can't parse the oauth token of a client",
+			token: sign(jwt.SigningMethodHS256, []byte(TEST_SIGNING_KEY),
+				jwt.MapClaims{"sub": courierPublicId, "jti": "session", "aud": "oauth", "scope": "email", "exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {},
+			wantErr:      true,
+		},
+		{
+			name: "Can't parse the token without the subject",
+			token: sign(jwt.SigningMethodHS256, []byte(TEST_SIGNING_KEY),
+				jwt.MapClaims{"exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {},
+			wantErr:      true,
+		},
+		{
+			name: "Can't parse the expired token",
+			token: sign(jwt.SigningMethodHS256, []byte(TEST_SIGNING_KEY),
+				jwt.MapClaims{"sub": courierPublicId, "exp": time.Now().Add(-time.Hour).Unix()}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {},
+			wantErr:      true,
+		},
+		{
+			name: "Can't parse the token signed with another key",
+			token: sign(jwt.SigningMethodHS256, []byte("other-key"),
+				jwt.MapClaims{"sub": courierPublicId, "exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {},
+			wantErr:      true,
+		},
+		{
+			name:         "Can't parse the unsigned token",
+			token:        sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"sub": courierPublicId}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockAccounter(ctrl)
+			tt.mockBehavior(repo)
+
+			subject, err := NewAccountService(repo, &config.Auth{SigningKey: TEST_SIGNING_KEY}).ParseToken(tt.token)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, subject)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+	"github.com/p12s/furniture-store/delivery/internal/repository"
+)
+
+var _ Addresser = (*AddressService)(nil)
+
+// Addresser - service interface
+type Addresser interface {
+	SaveAddress(address domain.Address) error
+	DeleteAddress(address domain.Address) error
+}
+
+// AddressService - copies of the account addresses, the deliveries snapshot the default one
+type AddressService struct {
+	repo repository.Addresser
+}
+
+// NewAddressService - constructor
+func NewAddressService(repo repository.Addresser) *AddressService {
+	return &AddressService{repo: repo}
+}
+
+// SaveAddress
+func (s *AddressService) SaveAddress(address domain.Address) error {
+	return s.repo.SaveAddress(address)
+}
+
+// DeleteAddress
+func (s *AddressService) DeleteAddress(address domain.Address) error {
+	return s.repo.DeleteAddress(address)
+}
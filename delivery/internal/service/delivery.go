@@ -0,0 +1,77 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+	"github.com/p12s/furniture-store/delivery/internal/repository"
+)
+
+var _ Deliverer = (*DeliveryService)(nil)
+
+// Deliverer - service interface
+type Deliverer interface {
+	SaveOrder(order domain.Order) error
+	CancelDelivery(orderPublicId uuid.UUID) error
+	GetReadyDeliveries() ([]domain.Delivery, error)
+	GetCourierDeliveries(courierPublicId string) ([]domain.Delivery, error)
+	TakeDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error)
+	ReleaseDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error)
+	CompleteDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error)
+	GetCourierAssignments(courierPublicId string) ([]domain.Assignment, error)
+	GetOrderAssignments(orderPublicId uuid.UUID) ([]domain.Assignment, error)
+}
+
+// DeliveryService - service
+type DeliveryService struct {
+	repo repository.Deliverer
+}
+
+// NewDeliveryService - constructor
+func NewDeliveryService(repo repository.Deliverer) *DeliveryService {
+	return &DeliveryService{repo: repo}
+}
+
+// SaveOrder - payed order becomes ready to deliver
+func (s *DeliveryService) SaveOrder(order domain.Order) error {
+	return s.repo.SaveOrder(order)
+}
+
+// CancelDelivery - refunded order is not delivered if nobody has taken it yet
+func (s *DeliveryService) CancelDelivery(orderPublicId uuid.UUID) error {
+	return s.repo.CancelDelivery(orderPublicId)
+}
+
+// GetReadyDeliveries
+func (s *DeliveryService) GetReadyDeliveries() ([]domain.Delivery, error) {
+	return s.repo.GetReadyDeliveries()
+}
+
+// GetCourierDeliveries
+func (s *DeliveryService) GetCourierDeliveries(courierPublicId string) ([]domain.Delivery, error) {
+	return s.repo.GetCourierDeliveries(courierPublicId)
+}
+
+// TakeDelivery
+func (s *DeliveryService) TakeDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error) {
+	return s.repo.TakeDelivery(orderPublicId, courierPublicId)
+}
+
+// ReleaseDelivery
+func (s *DeliveryService) ReleaseDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error) {
+	return s.repo.ReleaseDelivery(orderPublicId, courierPublicId)
+}
+
+// CompleteDelivery
+func (s *DeliveryService) CompleteDelivery(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error) {
+	return s.repo.CompleteDelivery(orderPublicId, courierPublicId)
+}
+
+// GetCourierAssignments
+func (s *DeliveryService) GetCourierAssignments(courierPublicId string) ([]domain.Assignment, error) {
+	return s.repo.GetCourierAssignments(courierPublicId)
+}
+
+// GetOrderAssignments
+func (s *DeliveryService) GetOrderAssignments(orderPublicId uuid.UUID) ([]domain.Assignment, error) {
+	return s.repo.GetOrderAssignments(orderPublicId)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/delivery/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliveryService(t *testing.T) {
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	courierPublicId := "5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"
+
+	t.Run("Can save the payed order for every payment event", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		order := domain.Order{PublicId: orderPublicId, AccountPublicId: uuid.New()}
+		repo := mock_repository.NewMockDeliverer(ctrl)
+		repo.EXPECT().SaveOrder(order).Return(nil).Times(2)
+
+		s := NewDeliveryService(repo)
+		assert.NoError(t, s.SaveOrder(order))
+		assert.NoError(t, s.SaveOrder(order))
+	})
+
+	t.Run("Can't take the order taken by another courier", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockDeliverer(ctrl)
+		repo.EXPECT().TakeDelivery(orderPublicId, courierPublicId).Return(domain.Delivery{}, domain.ErrDeliveryAlreadyTaken)
+
+		_, err := NewDeliveryService(repo).TakeDelivery(orderPublicId, courierPublicId)
+		assert.ErrorIs(t, err, domain.ErrDeliveryAlreadyTaken)
+	})
+}
@@ -0,0 +1,396 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/p12s/furniture-store/delivery/internal/service (interfaces: Accounter,Addresser,Deliverer,Privacier)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	domain "github.com/p12s/furniture-store/delivery/internal/domain"
+)
+
+// MockAccounter is a mock of Accounter interface.
+type MockAccounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccounterMockRecorder
+}
+
+// MockAccounterMockRecorder is the mock recorder for MockAccounter.
+type MockAccounterMockRecorder struct {
+	mock *MockAccounter
+}
+
+// NewMockAccounter creates a new mock instance.
+func NewMockAccounter(ctrl *gomock.Controller) *MockAccounter {
+	mock := &MockAccounter{ctrl: ctrl}
+	mock.recorder = &MockAccounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccounter) EXPECT() *MockAccounterMockRecorder {
+	return m.recorder
+}
+
+// CreateAccount mocks base method.
+func (m *MockAccounter) CreateAccount(arg0 domain.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockAccounterMockRecorder) CreateAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAccounter)(nil).CreateAccount), arg0)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccounter) DeleteAccount(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccounterMockRecorder) DeleteAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccounter)(nil).DeleteAccount), arg0)
+}
+
+// GetAccount mocks base method.
+func (m *MockAccounter) GetAccount(arg0 string) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccounterMockRecorder) GetAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccounter)(nil).GetAccount), arg0)
+}
+
+// ParseToken mocks base method.
+func (m *MockAccounter) ParseToken(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockAccounterMockRecorder) ParseToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAccounter)(nil).ParseToken), arg0)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockAccounter) RevokeSessions(arg0 domain.SessionsRevokedInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAccounterMockRecorder) RevokeSessions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockAccounter)(nil).RevokeSessions), arg0)
+}
+
+// UpdateAccountInfo mocks base method.
+func (m *MockAccounter) UpdateAccountInfo(arg0 domain.UpdateAccountInfoInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountInfo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountInfo indicates an expected call of UpdateAccountInfo.
+func (mr *MockAccounterMockRecorder) UpdateAccountInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountInfo", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountInfo), arg0)
+}
+
+// UpdateAccountRole mocks base method.
+func (m *MockAccounter) UpdateAccountRole(arg0 domain.UpdateAccountRoleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountRole", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountRole indicates an expected call of UpdateAccountRole.
+func (mr *MockAccounterMockRecorder) UpdateAccountRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountRole", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountRole), arg0)
+}
+
+// MockAddresser is a mock of Addresser interface.
+type MockAddresser struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddresserMockRecorder
+}
+
+// MockAddresserMockRecorder is the mock recorder for MockAddresser.
+type MockAddresserMockRecorder struct {
+	mock *MockAddresser
+}
+
+// NewMockAddresser creates a new mock instance.
+func NewMockAddresser(ctrl *gomock.Controller) *MockAddresser {
+	mock := &MockAddresser{ctrl: ctrl}
+	mock.recorder = &MockAddresserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddresser) EXPECT() *MockAddresserMockRecorder {
+	return m.recorder
+}
+
+// DeleteAddress mocks base method.
+func (m *MockAddresser) DeleteAddress(arg0 domain.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockAddresserMockRecorder) DeleteAddress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockAddresser)(nil).DeleteAddress), arg0)
+}
+
+// SaveAddress mocks base method.
+func (m *MockAddresser) SaveAddress(arg0 domain.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAddress", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAddress indicates an expected call of SaveAddress.
+func (mr *MockAddresserMockRecorder) SaveAddress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAddress", reflect.TypeOf((*MockAddresser)(nil).SaveAddress), arg0)
+}
+
+// MockDeliverer is a mock of Deliverer interface.
+type MockDeliverer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDelivererMockRecorder
+}
+
+// MockDelivererMockRecorder is the mock recorder for MockDeliverer.
+type MockDelivererMockRecorder struct {
+	mock *MockDeliverer
+}
+
+// NewMockDeliverer creates a new mock instance.
+func NewMockDeliverer(ctrl *gomock.Controller) *MockDeliverer {
+	mock := &MockDeliverer{ctrl: ctrl}
+	mock.recorder = &MockDelivererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliverer) EXPECT() *MockDelivererMockRecorder {
+	return m.recorder
+}
+
+// CancelDelivery mocks base method.
+func (m *MockDeliverer) CancelDelivery(arg0 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelDelivery", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelDelivery indicates an expected call of CancelDelivery.
+func (mr *MockDelivererMockRecorder) CancelDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDelivery", reflect.TypeOf((*MockDeliverer)(nil).CancelDelivery), arg0)
+}
+
+// CompleteDelivery mocks base method.
+func (m *MockDeliverer) CompleteDelivery(arg0 uuid.UUID, arg1 string) (domain.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDelivery", arg0, arg1)
+	ret0, _ := ret[0].(domain.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteDelivery indicates an expected call of CompleteDelivery.
+func (mr *MockDelivererMockRecorder) CompleteDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDelivery", reflect.TypeOf((*MockDeliverer)(nil).CompleteDelivery), arg0, arg1)
+}
+
+// GetCourierAssignments mocks base method.
+func (m *MockDeliverer) GetCourierAssignments(arg0 string) ([]domain.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierAssignments", arg0)
+	ret0, _ := ret[0].([]domain.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierAssignments indicates an expected call of GetCourierAssignments.
+func (mr *MockDelivererMockRecorder) GetCourierAssignments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierAssignments", reflect.TypeOf((*MockDeliverer)(nil).GetCourierAssignments), arg0)
+}
+
+// GetCourierDeliveries mocks base method.
+func (m *MockDeliverer) GetCourierDeliveries(arg0 string) ([]domain.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierDeliveries", arg0)
+	ret0, _ := ret[0].([]domain.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierDeliveries indicates an expected call of GetCourierDeliveries.
+func (mr *MockDelivererMockRecorder) GetCourierDeliveries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierDeliveries", reflect.TypeOf((*MockDeliverer)(nil).GetCourierDeliveries), arg0)
+}
+
+// GetOrderAssignments mocks base method.
+func (m *MockDeliverer) GetOrderAssignments(arg0 uuid.UUID) ([]domain.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderAssignments", arg0)
+	ret0, _ := ret[0].([]domain.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderAssignments indicates an expected call of GetOrderAssignments.
+func (mr *MockDelivererMockRecorder) GetOrderAssignments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderAssignments", reflect.TypeOf((*MockDeliverer)(nil).GetOrderAssignments), arg0)
+}
+
+// GetReadyDeliveries mocks base method.
+func (m *MockDeliverer) GetReadyDeliveries() ([]domain.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReadyDeliveries")
+	ret0, _ := ret[0].([]domain.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReadyDeliveries indicates an expected call of GetReadyDeliveries.
+func (mr *MockDelivererMockRecorder) GetReadyDeliveries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadyDeliveries", reflect.TypeOf((*MockDeliverer)(nil).GetReadyDeliveries))
+}
+
+// ReleaseDelivery mocks base method.
+func (m *MockDeliverer) ReleaseDelivery(arg0 uuid.UUID, arg1 string) (domain.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDelivery", arg0, arg1)
+	ret0, _ := ret[0].(domain.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseDelivery indicates an expected call of ReleaseDelivery.
+func (mr *MockDelivererMockRecorder) ReleaseDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDelivery", reflect.TypeOf((*MockDeliverer)(nil).ReleaseDelivery), arg0, arg1)
+}
+
+// SaveOrder mocks base method.
+func (m *MockDeliverer) SaveOrder(arg0 domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrder", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOrder indicates an expected call of SaveOrder.
+func (mr *MockDelivererMockRecorder) SaveOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockDeliverer)(nil).SaveOrder), arg0)
+}
+
+// TakeDelivery mocks base method.
+func (m *MockDeliverer) TakeDelivery(arg0 uuid.UUID, arg1 string) (domain.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeDelivery", arg0, arg1)
+	ret0, _ := ret[0].(domain.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeDelivery indicates an expected call of TakeDelivery.
+func (mr *MockDelivererMockRecorder) TakeDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeDelivery", reflect.TypeOf((*MockDeliverer)(nil).TakeDelivery), arg0, arg1)
+}
+
+// MockPrivacier is a mock of Privacier interface.
+type MockPrivacier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacierMockRecorder
+}
+
+// MockPrivacierMockRecorder is the mock recorder for MockPrivacier.
+type MockPrivacierMockRecorder struct {
+	mock *MockPrivacier
+}
+
+// NewMockPrivacier creates a new mock instance.
+func NewMockPrivacier(ctrl *gomock.Controller) *MockPrivacier {
+	mock := &MockPrivacier{ctrl: ctrl}
+	mock.recorder = &MockPrivacierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacier) EXPECT() *MockPrivacierMockRecorder {
+	return m.recorder
+}
+
+// EraseAccountData mocks base method.
+func (m *MockPrivacier) EraseAccountData(arg0 domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseAccountData", arg0)
+	ret0, _ := ret[0].(domain.PrivacyAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseAccountData indicates an expected call of EraseAccountData.
+func (mr *MockPrivacierMockRecorder) EraseAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseAccountData", reflect.TypeOf((*MockPrivacier)(nil).EraseAccountData), arg0)
+}
+
+// ExportAccountData mocks base method.
+func (m *MockPrivacier) ExportAccountData(arg0 domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccountData", arg0)
+	ret0, _ := ret[0].(domain.PrivacyAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccountData indicates an expected call of ExportAccountData.
+func (mr *MockPrivacierMockRecorder) ExportAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccountData", reflect.TypeOf((*MockPrivacier)(nil).ExportAccountData), arg0)
+}
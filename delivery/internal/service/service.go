@@ -0,0 +1,28 @@
+package service
+
+import (
+	_ "github.com/golang/mock/mockgen/model"
+
+	"github.com/p12s/furniture-store/delivery/internal/config"
+	"github.com/p12s/furniture-store/delivery/internal/repository"
+)
+
+//go:generate mockgen -destination mocks/mock.go -package service github.com/p12s/furniture-store/delivery/internal/service Accounter,Addresser,Deliverer,Privacier
+
+// Service - just service
+type Service struct {
+	Accounter
+	Addresser
+	Deliverer
+	Privacier
+}
+
+// NewService - constructor
+func NewService(repos *repository.Repository, auth *config.Auth) *Service {
+	return &Service{
+		Accounter: NewAccountService(repos.Accounter, auth),
+		Addresser: NewAddressService(repos.Addresser),
+		Deliverer: NewDeliveryService(repos.Deliverer),
+		Privacier: NewPrivacyService(repos.Privacier),
+	}
+}
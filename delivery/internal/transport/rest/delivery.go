@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Get ready deliveries
+// @Tags Delivery
+// @Description Payed orders nobody has taken yet, with the customer name and address
+// @ID getReadyDeliveries
+// @Produce  json
+// @Success 200
+// @Router /deliveries [get]
+func (h *Handler) getReadyDeliveries(c *gin.Context) {
+	deliveries, err := h.services.GetReadyDeliveries()
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// @Summary Get courier deliveries
+// @Tags Delivery
+// @Description Orders taken and delivered by the caller
+// @ID getCourierDeliveries
+// @Produce  json
+// @Success 200
+// @Router /deliveries/my [get]
+func (h *Handler) getCourierDeliveries(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	deliveries, err := h.services.GetCourierDeliveries(accountPublicId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// @Summary Get courier assignment history
+// @Tags Delivery
+// @Description Taken, released and delivered by the caller orders
+// @ID getCourierAssignments
+// @Produce  json
+// @Success 200
+// @Router /deliveries/history [get]
+func (h *Handler) getCourierAssignments(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	assignments, err := h.services.GetCourierAssignments(accountPublicId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// @Summary Take order to deliver
+// @Tags Delivery
+// @Description Only one courier can take an order
+// @ID takeDelivery
+// @Produce  json
+// @Param id path string true "order public_id"
+// @Success 200
+// @Failure 409 "order is already taken"
+// @Router /deliveries/orders/{id}/take [post]
+func (h *Handler) takeDelivery(c *gin.Context) {
+	h.assignDelivery(c, h.services.TakeDelivery, domain.EVENT_ORDER_TAKED_TO_DELIVER)
+}
+
+// @Summary Release taken order
+// @Tags Delivery
+// @Description Courier gives the order back, it is ready for others again
+// @ID releaseDelivery
+// @Produce  json
+// @Param id path string true "order public_id"
+// @Success 200
+// @Failure 409 "order is not taken by you"
+// @Router /deliveries/orders/{id}/release [post]
+func (h *Handler) releaseDelivery(c *gin.Context) {
+	h.assignDelivery(c, h.services.ReleaseDelivery, "")
+}
+
+// @Summary Deliver taken order
+// @Tags Delivery
+// @ID completeDelivery
+// @Produce  json
+// @Param id path string true "order public_id"
+// @Success 200
+// @Failure 409 "order is not taken by you"
+// @Router /deliveries/orders/{id}/deliver [post]
+func (h *Handler) completeDelivery(c *gin.Context) {
+	h.assignDelivery(c, h.services.CompleteDelivery, domain.EVENT_ORDER_DELIVERED)
+}
+
+// @Summary Get order assignment history
+// @Tags Delivery
+// @Description Admin can see who and when had the order
+// @ID getOrderAssignments
+// @Produce  json
+// @Param id path string true "order public_id"
+// @Success 200
+// @Router /admin/orders/{id}/assignments [get]
+func (h *Handler) getOrderAssignments(c *gin.Context) {
+	orderPublicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid order public id")
+		return
+	}
+
+	assignments, err := h.services.GetOrderAssignments(orderPublicId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// assignDelivery - courier action on the order, business event is sent if it is set
+func (h *Handler) assignDelivery(c *gin.Context,
+	action func(orderPublicId uuid.UUID, courierPublicId string) (domain.Delivery, error),
+	eventType domain.EventType) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	orderPublicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid order public id")
+		return
+	}
+
+	delivery, err := action(orderPublicId, accountPublicId)
+	switch {
+	case errors.Is(err, domain.ErrDeliveryNotFound):
+		newErrorResponse(c, http.StatusNotFound, "delivery not found")
+		return
+	case errors.Is(err, domain.ErrDeliveryAlreadyTaken):
+		newErrorResponse(c, http.StatusConflict, "order is already taken")
+		return
+	case errors.Is(err, domain.ErrDeliveryNotTaken):
+		newErrorResponse(c, http.StatusConflict, "order is not taken by you")
+		return
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	if eventType != "" {
+		go func() {
+			err := h.broker.Produce(eventType, h.broker.TopicDeliveryBE, delivery)
+			if err != nil {
+				logrus.Errorf("sent delivery event fail: %s/n", err.Error())
+			}
+		}()
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/delivery/internal/broker"
+	mock_broker "github.com/p12s/furniture-store/delivery/internal/broker/mocks"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+	"github.com/p12s/furniture-store/delivery/internal/service"
+	mock_service "github.com/p12s/furniture-store/delivery/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	WAITING_GORUTINE_END_TIME = 100 * time.Millisecond
+)
+
+func TestHandler_takeDelivery(t *testing.T) {
+	type deliveryMockBehavior func(s *mock_service.MockDeliverer)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	courierPublicId := "5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	createdAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	address := domain.DeliveryAddress{PublicId: "a3a9fb6c-58e1-4c3b-8d2c-8b6a1d4f2b7e", City: "Moscow",
+		Street: "Tverskaya", Building: "1"}
+	taken := domain.Delivery{
+		OrderPublicId:    orderPublicId,
+		CustomerPublicId: uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1"),
+		CustomerAddress:  "Moscow, Tverskaya, 1",
+		Address:          address,
+		Status:           domain.DELIVERY_TAKEN,
+		CourierPublicId:  courierPublicId,
+		CreatedAt:        createdAt,
+		UpdatedAt:        createdAt,
+	}
+
+	tests := []struct {
+		name                 string
+		orderId              string
+		deliveryMockBehavior deliveryMockBehavior
+		brokerMockProducer   brokerMockProducer
+		expectedStatusCode   int
+		expectedRequestBody  string
+	}{
+		{
+			name:    "Can take the order and publish it",
+			orderId: orderPublicId.String(),
+			deliveryMockBehavior: func(s *mock_service.MockDeliverer) {
+				s.EXPECT().TakeDelivery(orderPublicId, courierPublicId).Return(taken, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ORDER_TAKED_TO_DELIVER, "", taken).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `{"order_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","customer_public_id":"8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1","customer_name":"","customer_address":"Moscow, Tverskaya, 1","address":{"public_id":"a3a9fb6c-58e1-4c3b-8d2c-8b6a1d4f2b7e","city":"Moscow","street":"Tverskaya","building":"1"},"status":"taken","courier_public_id":"5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11","created_at":"2021-12-01T10:00:00Z","updated_at":"2021-12-01T10:00:00Z"}`,
+		},
+		{
+			name:    "Can't take the order taken by another courier",
+			orderId: orderPublicId.String(),
+			deliveryMockBehavior: func(s *mock_service.MockDeliverer) {
+				s.EXPECT().TakeDelivery(orderPublicId, courierPublicId).Return(domain.Delivery{}, domain.ErrDeliveryAlreadyTaken)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusConflict,
+			expectedRequestBody: `{"message":"order is already taken"}`,
+		},
+		{
+			name:    "Can't take unknown order",
+			orderId: orderPublicId.String(),
+			deliveryMockBehavior: func(s *mock_service.MockDeliverer) {
+				s.EXPECT().TakeDelivery(orderPublicId, courierPublicId).Return(domain.Delivery{}, domain.ErrDeliveryNotFound)
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedRequestBody: `{"message":"delivery not found"}`,
+		},
+		{
+			name:                 "Can't take the order by invalid id",
+			orderId:              "123",
+			deliveryMockBehavior: func(s *mock_service.MockDeliverer) {},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedRequestBody:  `{"message":"invalid order public id"}`,
+		},
+		{
+			name:    "Can return error response if service failure",
+			orderId: orderPublicId.String(),
+			deliveryMockBehavior: func(s *mock_service.MockDeliverer) {
+				s.EXPECT().TakeDelivery(orderPublicId, courierPublicId).Return(domain.Delivery{}, errors.New(""))
+			},
+			brokerMockProducer:  func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			deliveries := mock_service.NewMockDeliverer(ctrl)
+			tt.deliveryMockBehavior(deliveries)
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+
+			handler := NewHandler(&service.Service{Deliverer: deliveries}, &broker.Broker{Producer: brokerProducer})
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/deliveries/orders/:id/take", func(c *gin.Context) {
+				c.Set(accountCtx, courierPublicId)
+			}, handler.takeDelivery)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/deliveries/orders/"+tt.orderId+"/take", nil)
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_releaseDelivery(t *testing.T) {
+	courierPublicId := "5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"
+	orderPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+
+	t.Run("Can't release the order not taken by the courier, nothing is published", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deliveries := mock_service.NewMockDeliverer(ctrl)
+		deliveries.EXPECT().ReleaseDelivery(orderPublicId, courierPublicId).Return(domain.Delivery{}, domain.ErrDeliveryNotTaken)
+
+		handler := NewHandler(&service.Service{Deliverer: deliveries},
+			&broker.Broker{Producer: mock_broker.NewMockProducer(ctrl)})
+		gin.SetMode(gin.ReleaseMode)
+		r := gin.New()
+		r.POST("/deliveries/orders/:id/release", func(c *gin.Context) {
+			c.Set(accountCtx, courierPublicId)
+		}, handler.releaseDelivery)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/deliveries/orders/"+orderPublicId.String()+"/release", nil)
+
+		r.ServeHTTP(w, req)
+		time.Sleep(WAITING_GORUTINE_END_TIME)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, `{"message":"order is not taken by you"}`, w.Body.String())
+	})
+}
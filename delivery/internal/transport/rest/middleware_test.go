@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/delivery/internal/broker"
+	"github.com/p12s/furniture-store/delivery/internal/domain"
+	"github.com/p12s/furniture-store/delivery/internal/service"
+	mock_service "github.com/p12s/furniture-store/delivery/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_roleIdentity(t *testing.T) {
+	type accountMockBehavior func(s *mock_service.MockAccounter)
+
+	token := "courier-token"
+	courierPublicId := "5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"
+
+	tests := []struct {
+		name                string
+		headerValue         string
+		accountMockBehavior accountMockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:        "Can pass the courier",
+			headerValue: "Bearer " + token,
+			accountMockBehavior: func(s *mock_service.MockAccounter) {
+				s.EXPECT().ParseToken(token).Return(courierPublicId, nil)
+				s.EXPECT().GetAccount(courierPublicId).Return(domain.Account{
+					PublicId: uuid.MustParse(courierPublicId), Role: domain.ROLE_DELIVERY}, nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: courierPublicId,
+		},
+		{
+			name:        "Can't pass the customer",
+			headerValue: "Bearer " + token,
+			accountMockBehavior: func(s *mock_service.MockAccounter) {
+				s.EXPECT().ParseToken(token).Return(courierPublicId, nil)
+				s.EXPECT().GetAccount(courierPublicId).Return(domain.Account{
+					PublicId: uuid.MustParse(courierPublicId), Role: domain.ROLE_CUSTOMER}, nil)
+			},
+			expectedStatusCode:  http.StatusForbidden,
+			expectedRequestBody: `{"message":"access denied"}`,
+		},
+		{
+			name:        "Can't pass the account without the local copy",
+			headerValue: "Bearer " + token,
+			accountMockBehavior: func(s *mock_service.MockAccounter) {
+				s.EXPECT().ParseToken(token).Return(courierPublicId, nil)
+				s.EXPECT().GetAccount(courierPublicId).Return(domain.Account{}, errors.New("no rows"))
+			},
+			expectedStatusCode:  http.StatusForbidden,
+			expectedRequestBody: `{"message":"access denied"}`,
+		},
+		{
+			name:        "Can't pass the invalid or revoked token",
+			headerValue: "Bearer " + token,
+			accountMockBehavior: func(s *mock_service.MockAccounter) {
+				s.EXPECT().ParseToken(token).Return("", errors.New("revoked session"))
+			},
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedRequestBody: `{"message":"invalid token"}`,
+		},
+		{
+			name:                "Can't pass without Bearer",
+			headerValue:         token,
+			accountMockBehavior: func(s *mock_service.MockAccounter) {},
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedRequestBody: `{"message":"invalid auth header"}`,
+		},
+		{
+			name:                "Can't pass without the header",
+			accountMockBehavior: func(s *mock_service.MockAccounter) {},
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedRequestBody: `{"message":"empty auth header"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accounts := mock_service.NewMockAccounter(ctrl)
+			tt.accountMockBehavior(accounts)
+
+			handler := NewHandler(&service.Service{Accounter: accounts}, &broker.Broker{})
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/deliveries", handler.userIdentity, handler.roleIdentity(domain.ROLE_DELIVERY), func(c *gin.Context) {
+				id, _ := getAccountPublicId(c)
+				c.String(http.StatusOK, id)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/deliveries", nil)
+			if tt.headerValue != "" {
+				req.Header.Set(authorizationHandler, tt.headerValue)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
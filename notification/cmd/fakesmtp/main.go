@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/p12s/furniture-store/notification/internal/notifier/fakesmtp"
+	"github.com/sirupsen/logrus"
+)
+
+// Config - fake SMTP server, for local and offline notification testing only
+type Config struct {
+	Port int `envconfig:"FAKESMTP_PORT" default:"2525"`
+}
+
+func main() {
+	logrus.SetFormatter(new(logrus.JSONFormatter))
+
+	var cfg Config
+	if err := envconfig.Process("fakesmtp", &cfg); err != nil {
+		logrus.Fatalf("error loading env variables: %s\n", err.Error())
+	}
+
+	srv := fakesmtp.NewServer(true)
+	if err := srv.Listen(":" + strconv.Itoa(cfg.Port)); err != nil {
+		logrus.Fatalf("error while listening smtp port: %s\n", err.Error())
+	}
+	logrus.Print("📮 fakesmtp server started with port: ", cfg.Port)
+	if err := srv.Serve(); err != nil {
+		logrus.Fatalf("error while running smtp server: %s\n", err.Error())
+	}
+}
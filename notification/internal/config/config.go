@@ -0,0 +1,124 @@
+package config
+
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config
+type Config struct {
+	DB           DB
+	Server       Server
+	Auth         Auth
+	Notification Notification
+	SMTP         SMTP
+	Webhook      Webhook
+	Broker       Broker
+	Env          Env
+}
+
+// DB
+type DB struct {
+	Driver string `envconfig:"DB_DRIVER" required:"true"`
+}
+
+// Server
+type Server struct {
+	Port int `envconfig:"SERVER_PORT" required:"true"`
+}
+
+// Auth - notification only checks tokens issued by the account service
+type Auth struct {
+	SigningKey string `envconfig:"AUTH_SIGNING_KEY" required:"true"`
+}
+
+// Notification - failed sending is retried with growing delay: backoff * attempts
+type Notification struct {
+	DefaultLocale string        `envconfig:"NOTIFICATION_DEFAULT_LOCALE" required:"true"`
+	MaxAttempts   int           `envconfig:"NOTIFICATION_MAX_ATTEMPTS" required:"true"`
+	RetryInterval time.Duration `envconfig:"NOTIFICATION_RETRY_INTERVAL" required:"true"`
+	RetryBackoff  time.Duration `envconfig:"NOTIFICATION_RETRY_BACKOFF" required:"true"`
+}
+
+// SMTP - email channel, empty username means no authentication
+type SMTP struct {
+	Host     string `envconfig:"SMTP_HOST" required:"true"`
+	Port     int    `envconfig:"SMTP_PORT" required:"true"`
+	Username string `envconfig:"SMTP_USERNAME"`
+	Password string `envconfig:"SMTP_PASSWORD"`
+	From     string `envconfig:"SMTP_FROM" required:"true"`
+}
+
+// Webhook - webhook channel
+type Webhook struct {
+	Timeout time.Duration `envconfig:"WEBHOOK_TIMEOUT" required:"true"`
+	// AllowPrivate - loopback and private network urls, for the local setup only
+	AllowPrivate bool `envconfig:"WEBHOOK_ALLOW_PRIVATE" default:"false"`
+}
+
+// Broker
+type Broker struct {
+	Brokers          string `envconfig:"BROKER_BROKERS" required:"true"`
+	Username         string `envconfig:"BROKER_USERNAME" required:"true"`
+	Password         string `envconfig:"BROKER_PASSWORD" required:"true"`
+	TopicAccountBE   string `envconfig:"BROKER_TOPIC_ACCOUNT_BE" required:"true"`
+	TopicAccountCUD  string `envconfig:"BROKER_TOPIC_ACCOUNT_CUD" required:"true"`
+	TopicProductBE   string `envconfig:"BROKER_TOPIC_PRODUCT_BE" required:"true"`
+	TopicProductCUD  string `envconfig:"BROKER_TOPIC_PRODUCT_CUD" required:"true"`
+	TopicOrderBE     string `envconfig:"BROKER_TOPIC_ORDER_BE" required:"true"`
+	TopicOrderCUD    string `envconfig:"BROKER_TOPIC_ORDER_CUD" required:"true"`
+	TopicDeliveryBE  string `envconfig:"BROKER_TOPIC_DELIVERY_BE" required:"true"`
+	TopicDeliveryCUD string `envconfig:"BROKER_TOPIC_DELIVERY_CUD" required:"true"`
+	TopicBillingBE   string `envconfig:"BROKER_TOPIC_BILLING_BE" required:"true"`
+	TopicBillingCUD  string `envconfig:"BROKER_TOPIC_BILLING_CUD" required:"true"`
+	TopicPrivacy     string `envconfig:"BROKER_TOPIC_PRIVACY" required:"true"`
+	GroupId          string `envconfig:"BROKER_GROUP_ID" required:"true"`
+}
+
+// Env
+type Env struct {
+	Current string `envconfig:"ENV_CURRENT" required:"true"`
+	Dev     string `envconfig:"ENV_DEV" required:"true"`
+	Qa      string `envconfig:"ENV_QA" required:"true"`
+	Prod    string `envconfig:"ENV_PROD" required:"true"`
+}
+
+// New - contructor
+func New() (*Config, error) {
+	cfg := new(Config)
+
+	if err := envconfig.Process("db", &cfg.DB); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("server", &cfg.Server); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("auth", &cfg.Auth); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("notification", &cfg.Notification); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("smtp", &cfg.SMTP); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("webhook", &cfg.Webhook); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("broker", &cfg.Broker); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("env", &cfg.Env); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role
+type Role int
+
+const (
+	ROLE_CUSTOMER Role = iota
+	ROLE_ADMIN
+	ROLE_DELIVERY
+	ROLE_DEALER
+)
+
+// Account - copy, "reduced version" of the Auth domain: whom and how to address
+type Account struct {
+	PublicId uuid.UUID `json:"public_id" db:"public_id"`
+	Name     string    `json:"name" db:"name"`
+	Email    string    `json:"email" db:"email"`
+	Role     Role      `json:"role" db:"role"`
+}
+
+// UpdateAccountInfoInput - only the changed fields are set
+type UpdateAccountInfoInput struct {
+	PublicId uuid.UUID `json:"public_id" db:"public_id" binding:"required"`
+	Name     *string   `json:"name" db:"name"`
+	Email    *string   `json:"email" db:"email"`
+}
+
+// UpdateAccountRoleInput
+type UpdateAccountRoleInput struct {
+	PublicId uuid.UUID `json:"public_id" db:"public_id" binding:"required"`
+	Role     Role      `json:"role" db:"role" binding:"required"`
+}
+
+// DeleteAccountInput
+type DeleteAccountInput struct {
+	PublicId string `json:"public_id" db:"public_id" binding:"required"`
+}
+
+// SessionsRevokedInput - auth.sessions_revoked payload, the access tokens of the sessions
+// are not accepted until they expire
+type SessionsRevokedInput struct {
+	PublicId   string    `json:"public_id" binding:"required"`
+	SessionIds []string  `json:"session_ids" binding:"required"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "github.com/google/uuid"
+
+// EventType
+type EventType string
+
+const (
+	EVENT_ACCOUNT_CREATED          EventType = "auth.created"
+	EVENT_ACCOUNT_INFO_UPDATED     EventType = "auth.info_updated"
+	EVENT_ACCOUNT_ROLE_UPDATED     EventType = "auth.role_updated"
+	EVENT_ACCOUNT_DELETED          EventType = "auth.deleted"
+	EVENT_ACCOUNT_SESSIONS_REVOKED EventType = "auth.sessions_revoked"
+
+	EVENT_ACCOUNT_EXPORT_REQUESTED  EventType = "Account.ExportRequested"
+	EVENT_ACCOUNT_ERASURE_REQUESTED EventType = "Account.ErasureRequested"
+
+	EVENT_PRIVACY_DATA_EXPORTED     EventType = "Privacy.DataExported"
+	EVENT_PRIVACY_ERASURE_COMPLETED EventType = "Privacy.ErasureCompleted"
+
+	EVENT_ORDER_CHECKED_OUT      EventType = "Order.CheckedOut"
+	EVENT_ORDER_TAKED_TO_DELIVER EventType = "Order.TakedToDeliver"
+	EVENT_ORDER_DELIVERED        EventType = "Order.Delivered"
+
+	EVENT_BILLING_PAYMENT_RECEIVED EventType = "Billing.PaymentReceived"
+	EVENT_BILLING_PAYMENT_DECLINED EventType = "Billing.PaymentDeclined"
+	EVENT_BILLING_REFUND_ISSUED    EventType = "Billing.RefundIssued"
+)
+
+// Event
+type Event struct {
+	Type  EventType
+	Value interface{}
+}
+
+// Order - Order.CheckedOut payload, only the fields notification needs
+type Order struct {
+	PublicId        uuid.UUID `json:"public_id"`
+	AccountPublicId uuid.UUID `json:"account_public_id"`
+	Total           int64     `json:"total"`
+}
+
+// Transaction - Billing.PaymentReceived / Billing.RefundIssued payload
+type Transaction struct {
+	AccountPublicId uuid.UUID `json:"account_public_id"`
+	OrderPublicId   uuid.UUID `json:"order_public_id"`
+	Price           int64     `json:"price"`
+}
+
+// Payment - Billing.PaymentDeclined payload
+type Payment struct {
+	AccountPublicId uuid.UUID `json:"account_public_id"`
+	OrderPublicId   uuid.UUID `json:"order_public_id"`
+	Amount          int64     `json:"amount"`
+}
+
+// Delivery - Order.TakedToDeliver / Order.Delivered payload
+type Delivery struct {
+	OrderPublicId    uuid.UUID `json:"order_public_id"`
+	CustomerPublicId uuid.UUID `json:"customer_public_id"`
+	CourierName      string    `json:"courier_name"`
+	CourierEmail     string    `json:"courier_email"`
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrUnknownChannel   = errors.New("unknown notification channel")
+	ErrNoRecipient      = errors.New("recipient is unknown")
+	ErrWebhookURL       = errors.New("webhook url must be absolute http(s) url of a public host")
+	ErrUnknownLocale    = errors.New("unknown locale")
+	ErrPreferenceNotSet = errors.New("preference is not set")
+)
+
+// Channel
+type Channel string
+
+const (
+	CHANNEL_EMAIL   Channel = "email"
+	CHANNEL_WEBHOOK Channel = "webhook"
+)
+
+// NotificationStatus
+type NotificationStatus string
+
+const (
+	NOTIFICATION_PENDING NotificationStatus = "pending" // waiting for the first or the next attempt
+	NOTIFICATION_SENT    NotificationStatus = "sent"
+	NOTIFICATION_FAILED  NotificationStatus = "failed" // attempts are over
+)
+
+// Content - rendered message, html part is optional for a channel
+type Content struct {
+	Subject string `json:"subject" db:"subject"`
+	Text    string `json:"text" db:"text"`
+	HTML    string `json:"html" db:"html"`
+}
+
+// TemplateData - what an event template can show
+type TemplateData struct {
+	AccountPublicId uuid.UUID
+	Name            string
+	OrderPublicId   uuid.UUID
+	Amount          int64
+	CourierName     string
+	CourierEmail    string
+}
+
+// Notification - delivery log record, one per channel
+type Notification struct {
+	Id              int       `json:"id" db:"id"`
+	AccountPublicId uuid.UUID `json:"account_public_id" db:"account_public_id"`
+	EventType       EventType `json:"event_type" db:"event_type"`
+	Channel         Channel   `json:"channel" db:"channel"`
+	Content
+	Status        NotificationStatus `json:"status" db:"status"`
+	Attempts      int                `json:"attempts" db:"attempts"`
+	LastError     string             `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt time.Time          `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt     time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// Preference - per-account channels and language, default one is used until the account changes it
+type Preference struct {
+	AccountPublicId uuid.UUID `json:"account_public_id" db:"account_public_id"`
+	Locale          string    `json:"locale" db:"locale"`
+	Email           bool      `json:"email" db:"email"`
+	Webhook         bool      `json:"webhook" db:"webhook"`
+	WebhookURL      string    `json:"webhook_url" db:"webhook_url"`
+}
+
+// Channels - enabled channels
+func (p Preference) Channels() []Channel {
+	channels := make([]Channel, 0, 2)
+	if p.Email {
+		channels = append(channels, CHANNEL_EMAIL)
+	}
+	if p.Webhook {
+		channels = append(channels, CHANNEL_WEBHOOK)
+	}
+	return channels
+}
+
+// UpdatePreferenceInput - only the changed fields are set
+type UpdatePreferenceInput struct {
+	Locale     *string `json:"locale"`
+	Email      *bool   `json:"email"`
+	Webhook    *bool   `json:"webhook"`
+	WebhookURL *string `json:"webhook_url"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+const (
+	// PRIVACY_SERVICE - the service name in the privacy answers
+	PRIVACY_SERVICE = "notification"
+)
+
+// PrivacyRequestedInput - Account.ExportRequested and Account.ErasureRequested payload
+type PrivacyRequestedInput struct {
+	RequestId string `json:"request_id" binding:"required"`
+	PublicId  string `json:"public_id" binding:"required"`
+}
+
+// PrivacyAnswer - Privacy.DataExported and Privacy.ErasureCompleted payload, the erasure has no data
+type PrivacyAnswer struct {
+	RequestId string      `json:"request_id"`
+	PublicId  string      `json:"public_id"`
+	Service   string      `json:"service"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// AccountData - the account copy and the preference are nil if they are not kept
+type AccountData struct {
+	Account       *Account       `json:"account"`
+	Preference    *Preference    `json:"preference"`
+	Notifications []Notification `json:"notifications"`
+}
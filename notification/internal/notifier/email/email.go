@@ -0,0 +1,105 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+
+	"github.com/p12s/furniture-store/notification/internal/domain"
+)
+
+// Client - SMTP channel, message is sent as multipart/alternative text and html
+type Client struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewClient - constructor, without username the server is used without authentication
+func NewClient(host string, port int, username, password, from string) *Client {
+	c := &Client{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		from: from,
+	}
+	if username != "" {
+		c.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return c
+}
+
+// Notify
+func (c *Client) Notify(ctx context.Context, recipient string, content domain.Content) error {
+	if recipient == "" {
+		return domain.ErrNoRecipient
+	}
+
+	message, err := c.message(recipient, content)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(c.addr, c.auth, c.from, []string{recipient}, message)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("smtp send fail: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) message(recipient string, content domain.Content) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		text        string
+	}{
+		{"text/plain", content.Text},
+		{"text/html", content.HTML},
+	} {
+		if part.text == "" {
+			continue
+		}
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("message part fail: %w", err)
+		}
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part.text)); err != nil {
+			return nil, fmt.Errorf("message part fail: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, fmt.Errorf("message part fail: %w", err)
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("message fail: %w", err)
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", c.from)
+	fmt.Fprintf(&message, "To: %s\r\n", recipient)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", content.Subject))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
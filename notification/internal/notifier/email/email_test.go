@@ -0,0 +1,89 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/p12s/furniture-store/notification/internal/notifier/fakesmtp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Notify(t *testing.T) {
+	server := fakesmtp.NewServer(false)
+	assert.NoError(t, server.Listen("127.0.0.1:0"))
+	go server.Serve() // nolint
+	defer server.Close()
+
+	host, portString, err := net.SplitHostPort(server.Addr())
+	assert.NoError(t, err)
+	port, err := strconv.Atoi(portString)
+	assert.NoError(t, err)
+
+	content := domain.Content{
+		Subject: "Заказ оплачен",
+		Text:    "Здравствуйте, Иван!\n",
+		HTML:    "<p>Здравствуйте, Иван!</p>\n",
+	}
+
+	tests := []struct {
+		name     string
+		username string
+	}{
+		{name: "Can send email without authentication"},
+		{name: "Can send email with authentication", username: "user"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(host, port, tt.username, "pass", "noreply@test.ru")
+
+			err := client.Notify(context.Background(), "ivan@test.ru", content)
+			assert.NoError(t, err)
+
+			messages := server.Messages()
+			assert.Len(t, messages, i+1)
+			received := messages[i]
+			assert.Equal(t, "noreply@test.ru", received.From)
+			assert.Equal(t, []string{"ivan@test.ru"}, received.To)
+
+			message, err := mail.ReadMessage(bytes.NewReader(received.Data))
+			assert.NoError(t, err)
+			subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+			assert.NoError(t, err)
+			assert.Equal(t, content.Subject, subject)
+
+			mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+			assert.NoError(t, err)
+			assert.Equal(t, "multipart/alternative", mediaType)
+
+			parts := multipart.NewReader(message.Body, params["boundary"])
+			var bodies []string
+			for {
+				part, err := parts.NextPart()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				assert.NoError(t, err)
+				body, err := io.ReadAll(part)
+				assert.NoError(t, err)
+				bodies = append(bodies, strings.ReplaceAll(string(body), "\r\n", "\n"))
+			}
+			assert.Equal(t, []string{content.Text, content.HTML}, bodies)
+		})
+	}
+
+	t.Run("Can't send email without recipient", func(t *testing.T) {
+		err := NewClient(host, port, "", "", "noreply@test.ru").Notify(context.Background(), "", content)
+		assert.True(t, errors.Is(err, domain.ErrNoRecipient))
+	})
+}
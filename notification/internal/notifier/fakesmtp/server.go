@@ -0,0 +1,175 @@
+package fakesmtp
+
+import (
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Message - received mail, data is the raw message with headers
+type Message struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// Server - local fake SMTP server: accepts any sender, recipient and credentials
+// and keeps the messages in memory, so the email channel can be tested offline
+type Server struct {
+	listener net.Listener
+	verbose  bool
+
+	mu       sync.Mutex
+	messages []Message
+	wg       sync.WaitGroup
+}
+
+// NewServer - constructor, verbose server logs every received message
+func NewServer(verbose bool) *Server {
+	return &Server{verbose: verbose}
+}
+
+// Listen - address like "127.0.0.1:2525", port 0 takes any free one
+func (s *Server) Listen(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	return nil
+}
+
+// Addr - listening address
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Serve - accepts connections until Close
+func (s *Server) Serve() error {
+	for {
+		conn, err := s.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.session(conn)
+		}()
+	}
+}
+
+// Close - stops listening and waits for the open sessions
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+// Messages - received messages copy
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]Message, len(s.messages))
+	copy(messages, s.messages)
+	return messages
+}
+
+func (s *Server) session(c net.Conn) {
+	conn := textproto.NewConn(c)
+	defer conn.Close() // nolint
+
+	var message Message
+	reply := func(format string, args ...interface{}) bool {
+		return conn.PrintfLine(format, args...) == nil
+	}
+
+	if !reply("220 fakesmtp ready") {
+		return
+	}
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		command, arg := line, ""
+		if i := strings.IndexByte(line, ' '); i >= 0 {
+			command, arg = line[:i], line[i+1:]
+		}
+
+		ok := true
+		switch strings.ToUpper(command) {
+		case "EHLO":
+			ok = reply("250-fakesmtp") && reply("250-8BITMIME") && reply("250 AUTH PLAIN")
+		case "HELO":
+			ok = reply("250 fakesmtp")
+		case "AUTH":
+			if !strings.Contains(arg, " ") {
+				// credentials come with the next line
+				if ok = reply("334 "); ok {
+					_, err = conn.ReadLine()
+					ok = err == nil
+				}
+			}
+			ok = ok && reply("235 2.7.0 authentication successful")
+		case "MAIL":
+			message = Message{From: address(arg)}
+			ok = reply("250 2.1.0 ok")
+		case "RCPT":
+			message.To = append(message.To, address(arg))
+			ok = reply("250 2.1.5 ok")
+		case "DATA":
+			if !reply("354 end data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			message.Data, err = conn.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.receive(message)
+			message = Message{}
+			ok = reply("250 2.0.0 ok: queued")
+		case "RSET":
+			message = Message{}
+			ok = reply("250 2.0.0 ok")
+		case "NOOP":
+			ok = reply("250 2.0.0 ok")
+		case "QUIT":
+			reply("221 2.0.0 bye")
+			return
+		default:
+			ok = reply("502 5.5.2 command not recognized")
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+func (s *Server) receive(message Message) {
+	s.mu.Lock()
+	s.messages = append(s.messages, message)
+	s.mu.Unlock()
+
+	if s.verbose {
+		logrus.Printf("fakesmtp message from %s to %v:\n%s", message.From, message.To, message.Data)
+	}
+}
+
+// address - "FROM:<user@host>" or "TO:<user@host> SIZE=..." into user@host
+func address(arg string) string {
+	start, end := strings.IndexByte(arg, '<'), strings.IndexByte(arg, '>')
+	if start < 0 || end < start {
+		return ""
+	}
+	return arg[start+1 : end]
+}
@@ -0,0 +1,29 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/p12s/furniture-store/notification/internal/config"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/p12s/furniture-store/notification/internal/notifier/email"
+	"github.com/p12s/furniture-store/notification/internal/notifier/webhook"
+)
+
+// Notifier - notification channel, recipient is the channel address: email or webhook url
+type Notifier interface {
+	Notify(ctx context.Context, recipient string, content domain.Content) error
+}
+
+var (
+	_ Notifier = (*email.Client)(nil)
+	_ Notifier = (*webhook.Client)(nil)
+)
+
+// NewNotifiers - all supported channels
+func NewNotifiers(smtpConfig *config.SMTP, webhookConfig *config.Webhook) map[domain.Channel]Notifier {
+	return map[domain.Channel]Notifier{
+		domain.CHANNEL_EMAIL: email.NewClient(smtpConfig.Host, smtpConfig.Port,
+			smtpConfig.Username, smtpConfig.Password, smtpConfig.From),
+		domain.CHANNEL_WEBHOOK: webhook.NewClient(webhookConfig.Timeout, webhookConfig.AllowPrivate),
+	}
+}
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/p12s/furniture-store/notification/internal/domain"
+)
+
+// Client - webhook channel, rendered content is posted as json to the account url
+type Client struct {
+	client       *http.Client
+	allowPrivate bool
+}
+
+// NewClient - constructor, without allowPrivate only the public addresses are dialed,
+// the check is made on the connection, so a host resolved to another address after the url check
+// or a redirect doesn't reach the internal network
+func NewClient(timeout time.Duration, allowPrivate bool) *Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return fmt.Errorf("%w: %s is not a public address", domain.ErrWebhookURL, host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &Client{client: &http.Client{Timeout: timeout, Transport: transport}, allowPrivate: allowPrivate}
+}
+
+// Notify - any not 2xx answer is a failure and is retried
+func (c *Client) Notify(ctx context.Context, recipient string, content domain.Content) error {
+	if recipient == "" {
+		return domain.ErrNoRecipient
+	}
+	if err := CheckURL(ctx, recipient, c.allowPrivate); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("webhook encode fail: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, recipient, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook request fail: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook send fail: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook answered with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// CheckURL - absolute http(s) url, its host must be resolved to the public addresses only:
+// not loopback, private, link-local or unspecified. allowPrivate is for the local setup
+func CheckURL(ctx context.Context, rawURL string, allowPrivate bool) error {
+	u, err := url.ParseRequestURI(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return domain.ErrWebhookURL
+	}
+	if allowPrivate {
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("%w: host %s is not resolved", domain.ErrWebhookURL, u.Hostname())
+	}
+	for _, addr := range addrs {
+		if !publicIP(addr.IP) {
+			return fmt.Errorf("%w: %s is not a public address", domain.ErrWebhookURL, addr.IP)
+		}
+	}
+	return nil
+}
+
+// publicIP - the address outside of the service network
+func publicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Notify(t *testing.T) {
+	content := domain.Content{Subject: "Order is paid", Text: "text", HTML: "<p>html</p>"}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{name: "Can post content to webhook", statusCode: http.StatusNoContent},
+		{name: "Can fail on not successful answer", statusCode: http.StatusBadGateway, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received domain.Content
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			err := NewClient(time.Second, true).Notify(context.Background(), server.URL, content)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, content, received)
+		})
+	}
+}
+
+func TestClient_NotifyPrivate(t *testing.T) {
+	content := domain.Content{Subject: "Order is paid", Text: "text"}
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	t.Run("Can't post to the loopback url", func(t *testing.T) {
+		err := NewClient(time.Second, false).Notify(context.Background(), server.URL, content)
+		assert.ErrorIs(t, err, domain.ErrWebhookURL)
+		assert.False(t, requested)
+	})
+
+	t.Run("Can't dial the loopback address passed the url check", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+		assert.NoError(t, err)
+
+		_, err = NewClient(time.Second, false).client.Do(req)
+		assert.ErrorIs(t, err, domain.ErrWebhookURL)
+		assert.False(t, requested)
+	})
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		allowPrivate bool
+		wantErr      bool
+	}{
+		{name: "Can accept the public address", url: "https://93.184.216.34/hook"},
+		{name: "Can accept the loopback address for the local setup", url: "http://127.0.0.1:8080/hook", allowPrivate: true},
+		{name: "Can't accept the loopback address", url: "http://127.0.0.1:8080/hook", wantErr: true},
+		{name: "Can't accept the loopback host", url: "http://localhost/hook", wantErr: true},
+		{name: "Can't accept the ipv6 loopback address", url: "http://[::1]/hook", wantErr: true},
+		{name: "Can't accept the private address", url: "http://10.0.0.5/hook", wantErr: true},
+		{name: "Can't accept the link-local address", url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{name: "Can't accept the unspecified address", url: "http://0.0.0.0/hook", wantErr: true},
+		{name: "Can't accept not http url", url: "ftp://93.184.216.34/hook", wantErr: true},
+		{name: "Can't accept relative url", url: "/hook", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckURL(context.Background(), tt.url, tt.allowPrivate)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrWebhookURL)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package render
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/p12s/furniture-store/notification/internal/domain"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// names - event template file names, events without a template are not notified
+var names = map[domain.EventType]string{
+	domain.EVENT_ORDER_CHECKED_OUT:        "order_checked_out",
+	domain.EVENT_BILLING_PAYMENT_RECEIVED: "payment_received",
+	domain.EVENT_BILLING_PAYMENT_DECLINED: "payment_declined",
+	domain.EVENT_BILLING_REFUND_ISSUED:    "refund_issued",
+	domain.EVENT_ORDER_TAKED_TO_DELIVER:   "order_taked_to_deliver",
+	domain.EVENT_ORDER_DELIVERED:          "order_delivered",
+}
+
+var funcs = map[string]interface{}{
+	"money": money,
+}
+
+// Renderer - localized text/html templates of the events.
+// Every locale is a directory with <name>.txt (defines "subject" and "text") and <name>.html files.
+type Renderer struct {
+	defaultLocale string
+	text          map[string]*texttemplate.Template
+	html          map[string]*htmltemplate.Template
+}
+
+// NewRenderer - constructor, all templates are parsed at once
+func NewRenderer(defaultLocale string) (*Renderer, error) {
+	r := &Renderer{
+		defaultLocale: defaultLocale,
+		text:          make(map[string]*texttemplate.Template),
+		html:          make(map[string]*htmltemplate.Template),
+	}
+
+	locales, err := fs.ReadDir(templates, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("read templates: %w", err)
+	}
+	for _, locale := range locales {
+		for _, name := range names {
+			key := locale.Name() + "/" + name
+
+			text, err := texttemplate.New(name+".txt").Funcs(funcs).ParseFS(templates, "templates/"+key+".txt")
+			if err != nil {
+				return nil, fmt.Errorf("parse %s text template: %w", key, err)
+			}
+			html, err := htmltemplate.New(name+".html").Funcs(funcs).ParseFS(templates, "templates/"+key+".html")
+			if err != nil {
+				return nil, fmt.Errorf("parse %s html template: %w", key, err)
+			}
+
+			r.text[key], r.html[key] = text, html
+		}
+	}
+
+	if !r.HasLocale(defaultLocale) {
+		return nil, fmt.Errorf("default locale %q has no templates", defaultLocale)
+	}
+
+	return r, nil
+}
+
+// Render - message of the event in the locale, unknown locale falls back to the default one
+func (r *Renderer) Render(locale string, eventType domain.EventType, data domain.TemplateData) (domain.Content, error) {
+	var content domain.Content
+
+	name, ok := names[eventType]
+	if !ok {
+		return content, domain.ErrTemplateNotFound
+	}
+	key := locale + "/" + name
+	if _, ok := r.text[key]; !ok {
+		key = r.defaultLocale + "/" + name
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := r.text[key].ExecuteTemplate(&subject, "subject", data); err != nil {
+		return content, fmt.Errorf("render %s subject: %w", key, err)
+	}
+	if err := r.text[key].ExecuteTemplate(&text, "text", data); err != nil {
+		return content, fmt.Errorf("render %s text: %w", key, err)
+	}
+	if err := r.html[key].Execute(&html, data); err != nil {
+		return content, fmt.Errorf("render %s html: %w", key, err)
+	}
+
+	return domain.Content{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// HasLocale - locale has templates
+func (r *Renderer) HasLocale(locale string) bool {
+	_, ok := r.text[locale+"/"+names[domain.EVENT_ORDER_CHECKED_OUT]]
+	return ok
+}
+
+// money - amounts are in minor currency units
+func money(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
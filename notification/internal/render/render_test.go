@@ -0,0 +1,86 @@
+package render
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	renderer, err := NewRenderer("en")
+	assert.NoError(t, err)
+
+	data := domain.TemplateData{
+		Name:          "Ivan",
+		OrderPublicId: uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"),
+		Amount:        123456,
+		CourierName:   "Petr <courier>",
+		CourierEmail:  "petr@test.ru",
+	}
+
+	tests := []struct {
+		name            string
+		locale          string
+		eventType       domain.EventType
+		expectedSubject string
+		expectedText    []string
+		expectedHTML    []string
+		wantErr         error
+	}{
+		{
+			name:            "Can render payment received",
+			locale:          "en",
+			eventType:       domain.EVENT_BILLING_PAYMENT_RECEIVED,
+			expectedSubject: "Order 265cee57-2ff9-4ed3-85e1-d3373fa2a1a5 is paid",
+			expectedText:    []string{"Hello, Ivan!", "1234.56"},
+			expectedHTML:    []string{"<b>1234.56</b>"},
+		},
+		{
+			name:            "Can render courier contact in another locale",
+			locale:          "ru",
+			eventType:       domain.EVENT_ORDER_TAKED_TO_DELIVER,
+			expectedSubject: "Заказ 265cee57-2ff9-4ed3-85e1-d3373fa2a1a5 в пути",
+			expectedText:    []string{"Курьер Petr <courier>", "petr@test.ru"},
+			expectedHTML:    []string{"Petr &lt;courier&gt;", "mailto:petr@test.ru"},
+		},
+		{
+			name:            "Can fall back to the default locale",
+			locale:          "de",
+			eventType:       domain.EVENT_ORDER_DELIVERED,
+			expectedSubject: "Order 265cee57-2ff9-4ed3-85e1-d3373fa2a1a5 is delivered",
+		},
+		{
+			name:      "Can't render event without template",
+			locale:    "en",
+			eventType: domain.EVENT_ACCOUNT_CREATED,
+			wantErr:   domain.ErrTemplateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := renderer.Render(tt.locale, tt.eventType, data)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSubject, content.Subject)
+			for _, text := range tt.expectedText {
+				assert.Contains(t, content.Text, text)
+			}
+			for _, html := range tt.expectedHTML {
+				assert.Contains(t, content.HTML, html)
+			}
+		})
+	}
+}
+
+func TestNewRenderer(t *testing.T) {
+	_, err := NewRenderer("de")
+	assert.Error(t, err, "default locale must have templates")
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+)
+
+var _ Informer = (*Notification)(nil)
+
+// Informer - notification delivery log repository interface
+type Informer interface {
+	CreateNotification(notification domain.Notification) (int, error)
+	UpdateNotification(notification domain.Notification) error
+	GetDueNotifications(now time.Time, limit int) ([]domain.Notification, error)
+	GetAccountNotifications(accountPublicId string) ([]domain.Notification, error)
+}
+
+// Notification
+type Notification struct {
+	db *sqlx.DB
+}
+
+// NewNotification - constructor
+func NewNotification(db *sqlx.DB) *Notification {
+	return &Notification{db: db}
+}
+
+// CreateNotification
+func (r *Notification) CreateNotification(notification domain.Notification) (int, error) {
+	query := fmt.Sprintf(`INSERT INTO %s (account_public_id, event_type, channel, subject, text, html,
+		status, attempts, last_error, next_attempt_at, created_at, updated_at)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`, notificationTable)
+	result, err := r.db.Exec(query, notification.AccountPublicId, notification.EventType, notification.Channel,
+		notification.Subject, notification.Text, notification.HTML, notification.Status, notification.Attempts,
+		notification.LastError, notification.NextAttemptAt, notification.CreatedAt, notification.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("create notification: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("create notification: %w", err)
+	}
+
+	return int(id), nil
+}
+
+// UpdateNotification - attempt result, the content is never changed
+func (r *Notification) UpdateNotification(notification domain.Notification) error {
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, attempts=$2, last_error=$3, next_attempt_at=$4, updated_at=$5
+		WHERE id=$6`, notificationTable)
+	_, err := r.db.Exec(query, notification.Status, notification.Attempts, notification.LastError,
+		notification.NextAttemptAt, notification.UpdatedAt, notification.Id)
+	return err
+}
+
+// GetDueNotifications - pending notifications whose next attempt time has come, the oldest first
+func (r *Notification) GetDueNotifications(now time.Time, limit int) ([]domain.Notification, error) {
+	notifications := make([]domain.Notification, 0)
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE status=$1 AND next_attempt_at<=$2 ORDER BY id LIMIT $3`,
+		notificationTable)
+	err := r.db.Select(&notifications, query, domain.NOTIFICATION_PENDING, now, limit)
+	if err != nil {
+		return notifications, fmt.Errorf("get due notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// GetAccountNotifications - the latest first
+func (r *Notification) GetAccountNotifications(accountPublicId string) ([]domain.Notification, error) {
+	notifications := make([]domain.Notification, 0)
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE account_public_id=$1 ORDER BY id DESC`, notificationTable)
+	err := r.db.Select(&notifications, query, accountPublicId)
+	if err != nil {
+		return notifications, fmt.Errorf("get account notifications: %w", err)
+	}
+
+	return notifications, nil
+}
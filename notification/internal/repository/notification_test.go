@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotification_GetDueNotifications(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	now := time.Now().UTC()
+	accountPublicId := uuid.New()
+
+	for _, notification := range []domain.Notification{
+		{Status: domain.NOTIFICATION_PENDING, NextAttemptAt: now.Add(-time.Minute)},
+		{Status: domain.NOTIFICATION_PENDING, NextAttemptAt: now.Add(time.Minute)},
+		{Status: domain.NOTIFICATION_SENT, NextAttemptAt: now.Add(-time.Minute)},
+		{Status: domain.NOTIFICATION_FAILED, NextAttemptAt: now.Add(-time.Minute)},
+	} {
+		notification.AccountPublicId = accountPublicId
+		notification.EventType = domain.EVENT_ORDER_DELIVERED
+		notification.Channel = domain.CHANNEL_EMAIL
+		notification.CreatedAt, notification.UpdatedAt = now, now
+		_, err := repo.CreateNotification(notification)
+		assert.NoError(t, err)
+	}
+
+	due, err := repo.GetDueNotifications(now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+	assert.Equal(t, 1, due[0].Id)
+
+	due[0].Status = domain.NOTIFICATION_SENT
+	due[0].Attempts = 1
+	due[0].UpdatedAt = now
+	assert.NoError(t, repo.UpdateNotification(due[0]))
+
+	due, err = repo.GetDueNotifications(now.Add(2*time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+	assert.Equal(t, 2, due[0].Id)
+
+	log, err := repo.GetAccountNotifications(accountPublicId.String())
+	assert.NoError(t, err)
+	assert.Len(t, log, 4)
+	assert.Equal(t, 4, log[0].Id)
+}
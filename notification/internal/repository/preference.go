@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+)
+
+var _ Preferencer = (*Preference)(nil)
+
+// Preferencer - per-account notification preferences repository interface
+type Preferencer interface {
+	GetPreference(accountPublicId string) (domain.Preference, error)
+	SavePreference(preference domain.Preference) error
+}
+
+// Preference
+type Preference struct {
+	db *sqlx.DB
+}
+
+// NewPreference - constructor
+func NewPreference(db *sqlx.DB) *Preference {
+	return &Preference{db: db}
+}
+
+// GetPreference - ErrPreferenceNotSet if the account has never changed the defaults
+func (r *Preference) GetPreference(accountPublicId string) (domain.Preference, error) {
+	var preference domain.Preference
+
+	query := fmt.Sprintf(`SELECT account_public_id, locale, email, webhook, webhook_url FROM %s
+		WHERE account_public_id=$1`, preferenceTable)
+	err := r.db.Get(&preference, query, accountPublicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return preference, domain.ErrPreferenceNotSet
+	}
+	if err != nil {
+		return preference, fmt.Errorf("get preference: %w", err)
+	}
+
+	return preference, nil
+}
+
+// SavePreference
+func (r *Preference) SavePreference(preference domain.Preference) error {
+	query := fmt.Sprintf(`INSERT INTO %s (account_public_id, locale, email, webhook, webhook_url) values ($1, $2, $3, $4, $5)
+		ON CONFLICT(account_public_id) DO UPDATE SET locale=excluded.locale, email=excluded.email,
+		webhook=excluded.webhook, webhook_url=excluded.webhook_url`, preferenceTable)
+	_, err := r.db.Exec(query, preference.AccountPublicId, preference.Locale, preference.Email,
+		preference.Webhook, preference.WebhookURL)
+	return err
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+)
+
+var _ Privacier = (*Privacy)(nil)
+
+// Privacier - repository interface
+type Privacier interface {
+	ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error)
+	EraseAccountData(accountPublicId uuid.UUID, now time.Time) error
+}
+
+// Privacy - personal data of the account kept here
+type Privacy struct {
+	db *sqlx.DB
+}
+
+// NewPrivacy - constructor
+func NewPrivacy(db *sqlx.DB) *Privacy {
+	return &Privacy{db: db}
+}
+
+// ExportAccountData - the account copy and the preference are nil if they are not kept
+func (r *Privacy) ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error) {
+	data := domain.AccountData{
+		Notifications: make([]domain.Notification, 0),
+	}
+
+	var account domain.Account
+	query := fmt.Sprintf(`SELECT public_id, name, email, role FROM %s WHERE public_id=$1`, accountTable)
+	err := r.db.Get(&account, query, accountPublicId)
+	switch {
+	case err == nil:
+		data.Account = &account
+	case !errors.Is(err, sql.ErrNoRows):
+		return data, fmt.Errorf("export account: %w", err)
+	}
+
+	var preference domain.Preference
+	query = fmt.Sprintf(`SELECT account_public_id, locale, email, webhook, webhook_url FROM %s
+		WHERE account_public_id=$1`, preferenceTable)
+	err = r.db.Get(&preference, query, accountPublicId)
+	switch {
+	case err == nil:
+		data.Preference = &preference
+	case !errors.Is(err, sql.ErrNoRows):
+		return data, fmt.Errorf("export preference: %w", err)
+	}
+
+	query = fmt.Sprintf(`SELECT * FROM %s WHERE account_public_id=$1 ORDER BY id`, notificationTable)
+	if err := r.db.Select(&data.Notifications, query, accountPublicId); err != nil {
+		return data, fmt.Errorf("export notifications: %w", err)
+	}
+
+	return data, nil
+}
+
+// EraseAccountData - the delivery log is kept for the statistics without the message contents,
+// the pending messages are not sent anymore. The preference and the account copy are removed
+func (r *Privacy) EraseAccountData(accountPublicId uuid.UUID, now time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, last_error='account erased', updated_at=$2
+		WHERE account_public_id=$3 AND status=$4`, notificationTable)
+	if _, err := tx.Exec(query, domain.NOTIFICATION_FAILED, now, accountPublicId,
+		domain.NOTIFICATION_PENDING); err != nil {
+		return fmt.Errorf("erase pending notifications: %w", err)
+	}
+	query = fmt.Sprintf(`UPDATE %s SET subject='', text='', html='', updated_at=$1 WHERE account_public_id=$2`,
+		notificationTable)
+	if _, err := tx.Exec(query, now, accountPublicId); err != nil {
+		return fmt.Errorf("erase notifications: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE account_public_id=$1`, preferenceTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase preference: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, accountTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase account: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivacy(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	customer, other := uuid.New(), uuid.New()
+	assert.NoError(t, repos.CreateAccount(domain.Account{PublicId: customer, Name: "Alex", Email: "alex@mail.com"}))
+	assert.NoError(t, repos.SavePreference(domain.Preference{AccountPublicId: customer, Locale: "en", Email: true}))
+
+	for _, notification := range []domain.Notification{
+		{AccountPublicId: customer, Status: domain.NOTIFICATION_SENT},
+		{AccountPublicId: customer, Status: domain.NOTIFICATION_PENDING},
+		{AccountPublicId: other, Status: domain.NOTIFICATION_PENDING},
+	} {
+		notification.EventType = domain.EVENT_ORDER_DELIVERED
+		notification.Channel = domain.CHANNEL_EMAIL
+		notification.Content = domain.Content{Subject: "Delivered", Text: "Hi Alex"}
+		notification.NextAttemptAt, notification.CreatedAt, notification.UpdatedAt = now, now, now
+		_, err := repos.CreateNotification(notification)
+		assert.NoError(t, err)
+	}
+
+	t.Run("Can export the account copy, the preference and own notifications", func(t *testing.T) {
+		data, err := repos.ExportAccountData(customer)
+		assert.NoError(t, err)
+		assert.Equal(t, "alex@mail.com", data.Account.Email)
+		assert.Equal(t, "en", data.Preference.Locale)
+		assert.Len(t, data.Notifications, 2)
+	})
+
+	t.Run("Can erase the contents and stop the pending notifications", func(t *testing.T) {
+		assert.NoError(t, repos.EraseAccountData(customer, now.Add(time.Hour)))
+
+		data, err := repos.ExportAccountData(customer)
+		assert.NoError(t, err)
+		assert.Nil(t, data.Account)
+		assert.Nil(t, data.Preference)
+		assert.Len(t, data.Notifications, 2)
+		for _, notification := range data.Notifications {
+			assert.Equal(t, domain.Content{}, notification.Content)
+			assert.NotEqual(t, domain.NOTIFICATION_PENDING, notification.Status)
+		}
+
+		due, err := repos.GetDueNotifications(now.Add(time.Hour), 10)
+		assert.NoError(t, err)
+		assert.Len(t, due, 1)
+		assert.Equal(t, other, due[0].AccountPublicId)
+	})
+}
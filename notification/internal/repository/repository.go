@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"fmt"
+
+	_ "github.com/golang/mock/mockgen/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -destination mocks/mock.go -package repository github.com/p12s/furniture-store/notification/internal/repository Accounter,Preferencer,Informer,Privacier
+
+// Repository - repo
+type Repository struct {
+	Accounter
+	Preferencer
+	Informer
+	Privacier
+}
+
+// NewRepository - constructor
+func NewRepository(db *sqlx.DB) *Repository {
+	createSchema(db, accountTable, `CREATE TABLE IF NOT EXISTS account (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"name" TEXT DEFAULT '',
+		"email" TEXT DEFAULT '',
+		"role" INTEGER DEFAULT 0
+	  );`)
+	createSchema(db, revokedSessionTable, `CREATE TABLE IF NOT EXISTS revoked_session (
+		"session_public_id" TEXT NOT NULL PRIMARY KEY,
+		"account_public_id" TEXT NOT NULL,
+		"expires_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, preferenceTable, `CREATE TABLE IF NOT EXISTS preference (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"account_public_id" TEXT NOT NULL UNIQUE,
+		"locale" TEXT NOT NULL,
+		"email" INTEGER NOT NULL,
+		"webhook" INTEGER NOT NULL,
+		"webhook_url" TEXT DEFAULT '' NOT NULL
+	  );`)
+	createSchema(db, notificationTable, `CREATE TABLE IF NOT EXISTS notification (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"account_public_id" TEXT NOT NULL,
+		"event_type" TEXT NOT NULL,
+		"channel" TEXT NOT NULL,
+		"subject" TEXT NOT NULL,
+		"text" TEXT NOT NULL,
+		"html" TEXT NOT NULL,
+		"status" TEXT NOT NULL,
+		"attempts" INTEGER DEFAULT 0 NOT NULL,
+		"last_error" TEXT DEFAULT '' NOT NULL,
+		"next_attempt_at" DATETIME NOT NULL,
+		"created_at" DATETIME NOT NULL,
+		"updated_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, "notification_due index", `CREATE INDEX IF NOT EXISTS notification_due
+		ON notification (status, next_attempt_at);`)
+
+	return &Repository{
+		Accounter:   NewAccount(db),
+		Preferencer: NewPreference(db),
+		Informer:    NewNotification(db),
+		Privacier:   NewPrivacy(db),
+	}
+}
+
+// Deliberately removed the obligation of important fields,
+// because the architecture is asynchronous, the business-event with only role (role)
+// can come before a CUD-event with all other data.
+
+// createSchema - table, index
+func createSchema(db *sqlx.DB, name, query string) {
+	statement, err := db.Prepare(query)
+	if err != nil {
+		logrus.Fatalf("create notification.%s fail: %s", name, err.Error())
+	}
+	defer statement.Close() // nolint
+
+	_, err = statement.Exec()
+	if err != nil {
+		logrus.Fatalf("exec creating notification.%s fail: %s", name, err.Error())
+	}
+
+	fmt.Printf("notification.%s created 🗂\n", name)
+}
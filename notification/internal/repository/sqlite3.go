@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	accountTable        = "account"
+	revokedSessionTable = "revoked_session"
+	preferenceTable     = "preference"
+	notificationTable   = "notification"
+)
+
+// Config - db
+type Config struct {
+	Driver string
+}
+
+// NewSqlite3DB - open connect and ping trying
+func NewSqlite3DB(cfg Config) (*sqlx.DB, error) {
+	db, err := sqlx.Open(cfg.Driver, ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	// every new connection to ":memory:" gets its own empty database,
+	// the consumer and http handlers must share the only one
+	db.SetMaxOpenConns(1)
+
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
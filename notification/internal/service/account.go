@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/p12s/furniture-store/notification/internal/config"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/p12s/furniture-store/notification/internal/repository"
+)
+
+var _ Accounter = (*AccountService)(nil)
+
+// Accounter - service interface
+type Accounter interface {
+	CreateAccount(account domain.Account) error
+	GetAccount(publicId string) (domain.Account, error)
+	UpdateAccountInfo(input domain.UpdateAccountInfoInput) error
+	UpdateAccountRole(input domain.UpdateAccountRoleInput) error
+	DeleteAccount(accountPublicId string) error
+	RevokeSessions(input domain.SessionsRevokedInput) error
+	ParseToken(token string) (string, error)
+}
+
+// AccountService - service
+type AccountService struct {
+	repo       repository.Accounter
+	signingKey string
+}
+
+// NewAccountService - constructor
+func NewAccountService(repo repository.Accounter, config *config.Auth) *AccountService {
+	return &AccountService{
+		repo:       repo,
+		signingKey: config.SigningKey,
+	}
+}
+
+// CreateAccount
+func (s *AccountService) CreateAccount(account domain.Account) error {
+	return s.repo.CreateAccount(account)
+}
+
+// GetAccount
+func (s *AccountService) GetAccount(publicId string) (domain.Account, error) {
+	return s.repo.GetAccount(publicId)
+}
+
+// UpdateAccountInfo
+func (s *AccountService) UpdateAccountInfo(input domain.UpdateAccountInfoInput) error {
+	return s.repo.UpdateAccountInfo(input)
+}
+
+// UpdateAccountRole
+func (s *AccountService) UpdateAccountRole(input domain.UpdateAccountRoleInput) error {
+	return s.repo.UpdateAccountRole(input)
+}
+
+// DeleteAccount
+func (s *AccountService) DeleteAccount(accountPublicId string) error {
+	return s.repo.DeleteAccount(accountPublicId)
+}
+
+// RevokeSessions - the account service revoked the sessions
+func (s *AccountService) RevokeSessions(input domain.SessionsRevokedInput) error {
+	return s.repo.RevokeSessions(input, time.Now().UTC())
+}
+
+// ParseToken - token is issued by the account service for a session, the session must not be revoked.
+// The tokens with an audience (oauth client, email verification) are not the access tokens of the routes
+func (s *AccountService) ParseToken(accessToken string) (string, error) {
+	t, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return []byte(s.signingKey), nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("unexpected signing method: %w/n", err)
+	}
+
+	if !t.Valid {
+		return "", fmt.Errorf("invalid token")
+	}
+
+	claims, ok := t.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", fmt.Errorf("invalid claims")
+	}
+
+	subject, ok := claims["sub"].(string)
+	if !ok {
+		return "", fmt.Errorf("invalid subject")
+	}
+	if _, ok := claims["aud"]; ok {
+		return "", fmt.Errorf("invalid audience")
+	}
+
+	tokenId, ok := claims["jti"].(string)
+	if !ok {
+		return "", fmt.Errorf("invalid session")
+	}
+	revoked, err := s.repo.IsSessionRevoked(tokenId)
+	if err != nil {
+		return "", fmt.Errorf("token session: %w", err)
+	}
+	if revoked {
+		return "", fmt.Errorf("revoked session")
+	}
+
+	return subject, nil
+}
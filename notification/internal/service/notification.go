@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/p12s/furniture-store/notification/internal/config"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/p12s/furniture-store/notification/internal/notifier"
+	"github.com/p12s/furniture-store/notification/internal/render"
+	"github.com/p12s/furniture-store/notification/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	SEND_TIMEOUT      = 30 * time.Second
+	RETRY_BATCH_LIMIT = 100
+)
+
+var _ Informer = (*NotificationService)(nil)
+
+// Informer - service interface
+type Informer interface {
+	Notify(ctx context.Context, eventType domain.EventType, data domain.TemplateData) error
+	RetryDue(ctx context.Context) error
+	RunRetries(ctx context.Context)
+	GetAccountNotifications(accountPublicId string) ([]domain.Notification, error)
+}
+
+// NotificationService - renders the event for the account and sends it to every enabled channel.
+// Every message is logged first, so a failed one is retried later by RunRetries.
+type NotificationService struct {
+	repo          repository.Informer
+	accounts      repository.Accounter
+	preferences   Preferencer
+	renderer      *render.Renderer
+	notifiers     map[domain.Channel]notifier.Notifier
+	maxAttempts   int
+	retryInterval time.Duration
+	retryBackoff  time.Duration
+}
+
+// NewNotificationService - constructor
+func NewNotificationService(repo repository.Informer, accounts repository.Accounter, preferences Preferencer,
+	renderer *render.Renderer, notifiers map[domain.Channel]notifier.Notifier,
+	config *config.Notification) *NotificationService {
+	return &NotificationService{
+		repo:          repo,
+		accounts:      accounts,
+		preferences:   preferences,
+		renderer:      renderer,
+		notifiers:     notifiers,
+		maxAttempts:   config.MaxAttempts,
+		retryInterval: config.RetryInterval,
+		retryBackoff:  config.RetryBackoff,
+	}
+}
+
+// Notify - sending failures are not returned, they are kept in the log and retried
+func (s *NotificationService) Notify(ctx context.Context, eventType domain.EventType, data domain.TemplateData) error {
+	accountPublicId := data.AccountPublicId.String()
+
+	preference, err := s.preferences.GetPreference(accountPublicId)
+	if err != nil {
+		return err
+	}
+	// the account copy can come later, the name is optional in templates
+	if account, err := s.accounts.GetAccount(accountPublicId); err == nil {
+		data.Name = account.Name
+	}
+
+	content, err := s.renderer.Render(preference.Locale, eventType, data)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, channel := range preference.Channels() {
+		notification := domain.Notification{
+			AccountPublicId: data.AccountPublicId,
+			EventType:       eventType,
+			Channel:         channel,
+			Content:         content,
+			Status:          domain.NOTIFICATION_PENDING,
+			NextAttemptAt:   now,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		notification.Id, err = s.repo.CreateNotification(notification)
+		if err != nil {
+			return err
+		}
+
+		if err := s.send(ctx, notification); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RetryDue - one more attempt for every pending notification whose time has come
+func (s *NotificationService) RetryDue(ctx context.Context) error {
+	notifications, err := s.repo.GetDueNotifications(time.Now().UTC(), RETRY_BATCH_LIMIT)
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		if err := s.send(ctx, notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunRetries - retry loop, stops with the context
+func (s *NotificationService) RunRetries(ctx context.Context) {
+	ticker := time.NewTicker(s.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RetryDue(ctx); err != nil {
+				logrus.Errorf("retry notifications fail: %s/n", err.Error())
+			}
+		}
+	}
+}
+
+// GetAccountNotifications
+func (s *NotificationService) GetAccountNotifications(accountPublicId string) ([]domain.Notification, error) {
+	return s.repo.GetAccountNotifications(accountPublicId)
+}
+
+// send - one attempt, its result is saved to the log
+func (s *NotificationService) send(ctx context.Context, notification domain.Notification) error {
+	ctx, cancel := context.WithTimeout(ctx, SEND_TIMEOUT)
+	defer cancel()
+
+	err := s.attempt(ctx, notification)
+
+	now := time.Now().UTC()
+	notification.Attempts++
+	notification.UpdatedAt = now
+	switch {
+	case err == nil:
+		notification.Status = domain.NOTIFICATION_SENT
+		notification.LastError = ""
+	case notification.Attempts >= s.maxAttempts:
+		notification.Status = domain.NOTIFICATION_FAILED
+		notification.LastError = err.Error()
+	default:
+		notification.Status = domain.NOTIFICATION_PENDING
+		notification.LastError = err.Error()
+		notification.NextAttemptAt = now.Add(s.retryBackoff * time.Duration(notification.Attempts))
+	}
+
+	if err := s.repo.UpdateNotification(notification); err != nil {
+		return fmt.Errorf("update notification: %w", err)
+	}
+	return nil
+}
+
+func (s *NotificationService) attempt(ctx context.Context, notification domain.Notification) error {
+	channel, ok := s.notifiers[notification.Channel]
+	if !ok {
+		return domain.ErrUnknownChannel
+	}
+
+	recipient, err := s.recipient(notification)
+	if err != nil {
+		return err
+	}
+
+	return channel.Notify(ctx, recipient, notification.Content)
+}
+
+// recipient - address is taken at sending time, so a retry uses the updated email or url
+func (s *NotificationService) recipient(notification domain.Notification) (string, error) {
+	accountPublicId := notification.AccountPublicId.String()
+
+	switch notification.Channel {
+	case domain.CHANNEL_EMAIL:
+		account, err := s.accounts.GetAccount(accountPublicId)
+		if err != nil || account.Email == "" {
+			return "", domain.ErrNoRecipient
+		}
+		return account.Email, nil
+	case domain.CHANNEL_WEBHOOK:
+		preference, err := s.preferences.GetPreference(accountPublicId)
+		if err != nil {
+			return "", err
+		}
+		if preference.WebhookURL == "" {
+			return "", domain.ErrNoRecipient
+		}
+		return preference.WebhookURL, nil
+	}
+
+	return "", domain.ErrUnknownChannel
+}
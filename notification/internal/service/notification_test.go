@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/notification/internal/config"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/p12s/furniture-store/notification/internal/notifier"
+	"github.com/p12s/furniture-store/notification/internal/render"
+	mock_repository "github.com/p12s/furniture-store/notification/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// channelStub - records recipients, fails while err is set
+type channelStub struct {
+	recipients []string
+	err        error
+}
+
+func (c *channelStub) Notify(ctx context.Context, recipient string, content domain.Content) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.recipients = append(c.recipients, recipient)
+	return nil
+}
+
+func TestNotificationService_Notify(t *testing.T) {
+	accountPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	data := domain.TemplateData{
+		AccountPublicId: accountPublicId,
+		OrderPublicId:   uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"),
+		CourierName:     "Petr",
+		CourierEmail:    "petr@test.ru",
+	}
+	account := domain.Account{PublicId: accountPublicId, Name: "Ivan", Email: "ivan@test.ru"}
+
+	type mockBehavior func(n *mock_repository.MockInformer, a *mock_repository.MockAccounter, p *mock_repository.MockPreferencer)
+
+	tests := []struct {
+		name               string
+		mockBehavior       mockBehavior
+		channelErr         error
+		expectedRecipients []string
+		expectedStatuses   []domain.NotificationStatus
+	}{
+		{
+			name: "Can send email by default preferences",
+			mockBehavior: func(n *mock_repository.MockInformer, a *mock_repository.MockAccounter, p *mock_repository.MockPreferencer) {
+				p.EXPECT().GetPreference(accountPublicId.String()).Return(domain.Preference{}, domain.ErrPreferenceNotSet)
+				a.EXPECT().GetAccount(accountPublicId.String()).Return(account, nil).Times(2)
+				n.EXPECT().CreateNotification(gomock.Any()).DoAndReturn(func(notification domain.Notification) (int, error) {
+					assert.Equal(t, domain.CHANNEL_EMAIL, notification.Channel)
+					assert.Contains(t, notification.Text, "Ivan")
+					assert.Contains(t, notification.Text, "petr@test.ru")
+					return 1, nil
+				})
+			},
+			expectedRecipients: []string{"ivan@test.ru"},
+			expectedStatuses:   []domain.NotificationStatus{domain.NOTIFICATION_SENT},
+		},
+		{
+			name: "Can send to every enabled channel",
+			mockBehavior: func(n *mock_repository.MockInformer, a *mock_repository.MockAccounter, p *mock_repository.MockPreferencer) {
+				p.EXPECT().GetPreference(accountPublicId.String()).Return(domain.Preference{
+					AccountPublicId: accountPublicId,
+					Locale:          "ru",
+					Email:           true,
+					Webhook:         true,
+					WebhookURL:      "http://hook.test/notify",
+				}, nil).Times(2)
+				a.EXPECT().GetAccount(accountPublicId.String()).Return(account, nil).Times(2)
+				n.EXPECT().CreateNotification(gomock.Any()).DoAndReturn(func(notification domain.Notification) (int, error) {
+					assert.Contains(t, notification.Subject, "в пути")
+					return 1, nil
+				}).Times(2)
+			},
+			expectedRecipients: []string{"ivan@test.ru", "http://hook.test/notify"},
+			expectedStatuses:   []domain.NotificationStatus{domain.NOTIFICATION_SENT, domain.NOTIFICATION_SENT},
+		},
+		{
+			name: "Can keep notification for retry if the account email is unknown yet",
+			mockBehavior: func(n *mock_repository.MockInformer, a *mock_repository.MockAccounter, p *mock_repository.MockPreferencer) {
+				p.EXPECT().GetPreference(accountPublicId.String()).Return(domain.Preference{}, domain.ErrPreferenceNotSet)
+				a.EXPECT().GetAccount(accountPublicId.String()).Return(domain.Account{}, errors.New("not found")).Times(2)
+				n.EXPECT().CreateNotification(gomock.Any()).Return(1, nil)
+			},
+			expectedStatuses: []domain.NotificationStatus{domain.NOTIFICATION_PENDING},
+		},
+		{
+			name: "Can keep notification for retry if the channel fails",
+			mockBehavior: func(n *mock_repository.MockInformer, a *mock_repository.MockAccounter, p *mock_repository.MockPreferencer) {
+				p.EXPECT().GetPreference(accountPublicId.String()).Return(domain.Preference{}, domain.ErrPreferenceNotSet)
+				a.EXPECT().GetAccount(accountPublicId.String()).Return(account, nil).Times(2)
+				n.EXPECT().CreateNotification(gomock.Any()).Return(1, nil)
+			},
+			channelErr:       errors.New("smtp is down"),
+			expectedStatuses: []domain.NotificationStatus{domain.NOTIFICATION_PENDING},
+		},
+	}
+
+	renderer, err := render.NewRenderer("en")
+	assert.NoError(t, err)
+	conf := &config.Notification{DefaultLocale: "en", MaxAttempts: 3, RetryBackoff: time.Minute}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			informer := mock_repository.NewMockInformer(ctrl)
+			accounter := mock_repository.NewMockAccounter(ctrl)
+			preferencer := mock_repository.NewMockPreferencer(ctrl)
+			tt.mockBehavior(informer, accounter, preferencer)
+
+			var statuses []domain.NotificationStatus
+			informer.EXPECT().UpdateNotification(gomock.Any()).DoAndReturn(func(notification domain.Notification) error {
+				assert.Equal(t, 1, notification.Attempts)
+				if notification.Status == domain.NOTIFICATION_PENDING {
+					assert.NotEmpty(t, notification.LastError)
+					assert.True(t, notification.NextAttemptAt.After(time.Now().UTC()))
+				}
+				statuses = append(statuses, notification.Status)
+				return nil
+			}).AnyTimes()
+
+			channel := &channelStub{err: tt.channelErr}
+			s := NewNotificationService(informer, accounter, NewPreferenceService(preferencer, renderer, conf, &config.Webhook{}), renderer,
+				map[domain.Channel]notifier.Notifier{domain.CHANNEL_EMAIL: channel, domain.CHANNEL_WEBHOOK: channel}, conf)
+
+			err := s.Notify(context.Background(), domain.EVENT_ORDER_TAKED_TO_DELIVER, data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRecipients, channel.recipients)
+			assert.Equal(t, tt.expectedStatuses, statuses)
+		})
+	}
+}
+
+func TestNotificationService_RetryDue(t *testing.T) {
+	accountPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+
+	tests := []struct {
+		name           string
+		attempts       int
+		channelErr     error
+		expectedStatus domain.NotificationStatus
+	}{
+		{name: "Can send notification on retry", attempts: 1, expectedStatus: domain.NOTIFICATION_SENT},
+		{name: "Can retry later while attempts are left", attempts: 1, channelErr: errors.New("smtp is down"),
+			expectedStatus: domain.NOTIFICATION_PENDING},
+		{name: "Can give up when attempts are over", attempts: 2, channelErr: errors.New("smtp is down"),
+			expectedStatus: domain.NOTIFICATION_FAILED},
+	}
+
+	renderer, err := render.NewRenderer("en")
+	assert.NoError(t, err)
+	conf := &config.Notification{DefaultLocale: "en", MaxAttempts: 3, RetryBackoff: time.Minute}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			informer := mock_repository.NewMockInformer(ctrl)
+			accounter := mock_repository.NewMockAccounter(ctrl)
+			informer.EXPECT().GetDueNotifications(gomock.Any(), RETRY_BATCH_LIMIT).Return([]domain.Notification{{
+				Id:              7,
+				AccountPublicId: accountPublicId,
+				Channel:         domain.CHANNEL_EMAIL,
+				Status:          domain.NOTIFICATION_PENDING,
+				Attempts:        tt.attempts,
+			}}, nil)
+			accounter.EXPECT().GetAccount(accountPublicId.String()).Return(domain.Account{Email: "ivan@test.ru"}, nil)
+			informer.EXPECT().UpdateNotification(gomock.Any()).DoAndReturn(func(notification domain.Notification) error {
+				assert.Equal(t, 7, notification.Id)
+				assert.Equal(t, tt.attempts+1, notification.Attempts)
+				assert.Equal(t, tt.expectedStatus, notification.Status)
+				return nil
+			})
+
+			s := NewNotificationService(informer, accounter,
+				NewPreferenceService(mock_repository.NewMockPreferencer(ctrl), renderer, conf, &config.Webhook{}), renderer,
+				map[domain.Channel]notifier.Notifier{domain.CHANNEL_EMAIL: &channelStub{err: tt.channelErr}}, conf)
+
+			assert.NoError(t, s.RetryDue(context.Background()))
+		})
+	}
+}
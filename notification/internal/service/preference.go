@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/notification/internal/config"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/p12s/furniture-store/notification/internal/notifier/webhook"
+	"github.com/p12s/furniture-store/notification/internal/render"
+	"github.com/p12s/furniture-store/notification/internal/repository"
+)
+
+const (
+	// WEBHOOK_CHECK_TIMEOUT - the webhook host resolving on save
+	WEBHOOK_CHECK_TIMEOUT = 5 * time.Second
+)
+
+var _ Preferencer = (*PreferenceService)(nil)
+
+// Preferencer - service interface
+type Preferencer interface {
+	GetPreference(accountPublicId string) (domain.Preference, error)
+	UpdatePreference(accountPublicId string, input domain.UpdatePreferenceInput) (domain.Preference, error)
+}
+
+// PreferenceService - service
+type PreferenceService struct {
+	repo          repository.Preferencer
+	renderer      *render.Renderer
+	defaultLocale string
+	allowPrivate  bool
+}
+
+// NewPreferenceService - constructor
+func NewPreferenceService(repo repository.Preferencer, renderer *render.Renderer,
+	config *config.Notification, webhookConfig *config.Webhook) *PreferenceService {
+	return &PreferenceService{
+		repo:          repo,
+		renderer:      renderer,
+		defaultLocale: config.DefaultLocale,
+		allowPrivate:  webhookConfig.AllowPrivate,
+	}
+}
+
+// GetPreference - email in the default locale until the account changes it
+func (s *PreferenceService) GetPreference(accountPublicId string) (domain.Preference, error) {
+	preference, err := s.repo.GetPreference(accountPublicId)
+	if errors.Is(err, domain.ErrPreferenceNotSet) {
+		publicId, _ := uuid.Parse(accountPublicId) // nolint
+		return domain.Preference{
+			AccountPublicId: publicId,
+			Locale:          s.defaultLocale,
+			Email:           true,
+		}, nil
+	}
+	return preference, err
+}
+
+// UpdatePreference - the webhook url is checked again on every sending
+func (s *PreferenceService) UpdatePreference(accountPublicId string,
+	input domain.UpdatePreferenceInput) (domain.Preference, error) {
+	preference, err := s.GetPreference(accountPublicId)
+	if err != nil {
+		return preference, err
+	}
+
+	if input.Locale != nil {
+		preference.Locale = *input.Locale
+	}
+	if input.Email != nil {
+		preference.Email = *input.Email
+	}
+	if input.Webhook != nil {
+		preference.Webhook = *input.Webhook
+	}
+	if input.WebhookURL != nil {
+		preference.WebhookURL = *input.WebhookURL
+	}
+
+	if !s.renderer.HasLocale(preference.Locale) {
+		return preference, domain.ErrUnknownLocale
+	}
+	if preference.Webhook || preference.WebhookURL != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), WEBHOOK_CHECK_TIMEOUT)
+		defer cancel()
+		if err := webhook.CheckURL(ctx, preference.WebhookURL, s.allowPrivate); err != nil {
+			return preference, err
+		}
+	}
+
+	return preference, s.repo.SavePreference(preference)
+}
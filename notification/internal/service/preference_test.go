@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/notification/internal/config"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/p12s/furniture-store/notification/internal/render"
+	mock_repository "github.com/p12s/furniture-store/notification/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreferenceService_UpdatePreference(t *testing.T) {
+	accountPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	renderer, err := render.NewRenderer("en")
+	assert.NoError(t, err)
+	conf := &config.Notification{DefaultLocale: "en"}
+	enabled := true
+
+	type mockBehavior func(r *mock_repository.MockPreferencer)
+
+	tests := []struct {
+		name         string
+		webhookURL   string
+		allowPrivate bool
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name:       "Can save the public webhook url",
+			webhookURL: "https://93.184.216.34/hook",
+			mockBehavior: func(r *mock_repository.MockPreferencer) {
+				r.EXPECT().GetPreference(accountPublicId.String()).Return(domain.Preference{}, domain.ErrPreferenceNotSet)
+				r.EXPECT().SavePreference(domain.Preference{AccountPublicId: accountPublicId, Locale: "en", Email: true,
+					Webhook: true, WebhookURL: "https://93.184.216.34/hook"}).Return(nil)
+			},
+		},
+		{
+			name:         "Can save the loopback webhook url for the local setup",
+			webhookURL:   "http://127.0.0.1:8080/hook",
+			allowPrivate: true,
+			mockBehavior: func(r *mock_repository.MockPreferencer) {
+				r.EXPECT().GetPreference(accountPublicId.String()).Return(domain.Preference{}, domain.ErrPreferenceNotSet)
+				r.EXPECT().SavePreference(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:       "Can't save the loopback webhook url",
+			webhookURL: "http://127.0.0.1:8080/hook",
+			mockBehavior: func(r *mock_repository.MockPreferencer) {
+				r.EXPECT().GetPreference(accountPublicId.String()).Return(domain.Preference{}, domain.ErrPreferenceNotSet)
+			},
+			wantErr: domain.ErrWebhookURL,
+		},
+		{
+			name:       "Can't save the cloud metadata webhook url",
+			webhookURL: "http://169.254.169.254/latest/meta-data",
+			mockBehavior: func(r *mock_repository.MockPreferencer) {
+				r.EXPECT().GetPreference(accountPublicId.String()).Return(domain.Preference{}, domain.ErrPreferenceNotSet)
+			},
+			wantErr: domain.ErrWebhookURL,
+		},
+		{
+			name:       "Can't save the private network webhook url",
+			webhookURL: "http://192.168.1.10/hook",
+			mockBehavior: func(r *mock_repository.MockPreferencer) {
+				r.EXPECT().GetPreference(accountPublicId.String()).Return(domain.Preference{}, domain.ErrPreferenceNotSet)
+			},
+			wantErr: domain.ErrWebhookURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockPreferencer(ctrl)
+			tt.mockBehavior(repo)
+
+			webhookURL := tt.webhookURL
+			s := NewPreferenceService(repo, renderer, conf, &config.Webhook{AllowPrivate: tt.allowPrivate})
+			_, err := s.UpdatePreference(accountPublicId.String(),
+				domain.UpdatePreferenceInput{Webhook: &enabled, WebhookURL: &webhookURL})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
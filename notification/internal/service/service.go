@@ -0,0 +1,34 @@
+package service
+
+import (
+	_ "github.com/golang/mock/mockgen/model"
+
+	"github.com/p12s/furniture-store/notification/internal/config"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/p12s/furniture-store/notification/internal/notifier"
+	"github.com/p12s/furniture-store/notification/internal/render"
+	"github.com/p12s/furniture-store/notification/internal/repository"
+)
+
+//go:generate mockgen -destination mocks/mock.go -package service github.com/p12s/furniture-store/notification/internal/service Accounter,Preferencer,Informer,Privacier
+
+// Service - just service
+type Service struct {
+	Accounter
+	Preferencer
+	Informer
+	Privacier
+}
+
+// NewService - constructor
+func NewService(repos *repository.Repository, renderer *render.Renderer, notifiers map[domain.Channel]notifier.Notifier,
+	auth *config.Auth, notification *config.Notification, webhook *config.Webhook) *Service {
+	preferences := NewPreferenceService(repos.Preferencer, renderer, notification, webhook)
+
+	return &Service{
+		Accounter:   NewAccountService(repos.Accounter, auth),
+		Preferencer: preferences,
+		Informer:    NewNotificationService(repos.Informer, repos.Accounter, preferences, renderer, notifiers, notification),
+		Privacier:   NewPrivacyService(repos.Privacier),
+	}
+}
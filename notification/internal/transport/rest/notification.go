@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+)
+
+// @Summary Get notifications
+// @Tags Notification
+// @Description Caller notification log with the sending status, the latest first
+// @ID getNotifications
+// @Produce  json
+// @Success 200
+// @Router /notifications [get]
+func (h *Handler) getNotifications(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	notifications, err := h.services.GetAccountNotifications(accountPublicId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// @Summary Get notification preferences
+// @Tags Notification
+// @ID getPreference
+// @Produce  json
+// @Success 200
+// @Router /notifications/preferences [get]
+func (h *Handler) getPreference(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	preference, err := h.services.GetPreference(accountPublicId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, preference)
+}
+
+// @Summary Update notification preferences
+// @Tags Notification
+// @Description Channels and language, not set fields are kept
+// @ID updatePreference
+// @Accept  json
+// @Produce  json
+// @Param input body domain.UpdatePreferenceInput true "preferences"
+// @Success 200
+// @Router /notifications/preferences [put]
+func (h *Handler) updatePreference(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	var input domain.UpdatePreferenceInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	preference, err := h.services.UpdatePreference(accountPublicId, input)
+	switch {
+	case errors.Is(err, domain.ErrUnknownLocale):
+		newErrorResponse(c, http.StatusBadRequest, "unknown locale")
+		return
+	case errors.Is(err, domain.ErrWebhookURL):
+		newErrorResponse(c, http.StatusBadRequest, "invalid webhook url")
+		return
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, preference)
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/notification/internal/broker"
+	"github.com/p12s/furniture-store/notification/internal/domain"
+	"github.com/p12s/furniture-store/notification/internal/service"
+	mock_service "github.com/p12s/furniture-store/notification/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_updatePreference(t *testing.T) {
+	type preferenceMockBehavior func(s *mock_service.MockPreferencer)
+
+	accountPublicId := "8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1"
+	webhookURL := "https://93.184.216.34/hook"
+	privateURL := "http://10.0.0.5/hook"
+	enabled := true
+
+	tests := []struct {
+		name                   string
+		inputBody              string
+		preferenceMockBehavior preferenceMockBehavior
+		expectedStatusCode     int
+		expectedRequestBody    string
+	}{
+		{
+			name:      "Can enable the webhook channel",
+			inputBody: `{"webhook":true,"webhook_url":"https://93.184.216.34/hook"}`,
+			preferenceMockBehavior: func(s *mock_service.MockPreferencer) {
+				s.EXPECT().UpdatePreference(accountPublicId, domain.UpdatePreferenceInput{Webhook: &enabled,
+					WebhookURL: &webhookURL}).Return(domain.Preference{AccountPublicId: uuid.MustParse(accountPublicId),
+					Locale: "en", Email: true, Webhook: true, WebhookURL: webhookURL}, nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `{"account_public_id":"8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1","locale":"en","email":true,"webhook":true,"webhook_url":"https://93.184.216.34/hook"}`,
+		},
+		{
+			name:      "Can't enable the webhook to the private network",
+			inputBody: `{"webhook":true,"webhook_url":"http://10.0.0.5/hook"}`,
+			preferenceMockBehavior: func(s *mock_service.MockPreferencer) {
+				s.EXPECT().UpdatePreference(accountPublicId, domain.UpdatePreferenceInput{Webhook: &enabled,
+					WebhookURL: &privateURL}).Return(domain.Preference{}, domain.ErrWebhookURL)
+			},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"invalid webhook url"}`,
+		},
+		{
+			name:      "Can't set unknown locale",
+			inputBody: `{"locale":"xx"}`,
+			preferenceMockBehavior: func(s *mock_service.MockPreferencer) {
+				s.EXPECT().UpdatePreference(accountPublicId, gomock.Any()).Return(domain.Preference{}, domain.ErrUnknownLocale)
+			},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedRequestBody: `{"message":"unknown locale"}`,
+		},
+		{
+			name:                   "Can't update with invalid body",
+			inputBody:              `{"webhook":"yes"}`,
+			preferenceMockBehavior: func(s *mock_service.MockPreferencer) {},
+			expectedStatusCode:     http.StatusBadRequest,
+			expectedRequestBody:    `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Can return error response if service failure",
+			inputBody: `{"email":true}`,
+			preferenceMockBehavior: func(s *mock_service.MockPreferencer) {
+				s.EXPECT().UpdatePreference(accountPublicId, gomock.Any()).Return(domain.Preference{}, errors.New(""))
+			},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			preferences := mock_service.NewMockPreferencer(ctrl)
+			tt.preferenceMockBehavior(preferences)
+
+			handler := NewHandler(&service.Service{Preferencer: preferences}, &broker.Broker{})
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.PUT("/notifications/preferences", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.updatePreference)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/notifications/preferences", bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getNotifications(t *testing.T) {
+	accountPublicId := "8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1"
+
+	tests := []struct {
+		name                string
+		headerValue         string
+		mockBehavior        func(a *mock_service.MockAccounter, i *mock_service.MockInformer)
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:        "Can get own notifications",
+			headerValue: "Bearer token",
+			mockBehavior: func(a *mock_service.MockAccounter, i *mock_service.MockInformer) {
+				a.EXPECT().ParseToken("token").Return(accountPublicId, nil)
+				i.EXPECT().GetAccountNotifications(accountPublicId).Return([]domain.Notification{}, nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedRequestBody: `[]`,
+		},
+		{
+			name:        "Can't get notifications with invalid token",
+			headerValue: "Bearer token",
+			mockBehavior: func(a *mock_service.MockAccounter, i *mock_service.MockInformer) {
+				a.EXPECT().ParseToken("token").Return("", errors.New("invalid token"))
+			},
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedRequestBody: `{"message":"invalid token"}`,
+		},
+		{
+			name:                "Can't get notifications without token",
+			mockBehavior:        func(a *mock_service.MockAccounter, i *mock_service.MockInformer) {},
+			expectedStatusCode:  http.StatusUnauthorized,
+			expectedRequestBody: `{"message":"empty auth header"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accounts := mock_service.NewMockAccounter(ctrl)
+			informer := mock_service.NewMockInformer(ctrl)
+			tt.mockBehavior(accounts, informer)
+
+			handler := NewHandler(&service.Service{Accounter: accounts, Informer: informer}, &broker.Broker{})
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/notifications", handler.userIdentity, handler.getNotifications)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/notifications", nil)
+			if tt.headerValue != "" {
+				req.Header.Set(authorizationHandler, tt.headerValue)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
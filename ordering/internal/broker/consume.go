@@ -0,0 +1,341 @@
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	_ "github.com/golang/mock/mockgen/model"
+	"github.com/p12s/furniture-store/ordering/internal/config"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	"github.com/p12s/furniture-store/ordering/internal/service"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	AUTO_OFFSET_RESET = "earliest"
+)
+
+var _ Consumer = (*BrokerConsume)(nil)
+
+type Consumer interface {
+	Subscribe() error
+	ProcessEvent(event domain.Event)
+}
+
+type BrokerConsume struct {
+	connection                        *kafka.Consumer
+	service                           *service.Service
+	producer                          Producer
+	TopicAccountBE, TopicAccountCUD   string
+	TopicProductBE, TopicProductCUD   string
+	TopicOrderBE, TopicOrderCUD       string
+	TopicDeliveryBE, TopicDeliveryCUD string
+	TopicBillingBE, TopicBillingCUD   string
+	TopicPrivacy                      string
+}
+
+func NewConsumer(service *service.Service, producer Producer, conf *config.Broker) (*BrokerConsume, error) {
+	connection, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"metadata.broker.list": conf.Brokers,
+		"security.protocol":    SECURITY_PROTOCOL,
+		"sasl.mechanisms":      SASL_MECHANISMS,
+		"sasl.username":        conf.Username,
+		"sasl.password":        conf.Password,
+		"group.id":             conf.GroupId,
+		"auto.offset.reset":    AUTO_OFFSET_RESET,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create kafka consumer fail: %w", err)
+	}
+
+	return &BrokerConsume{
+		connection:       connection,
+		service:          service,
+		producer:         producer,
+		TopicAccountBE:   conf.TopicAccountBE,
+		TopicAccountCUD:  conf.TopicAccountCUD,
+		TopicProductBE:   conf.TopicProductBE,
+		TopicProductCUD:  conf.TopicProductCUD,
+		TopicOrderBE:     conf.TopicOrderBE,
+		TopicOrderCUD:    conf.TopicOrderCUD,
+		TopicDeliveryBE:  conf.TopicDeliveryBE,
+		TopicDeliveryCUD: conf.TopicDeliveryCUD,
+		TopicBillingBE:   conf.TopicBillingBE,
+		TopicBillingCUD:  conf.TopicBillingCUD,
+		TopicPrivacy:     conf.TopicPrivacy,
+	}, nil
+}
+
+func (k *BrokerConsume) Subscribe() error {
+	sigchan := make(chan os.Signal, 1)
+	signal.Notify(sigchan, syscall.SIGINT, syscall.SIGTERM)
+
+	err := k.connection.SubscribeTopics([]string{
+		k.TopicAccountBE, k.TopicAccountCUD,
+		k.TopicProductCUD,
+		k.TopicOrderBE, k.TopicOrderCUD,
+		k.TopicDeliveryBE, k.TopicDeliveryCUD,
+		k.TopicBillingBE, k.TopicBillingCUD,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("subscribe broker topics fail: %w", err)
+	}
+
+	run := true
+	for run == true { // nolint
+		select {
+		case sig := <-sigchan:
+			logrus.Printf("Caught signal %v: terminating\n", sig)
+			run = false
+		default:
+			ev, err := k.connection.ReadMessage(1 * time.Second)
+			if err != nil {
+				continue
+			}
+			var eventData domain.Event
+			err = json.Unmarshal(ev.Value, &eventData)
+			if err != nil {
+				logrus.Errorf("Unmarshal error: %s\n", err.Error())
+				continue
+			}
+			k.ProcessEvent(eventData)
+		}
+	}
+
+	logrus.Println("closing consumer")
+	err = k.connection.Close()
+	if err != nil {
+		return fmt.Errorf("closing consumer fail: %w", err)
+	}
+	return nil
+}
+
+func (k *BrokerConsume) ProcessEvent(event domain.Event) {
+	switch event.Type {
+	case domain.EVENT_ACCOUNT_CREATED:
+		err := k.createAccount(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'create account' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_ROLE_UPDATED:
+		err := k.updateAccountRole(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'update account role' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_DELETED:
+		err := k.deleteAccount(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'delete account' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_SESSIONS_REVOKED:
+		err := k.revokeSessions(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'revoke sessions' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_EXPORT_REQUESTED:
+		err := k.exportAccountData(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'export account data' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_ERASURE_REQUESTED:
+		err := k.eraseAccountData(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'erase account data' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_PRODUCT_CREATED, domain.EVENT_PRODUCT_UPDATED:
+		err := k.saveProduct(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'save product' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_PRODUCT_DELETED:
+		err := k.deleteProduct(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'delete product' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ORDER_CHECKED_OUT:
+		err := k.orderCheckedOut(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'order checked out' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_BILLING_PAYMENT_RECEIVED:
+		err := k.billingChanged(event.Value, domain.ORDER_PAYED)
+		if err != nil {
+			logrus.Errorf("process 'payment received' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_BILLING_PAYMENT_DECLINED:
+		err := k.billingChanged(event.Value, domain.ORDER_PAYMENT_DECLINED)
+		if err != nil {
+			logrus.Errorf("process 'payment declined' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_BILLING_REFUND_ISSUED:
+		err := k.billingChanged(event.Value, domain.ORDER_REFUNDED)
+		if err != nil {
+			logrus.Errorf("process 'refund issued' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ORDER_TAKED_TO_DELIVER:
+		err := k.deliveryChanged(event.Value, domain.ORDER_TAKED_TO_DELIVER)
+		if err != nil {
+			logrus.Errorf("process 'order taked to deliver' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ORDER_DELIVERED:
+		err := k.deliveryChanged(event.Value, domain.ORDER_DELIVERED)
+		if err != nil {
+			logrus.Errorf("process 'order delivered' event fail: %s/n", err.Error())
+		}
+	default:
+		fmt.Printf("unknown event type: %v/n", event.Value)
+	}
+}
+
+func (k *BrokerConsume) createAccount(payload interface{}) error {
+	var account domain.Account
+	err := readPayload(payload, &account)
+	if err != nil {
+		return fmt.Errorf("account-create payload fail: %w/n", err)
+	}
+
+	return k.service.CreateAccount(account)
+}
+
+func (k *BrokerConsume) updateAccountRole(payload interface{}) error {
+	var data domain.UpdateAccountRoleInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("account-role update payload fail: %w/n", err)
+	}
+
+	return k.service.UpdateAccountRole(data)
+}
+
+func (k *BrokerConsume) deleteAccount(payload interface{}) error {
+	var data domain.DeleteAccountInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("delete-account payload fail: %w/n", err)
+	}
+
+	return k.service.DeleteAccount(data.PublicId)
+}
+
+func (k *BrokerConsume) revokeSessions(payload interface{}) error {
+	var data domain.SessionsRevokedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("revoke-sessions payload fail: %w/n", err)
+	}
+
+	return k.service.RevokeSessions(data)
+}
+
+// exportAccountData - the answer goes to the privacy topic, only the account service reads it
+func (k *BrokerConsume) exportAccountData(payload interface{}) error {
+	var data domain.PrivacyRequestedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("export-account-data payload fail: %w/n", err)
+	}
+
+	answer, err := k.service.ExportAccountData(data)
+	if err != nil {
+		return err
+	}
+	return k.producer.Produce(domain.EVENT_PRIVACY_DATA_EXPORTED, k.TopicPrivacy, answer)
+}
+
+func (k *BrokerConsume) eraseAccountData(payload interface{}) error {
+	var data domain.PrivacyRequestedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("erase-account-data payload fail: %w/n", err)
+	}
+
+	answer, err := k.service.EraseAccountData(data)
+	if err != nil {
+		return err
+	}
+	return k.producer.Produce(domain.EVENT_PRIVACY_ERASURE_COMPLETED, k.TopicPrivacy, answer)
+}
+
+func (k *BrokerConsume) saveProduct(payload interface{}) error {
+	var product domain.Product
+	err := readPayload(payload, &product)
+	if err != nil {
+		return fmt.Errorf("product-save payload fail: %w/n", err)
+	}
+
+	return k.service.SaveProduct(product)
+}
+
+func (k *BrokerConsume) deleteProduct(payload interface{}) error {
+	var data domain.DeleteProductInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("product-delete payload fail: %w/n", err)
+	}
+
+	return k.service.DeleteProduct(data.PublicId)
+}
+
+func (k *BrokerConsume) orderCheckedOut(payload interface{}) error {
+	var order domain.OrderEvent
+	err := readPayload(payload, &order)
+	if err != nil {
+		return fmt.Errorf("order-checked-out payload fail: %w/n", err)
+	}
+
+	return k.service.ChangeStatus(domain.StatusChange{
+		OrderPublicId:   order.PublicId,
+		AccountPublicId: order.AccountPublicId,
+		Status:          domain.ORDER_CHECKED_OUT,
+		CreatedAt:       time.Now().UTC(),
+	})
+}
+
+func (k *BrokerConsume) billingChanged(payload interface{}, status domain.OrderStatus) error {
+	var data domain.BillingEvent
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("billing payload fail: %w/n", err)
+	}
+
+	return k.service.ChangeStatus(domain.StatusChange{
+		OrderPublicId:   data.OrderPublicId,
+		AccountPublicId: data.AccountPublicId,
+		Status:          status,
+		CreatedAt:       time.Now().UTC(),
+	})
+}
+
+func (k *BrokerConsume) deliveryChanged(payload interface{}, status domain.OrderStatus) error {
+	var delivery domain.DeliveryEvent
+	err := readPayload(payload, &delivery)
+	if err != nil {
+		return fmt.Errorf("delivery payload fail: %w/n", err)
+	}
+
+	return k.service.ChangeStatus(domain.StatusChange{
+		OrderPublicId:   delivery.OrderPublicId,
+		AccountPublicId: delivery.CustomerPublicId,
+		Status:          status,
+		CreatedAt:       time.Now().UTC(),
+	})
+}
+
+func readPayload(payload interface{}, target interface{}) error {
+	jsonString, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling event value to json string fail: %w", err)
+	}
+
+	err = json.Unmarshal(jsonString, &target)
+	if err != nil {
+		return fmt.Errorf("unmarshaling event value to []byte fail: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role
+type Role int
+
+const (
+	ROLE_CUSTOMER Role = iota
+	ROLE_ADMIN
+	ROLE_DELIVERY
+	ROLE_DEALER
+)
+
+// Account - copy, "reduced version" of the Auth domain
+type Account struct {
+	PublicId uuid.UUID `json:"public_id" db:"public_id"`
+	Role     Role      `json:"role" db:"role"`
+}
+
+// UpdateAccountRoleInput
+type UpdateAccountRoleInput struct {
+	PublicId uuid.UUID `json:"public_id" db:"public_id" binding:"required"`
+	Role     Role      `json:"role" db:"role" binding:"required"`
+}
+
+// DeleteAccountInput
+type DeleteAccountInput struct {
+	PublicId string `json:"public_id" db:"public_id" binding:"required"`
+}
+
+// SessionsRevokedInput - auth.sessions_revoked payload, the access tokens of the sessions
+// are not accepted until they expire
+type SessionsRevokedInput struct {
+	PublicId   string    `json:"public_id" binding:"required"`
+	SessionIds []string  `json:"session_ids" binding:"required"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// StreamTicket - short-lived ticket of the order status stream only.
+// Browser EventSource can't set the auth header, the ticket goes as the query param instead of the session token
+type StreamTicket struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// StreamSession - account and session of the stream ticket, the stream is closed when the session is revoked
+type StreamSession struct {
+	AccountPublicId string
+	SessionId       string
+}
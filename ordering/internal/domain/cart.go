@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrProductNotFound  = errors.New("product not found")
+	ErrCartItemNotFound = errors.New("cart item not found")
+	ErrCartEmpty        = errors.New("cart is empty")
+	ErrInvalidVariant   = errors.New("invalid variant")
+)
+
+// EffectivePrice - price with the discount, rounded down to the minor unit as the product service does
+func EffectivePrice(price, discount int64) int64 {
+	return price * (100 - discount) / 100
+}
+
+// Product - copy, "reduced version" of the Product domain, only what a cart line needs.
+// Price is kept in minor currency units (kopecks), discount in percents.
+// A variant has its own copy with the parent product
+type Product struct {
+	PublicId       uuid.UUID     `json:"public_id" db:"public_id"`
+	DealerPublicId uuid.UUID     `json:"dealer_public_id" db:"dealer_public_id"`
+	Price          int64         `json:"price" db:"price"`
+	Discount       int64         `json:"discount" db:"discount"`
+	ParentPublicId uuid.NullUUID `json:"parent_public_id" db:"parent_public_id"`
+}
+
+// IsVariantOf - the variant copy of the parent product
+func (p Product) IsVariantOf(parentPublicId uuid.UUID) bool {
+	return p.ParentPublicId.Valid && p.ParentPublicId.UUID == parentPublicId
+}
+
+// DeleteProductInput - Product.Deleted payload
+type DeleteProductInput struct {
+	PublicId uuid.UUID `json:"public_id"`
+}
+
+// Cart - the open order of the account, its public id becomes the order public id at the checkout
+type Cart struct {
+	PublicId        uuid.UUID  `json:"public_id" db:"public_id"`
+	AccountPublicId uuid.UUID  `json:"account_public_id" db:"account_public_id"`
+	Items           []CartItem `json:"items" db:"-"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CartItem - Price is the effective unit price snapshot at the add-to-cart,
+// the line keeps it when more of the product is added.
+// VariantPublicId is set when the line is a variant of the product, every variant is its own line
+type CartItem struct {
+	ProductPublicId uuid.UUID `json:"product_public_id" db:"product_public_id"`
+	VariantPublicId uuid.UUID `json:"variant_public_id" db:"variant_public_id"`
+	DealerPublicId  uuid.UUID `json:"dealer_public_id" db:"dealer_public_id"`
+	Quantity        int64     `json:"quantity" db:"quantity"`
+	Price           int64     `json:"price" db:"price"`
+	AddedAt         time.Time `json:"added_at" db:"added_at"`
+}
+
+// SKU - the variant of the line or the product itself, a variant has its own product copy
+func (i CartItem) SKU() uuid.UUID {
+	if i.VariantPublicId != uuid.Nil {
+		return i.VariantPublicId
+	}
+	return i.ProductPublicId
+}
+
+// AddToCartInput - the variant, if any, must be a variant of the product
+type AddToCartInput struct {
+	ProductPublicId uuid.UUID `json:"product_public_id" binding:"required"`
+	VariantPublicId uuid.UUID `json:"variant_public_id"`
+	Quantity        int64     `json:"quantity" binding:"required,min=1"`
+}
+
+// OrderItem - order line of the order events. Price is the effective unit price at the checkout,
+// CartPrice is the one snapshot at the add-to-cart. VariantPublicId is set when the line is a variant of the product
+type OrderItem struct {
+	ProductPublicId uuid.UUID `json:"product_public_id"`
+	VariantPublicId uuid.UUID `json:"variant_public_id"`
+	DealerPublicId  uuid.UUID `json:"dealer_public_id"`
+	Quantity        int64     `json:"quantity"`
+	Price           int64     `json:"price"`
+	CartPrice       int64     `json:"cart_price"`
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "github.com/google/uuid"
+
+// EventType
+type EventType string
+
+const (
+	EVENT_ACCOUNT_CREATED          EventType = "auth.created"
+	EVENT_ACCOUNT_ROLE_UPDATED     EventType = "auth.role_updated"
+	EVENT_ACCOUNT_DELETED          EventType = "auth.deleted"
+	EVENT_ACCOUNT_SESSIONS_REVOKED EventType = "auth.sessions_revoked"
+
+	EVENT_ACCOUNT_EXPORT_REQUESTED  EventType = "Account.ExportRequested"
+	EVENT_ACCOUNT_ERASURE_REQUESTED EventType = "Account.ErasureRequested"
+
+	EVENT_PRIVACY_DATA_EXPORTED     EventType = "Privacy.DataExported"
+	EVENT_PRIVACY_ERASURE_COMPLETED EventType = "Privacy.ErasureCompleted"
+
+	EVENT_PRODUCT_CREATED EventType = "Product.Created"
+	EVENT_PRODUCT_UPDATED EventType = "Product.Updated"
+	EVENT_PRODUCT_DELETED EventType = "Product.Deleted"
+
+	EVENT_ORDER_PRODUCT_ADDED    EventType = "Order.ProductAdded"
+	EVENT_ORDER_CHECKED_OUT      EventType = "Order.CheckedOut"
+	EVENT_ORDER_TAKED_TO_DELIVER EventType = "Order.TakedToDeliver"
+	EVENT_ORDER_DELIVERED        EventType = "Order.Delivered"
+
+	EVENT_BILLING_PAYMENT_RECEIVED EventType = "Billing.PaymentReceived"
+	EVENT_BILLING_PAYMENT_DECLINED EventType = "Billing.PaymentDeclined"
+	EVENT_BILLING_REFUND_ISSUED    EventType = "Billing.RefundIssued"
+)
+
+// Event
+type Event struct {
+	Type  EventType
+	Value interface{}
+}
+
+// OrderEvent - Order.ProductAdded / Order.CheckedOut payload, the cart lines come with the public id of the cart
+type OrderEvent struct {
+	PublicId        uuid.UUID   `json:"public_id"`
+	AccountPublicId uuid.UUID   `json:"account_public_id"`
+	Items           []OrderItem `json:"items"`
+	Total           int64       `json:"total"`
+}
+
+// BillingEvent - Billing.* payload, transaction or payment
+type BillingEvent struct {
+	AccountPublicId uuid.UUID `json:"account_public_id"`
+	OrderPublicId   uuid.UUID `json:"order_public_id"`
+}
+
+// DeliveryEvent - Order.TakedToDeliver / Order.Delivered payload
+type DeliveryEvent struct {
+	OrderPublicId    uuid.UUID `json:"order_public_id"`
+	CustomerPublicId uuid.UUID `json:"customer_public_id"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderStatus
+type OrderStatus string
+
+const (
+	ORDER_CHECKED_OUT      OrderStatus = "checked_out"
+	ORDER_PAYMENT_DECLINED OrderStatus = "payment_declined"
+	ORDER_PAYED            OrderStatus = "payed"
+	ORDER_TAKED_TO_DELIVER OrderStatus = "taked_to_deliver"
+	ORDER_DELIVERED        OrderStatus = "delivered"
+	ORDER_REFUNDED         OrderStatus = "refunded"
+)
+
+// statusRanks - events come from different topics and can be late,
+// an order status only moves forward, e.g. late Billing.PaymentDeclined can't override payed
+var statusRanks = map[OrderStatus]int{
+	ORDER_CHECKED_OUT:      1,
+	ORDER_PAYMENT_DECLINED: 2,
+	ORDER_PAYED:            3,
+	ORDER_TAKED_TO_DELIVER: 4,
+	ORDER_DELIVERED:        5,
+	ORDER_REFUNDED:         6,
+}
+
+// Follows - status can come after the current one
+func (s OrderStatus) Follows(current OrderStatus) bool {
+	return statusRanks[s] > statusRanks[current]
+}
+
+// Order - order status projection
+type Order struct {
+	Id              int         `json:"-" db:"id"`
+	PublicId        uuid.UUID   `json:"public_id" db:"public_id"`
+	AccountPublicId uuid.UUID   `json:"account_public_id" db:"account_public_id"`
+	Status          OrderStatus `json:"status" db:"status"`
+	CreatedAt       time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// StatusChange - order status history record, id is the stream event id
+type StatusChange struct {
+	Id              int64       `json:"id" db:"id"`
+	OrderPublicId   uuid.UUID   `json:"order_public_id" db:"order_public_id"`
+	AccountPublicId uuid.UUID   `json:"account_public_id" db:"account_public_id"`
+	Status          OrderStatus `json:"status" db:"status"`
+	CreatedAt       time.Time   `json:"created_at" db:"created_at"`
+}
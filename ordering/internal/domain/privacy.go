@@ -0,0 +1,27 @@
+package domain
+
+const (
+	// PRIVACY_SERVICE - the service name in the privacy answers
+	PRIVACY_SERVICE = "ordering"
+)
+
+// PrivacyRequestedInput - Account.ExportRequested and Account.ErasureRequested payload
+type PrivacyRequestedInput struct {
+	RequestId string `json:"request_id" binding:"required"`
+	PublicId  string `json:"public_id" binding:"required"`
+}
+
+// PrivacyAnswer - Privacy.DataExported and Privacy.ErasureCompleted payload, the erasure has no data
+type PrivacyAnswer struct {
+	RequestId string      `json:"request_id"`
+	PublicId  string      `json:"public_id"`
+	Service   string      `json:"service"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// AccountData - the orders of the account with the status history and the open cart
+type AccountData struct {
+	Orders        []Order        `json:"orders"`
+	StatusChanges []StatusChange `json:"status_changes"`
+	Cart          Cart           `json:"cart"`
+}
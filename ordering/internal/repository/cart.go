@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+)
+
+var _ Carter = (*Cart)(nil)
+
+// Carter - cart and product copy repository interface
+type Carter interface {
+	SaveProduct(product domain.Product) error
+	DeleteProduct(publicId uuid.UUID) error
+	GetProduct(publicId uuid.UUID) (domain.Product, error)
+	GetCart(accountPublicId string) (domain.Cart, error)
+	AddCartItem(accountPublicId string, item domain.CartItem) (domain.Cart, error)
+	RemoveCartItem(accountPublicId string, productPublicId, variantPublicId uuid.UUID) (domain.Cart, error)
+	CheckoutCart(accountPublicId string) (domain.Cart, error)
+}
+
+// Cart
+type Cart struct {
+	db *sqlx.DB
+}
+
+// NewCart - constructor
+func NewCart(db *sqlx.DB) *Cart {
+	return &Cart{db: db}
+}
+
+// SaveProduct - Product.Created / Product.Updated, the copy is upserted
+func (r *Cart) SaveProduct(product domain.Product) error {
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, dealer_public_id, price, discount, parent_public_id)
+		values ($1, $2, $3, $4, $5)
+		ON CONFLICT(public_id) DO UPDATE SET dealer_public_id=excluded.dealer_public_id,
+		price=excluded.price, discount=excluded.discount, parent_public_id=excluded.parent_public_id`, productTable)
+	_, err := r.db.Exec(query, product.PublicId, product.DealerPublicId, product.Price, product.Discount,
+		product.ParentPublicId)
+	return err
+}
+
+// DeleteProduct - the deleted product can't be bought, its lines are removed from the carts.
+// The product service deletes the variants with the parent, so their copies go as well
+func (r *Cart) DeleteProduct(publicId uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE product_public_id=$1 OR variant_public_id=$1`, cartItemTable)
+	if _, err := tx.Exec(query, publicId); err != nil {
+		return fmt.Errorf("delete cart items: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1 OR parent_public_id=$1`, productTable)
+	if _, err := tx.Exec(query, publicId); err != nil {
+		return fmt.Errorf("delete product: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetProduct - domain.ErrProductNotFound if there is no copy
+func (r *Cart) GetProduct(publicId uuid.UUID) (domain.Product, error) {
+	var product domain.Product
+
+	query := fmt.Sprintf(`SELECT public_id, dealer_public_id, price, discount, parent_public_id FROM %s
+		WHERE public_id=$1`, productTable)
+	err := r.db.Get(&product, query, publicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return product, domain.ErrProductNotFound
+	}
+	if err != nil {
+		return product, fmt.Errorf("get product: %w", err)
+	}
+
+	return product, nil
+}
+
+// GetCart - an account without the cart gets an empty one with no public id
+func (r *Cart) GetCart(accountPublicId string) (domain.Cart, error) {
+	return getCart(r.db, accountPublicId)
+}
+
+// AddCartItem - the cart is created with the first line. More of the product (or the variant) in the cart
+// adds up the quantity, the line keeps its first price snapshot
+func (r *Cart) AddCartItem(accountPublicId string, item domain.CartItem) (domain.Cart, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return domain.Cart{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, account_public_id, created_at) values ($1, $2, $3)
+		ON CONFLICT(account_public_id) DO NOTHING`, cartTable)
+	if _, err := tx.Exec(query, uuid.New(), accountPublicId, item.AddedAt); err != nil {
+		return domain.Cart{}, fmt.Errorf("create cart: %w", err)
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (cart_public_id, product_public_id, variant_public_id, dealer_public_id,
+		quantity, price, added_at)
+		SELECT public_id, $1, $2, $3, $4, $5, $6 FROM %s WHERE account_public_id=$7
+		ON CONFLICT(cart_public_id, product_public_id, variant_public_id) DO UPDATE SET quantity=quantity+excluded.quantity`,
+		cartItemTable, cartTable)
+	_, err = tx.Exec(query, item.ProductPublicId, item.VariantPublicId, item.DealerPublicId, item.Quantity, item.Price,
+		item.AddedAt, accountPublicId)
+	if err != nil {
+		return domain.Cart{}, fmt.Errorf("add cart item: %w", err)
+	}
+
+	cart, err := getCart(tx, accountPublicId)
+	if err != nil {
+		return cart, err
+	}
+
+	return cart, tx.Commit()
+}
+
+// RemoveCartItem - domain.ErrCartItemNotFound if the product is not in the cart,
+// uuid.Nil variant is the line of the product itself
+func (r *Cart) RemoveCartItem(accountPublicId string, productPublicId, variantPublicId uuid.UUID) (domain.Cart, error) {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE product_public_id=$1 AND variant_public_id=$2
+		AND cart_public_id=(SELECT public_id FROM %s WHERE account_public_id=$3)`, cartItemTable, cartTable)
+	result, err := r.db.Exec(query, productPublicId, variantPublicId, accountPublicId)
+	if err != nil {
+		return domain.Cart{}, fmt.Errorf("remove cart item: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return domain.Cart{}, fmt.Errorf("remove cart item: %w", err)
+	}
+	if affected == 0 {
+		return domain.Cart{}, domain.ErrCartItemNotFound
+	}
+
+	return getCart(r.db, accountPublicId)
+}
+
+// CheckoutCart - the cart is closed and returned, domain.ErrCartEmpty if there is nothing to check out
+func (r *Cart) CheckoutCart(accountPublicId string) (domain.Cart, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return domain.Cart{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	cart, err := getCart(tx, accountPublicId)
+	if err != nil {
+		return cart, err
+	}
+	if len(cart.Items) == 0 {
+		return cart, domain.ErrCartEmpty
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE cart_public_id=$1`, cartItemTable)
+	if _, err := tx.Exec(query, cart.PublicId); err != nil {
+		return cart, fmt.Errorf("delete cart items: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, cartTable)
+	if _, err := tx.Exec(query, cart.PublicId); err != nil {
+		return cart, fmt.Errorf("delete cart: %w", err)
+	}
+
+	return cart, tx.Commit()
+}
+
+// getCart - the cart with its lines in the order they were added
+func getCart(db sqlx.Queryer, accountPublicId string) (domain.Cart, error) {
+	cart := domain.Cart{Items: make([]domain.CartItem, 0)}
+
+	query := fmt.Sprintf(`SELECT public_id, account_public_id, created_at FROM %s WHERE account_public_id=$1`, cartTable)
+	err := sqlx.Get(db, &cart, query, accountPublicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return cart, nil
+	}
+	if err != nil {
+		return cart, fmt.Errorf("get cart: %w", err)
+	}
+
+	query = fmt.Sprintf(`SELECT product_public_id, variant_public_id, dealer_public_id, quantity, price, added_at
+		FROM %s WHERE cart_public_id=$1 ORDER BY added_at, product_public_id, variant_public_id`, cartItemTable)
+	if err := sqlx.Select(db, &cart.Items, query, cart.PublicId); err != nil {
+		return cart, fmt.Errorf("get cart items: %w", err)
+	}
+
+	return cart, nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCart(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	accountPublicId := uuid.New()
+	dealerPublicId := uuid.New()
+	sofa, chair := uuid.New(), uuid.New()
+	addedAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	item := func(productPublicId uuid.UUID, quantity, price int64, at time.Time) domain.CartItem {
+		return domain.CartItem{ProductPublicId: productPublicId, DealerPublicId: dealerPublicId,
+			Quantity: quantity, Price: price, AddedAt: at}
+	}
+
+	t.Run("Can get empty cart", func(t *testing.T) {
+		cart, err := repos.GetCart(accountPublicId.String())
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.Nil, cart.PublicId)
+		assert.Empty(t, cart.Items)
+	})
+
+	t.Run("Can keep the product copy", func(t *testing.T) {
+		assert.NoError(t, repos.SaveProduct(domain.Product{PublicId: sofa, DealerPublicId: dealerPublicId, Price: 100}))
+		assert.NoError(t, repos.SaveProduct(domain.Product{PublicId: sofa, DealerPublicId: dealerPublicId, Price: 100, Discount: 10}))
+
+		product, err := repos.GetProduct(sofa)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), product.Discount)
+
+		_, err = repos.GetProduct(uuid.New())
+		assert.ErrorIs(t, err, domain.ErrProductNotFound)
+	})
+
+	var cartPublicId uuid.UUID
+	t.Run("Can add products to cart with the price snapshot", func(t *testing.T) {
+		cart, err := repos.AddCartItem(accountPublicId.String(), item(sofa, 1, 90, addedAt))
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, cart.PublicId)
+		assert.Equal(t, accountPublicId, cart.AccountPublicId)
+		cartPublicId = cart.PublicId
+
+		_, err = repos.AddCartItem(accountPublicId.String(), item(chair, 2, 50, addedAt.Add(time.Minute)))
+		assert.NoError(t, err)
+
+		// more of the same product keeps the first price
+		cart, err = repos.AddCartItem(accountPublicId.String(), item(sofa, 2, 80, addedAt.Add(time.Hour)))
+		assert.NoError(t, err)
+		assert.Equal(t, cartPublicId, cart.PublicId)
+		assert.Equal(t, []domain.CartItem{item(sofa, 3, 90, addedAt), item(chair, 2, 50, addedAt.Add(time.Minute))}, cart.Items)
+	})
+
+	t.Run("Can remove product from cart", func(t *testing.T) {
+		cart, err := repos.RemoveCartItem(accountPublicId.String(), chair, uuid.Nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.CartItem{item(sofa, 3, 90, addedAt)}, cart.Items)
+
+		_, err = repos.RemoveCartItem(accountPublicId.String(), chair, uuid.Nil)
+		assert.ErrorIs(t, err, domain.ErrCartItemNotFound)
+		_, err = repos.RemoveCartItem(uuid.NewString(), sofa, uuid.Nil)
+		assert.ErrorIs(t, err, domain.ErrCartItemNotFound)
+	})
+
+	t.Run("Can check out the cart once", func(t *testing.T) {
+		cart, err := repos.CheckoutCart(accountPublicId.String())
+		assert.NoError(t, err)
+		assert.Equal(t, cartPublicId, cart.PublicId)
+		assert.Equal(t, []domain.CartItem{item(sofa, 3, 90, addedAt)}, cart.Items)
+
+		_, err = repos.CheckoutCart(accountPublicId.String())
+		assert.ErrorIs(t, err, domain.ErrCartEmpty)
+
+		// the next cart is a new order
+		cart, err = repos.AddCartItem(accountPublicId.String(), item(sofa, 1, 90, addedAt))
+		assert.NoError(t, err)
+		assert.NotEqual(t, cartPublicId, cart.PublicId)
+	})
+
+	velvetSofa := uuid.New()
+	variant := func(quantity, price int64, at time.Time) domain.CartItem {
+		line := item(sofa, quantity, price, at)
+		line.VariantPublicId = velvetSofa
+		return line
+	}
+	t.Run("Can keep the variant of the product in its own line", func(t *testing.T) {
+		parent := uuid.NullUUID{UUID: sofa, Valid: true}
+		assert.NoError(t, repos.SaveProduct(domain.Product{PublicId: velvetSofa, DealerPublicId: dealerPublicId,
+			Price: 120, ParentPublicId: parent}))
+		product, err := repos.GetProduct(velvetSofa)
+		assert.NoError(t, err)
+		assert.True(t, product.IsVariantOf(sofa))
+
+		_, err = repos.AddCartItem(accountPublicId.String(), variant(1, 110, addedAt.Add(time.Minute)))
+		assert.NoError(t, err)
+		cart, err := repos.AddCartItem(accountPublicId.String(), variant(1, 100, addedAt.Add(time.Hour)))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.CartItem{item(sofa, 1, 90, addedAt), variant(2, 110, addedAt.Add(time.Minute))}, cart.Items)
+
+		cart, err = repos.RemoveCartItem(accountPublicId.String(), sofa, velvetSofa)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.CartItem{item(sofa, 1, 90, addedAt)}, cart.Items)
+
+		_, err = repos.AddCartItem(accountPublicId.String(), variant(1, 110, addedAt.Add(time.Minute)))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Can remove the deleted product with its variants from carts", func(t *testing.T) {
+		assert.NoError(t, repos.DeleteProduct(sofa))
+
+		_, err := repos.GetProduct(sofa)
+		assert.ErrorIs(t, err, domain.ErrProductNotFound)
+		_, err = repos.GetProduct(velvetSofa)
+		assert.ErrorIs(t, err, domain.ErrProductNotFound)
+		cart, err := repos.GetCart(accountPublicId.String())
+		assert.NoError(t, err)
+		assert.Empty(t, cart.Items)
+	})
+}
+
+func TestPrivacy_EraseAccountData(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	accountPublicId := uuid.New()
+	otherPublicId := uuid.New()
+	item := domain.CartItem{ProductPublicId: uuid.New(), Quantity: 1, Price: 100, AddedAt: time.Now().UTC()}
+	for _, publicId := range []uuid.UUID{accountPublicId, otherPublicId} {
+		assert.NoError(t, repos.CreateAccount(domain.Account{PublicId: publicId}))
+		_, err := repos.AddCartItem(publicId.String(), item)
+		assert.NoError(t, err)
+	}
+
+	data, err := repos.ExportAccountData(accountPublicId)
+	assert.NoError(t, err)
+	assert.Len(t, data.Cart.Items, 1)
+
+	assert.NoError(t, repos.EraseAccountData(accountPublicId))
+
+	cart, err := repos.GetCart(accountPublicId.String())
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, cart.PublicId)
+	_, err = repos.GetAccount(accountPublicId.String())
+	assert.Error(t, err)
+
+	cart, err = repos.GetCart(otherPublicId.String())
+	assert.NoError(t, err)
+	assert.Len(t, cart.Items, 1)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+)
+
+var _ Orderer = (*Order)(nil)
+
+// Orderer - order status projection repository interface
+type Orderer interface {
+	ChangeStatus(change domain.StatusChange) (domain.StatusChange, bool, error)
+	GetAccountOrders(accountPublicId string) ([]domain.Order, error)
+	GetAccountStatusChanges(accountPublicId string, afterId int64) ([]domain.StatusChange, error)
+}
+
+// Order
+type Order struct {
+	db *sqlx.DB
+}
+
+// NewOrder - constructor
+func NewOrder(db *sqlx.DB) *Order {
+	return &Order{db: db}
+}
+
+// ChangeStatus - moves the order forward and records the history, false if the status is not newer.
+// The first event of an unknown order creates it, whichever event it is.
+func (r *Order) ChangeStatus(change domain.StatusChange) (domain.StatusChange, bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return change, false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	var current domain.OrderStatus
+	query := fmt.Sprintf(`SELECT status FROM %s WHERE public_id=$1`, orderTable)
+	err = tx.Get(&current, query, change.OrderPublicId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return change, false, fmt.Errorf("get order status: %w", err)
+	}
+	if err == nil && !change.Status.Follows(current) {
+		return change, false, nil
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (public_id, account_public_id, status, created_at, updated_at)
+		values ($1, $2, $3, $4, $4) ON CONFLICT(public_id) DO UPDATE SET status=excluded.status,
+		updated_at=excluded.updated_at`, orderTable)
+	_, err = tx.Exec(query, change.OrderPublicId, change.AccountPublicId, change.Status, change.CreatedAt)
+	if err != nil {
+		return change, false, fmt.Errorf("save order: %w", err)
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (order_public_id, account_public_id, status, created_at)
+		values ($1, $2, $3, $4)`, orderStatusTable)
+	result, err := tx.Exec(query, change.OrderPublicId, change.AccountPublicId, change.Status, change.CreatedAt)
+	if err != nil {
+		return change, false, fmt.Errorf("create status change: %w", err)
+	}
+	change.Id, err = result.LastInsertId()
+	if err != nil {
+		return change, false, fmt.Errorf("create status change: %w", err)
+	}
+
+	return change, true, tx.Commit()
+}
+
+// GetAccountOrders - the latest first
+func (r *Order) GetAccountOrders(accountPublicId string) ([]domain.Order, error) {
+	orders := make([]domain.Order, 0)
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE account_public_id=$1 ORDER BY id DESC`, orderTable)
+	err := r.db.Select(&orders, query, accountPublicId)
+	if err != nil {
+		return orders, fmt.Errorf("get account orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// GetAccountStatusChanges - history after the given change id, in order
+func (r *Order) GetAccountStatusChanges(accountPublicId string, afterId int64) ([]domain.StatusChange, error) {
+	changes := make([]domain.StatusChange, 0)
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE account_public_id=$1 AND id>$2 ORDER BY id`, orderStatusTable)
+	err := r.db.Select(&changes, query, accountPublicId, afterId)
+	if err != nil {
+		return changes, fmt.Errorf("get status changes: %w", err)
+	}
+
+	return changes, nil
+}
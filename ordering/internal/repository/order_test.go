@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_ChangeStatus(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	orderPublicId := uuid.New()
+	accountPublicId := uuid.New()
+	change := func(status domain.OrderStatus) domain.StatusChange {
+		return domain.StatusChange{
+			OrderPublicId:   orderPublicId,
+			AccountPublicId: accountPublicId,
+			Status:          status,
+			CreatedAt:       time.Now().UTC(),
+		}
+	}
+
+	tests := []struct {
+		name            string
+		status          domain.OrderStatus
+		expectedApplied bool
+		expectedStatus  domain.OrderStatus
+	}{
+		{name: "Can create order with the first event", status: domain.ORDER_CHECKED_OUT,
+			expectedApplied: true, expectedStatus: domain.ORDER_CHECKED_OUT},
+		{name: "Can move order forward", status: domain.ORDER_PAYED,
+			expectedApplied: true, expectedStatus: domain.ORDER_PAYED},
+		{name: "Can't move order back with late event", status: domain.ORDER_PAYMENT_DECLINED,
+			expectedApplied: false, expectedStatus: domain.ORDER_PAYED},
+		{name: "Can't apply repeated event", status: domain.ORDER_PAYED,
+			expectedApplied: false, expectedStatus: domain.ORDER_PAYED},
+		{name: "Can skip a status", status: domain.ORDER_DELIVERED,
+			expectedApplied: true, expectedStatus: domain.ORDER_DELIVERED},
+	}
+
+	var lastId int64
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved, applied, err := repos.ChangeStatus(change(tt.status))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedApplied, applied)
+			if applied {
+				assert.Greater(t, saved.Id, lastId)
+				lastId = saved.Id
+			}
+
+			orders, err := repos.GetAccountOrders(accountPublicId.String())
+			assert.NoError(t, err)
+			assert.Len(t, orders, 1)
+			assert.Equal(t, tt.expectedStatus, orders[0].Status)
+		})
+	}
+
+	t.Run("Can get history after the event id", func(t *testing.T) {
+		changes, err := repos.GetAccountStatusChanges(accountPublicId.String(), 0)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 3)
+
+		changes, err = repos.GetAccountStatusChanges(accountPublicId.String(), changes[0].Id)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 2)
+		assert.Equal(t, domain.ORDER_PAYED, changes[0].Status)
+		assert.Equal(t, domain.ORDER_DELIVERED, changes[1].Status)
+
+		changes, err = repos.GetAccountStatusChanges(uuid.NewString(), 0)
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+)
+
+var _ Privacier = (*Privacy)(nil)
+
+// Privacier - repository interface
+type Privacier interface {
+	ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error)
+	EraseAccountData(accountPublicId uuid.UUID) error
+}
+
+// Privacy - personal data of the account kept here
+type Privacy struct {
+	db *sqlx.DB
+}
+
+// NewPrivacy - constructor
+func NewPrivacy(db *sqlx.DB) *Privacy {
+	return &Privacy{db: db}
+}
+
+// ExportAccountData
+func (r *Privacy) ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error) {
+	data := domain.AccountData{
+		Orders:        make([]domain.Order, 0),
+		StatusChanges: make([]domain.StatusChange, 0),
+	}
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE account_public_id=$1 ORDER BY id`, orderTable)
+	if err := r.db.Select(&data.Orders, query, accountPublicId); err != nil {
+		return data, fmt.Errorf("export orders: %w", err)
+	}
+	query = fmt.Sprintf(`SELECT * FROM %s WHERE account_public_id=$1 ORDER BY id`, orderStatusTable)
+	if err := r.db.Select(&data.StatusChanges, query, accountPublicId); err != nil {
+		return data, fmt.Errorf("export status changes: %w", err)
+	}
+	cart, err := getCart(r.db, accountPublicId.String())
+	if err != nil {
+		return data, fmt.Errorf("export cart: %w", err)
+	}
+	data.Cart = cart
+
+	return data, nil
+}
+
+// EraseAccountData - the orders have no personal data but the account public id, they are kept.
+// The account copy and the open cart are removed
+func (r *Privacy) EraseAccountData(accountPublicId uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE cart_public_id IN
+		(SELECT public_id FROM %s WHERE account_public_id=$1)`, cartItemTable, cartTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase cart items: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE account_public_id=$1`, cartTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase cart: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, accountTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase account: %w", err)
+	}
+
+	return tx.Commit()
+}
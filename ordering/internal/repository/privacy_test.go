@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivacy_ExportAccountData(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	accountPublicId := uuid.New()
+	orderPublicId := uuid.New()
+	for _, status := range []domain.OrderStatus{domain.ORDER_CHECKED_OUT, domain.ORDER_PAYED} {
+		_, _, err := repos.ChangeStatus(domain.StatusChange{OrderPublicId: orderPublicId,
+			AccountPublicId: accountPublicId, Status: status, CreatedAt: time.Now().UTC()})
+		assert.NoError(t, err)
+	}
+	_, _, err = repos.ChangeStatus(domain.StatusChange{OrderPublicId: uuid.New(),
+		AccountPublicId: uuid.New(), Status: domain.ORDER_CHECKED_OUT, CreatedAt: time.Now().UTC()})
+	assert.NoError(t, err)
+
+	t.Run("Can export own orders with the status history", func(t *testing.T) {
+		data, err := repos.ExportAccountData(accountPublicId)
+		assert.NoError(t, err)
+		assert.Len(t, data.Orders, 1)
+		assert.Equal(t, domain.ORDER_PAYED, data.Orders[0].Status)
+		assert.Len(t, data.StatusChanges, 2)
+	})
+
+	t.Run("Can export nothing of unknown account", func(t *testing.T) {
+		data, err := repos.ExportAccountData(uuid.New())
+		assert.NoError(t, err)
+		assert.Empty(t, data.Orders)
+		assert.Empty(t, data.StatusChanges)
+	})
+}
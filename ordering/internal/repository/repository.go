@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"fmt"
+
+	_ "github.com/golang/mock/mockgen/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -destination mocks/mock.go -package repository github.com/p12s/furniture-store/ordering/internal/repository Accounter,Orderer,Carter,Privacier
+
+// Repository - repo
+type Repository struct {
+	Accounter
+	Orderer
+	Carter
+	Privacier
+}
+
+// NewRepository - constructor
+func NewRepository(db *sqlx.DB) *Repository {
+	createSchema(db, accountTable, `CREATE TABLE IF NOT EXISTS account (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"role" INTEGER DEFAULT 0
+	  );`)
+	createSchema(db, revokedSessionTable, `CREATE TABLE IF NOT EXISTS revoked_session (
+		"session_public_id" TEXT NOT NULL PRIMARY KEY,
+		"account_public_id" TEXT NOT NULL,
+		"expires_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, orderTable, `CREATE TABLE IF NOT EXISTS orders (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"account_public_id" TEXT NOT NULL,
+		"status" TEXT NOT NULL,
+		"created_at" DATETIME NOT NULL,
+		"updated_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, orderStatusTable, `CREATE TABLE IF NOT EXISTS order_status (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"order_public_id" TEXT NOT NULL,
+		"account_public_id" TEXT NOT NULL,
+		"status" TEXT NOT NULL,
+		"created_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, "order_status_account index", `CREATE INDEX IF NOT EXISTS order_status_account
+		ON order_status (account_public_id, id);`)
+	createSchema(db, productTable, `CREATE TABLE IF NOT EXISTS product (
+		"public_id" TEXT NOT NULL PRIMARY KEY,
+		"dealer_public_id" TEXT,
+		"price" INTEGER DEFAULT 0,
+		"discount" INTEGER DEFAULT 0,
+		"parent_public_id" TEXT
+	  );`)
+	createSchema(db, cartTable, `CREATE TABLE IF NOT EXISTS cart (
+		"public_id" TEXT NOT NULL PRIMARY KEY,
+		"account_public_id" TEXT NOT NULL UNIQUE,
+		"created_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, cartItemTable, `CREATE TABLE IF NOT EXISTS cart_item (
+		"cart_public_id" TEXT NOT NULL,
+		"product_public_id" TEXT NOT NULL,
+		"variant_public_id" TEXT NOT NULL,
+		"dealer_public_id" TEXT,
+		"quantity" INTEGER NOT NULL,
+		"price" INTEGER NOT NULL,
+		"added_at" DATETIME NOT NULL,
+		PRIMARY KEY (cart_public_id, product_public_id, variant_public_id)
+	  );`)
+
+	return &Repository{
+		Accounter: NewAccount(db),
+		Orderer:   NewOrder(db),
+		Carter:    NewCart(db),
+		Privacier: NewPrivacy(db),
+	}
+}
+
+// Deliberately removed the obligation of important fields,
+// because the architecture is asynchronous, the business-event with only role (role)
+// can come before a CUD-event with all other data.
+
+// createSchema - table, index
+func createSchema(db *sqlx.DB, name, query string) {
+	statement, err := db.Prepare(query)
+	if err != nil {
+		logrus.Fatalf("create ordering.%s fail: %s", name, err.Error())
+	}
+	defer statement.Close() // nolint
+
+	_, err = statement.Exec()
+	if err != nil {
+		logrus.Fatalf("exec creating ordering.%s fail: %s", name, err.Error())
+	}
+
+	fmt.Printf("ordering.%s created 🗂\n", name)
+}
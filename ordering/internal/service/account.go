@@ -0,0 +1,192 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/p12s/furniture-store/ordering/internal/config"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	"github.com/p12s/furniture-store/ordering/internal/repository"
+	"github.com/p12s/furniture-store/ordering/internal/stream"
+)
+
+const (
+	// STREAM_TICKET_AUDIENCE - audience of the stream ticket, the other routes and services don't accept it
+	STREAM_TICKET_AUDIENCE = "order-stream"
+	// STREAM_TICKET_TTL - the ticket only opens the stream, the open stream lives until the session is revoked
+	STREAM_TICKET_TTL = time.Minute
+)
+
+var _ Accounter = (*AccountService)(nil)
+
+// Accounter - service interface
+type Accounter interface {
+	CreateAccount(account domain.Account) error
+	GetAccount(publicId string) (domain.Account, error)
+	UpdateAccountRole(input domain.UpdateAccountRoleInput) error
+	DeleteAccount(accountPublicId string) error
+	RevokeSessions(input domain.SessionsRevokedInput) error
+	ParseToken(token string) (string, error)
+	IssueStreamTicket(accessToken string) (domain.StreamTicket, error)
+	ParseStreamTicket(ticket string) (domain.StreamSession, error)
+}
+
+// AccountService - service
+type AccountService struct {
+	repo       repository.Accounter
+	hub        *stream.Hub
+	signingKey string
+}
+
+// NewAccountService - constructor
+func NewAccountService(repo repository.Accounter, hub *stream.Hub, config *config.Auth) *AccountService {
+	return &AccountService{
+		repo:       repo,
+		hub:        hub,
+		signingKey: config.SigningKey,
+	}
+}
+
+// CreateAccount
+func (s *AccountService) CreateAccount(account domain.Account) error {
+	return s.repo.CreateAccount(account)
+}
+
+// GetAccount
+func (s *AccountService) GetAccount(publicId string) (domain.Account, error) {
+	return s.repo.GetAccount(publicId)
+}
+
+// UpdateAccountRole
+func (s *AccountService) UpdateAccountRole(input domain.UpdateAccountRoleInput) error {
+	return s.repo.UpdateAccountRole(input)
+}
+
+// DeleteAccount
+func (s *AccountService) DeleteAccount(accountPublicId string) error {
+	return s.repo.DeleteAccount(accountPublicId)
+}
+
+// RevokeSessions - the account service revoked the sessions, their open streams are closed
+func (s *AccountService) RevokeSessions(input domain.SessionsRevokedInput) error {
+	if err := s.repo.RevokeSessions(input, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	s.hub.CloseSessions(input.PublicId, input.SessionIds)
+	return nil
+}
+
+// ParseToken - token is issued by the account service for a session, the session must not be revoked.
+// The tokens with an audience (oauth client, email verification, stream ticket) are not the access tokens of the routes
+func (s *AccountService) ParseToken(accessToken string) (string, error) {
+	claims, err := s.parseClaims(accessToken)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := claims["aud"]; ok {
+		return "", fmt.Errorf("invalid audience")
+	}
+
+	session, err := s.checkSession(claims, "jti")
+	if err != nil {
+		return "", err
+	}
+
+	return session.AccountPublicId, nil
+}
+
+// IssueStreamTicket - the ticket keeps the session of the access token, so it stops working with the session
+func (s *AccountService) IssueStreamTicket(accessToken string) (domain.StreamTicket, error) {
+	claims, err := s.parseClaims(accessToken)
+	if err != nil {
+		return domain.StreamTicket{}, err
+	}
+	if _, ok := claims["aud"]; ok {
+		return domain.StreamTicket{}, fmt.Errorf("invalid audience")
+	}
+
+	session, err := s.checkSession(claims, "jti")
+	if err != nil {
+		return domain.StreamTicket{}, err
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(STREAM_TICKET_TTL)
+	ticket, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": session.AccountPublicId,
+		"sid": session.SessionId,
+		"aud": STREAM_TICKET_AUDIENCE,
+		"iat": now.Unix(),
+		"exp": expiresAt.Unix(),
+	}).SignedString([]byte(s.signingKey))
+	if err != nil {
+		return domain.StreamTicket{}, fmt.Errorf("sign stream ticket: %w", err)
+	}
+
+	return domain.StreamTicket{
+		Ticket:    ticket,
+		ExpiresAt: time.Unix(expiresAt.Unix(), 0).UTC(),
+	}, nil
+}
+
+// ParseStreamTicket - the ticket is issued by IssueStreamTicket, its session must not be revoked
+func (s *AccountService) ParseStreamTicket(ticket string) (domain.StreamSession, error) {
+	claims, err := s.parseClaims(ticket)
+	if err != nil {
+		return domain.StreamSession{}, err
+	}
+	if !claims.VerifyAudience(STREAM_TICKET_AUDIENCE, true) {
+		return domain.StreamSession{}, fmt.Errorf("invalid audience")
+	}
+
+	return s.checkSession(claims, "sid")
+}
+
+// parseClaims - signature and expiration
+func (s *AccountService) parseClaims(token string) (jwt.MapClaims, error) {
+	t, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return []byte(s.signingKey), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unexpected signing method: %w/n", err)
+	}
+
+	if !t.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	claims, ok := t.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("invalid claims")
+	}
+
+	return claims, nil
+}
+
+// checkSession - subject and the not revoked session of the claim
+func (s *AccountService) checkSession(claims jwt.MapClaims, sessionClaim string) (domain.StreamSession, error) {
+	subject, ok := claims["sub"].(string)
+	if !ok {
+		return domain.StreamSession{}, fmt.Errorf("invalid subject")
+	}
+
+	sessionId, ok := claims[sessionClaim].(string)
+	if !ok {
+		return domain.StreamSession{}, fmt.Errorf("invalid session")
+	}
+	revoked, err := s.repo.IsSessionRevoked(sessionId)
+	if err != nil {
+		return domain.StreamSession{}, fmt.Errorf("token session: %w", err)
+	}
+	if revoked {
+		return domain.StreamSession{}, fmt.Errorf("revoked session")
+	}
+
+	return domain.StreamSession{AccountPublicId: subject, SessionId: sessionId}, nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/p12s/furniture-store/ordering/internal/config"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/ordering/internal/repository/mocks"
+	"github.com/p12s/furniture-store/ordering/internal/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_SIGNING_KEY = "signing-key"
+
+func TestAccountService_StreamTicket(t *testing.T) {
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(TEST_SIGNING_KEY))
+		assert.NoError(t, err)
+		return token
+	}
+	accessToken := sign(jwt.MapClaims{"sub": accountPublicId, "jti": "session", "exp": time.Now().Add(time.Hour).Unix()})
+
+	t.Run("Can open the stream with the ticket only", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockAccounter(ctrl)
+		repo.EXPECT().IsSessionRevoked("session").Return(false, nil).Times(2)
+		service := NewAccountService(repo, stream.NewHub(), &config.Auth{SigningKey: TEST_SIGNING_KEY})
+
+		ticket, err := service.IssueStreamTicket(accessToken)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(STREAM_TICKET_TTL), ticket.ExpiresAt, 2*time.Second)
+
+		session, err := service.ParseStreamTicket(ticket.Ticket)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StreamSession{AccountPublicId: accountPublicId, SessionId: "session"}, session)
+
+		// the ticket is not the access token and the other way round
+		_, err = service.ParseToken(ticket.Ticket)
+		assert.Error(t, err)
+		_, err = service.ParseStreamTicket(accessToken)
+		assert.Error(t, err)
+		_, err = service.IssueStreamTicket(ticket.Ticket)
+		assert.Error(t, err)
+	})
+
+	t.Run("Can't issue the ticket for the revoked session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockAccounter(ctrl)
+		repo.EXPECT().IsSessionRevoked("session").Return(true, nil)
+		service := NewAccountService(repo, stream.NewHub(), &config.Auth{SigningKey: TEST_SIGNING_KEY})
+
+		_, err := service.IssueStreamTicket(accessToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("Can't open the stream with the ticket of the revoked session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockAccounter(ctrl)
+		repo.EXPECT().IsSessionRevoked("session").Return(true, nil)
+		service := NewAccountService(repo, stream.NewHub(), &config.Auth{SigningKey: TEST_SIGNING_KEY})
+
+		_, err := service.ParseStreamTicket(sign(jwt.MapClaims{"sub": accountPublicId, "sid": "session",
+			"aud": STREAM_TICKET_AUDIENCE, "exp": time.Now().Add(time.Minute).Unix()}))
+		assert.Error(t, err)
+	})
+
+	t.Run("Can't open the stream with the expired ticket", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockAccounter(ctrl)
+		service := NewAccountService(repo, stream.NewHub(), &config.Auth{SigningKey: TEST_SIGNING_KEY})
+
+		_, err := service.ParseStreamTicket(sign(jwt.MapClaims{"sub": accountPublicId, "sid": "session",
+			"aud": STREAM_TICKET_AUDIENCE, "exp": time.Now().Add(-time.Second).Unix()}))
+		assert.Error(t, err)
+	})
+}
+
+func TestAccountService_RevokeSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	input := domain.SessionsRevokedInput{PublicId: accountPublicId, SessionIds: []string{"revoked"}}
+
+	repo := mock_repository.NewMockAccounter(ctrl)
+	repo.EXPECT().RevokeSessions(input, gomock.Any()).Return(nil)
+	hub := stream.NewHub()
+	revoked, cancelRevoked := hub.Subscribe(accountPublicId, "revoked")
+	defer cancelRevoked()
+	active, cancelActive := hub.Subscribe(accountPublicId, "active")
+	defer cancelActive()
+
+	assert.NoError(t, NewAccountService(repo, hub, &config.Auth{SigningKey: TEST_SIGNING_KEY}).RevokeSessions(input))
+
+	_, ok := <-revoked
+	assert.False(t, ok)
+	assert.Len(t, active, 0)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	"github.com/p12s/furniture-store/ordering/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+var _ Carter = (*CartService)(nil)
+
+// Carter - service interface
+type Carter interface {
+	SaveProduct(product domain.Product) error
+	DeleteProduct(publicId uuid.UUID) error
+	GetCart(accountPublicId string) (domain.Cart, error)
+	AddToCart(accountPublicId string, input domain.AddToCartInput) (domain.Cart, error)
+	RemoveFromCart(accountPublicId string, productPublicId, variantPublicId uuid.UUID) (domain.Cart, error)
+	Checkout(accountPublicId string) (domain.OrderEvent, error)
+}
+
+// CartService - the cart lines snapshot the effective price at the add-to-cart,
+// the checkout prices them again and opens the order
+type CartService struct {
+	repo   repository.Carter
+	orders Orderer
+}
+
+// NewCartService - constructor
+func NewCartService(repo repository.Carter, orders Orderer) *CartService {
+	return &CartService{
+		repo:   repo,
+		orders: orders,
+	}
+}
+
+// SaveProduct
+func (s *CartService) SaveProduct(product domain.Product) error {
+	return s.repo.SaveProduct(product)
+}
+
+// DeleteProduct
+func (s *CartService) DeleteProduct(publicId uuid.UUID) error {
+	return s.repo.DeleteProduct(publicId)
+}
+
+// GetCart
+func (s *CartService) GetCart(accountPublicId string) (domain.Cart, error) {
+	return s.repo.GetCart(accountPublicId)
+}
+
+// AddToCart - domain.ErrProductNotFound if there is no product copy. The line references the parent product,
+// the variant is priced by its own copy, domain.ErrInvalidVariant if it is not a variant of the product
+func (s *CartService) AddToCart(accountPublicId string, input domain.AddToCartInput) (domain.Cart, error) {
+	product, err := s.repo.GetProduct(input.ProductPublicId)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+	if product.ParentPublicId.Valid {
+		return domain.Cart{}, domain.ErrInvalidVariant
+	}
+
+	if input.VariantPublicId != uuid.Nil {
+		variant, err := s.repo.GetProduct(input.VariantPublicId)
+		if errors.Is(err, domain.ErrProductNotFound) {
+			return domain.Cart{}, domain.ErrInvalidVariant
+		}
+		if err != nil {
+			return domain.Cart{}, err
+		}
+		if !variant.IsVariantOf(product.PublicId) {
+			return domain.Cart{}, domain.ErrInvalidVariant
+		}
+		product = variant
+	}
+
+	return s.repo.AddCartItem(accountPublicId, domain.CartItem{
+		ProductPublicId: input.ProductPublicId,
+		VariantPublicId: input.VariantPublicId,
+		DealerPublicId:  product.DealerPublicId,
+		Quantity:        input.Quantity,
+		Price:           domain.EffectivePrice(product.Price, product.Discount),
+		AddedAt:         time.Now().UTC(),
+	})
+}
+
+// RemoveFromCart
+func (s *CartService) RemoveFromCart(accountPublicId string, productPublicId, variantPublicId uuid.UUID) (domain.Cart, error) {
+	return s.repo.RemoveCartItem(accountPublicId, productPublicId, variantPublicId)
+}
+
+// Checkout - the lines are priced at the current effective price with the add-to-cart one kept,
+// a product deleted in between keeps the add-to-cart price. The order gets the cart public id
+func (s *CartService) Checkout(accountPublicId string) (domain.OrderEvent, error) {
+	cart, err := s.repo.CheckoutCart(accountPublicId)
+	if err != nil {
+		return domain.OrderEvent{}, err
+	}
+
+	order := domain.OrderEvent{
+		PublicId:        cart.PublicId,
+		AccountPublicId: cart.AccountPublicId,
+		Items:           make([]domain.OrderItem, len(cart.Items)),
+	}
+	for i, item := range cart.Items {
+		price := item.Price
+		product, err := s.repo.GetProduct(item.SKU())
+		if err == nil {
+			price = domain.EffectivePrice(product.Price, product.Discount)
+		} else if !errors.Is(err, domain.ErrProductNotFound) {
+			return domain.OrderEvent{}, fmt.Errorf("get product: %w", err)
+		}
+
+		order.Items[i] = domain.OrderItem{
+			ProductPublicId: item.ProductPublicId,
+			VariantPublicId: item.VariantPublicId,
+			DealerPublicId:  item.DealerPublicId,
+			Quantity:        item.Quantity,
+			Price:           price,
+			CartPrice:       item.Price,
+		}
+		order.Total += price * item.Quantity
+	}
+
+	// the order is in the projection at once, the repeated Order.CheckedOut is skipped then
+	err = s.orders.ChangeStatus(domain.StatusChange{
+		OrderPublicId:   order.PublicId,
+		AccountPublicId: order.AccountPublicId,
+		Status:          domain.ORDER_CHECKED_OUT,
+		CreatedAt:       time.Now().UTC(),
+	})
+	if err != nil {
+		logrus.Errorf("order %s checked out status: %s", order.PublicId, err.Error())
+	}
+
+	return order, nil
+}
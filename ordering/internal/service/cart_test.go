@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/ordering/internal/repository/mocks"
+	"github.com/p12s/furniture-store/ordering/internal/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCartService_AddToCart(t *testing.T) {
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	productPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	dealerPublicId := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+	input := domain.AddToCartInput{ProductPublicId: productPublicId, Quantity: 2}
+
+	t.Run("Can snapshot the effective price", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockCarter(ctrl)
+		repo.EXPECT().GetProduct(productPublicId).Return(domain.Product{PublicId: productPublicId,
+			DealerPublicId: dealerPublicId, Price: 9999, Discount: 15}, nil)
+		repo.EXPECT().AddCartItem(accountPublicId, gomock.Any()).DoAndReturn(
+			func(accountPublicId string, item domain.CartItem) (domain.Cart, error) {
+				assert.Equal(t, productPublicId, item.ProductPublicId)
+				assert.Equal(t, dealerPublicId, item.DealerPublicId)
+				assert.Equal(t, int64(2), item.Quantity)
+				// rounded down to kopecks
+				assert.Equal(t, int64(8499), item.Price)
+				assert.False(t, item.AddedAt.IsZero())
+				return domain.Cart{Items: []domain.CartItem{item}}, nil
+			})
+
+		cart, err := NewCartService(repo, nil).AddToCart(accountPublicId, input)
+		assert.NoError(t, err)
+		assert.Len(t, cart.Items, 1)
+	})
+
+	t.Run("Can add the variant at its own price", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		variantPublicId := uuid.MustParse("a3a9fb6c-58e1-4c3b-8d2c-8b6a1d4f2b7e")
+		repo := mock_repository.NewMockCarter(ctrl)
+		repo.EXPECT().GetProduct(productPublicId).Return(domain.Product{PublicId: productPublicId,
+			DealerPublicId: dealerPublicId, Price: 9999, Discount: 15}, nil)
+		repo.EXPECT().GetProduct(variantPublicId).Return(domain.Product{PublicId: variantPublicId,
+			DealerPublicId: dealerPublicId, Price: 12000, Discount: 15,
+			ParentPublicId: uuid.NullUUID{UUID: productPublicId, Valid: true}}, nil)
+		repo.EXPECT().AddCartItem(accountPublicId, gomock.Any()).DoAndReturn(
+			func(accountPublicId string, item domain.CartItem) (domain.Cart, error) {
+				assert.Equal(t, productPublicId, item.ProductPublicId)
+				assert.Equal(t, variantPublicId, item.VariantPublicId)
+				assert.Equal(t, int64(10200), item.Price)
+				return domain.Cart{Items: []domain.CartItem{item}}, nil
+			})
+
+		_, err := NewCartService(repo, nil).AddToCart(accountPublicId, domain.AddToCartInput{
+			ProductPublicId: productPublicId, VariantPublicId: variantPublicId, Quantity: 1})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Can't add the variant of other product", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		variantPublicId := uuid.MustParse("a3a9fb6c-58e1-4c3b-8d2c-8b6a1d4f2b7e")
+		repo := mock_repository.NewMockCarter(ctrl)
+		repo.EXPECT().GetProduct(productPublicId).Return(domain.Product{PublicId: productPublicId}, nil)
+		repo.EXPECT().GetProduct(variantPublicId).Return(domain.Product{PublicId: variantPublicId,
+			ParentPublicId: uuid.NullUUID{UUID: uuid.New(), Valid: true}}, nil)
+
+		_, err := NewCartService(repo, nil).AddToCart(accountPublicId, domain.AddToCartInput{
+			ProductPublicId: productPublicId, VariantPublicId: variantPublicId, Quantity: 1})
+		assert.ErrorIs(t, err, domain.ErrInvalidVariant)
+	})
+
+	t.Run("Can't add unknown variant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		variantPublicId := uuid.MustParse("a3a9fb6c-58e1-4c3b-8d2c-8b6a1d4f2b7e")
+		repo := mock_repository.NewMockCarter(ctrl)
+		repo.EXPECT().GetProduct(productPublicId).Return(domain.Product{PublicId: productPublicId}, nil)
+		repo.EXPECT().GetProduct(variantPublicId).Return(domain.Product{}, domain.ErrProductNotFound)
+
+		_, err := NewCartService(repo, nil).AddToCart(accountPublicId, domain.AddToCartInput{
+			ProductPublicId: productPublicId, VariantPublicId: variantPublicId, Quantity: 1})
+		assert.ErrorIs(t, err, domain.ErrInvalidVariant)
+	})
+
+	t.Run("Can't add the variant as the product", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockCarter(ctrl)
+		repo.EXPECT().GetProduct(productPublicId).Return(domain.Product{PublicId: productPublicId,
+			ParentPublicId: uuid.NullUUID{UUID: uuid.New(), Valid: true}}, nil)
+
+		_, err := NewCartService(repo, nil).AddToCart(accountPublicId, input)
+		assert.ErrorIs(t, err, domain.ErrInvalidVariant)
+	})
+
+	t.Run("Can't add unknown product", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockCarter(ctrl)
+		repo.EXPECT().GetProduct(productPublicId).Return(domain.Product{}, domain.ErrProductNotFound)
+
+		_, err := NewCartService(repo, nil).AddToCart(accountPublicId, input)
+		assert.ErrorIs(t, err, domain.ErrProductNotFound)
+	})
+}
+
+func TestCartService_Checkout(t *testing.T) {
+	accountPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	cartPublicId := uuid.MustParse("a3a9fb6c-58e1-4c3b-8d2c-8b6a1d4f2b7e")
+	sofa := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	chair := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+	velvetSofa := uuid.MustParse("d6f1c0a2-3b4e-4f5a-8c9d-0e1f2a3b4c5d")
+	cart := domain.Cart{PublicId: cartPublicId, AccountPublicId: accountPublicId, Items: []domain.CartItem{
+		{ProductPublicId: sofa, Quantity: 1, Price: 9000},
+		{ProductPublicId: chair, Quantity: 2, Price: 500},
+		{ProductPublicId: sofa, VariantPublicId: velvetSofa, Quantity: 1, Price: 11000},
+	}}
+
+	type mockBehavior func(c *mock_repository.MockCarter, o *mock_repository.MockOrderer)
+
+	tests := []struct {
+		name          string
+		mockBehavior  mockBehavior
+		expectedOrder domain.OrderEvent
+		wantErr       error
+	}{
+		{
+			name: "Can price the lines at the checkout and keep the cart price",
+			mockBehavior: func(c *mock_repository.MockCarter, o *mock_repository.MockOrderer) {
+				c.EXPECT().CheckoutCart(accountPublicId.String()).Return(cart, nil)
+				c.EXPECT().GetProduct(sofa).Return(domain.Product{PublicId: sofa, Price: 10000, Discount: 20}, nil)
+				// deleted after the add-to-cart
+				c.EXPECT().GetProduct(chair).Return(domain.Product{}, domain.ErrProductNotFound)
+				// the variant is priced by its own copy
+				c.EXPECT().GetProduct(velvetSofa).Return(domain.Product{PublicId: velvetSofa, Price: 12000, Discount: 20,
+					ParentPublicId: uuid.NullUUID{UUID: sofa, Valid: true}}, nil)
+				o.EXPECT().ChangeStatus(gomock.Any()).DoAndReturn(
+					func(change domain.StatusChange) (domain.StatusChange, bool, error) {
+						assert.Equal(t, cartPublicId, change.OrderPublicId)
+						assert.Equal(t, accountPublicId, change.AccountPublicId)
+						assert.Equal(t, domain.ORDER_CHECKED_OUT, change.Status)
+						return change, false, nil
+					})
+			},
+			expectedOrder: domain.OrderEvent{
+				PublicId:        cartPublicId,
+				AccountPublicId: accountPublicId,
+				Items: []domain.OrderItem{
+					{ProductPublicId: sofa, Quantity: 1, Price: 8000, CartPrice: 9000},
+					{ProductPublicId: chair, Quantity: 2, Price: 500, CartPrice: 500},
+					{ProductPublicId: sofa, VariantPublicId: velvetSofa, Quantity: 1, Price: 9600, CartPrice: 11000},
+				},
+				Total: 18600,
+			},
+		},
+		{
+			name: "Can't check out the empty cart",
+			mockBehavior: func(c *mock_repository.MockCarter, o *mock_repository.MockOrderer) {
+				c.EXPECT().CheckoutCart(accountPublicId.String()).Return(domain.Cart{}, domain.ErrCartEmpty)
+			},
+			wantErr: domain.ErrCartEmpty,
+		},
+		{
+			name: "Can return error if the product copy fails",
+			mockBehavior: func(c *mock_repository.MockCarter, o *mock_repository.MockOrderer) {
+				c.EXPECT().CheckoutCart(accountPublicId.String()).Return(cart, nil)
+				c.EXPECT().GetProduct(sofa).Return(domain.Product{}, errors.New("db is closed"))
+			},
+			wantErr: errors.New("get product: db is closed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			carts := mock_repository.NewMockCarter(ctrl)
+			orders := mock_repository.NewMockOrderer(ctrl)
+			tt.mockBehavior(carts, orders)
+
+			order, err := NewCartService(carts, NewOrderService(orders, stream.NewHub())).Checkout(accountPublicId.String())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOrder, order)
+		})
+	}
+}
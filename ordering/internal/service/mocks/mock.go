@@ -0,0 +1,385 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/p12s/furniture-store/ordering/internal/service (interfaces: Accounter,Orderer,Carter,Privacier)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	domain "github.com/p12s/furniture-store/ordering/internal/domain"
+)
+
+// MockAccounter is a mock of Accounter interface.
+type MockAccounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccounterMockRecorder
+}
+
+// MockAccounterMockRecorder is the mock recorder for MockAccounter.
+type MockAccounterMockRecorder struct {
+	mock *MockAccounter
+}
+
+// NewMockAccounter creates a new mock instance.
+func NewMockAccounter(ctrl *gomock.Controller) *MockAccounter {
+	mock := &MockAccounter{ctrl: ctrl}
+	mock.recorder = &MockAccounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccounter) EXPECT() *MockAccounterMockRecorder {
+	return m.recorder
+}
+
+// CreateAccount mocks base method.
+func (m *MockAccounter) CreateAccount(arg0 domain.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockAccounterMockRecorder) CreateAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAccounter)(nil).CreateAccount), arg0)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccounter) DeleteAccount(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccounterMockRecorder) DeleteAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccounter)(nil).DeleteAccount), arg0)
+}
+
+// GetAccount mocks base method.
+func (m *MockAccounter) GetAccount(arg0 string) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccounterMockRecorder) GetAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccounter)(nil).GetAccount), arg0)
+}
+
+// IssueStreamTicket mocks base method.
+func (m *MockAccounter) IssueStreamTicket(arg0 string) (domain.StreamTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueStreamTicket", arg0)
+	ret0, _ := ret[0].(domain.StreamTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueStreamTicket indicates an expected call of IssueStreamTicket.
+func (mr *MockAccounterMockRecorder) IssueStreamTicket(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueStreamTicket", reflect.TypeOf((*MockAccounter)(nil).IssueStreamTicket), arg0)
+}
+
+// ParseStreamTicket mocks base method.
+func (m *MockAccounter) ParseStreamTicket(arg0 string) (domain.StreamSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseStreamTicket", arg0)
+	ret0, _ := ret[0].(domain.StreamSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseStreamTicket indicates an expected call of ParseStreamTicket.
+func (mr *MockAccounterMockRecorder) ParseStreamTicket(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseStreamTicket", reflect.TypeOf((*MockAccounter)(nil).ParseStreamTicket), arg0)
+}
+
+// ParseToken mocks base method.
+func (m *MockAccounter) ParseToken(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockAccounterMockRecorder) ParseToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAccounter)(nil).ParseToken), arg0)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockAccounter) RevokeSessions(arg0 domain.SessionsRevokedInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAccounterMockRecorder) RevokeSessions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockAccounter)(nil).RevokeSessions), arg0)
+}
+
+// UpdateAccountRole mocks base method.
+func (m *MockAccounter) UpdateAccountRole(arg0 domain.UpdateAccountRoleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountRole", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountRole indicates an expected call of UpdateAccountRole.
+func (mr *MockAccounterMockRecorder) UpdateAccountRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountRole", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountRole), arg0)
+}
+
+// MockOrderer is a mock of Orderer interface.
+type MockOrderer struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrdererMockRecorder
+}
+
+// MockOrdererMockRecorder is the mock recorder for MockOrderer.
+type MockOrdererMockRecorder struct {
+	mock *MockOrderer
+}
+
+// NewMockOrderer creates a new mock instance.
+func NewMockOrderer(ctrl *gomock.Controller) *MockOrderer {
+	mock := &MockOrderer{ctrl: ctrl}
+	mock.recorder = &MockOrdererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderer) EXPECT() *MockOrdererMockRecorder {
+	return m.recorder
+}
+
+// ChangeStatus mocks base method.
+func (m *MockOrderer) ChangeStatus(arg0 domain.StatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MockOrdererMockRecorder) ChangeStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockOrderer)(nil).ChangeStatus), arg0)
+}
+
+// GetAccountOrders mocks base method.
+func (m *MockOrderer) GetAccountOrders(arg0 string) ([]domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountOrders", arg0)
+	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountOrders indicates an expected call of GetAccountOrders.
+func (mr *MockOrdererMockRecorder) GetAccountOrders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountOrders", reflect.TypeOf((*MockOrderer)(nil).GetAccountOrders), arg0)
+}
+
+// Subscribe mocks base method.
+func (m *MockOrderer) Subscribe(arg0, arg1 string, arg2 int64) ([]domain.StatusChange, <-chan domain.StatusChange, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.StatusChange)
+	ret1, _ := ret[1].(<-chan domain.StatusChange)
+	ret2, _ := ret[2].(func())
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockOrdererMockRecorder) Subscribe(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockOrderer)(nil).Subscribe), arg0, arg1, arg2)
+}
+
+// MockCarter is a mock of Carter interface.
+type MockCarter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarterMockRecorder
+}
+
+// MockCarterMockRecorder is the mock recorder for MockCarter.
+type MockCarterMockRecorder struct {
+	mock *MockCarter
+}
+
+// NewMockCarter creates a new mock instance.
+func NewMockCarter(ctrl *gomock.Controller) *MockCarter {
+	mock := &MockCarter{ctrl: ctrl}
+	mock.recorder = &MockCarterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarter) EXPECT() *MockCarterMockRecorder {
+	return m.recorder
+}
+
+// AddToCart mocks base method.
+func (m *MockCarter) AddToCart(arg0 string, arg1 domain.AddToCartInput) (domain.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToCart", arg0, arg1)
+	ret0, _ := ret[0].(domain.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToCart indicates an expected call of AddToCart.
+func (mr *MockCarterMockRecorder) AddToCart(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockCarter)(nil).AddToCart), arg0, arg1)
+}
+
+// Checkout mocks base method.
+func (m *MockCarter) Checkout(arg0 string) (domain.OrderEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", arg0)
+	ret0, _ := ret[0].(domain.OrderEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockCarterMockRecorder) Checkout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockCarter)(nil).Checkout), arg0)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockCarter) DeleteProduct(arg0 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockCarterMockRecorder) DeleteProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockCarter)(nil).DeleteProduct), arg0)
+}
+
+// GetCart mocks base method.
+func (m *MockCarter) GetCart(arg0 string) (domain.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCart", arg0)
+	ret0, _ := ret[0].(domain.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCart indicates an expected call of GetCart.
+func (mr *MockCarterMockRecorder) GetCart(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockCarter)(nil).GetCart), arg0)
+}
+
+// RemoveFromCart mocks base method.
+func (m *MockCarter) RemoveFromCart(arg0 string, arg1, arg2 uuid.UUID) (domain.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromCart", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveFromCart indicates an expected call of RemoveFromCart.
+func (mr *MockCarterMockRecorder) RemoveFromCart(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromCart", reflect.TypeOf((*MockCarter)(nil).RemoveFromCart), arg0, arg1, arg2)
+}
+
+// SaveProduct mocks base method.
+func (m *MockCarter) SaveProduct(arg0 domain.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProduct", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProduct indicates an expected call of SaveProduct.
+func (mr *MockCarterMockRecorder) SaveProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProduct", reflect.TypeOf((*MockCarter)(nil).SaveProduct), arg0)
+}
+
+// MockPrivacier is a mock of Privacier interface.
+type MockPrivacier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacierMockRecorder
+}
+
+// MockPrivacierMockRecorder is the mock recorder for MockPrivacier.
+type MockPrivacierMockRecorder struct {
+	mock *MockPrivacier
+}
+
+// NewMockPrivacier creates a new mock instance.
+func NewMockPrivacier(ctrl *gomock.Controller) *MockPrivacier {
+	mock := &MockPrivacier{ctrl: ctrl}
+	mock.recorder = &MockPrivacierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacier) EXPECT() *MockPrivacierMockRecorder {
+	return m.recorder
+}
+
+// EraseAccountData mocks base method.
+func (m *MockPrivacier) EraseAccountData(arg0 domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseAccountData", arg0)
+	ret0, _ := ret[0].(domain.PrivacyAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseAccountData indicates an expected call of EraseAccountData.
+func (mr *MockPrivacierMockRecorder) EraseAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseAccountData", reflect.TypeOf((*MockPrivacier)(nil).EraseAccountData), arg0)
+}
+
+// ExportAccountData mocks base method.
+func (m *MockPrivacier) ExportAccountData(arg0 domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccountData", arg0)
+	ret0, _ := ret[0].(domain.PrivacyAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccountData indicates an expected call of ExportAccountData.
+func (mr *MockPrivacierMockRecorder) ExportAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccountData", reflect.TypeOf((*MockPrivacier)(nil).ExportAccountData), arg0)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	"github.com/p12s/furniture-store/ordering/internal/repository"
+	"github.com/p12s/furniture-store/ordering/internal/stream"
+)
+
+var _ Orderer = (*OrderService)(nil)
+
+// Orderer - service interface
+type Orderer interface {
+	ChangeStatus(change domain.StatusChange) error
+	GetAccountOrders(accountPublicId string) ([]domain.Order, error)
+	Subscribe(accountPublicId, sessionId string, lastEventId int64) ([]domain.StatusChange, <-chan domain.StatusChange, func(), error)
+}
+
+// OrderService - order status projection, every applied change goes to the account streams
+type OrderService struct {
+	repo repository.Orderer
+	hub  *stream.Hub
+}
+
+// NewOrderService - constructor
+func NewOrderService(repo repository.Orderer, hub *stream.Hub) *OrderService {
+	return &OrderService{
+		repo: repo,
+		hub:  hub,
+	}
+}
+
+// ChangeStatus - late or repeated events are skipped
+func (s *OrderService) ChangeStatus(change domain.StatusChange) error {
+	saved, applied, err := s.repo.ChangeStatus(change)
+	if err != nil {
+		return err
+	}
+	if applied {
+		s.hub.Publish(saved)
+	}
+	return nil
+}
+
+// GetAccountOrders
+func (s *OrderService) GetAccountOrders(accountPublicId string) ([]domain.Order, error) {
+	return s.repo.GetAccountOrders(accountPublicId)
+}
+
+// Subscribe - missed changes after the last event id and the live ones.
+// The live channel is subscribed before the history is read, so nothing is lost in between,
+// a change can come twice - the caller skips ids it has already sent.
+// The live channel is closed when the subscriber is slow or the session is revoked.
+func (s *OrderService) Subscribe(accountPublicId, sessionId string, lastEventId int64) ([]domain.StatusChange,
+	<-chan domain.StatusChange, func(), error) {
+	live, cancel := s.hub.Subscribe(accountPublicId, sessionId)
+
+	missed, err := s.repo.GetAccountStatusChanges(accountPublicId, lastEventId)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, fmt.Errorf("get missed changes: %w", err)
+	}
+
+	return missed, live, cancel, nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/ordering/internal/repository/mocks"
+	"github.com/p12s/furniture-store/ordering/internal/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderService_Subscribe(t *testing.T) {
+	accountPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	orderPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	change := func(id int64, status domain.OrderStatus) domain.StatusChange {
+		return domain.StatusChange{Id: id, OrderPublicId: orderPublicId, AccountPublicId: accountPublicId, Status: status}
+	}
+
+	t.Run("Can get missed changes and the live ones", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockOrderer(ctrl)
+		repo.EXPECT().GetAccountStatusChanges(accountPublicId.String(), int64(1)).
+			Return([]domain.StatusChange{change(2, domain.ORDER_PAYED)}, nil)
+		repo.EXPECT().ChangeStatus(change(3, domain.ORDER_DELIVERED)).Return(change(3, domain.ORDER_DELIVERED), true, nil)
+		service := NewOrderService(repo, stream.NewHub())
+
+		missed, live, cancel, err := service.Subscribe(accountPublicId.String(), "session", 1)
+		assert.NoError(t, err)
+		defer cancel()
+		assert.Equal(t, []domain.StatusChange{change(2, domain.ORDER_PAYED)}, missed)
+
+		assert.NoError(t, service.ChangeStatus(change(3, domain.ORDER_DELIVERED)))
+		assert.Equal(t, change(3, domain.ORDER_DELIVERED), <-live)
+	})
+
+	t.Run("Can return error if the history fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockOrderer(ctrl)
+		repo.EXPECT().GetAccountStatusChanges(accountPublicId.String(), int64(0)).Return(nil, errors.New("db is closed"))
+
+		_, _, _, err := NewOrderService(repo, stream.NewHub()).Subscribe(accountPublicId.String(), "session", 0)
+		assert.EqualError(t, err, "get missed changes: db is closed")
+	})
+}
+
+func TestOrderService_ChangeStatus(t *testing.T) {
+	accountPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	otherPublicId := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+	change := domain.StatusChange{Id: 1, OrderPublicId: uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1"),
+		AccountPublicId: accountPublicId, Status: domain.ORDER_PAYED}
+
+	t.Run("Can fan out the applied change to the account streams", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockOrderer(ctrl)
+		repo.EXPECT().GetAccountStatusChanges(gomock.Any(), int64(0)).Return(nil, nil).Times(3)
+		repo.EXPECT().ChangeStatus(change).Return(change, true, nil)
+		service := NewOrderService(repo, stream.NewHub())
+
+		_, phone, cancelPhone, err := service.Subscribe(accountPublicId.String(), "phone", 0)
+		assert.NoError(t, err)
+		defer cancelPhone()
+		_, laptop, cancelLaptop, err := service.Subscribe(accountPublicId.String(), "laptop", 0)
+		assert.NoError(t, err)
+		defer cancelLaptop()
+		_, other, cancelOther, err := service.Subscribe(otherPublicId.String(), "other", 0)
+		assert.NoError(t, err)
+		defer cancelOther()
+
+		assert.NoError(t, service.ChangeStatus(change))
+
+		assert.Equal(t, change, <-phone)
+		assert.Equal(t, change, <-laptop)
+		assert.Len(t, other, 0)
+	})
+
+	t.Run("Can skip the change that is not applied", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockOrderer(ctrl)
+		repo.EXPECT().GetAccountStatusChanges(accountPublicId.String(), int64(0)).Return(nil, nil)
+		repo.EXPECT().ChangeStatus(change).Return(domain.StatusChange{}, false, nil)
+		service := NewOrderService(repo, stream.NewHub())
+
+		_, live, cancel, err := service.Subscribe(accountPublicId.String(), "session", 0)
+		assert.NoError(t, err)
+		defer cancel()
+
+		assert.NoError(t, service.ChangeStatus(change))
+		assert.Len(t, live, 0)
+	})
+
+	t.Run("Can drop the slow stream and keep the others", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockOrderer(ctrl)
+		repo.EXPECT().GetAccountStatusChanges(accountPublicId.String(), int64(0)).Return(nil, nil).Times(2)
+		repo.EXPECT().ChangeStatus(change).Return(change, true, nil).Times(stream.SUBSCRIBER_BUFFER + 1)
+		service := NewOrderService(repo, stream.NewHub())
+
+		_, slow, cancelSlow, err := service.Subscribe(accountPublicId.String(), "slow", 0)
+		assert.NoError(t, err)
+		defer cancelSlow()
+		_, fast, cancelFast, err := service.Subscribe(accountPublicId.String(), "fast", 0)
+		assert.NoError(t, err)
+		defer cancelFast()
+
+		for i := 0; i <= stream.SUBSCRIBER_BUFFER; i++ {
+			assert.NoError(t, service.ChangeStatus(change))
+			assert.Equal(t, change, <-fast)
+		}
+
+		received := 0
+		for range slow {
+			received++
+		}
+		assert.Equal(t, stream.SUBSCRIBER_BUFFER, received)
+	})
+
+	t.Run("Can return error and publish nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockOrderer(ctrl)
+		repo.EXPECT().GetAccountStatusChanges(accountPublicId.String(), int64(0)).Return(nil, nil)
+		repo.EXPECT().ChangeStatus(change).Return(domain.StatusChange{}, false, errors.New("db is closed"))
+		service := NewOrderService(repo, stream.NewHub())
+
+		_, live, cancel, err := service.Subscribe(accountPublicId.String(), "session", 0)
+		assert.NoError(t, err)
+		defer cancel()
+
+		assert.EqualError(t, service.ChangeStatus(change), "db is closed")
+		assert.Len(t, live, 0)
+	})
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	_ "github.com/golang/mock/mockgen/model"
+
+	"github.com/p12s/furniture-store/ordering/internal/config"
+	"github.com/p12s/furniture-store/ordering/internal/repository"
+	"github.com/p12s/furniture-store/ordering/internal/stream"
+)
+
+//go:generate mockgen -destination mocks/mock.go -package service github.com/p12s/furniture-store/ordering/internal/service Accounter,Orderer,Carter,Privacier
+
+// Service - just service
+type Service struct {
+	Accounter
+	Orderer
+	Carter
+	Privacier
+}
+
+// NewService - constructor
+func NewService(repos *repository.Repository, hub *stream.Hub, auth *config.Auth) *Service {
+	orders := NewOrderService(repos.Orderer, hub)
+	return &Service{
+		Accounter: NewAccountService(repos.Accounter, hub, auth),
+		Orderer:   orders,
+		Carter:    NewCartService(repos.Carter, orders),
+		Privacier: NewPrivacyService(repos.Privacier),
+	}
+}
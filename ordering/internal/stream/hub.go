@@ -0,0 +1,93 @@
+package stream
+
+import (
+	"sync"
+
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+)
+
+// SUBSCRIBER_BUFFER - changes a subscriber can lag behind before it is dropped
+const SUBSCRIBER_BUFFER = 64
+
+// Hub - in-process fan-out of order status changes to the account streams,
+// every stream keeps the session it was opened by
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan domain.StatusChange]string
+}
+
+// NewHub - constructor
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[string]map[chan domain.StatusChange]string),
+	}
+}
+
+// Subscribe - changes of the account orders, call the cancel func when the stream is done
+func (h *Hub) Subscribe(accountPublicId, sessionId string) (<-chan domain.StatusChange, func()) {
+	ch := make(chan domain.StatusChange, SUBSCRIBER_BUFFER)
+
+	h.mu.Lock()
+	if h.subscribers[accountPublicId] == nil {
+		h.subscribers[accountPublicId] = make(map[chan domain.StatusChange]string)
+	}
+	h.subscribers[accountPublicId][ch] = sessionId
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(accountPublicId, ch)
+	}
+}
+
+// Publish - never blocks the consumer: a subscriber with the full buffer is closed,
+// the client reconnects with the last event id and gets the rest from the history
+func (h *Hub) Publish(change domain.StatusChange) {
+	accountPublicId := change.AccountPublicId.String()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[accountPublicId] {
+		select {
+		case ch <- change:
+		default:
+			h.remove(accountPublicId, ch)
+		}
+	}
+}
+
+// CloseSessions - the sessions are revoked, their streams are closed
+func (h *Hub) CloseSessions(accountPublicId string, sessionIds []string) {
+	revoked := make(map[string]struct{}, len(sessionIds))
+	for _, sessionId := range sessionIds {
+		revoked[sessionId] = struct{}{}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch, sessionId := range h.subscribers[accountPublicId] {
+		if _, ok := revoked[sessionId]; ok {
+			h.remove(accountPublicId, ch)
+		}
+	}
+}
+
+// remove - under the lock, closes the channel only once
+func (h *Hub) remove(accountPublicId string, ch chan domain.StatusChange) {
+	channels, ok := h.subscribers[accountPublicId]
+	if !ok {
+		return
+	}
+	if _, ok := channels[ch]; !ok {
+		return
+	}
+
+	delete(channels, ch)
+	close(ch)
+	if len(channels) == 0 {
+		delete(h.subscribers, accountPublicId)
+	}
+}
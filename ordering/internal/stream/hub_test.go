@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub_Publish(t *testing.T) {
+	accountPublicId := uuid.New()
+	change := domain.StatusChange{Id: 1, AccountPublicId: accountPublicId, Status: domain.ORDER_PAYED}
+
+	t.Run("Can deliver change only to the account subscribers", func(t *testing.T) {
+		hub := NewHub()
+		mine, cancelMine := hub.Subscribe(accountPublicId.String(), "session")
+		defer cancelMine()
+		other, cancelOther := hub.Subscribe(uuid.NewString(), "session")
+		defer cancelOther()
+
+		hub.Publish(change)
+
+		assert.Equal(t, change, <-mine)
+		assert.Len(t, other, 0)
+	})
+
+	t.Run("Can drop slow subscriber without blocking", func(t *testing.T) {
+		hub := NewHub()
+		ch, cancel := hub.Subscribe(accountPublicId.String(), "session")
+
+		for i := 0; i <= SUBSCRIBER_BUFFER; i++ {
+			hub.Publish(change)
+		}
+
+		received := 0
+		for range ch {
+			received++
+		}
+		assert.Equal(t, SUBSCRIBER_BUFFER, received)
+		cancel() // already closed by the hub
+	})
+
+	t.Run("Can unsubscribe", func(t *testing.T) {
+		hub := NewHub()
+		ch, cancel := hub.Subscribe(accountPublicId.String(), "session")
+		cancel()
+
+		hub.Publish(change)
+
+		_, ok := <-ch
+		assert.False(t, ok)
+		assert.Empty(t, hub.subscribers)
+	})
+	t.Run("Can close the streams of the revoked sessions", func(t *testing.T) {
+		hub := NewHub()
+		revoked, cancelRevoked := hub.Subscribe(accountPublicId.String(), "revoked")
+		defer cancelRevoked()
+		active, cancelActive := hub.Subscribe(accountPublicId.String(), "active")
+		defer cancelActive()
+
+		hub.CloseSessions(accountPublicId.String(), []string{"revoked"})
+		hub.Publish(change)
+
+		_, ok := <-revoked
+		assert.False(t, ok)
+		assert.Equal(t, change, <-active)
+	})
+}
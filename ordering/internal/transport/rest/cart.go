@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+const variantPublicIdQuery = "variant_public_id"
+
+// @Summary Get my cart
+// @Tags Cart
+// @Description Open cart of the caller, the line price is the one at the add-to-cart
+// @ID getCart
+// @Produce  json
+// @Success 200 {object} domain.Cart
+// @Router /cart [get]
+func (h *Handler) getCart(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	cart, err := h.services.GetCart(accountPublicId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// @Summary Add product to cart
+// @Tags Cart
+// @Description The effective price of the product (or its variant) is snapshot to the cart line
+// @ID addToCart
+// @Accept  json
+// @Produce  json
+// @Param input body domain.AddToCartInput true "product, variant and quantity"
+// @Success 200 {object} domain.Cart
+// @Router /cart/items [post]
+func (h *Handler) addToCart(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	var input domain.AddToCartInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	cart, err := h.services.AddToCart(accountPublicId, input)
+	if errors.Is(err, domain.ErrProductNotFound) {
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidVariant) {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	h.produceOrderEvent(domain.EVENT_ORDER_PRODUCT_ADDED, cartEvent(cart))
+	c.JSON(http.StatusOK, cart)
+}
+
+// @Summary Remove product from cart
+// @Tags Cart
+// @ID removeFromCart
+// @Produce  json
+// @Param id path string true "product public id"
+// @Param variant_public_id query string false "variant public id, the line of the product itself if empty"
+// @Success 200 {object} domain.Cart
+// @Router /cart/items/{id} [delete]
+func (h *Handler) removeFromCart(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	productPublicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	variantPublicId := uuid.Nil
+	if value := c.Query(variantPublicIdQuery); value != "" {
+		variantPublicId, err = uuid.Parse(value)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid variant public id")
+			return
+		}
+	}
+
+	cart, err := h.services.RemoveFromCart(accountPublicId, productPublicId, variantPublicId)
+	if errors.Is(err, domain.ErrCartItemNotFound) {
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// @Summary Check out my cart
+// @Tags Cart
+// @Description The cart becomes the order with the same public id, the lines are priced at the checkout
+// @Description and keep the add-to-cart price as cart_price
+// @ID checkout
+// @Produce  json
+// @Success 201 {object} domain.OrderEvent
+// @Router /cart/checkout [post]
+func (h *Handler) checkout(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	order, err := h.services.Checkout(accountPublicId)
+	if errors.Is(err, domain.ErrCartEmpty) {
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	h.produceOrderEvent(domain.EVENT_ORDER_CHECKED_OUT, order)
+	c.JSON(http.StatusCreated, order)
+}
+
+// produceOrderEvent - order business event
+func (h *Handler) produceOrderEvent(eventType domain.EventType, order domain.OrderEvent) {
+	go func() {
+		err := h.broker.Produce(eventType, h.broker.TopicOrderBE, order)
+		if err != nil {
+			logrus.Errorf("sent order event fail: %s/n", err.Error())
+		}
+	}()
+}
+
+// cartEvent - Order.ProductAdded payload, the lines with the add-to-cart price
+func cartEvent(cart domain.Cart) domain.OrderEvent {
+	order := domain.OrderEvent{
+		PublicId:        cart.PublicId,
+		AccountPublicId: cart.AccountPublicId,
+		Items:           make([]domain.OrderItem, len(cart.Items)),
+	}
+	for i, item := range cart.Items {
+		order.Items[i] = domain.OrderItem{
+			ProductPublicId: item.ProductPublicId,
+			VariantPublicId: item.VariantPublicId,
+			DealerPublicId:  item.DealerPublicId,
+			Quantity:        item.Quantity,
+			Price:           item.Price,
+			CartPrice:       item.Price,
+		}
+		order.Total += item.Price * item.Quantity
+	}
+	return order
+}
@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/ordering/internal/broker"
+	mock_broker "github.com/p12s/furniture-store/ordering/internal/broker/mocks"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	"github.com/p12s/furniture-store/ordering/internal/service"
+	mock_service "github.com/p12s/furniture-store/ordering/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	WAITING_GORUTINE_END_TIME = 100 * time.Millisecond
+)
+
+func TestHandler_addToCart(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockCarter)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	cartPublicId := uuid.MustParse("a3a9fb6c-58e1-4c3b-8d2c-8b6a1d4f2b7e")
+	productPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	addedAt := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	input := domain.AddToCartInput{ProductPublicId: productPublicId, Quantity: 2}
+	cart := domain.Cart{PublicId: cartPublicId, AccountPublicId: uuid.MustParse(accountPublicId), CreatedAt: addedAt,
+		Items: []domain.CartItem{{ProductPublicId: productPublicId, Quantity: 2, Price: 8499, AddedAt: addedAt}}}
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		brokerMockProducer   brokerMockProducer
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Can add product to cart",
+			inputBody: `{"product_public_id":"8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1","quantity":2}`,
+			mockBehavior: func(s *mock_service.MockCarter) {
+				s.EXPECT().AddToCart(accountPublicId, input).Return(cart, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ORDER_PRODUCT_ADDED, "", domain.OrderEvent{
+					PublicId:        cartPublicId,
+					AccountPublicId: cart.AccountPublicId,
+					Items: []domain.OrderItem{{ProductPublicId: productPublicId, Quantity: 2,
+						Price: 8499, CartPrice: 8499}},
+					Total: 16998,
+				}).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"public_id":"a3a9fb6c-58e1-4c3b-8d2c-8b6a1d4f2b7e",` +
+				`"account_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","items":[{` +
+				`"product_public_id":"8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1","variant_public_id":"00000000-0000-0000-0000-000000000000",` +
+				`"dealer_public_id":"00000000-0000-0000-0000-000000000000",` +
+				`"quantity":2,"price":8499,"added_at":"2021-12-01T10:00:00Z"}],"created_at":"2021-12-01T10:00:00Z"}`,
+		},
+		{
+			name:      "Can't add unknown product",
+			inputBody: `{"product_public_id":"8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1","quantity":2}`,
+			mockBehavior: func(s *mock_service.MockCarter) {
+				s.EXPECT().AddToCart(accountPublicId, input).Return(domain.Cart{}, domain.ErrProductNotFound)
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"product not found"}`,
+		},
+		{
+			name:      "Can't add the variant of other product",
+			inputBody: `{"product_public_id":"8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1","variant_public_id":"5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11","quantity":2}`,
+			mockBehavior: func(s *mock_service.MockCarter) {
+				s.EXPECT().AddToCart(accountPublicId, domain.AddToCartInput{ProductPublicId: productPublicId,
+					VariantPublicId: uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"), Quantity: 2}).
+					Return(domain.Cart{}, domain.ErrInvalidVariant)
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid variant"}`,
+		},
+		{
+			name:                 "Can't add zero quantity",
+			inputBody:            `{"product_public_id":"8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1","quantity":0}`,
+			mockBehavior:         func(s *mock_service.MockCarter) {},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Can return error response if service failure",
+			inputBody: `{"product_public_id":"8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1","quantity":2}`,
+			mockBehavior: func(s *mock_service.MockCarter) {
+				s.EXPECT().AddToCart(accountPublicId, input).Return(domain.Cart{}, errors.New(""))
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			carter := mock_service.NewMockCarter(ctrl)
+			tt.mockBehavior(carter)
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+
+			handler := NewHandler(&service.Service{Carter: carter}, &broker.Broker{Producer: brokerProducer})
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/cart/items", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.addToCart)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/cart/items", bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_removeFromCart(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockCarter)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	productPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	variantPublicId := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+
+	tests := []struct {
+		name                 string
+		productId            string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Can remove product from cart",
+			productId: productPublicId.String(),
+			mockBehavior: func(s *mock_service.MockCarter) {
+				s.EXPECT().RemoveFromCart(accountPublicId, productPublicId, uuid.Nil).Return(domain.Cart{Items: []domain.CartItem{}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"public_id":"00000000-0000-0000-0000-000000000000",` +
+				`"account_public_id":"00000000-0000-0000-0000-000000000000","items":[],"created_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:      "Can remove variant from cart",
+			productId: productPublicId.String(),
+			query:     "?variant_public_id=" + variantPublicId.String(),
+			mockBehavior: func(s *mock_service.MockCarter) {
+				s.EXPECT().RemoveFromCart(accountPublicId, productPublicId, variantPublicId).Return(domain.Cart{Items: []domain.CartItem{}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"public_id":"00000000-0000-0000-0000-000000000000",` +
+				`"account_public_id":"00000000-0000-0000-0000-000000000000","items":[],"created_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:      "Can't remove product not in cart",
+			productId: productPublicId.String(),
+			mockBehavior: func(s *mock_service.MockCarter) {
+				s.EXPECT().RemoveFromCart(accountPublicId, productPublicId, uuid.Nil).Return(domain.Cart{}, domain.ErrCartItemNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"cart item not found"}`,
+		},
+		{
+			name:                 "Can't remove by invalid id",
+			productId:            "123",
+			mockBehavior:         func(s *mock_service.MockCarter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid product public id"}`,
+		},
+		{
+			name:                 "Can't remove by invalid variant id",
+			productId:            productPublicId.String(),
+			query:                "?variant_public_id=123",
+			mockBehavior:         func(s *mock_service.MockCarter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid variant public id"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			carter := mock_service.NewMockCarter(ctrl)
+			tt.mockBehavior(carter)
+
+			handler := NewHandler(&service.Service{Carter: carter}, nil)
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.DELETE("/cart/items/:id", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.removeFromCart)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/cart/items/"+tt.productId+tt.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_checkout(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockCarter)
+	type brokerMockProducer func(s *mock_broker.MockProducer)
+
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	order := domain.OrderEvent{
+		PublicId:        uuid.MustParse("a3a9fb6c-58e1-4c3b-8d2c-8b6a1d4f2b7e"),
+		AccountPublicId: uuid.MustParse(accountPublicId),
+		Items: []domain.OrderItem{{ProductPublicId: uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1"),
+			Quantity: 1, Price: 8000, CartPrice: 9000}},
+		Total: 8000,
+	}
+
+	tests := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		brokerMockProducer   brokerMockProducer
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Can check out the cart",
+			mockBehavior: func(s *mock_service.MockCarter) {
+				s.EXPECT().Checkout(accountPublicId).Return(order, nil)
+			},
+			brokerMockProducer: func(s *mock_broker.MockProducer) {
+				s.EXPECT().Produce(domain.EVENT_ORDER_CHECKED_OUT, "", order).Return(nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponseBody: `{"public_id":"a3a9fb6c-58e1-4c3b-8d2c-8b6a1d4f2b7e",` +
+				`"account_public_id":"265cee57-2ff9-4ed3-85e1-d3373fa2a1a5","items":[{` +
+				`"product_public_id":"8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1","variant_public_id":"00000000-0000-0000-0000-000000000000",` +
+				`"dealer_public_id":"00000000-0000-0000-0000-000000000000",` +
+				`"quantity":1,"price":8000,"cart_price":9000}],"total":8000}`,
+		},
+		{
+			name: "Can't check out the empty cart",
+			mockBehavior: func(s *mock_service.MockCarter) {
+				s.EXPECT().Checkout(accountPublicId).Return(domain.OrderEvent{}, domain.ErrCartEmpty)
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"cart is empty"}`,
+		},
+		{
+			name: "Can return error response if service failure",
+			mockBehavior: func(s *mock_service.MockCarter) {
+				s.EXPECT().Checkout(accountPublicId).Return(domain.OrderEvent{}, errors.New(""))
+			},
+			brokerMockProducer:   func(s *mock_broker.MockProducer) {},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			carter := mock_service.NewMockCarter(ctrl)
+			tt.mockBehavior(carter)
+			brokerProducer := mock_broker.NewMockProducer(ctrl)
+			tt.brokerMockProducer(brokerProducer)
+
+			handler := NewHandler(&service.Service{Carter: carter}, &broker.Broker{Producer: brokerProducer})
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/cart/checkout", func(c *gin.Context) {
+				c.Set(accountCtx, accountPublicId)
+			}, handler.checkout)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/cart/checkout", nil)
+
+			r.ServeHTTP(w, req)
+			time.Sleep(WAITING_GORUTINE_END_TIME)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/ordering/internal/broker"
+	"github.com/p12s/furniture-store/ordering/internal/service"
+)
+
+// Handler
+type Handler struct {
+	services *service.Service
+	broker   *broker.Broker
+}
+
+// NewHandler - constructor
+func NewHandler(services *service.Service, broker *broker.Broker) *Handler {
+	return &Handler{services: services, broker: broker}
+}
+
+// InitRoutes - routes
+func (h *Handler) InitRoutes() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(CORSMiddleware())
+
+	router.GET("/health", h.health)
+
+	orders := router.Group("/orders")
+	{
+		orders.GET("", h.userIdentity, h.getAccountOrders)
+		orders.POST("/stream/ticket", h.issueStreamTicket)
+		orders.GET("/stream", h.streamIdentity, h.streamOrderStatuses)
+	}
+
+	cart := router.Group("/cart", h.userIdentity)
+	{
+		cart.GET("", h.getCart)
+		cart.POST("/items", h.addToCart)
+		cart.DELETE("/items/:id", h.removeFromCart)
+		cart.POST("/checkout", h.checkout)
+	}
+
+	return router
+}
+
+// CORSMiddleware - cross site work
+func CORSMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Header("Access-Control-Allow-Credentials", "true")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Methods", "POST,HEAD,PATCH,OPTIONS,GET,PUT,DELETE")
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	authorizationHandler = "Authorization"
+	accountCtx           = "accountPublicId"
+	sessionCtx           = "sessionId"
+	streamTicketQuery    = "ticket"
+)
+
+// userIdentity - checking token
+func (h *Handler) userIdentity(c *gin.Context) {
+	token, err := getBearerToken(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	accountId, err := h.services.Accounter.ParseToken(token)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, "invalid token")
+		return
+	}
+
+	c.Set(accountCtx, accountId)
+}
+
+// streamIdentity - checking stream ticket.
+// Browser EventSource can't set headers, so the stream takes the short-lived ticket param, not the token
+func (h *Handler) streamIdentity(c *gin.Context) {
+	ticket := c.Query(streamTicketQuery)
+	if ticket == "" {
+		newErrorResponse(c, http.StatusUnauthorized, "empty stream ticket")
+		return
+	}
+
+	session, err := h.services.Accounter.ParseStreamTicket(ticket)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, "invalid stream ticket")
+		return
+	}
+
+	c.Set(accountCtx, session.AccountPublicId)
+	c.Set(sessionCtx, session.SessionId)
+}
+
+// getBearerToken - token of the auth header
+func getBearerToken(c *gin.Context) (string, error) {
+	header := c.GetHeader(authorizationHandler)
+	if header == "" {
+		return "", errors.New("empty auth header")
+	}
+
+	headerParts := strings.Split(header, " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+		return "", errors.New("invalid auth header")
+	}
+
+	if headerParts[1] == "" {
+		return "", errors.New("token is empty")
+	}
+
+	return headerParts[1], nil
+}
+
+// getAccountPublicId - getting current account public_id
+func getAccountPublicId(c *gin.Context) (string, error) {
+	id, ok := c.Get(accountCtx)
+	if !ok {
+		return "", errors.New("account public_id not found")
+	}
+
+	idString, ok := id.(string)
+	if !ok {
+		return "", errors.New("account id is of invalid type")
+	}
+
+	return idString, nil
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+)
+
+const (
+	lastEventIdHeader = "Last-Event-ID"
+	lastEventIdQuery  = "last_event_id"
+	streamEvent       = "status"
+)
+
+// heartbeatInterval - keeps idle streams alive behind proxies
+var heartbeatInterval = 15 * time.Second
+
+// @Summary Get my orders
+// @Tags Order
+// @Description Orders of the caller with the current status
+// @ID getAccountOrders
+// @Produce  json
+// @Success 200 {array} domain.Order
+// @Router /orders [get]
+func (h *Handler) getAccountOrders(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	orders, err := h.services.GetAccountOrders(accountPublicId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// @Summary Issue stream ticket
+// @Tags Order
+// @Description Short-lived ticket to open the status stream, browser EventSource can't set the auth header.
+// @Description The ticket works for the stream only and until the session is revoked
+// @ID issueStreamTicket
+// @Produce  json
+// @Success 201 {object} domain.StreamTicket
+// @Router /orders/stream/ticket [post]
+func (h *Handler) issueStreamTicket(c *gin.Context) {
+	token, err := getBearerToken(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	ticket, err := h.services.IssueStreamTicket(token)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, "invalid token")
+		return
+	}
+
+	c.JSON(http.StatusCreated, ticket)
+}
+
+// @Summary Stream order statuses
+// @Tags Order
+// @Description Server-sent events with the caller order status changes.
+// @Description Reconnect with Last-Event-ID header (or last_event_id param) to get the missed ones
+// @ID streamOrderStatuses
+// @Produce  text/event-stream
+// @Param Last-Event-ID header int false "last received event id"
+// @Param last_event_id query int false "last received event id"
+// @Param ticket query string true "stream ticket"
+// @Success 200 {object} domain.StatusChange
+// @Router /orders/stream [get]
+func (h *Handler) streamOrderStatuses(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	lastEventId, err := getLastEventId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid last event id")
+		return
+	}
+
+	missed, live, cancel, err := h.services.Subscribe(accountPublicId, c.GetString(sessionCtx), lastEventId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	send := func(change domain.StatusChange) error {
+		if change.Id <= lastEventId {
+			// came with the history and the live channel both
+			return nil
+		}
+		if err := writeStatusEvent(c, change); err != nil {
+			return err
+		}
+		lastEventId = change.Id
+		return nil
+	}
+
+	for _, change := range missed {
+		if err := send(change); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case change, ok := <-live:
+			if !ok {
+				// dropped as a slow subscriber, the client reconnects from the last event id with a new ticket.
+				// Closed for the revoked session, no new ticket is issued for it
+				return
+			}
+			if err := send(change); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// getLastEventId - header is set by EventSource on reconnect, param is for the first connect
+func getLastEventId(c *gin.Context) (int64, error) {
+	value := c.GetHeader(lastEventIdHeader)
+	if value == "" {
+		value = c.Query(lastEventIdQuery)
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// writeStatusEvent - one server-sent event
+func writeStatusEvent(c *gin.Context, change domain.StatusChange) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", change.Id, streamEvent, data)
+	return err
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/ordering/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/ordering/internal/repository/mocks"
+	"github.com/p12s/furniture-store/ordering/internal/service"
+	mock_service "github.com/p12s/furniture-store/ordering/internal/service/mocks"
+	"github.com/p12s/furniture-store/ordering/internal/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_streamOrderStatuses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	token := "some-token"
+	ticket := "some-ticket"
+	accountPublicId := uuid.MustParse("265cee57-2ff9-4ed3-85e1-d3373fa2a1a5")
+	orderPublicId := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	change := func(id int64, status domain.OrderStatus) domain.StatusChange {
+		return domain.StatusChange{Id: id, OrderPublicId: orderPublicId, AccountPublicId: accountPublicId, Status: status}
+	}
+
+	accounter := mock_service.NewMockAccounter(ctrl)
+	accounter.EXPECT().ParseStreamTicket(ticket).
+		Return(domain.StreamSession{AccountPublicId: accountPublicId.String(), SessionId: "session"}, nil).AnyTimes()
+	accounter.EXPECT().ParseStreamTicket(token).Return(domain.StreamSession{}, errors.New("invalid audience")).AnyTimes()
+	repo := mock_repository.NewMockOrderer(ctrl)
+	hub := stream.NewHub()
+	services := &service.Service{
+		Accounter: accounter,
+		Orderer:   service.NewOrderService(repo, hub),
+	}
+	server := httptest.NewServer(NewHandler(services, nil).InitRoutes())
+	defer server.Close()
+
+	t.Run("Can't stream without ticket", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/orders/stream")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Can't stream with the session token", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/orders/stream?ticket=" + token)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Can't stream with invalid last event id", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/orders/stream?last_event_id=abc&ticket=" + ticket)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Can resume from last event id and get live changes", func(t *testing.T) {
+		// the change 3 comes with the history and live both, it is sent once
+		repo.EXPECT().GetAccountStatusChanges(accountPublicId.String(), int64(1)).
+			Return([]domain.StatusChange{change(2, domain.ORDER_PAYED), change(3, domain.ORDER_TAKED_TO_DELIVER)}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/orders/stream?ticket="+ticket, nil)
+		assert.NoError(t, err)
+		req.Header.Set(lastEventIdHeader, "1")
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		events := make(chan domain.StatusChange)
+		go readStatusEvents(t, bufio.NewReader(resp.Body), events)
+
+		assert.Equal(t, change(2, domain.ORDER_PAYED), receive(t, events))
+		assert.Equal(t, change(3, domain.ORDER_TAKED_TO_DELIVER), receive(t, events))
+
+		for _, live := range []domain.StatusChange{change(3, domain.ORDER_TAKED_TO_DELIVER), change(4, domain.ORDER_DELIVERED)} {
+			live := live
+			repo.EXPECT().ChangeStatus(live).Return(live, true, nil)
+			assert.NoError(t, services.ChangeStatus(live))
+		}
+		assert.Equal(t, change(4, domain.ORDER_DELIVERED), receive(t, events))
+	})
+
+	t.Run("Can close the stream of the revoked session", func(t *testing.T) {
+		repo.EXPECT().GetAccountStatusChanges(accountPublicId.String(), int64(0)).Return(nil, nil)
+
+		resp, err := http.Get(server.URL + "/orders/stream?ticket=" + ticket)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		events := make(chan domain.StatusChange)
+		go readStatusEvents(t, bufio.NewReader(resp.Body), events)
+
+		hub.CloseSessions(accountPublicId.String(), []string{"session"})
+		select {
+		case _, ok := <-events:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("stream was not closed")
+		}
+	})
+}
+
+func TestHandler_issueStreamTicket(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAccounter)
+
+	expiresAt := time.Date(2021, 12, 1, 10, 1, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		authHeader           string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Can issue stream ticket",
+			authHeader: "Bearer some-token",
+			mockBehavior: func(s *mock_service.MockAccounter) {
+				s.EXPECT().IssueStreamTicket("some-token").
+					Return(domain.StreamTicket{Ticket: "some-ticket", ExpiresAt: expiresAt}, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"ticket":"some-ticket","expires_at":"2021-12-01T10:01:00Z"}`,
+		},
+		{
+			name:                 "Can't issue stream ticket without token",
+			mockBehavior:         func(s *mock_service.MockAccounter) {},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"message":"empty auth header"}`,
+		},
+		{
+			name:       "Can't issue stream ticket for the revoked session",
+			authHeader: "Bearer some-token",
+			mockBehavior: func(s *mock_service.MockAccounter) {
+				s.EXPECT().IssueStreamTicket("some-token").Return(domain.StreamTicket{}, errors.New("revoked session"))
+			},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"message":"invalid token"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accounter := mock_service.NewMockAccounter(ctrl)
+			tt.mockBehavior(accounter)
+
+			handler := NewHandler(&service.Service{Accounter: accounter}, nil)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/orders/stream/ticket", nil)
+			if tt.authHeader != "" {
+				req.Header.Set(authorizationHandler, tt.authHeader)
+			}
+			handler.InitRoutes().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+// readStatusEvents - parses the stream, the event id must match the data id
+func readStatusEvents(t *testing.T, reader *bufio.Reader, events chan<- domain.StatusChange) {
+	var id string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			close(events)
+			return
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			var change domain.StatusChange
+			assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &change))
+			assert.Equal(t, id, strconv.FormatInt(change.Id, 10))
+			events <- change
+		}
+	}
+}
+
+func receive(t *testing.T, events <-chan domain.StatusChange) domain.StatusChange {
+	select {
+	case change := <-events:
+		return change
+	case <-time.After(time.Second):
+		t.Fatal("status event was not sent")
+	}
+	return domain.StatusChange{}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/p12s/furniture-store/product/internal/blob/fakes3"
+	"github.com/sirupsen/logrus"
+)
+
+// Config - fake S3 compatible storage, for local and offline image testing only
+type Config struct {
+	Port      int    `envconfig:"FAKES3_PORT" default:"9000"`
+	Region    string `envconfig:"FAKES3_REGION" default:"us-east-1"`
+	AccessKey string `envconfig:"FAKES3_ACCESS_KEY" default:"fakes3"`
+	SecretKey string `envconfig:"FAKES3_SECRET_KEY" default:"fakes3-secret"`
+}
+
+func main() {
+	logrus.SetFormatter(new(logrus.JSONFormatter))
+
+	var cfg Config
+	if err := envconfig.Process("fakes3", &cfg); err != nil {
+		logrus.Fatalf("error loading env variables: %s\n", err.Error())
+	}
+
+	srv := &http.Server{
+		Addr:           ":" + strconv.Itoa(cfg.Port),
+		Handler:        fakes3.NewServer(cfg.Region, map[string]string{cfg.AccessKey: cfg.SecretKey}).Routes(),
+		MaxHeaderBytes: 1 << 20, // 1 MB
+		ReadTimeout:    10 * time.Second,
+		WriteTimeout:   10 * time.Second,
+	}
+	logrus.Print("🪣 fakes3 storage started with port: ", cfg.Port)
+	if err := srv.ListenAndServe(); err != nil {
+		logrus.Fatalf("error while running http server: %s\n", err.Error())
+	}
+}
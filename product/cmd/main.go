@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/p12s/furniture-store/product/internal/blob"
 	"github.com/p12s/furniture-store/product/internal/broker"
 	"github.com/p12s/furniture-store/product/internal/config"
 	"github.com/p12s/furniture-store/product/internal/repository"
@@ -34,11 +35,13 @@ func main() {
 		logrus.Fatalf("failed to initialize db: %s\n", err.Error())
 	}
 
-	repos := repository.NewRepository(db)
+	store, err := blob.NewBlobStore(&cfg.Blob)
 	if err != nil {
-		logrus.Fatalf("failed to initialize authentication token ttl: %s\n", err.Error())
+		logrus.Fatalf("blob store create fail: %s\n", err.Error())
 	}
-	services := service.NewService(repos, &cfg.Auth)
+
+	repos := repository.NewRepository(db)
+	services := service.NewService(repos, store, &cfg.Auth, &cfg.Image, &cfg.Stock)
 	broker, err := broker.NewBroker(services, &cfg.Broker)
 	if err != nil {
 		logrus.Fatalf("broker create fail: %s\n", err.Error())
@@ -49,20 +52,24 @@ func main() {
 		}
 	}()
 	handlers := handler.NewHandler(services, broker)
+	router := handlers.InitRoutes()
+	if cfg.Blob.Store == blob.STORE_LOCAL {
+		router.Static(blob.LOCAL_PATH, cfg.Blob.LocalDir)
+	}
 
 	srv := new(Server)
 	go func() {
-		if err := srv.Run(cfg.Server.Port, handlers.InitRoutes()); err != nil {
+		if err := srv.Run(cfg.Server.Port, router); err != nil {
 			logrus.Fatalf("error while running http server: %s\n", err.Error())
 		}
 	}()
-	logrus.Print("😀 account app started with port: ", cfg.Server.Port)
+	logrus.Print("😀 product app started with port: ", cfg.Server.Port)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
 	<-quit
 
-	logrus.Print("account app shutting down")
+	logrus.Print("product app shutting down")
 	if err := srv.Shutdown(context.Background()); err != nil {
 		logrus.Errorf("error occurred on server shutting down: %s", err.Error())
 	}
@@ -0,0 +1,43 @@
+package blob
+
+import (
+	"context"
+
+	"github.com/p12s/furniture-store/product/internal/blob/local"
+	"github.com/p12s/furniture-store/product/internal/blob/s3"
+	"github.com/p12s/furniture-store/product/internal/config"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+const (
+	STORE_LOCAL = "local"
+	STORE_S3    = "s3"
+
+	// LOCAL_PATH - route of the local store files on the product service
+	LOCAL_PATH = "/images"
+)
+
+// BlobStore - binary object storage, keys are slash separated paths.
+// Deleting a missing blob is not an error
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// NewBlobStore - constructor, store is chosen by config
+func NewBlobStore(config *config.Blob) (BlobStore, error) {
+	switch config.Store {
+	case STORE_LOCAL:
+		return local.NewStore(config.LocalDir, config.URL)
+	case STORE_S3:
+		return s3.NewClient(config.S3Endpoint, config.S3Bucket, config.URL, s3.Credentials{
+			AccessKey: config.S3AccessKey,
+			SecretKey: config.S3SecretKey,
+			Region:    config.S3Region,
+		}), nil
+	default:
+		return nil, domain.ErrUnknownBlobStore
+	}
+}
@@ -0,0 +1,98 @@
+package fakes3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/p12s/furniture-store/product/internal/blob/s3"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	TEST_REGION = "us-east-1"
+	TEST_BUCKET = "fur-product"
+)
+
+var testCredentials = s3.Credentials{AccessKey: "access", SecretKey: "secret", Region: TEST_REGION}
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(NewServer(TEST_REGION, map[string]string{"access": "secret"}).Routes())
+	defer server.Close()
+	client := s3.NewClient(server.URL, TEST_BUCKET, server.URL+"/"+TEST_BUCKET, testCredentials)
+	ctx := context.Background()
+	key := "products/1/thumb image.jpg"
+
+	t.Run("Can put and get object", func(t *testing.T) {
+		assert.NoError(t, client.Put(ctx, key, "image/jpeg", []byte("jpeg data")))
+
+		data, err := client.Get(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("jpeg data"), data)
+	})
+
+	t.Run("Can read object by public url", func(t *testing.T) {
+		resp, err := http.Get(client.URL(key))
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/jpeg", resp.Header.Get("Content-Type"))
+	})
+
+	t.Run("Can't put object with wrong secret", func(t *testing.T) {
+		wrong := s3.NewClient(server.URL, TEST_BUCKET, "", s3.Credentials{
+			AccessKey: "access", SecretKey: "wrong", Region: TEST_REGION})
+		assert.Error(t, wrong.Put(ctx, "products/2/original.png", "image/png", []byte("png data")))
+
+		_, err := client.Get(ctx, "products/2/original.png")
+		assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+	})
+
+	t.Run("Can delete object twice", func(t *testing.T) {
+		assert.NoError(t, client.Delete(ctx, key))
+		assert.NoError(t, client.Delete(ctx, key))
+
+		_, err := client.Get(ctx, key)
+		assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	secrets := map[string]string{"access": "secret"}
+	now := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	payload := []byte("data")
+
+	signed := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPut, "http://storage/fur-product/products/1/original.jpg", nil)
+		s3.Sign(req, payload, testCredentials, now)
+		return req
+	}
+
+	t.Run("Can verify signed request", func(t *testing.T) {
+		assert.NoError(t, s3.Verify(signed(), payload, TEST_REGION, secrets, now.Add(time.Minute)))
+	})
+
+	t.Run("Can't verify changed payload", func(t *testing.T) {
+		assert.ErrorIs(t, s3.Verify(signed(), []byte("other"), TEST_REGION, secrets, now), s3.ErrInvalidSignature)
+	})
+
+	t.Run("Can't verify changed path", func(t *testing.T) {
+		req := signed()
+		req.URL.Path = "/fur-product/products/2/original.jpg"
+		assert.ErrorIs(t, s3.Verify(req, payload, TEST_REGION, secrets, now), s3.ErrInvalidSignature)
+	})
+
+	t.Run("Can't verify old request", func(t *testing.T) {
+		assert.ErrorIs(t, s3.Verify(signed(), payload, TEST_REGION, secrets, now.Add(time.Hour)),
+			s3.ErrRequestTimeSkewed)
+	})
+
+	t.Run("Can't verify unknown access key", func(t *testing.T) {
+		assert.ErrorIs(t, s3.Verify(signed(), payload, TEST_REGION, map[string]string{}, now),
+			s3.ErrUnknownAccessKey)
+	})
+}
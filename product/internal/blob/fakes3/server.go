@@ -0,0 +1,152 @@
+package fakes3
+
+import (
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/product/internal/blob/s3"
+)
+
+// Server - local S3 compatible stand-in, keeps objects in memory.
+// Writes must be signed, reads are public like a bucket with the public-read policy
+type Server struct {
+	region  string
+	secrets map[string]string
+	mu      sync.Mutex
+	objects map[string]object
+}
+
+type object struct {
+	contentType string
+	data        []byte
+}
+
+// NewServer - constructor, secrets are by access keys
+func NewServer(region string, secrets map[string]string) *Server {
+	return &Server{
+		region:  region,
+		secrets: secrets,
+		objects: make(map[string]object),
+	}
+}
+
+// Routes - path style: /bucket/key
+func (s *Server) Routes() *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+
+	router.PUT("/:bucket/*key", s.put)
+	router.GET("/:bucket/*key", s.get)
+	router.DELETE("/:bucket/*key", s.delete)
+
+	return router
+}
+
+// errorResponse - S3 error document
+type errorResponse struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+func (s *Server) put(c *gin.Context) {
+	name, ok := objectName(c)
+	if !ok {
+		return
+	}
+	data, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		abort(c, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+	if !s.verify(c, data) {
+		return
+	}
+
+	s.mu.Lock()
+	s.objects[name] = object{contentType: c.GetHeader("Content-Type"), data: data}
+	s.mu.Unlock()
+
+	c.Status(http.StatusOK)
+}
+
+func (s *Server) get(c *gin.Context) {
+	name, ok := objectName(c)
+	if !ok {
+		return
+	}
+	if c.GetHeader(s3.HEADER_AUTHORIZATION) != "" && !s.verify(c, nil) {
+		return
+	}
+
+	s.mu.Lock()
+	stored, found := s.objects[name]
+	s.mu.Unlock()
+
+	if !found {
+		abort(c, http.StatusNotFound, "NoSuchKey", "the specified key does not exist")
+		return
+	}
+	contentType := stored.contentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Data(http.StatusOK, contentType, stored.data)
+}
+
+func (s *Server) delete(c *gin.Context) {
+	name, ok := objectName(c)
+	if !ok {
+		return
+	}
+	if !s.verify(c, nil) {
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.objects, name)
+	s.mu.Unlock()
+
+	c.Status(http.StatusNoContent)
+}
+
+// verify - false when the error is sent
+func (s *Server) verify(c *gin.Context, payload []byte) bool {
+	err := s3.Verify(c.Request, payload, s.region, s.secrets, time.Now())
+	switch {
+	case errors.Is(err, s3.ErrMissingSignature):
+		abort(c, http.StatusForbidden, "AccessDenied", err.Error())
+		return false
+	case errors.Is(err, s3.ErrUnknownAccessKey):
+		abort(c, http.StatusForbidden, "InvalidAccessKeyId", err.Error())
+		return false
+	case errors.Is(err, s3.ErrRequestTimeSkewed):
+		abort(c, http.StatusForbidden, "RequestTimeTooSkewed", err.Error())
+		return false
+	case err != nil:
+		abort(c, http.StatusForbidden, "SignatureDoesNotMatch", err.Error())
+		return false
+	}
+	return true
+}
+
+// objectName - bucket and key, false when the error is sent
+func objectName(c *gin.Context) (string, bool) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if key == "" {
+		abort(c, http.StatusBadRequest, "InvalidRequest", "object key is empty")
+		return "", false
+	}
+	return c.Param("bucket") + "/" + key, true
+}
+
+func abort(c *gin.Context, status int, code, message string) {
+	c.Abort()
+	c.XML(status, errorResponse{Code: code, Message: message})
+}
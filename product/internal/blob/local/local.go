@@ -0,0 +1,85 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+// Store - blobs are files under the directory, served by the service itself at the url
+type Store struct {
+	dir string
+	url string
+}
+
+// NewStore - constructor, the directory is created if there is none
+func NewStore(dir, url string) (*Store, error) {
+	if dir == "" {
+		return nil, errors.New("local store directory is empty")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create local store directory: %w", err)
+	}
+	return &Store{dir: dir, url: strings.TrimRight(url, "/")}, nil
+}
+
+// Put - written to a temporary file first, so a reader never gets a half of the blob
+func (s *Store) Put(ctx context.Context, key, contentType string, data []byte) error {
+	name := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return fmt.Errorf("create blob directory: %w", err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // nolint
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close() // nolint
+		return fmt.Errorf("write blob file: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write blob file: %w", err)
+	}
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("write blob file: %w", err)
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Get
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, domain.ErrBlobNotFound
+	}
+	return data, err
+}
+
+// Delete
+func (s *Store) Delete(ctx context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// URL
+func (s *Store) URL(key string) string {
+	return s.url + "/" + strings.TrimLeft(path.Clean("/"+key), "/")
+}
+
+// path - file of the key, a key can't leave the store directory
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(path.Clean("/"+key)))
+}
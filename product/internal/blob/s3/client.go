@@ -0,0 +1,117 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+const (
+	CLIENT_TIMEOUT = 30 * time.Second
+)
+
+// Client - S3 compatible object storage with path style urls: endpoint/bucket/key.
+// Works with AWS, MinIO and the local fakes3 stand-in
+type Client struct {
+	endpoint    string
+	bucket      string
+	url         string
+	credentials Credentials
+	client      *http.Client
+}
+
+// NewClient - constructor, url is the public address of the bucket
+func NewClient(endpoint, bucket, url string, credentials Credentials) *Client {
+	return &Client{
+		endpoint:    strings.TrimRight(endpoint, "/"),
+		bucket:      bucket,
+		url:         strings.TrimRight(url, "/"),
+		credentials: credentials,
+		client:      &http.Client{Timeout: CLIENT_TIMEOUT},
+	}
+}
+
+// Put
+func (c *Client) Put(ctx context.Context, key, contentType string, data []byte) error {
+	resp, err := c.do(ctx, http.MethodPut, key, contentType, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusError(http.MethodPut, key, resp)
+	}
+	return nil
+}
+
+// Get
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return ioutil.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, domain.ErrBlobNotFound
+	default:
+		return nil, statusError(http.MethodGet, key, resp)
+	}
+}
+
+// Delete
+func (c *Client) Delete(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return statusError(http.MethodDelete, key, resp)
+	}
+}
+
+// URL
+func (c *Client) URL(key string) string {
+	return c.url + "/" + escapePath(key)
+}
+
+// do - signed request to the object
+func (c *Client) do(ctx context.Context, method, key, contentType string, data []byte) (*http.Response, error) {
+	url := c.endpoint + "/" + escapePath(c.bucket) + "/" + escapePath(key)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("s3 request fail: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	Sign(req, data, c.credentials, time.Now())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s %s fail: %w", method, key, err)
+	}
+	return resp, nil
+}
+
+// statusError - S3 errors are xml documents, the beginning is enough for the log
+func statusError(method, key string, resp *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("s3 %s %s: status %d: %s", method, key, resp.StatusCode, strings.TrimSpace(string(body)))
+}
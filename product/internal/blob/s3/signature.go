@@ -0,0 +1,193 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	SIGNATURE_ALGORITHM = "AWS4-HMAC-SHA256"
+	SIGNATURE_SERVICE   = "s3"
+	SIGNATURE_REQUEST   = "aws4_request"
+	AMZ_DATE_FORMAT     = "20060102T150405Z"
+	SCOPE_DATE_FORMAT   = "20060102"
+
+	HEADER_AUTHORIZATION  = "Authorization"
+	HEADER_DATE           = "X-Amz-Date"
+	HEADER_CONTENT_SHA256 = "X-Amz-Content-Sha256"
+
+	// MAX_CLOCK_SKEW - signed request lifetime
+	MAX_CLOCK_SKEW = 15 * time.Minute
+)
+
+var (
+	ErrMissingSignature  = errors.New("request is not signed")
+	ErrInvalidSignature  = errors.New("signature does not match")
+	ErrUnknownAccessKey  = errors.New("unknown access key")
+	ErrRequestTimeSkewed = errors.New("request time is too skewed")
+)
+
+// signedHeaders - the minimal set, the content type is not signed
+var signedHeaders = []string{"host", "x-amz-content-sha256", "x-amz-date"}
+
+// Credentials - access key pair and the bucket region
+type Credentials struct {
+	AccessKey string
+	SecretKey string
+	Region    string
+}
+
+// Sign - AWS signature version 4 in the Authorization header, the payload is hashed whole
+func Sign(req *http.Request, payload []byte, credentials Credentials, now time.Time) {
+	now = now.UTC()
+	payloadHash := hashHex(payload)
+	req.Header.Set(HEADER_CONTENT_SHA256, payloadHash)
+	req.Header.Set(HEADER_DATE, now.Format(AMZ_DATE_FORMAT))
+
+	scope := credentialScope(now, credentials.Region)
+	signature := signature(req, signedHeaders, payloadHash, scope, credentials.SecretKey, now)
+	req.Header.Set(HEADER_AUTHORIZATION, fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		SIGNATURE_ALGORITHM, credentials.AccessKey, scope, strings.Join(signedHeaders, ";"), signature))
+}
+
+// Verify - checks the Authorization header by the secret of its access key, secrets are by access keys
+func Verify(req *http.Request, payload []byte, region string, secrets map[string]string, now time.Time) error {
+	header := req.Header.Get(HEADER_AUTHORIZATION)
+	if header == "" {
+		return ErrMissingSignature
+	}
+	if !strings.HasPrefix(header, SIGNATURE_ALGORITHM+" ") {
+		return fmt.Errorf("%w: unsupported algorithm", ErrInvalidSignature)
+	}
+
+	fields := make(map[string]string)
+	for _, field := range strings.Split(strings.TrimPrefix(header, SIGNATURE_ALGORITHM+" "), ",") {
+		parts := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(parts) == 2 {
+			fields[parts[0]] = parts[1]
+		}
+	}
+	credential := strings.SplitN(fields["Credential"], "/", 2)
+	if len(credential) != 2 || fields["SignedHeaders"] == "" || fields["Signature"] == "" {
+		return fmt.Errorf("%w: malformed authorization header", ErrInvalidSignature)
+	}
+	secret, ok := secrets[credential[0]]
+	if !ok {
+		return ErrUnknownAccessKey
+	}
+
+	signedAt, err := time.Parse(AMZ_DATE_FORMAT, req.Header.Get(HEADER_DATE))
+	if err != nil {
+		return fmt.Errorf("%w: invalid request date", ErrInvalidSignature)
+	}
+	if skew := now.Sub(signedAt); skew > MAX_CLOCK_SKEW || skew < -MAX_CLOCK_SKEW {
+		return ErrRequestTimeSkewed
+	}
+	scope := credentialScope(signedAt, region)
+	if credential[1] != scope {
+		return fmt.Errorf("%w: invalid credential scope", ErrInvalidSignature)
+	}
+
+	payloadHash := hashHex(payload)
+	if req.Header.Get(HEADER_CONTENT_SHA256) != payloadHash {
+		return fmt.Errorf("%w: payload hash does not match", ErrInvalidSignature)
+	}
+
+	expected := signature(req, strings.Split(fields["SignedHeaders"], ";"), payloadHash, scope, secret, signedAt)
+	if !hmac.Equal([]byte(expected), []byte(fields["Signature"])) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// signature - hex HMAC of the string to sign with the key derived for the date, region and service
+func signature(req *http.Request, headers []string, payloadHash, scope, secret string, signedAt time.Time) string {
+	canonical := canonicalRequest(req, headers, payloadHash)
+	stringToSign := strings.Join([]string{
+		SIGNATURE_ALGORITHM,
+		signedAt.Format(AMZ_DATE_FORMAT),
+		scope,
+		hashHex([]byte(canonical)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secret), signedAt.Format(SCOPE_DATE_FORMAT))
+	key = hmacSHA256(key, strings.Split(scope, "/")[1])
+	key = hmacSHA256(key, SIGNATURE_SERVICE)
+	key = hmacSHA256(key, SIGNATURE_REQUEST)
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalRequest - method, path, sorted query, signed headers and the payload hash
+func canonicalRequest(req *http.Request, headers []string, payloadHash string) string {
+	uri := req.URL.EscapedPath()
+	if uri == "" {
+		uri = "/"
+	}
+	query := strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20")
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headers {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		uri,
+		query,
+		canonicalHeaders.String(),
+		strings.Join(headers, ";"),
+		payloadHash,
+	}, "\n")
+}
+
+// credentialScope - date/region/service/aws4_request
+func credentialScope(signedAt time.Time, region string) string {
+	return strings.Join([]string{signedAt.UTC().Format(SCOPE_DATE_FORMAT), region,
+		SIGNATURE_SERVICE, SIGNATURE_REQUEST}, "/")
+}
+
+// escapePath - every key segment is uri encoded, only unreserved characters are kept
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		var escaped strings.Builder
+		for _, b := range []byte(segment) {
+			if isUnreserved(b) {
+				escaped.WriteByte(b)
+				continue
+			}
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+		segments[i] = escaped.String()
+	}
+	return strings.Join(segments, "/")
+}
+
+func isUnreserved(b byte) bool {
+	return 'A' <= b && b <= 'Z' || 'a' <= b && b <= 'z' || '0' <= b && b <= '9' ||
+		b == '-' || b == '_' || b == '.' || b == '~'
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data)) // nolint
+	return mac.Sum(nil)
+}
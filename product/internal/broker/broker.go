@@ -19,6 +19,7 @@ type Broker struct {
 	TopicOrderBE, TopicOrderCUD       string
 	TopicDeliveryBE, TopicDeliveryCUD string
 	TopicBillingBE, TopicBillingCUD   string
+	TopicPrivacy                      string
 }
 
 // NewBroker - constructor
@@ -27,7 +28,7 @@ func NewBroker(service *service.Service, config *config.Broker) (*Broker, error)
 	if err != nil {
 		return nil, fmt.Errorf("broker producer fail: %w/n", err)
 	}
-	consumer, err := NewConsumer(service, config)
+	consumer, err := NewConsumer(service, producer, config)
 	if err != nil {
 		return nil, fmt.Errorf("broker consumer fail: %w/n", err)
 	}
@@ -45,5 +46,6 @@ func NewBroker(service *service.Service, config *config.Broker) (*Broker, error)
 		TopicDeliveryCUD: config.TopicDeliveryCUD,
 		TopicBillingBE:   config.TopicBillingBE,
 		TopicBillingCUD:  config.TopicBillingCUD,
+		TopicPrivacy:     config.TopicPrivacy,
 	}, nil
 }
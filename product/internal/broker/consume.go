@@ -30,14 +30,16 @@ type Consumer interface {
 type BrokerConsume struct {
 	connection                        *kafka.Consumer
 	service                           *service.Service
+	producer                          Producer
 	TopicAccountBE, TopicAccountCUD   string
 	TopicProductBE, TopicProductCUD   string
 	TopicOrderBE, TopicOrderCUD       string
 	TopicDeliveryBE, TopicDeliveryCUD string
 	TopicBillingBE, TopicBillingCUD   string
+	TopicPrivacy                      string
 }
 
-func NewConsumer(service *service.Service, conf *config.Broker) (*BrokerConsume, error) {
+func NewConsumer(service *service.Service, producer Producer, conf *config.Broker) (*BrokerConsume, error) {
 	connection, err := kafka.NewConsumer(&kafka.ConfigMap{
 		"metadata.broker.list": conf.Brokers,
 		"security.protocol":    SECURITY_PROTOCOL,
@@ -54,6 +56,7 @@ func NewConsumer(service *service.Service, conf *config.Broker) (*BrokerConsume,
 	return &BrokerConsume{
 		connection:       connection,
 		service:          service,
+		producer:         producer,
 		TopicAccountBE:   conf.TopicAccountBE,
 		TopicAccountCUD:  conf.TopicAccountCUD,
 		TopicProductBE:   conf.TopicProductBE,
@@ -64,6 +67,7 @@ func NewConsumer(service *service.Service, conf *config.Broker) (*BrokerConsume,
 		TopicDeliveryCUD: conf.TopicDeliveryCUD,
 		TopicBillingBE:   conf.TopicBillingBE,
 		TopicBillingCUD:  conf.TopicBillingCUD,
+		TopicPrivacy:     conf.TopicPrivacy,
 	}, nil
 }
 
@@ -71,21 +75,11 @@ func (k *BrokerConsume) Subscribe() error {
 	sigchan := make(chan os.Signal, 1)
 	signal.Notify(sigchan, syscall.SIGINT, syscall.SIGTERM)
 
-	topics := []string{
-		k.TopicAccountBE, k.TopicAccountCUD,
-		k.TopicProductBE, k.TopicProductCUD,
-		k.TopicOrderBE, k.TopicOrderCUD,
-		k.TopicDeliveryBE, k.TopicDeliveryCUD,
-		k.TopicBillingBE, k.TopicBillingCUD,
-	}
-	fmt.Printf("topics: %T %v /n", topics, topics)
-
+	// products are owned here, only the account copies, the order lines and the delivered orders come from the outside
 	err := k.connection.SubscribeTopics([]string{
 		k.TopicAccountBE, k.TopicAccountCUD,
-		k.TopicProductBE, k.TopicProductCUD,
 		k.TopicOrderBE, k.TopicOrderCUD,
-		k.TopicDeliveryBE, k.TopicDeliveryCUD,
-		k.TopicBillingBE, k.TopicBillingCUD,
+		k.TopicDeliveryBE,
 	}, nil)
 	if err != nil {
 		return fmt.Errorf("subscribe broker topics fail: %w", err)
@@ -102,7 +96,6 @@ func (k *BrokerConsume) Subscribe() error {
 			if err != nil {
 				continue
 			}
-			fmt.Printf("✅ Message on %s:\nvalue: %s\n", ev.TopicPartition, string(ev.Value)) // TODO удалить вывод после реализации/обкатки всех событий
 			var eventData domain.Event
 			err = json.Unmarshal(ev.Value, &eventData)
 			if err != nil {
@@ -139,15 +132,47 @@ func (k *BrokerConsume) ProcessEvent(event domain.Event) {
 			logrus.Errorf("process 'update account role' event fail: %s/n", err.Error())
 		}
 	case domain.EVENT_ACCOUNT_TOKEN_UPDATED:
-		err := k.updateAccountToken(event.Value)
-		if err != nil {
-			logrus.Errorf("process 'update account token' event fail: %s/n", err.Error())
-		}
+		// the tokens are not stored, the sign-in event doesn't change the account copy
 	case domain.EVENT_ACCOUNT_DELETED:
 		err := k.deleteAccount(event.Value)
 		if err != nil {
 			logrus.Errorf("process 'delete account' event fail: %s/n", err.Error())
 		}
+	case domain.EVENT_ACCOUNT_SESSIONS_REVOKED:
+		err := k.revokeSessions(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'revoke sessions' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_API_KEY_CREATED:
+		err := k.createAPIKey(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'create api key' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_API_KEY_REVOKED:
+		err := k.revokeAPIKey(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'revoke api key' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_EXPORT_REQUESTED:
+		err := k.exportAccountData(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'export account data' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ACCOUNT_ERASURE_REQUESTED:
+		err := k.eraseAccountData(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'erase account data' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ORDER_CHECKED_OUT, domain.EVENT_ORDER_PAYED:
+		err := k.orderLines(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'order lines' event fail: %s/n", err.Error())
+		}
+	case domain.EVENT_ORDER_DELIVERED:
+		err := k.orderDelivered(event.Value)
+		if err != nil {
+			logrus.Errorf("process 'order delivered' event fail: %s/n", err.Error())
+		}
 	default:
 		fmt.Printf("unknown event type: %v/n", event.Value)
 	}
@@ -166,6 +191,7 @@ func (k *BrokerConsume) createAccount(payload interface{}) error {
 		Username: account.Username,
 		Email:    account.Email,
 		Address:  account.Address,
+		Role:     account.Role,
 	})
 }
 
@@ -176,14 +202,7 @@ func (k *BrokerConsume) updateAccountInfo(payload interface{}) error {
 		return fmt.Errorf("account-update info payload fail: %w/n", err)
 	}
 
-	return k.service.UpdateAccountInfo(domain.UpdateAccountInput{
-		PublicId: data.PublicId,
-		Name:     data.Name,
-		Username: data.Username,
-		Password: data.Password,
-		Email:    data.Email,
-		Address:  data.Address,
-	})
+	return k.service.UpdateAccountInfo(data)
 }
 
 func (k *BrokerConsume) updateAccountRole(payload interface{}) error {
@@ -193,33 +212,96 @@ func (k *BrokerConsume) updateAccountRole(payload interface{}) error {
 		return fmt.Errorf("account-role update payload fail: %w/n", err)
 	}
 
-	return k.service.UpdateAccountRole(domain.UpdateAccountRoleInput{
-		PublicId: data.PublicId,
-		Role:     data.Role,
-	})
+	return k.service.UpdateAccountRole(data)
 }
 
-func (k *BrokerConsume) updateAccountToken(payload interface{}) error {
-	var data domain.UpdateAccountRoleInput // TODO тело не свое пока
+func (k *BrokerConsume) deleteAccount(payload interface{}) error {
+	var data domain.DeleteAccountInput
 	err := readPayload(payload, &data)
 	if err != nil {
-		return fmt.Errorf("account-role update payload fail: %w/n", err)
+		return fmt.Errorf("delete-account payload fail: %w/n", err)
 	}
 
-	return k.service.UpdateAccountRole(domain.UpdateAccountRoleInput{
-		PublicId: data.PublicId,
-		Role:     data.Role,
-	})
+	return k.service.DeleteAccount(data.PublicId)
 }
 
-func (k *BrokerConsume) deleteAccount(payload interface{}) error {
-	var data domain.DeleteAccountInput
+func (k *BrokerConsume) revokeSessions(payload interface{}) error {
+	var data domain.SessionsRevokedInput
 	err := readPayload(payload, &data)
 	if err != nil {
-		return fmt.Errorf("delete-account payload fail: %w/n", err)
+		return fmt.Errorf("revoke-sessions payload fail: %w/n", err)
 	}
 
-	return k.service.DeleteAccount(data.PublicId)
+	return k.service.RevokeSessions(data)
+}
+
+func (k *BrokerConsume) createAPIKey(payload interface{}) error {
+	var data domain.APIKeyCreatedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("api-key create payload fail: %w/n", err)
+	}
+
+	return k.service.CreateAPIKey(data)
+}
+
+func (k *BrokerConsume) revokeAPIKey(payload interface{}) error {
+	var data domain.APIKeyRevokedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("api-key revoke payload fail: %w/n", err)
+	}
+
+	return k.service.RevokeAPIKey(data)
+}
+
+// exportAccountData - the answer goes to the privacy topic, only the account service reads it
+func (k *BrokerConsume) exportAccountData(payload interface{}) error {
+	var data domain.PrivacyRequestedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("export-account-data payload fail: %w/n", err)
+	}
+
+	answer, err := k.service.ExportAccountData(data)
+	if err != nil {
+		return err
+	}
+	return k.producer.Produce(domain.EVENT_PRIVACY_DATA_EXPORTED, k.TopicPrivacy, answer)
+}
+
+func (k *BrokerConsume) eraseAccountData(payload interface{}) error {
+	var data domain.PrivacyRequestedInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("erase-account-data payload fail: %w/n", err)
+	}
+
+	answer, err := k.service.EraseAccountData(data)
+	if err != nil {
+		return err
+	}
+	return k.producer.Produce(domain.EVENT_PRIVACY_ERASURE_COMPLETED, k.TopicPrivacy, answer)
+}
+
+func (k *BrokerConsume) orderLines(payload interface{}) error {
+	var data domain.OrderLinesInput
+	err := readPayload(payload, &data)
+	if err != nil {
+		return fmt.Errorf("order-lines payload fail: %w/n", err)
+	}
+
+	return k.service.OrderLines(data)
+}
+
+func (k *BrokerConsume) orderDelivered(payload interface{}) error {
+	var order domain.DeliveredOrder
+	err := readPayload(payload, &order)
+	if err != nil {
+		return fmt.Errorf("order-delivered payload fail: %w/n", err)
+	}
+
+	return k.service.OrderDelivered(order)
 }
 
 func readPayload(payload interface{}, target interface{}) error {
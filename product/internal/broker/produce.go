@@ -25,11 +25,6 @@ type Producer interface {
 
 type BrokerProduce struct {
 	connection *kafka.Producer
-	// TopicAccountBE, TopicAccountCUD   string
-	// TopicProductBE, TopicProductCUD   string
-	// TopicOrderBE, TopicOrderCUD       string
-	// TopicDeliveryBE, TopicDeliveryCUD string
-	// TopicBillingBE, TopicBillingCUD   string
 }
 
 func NewProducer(conf *config.Broker) (*BrokerProduce, error) { // ???? return error
@@ -46,16 +41,6 @@ func NewProducer(conf *config.Broker) (*BrokerProduce, error) { // ???? return e
 
 	return &BrokerProduce{
 		connection: connection,
-		// TopicAccountBE:   conf.TopicAccountBE,
-		// TopicAccountCUD:  conf.TopicAccountCUD,
-		// TopicProductBE:   conf.TopicProductBE,
-		// TopicProductCUD:  conf.TopicProductCUD,
-		// TopicOrderBE:     conf.TopicOrderBE,
-		// TopicOrderCUD:    conf.TopicOrderCUD,
-		// TopicDeliveryBE:  conf.TopicDeliveryBE,
-		// TopicDeliveryCUD: conf.TopicDeliveryBE,
-		// TopicBillingBE:   conf.TopicBillingBE,
-		// TopicBillingCUD:  conf.TopicBillingCUD,
 	}, nil
 }
 
@@ -7,6 +7,9 @@ type Config struct {
 	DB     DB
 	Server Server
 	Auth   Auth
+	Blob   Blob
+	Image  Image
+	Stock  Stock
 	Broker Broker
 	Env    Env
 }
@@ -23,11 +26,32 @@ type Server struct {
 
 // Auth
 type Auth struct {
-	Salt       string `envconfig:"AUTH_SALT" required:"true"`
-	TokenTTL   int    `envconfig:"AUTH_TOKEN_TTL" required:"true"`
 	SigningKey string `envconfig:"AUTH_SIGNING_KEY" required:"true"`
 }
 
+// Blob - image storage, store is local or s3. URL is the public address of the stored blobs:
+// the service itself for the local store, the bucket for s3
+type Blob struct {
+	Store       string `envconfig:"BLOB_STORE" required:"true"`
+	URL         string `envconfig:"BLOB_URL" required:"true"`
+	LocalDir    string `envconfig:"BLOB_LOCAL_DIR"`
+	S3Endpoint  string `envconfig:"BLOB_S3_ENDPOINT"`
+	S3Region    string `envconfig:"BLOB_S3_REGION"`
+	S3Bucket    string `envconfig:"BLOB_S3_BUCKET"`
+	S3AccessKey string `envconfig:"BLOB_S3_ACCESS_KEY"`
+	S3SecretKey string `envconfig:"BLOB_S3_SECRET_KEY"`
+}
+
+// Image - upload limit in bytes
+type Image struct {
+	MaxSize int64 `envconfig:"IMAGE_MAX_SIZE" required:"true"`
+}
+
+// Stock - low stock threshold of the new products, 0 turns the low stock event off
+type Stock struct {
+	LowThreshold int64 `envconfig:"STOCK_LOW_THRESHOLD" required:"true"`
+}
+
 // Broker
 type Broker struct {
 	// TopicPrefix      string `envconfig:"BROKER_TOPIC_PREFIX" required:"true"`
@@ -44,6 +68,7 @@ type Broker struct {
 	TopicDeliveryCUD string `envconfig:"BROKER_TOPIC_DELIVERY_CUD" required:"true"`
 	TopicBillingBE   string `envconfig:"BROKER_TOPIC_BILLING_BE" required:"true"`
 	TopicBillingCUD  string `envconfig:"BROKER_TOPIC_BILLING_CUD" required:"true"`
+	TopicPrivacy     string `envconfig:"BROKER_TOPIC_PRIVACY" required:"true"`
 	GroupId          string `envconfig:"BROKER_GROUP_ID" required:"true"`
 }
 
@@ -71,6 +96,18 @@ func New() (*Config, error) {
 		return nil, err
 	}
 
+	if err := envconfig.Process("blob", &cfg.Blob); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("image", &cfg.Image); err != nil {
+		return nil, err
+	}
+
+	if err := envconfig.Process("stock", &cfg.Stock); err != nil {
+		return nil, err
+	}
+
 	if err := envconfig.Process("broker", &cfg.Broker); err != nil {
 		return nil, err
 	}
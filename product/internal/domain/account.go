@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 )
 
+// Role
 type Role int
 
 const (
@@ -15,58 +16,41 @@ const (
 	ROLE_DEALER
 )
 
+// Account - copy, "reduced version" of the Auth domain. The accounts sign in to the account service only
 type Account struct {
-	Id        int        `json:"id,omitempty" db:"id"`
 	PublicId  uuid.UUID  `json:"public_id" db:"public_id"`
 	Name      string     `json:"name" db:"name" binding:"required"`
 	Username  string     `json:"username" db:"username" binding:"required"`
-	Password  string     `json:"password,omitempty" db:"password_hash" binding:"required"`
 	Email     string     `json:"email" db:"email" binding:"required"`
-	Address   string     `json:"address" db:"address" binding:"required"` // TODO should be different columns - Country, City, Street, etc.
-	Token     string     `json:"token,omitempty"`
+	Address   string     `json:"address" db:"address" binding:"required"`
 	Role      Role       `json:"role" db:"role"`
-	CreatedAt *time.Time `json:"created_at,omitempty" db:"created_at"` // nolint
-}
-
-type SignInInput struct {
-	Email    string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	CreatedAt *time.Time `json:"created_at,omitempty" db:"created_at"`
 }
 
+// UpdateAccountInput - auth.info_updated payload, only the set fields are updated
 type UpdateAccountInput struct {
 	PublicId uuid.UUID `json:"public_id" db:"public_id" binding:"required"`
-	Name     *string   `json:"name" db:"role"`
+	Name     *string   `json:"name" db:"name"`
 	Username *string   `json:"username" db:"username"`
-	Password *string   `json:"password,omitempty" db:"role"`
 	Email    *string   `json:"email" db:"email"`
 	Address  *string   `json:"address" db:"address"`
 }
 
+// UpdateAccountRoleInput
 type UpdateAccountRoleInput struct {
 	PublicId uuid.UUID `json:"public_id" db:"public_id" binding:"required"`
 	Role     Role      `json:"role" db:"role" binding:"required"`
 }
 
-type AccountToken struct {
-	PublicId uuid.UUID `json:"public_id" db:"public_id" binding:"required"`
-	Token    string    `json:"token" db:"token" binding:"token"`
-}
-
+// DeleteAccountInput
 type DeleteAccountInput struct {
-	PublicId uuid.UUID `json:"public_id" db:"public_id" binding:"required"`
+	PublicId string `json:"public_id" db:"public_id" binding:"required"`
 }
 
-type EventType string
-
-const (
-	EVENT_ACCOUNT_CREATED       EventType = "auth.created"
-	EVENT_ACCOUNT_INFO_UPDATED  EventType = "auth.info_updated"
-	EVENT_ACCOUNT_ROLE_UPDATED  EventType = "auth.role_updated"
-	EVENT_ACCOUNT_DELETED       EventType = "auth.deleted"
-	EVENT_ACCOUNT_TOKEN_UPDATED EventType = "auth.token_updated" // nolint
-)
-
-type Event struct {
-	Type  EventType
-	Value interface{}
+// SessionsRevokedInput - auth.sessions_revoked payload, the access tokens of the sessions
+// are not accepted until they expire
+type SessionsRevokedInput struct {
+	PublicId   string    `json:"public_id" binding:"required"`
+	SessionIds []string  `json:"session_ids" binding:"required"`
+	ExpiresAt  time.Time `json:"expires_at"`
 }
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// API_KEY_PREFIX - the bearer token with it is the api key of a dealer integration, not the access token
+	API_KEY_PREFIX = "fsk_"
+)
+
+// API key scopes by the route
+const (
+	API_KEY_SCOPE_PRODUCTS_READ  = "products:read"
+	API_KEY_SCOPE_PRODUCTS_WRITE = "products:write"
+	API_KEY_SCOPE_STOCK_READ     = "stock:read"
+	API_KEY_SCOPE_STOCK_WRITE    = "stock:write"
+)
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid api key")
+)
+
+// APIKey - copy of the key issued by the account service, only the hash is known here.
+// The key is the prefix, "_" and the secret
+type APIKey struct {
+	Prefix          string     `json:"prefix" db:"prefix"`
+	KeyHash         string     `json:"-" db:"key_hash"`
+	AccountPublicId string     `json:"account_public_id" db:"account_public_id"`
+	Scopes          []string   `json:"scopes" db:"-"`
+	ExpiresAt       time.Time  `json:"expires_at" db:"expires_at"`
+	LastUsedAt      *time.Time `json:"last_used_at" db:"last_used_at"`
+}
+
+// HasScope
+func (k APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyCreatedInput - auth.api_key_created payload
+type APIKeyCreatedInput struct {
+	Prefix    string    `json:"prefix" binding:"required"`
+	KeyHash   string    `json:"key_hash" binding:"required"`
+	PublicId  string    `json:"public_id" binding:"required"`
+	Scopes    []string  `json:"scopes" binding:"required"`
+	ExpiresAt time.Time `json:"expires_at" binding:"required"`
+}
+
+// APIKeyRevokedInput - auth.api_key_revoked payload
+type APIKeyRevokedInput struct {
+	Prefix   string `json:"prefix" binding:"required"`
+	PublicId string `json:"public_id"`
+}
+
+// APIKeyUsedEvent - Product.ApiKeyUsed payload, it is sent not more often than once a minute for a key,
+// so the account service shows when the key was used last
+type APIKeyUsedEvent struct {
+	Prefix string    `json:"prefix"`
+	UsedAt time.Time `json:"used_at"`
+}
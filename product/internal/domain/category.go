@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryExists   = errors.New("category already exists")
+	ErrCategoryInUse    = errors.New("category has subcategories or products")
+	ErrCategoryCycle    = errors.New("category can't be moved into own subtree")
+	ErrInvalidCategory  = errors.New("invalid category")
+	ErrInvalidAttribute = errors.New("invalid attribute")
+)
+
+var (
+	slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	codePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// AttributeType - type of the attribute value
+type AttributeType string
+
+const (
+	ATTRIBUTE_ENUM   AttributeType = "enum"   // one of the Values
+	ATTRIBUTE_NUMBER AttributeType = "number" // number in the Unit
+	ATTRIBUTE_TEXT   AttributeType = "text"
+)
+
+// AttributeDefinition - typed product attribute of a category
+type AttributeDefinition struct {
+	Code     string        `json:"code"`
+	Name     string        `json:"name"`
+	Type     AttributeType `json:"type"`
+	Unit     string        `json:"unit,omitempty"`
+	Values   []string      `json:"values,omitempty"`
+	Required bool          `json:"required"`
+}
+
+// Validate
+func (d AttributeDefinition) Validate() error {
+	if !codePattern.MatchString(d.Code) {
+		return fmt.Errorf("%w: attribute code '%s' must be snake_case", ErrInvalidCategory, d.Code)
+	}
+
+	switch d.Type {
+	case ATTRIBUTE_ENUM:
+		if len(d.Values) == 0 {
+			return fmt.Errorf("%w: enum attribute '%s' needs values", ErrInvalidCategory, d.Code)
+		}
+		seen := make(map[string]bool, len(d.Values))
+		for _, value := range d.Values {
+			if value == "" || seen[value] {
+				return fmt.Errorf("%w: enum attribute '%s' has empty or repeated value", ErrInvalidCategory, d.Code)
+			}
+			seen[value] = true
+		}
+	case ATTRIBUTE_NUMBER, ATTRIBUTE_TEXT:
+		if len(d.Values) > 0 {
+			return fmt.Errorf("%w: only enum attribute has values, '%s' is %s", ErrInvalidCategory, d.Code, d.Type)
+		}
+	default:
+		return fmt.Errorf("%w: attribute '%s' has unknown type '%s'", ErrInvalidCategory, d.Code, d.Type)
+	}
+
+	if d.Unit != "" && d.Type != ATTRIBUTE_NUMBER {
+		return fmt.Errorf("%w: only number attribute has unit, '%s' is %s", ErrInvalidCategory, d.Code, d.Type)
+	}
+
+	return nil
+}
+
+// Check - value of the product attribute, as it comes from json
+func (d AttributeDefinition) Check(value interface{}) error {
+	switch d.Type {
+	case ATTRIBUTE_ENUM:
+		text, ok := value.(string)
+		if ok {
+			for _, allowed := range d.Values {
+				if text == allowed {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("%w: '%s' must be one of %s", ErrInvalidAttribute, d.Code, strings.Join(d.Values, ", "))
+	case ATTRIBUTE_NUMBER:
+		number, ok := value.(float64)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return fmt.Errorf("%w: '%s' must be a number", ErrInvalidAttribute, d.Code)
+		}
+	case ATTRIBUTE_TEXT:
+		text, ok := value.(string)
+		if !ok || strings.TrimSpace(text) == "" {
+			return fmt.Errorf("%w: '%s' must be a not empty text", ErrInvalidAttribute, d.Code)
+		}
+	}
+	return nil
+}
+
+// AttributeDefinitions - stored as json
+type AttributeDefinitions []AttributeDefinition
+
+// Value - driver.Valuer
+func (a AttributeDefinitions) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(a)
+	return string(data), err
+}
+
+// Scan - sql.Scanner
+func (a *AttributeDefinitions) Scan(src interface{}) error {
+	return scanJSON(src, a)
+}
+
+// Attributes - product attribute values by code, stored as json
+type Attributes map[string]interface{}
+
+// Value - driver.Valuer
+func (a Attributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(a)
+	return string(data), err
+}
+
+// Scan - sql.Scanner
+func (a *Attributes) Scan(src interface{}) error {
+	return scanJSON(src, a)
+}
+
+// Category - node of the category tree, the slug is the category key and can't be changed
+type Category struct {
+	Slug       string               `json:"slug" db:"slug"`
+	Parent     string               `json:"parent,omitempty" db:"parent_slug"`
+	Name       string               `json:"name" db:"name"`
+	Attributes AttributeDefinitions `json:"attributes" db:"attributes"`
+}
+
+// CategoryInput - category data, slug is used only on creation
+type CategoryInput struct {
+	Slug       string                `json:"slug"`
+	Parent     string                `json:"parent"`
+	Name       string                `json:"name" binding:"required"`
+	Attributes []AttributeDefinition `json:"attributes"`
+}
+
+// Validate
+func (c Category) Validate() error {
+	if !slugPattern.MatchString(c.Slug) {
+		return fmt.Errorf("%w: slug '%s' must be lowercase words with dashes", ErrInvalidCategory, c.Slug)
+	}
+	if c.Parent == c.Slug {
+		return ErrCategoryCycle
+	}
+
+	seen := make(map[string]bool, len(c.Attributes))
+	for _, definition := range c.Attributes {
+		if err := definition.Validate(); err != nil {
+			return err
+		}
+		if seen[definition.Code] {
+			return fmt.Errorf("%w: attribute '%s' is repeated", ErrInvalidCategory, definition.Code)
+		}
+		seen[definition.Code] = true
+	}
+
+	return nil
+}
+
+// CategoryNode - category with subcategories
+type CategoryNode struct {
+	Category
+	Children []CategoryNode `json:"children"`
+}
+
+// CategorySchema - attributes of the category with the inherited ones,
+// a subcategory can redefine an attribute of its parent
+type CategorySchema struct {
+	Slug       string                `json:"slug"`
+	Parent     string                `json:"parent,omitempty"`
+	Name       string                `json:"name"`
+	Path       []string              `json:"path"`
+	Attributes []AttributeDefinition `json:"attributes"`
+}
+
+// NewCategorySchema - path is ordered from the root
+func NewCategorySchema(path []Category) CategorySchema {
+	category := path[len(path)-1]
+	schema := CategorySchema{
+		Slug:       category.Slug,
+		Parent:     category.Parent,
+		Name:       category.Name,
+		Path:       make([]string, 0, len(path)),
+		Attributes: []AttributeDefinition{},
+	}
+
+	index := make(map[string]int)
+	for _, category := range path {
+		schema.Path = append(schema.Path, category.Slug)
+		for _, definition := range category.Attributes {
+			if i, ok := index[definition.Code]; ok {
+				schema.Attributes[i] = definition
+				continue
+			}
+			index[definition.Code] = len(schema.Attributes)
+			schema.Attributes = append(schema.Attributes, definition)
+		}
+	}
+
+	return schema
+}
+
+// Validate - product attribute values
+func (s CategorySchema) Validate(attributes Attributes) error {
+	definitions := make(map[string]AttributeDefinition, len(s.Attributes))
+	for _, definition := range s.Attributes {
+		definitions[definition.Code] = definition
+		if _, ok := attributes[definition.Code]; !ok && definition.Required {
+			return fmt.Errorf("%w: '%s' is required", ErrInvalidAttribute, definition.Code)
+		}
+	}
+
+	for code, value := range attributes {
+		definition, ok := definitions[code]
+		if !ok {
+			return fmt.Errorf("%w: '%s' is not defined for category '%s'", ErrInvalidAttribute, code, s.Slug)
+		}
+		if err := definition.Check(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scanJSON - json column to the target
+func scanJSON(src interface{}, target interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(value), target)
+	case []byte:
+		return json.Unmarshal(value, target)
+	}
+	return fmt.Errorf("unsupported json column type %T", src)
+}
@@ -0,0 +1,39 @@
+package domain
+
+// EventType
+type EventType string
+
+const (
+	EVENT_ACCOUNT_CREATED          EventType = "auth.created"
+	EVENT_ACCOUNT_INFO_UPDATED     EventType = "auth.info_updated"
+	EVENT_ACCOUNT_ROLE_UPDATED     EventType = "auth.role_updated"
+	EVENT_ACCOUNT_TOKEN_UPDATED    EventType = "auth.token_updated"
+	EVENT_ACCOUNT_DELETED          EventType = "auth.deleted"
+	EVENT_ACCOUNT_SESSIONS_REVOKED EventType = "auth.sessions_revoked"
+	EVENT_API_KEY_CREATED          EventType = "auth.api_key_created"
+	EVENT_API_KEY_REVOKED          EventType = "auth.api_key_revoked"
+
+	EVENT_ACCOUNT_EXPORT_REQUESTED  EventType = "Account.ExportRequested"
+	EVENT_ACCOUNT_ERASURE_REQUESTED EventType = "Account.ErasureRequested"
+
+	EVENT_PRIVACY_DATA_EXPORTED     EventType = "Privacy.DataExported"
+	EVENT_PRIVACY_ERASURE_COMPLETED EventType = "Privacy.ErasureCompleted"
+
+	EVENT_PRODUCT_CREATED EventType = "Product.Created"
+	EVENT_PRODUCT_UPDATED EventType = "Product.Updated"
+	EVENT_PRODUCT_DELETED EventType = "Product.Deleted"
+
+	EVENT_PRODUCT_LOW_STOCK EventType = "Product.LowStock"
+	EVENT_PRODUCT_REVIEWED  EventType = "Product.Reviewed"
+	EVENT_API_KEY_USED      EventType = "Product.ApiKeyUsed"
+
+	EVENT_ORDER_CHECKED_OUT EventType = "Order.CheckedOut"
+	EVENT_ORDER_PAYED       EventType = "Order.Payed"
+	EVENT_ORDER_DELIVERED   EventType = "Order.Delivered"
+)
+
+// Event
+type Event struct {
+	Type  EventType
+	Value interface{}
+}
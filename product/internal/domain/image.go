@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrImageNotFound     = errors.New("image not found")
+	ErrInvalidImage      = errors.New("invalid image")
+	ErrImageTooLarge     = errors.New("image is too large")
+	ErrTooManyImages     = errors.New("too many product images")
+	ErrInvalidImageOrder = errors.New("image order must list every product image once")
+	ErrBlobNotFound      = errors.New("blob not found")
+	ErrUnknownBlobStore  = errors.New("unknown blob store")
+)
+
+// Image sizes, the original is kept as uploaded, the others are generated jpeg
+const (
+	IMAGE_ORIGINAL = "original"
+	IMAGE_LARGE    = "large"
+	IMAGE_MEDIUM   = "medium"
+	IMAGE_THUMB    = "thumb"
+
+	// MAX_PRODUCT_IMAGES - per product
+	MAX_PRODUCT_IMAGES = 10
+)
+
+// IMAGE_SIZES - the longest side of the generated images in pixels, smaller images are not upscaled
+var IMAGE_SIZES = map[string]int{
+	IMAGE_LARGE:  1280,
+	IMAGE_MEDIUM: 640,
+	IMAGE_THUMB:  240,
+}
+
+// IMAGE_CONTENT_TYPES - accepted uploads, the type is sniffed from the data, not taken from the request
+var IMAGE_CONTENT_TYPES = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// ProductImage - product photo, the lowest position is the main one.
+// Keys are blob store keys by size, URLs are the public addresses of the same blobs
+type ProductImage struct {
+	PublicId        uuid.UUID `json:"public_id" db:"public_id"`
+	ProductPublicId uuid.UUID `json:"-" db:"product_public_id"`
+	Position        int       `json:"position" db:"position"`
+	ContentType     string    `json:"content_type" db:"content_type"`
+	Width           int       `json:"width" db:"width"`
+	Height          int       `json:"height" db:"height"`
+	URLs            ImageMap  `json:"urls" db:"urls"`
+	Keys            ImageMap  `json:"-" db:"blob_keys"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// ImageMap - value by image size, stored as json
+type ImageMap map[string]string
+
+// Value - driver.Valuer
+func (m ImageMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(m)
+	return string(data), err
+}
+
+// Scan - sql.Scanner
+func (m *ImageMap) Scan(src interface{}) error {
+	return scanJSON(src, m)
+}
+
+// ImageOrderInput - all product image ids in the new order
+type ImageOrderInput struct {
+	Order []uuid.UUID `json:"order" binding:"required"`
+}
@@ -0,0 +1,268 @@
+package domain
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidImportFormat = errors.New("format must be csv or json")
+	ErrInvalidImportFile   = errors.New("invalid import file")
+	ErrImportTooLarge      = errors.New("too many import rows")
+	ErrImportJobNotFound   = errors.New("import job not found")
+)
+
+// Import formats
+const (
+	FORMAT_CSV  = "csv"
+	FORMAT_JSON = "json"
+
+	// MAX_IMPORT_ROWS - per file
+	MAX_IMPORT_ROWS = 5000
+)
+
+// ImportStatus
+type ImportStatus string
+
+const (
+	IMPORT_QUEUED  ImportStatus = "queued"
+	IMPORT_RUNNING ImportStatus = "running"
+	IMPORT_DONE    ImportStatus = "done"
+	IMPORT_FAILED  ImportStatus = "failed"
+)
+
+// CSV_COLUMNS - header of the csv import and export, attributes are a json object
+var CSV_COLUMNS = []string{"sku", "name", "description", "category", "price", "discount", "quantity", "attributes"}
+
+// ImportRow - parsed product of the file. Row is the line in csv and the item number in json,
+// Err is the parse error of the row
+type ImportRow struct {
+	Row   int
+	Input ProductInput
+	Err   error
+}
+
+// ImportError - row level error
+type ImportError struct {
+	Row     int    `json:"row"`
+	Sku     string `json:"sku"`
+	Message string `json:"message"`
+}
+
+// ImportErrors - stored as json
+type ImportErrors []ImportError
+
+// Value - driver.Valuer
+func (e ImportErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(e)
+	return string(data), err
+}
+
+// Scan - sql.Scanner
+func (e *ImportErrors) Scan(src interface{}) error {
+	return scanJSON(src, e)
+}
+
+// ImportReport - dry run result, nothing is saved
+type ImportReport struct {
+	Rows   int          `json:"rows"`
+	Valid  int          `json:"valid"`
+	Create int          `json:"create"`
+	Update int          `json:"update"`
+	Errors ImportErrors `json:"errors"`
+}
+
+// ImportJob - asynchronous import progress, rows with errors are skipped
+type ImportJob struct {
+	PublicId       uuid.UUID    `json:"public_id" db:"public_id"`
+	DealerPublicId uuid.UUID    `json:"dealer_public_id" db:"dealer_public_id"`
+	Status         ImportStatus `json:"status" db:"status"`
+	Total          int          `json:"total" db:"total"`
+	Processed      int          `json:"processed" db:"processed"`
+	Created        int          `json:"created" db:"created"`
+	Updated        int          `json:"updated" db:"updated"`
+	Failed         int          `json:"failed" db:"failed"`
+	Errors         ImportErrors `json:"errors" db:"errors"`
+	CreatedAt      time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// ParseImport - rows of the csv or json file, a broken row doesn't stop the parsing
+func ParseImport(format string, data []byte) ([]ImportRow, error) {
+	var rows []ImportRow
+	var err error
+	switch format {
+	case FORMAT_CSV:
+		rows, err = parseCSV(data)
+	case FORMAT_JSON:
+		rows, err = parseJSON(data)
+	default:
+		return nil, ErrInvalidImportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > MAX_IMPORT_ROWS {
+		return nil, fmt.Errorf("%w: %d max", ErrImportTooLarge, MAX_IMPORT_ROWS)
+	}
+	return rows, nil
+}
+
+// parseCSV - the header is required, the columns can go in any order
+func parseCSV(data []byte) ([]ImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: no csv header", ErrInvalidImportFile)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{"sku", "name"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: no %s column", ErrInvalidImportFile, name)
+		}
+	}
+
+	rows := []ImportRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && parseErr.Err != csv.ErrQuote {
+				rows = append(rows, ImportRow{Row: parseErr.StartLine, Err: err})
+				continue
+			}
+			return nil, fmt.Errorf("%w: %s", ErrInvalidImportFile, err.Error())
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := ImportRow{Row: line}
+		row.Input, row.Err = parseRecord(record, columns)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseRecord - csv record by the header columns
+func parseRecord(record []string, columns map[string]int) (ProductInput, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	number := func(name string) (int64, error) {
+		value := field(name)
+		if value == "" {
+			return 0, nil
+		}
+		result, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be an integer", name)
+		}
+		return result, nil
+	}
+
+	input := ProductInput{
+		Sku:         field("sku"),
+		Name:        field("name"),
+		Description: field("description"),
+		Category:    field("category"),
+	}
+	var err error
+	if input.Price, err = number("price"); err != nil {
+		return input, err
+	}
+	if input.Discount, err = number("discount"); err != nil {
+		return input, err
+	}
+	if input.Quantity, err = number("quantity"); err != nil {
+		return input, err
+	}
+	if attributes := field("attributes"); attributes != "" {
+		if err = json.Unmarshal([]byte(attributes), &input.Attributes); err != nil {
+			return input, errors.New("attributes must be a json object")
+		}
+	}
+	return input, nil
+}
+
+// parseJSON - array of the product inputs
+func parseJSON(data []byte) ([]ImportRow, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("%w: json array of products is expected", ErrInvalidImportFile)
+	}
+
+	rows := make([]ImportRow, 0, len(items))
+	for i, item := range items {
+		row := ImportRow{Row: i + 1}
+		if err := json.Unmarshal(item, &row.Input); err != nil {
+			row.Err = errors.New("invalid product object")
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// WriteExport - products in the import format, so the export can be imported back
+func WriteExport(format string, w io.Writer, products []Product) error {
+	switch format {
+	case FORMAT_CSV:
+		return writeCSV(w, products)
+	case FORMAT_JSON:
+		inputs := make([]ProductInput, 0, len(products))
+		for _, product := range products {
+			inputs = append(inputs, product.Input())
+		}
+		return json.NewEncoder(w).Encode(inputs)
+	default:
+		return ErrInvalidImportFormat
+	}
+}
+
+func writeCSV(w io.Writer, products []Product) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(CSV_COLUMNS); err != nil {
+		return err
+	}
+	for _, product := range products {
+		attributes := ""
+		if len(product.Attributes) > 0 {
+			data, err := json.Marshal(product.Attributes)
+			if err != nil {
+				return err
+			}
+			attributes = string(data)
+		}
+		err := writer.Write([]string{product.Sku, product.Name, product.Description, product.Category,
+			strconv.FormatInt(product.Price, 10), strconv.FormatInt(product.Discount, 10),
+			strconv.FormatInt(product.Quantity, 10), attributes})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PRICE_HISTORY_DEFAULT_LIMIT, PRICE_HISTORY_MAX_LIMIT - changes per page
+	PRICE_HISTORY_DEFAULT_LIMIT = 50
+	PRICE_HISTORY_MAX_LIMIT     = 200
+)
+
+// EffectivePrice - price with the discount, rounded down to the minor unit
+func EffectivePrice(price, discount int64) int64 {
+	return price * (100 - discount) / 100
+}
+
+// PriceChange - price history record, the price and discount set from the creation time.
+// Actor is the account that made the change
+type PriceChange struct {
+	Id              int64     `json:"id" db:"id"`
+	ProductPublicId uuid.UUID `json:"product_public_id" db:"product_public_id"`
+	Price           int64     `json:"price" db:"price"`
+	Discount        int64     `json:"discount" db:"discount"`
+	EffectivePrice  int64     `json:"effective_price" db:"effective_price"`
+	ActorPublicId   uuid.UUID `json:"actor_public_id" db:"actor_public_id"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// PriceHistory - product price changes from the newest, next is the before id of the next page
+type PriceHistory struct {
+	ProductPublicId uuid.UUID     `json:"product_public_id"`
+	Changes         []PriceChange `json:"changes"`
+	Next            int64         `json:"next,omitempty"`
+}
+
+// PriceChange - the current product price as a history record
+func (p Product) PriceChange(actor uuid.UUID) PriceChange {
+	return PriceChange{
+		ProductPublicId: p.PublicId,
+		Price:           p.Price,
+		Discount:        p.Discount,
+		EffectivePrice:  EffectivePrice(p.Price, p.Discount),
+		ActorPublicId:   actor,
+		CreatedAt:       p.UpdatedAt,
+	}
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrProductNotFound     = errors.New("product not found")
+	ErrProductAccessDenied = errors.New("product belongs to another dealer")
+	ErrSkuExists           = errors.New("dealer already has a product with the sku")
+	ErrInvalidProduct      = errors.New("invalid product")
+)
+
+// Product - price is kept in minor currency units (kopecks), discount in percents,
+// Quantity is the stock on hand and Reserved is its part held for customers, both are changed by the stock movements.
+// Category is the category slug, Attributes are checked by its schema.
+// Images are ordered by position and kept in their own table. Sku is the dealer own code, unique for the dealer.
+// A variant has the parent product, see VariantInput. Rating is the average of the approved reviews
+type Product struct {
+	PublicId          uuid.UUID      `json:"public_id" db:"public_id"`
+	DealerPublicId    uuid.UUID      `json:"dealer_public_id" db:"dealer_public_id"`
+	Sku               string         `json:"sku" db:"sku"`
+	Name              string         `json:"name" db:"name"`
+	Description       string         `json:"description" db:"description"`
+	Category          string         `json:"category" db:"category"`
+	Price             int64          `json:"price" db:"price"`
+	Discount          int64          `json:"discount" db:"discount"`
+	Quantity          int64          `json:"quantity" db:"quantity"`
+	Reserved          int64          `json:"reserved" db:"reserved"`
+	LowStockThreshold int64          `json:"low_stock_threshold" db:"low_stock_threshold"`
+	Attributes        Attributes     `json:"attributes" db:"attributes"`
+	Images            []ProductImage `json:"images" db:"-"`
+	CreatedAt         time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at" db:"updated_at"`
+
+	ParentPublicId uuid.NullUUID `json:"parent_public_id" db:"parent_public_id"`
+	PriceOverride  bool          `json:"price_override" db:"price_override"`
+	Rating         float64       `json:"rating" db:"rating"`
+	RatingCount    int64         `json:"rating_count" db:"rating_count"`
+	Variants       []Product     `json:"variants,omitempty" db:"-"`
+	MinPrice       int64         `json:"min_price" db:"-"`
+	MaxPrice       int64         `json:"max_price" db:"-"`
+
+	// LowStockReached - the last change took the available stock down to the threshold
+	LowStockReached bool `json:"-" db:"-"`
+}
+
+// Stock
+func (p Product) Stock() Stock {
+	return Stock{OnHand: p.Quantity, Reserved: p.Reserved}
+}
+
+// StockLevel
+func (p Product) StockLevel() StockLevel {
+	available := p.Stock().Available()
+	return StockLevel{
+		ProductPublicId:   p.PublicId,
+		Sku:               p.Sku,
+		Name:              p.Name,
+		OnHand:            p.Quantity,
+		Reserved:          p.Reserved,
+		Available:         available,
+		LowStockThreshold: p.LowStockThreshold,
+		LowStock:          p.LowStockThreshold > 0 && available <= p.LowStockThreshold,
+	}
+}
+
+// LowStockEvent
+func (p Product) LowStockEvent() LowStockEvent {
+	return LowStockEvent{
+		PublicId:          p.PublicId,
+		DealerPublicId:    p.DealerPublicId,
+		Sku:               p.Sku,
+		Name:              p.Name,
+		OnHand:            p.Quantity,
+		Reserved:          p.Reserved,
+		Available:         p.Stock().Available(),
+		LowStockThreshold: p.LowStockThreshold,
+	}
+}
+
+// ProductInput - dealer product data, also a row of the bulk import
+type ProductInput struct {
+	Sku         string     `json:"sku"`
+	Name        string     `json:"name" binding:"required"`
+	Description string     `json:"description"`
+	Category    string     `json:"category"`
+	Price       int64      `json:"price" binding:"min=0"`
+	Discount    int64      `json:"discount" binding:"min=0,max=100"`
+	Quantity    int64      `json:"quantity" binding:"min=0"` // on hand, a change is recorded as a stock movement
+	Attributes  Attributes `json:"attributes"`
+}
+
+// Validate - the same rules as the binding tags, for the input that doesn't come from a request body
+func (i ProductInput) Validate() error {
+	switch {
+	case strings.TrimSpace(i.Name) == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidProduct)
+	case i.Price < 0:
+		return fmt.Errorf("%w: price can't be negative", ErrInvalidProduct)
+	case i.Discount < 0 || i.Discount > 100:
+		return fmt.Errorf("%w: discount must be from 0 to 100", ErrInvalidProduct)
+	case i.Quantity < 0:
+		return fmt.Errorf("%w: quantity can't be negative", ErrInvalidProduct)
+	}
+	return nil
+}
+
+// Input - product data as the dealer sends it
+func (p Product) Input() ProductInput {
+	return ProductInput{
+		Sku:         p.Sku,
+		Name:        p.Name,
+		Description: p.Description,
+		Category:    p.Category,
+		Price:       p.Price,
+		Discount:    p.Discount,
+		Quantity:    p.Quantity,
+		Attributes:  p.Attributes,
+	}
+}
+
+// ProductAttributesInput - product place in the category tree
+type ProductAttributesInput struct {
+	Category   string     `json:"category"`
+	Attributes Attributes `json:"attributes"`
+}
+
+// DeleteProductInput
+type DeleteProductInput struct {
+	PublicId uuid.UUID `json:"public_id" db:"public_id" binding:"required"`
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrReviewNotFound    = errors.New("review not found")
+	ErrReviewExists      = errors.New("product is already reviewed by the account")
+	ErrReviewNotAllowed  = errors.New("product is not in an order delivered to the account")
+	ErrInvalidModeration = errors.New("review can be approved or rejected only")
+)
+
+// ReviewStatus
+type ReviewStatus string
+
+const (
+	REVIEW_PENDING  ReviewStatus = "pending"
+	REVIEW_APPROVED ReviewStatus = "approved"
+	REVIEW_REJECTED ReviewStatus = "rejected"
+
+	// REVIEWS_DEFAULT_LIMIT, REVIEWS_MAX_LIMIT - reviews per page
+	REVIEWS_DEFAULT_LIMIT = 20
+	REVIEWS_MAX_LIMIT     = 100
+)
+
+// Review - customer review of the delivered product, only approved ones are public and rated.
+// A variant review goes to its parent product
+type Review struct {
+	Id                int64         `json:"id" db:"id"`
+	PublicId          uuid.UUID     `json:"public_id" db:"public_id"`
+	ProductPublicId   uuid.UUID     `json:"product_public_id" db:"product_public_id"`
+	AccountPublicId   uuid.UUID     `json:"account_public_id" db:"account_public_id"`
+	OrderPublicId     uuid.UUID     `json:"order_public_id" db:"order_public_id"`
+	Rating            int           `json:"rating" db:"rating"`
+	Text              string        `json:"text" db:"text"`
+	Status            ReviewStatus  `json:"status" db:"status"`
+	ModeratorPublicId uuid.NullUUID `json:"moderator_public_id" db:"moderator_public_id"`
+	ModerationComment string        `json:"moderation_comment" db:"moderation_comment"`
+	CreatedAt         time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// ReviewInput - the order is the delivered order with the product
+type ReviewInput struct {
+	OrderPublicId uuid.UUID `json:"order_public_id" binding:"required"`
+	Rating        int       `json:"rating" binding:"required,min=1,max=5"`
+	Text          string    `json:"text" binding:"max=5000"`
+}
+
+// ModerationInput
+type ModerationInput struct {
+	Status  ReviewStatus `json:"status" binding:"required"`
+	Comment string       `json:"comment"`
+}
+
+// ReviewPage - reviews from the newest, next is the before id of the next page
+type ReviewPage struct {
+	Reviews []Review `json:"reviews"`
+	Next    int64    `json:"next,omitempty"`
+}
+
+// Rating - average of the approved reviews
+type Rating struct {
+	Average float64 `json:"rating" db:"rating"`
+	Count   int64   `json:"rating_count" db:"rating_count"`
+}
+
+// DeliveredOrder - Order.Delivered payload, the order makes its customer a reviewer of its products.
+// The lines usually come before with the order, the delivery can carry them too
+type DeliveredOrder struct {
+	OrderPublicId    uuid.UUID   `json:"order_public_id" db:"order_public_id"`
+	CustomerPublicId uuid.UUID   `json:"customer_public_id" db:"customer_public_id"`
+	Items            []OrderLine `json:"items,omitempty" db:"-"`
+}
+
+// OrderLine - the ordered product, VariantPublicId is set when the line is a variant of the product
+type OrderLine struct {
+	ProductPublicId uuid.UUID `json:"product_public_id" db:"product_public_id"`
+	VariantPublicId uuid.UUID `json:"variant_public_id" db:"variant_public_id"`
+}
+
+// OrderLinesInput - Order.CheckedOut / Order.Payed payload, only the lines are used
+type OrderLinesInput struct {
+	PublicId uuid.UUID   `json:"public_id"`
+	Items    []OrderLine `json:"items"`
+}
+
+// ReviewedEvent - Product.Reviewed payload, sent when a review is approved
+type ReviewedEvent struct {
+	ReviewPublicId  uuid.UUID `json:"review_public_id"`
+	ProductPublicId uuid.UUID `json:"product_public_id"`
+	DealerPublicId  uuid.UUID `json:"dealer_public_id"`
+	AccountPublicId uuid.UUID `json:"account_public_id"`
+	Rating          int       `json:"rating"`
+	ProductRating   Rating    `json:"product_rating"`
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSort       = errors.New("invalid sort")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrInvalidPriceRange = errors.New("invalid price range")
+)
+
+// SearchSort - order of the search results
+type SearchSort string
+
+const (
+	SORT_RELEVANCE  SearchSort = "relevance"
+	SORT_PRICE_ASC  SearchSort = "price_asc"
+	SORT_PRICE_DESC SearchSort = "price_desc"
+	SORT_NEWEST     SearchSort = "newest"
+)
+
+const (
+	SEARCH_DEFAULT_LIMIT = 20
+	SEARCH_MAX_LIMIT     = 100
+)
+
+// PRICE_FACET_BOUNDS - price facet buckets borders, in kopecks
+var PRICE_FACET_BOUNDS = []int64{1000000, 2500000, 5000000, 10000000}
+
+// SearchQuery - full-text query with filters, zero values are not applied
+type SearchQuery struct {
+	Text           string     `form:"q"`
+	Category       string     `form:"category"`
+	DealerPublicId uuid.UUID  `form:"-"`
+	MinPrice       int64      `form:"min_price"`
+	MaxPrice       int64      `form:"max_price"`
+	InStock        bool       `form:"in_stock"`
+	Sort           SearchSort `form:"sort"`
+	Limit          int        `form:"limit"`
+	Cursor         string     `form:"cursor"`
+
+	After *SearchCursor `form:"-"`
+}
+
+// Validate - sets defaults and decodes the cursor
+func (q *SearchQuery) Validate() error {
+	if q.MinPrice < 0 || q.MaxPrice < 0 || (q.MaxPrice > 0 && q.MinPrice > q.MaxPrice) {
+		return ErrInvalidPriceRange
+	}
+
+	switch q.Sort {
+	case "":
+		q.Sort = SORT_NEWEST
+		if q.MatchQuery() != "" {
+			q.Sort = SORT_RELEVANCE
+		}
+	case SORT_RELEVANCE:
+		if q.MatchQuery() == "" {
+			q.Sort = SORT_NEWEST
+		}
+	case SORT_PRICE_ASC, SORT_PRICE_DESC, SORT_NEWEST:
+	default:
+		return ErrInvalidSort
+	}
+
+	if q.Limit <= 0 {
+		q.Limit = SEARCH_DEFAULT_LIMIT
+	}
+	if q.Limit > SEARCH_MAX_LIMIT {
+		q.Limit = SEARCH_MAX_LIMIT
+	}
+
+	q.After = nil
+	if q.Cursor != "" {
+		cursor, err := DecodeSearchCursor(q.Cursor)
+		if err != nil || cursor.Sort != q.Sort {
+			return ErrInvalidCursor
+		}
+		q.After = &cursor
+	}
+
+	return nil
+}
+
+// MatchQuery - user text as an index query: every word is required and matched by prefix,
+// everything except letters and digits is dropped and the words are lowercased (the index is
+// case-insensitive anyway), so the text can't break the query syntax with quotes or AND/OR/NEAR
+func (q SearchQuery) MatchQuery() string {
+	words := strings.FieldsFunc(strings.ToLower(q.Text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + "*"
+	}
+	return strings.Join(words, " ")
+}
+
+// SearchCursor - position after the last returned product, opaque for clients
+type SearchCursor struct {
+	Sort  SearchSort `json:"s"`
+	Rank  int64      `json:"r,omitempty"`
+	Price int64      `json:"p,omitempty"`
+	Id    int64      `json:"i"`
+}
+
+// Encode
+func (c SearchCursor) Encode() string {
+	data, _ := json.Marshal(c) // nolint
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeSearchCursor
+func DecodeSearchCursor(value string) (SearchCursor, error) {
+	var cursor SearchCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// FacetCount - products with the value
+type FacetCount struct {
+	Value string `json:"value" db:"value"`
+	Count int64  `json:"count" db:"count"`
+}
+
+// PriceBucket - products with price in [From, To), To is 0 for the last bucket
+type PriceBucket struct {
+	From  int64 `json:"from"`
+	To    int64 `json:"to,omitempty"`
+	Count int64 `json:"count"`
+}
+
+// PriceFacet
+type PriceFacet struct {
+	Min     int64         `json:"min" db:"min_price"`
+	Max     int64         `json:"max" db:"max_price"`
+	Buckets []PriceBucket `json:"buckets"`
+}
+
+// SearchFacets - every facet is counted with all filters except its own,
+// so the client can show the other choices of the facet
+type SearchFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Dealers    []FacetCount `json:"dealers"`
+	Price      PriceFacet   `json:"price"`
+	InStock    int64        `json:"in_stock"`
+}
+
+// SearchResult
+type SearchResult struct {
+	Items      []Product    `json:"items"`
+	Facets     SearchFacets `json:"facets"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
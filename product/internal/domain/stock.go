@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidMovement   = errors.New("invalid stock movement")
+	ErrInsufficientStock = errors.New("insufficient stock")
+)
+
+// MovementType - reason of the stock change
+type MovementType string
+
+const (
+	MOVEMENT_RECEIVED MovementType = "received" // goods came to the warehouse
+	MOVEMENT_RESERVED MovementType = "reserved" // held for a customer, negative quantity releases the hold
+	MOVEMENT_SOLD     MovementType = "sold"     // left the warehouse, the reservation is used first
+	MOVEMENT_RETURNED MovementType = "returned" // came back from a customer
+	MOVEMENT_ADJUSTED MovementType = "adjusted" // stock count correction, signed
+
+	// STOCK_HISTORY_DEFAULT_LIMIT, STOCK_HISTORY_MAX_LIMIT - movements per page
+	STOCK_HISTORY_DEFAULT_LIMIT = 50
+	STOCK_HISTORY_MAX_LIMIT     = 200
+)
+
+// StockMovement - stock history record, on hand and reserved are the stock after the movement.
+// Actor is the dealer or the account that made the change
+type StockMovement struct {
+	Id              int64        `json:"id" db:"id"`
+	ProductPublicId uuid.UUID    `json:"product_public_id" db:"product_public_id"`
+	Type            MovementType `json:"type" db:"type"`
+	Quantity        int64        `json:"quantity" db:"quantity"`
+	OnHand          int64        `json:"on_hand" db:"on_hand"`
+	Reserved        int64        `json:"reserved" db:"reserved"`
+	ActorPublicId   uuid.UUID    `json:"actor_public_id" db:"actor_public_id"`
+	Comment         string       `json:"comment" db:"comment"`
+	CreatedAt       time.Time    `json:"created_at" db:"created_at"`
+}
+
+// StockMovementInput - dealer stock change
+type StockMovementInput struct {
+	Type     MovementType `json:"type" binding:"required"`
+	Quantity int64        `json:"quantity" binding:"required"`
+	Comment  string       `json:"comment"`
+}
+
+// StockThresholdInput - 0 turns the low stock event off
+type StockThresholdInput struct {
+	LowStockThreshold int64 `json:"low_stock_threshold" binding:"min=0"`
+}
+
+// Stock - on hand and reserved quantity of the product
+type Stock struct {
+	OnHand   int64
+	Reserved int64
+}
+
+// Available - can be sold
+func (s Stock) Available() int64 {
+	return s.OnHand - s.Reserved
+}
+
+// Apply - stock after the movement, reserved can't exceed on hand
+func (s Stock) Apply(movementType MovementType, quantity int64) (Stock, error) {
+	switch movementType {
+	case MOVEMENT_RECEIVED, MOVEMENT_RETURNED:
+		if quantity <= 0 {
+			return s, fmt.Errorf("%w: %s quantity must be positive", ErrInvalidMovement, movementType)
+		}
+		s.OnHand += quantity
+	case MOVEMENT_SOLD:
+		if quantity <= 0 {
+			return s, fmt.Errorf("%w: %s quantity must be positive", ErrInvalidMovement, movementType)
+		}
+		s.OnHand -= quantity
+		if quantity < s.Reserved {
+			s.Reserved -= quantity
+		} else {
+			s.Reserved = 0
+		}
+	case MOVEMENT_RESERVED:
+		if quantity == 0 {
+			return s, fmt.Errorf("%w: %s quantity can't be zero", ErrInvalidMovement, movementType)
+		}
+		s.Reserved += quantity
+		if s.Reserved < 0 {
+			return s, fmt.Errorf("%w: only %d is reserved", ErrInvalidMovement, s.Reserved-quantity)
+		}
+	case MOVEMENT_ADJUSTED:
+		if quantity == 0 {
+			return s, fmt.Errorf("%w: %s quantity can't be zero", ErrInvalidMovement, movementType)
+		}
+		s.OnHand += quantity
+	default:
+		return s, fmt.Errorf("%w: unknown type %s", ErrInvalidMovement, movementType)
+	}
+
+	if s.OnHand < 0 || s.Available() < 0 {
+		return s, ErrInsufficientStock
+	}
+	return s, nil
+}
+
+// LowStockReached - the change took the available stock down to the threshold, 0 threshold is off
+func LowStockReached(threshold, before, after int64) bool {
+	return threshold > 0 && before > threshold && after <= threshold
+}
+
+// StockLevel - warehouse dashboard row
+type StockLevel struct {
+	ProductPublicId   uuid.UUID `json:"product_public_id" db:"public_id"`
+	Sku               string    `json:"sku" db:"sku"`
+	Name              string    `json:"name" db:"name"`
+	OnHand            int64     `json:"on_hand" db:"quantity"`
+	Reserved          int64     `json:"reserved" db:"reserved"`
+	Available         int64     `json:"available" db:"available"`
+	LowStockThreshold int64     `json:"low_stock_threshold" db:"low_stock_threshold"`
+	LowStock          bool      `json:"low_stock" db:"low_stock"`
+}
+
+// StockDashboard - dealer warehouse, totals are over all the dealer products
+type StockDashboard struct {
+	Products  int64        `json:"products"`
+	OnHand    int64        `json:"on_hand"`
+	Reserved  int64        `json:"reserved"`
+	Available int64        `json:"available"`
+	LowStock  int64        `json:"low_stock"`
+	Items     []StockLevel `json:"items"`
+}
+
+// StockHistory - product stock with the movements from the newest, next is the before id of the next page
+type StockHistory struct {
+	StockLevel
+	Movements []StockMovement `json:"movements"`
+	Next      int64           `json:"next,omitempty"`
+}
+
+// LowStockEvent - Product.LowStock payload
+type LowStockEvent struct {
+	PublicId          uuid.UUID `json:"public_id"`
+	DealerPublicId    uuid.UUID `json:"dealer_public_id"`
+	Sku               string    `json:"sku"`
+	Name              string    `json:"name"`
+	OnHand            int64     `json:"on_hand"`
+	Reserved          int64     `json:"reserved"`
+	Available         int64     `json:"available"`
+	LowStockThreshold int64     `json:"low_stock_threshold"`
+}
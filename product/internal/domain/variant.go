@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidVariant = errors.New("invalid variant")
+
+// VariantInput - a variant is a product with the parent, e.g. a sofa model in one fabric and size.
+// It has its own sku, attributes and stock, price 0 takes the parent price. Name, description, category
+// and discount are the parent ones and follow the parent changes. Images are kept by the parent only
+type VariantInput struct {
+	Sku        string     `json:"sku" binding:"required"`
+	Price      int64      `json:"price" binding:"min=0"`
+	Quantity   int64      `json:"quantity" binding:"min=0"` // on hand, a change is recorded as a stock movement
+	Attributes Attributes `json:"attributes"`
+}
+
+// IsVariant
+func (p Product) IsVariant() bool {
+	return p.ParentPublicId.Valid
+}
+
+// NewVariant - variant of the parent product with the parent data
+func (p Product) NewVariant(publicId uuid.UUID) Product {
+	return Product{
+		PublicId:          publicId,
+		DealerPublicId:    p.DealerPublicId,
+		Name:              p.Name,
+		Description:       p.Description,
+		Category:          p.Category,
+		Price:             p.Price,
+		Discount:          p.Discount,
+		LowStockThreshold: p.LowStockThreshold,
+		ParentPublicId:    uuid.NullUUID{UUID: p.PublicId, Valid: true},
+		Images:            []ProductImage{},
+	}
+}
+
+// ApplyVariantInput - the parent gives the price when it is not overridden
+func (p *Product) ApplyVariantInput(parent Product, input VariantInput) {
+	p.Sku = input.Sku
+	p.Attributes = input.Attributes
+	p.PriceOverride = input.Price > 0
+	p.Price = parent.Price
+	if p.PriceOverride {
+		p.Price = input.Price
+	}
+	p.MinPrice, p.MaxPrice = p.Price, p.Price
+}
+
+// SetVariants - the price range is over the variants, over the product itself when it has none
+func (p *Product) SetVariants(variants []Product) {
+	p.Variants = variants
+	p.MinPrice, p.MaxPrice = p.Price, p.Price
+	for i, variant := range variants {
+		if i == 0 || variant.Price < p.MinPrice {
+			p.MinPrice = variant.Price
+		}
+		if i == 0 || variant.Price > p.MaxPrice {
+			p.MaxPrice = variant.Price
+		}
+	}
+}
+
+// SyncVariants - the parent data goes to the variants
+func (p *Product) SyncVariants() {
+	for i := range p.Variants {
+		variant := &p.Variants[i]
+		variant.Name = p.Name
+		variant.Description = p.Description
+		variant.Category = p.Category
+		variant.Discount = p.Discount
+		if !variant.PriceOverride {
+			variant.Price = p.Price
+		}
+		variant.MinPrice, variant.MaxPrice = variant.Price, variant.Price
+		variant.UpdatedAt = p.UpdatedAt
+	}
+	p.SetVariants(p.Variants)
+}
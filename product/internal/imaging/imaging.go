@@ -0,0 +1,179 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"sort"
+
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+const (
+	JPEG_QUALITY = 85
+
+	// MAX_PIXELS - decoded image limit, a small file can declare a huge canvas
+	MAX_PIXELS = 50000000
+)
+
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// Variant - one stored size of the image
+type Variant struct {
+	Size        string
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// Process - checks the uploaded image and makes all its sizes, the original goes first as is.
+// The generated sizes are jpeg, transparent pixels are put on white
+func Process(data []byte) ([]Variant, error) {
+	contentType := http.DetectContentType(data)
+	if !domain.IMAGE_CONTENT_TYPES[contentType] {
+		return nil, fmt.Errorf("%w: %s is not supported", domain.ErrInvalidImage, contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidImage, err.Error())
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MAX_PIXELS {
+		return nil, fmt.Errorf("%w: %dx%d pixels", domain.ErrInvalidImage, config.Width, config.Height)
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidImage, err.Error())
+	}
+
+	variants := []Variant{{
+		Size:        domain.IMAGE_ORIGINAL,
+		ContentType: contentType,
+		Extension:   extensions[contentType],
+		Width:       config.Width,
+		Height:      config.Height,
+		Data:        data,
+	}}
+
+	source := toRGBA(decoded)
+	for _, size := range sizes() {
+		resized := Flatten(Resize(source, domain.IMAGE_SIZES[size]))
+
+		var encoded bytes.Buffer
+		if err := jpeg.Encode(&encoded, resized, &jpeg.Options{Quality: JPEG_QUALITY}); err != nil {
+			return nil, fmt.Errorf("encode %s image: %w", size, err)
+		}
+		variants = append(variants, Variant{
+			Size:        size,
+			ContentType: "image/jpeg",
+			Extension:   ".jpg",
+			Width:       resized.Bounds().Dx(),
+			Height:      resized.Bounds().Dy(),
+			Data:        encoded.Bytes(),
+		})
+	}
+
+	return variants, nil
+}
+
+// Resize - fits the image into the square with the side, keeping the proportions.
+// Every pixel is the average of the source area it covers, a smaller image is returned as is
+func Resize(source *image.RGBA, side int) *image.RGBA {
+	bounds := source.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	targetWidth, targetHeight := fit(width, height, side)
+	if targetWidth == width && targetHeight == height {
+		return source
+	}
+
+	target := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+	for y := 0; y < targetHeight; y++ {
+		y0, y1 := y*height/targetHeight, (y+1)*height/targetHeight
+		for x := 0; x < targetWidth; x++ {
+			x0, x1 := x*width/targetWidth, (x+1)*width/targetWidth
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := source.PixOffset(bounds.Min.X+x0, bounds.Min.Y+sy)
+				for i := row; i < row+(x1-x0)*4; i += 4 {
+					sum[0] += int(source.Pix[i])
+					sum[1] += int(source.Pix[i+1])
+					sum[2] += int(source.Pix[i+2])
+					sum[3] += int(source.Pix[i+3])
+				}
+			}
+
+			count := (x1 - x0) * (y1 - y0)
+			offset := target.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				target.Pix[offset+c] = uint8((sum[c] + count/2) / count)
+			}
+		}
+	}
+	return target
+}
+
+// Flatten - premultiplied pixels on the white background, the result is opaque
+func Flatten(img *image.RGBA) *image.RGBA {
+	flat := image.NewRGBA(img.Bounds())
+	for i := 0; i < len(img.Pix); i += 4 {
+		transparency := 0xff - img.Pix[i+3]
+		flat.Pix[i] = img.Pix[i] + transparency
+		flat.Pix[i+1] = img.Pix[i+1] + transparency
+		flat.Pix[i+2] = img.Pix[i+2] + transparency
+		flat.Pix[i+3] = 0xff
+	}
+	return flat
+}
+
+// fit - size inside the square, never larger than the source and never zero
+func fit(width, height, side int) (int, int) {
+	if width <= side && height <= side {
+		return width, height
+	}
+	if width >= height {
+		return side, maxInt(1, (height*side+width/2)/width)
+	}
+	return maxInt(1, (width*side+height/2)/height), side
+}
+
+// toRGBA - decoders give different image types, resizing works with one
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
+
+// sizes - generated sizes from the largest
+func sizes() []string {
+	result := make([]string, 0, len(domain.IMAGE_SIZES))
+	for size := range domain.IMAGE_SIZES {
+		result = append(result, size)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return domain.IMAGE_SIZES[result[i]] > domain.IMAGE_SIZES[result[j]]
+	})
+	return result
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,77 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResize(t *testing.T) {
+	source := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		source.Set(x, 0, color.RGBA{R: 255, A: 255})
+		source.Set(x, 1, color.RGBA{B: 255, A: 255})
+	}
+
+	t.Run("Can fit image keeping proportions", func(t *testing.T) {
+		resized := Resize(source, 2)
+		assert.Equal(t, image.Rect(0, 0, 2, 1), resized.Bounds())
+		assert.Equal(t, color.RGBA{R: 128, B: 128, A: 255}, resized.RGBAAt(0, 0))
+	})
+
+	t.Run("Can't upscale small image", func(t *testing.T) {
+		assert.Equal(t, source, Resize(source, 100))
+	})
+
+	t.Run("Can put transparent pixels on white", func(t *testing.T) {
+		transparent := image.NewRGBA(image.Rect(0, 0, 1, 1))
+		assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, Flatten(transparent).RGBAAt(0, 0))
+	})
+}
+
+func TestProcess(t *testing.T) {
+	var upload bytes.Buffer
+	assert.NoError(t, png.Encode(&upload, image.NewNRGBA(image.Rect(0, 0, 2000, 1000))))
+
+	t.Run("Can make all sizes", func(t *testing.T) {
+		variants, err := Process(upload.Bytes())
+		assert.NoError(t, err)
+		assert.Len(t, variants, len(domain.IMAGE_SIZES)+1)
+
+		original := variants[0]
+		assert.Equal(t, domain.IMAGE_ORIGINAL, original.Size)
+		assert.Equal(t, "image/png", original.ContentType)
+		assert.Equal(t, ".png", original.Extension)
+		assert.Equal(t, upload.Bytes(), original.Data)
+
+		sizes := map[string][2]int{}
+		for _, variant := range variants[1:] {
+			assert.Equal(t, "image/jpeg", variant.ContentType)
+			decoded, err := jpeg.Decode(bytes.NewReader(variant.Data))
+			assert.NoError(t, err)
+			assert.Equal(t, variant.Width, decoded.Bounds().Dx())
+			sizes[variant.Size] = [2]int{variant.Width, variant.Height}
+		}
+		assert.Equal(t, map[string][2]int{
+			domain.IMAGE_LARGE:  {1280, 640},
+			domain.IMAGE_MEDIUM: {640, 320},
+			domain.IMAGE_THUMB:  {240, 120},
+		}, sizes)
+	})
+
+	t.Run("Can't process not an image", func(t *testing.T) {
+		_, err := Process([]byte("<svg xmlns='http://www.w3.org/2000/svg'></svg>"))
+		assert.ErrorIs(t, err, domain.ErrInvalidImage)
+	})
+
+	t.Run("Can't process broken image", func(t *testing.T) {
+		_, err := Process(upload.Bytes()[:100])
+		assert.ErrorIs(t, err, domain.ErrInvalidImage)
+	})
+}
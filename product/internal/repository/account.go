@@ -3,36 +3,60 @@ package repository
 import (
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/p12s/furniture-store/product/internal/domain"
 )
 
+var _ Accounter = (*Account)(nil)
+
+// Accounter - repository interface
 type Accounter interface {
 	CreateAccount(account domain.Account) error
+	GetAccount(publicId string) (domain.Account, error)
 	UpdateAccountInfo(input domain.UpdateAccountInput) error
 	UpdateAccountRole(input domain.UpdateAccountRoleInput) error
-	DeleteAccount(accountPublicId uuid.UUID) error
-	GetByCredentials(email, password string) (domain.Account, error)
+	DeleteAccount(accountPublicId string) error
+	RevokeSessions(input domain.SessionsRevokedInput, now time.Time) error
+	IsSessionRevoked(sessionPublicId string) (bool, error)
 }
 
+// Account
 type Account struct {
 	db *sqlx.DB
 }
 
+// NewAccount - constructor
 func NewAccount(db *sqlx.DB) *Account {
 	return &Account{db: db}
 }
 
+// CreateAccount - role update can come before the created-event, so it is an upsert
 func (r *Account) CreateAccount(account domain.Account) error {
-	query := fmt.Sprintf(`INSERT INTO %s (public_id, name, username, password_hash, email, address, role)
-		values ($1, $2, $3, $4, $5, $6, $7)`, accountTable)
-	_, err := r.db.Exec(query, account.PublicId, account.Name,
-		account.Username, account.Password, account.Email, account.Address, domain.ROLE_CUSTOMER)
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, name, username, email, address, role)
+		values ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT(public_id) DO UPDATE SET name=excluded.name, username=excluded.username,
+			email=excluded.email, address=excluded.address, role=excluded.role`, accountTable)
+	_, err := r.db.Exec(query, account.PublicId, account.Name, account.Username,
+		account.Email, account.Address, account.Role)
 	return err
 }
 
+// GetAccount
+func (r *Account) GetAccount(publicId string) (domain.Account, error) {
+	var account domain.Account
+
+	query := fmt.Sprintf(`SELECT public_id, role FROM %s WHERE public_id=$1`, accountTable)
+	err := r.db.Get(&account, query, publicId)
+	if err != nil {
+		return account, fmt.Errorf("get account: %w", err)
+	}
+
+	return account, err
+}
+
+// UpdateAccountInfo - only the set fields are updated
 func (r *Account) UpdateAccountInfo(input domain.UpdateAccountInput) error {
 	setValues := make([]string, 0)
 	args := make([]interface{}, 0)
@@ -50,12 +74,6 @@ func (r *Account) UpdateAccountInfo(input domain.UpdateAccountInput) error {
 		argId++
 	}
 
-	if input.Password != nil {
-		setValues = append(setValues, fmt.Sprintf("password_hash=$%d", argId))
-		args = append(args, *input.Password)
-		argId++
-	}
-
 	if input.Email != nil {
 		setValues = append(setValues, fmt.Sprintf("email=$%d", argId))
 		args = append(args, *input.Email)
@@ -68,6 +86,9 @@ func (r *Account) UpdateAccountInfo(input domain.UpdateAccountInput) error {
 		argId++
 	}
 
+	if len(setValues) == 0 {
+		return nil
+	}
 	setQuery := strings.Join(setValues, ", ")
 
 	query := fmt.Sprintf(`UPDATE %s SET %s WHERE public_id = $%d`,
@@ -78,26 +99,48 @@ func (r *Account) UpdateAccountInfo(input domain.UpdateAccountInput) error {
 	return err
 }
 
+// UpdateAccountRole
 func (r *Account) UpdateAccountRole(input domain.UpdateAccountRoleInput) error {
-	query := fmt.Sprintf(`UPDATE %s SET role=$1 WHERE public_id = $2`, accountTable)
-	_, err := r.db.Exec(query, input.Role, input.PublicId)
+	query := fmt.Sprintf(`INSERT INTO %s (public_id, role) values ($1, $2)
+		ON CONFLICT(public_id) DO UPDATE SET role=excluded.role`, accountTable)
+	_, err := r.db.Exec(query, input.PublicId, input.Role)
 	return err
 }
 
-func (r *Account) DeleteAccount(accountPublicId uuid.UUID) error {
+// DeleteAccount - only the account copy is removed, dealer products are kept
+func (r *Account) DeleteAccount(accountPublicId string) error {
 	query := fmt.Sprintf(`DELETE FROM %s WHERE public_id = $1`, accountTable)
-	_, err := r.db.Exec(query, accountPublicId.String())
+	_, err := r.db.Exec(query, accountPublicId)
 	return err
 }
 
-func (r *Account) GetByCredentials(email, password string) (domain.Account, error) {
-	var account domain.Account
-
-	query := fmt.Sprintf(`SELECT * FROM %s WHERE email=$1 AND password_hash=$2`, accountTable)
-	err := r.db.Get(&account, query, email, password)
+// RevokeSessions - kept until the tokens expire, the expired ones are removed
+func (r *Account) RevokeSessions(input domain.SessionsRevokedInput, now time.Time) error {
+	tx, err := r.db.Beginx()
 	if err != nil {
-		return account, fmt.Errorf("get account: %w", err)
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`INSERT INTO %s (session_public_id, account_public_id, expires_at) values ($1, $2, $3)
+		ON CONFLICT(session_public_id) DO NOTHING`, revokedSessionTable)
+	for _, sessionPublicId := range input.SessionIds {
+		if _, err := tx.Exec(query, sessionPublicId, input.PublicId, input.ExpiresAt); err != nil {
+			return fmt.Errorf("revoke session: %w", err)
+		}
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE expires_at < $1`, revokedSessionTable)
+	if _, err := tx.Exec(query, now); err != nil {
+		return fmt.Errorf("remove expired sessions: %w", err)
 	}
 
-	return account, err
+	return tx.Commit()
+}
+
+// IsSessionRevoked
+func (r *Account) IsSessionRevoked(sessionPublicId string) (bool, error) {
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE session_public_id=$1`, revokedSessionTable)
+	err := r.db.Get(&count, query, sessionPublicId)
+	return count > 0, err
 }
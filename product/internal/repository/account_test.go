@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccount(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	copied := domain.Account{PublicId: uuid.New(), Name: "Copy", Username: "copy",
+		Email: "copy@mail.com", Address: "Address"}
+
+	t.Run("Can keep the role updated before the copy event", func(t *testing.T) {
+		assert.NoError(t, repos.UpdateAccountRole(domain.UpdateAccountRoleInput{PublicId: copied.PublicId, Role: domain.ROLE_DEALER}))
+		event := copied
+		event.Role = domain.ROLE_DEALER
+		assert.NoError(t, repos.CreateAccount(event))
+
+		account, err := repos.GetAccount(copied.PublicId.String())
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ROLE_DEALER, account.Role)
+	})
+
+	t.Run("Can update only the set account info", func(t *testing.T) {
+		email := "new@mail.com"
+		assert.NoError(t, repos.UpdateAccountInfo(domain.UpdateAccountInput{PublicId: copied.PublicId, Email: &email}))
+		assert.NoError(t, repos.UpdateAccountInfo(domain.UpdateAccountInput{PublicId: copied.PublicId}))
+
+		var account domain.Account
+		assert.NoError(t, db.Get(&account, `SELECT public_id, name, email FROM account WHERE public_id=$1`, copied.PublicId))
+		assert.Equal(t, copied.Name, account.Name)
+		assert.Equal(t, email, account.Email)
+	})
+}
+
+func TestAccount_dropPasswordHash(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// the account table of the service with its own sign-in
+	_, err = db.Exec(`CREATE TABLE account (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"name" TEXT,
+		"username" TEXT,
+		"password_hash" TEXT,
+		"email" TEXT,
+		"address" TEXT,
+		"role" INTEGER DEFAULT 0,
+		"created_at" DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL
+	  );`)
+	assert.NoError(t, err)
+	publicId := uuid.New()
+	_, err = db.Exec(`INSERT INTO account (public_id, password_hash) VALUES ($1, 'hash')`, publicId)
+	assert.NoError(t, err)
+
+	repos := NewRepository(db)
+	assert.False(t, columnExists(db, accountTable, "password_hash"))
+
+	account, err := repos.GetAccount(publicId.String())
+	assert.NoError(t, err)
+	assert.Equal(t, publicId, account.PublicId)
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+var _ APIKeyer = (*APIKey)(nil)
+
+// APIKeyer - repository interface
+type APIKeyer interface {
+	CreateAPIKey(key domain.APIKey) error
+	RevokeAPIKey(prefix, accountPublicId string, now time.Time) error
+	GetAPIKey(prefix string) (domain.APIKey, error)
+	TouchAPIKey(prefix string, now, before time.Time) (bool, error)
+}
+
+// APIKey - copies of the dealer api keys
+type APIKey struct {
+	db *sqlx.DB
+}
+
+// NewAPIKey - constructor
+func NewAPIKey(db *sqlx.DB) *APIKey {
+	return &APIKey{db: db}
+}
+
+// apiKeyRow - the scopes are kept space separated
+type apiKeyRow struct {
+	domain.APIKey
+	Scopes string `db:"scopes"`
+}
+
+// CreateAPIKey - the revoke event can come before the created one, the revoked key is not created again
+func (r *APIKey) CreateAPIKey(key domain.APIKey) error {
+	query := fmt.Sprintf(`INSERT INTO %s (prefix, key_hash, account_public_id, scopes, expires_at)
+		values ($1, $2, $3, $4, $5) ON CONFLICT(prefix) DO NOTHING`, apiKeyTable)
+	_, err := r.db.Exec(query, key.Prefix, key.KeyHash, key.AccountPublicId, strings.Join(key.Scopes, " "),
+		key.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("create api key: %w", err)
+	}
+	return nil
+}
+
+// RevokeAPIKey - the revoked key is kept, so its late created event is ignored
+func (r *APIKey) RevokeAPIKey(prefix, accountPublicId string, now time.Time) error {
+	query := fmt.Sprintf(`INSERT INTO %s (prefix, account_public_id, expires_at, revoked_at) values ($1, $2, $3, $4)
+		ON CONFLICT(prefix) DO UPDATE SET revoked_at=excluded.revoked_at`, apiKeyTable)
+	if _, err := r.db.Exec(query, prefix, accountPublicId, now, now); err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+	return nil
+}
+
+// GetAPIKey - not revoked, ErrInvalidAPIKey for the unknown one
+func (r *APIKey) GetAPIKey(prefix string) (domain.APIKey, error) {
+	var row apiKeyRow
+	query := fmt.Sprintf(`SELECT prefix, key_hash, account_public_id, scopes, expires_at, last_used_at FROM %s
+		WHERE prefix=$1 AND revoked_at IS NULL`, apiKeyTable)
+	err := r.db.Get(&row, query, prefix)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.APIKey{}, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return domain.APIKey{}, fmt.Errorf("get api key: %w", err)
+	}
+	key := row.APIKey
+	key.Scopes = strings.Fields(row.Scopes)
+	return key, nil
+}
+
+// TouchAPIKey - the last used time is updated when it is older than before, true when it is updated
+func (r *APIKey) TouchAPIKey(prefix string, now, before time.Time) (bool, error) {
+	query := fmt.Sprintf(`UPDATE %s SET last_used_at=$1 WHERE prefix=$2
+		AND (last_used_at IS NULL OR last_used_at < $3)`, apiKeyTable)
+	result, err := r.db.Exec(query, now, prefix, before)
+	if err != nil {
+		return false, fmt.Errorf("touch api key: %w", err)
+	}
+	touched, err := result.RowsAffected()
+	return touched > 0, err
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKey(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	dealer := uuid.NewString()
+	key := domain.APIKey{Prefix: "fsk_0a1b2c3d4e5f", KeyHash: "hash", AccountPublicId: dealer,
+		Scopes:    []string{domain.API_KEY_SCOPE_PRODUCTS_WRITE, domain.API_KEY_SCOPE_STOCK_READ},
+		ExpiresAt: now.Add(24 * time.Hour)}
+
+	t.Run("Can get the created key with its scopes", func(t *testing.T) {
+		assert.NoError(t, repos.CreateAPIKey(key))
+		assert.NoError(t, repos.CreateAPIKey(key))
+
+		stored, err := repos.GetAPIKey(key.Prefix)
+		assert.NoError(t, err)
+		assert.Equal(t, key.KeyHash, stored.KeyHash)
+		assert.Equal(t, dealer, stored.AccountPublicId)
+		assert.Equal(t, key.Scopes, stored.Scopes)
+		assert.Nil(t, stored.LastUsedAt)
+
+		_, err = repos.GetAPIKey("fsk_ffffffffffff")
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+
+	t.Run("Can touch the key once an interval", func(t *testing.T) {
+		touched, err := repos.TouchAPIKey(key.Prefix, now, now.Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, touched)
+
+		touched, err = repos.TouchAPIKey(key.Prefix, now.Add(30*time.Second), now.Add(-30*time.Second))
+		assert.NoError(t, err)
+		assert.False(t, touched)
+
+		touched, err = repos.TouchAPIKey(key.Prefix, now.Add(2*time.Minute), now.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, touched)
+	})
+
+	t.Run("Can't get the revoked key, even created after the revoke", func(t *testing.T) {
+		assert.NoError(t, repos.RevokeAPIKey(key.Prefix, dealer, now))
+		_, err := repos.GetAPIKey(key.Prefix)
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+
+		late := domain.APIKey{Prefix: "fsk_a1a1a1a1a1a1", KeyHash: "hash", AccountPublicId: dealer,
+			Scopes: []string{domain.API_KEY_SCOPE_STOCK_READ}, ExpiresAt: now.Add(time.Hour)}
+		assert.NoError(t, repos.RevokeAPIKey(late.Prefix, dealer, now))
+		assert.NoError(t, repos.CreateAPIKey(late))
+		_, err = repos.GetAPIKey(late.Prefix)
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+}
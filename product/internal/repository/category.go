@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+var _ Categorizer = (*Category)(nil)
+
+// Categorizer - repository interface
+type Categorizer interface {
+	CreateCategory(category domain.Category) error
+	GetCategory(slug string) (domain.Category, error)
+	GetCategories() ([]domain.Category, error)
+	GetCategoryPath(slug string) ([]domain.Category, error)
+	UpdateCategory(category domain.Category) error
+	DeleteCategory(slug string) error
+}
+
+// Category - category tree, parent is referenced by slug, root categories have empty parent
+type Category struct {
+	db *sqlx.DB
+}
+
+// NewCategory - constructor
+func NewCategory(db *sqlx.DB) *Category {
+	return &Category{db: db}
+}
+
+// CreateCategory
+func (r *Category) CreateCategory(category domain.Category) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	var exists int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE slug=$1`, categoryTable)
+	if err = tx.Get(&exists, query, category.Slug); err != nil {
+		return fmt.Errorf("check category slug: %w", err)
+	}
+	if exists > 0 {
+		return domain.ErrCategoryExists
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (slug, parent_slug, name, attributes) values ($1, $2, $3, $4)`, categoryTable)
+	if _, err = tx.Exec(query, category.Slug, category.Parent, category.Name, category.Attributes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetCategory
+func (r *Category) GetCategory(slug string) (domain.Category, error) {
+	var category domain.Category
+
+	query := fmt.Sprintf(`SELECT slug, parent_slug, name, attributes FROM %s WHERE slug=$1`, categoryTable)
+	err := r.db.Get(&category, query, slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return category, domain.ErrCategoryNotFound
+	}
+	if err != nil {
+		return category, fmt.Errorf("get category: %w", err)
+	}
+
+	return category, nil
+}
+
+// GetCategories - all categories ordered by name
+func (r *Category) GetCategories() ([]domain.Category, error) {
+	categories := []domain.Category{}
+
+	query := fmt.Sprintf(`SELECT slug, parent_slug, name, attributes FROM %s ORDER BY name, slug`, categoryTable)
+	if err := r.db.Select(&categories, query); err != nil {
+		return nil, fmt.Errorf("get categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// GetCategoryPath - the category with all its parents, from the root
+func (r *Category) GetCategoryPath(slug string) ([]domain.Category, error) {
+	var path []domain.Category
+
+	query := fmt.Sprintf(`WITH RECURSIVE path(slug, parent_slug, name, attributes, depth) AS (
+			SELECT slug, parent_slug, name, attributes, 0 FROM %[1]s WHERE slug=$1
+			UNION ALL
+			SELECT c.slug, c.parent_slug, c.name, c.attributes, path.depth + 1
+			FROM %[1]s c JOIN path ON c.slug = path.parent_slug
+		) SELECT slug, parent_slug, name, attributes FROM path ORDER BY depth DESC`, categoryTable)
+	if err := r.db.Select(&path, query, slug); err != nil {
+		return nil, fmt.Errorf("get category path: %w", err)
+	}
+	if len(path) == 0 {
+		return nil, domain.ErrCategoryNotFound
+	}
+
+	return path, nil
+}
+
+// UpdateCategory - slug is not changed
+func (r *Category) UpdateCategory(category domain.Category) error {
+	query := fmt.Sprintf(`UPDATE %s SET parent_slug=$1, name=$2, attributes=$3 WHERE slug=$4`, categoryTable)
+	result, err := r.db.Exec(query, category.Parent, category.Name, category.Attributes, category.Slug)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// DeleteCategory - only a category without subcategories and products
+func (r *Category) DeleteCategory(slug string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	var used int64
+	query := fmt.Sprintf(`SELECT (SELECT count(*) FROM %s WHERE parent_slug=$1)
+		+ (SELECT count(*) FROM %s WHERE category=$1)`, categoryTable, productTable)
+	if err := tx.Get(&used, query, slug); err != nil {
+		return fmt.Errorf("check category usage: %w", err)
+	}
+	if used > 0 {
+		return domain.ErrCategoryInUse
+	}
+
+	result, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE slug=$1`, categoryTable), slug)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrCategoryNotFound
+	}
+
+	return tx.Commit()
+}
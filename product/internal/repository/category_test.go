@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategory(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	furniture := domain.Category{Slug: "furniture", Name: "Furniture", Attributes: domain.AttributeDefinitions{
+		{Code: "color", Name: "Colour", Type: domain.ATTRIBUTE_ENUM, Values: []string{"grey", "white"}},
+	}}
+	sofas := domain.Category{Slug: "sofas", Parent: "furniture", Name: "Sofas"}
+	corner := domain.Category{Slug: "corner-sofas", Parent: "sofas", Name: "Corner sofas", Attributes: domain.AttributeDefinitions{
+		{Code: "width", Name: "Width", Type: domain.ATTRIBUTE_NUMBER, Unit: "cm", Required: true},
+	}}
+	for _, category := range []domain.Category{furniture, sofas, corner} {
+		assert.NoError(t, repos.CreateCategory(category))
+	}
+
+	t.Run("Can't create category twice", func(t *testing.T) {
+		assert.ErrorIs(t, repos.CreateCategory(sofas), domain.ErrCategoryExists)
+	})
+
+	t.Run("Can get category path from the root", func(t *testing.T) {
+		path, err := repos.GetCategoryPath("corner-sofas")
+		assert.NoError(t, err)
+		assert.Equal(t, furniture, path[0])
+		assert.Equal(t, []string{"furniture", "sofas", "corner-sofas"}, []string{path[0].Slug, path[1].Slug, path[2].Slug})
+		assert.Equal(t, corner, path[2])
+
+		_, err = repos.GetCategoryPath("beds")
+		assert.ErrorIs(t, err, domain.ErrCategoryNotFound)
+	})
+
+	t.Run("Can search category with subcategories", func(t *testing.T) {
+		now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+		product := domain.Product{PublicId: uuid.New(), DealerPublicId: uuid.New(), Name: "Corner sofa",
+			Category: "corner-sofas", Attributes: domain.Attributes{"color": "grey", "width": float64(240)},
+			CreatedAt: now, UpdatedAt: now}
+		assert.NoError(t, repos.CreateProduct(product))
+
+		query := domain.SearchQuery{Category: "furniture"}
+		assert.NoError(t, query.Validate())
+		products, _, err := repos.SearchProducts(query)
+		assert.NoError(t, err)
+		assert.Len(t, products, 1)
+		assert.Equal(t, product.Attributes, products[0].Attributes)
+
+		stored, err := repos.GetProduct(product.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, product.Attributes, stored.Attributes)
+	})
+
+	t.Run("Can delete only unused category", func(t *testing.T) {
+		assert.ErrorIs(t, repos.DeleteCategory("sofas"), domain.ErrCategoryInUse)
+		assert.ErrorIs(t, repos.DeleteCategory("corner-sofas"), domain.ErrCategoryInUse)
+
+		assert.NoError(t, repos.CreateCategory(domain.Category{Slug: "beds", Parent: "furniture", Name: "Beds"}))
+		assert.NoError(t, repos.DeleteCategory("beds"))
+		assert.ErrorIs(t, repos.DeleteCategory("beds"), domain.ErrCategoryNotFound)
+	})
+}
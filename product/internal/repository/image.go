@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+var _ Imager = (*Image)(nil)
+
+// Imager - repository interface
+type Imager interface {
+	AddImage(image domain.ProductImage) error
+	DeleteImage(productPublicId, publicId uuid.UUID, now time.Time) (domain.ProductImage, error)
+	OrderImages(productPublicId uuid.UUID, order []uuid.UUID, now time.Time) error
+}
+
+// Image - product images, every change touches the product update time
+type Image struct {
+	db *sqlx.DB
+}
+
+// NewImage - constructor
+func NewImage(db *sqlx.DB) *Image {
+	return &Image{db: db}
+}
+
+const imageColumns = `public_id, product_public_id, position, content_type, width, height, urls, blob_keys, created_at`
+
+// AddImage - the new image goes last
+func (r *Image) AddImage(image domain.ProductImage) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE product_public_id=$1`, productImageTable)
+	if err = tx.Get(&count, query, image.ProductPublicId); err != nil {
+		return fmt.Errorf("count product images: %w", err)
+	}
+	if count >= domain.MAX_PRODUCT_IMAGES {
+		return domain.ErrTooManyImages
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (%s) values ($1, $2,
+		(SELECT COALESCE(MAX(position), -1) + 1 FROM %[1]s WHERE product_public_id=$2), $3, $4, $5, $6, $7, $8)`,
+		productImageTable, imageColumns)
+	_, err = tx.Exec(query, image.PublicId, image.ProductPublicId, image.ContentType,
+		image.Width, image.Height, image.URLs, image.Keys, image.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err = touchProduct(tx, image.ProductPublicId, image.CreatedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteImage - the next images are moved up
+func (r *Image) DeleteImage(productPublicId, publicId uuid.UUID, now time.Time) (domain.ProductImage, error) {
+	var image domain.ProductImage
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return image, err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE product_public_id=$1 AND public_id=$2`,
+		imageColumns, productImageTable)
+	images := []domain.ProductImage{}
+	if err = tx.Select(&images, query, productPublicId, publicId); err != nil {
+		return image, fmt.Errorf("get product image: %w", err)
+	}
+	if len(images) == 0 {
+		return image, domain.ErrImageNotFound
+	}
+	image = images[0]
+
+	if _, err = tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, productImageTable), publicId); err != nil {
+		return image, err
+	}
+	query = fmt.Sprintf(`UPDATE %s SET position=position - 1 WHERE product_public_id=$1 AND position > $2`,
+		productImageTable)
+	if _, err = tx.Exec(query, productPublicId, image.Position); err != nil {
+		return image, err
+	}
+
+	if err = touchProduct(tx, productPublicId, now); err != nil {
+		return image, err
+	}
+	return image, tx.Commit()
+}
+
+// OrderImages - positions by the order, the order is checked by the service
+func (r *Image) OrderImages(productPublicId uuid.UUID, order []uuid.UUID, now time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`UPDATE %s SET position=$1 WHERE product_public_id=$2 AND public_id=$3`, productImageTable)
+	for position, publicId := range order {
+		result, err := tx.Exec(query, position, productPublicId, publicId)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return domain.ErrImageNotFound
+		}
+	}
+
+	if err = touchProduct(tx, productPublicId, now); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// touchProduct - image changes are product changes
+func touchProduct(tx *sqlx.Tx, publicId uuid.UUID, now time.Time) error {
+	result, err := tx.Exec(fmt.Sprintf(`UPDATE %s SET updated_at=$1 WHERE public_id=$2`, productTable), now, publicId)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+// selectImages - images of the products by position, every product gets at least an empty list
+func selectImages(db sqlx.Queryer, products []domain.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.PublicId)
+	}
+	query, args, err := sqlx.In(fmt.Sprintf(`SELECT %s FROM %s WHERE product_public_id IN (?)
+		ORDER BY position, id`, imageColumns, productImageTable), ids)
+	if err != nil {
+		return err
+	}
+
+	var images []domain.ProductImage
+	if err = sqlx.Select(db, &images, query, args...); err != nil {
+		return fmt.Errorf("get product images: %w", err)
+	}
+
+	byProduct := make(map[uuid.UUID][]domain.ProductImage, len(products))
+	for _, image := range images {
+		byProduct[image.ProductPublicId] = append(byProduct[image.ProductPublicId], image)
+	}
+	for i := range products {
+		products[i].Images = byProduct[products[i].PublicId]
+		if products[i].Images == nil {
+			products[i].Images = []domain.ProductImage{}
+		}
+	}
+	return nil
+}
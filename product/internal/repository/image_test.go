@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImage(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	product := domain.Product{PublicId: uuid.New(), DealerPublicId: uuid.New(), Name: "Sofa", CreatedAt: now, UpdatedAt: now}
+	assert.NoError(t, repos.CreateProduct(product))
+
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		image := domain.ProductImage{PublicId: uuid.New(), ProductPublicId: product.PublicId, ContentType: "image/png",
+			URLs: domain.ImageMap{domain.IMAGE_THUMB: "http://images/thumb.jpg"},
+			Keys: domain.ImageMap{domain.IMAGE_THUMB: "thumb.jpg"}, CreatedAt: now.Add(time.Hour)}
+		assert.NoError(t, repos.AddImage(image))
+		ids = append(ids, image.PublicId)
+	}
+
+	positions := func() []uuid.UUID {
+		stored, err := repos.GetProduct(product.PublicId)
+		assert.NoError(t, err)
+		var result []uuid.UUID
+		for i, image := range stored.Images {
+			assert.Equal(t, i, image.Position)
+			result = append(result, image.PublicId)
+		}
+		return result
+	}
+
+	t.Run("Can get product with images in adding order", func(t *testing.T) {
+		stored, err := repos.GetProduct(product.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, ids, positions())
+		assert.Equal(t, "http://images/thumb.jpg", stored.Images[0].URLs[domain.IMAGE_THUMB])
+		assert.True(t, stored.UpdatedAt.After(now))
+	})
+
+	t.Run("Can order images", func(t *testing.T) {
+		order := []uuid.UUID{ids[2], ids[0], ids[1]}
+		assert.NoError(t, repos.OrderImages(product.PublicId, order, now))
+		assert.Equal(t, order, positions())
+	})
+
+	t.Run("Can delete image and keep positions", func(t *testing.T) {
+		deleted, err := repos.DeleteImage(product.PublicId, ids[2], now)
+		assert.NoError(t, err)
+		assert.Equal(t, "thumb.jpg", deleted.Keys[domain.IMAGE_THUMB])
+		assert.Equal(t, []uuid.UUID{ids[0], ids[1]}, positions())
+
+		_, err = repos.DeleteImage(product.PublicId, ids[2], now)
+		assert.ErrorIs(t, err, domain.ErrImageNotFound)
+	})
+
+	t.Run("Can't add images over the limit", func(t *testing.T) {
+		for i := 2; i < domain.MAX_PRODUCT_IMAGES; i++ {
+			assert.NoError(t, repos.AddImage(domain.ProductImage{PublicId: uuid.New(),
+				ProductPublicId: product.PublicId, ContentType: "image/png", CreatedAt: now}))
+		}
+		assert.ErrorIs(t, repos.AddImage(domain.ProductImage{PublicId: uuid.New(),
+			ProductPublicId: product.PublicId, ContentType: "image/png", CreatedAt: now}), domain.ErrTooManyImages)
+	})
+
+	t.Run("Can delete product with images", func(t *testing.T) {
+		assert.NoError(t, repos.DeleteProduct(product.PublicId))
+
+		var count int
+		assert.NoError(t, db.Get(&count, `SELECT COUNT(*) FROM product_image`))
+		assert.Equal(t, 0, count)
+	})
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+var _ Importer = (*Import)(nil)
+
+// Importer - repository interface
+type Importer interface {
+	CreateImportJob(job domain.ImportJob) error
+	UpdateImportJob(job domain.ImportJob) error
+	GetImportJob(publicId uuid.UUID) (domain.ImportJob, error)
+}
+
+// Import - bulk import jobs, the rows are not kept
+type Import struct {
+	db *sqlx.DB
+}
+
+// NewImport - constructor
+func NewImport(db *sqlx.DB) *Import {
+	return &Import{db: db}
+}
+
+const importJobColumns = `public_id, dealer_public_id, status, total, processed,
+	created, updated, failed, errors, created_at, updated_at`
+
+// CreateImportJob
+func (r *Import) CreateImportJob(job domain.ImportJob) error {
+	query := fmt.Sprintf(`INSERT INTO %s (%s) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		importJobTable, importJobColumns)
+	_, err := r.db.Exec(query, job.PublicId, job.DealerPublicId, job.Status, job.Total, job.Processed,
+		job.Created, job.Updated, job.Failed, job.Errors, job.CreatedAt, job.UpdatedAt)
+	return err
+}
+
+// UpdateImportJob - progress and status
+func (r *Import) UpdateImportJob(job domain.ImportJob) error {
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, processed=$2, created=$3, updated=$4, failed=$5,
+		errors=$6, updated_at=$7 WHERE public_id=$8`, importJobTable)
+	result, err := r.db.Exec(query, job.Status, job.Processed, job.Created, job.Updated, job.Failed,
+		job.Errors, job.UpdatedAt, job.PublicId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrImportJobNotFound
+	}
+	return nil
+}
+
+// GetImportJob
+func (r *Import) GetImportJob(publicId uuid.UUID) (domain.ImportJob, error) {
+	var job domain.ImportJob
+
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE public_id=$1`, importJobColumns, importJobTable)
+	err := r.db.Get(&job, query, publicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return job, domain.ErrImportJobNotFound
+	}
+	if err != nil {
+		return job, fmt.Errorf("get import job: %w", err)
+	}
+
+	return job, nil
+}
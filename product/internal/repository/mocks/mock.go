@@ -0,0 +1,922 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/p12s/furniture-store/product/internal/repository (interfaces: Accounter,Producter,Searcher,Categorizer,Imager,Importer,Stocker,Pricer,Reviewer,Privacier,APIKeyer)
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	domain "github.com/p12s/furniture-store/product/internal/domain"
+)
+
+// MockAccounter is a mock of Accounter interface.
+type MockAccounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccounterMockRecorder
+}
+
+// MockAccounterMockRecorder is the mock recorder for MockAccounter.
+type MockAccounterMockRecorder struct {
+	mock *MockAccounter
+}
+
+// NewMockAccounter creates a new mock instance.
+func NewMockAccounter(ctrl *gomock.Controller) *MockAccounter {
+	mock := &MockAccounter{ctrl: ctrl}
+	mock.recorder = &MockAccounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccounter) EXPECT() *MockAccounterMockRecorder {
+	return m.recorder
+}
+
+// CreateAccount mocks base method.
+func (m *MockAccounter) CreateAccount(arg0 domain.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockAccounterMockRecorder) CreateAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAccounter)(nil).CreateAccount), arg0)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccounter) DeleteAccount(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccounterMockRecorder) DeleteAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccounter)(nil).DeleteAccount), arg0)
+}
+
+// GetAccount mocks base method.
+func (m *MockAccounter) GetAccount(arg0 string) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccounterMockRecorder) GetAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccounter)(nil).GetAccount), arg0)
+}
+
+// IsSessionRevoked mocks base method.
+func (m *MockAccounter) IsSessionRevoked(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSessionRevoked", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSessionRevoked indicates an expected call of IsSessionRevoked.
+func (mr *MockAccounterMockRecorder) IsSessionRevoked(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSessionRevoked", reflect.TypeOf((*MockAccounter)(nil).IsSessionRevoked), arg0)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockAccounter) RevokeSessions(arg0 domain.SessionsRevokedInput, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAccounterMockRecorder) RevokeSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockAccounter)(nil).RevokeSessions), arg0, arg1)
+}
+
+// UpdateAccountInfo mocks base method.
+func (m *MockAccounter) UpdateAccountInfo(arg0 domain.UpdateAccountInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountInfo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountInfo indicates an expected call of UpdateAccountInfo.
+func (mr *MockAccounterMockRecorder) UpdateAccountInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountInfo", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountInfo), arg0)
+}
+
+// UpdateAccountRole mocks base method.
+func (m *MockAccounter) UpdateAccountRole(arg0 domain.UpdateAccountRoleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountRole", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountRole indicates an expected call of UpdateAccountRole.
+func (mr *MockAccounterMockRecorder) UpdateAccountRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountRole", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountRole), arg0)
+}
+
+// MockProducter is a mock of Producter interface.
+type MockProducter struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducterMockRecorder
+}
+
+// MockProducterMockRecorder is the mock recorder for MockProducter.
+type MockProducterMockRecorder struct {
+	mock *MockProducter
+}
+
+// NewMockProducter creates a new mock instance.
+func NewMockProducter(ctrl *gomock.Controller) *MockProducter {
+	mock := &MockProducter{ctrl: ctrl}
+	mock.recorder = &MockProducterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducter) EXPECT() *MockProducterMockRecorder {
+	return m.recorder
+}
+
+// CreateProduct mocks base method.
+func (m *MockProducter) CreateProduct(arg0 domain.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockProducterMockRecorder) CreateProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockProducter)(nil).CreateProduct), arg0)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockProducter) DeleteProduct(arg0 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockProducterMockRecorder) DeleteProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProducter)(nil).DeleteProduct), arg0)
+}
+
+// GetDealerProducts mocks base method.
+func (m *MockProducter) GetDealerProducts(arg0 uuid.UUID) ([]domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDealerProducts", arg0)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDealerProducts indicates an expected call of GetDealerProducts.
+func (mr *MockProducterMockRecorder) GetDealerProducts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDealerProducts", reflect.TypeOf((*MockProducter)(nil).GetDealerProducts), arg0)
+}
+
+// GetProduct mocks base method.
+func (m *MockProducter) GetProduct(arg0 uuid.UUID) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", arg0)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockProducterMockRecorder) GetProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockProducter)(nil).GetProduct), arg0)
+}
+
+// GetProductBySku mocks base method.
+func (m *MockProducter) GetProductBySku(arg0 uuid.UUID, arg1 string) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductBySku", arg0, arg1)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductBySku indicates an expected call of GetProductBySku.
+func (mr *MockProducterMockRecorder) GetProductBySku(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductBySku", reflect.TypeOf((*MockProducter)(nil).GetProductBySku), arg0, arg1)
+}
+
+// UpdateProduct mocks base method.
+func (m *MockProducter) UpdateProduct(arg0 domain.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *MockProducterMockRecorder) UpdateProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockProducter)(nil).UpdateProduct), arg0)
+}
+
+// MockSearcher is a mock of Searcher interface.
+type MockSearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearcherMockRecorder
+}
+
+// MockSearcherMockRecorder is the mock recorder for MockSearcher.
+type MockSearcherMockRecorder struct {
+	mock *MockSearcher
+}
+
+// NewMockSearcher creates a new mock instance.
+func NewMockSearcher(ctrl *gomock.Controller) *MockSearcher {
+	mock := &MockSearcher{ctrl: ctrl}
+	mock.recorder = &MockSearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearcher) EXPECT() *MockSearcherMockRecorder {
+	return m.recorder
+}
+
+// GetSearchFacets mocks base method.
+func (m *MockSearcher) GetSearchFacets(arg0 domain.SearchQuery) (domain.SearchFacets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSearchFacets", arg0)
+	ret0, _ := ret[0].(domain.SearchFacets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSearchFacets indicates an expected call of GetSearchFacets.
+func (mr *MockSearcherMockRecorder) GetSearchFacets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSearchFacets", reflect.TypeOf((*MockSearcher)(nil).GetSearchFacets), arg0)
+}
+
+// SearchProducts mocks base method.
+func (m *MockSearcher) SearchProducts(arg0 domain.SearchQuery) ([]domain.Product, *domain.SearchCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchProducts", arg0)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(*domain.SearchCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchProducts indicates an expected call of SearchProducts.
+func (mr *MockSearcherMockRecorder) SearchProducts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProducts", reflect.TypeOf((*MockSearcher)(nil).SearchProducts), arg0)
+}
+
+// MockCategorizer is a mock of Categorizer interface.
+type MockCategorizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategorizerMockRecorder
+}
+
+// MockCategorizerMockRecorder is the mock recorder for MockCategorizer.
+type MockCategorizerMockRecorder struct {
+	mock *MockCategorizer
+}
+
+// NewMockCategorizer creates a new mock instance.
+func NewMockCategorizer(ctrl *gomock.Controller) *MockCategorizer {
+	mock := &MockCategorizer{ctrl: ctrl}
+	mock.recorder = &MockCategorizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategorizer) EXPECT() *MockCategorizerMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockCategorizer) CreateCategory(arg0 domain.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCategorizerMockRecorder) CreateCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategorizer)(nil).CreateCategory), arg0)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategorizer) DeleteCategory(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategorizerMockRecorder) DeleteCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategorizer)(nil).DeleteCategory), arg0)
+}
+
+// GetCategories mocks base method.
+func (m *MockCategorizer) GetCategories() ([]domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories")
+	ret0, _ := ret[0].([]domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockCategorizerMockRecorder) GetCategories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockCategorizer)(nil).GetCategories))
+}
+
+// GetCategory mocks base method.
+func (m *MockCategorizer) GetCategory(arg0 string) (domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0)
+	ret0, _ := ret[0].(domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockCategorizerMockRecorder) GetCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockCategorizer)(nil).GetCategory), arg0)
+}
+
+// GetCategoryPath mocks base method.
+func (m *MockCategorizer) GetCategoryPath(arg0 string) ([]domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryPath", arg0)
+	ret0, _ := ret[0].([]domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryPath indicates an expected call of GetCategoryPath.
+func (mr *MockCategorizerMockRecorder) GetCategoryPath(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryPath", reflect.TypeOf((*MockCategorizer)(nil).GetCategoryPath), arg0)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategorizer) UpdateCategory(arg0 domain.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategorizerMockRecorder) UpdateCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategorizer)(nil).UpdateCategory), arg0)
+}
+
+// MockImager is a mock of Imager interface.
+type MockImager struct {
+	ctrl     *gomock.Controller
+	recorder *MockImagerMockRecorder
+}
+
+// MockImagerMockRecorder is the mock recorder for MockImager.
+type MockImagerMockRecorder struct {
+	mock *MockImager
+}
+
+// NewMockImager creates a new mock instance.
+func NewMockImager(ctrl *gomock.Controller) *MockImager {
+	mock := &MockImager{ctrl: ctrl}
+	mock.recorder = &MockImagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImager) EXPECT() *MockImagerMockRecorder {
+	return m.recorder
+}
+
+// AddImage mocks base method.
+func (m *MockImager) AddImage(arg0 domain.ProductImage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddImage", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddImage indicates an expected call of AddImage.
+func (mr *MockImagerMockRecorder) AddImage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddImage", reflect.TypeOf((*MockImager)(nil).AddImage), arg0)
+}
+
+// DeleteImage mocks base method.
+func (m *MockImager) DeleteImage(arg0, arg1 uuid.UUID, arg2 time.Time) (domain.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockImagerMockRecorder) DeleteImage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockImager)(nil).DeleteImage), arg0, arg1, arg2)
+}
+
+// OrderImages mocks base method.
+func (m *MockImager) OrderImages(arg0 uuid.UUID, arg1 []uuid.UUID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderImages", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrderImages indicates an expected call of OrderImages.
+func (mr *MockImagerMockRecorder) OrderImages(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderImages", reflect.TypeOf((*MockImager)(nil).OrderImages), arg0, arg1, arg2)
+}
+
+// MockImporter is a mock of Importer interface.
+type MockImporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockImporterMockRecorder
+}
+
+// MockImporterMockRecorder is the mock recorder for MockImporter.
+type MockImporterMockRecorder struct {
+	mock *MockImporter
+}
+
+// NewMockImporter creates a new mock instance.
+func NewMockImporter(ctrl *gomock.Controller) *MockImporter {
+	mock := &MockImporter{ctrl: ctrl}
+	mock.recorder = &MockImporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImporter) EXPECT() *MockImporterMockRecorder {
+	return m.recorder
+}
+
+// CreateImportJob mocks base method.
+func (m *MockImporter) CreateImportJob(arg0 domain.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportJob", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateImportJob indicates an expected call of CreateImportJob.
+func (mr *MockImporterMockRecorder) CreateImportJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportJob", reflect.TypeOf((*MockImporter)(nil).CreateImportJob), arg0)
+}
+
+// GetImportJob mocks base method.
+func (m *MockImporter) GetImportJob(arg0 uuid.UUID) (domain.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", arg0)
+	ret0, _ := ret[0].(domain.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockImporterMockRecorder) GetImportJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockImporter)(nil).GetImportJob), arg0)
+}
+
+// UpdateImportJob mocks base method.
+func (m *MockImporter) UpdateImportJob(arg0 domain.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateImportJob", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateImportJob indicates an expected call of UpdateImportJob.
+func (mr *MockImporterMockRecorder) UpdateImportJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImportJob", reflect.TypeOf((*MockImporter)(nil).UpdateImportJob), arg0)
+}
+
+// MockStocker is a mock of Stocker interface.
+type MockStocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockerMockRecorder
+}
+
+// MockStockerMockRecorder is the mock recorder for MockStocker.
+type MockStockerMockRecorder struct {
+	mock *MockStocker
+}
+
+// NewMockStocker creates a new mock instance.
+func NewMockStocker(ctrl *gomock.Controller) *MockStocker {
+	mock := &MockStocker{ctrl: ctrl}
+	mock.recorder = &MockStockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStocker) EXPECT() *MockStockerMockRecorder {
+	return m.recorder
+}
+
+// AddMovement mocks base method.
+func (m *MockStocker) AddMovement(arg0 domain.StockMovement) (domain.StockMovement, domain.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMovement", arg0)
+	ret0, _ := ret[0].(domain.StockMovement)
+	ret1, _ := ret[1].(domain.Stock)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddMovement indicates an expected call of AddMovement.
+func (mr *MockStockerMockRecorder) AddMovement(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovement", reflect.TypeOf((*MockStocker)(nil).AddMovement), arg0)
+}
+
+// GetMovements mocks base method.
+func (m *MockStocker) GetMovements(arg0 uuid.UUID, arg1 int64, arg2 int) ([]domain.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovements", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovements indicates an expected call of GetMovements.
+func (mr *MockStockerMockRecorder) GetMovements(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovements", reflect.TypeOf((*MockStocker)(nil).GetMovements), arg0, arg1, arg2)
+}
+
+// GetStockLevels mocks base method.
+func (m *MockStocker) GetStockLevels(arg0 uuid.UUID, arg1 bool) ([]domain.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockLevels", arg0, arg1)
+	ret0, _ := ret[0].([]domain.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockLevels indicates an expected call of GetStockLevels.
+func (mr *MockStockerMockRecorder) GetStockLevels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockLevels", reflect.TypeOf((*MockStocker)(nil).GetStockLevels), arg0, arg1)
+}
+
+// SetLowStockThreshold mocks base method.
+func (m *MockStocker) SetLowStockThreshold(arg0 uuid.UUID, arg1 int64, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLowStockThreshold", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLowStockThreshold indicates an expected call of SetLowStockThreshold.
+func (mr *MockStockerMockRecorder) SetLowStockThreshold(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLowStockThreshold", reflect.TypeOf((*MockStocker)(nil).SetLowStockThreshold), arg0, arg1, arg2)
+}
+
+// MockPricer is a mock of Pricer interface.
+type MockPricer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPricerMockRecorder
+}
+
+// MockPricerMockRecorder is the mock recorder for MockPricer.
+type MockPricerMockRecorder struct {
+	mock *MockPricer
+}
+
+// NewMockPricer creates a new mock instance.
+func NewMockPricer(ctrl *gomock.Controller) *MockPricer {
+	mock := &MockPricer{ctrl: ctrl}
+	mock.recorder = &MockPricerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPricer) EXPECT() *MockPricerMockRecorder {
+	return m.recorder
+}
+
+// GetPriceHistory mocks base method.
+func (m *MockPricer) GetPriceHistory(arg0 uuid.UUID, arg1 time.Time, arg2 int64, arg3 int) ([]domain.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceHistory indicates an expected call of GetPriceHistory.
+func (mr *MockPricerMockRecorder) GetPriceHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceHistory", reflect.TypeOf((*MockPricer)(nil).GetPriceHistory), arg0, arg1, arg2, arg3)
+}
+
+// MockReviewer is a mock of Reviewer interface.
+type MockReviewer struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewerMockRecorder
+}
+
+// MockReviewerMockRecorder is the mock recorder for MockReviewer.
+type MockReviewerMockRecorder struct {
+	mock *MockReviewer
+}
+
+// NewMockReviewer creates a new mock instance.
+func NewMockReviewer(ctrl *gomock.Controller) *MockReviewer {
+	mock := &MockReviewer{ctrl: ctrl}
+	mock.recorder = &MockReviewerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewer) EXPECT() *MockReviewerMockRecorder {
+	return m.recorder
+}
+
+// AddDeliveredOrder mocks base method.
+func (m *MockReviewer) AddDeliveredOrder(arg0 domain.DeliveredOrder, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeliveredOrder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDeliveredOrder indicates an expected call of AddDeliveredOrder.
+func (mr *MockReviewerMockRecorder) AddDeliveredOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeliveredOrder", reflect.TypeOf((*MockReviewer)(nil).AddDeliveredOrder), arg0, arg1)
+}
+
+// AddOrderLines mocks base method.
+func (m *MockReviewer) AddOrderLines(arg0 uuid.UUID, arg1 []domain.OrderLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrderLines", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOrderLines indicates an expected call of AddOrderLines.
+func (mr *MockReviewerMockRecorder) AddOrderLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrderLines", reflect.TypeOf((*MockReviewer)(nil).AddOrderLines), arg0, arg1)
+}
+
+// CreateReview mocks base method.
+func (m *MockReviewer) CreateReview(arg0 domain.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockReviewerMockRecorder) CreateReview(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockReviewer)(nil).CreateReview), arg0)
+}
+
+// GetReview mocks base method.
+func (m *MockReviewer) GetReview(arg0 uuid.UUID) (domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", arg0)
+	ret0, _ := ret[0].(domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockReviewerMockRecorder) GetReview(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockReviewer)(nil).GetReview), arg0)
+}
+
+// GetReviews mocks base method.
+func (m *MockReviewer) GetReviews(arg0 uuid.UUID, arg1 domain.ReviewStatus, arg2 int64, arg3 int) ([]domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviews", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviews indicates an expected call of GetReviews.
+func (mr *MockReviewerMockRecorder) GetReviews(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviews", reflect.TypeOf((*MockReviewer)(nil).GetReviews), arg0, arg1, arg2, arg3)
+}
+
+// IsProductDelivered mocks base method.
+func (m *MockReviewer) IsProductDelivered(arg0, arg1, arg2 uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsProductDelivered", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsProductDelivered indicates an expected call of IsProductDelivered.
+func (mr *MockReviewerMockRecorder) IsProductDelivered(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProductDelivered", reflect.TypeOf((*MockReviewer)(nil).IsProductDelivered), arg0, arg1, arg2)
+}
+
+// ModerateReview mocks base method.
+func (m *MockReviewer) ModerateReview(arg0 domain.Review) (domain.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateReview", arg0)
+	ret0, _ := ret[0].(domain.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateReview indicates an expected call of ModerateReview.
+func (mr *MockReviewerMockRecorder) ModerateReview(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateReview", reflect.TypeOf((*MockReviewer)(nil).ModerateReview), arg0)
+}
+
+// MockPrivacier is a mock of Privacier interface.
+type MockPrivacier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacierMockRecorder
+}
+
+// MockPrivacierMockRecorder is the mock recorder for MockPrivacier.
+type MockPrivacierMockRecorder struct {
+	mock *MockPrivacier
+}
+
+// NewMockPrivacier creates a new mock instance.
+func NewMockPrivacier(ctrl *gomock.Controller) *MockPrivacier {
+	mock := &MockPrivacier{ctrl: ctrl}
+	mock.recorder = &MockPrivacierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacier) EXPECT() *MockPrivacierMockRecorder {
+	return m.recorder
+}
+
+// EraseAccountData mocks base method.
+func (m *MockPrivacier) EraseAccountData(arg0 uuid.UUID, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseAccountData", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseAccountData indicates an expected call of EraseAccountData.
+func (mr *MockPrivacierMockRecorder) EraseAccountData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseAccountData", reflect.TypeOf((*MockPrivacier)(nil).EraseAccountData), arg0, arg1)
+}
+
+// ExportAccountData mocks base method.
+func (m *MockPrivacier) ExportAccountData(arg0 uuid.UUID) (domain.AccountData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccountData", arg0)
+	ret0, _ := ret[0].(domain.AccountData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccountData indicates an expected call of ExportAccountData.
+func (mr *MockPrivacierMockRecorder) ExportAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccountData", reflect.TypeOf((*MockPrivacier)(nil).ExportAccountData), arg0)
+}
+
+// MockAPIKeyer is a mock of APIKeyer interface.
+type MockAPIKeyer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyerMockRecorder
+}
+
+// MockAPIKeyerMockRecorder is the mock recorder for MockAPIKeyer.
+type MockAPIKeyerMockRecorder struct {
+	mock *MockAPIKeyer
+}
+
+// NewMockAPIKeyer creates a new mock instance.
+func NewMockAPIKeyer(ctrl *gomock.Controller) *MockAPIKeyer {
+	mock := &MockAPIKeyer{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyer) EXPECT() *MockAPIKeyerMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyer) CreateAPIKey(arg0 domain.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyerMockRecorder) CreateAPIKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyer)(nil).CreateAPIKey), arg0)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockAPIKeyer) GetAPIKey(arg0 string) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", arg0)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockAPIKeyerMockRecorder) GetAPIKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockAPIKeyer)(nil).GetAPIKey), arg0)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyer) RevokeAPIKey(arg0, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyerMockRecorder) RevokeAPIKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyer)(nil).RevokeAPIKey), arg0, arg1, arg2)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockAPIKeyer) TouchAPIKey(arg0 string, arg1, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockAPIKeyerMockRecorder) TouchAPIKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockAPIKeyer)(nil).TouchAPIKey), arg0, arg1, arg2)
+}
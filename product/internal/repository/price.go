@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+var _ Pricer = (*Price)(nil)
+
+// Pricer - repository interface
+type Pricer interface {
+	GetPriceHistory(productPublicId uuid.UUID, at time.Time, before int64, limit int) ([]domain.PriceChange, error)
+}
+
+// Price - product price history, the changes are recorded by the product repository
+type Price struct {
+	db *sqlx.DB
+}
+
+// NewPrice - constructor
+func NewPrice(db *sqlx.DB) *Price {
+	return &Price{db: db}
+}
+
+const priceChangeColumns = `product_public_id, price, discount, effective_price, actor_public_id, created_at`
+
+// GetPriceHistory - from the newest, made not later than at when it is set,
+// so the first one is the price of that time. Before is the id to start under, 0 is from the last one
+func (r *Price) GetPriceHistory(productPublicId uuid.UUID, at time.Time, before int64,
+	limit int) ([]domain.PriceChange, error) {
+	changes := []domain.PriceChange{}
+
+	query := fmt.Sprintf(`SELECT id, %s FROM %s WHERE product_public_id=$1 AND ($2 = 0 OR id < $2)
+		AND ($3 OR created_at <= $4) ORDER BY id DESC LIMIT $5`, priceChangeColumns, priceChangeTable)
+	if err := r.db.Select(&changes, query, productPublicId, before, at.IsZero(), at, limit); err != nil {
+		return nil, fmt.Errorf("get price history: %w", err)
+	}
+
+	return changes, nil
+}
+
+// insertPriceChange
+func insertPriceChange(tx *sqlx.Tx, change domain.PriceChange) error {
+	query := fmt.Sprintf(`INSERT INTO %s (%s) values ($1, $2, $3, $4, $5, $6)`, priceChangeTable, priceChangeColumns)
+	_, err := tx.Exec(query, change.ProductPublicId, change.Price, change.Discount, change.EffectivePrice,
+		change.ActorPublicId, change.CreatedAt)
+	return err
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrice(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	sofa := domain.Product{PublicId: uuid.New(), DealerPublicId: uuid.New(), Name: "Sofa", Price: 10000,
+		CreatedAt: now, UpdatedAt: now}
+	assert.NoError(t, repos.CreateProduct(sofa))
+
+	update := func(change func(product *domain.Product), at time.Time) {
+		change(&sofa)
+		sofa.UpdatedAt = at
+		assert.NoError(t, repos.UpdateProduct(sofa))
+	}
+	update(func(product *domain.Product) { product.Discount = 15 }, now.Add(time.Hour))
+	update(func(product *domain.Product) { product.Name = "Grey sofa" }, now.Add(2*time.Hour))
+	update(func(product *domain.Product) { product.Price = 12000 }, now.Add(3*time.Hour))
+
+	t.Run("Can record price and discount changes only", func(t *testing.T) {
+		changes, err := repos.GetPriceHistory(sofa.PublicId, time.Time{}, 0, 10)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 3)
+		assert.Equal(t, int64(12000), changes[0].Price)
+		assert.Equal(t, int64(10200), changes[0].EffectivePrice)
+		assert.Equal(t, int64(8500), changes[1].EffectivePrice)
+		assert.Equal(t, sofa.DealerPublicId, changes[2].ActorPublicId)
+		assert.Equal(t, now, changes[2].CreatedAt)
+	})
+
+	t.Run("Can get price effective at time", func(t *testing.T) {
+		changes, err := repos.GetPriceHistory(sofa.PublicId, now.Add(150*time.Minute), 0, 1)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, int64(10000), changes[0].Price)
+		assert.Equal(t, int64(15), changes[0].Discount)
+
+		changes, err = repos.GetPriceHistory(sofa.PublicId, now.Add(-time.Hour), 0, 1)
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("Can page price history", func(t *testing.T) {
+		changes, err := repos.GetPriceHistory(sofa.PublicId, time.Time{}, 0, 2)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 2)
+
+		changes, err = repos.GetPriceHistory(sofa.PublicId, time.Time{}, changes[1].Id, 2)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, int64(0), changes[0].Discount)
+	})
+}
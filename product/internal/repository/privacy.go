@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+var _ Privacier = (*Privacy)(nil)
+
+// Privacier - repository interface
+type Privacier interface {
+	ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error)
+	EraseAccountData(accountPublicId uuid.UUID, now time.Time) error
+}
+
+// Privacy - personal data of the account kept here
+type Privacy struct {
+	db *sqlx.DB
+}
+
+// NewPrivacy - constructor
+func NewPrivacy(db *sqlx.DB) *Privacy {
+	return &Privacy{db: db}
+}
+
+// ExportAccountData
+func (r *Privacy) ExportAccountData(accountPublicId uuid.UUID) (domain.AccountData, error) {
+	data := domain.AccountData{
+		Reviews:         make([]domain.Review, 0),
+		DeliveredOrders: make([]domain.DeliveredOrder, 0),
+	}
+
+	var accounts []domain.Account
+	query := fmt.Sprintf(`SELECT public_id, COALESCE(name, '') AS name, COALESCE(username, '') AS username,
+		COALESCE(email, '') AS email, COALESCE(address, '') AS address, role, created_at
+		FROM %s WHERE public_id=$1`, accountTable)
+	if err := r.db.Select(&accounts, query, accountPublicId); err != nil {
+		return data, fmt.Errorf("export account: %w", err)
+	}
+	if len(accounts) > 0 {
+		data.Account = &accounts[0]
+	}
+
+	query = fmt.Sprintf(`SELECT id, %s FROM %s WHERE account_public_id=$1 ORDER BY id`, reviewColumns, reviewTable)
+	if err := r.db.Select(&data.Reviews, query, accountPublicId); err != nil {
+		return data, fmt.Errorf("export reviews: %w", err)
+	}
+	query = fmt.Sprintf(`SELECT order_public_id, customer_public_id FROM %s WHERE customer_public_id=$1 ORDER BY id`,
+		deliveredOrderTable)
+	if err := r.db.Select(&data.DeliveredOrders, query, accountPublicId); err != nil {
+		return data, fmt.Errorf("export delivered orders: %w", err)
+	}
+
+	return data, nil
+}
+
+// EraseAccountData - the review texts are removed, the ratings are kept so the product ratings don't change.
+// The delivered orders only allow the reviews, so they are removed with the account copy and the api keys
+func (r *Privacy) EraseAccountData(accountPublicId uuid.UUID, now time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`UPDATE %s SET text='', moderation_comment='', updated_at=$1 WHERE account_public_id=$2`,
+		reviewTable)
+	if _, err := tx.Exec(query, now, accountPublicId); err != nil {
+		return fmt.Errorf("erase reviews: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE order_public_id IN (
+		SELECT order_public_id FROM %s WHERE customer_public_id=$1)`, orderLineTable, deliveredOrderTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase order lines: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE customer_public_id=$1`, deliveredOrderTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase delivered orders: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE account_public_id=$1`, apiKeyTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase api keys: %w", err)
+	}
+	query = fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, accountTable)
+	if _, err := tx.Exec(query, accountPublicId); err != nil {
+		return fmt.Errorf("erase account: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivacy(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	sofa := domain.Product{PublicId: uuid.New(), DealerPublicId: uuid.New(), Name: "Sofa", CreatedAt: now, UpdatedAt: now}
+	assert.NoError(t, repos.CreateProduct(sofa))
+
+	customer, other := uuid.New(), uuid.New()
+	assert.NoError(t, repos.CreateAccount(domain.Account{PublicId: customer}))
+	order := domain.DeliveredOrder{OrderPublicId: uuid.New(), CustomerPublicId: customer}
+	assert.NoError(t, repos.AddOrderLines(order.OrderPublicId, []domain.OrderLine{{ProductPublicId: sofa.PublicId}}))
+	assert.NoError(t, repos.AddDeliveredOrder(order, now))
+	review := func(account uuid.UUID) domain.Review {
+		review := domain.Review{PublicId: uuid.New(), ProductPublicId: sofa.PublicId, AccountPublicId: account,
+			OrderPublicId: order.OrderPublicId, Rating: 4, Text: "Soft and comfy", Status: domain.REVIEW_PENDING,
+			CreatedAt: now, UpdatedAt: now}
+		assert.NoError(t, repos.CreateReview(review))
+		return review
+	}
+	own, foreign := review(customer), review(other)
+
+	t.Run("Can export own reviews and delivered orders only", func(t *testing.T) {
+		data, err := repos.ExportAccountData(customer)
+		assert.NoError(t, err)
+		assert.Len(t, data.Reviews, 1)
+		assert.Equal(t, own.PublicId, data.Reviews[0].PublicId)
+		assert.Equal(t, []domain.DeliveredOrder{order}, data.DeliveredOrders)
+	})
+
+	t.Run("Can erase the review texts keeping the ratings", func(t *testing.T) {
+		assert.NoError(t, repos.EraseAccountData(customer, now.Add(time.Hour)))
+
+		erased, err := repos.GetReview(own.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, "", erased.Text)
+		assert.Equal(t, 4, erased.Rating)
+		kept, err := repos.GetReview(foreign.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, "Soft and comfy", kept.Text)
+
+		delivered, err := repos.IsProductDelivered(order.OrderPublicId, customer, sofa.PublicId)
+		assert.NoError(t, err)
+		assert.False(t, delivered)
+		_, err = repos.GetAccount(customer.String())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+var _ Producter = (*Product)(nil)
+
+// Producter - repository interface
+type Producter interface {
+	CreateProduct(product domain.Product) error
+	GetProduct(publicId uuid.UUID) (domain.Product, error)
+	UpdateProduct(product domain.Product) error
+	DeleteProduct(publicId uuid.UUID) error
+	GetProductBySku(dealerPublicId uuid.UUID, sku string) (domain.Product, error)
+	GetDealerProducts(dealerPublicId uuid.UUID) ([]domain.Product, error)
+}
+
+// Product
+type Product struct {
+	db *sqlx.DB
+}
+
+// NewProduct - constructor
+func NewProduct(db *sqlx.DB) *Product {
+	return &Product{db: db}
+}
+
+const productColumns = `public_id, dealer_public_id, sku, name, description, category,
+	price, discount, quantity, reserved, low_stock_threshold, attributes, created_at, updated_at,
+	parent_public_id, price_override, rating, rating_count`
+
+// CreateProduct - the initial quantity is recorded as the received stock, the price as the first price change
+func (r *Product) CreateProduct(product domain.Product) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`INSERT INTO %s (%s) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`,
+		productTable, productColumns)
+	_, err = tx.Exec(query, product.PublicId, product.DealerPublicId, product.Sku, product.Name, product.Description,
+		product.Category, product.Price, product.Discount, product.Quantity, product.Reserved,
+		product.LowStockThreshold, product.Attributes, product.CreatedAt, product.UpdatedAt,
+		product.ParentPublicId, product.PriceOverride, product.Rating, product.RatingCount)
+	if err != nil {
+		return err
+	}
+
+	if err = insertPriceChange(tx, product.PriceChange(product.DealerPublicId)); err != nil {
+		return err
+	}
+	if product.Quantity > 0 {
+		err = insertMovement(tx, domain.StockMovement{
+			ProductPublicId: product.PublicId,
+			Type:            domain.MOVEMENT_RECEIVED,
+			Quantity:        product.Quantity,
+			OnHand:          product.Quantity,
+			Reserved:        product.Reserved,
+			ActorPublicId:   product.DealerPublicId,
+			Comment:         "initial stock",
+			CreatedAt:       product.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetProduct - with images and variants
+func (r *Product) GetProduct(publicId uuid.UUID) (domain.Product, error) {
+	var product domain.Product
+
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE public_id=$1`, productColumns, productTable)
+	err := r.db.Get(&product, query, publicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return product, domain.ErrProductNotFound
+	}
+	if err != nil {
+		return product, fmt.Errorf("get product: %w", err)
+	}
+
+	products := []domain.Product{product}
+	if err = selectImages(r.db, products); err != nil {
+		return product, err
+	}
+	if err = selectVariants(r.db, products); err != nil {
+		return product, err
+	}
+	return products[0], nil
+}
+
+// UpdateProduct - dealer, stock and creation time are not changed, the parent changes go to its variants.
+// A price or discount change is recorded in the price history with the dealer as the actor
+func (r *Product) UpdateProduct(product domain.Product) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	var stored domain.Product
+	query := fmt.Sprintf(`SELECT price, discount FROM %s WHERE public_id=$1`, productTable)
+	err = tx.Get(&stored, query, product.PublicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrProductNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get product price: %w", err)
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET sku=$1, name=$2, description=$3, category=$4, price=$5, discount=$6,
+		price_override=$7, attributes=$8, updated_at=$9 WHERE public_id=$10`, productTable)
+	_, err = tx.Exec(query, product.Sku, product.Name, product.Description, product.Category, product.Price,
+		product.Discount, product.PriceOverride, product.Attributes, product.UpdatedAt, product.PublicId)
+	if err != nil {
+		return err
+	}
+
+	if stored.Price != product.Price || stored.Discount != product.Discount {
+		if err = insertPriceChange(tx, product.PriceChange(product.DealerPublicId)); err != nil {
+			return err
+		}
+	}
+	if !product.IsVariant() {
+		if err = syncVariants(tx, product); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteProduct - with variants, images, stock and price history, reviews, the image blobs are removed by the service
+func (r *Product) DeleteProduct(publicId uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	var variants []uuid.UUID
+	query := fmt.Sprintf(`SELECT public_id FROM %s WHERE parent_public_id=$1`, productTable)
+	if err = tx.Select(&variants, query, publicId); err != nil {
+		return fmt.Errorf("get product variants: %w", err)
+	}
+
+	result, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE public_id=$1`, productTable), publicId)
+	if err != nil {
+		return err
+	}
+	if err = checkAffected(result); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE parent_public_id=$1`, productTable), publicId); err != nil {
+		return err
+	}
+
+	for _, id := range append(variants, publicId) {
+		for _, table := range []string{productImageTable, stockMovementTable, priceChangeTable, reviewTable} {
+			query := fmt.Sprintf(`DELETE FROM %s WHERE product_public_id=$1`, table)
+			if _, err = tx.Exec(query, id); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// GetProductBySku - with images, a variant too
+func (r *Product) GetProductBySku(dealerPublicId uuid.UUID, sku string) (domain.Product, error) {
+	var publicId uuid.UUID
+
+	query := fmt.Sprintf(`SELECT public_id FROM %s WHERE dealer_public_id=$1 AND sku=$2`, productTable)
+	err := r.db.Get(&publicId, query, dealerPublicId, sku)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Product{}, domain.ErrProductNotFound
+	}
+	if err != nil {
+		return domain.Product{}, fmt.Errorf("get product by sku: %w", err)
+	}
+
+	return r.GetProduct(publicId)
+}
+
+// GetDealerProducts - full dealer catalog in creation order, without images and variants
+func (r *Product) GetDealerProducts(dealerPublicId uuid.UUID) ([]domain.Product, error) {
+	products := []domain.Product{}
+
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE dealer_public_id=$1 AND parent_public_id IS NULL ORDER BY id`,
+		productColumns, productTable)
+	if err := r.db.Select(&products, query, dealerPublicId); err != nil {
+		return nil, fmt.Errorf("get dealer products: %w", err)
+	}
+
+	return products, nil
+}
+
+// checkAffected - product not found when nothing is changed
+func checkAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrProductNotFound
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProduct_Sku(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	dealer, other := uuid.New(), uuid.New()
+	sofa := domain.Product{PublicId: uuid.New(), DealerPublicId: dealer, Sku: "SF-1", Name: "Sofa", CreatedAt: now, UpdatedAt: now}
+	chair := domain.Product{PublicId: uuid.New(), DealerPublicId: dealer, Name: "Chair", CreatedAt: now, UpdatedAt: now}
+	for _, product := range []domain.Product{sofa, chair} {
+		assert.NoError(t, repos.CreateProduct(product))
+	}
+
+	t.Run("Can get product by dealer sku", func(t *testing.T) {
+		stored, err := repos.GetProductBySku(dealer, "SF-1")
+		assert.NoError(t, err)
+		assert.Equal(t, sofa.PublicId, stored.PublicId)
+
+		_, err = repos.GetProductBySku(other, "SF-1")
+		assert.ErrorIs(t, err, domain.ErrProductNotFound)
+	})
+
+	t.Run("Can use the same sku by different dealers only", func(t *testing.T) {
+		assert.NoError(t, repos.CreateProduct(domain.Product{PublicId: uuid.New(), DealerPublicId: other,
+			Sku: "SF-1", Name: "Other sofa", CreatedAt: now, UpdatedAt: now}))
+		assert.Error(t, repos.CreateProduct(domain.Product{PublicId: uuid.New(), DealerPublicId: dealer,
+			Sku: "SF-1", Name: "Same sofa", CreatedAt: now, UpdatedAt: now}))
+	})
+
+	t.Run("Can get dealer catalog", func(t *testing.T) {
+		products, err := repos.GetDealerProducts(dealer)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Sofa", "Chair"}, []string{products[0].Name, products[1].Name})
+	})
+}
@@ -3,51 +3,256 @@ package repository
 import (
 	"fmt"
 
+	_ "github.com/golang/mock/mockgen/model"
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
+//go:generate mockgen -destination mocks/mock.go -package repository github.com/p12s/furniture-store/product/internal/repository Accounter,Producter,Searcher,Categorizer,Imager,Importer,Stocker,Pricer,Reviewer,Privacier,APIKeyer
+
 // Repository - repo
 type Repository struct {
 	Accounter
+	Producter
+	Searcher
+	Categorizer
+	Imager
+	Importer
+	Stocker
+	Pricer
+	Reviewer
+	Privacier
+	APIKeyer
 }
 
 // NewRepository - constructor
 func NewRepository(db *sqlx.DB) *Repository {
-	createAccountTable(db)
+	// the account data is not required, a role event can come before the created-event
+	createSchema(db, accountTable, `CREATE TABLE IF NOT EXISTS account (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"name" TEXT,
+		"username" TEXT,
+		"email" TEXT,
+		"address" TEXT,
+		"role" INTEGER DEFAULT 0,
+		"created_at" DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL
+	  );`)
+	// the accounts signed in here before, their password hashes are not kept
+	if columnExists(db, accountTable, "password_hash") {
+		createSchema(db, "account password_hash drop", `ALTER TABLE account DROP COLUMN password_hash;`)
+	}
+	createSchema(db, revokedSessionTable, `CREATE TABLE IF NOT EXISTS revoked_session (
+		"session_public_id" TEXT NOT NULL PRIMARY KEY,
+		"account_public_id" TEXT NOT NULL,
+		"expires_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, apiKeyTable, `CREATE TABLE IF NOT EXISTS api_key (
+		"prefix" TEXT NOT NULL PRIMARY KEY,
+		"key_hash" TEXT DEFAULT '' NOT NULL,
+		"account_public_id" TEXT NOT NULL,
+		"scopes" TEXT DEFAULT '' NOT NULL,
+		"expires_at" DATETIME NOT NULL,
+		"last_used_at" DATETIME,
+		"revoked_at" DATETIME
+	  );`)
+	createSchema(db, productTable, `CREATE TABLE IF NOT EXISTS product (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"dealer_public_id" TEXT NOT NULL,
+		"sku" TEXT DEFAULT '' NOT NULL,
+		"name" TEXT NOT NULL,
+		"description" TEXT DEFAULT '' NOT NULL,
+		"category" TEXT DEFAULT '' NOT NULL,
+		"price" INTEGER DEFAULT 0 NOT NULL,
+		"discount" INTEGER DEFAULT 0 NOT NULL,
+		"quantity" INTEGER DEFAULT 0 NOT NULL,
+		"reserved" INTEGER DEFAULT 0 NOT NULL,
+		"low_stock_threshold" INTEGER DEFAULT 0 NOT NULL,
+		"attributes" TEXT DEFAULT '{}' NOT NULL,
+		"created_at" DATETIME NOT NULL,
+		"updated_at" DATETIME NOT NULL,
+		"parent_public_id" TEXT,
+		"price_override" INTEGER DEFAULT 0 NOT NULL,
+		"rating" REAL DEFAULT 0 NOT NULL,
+		"rating_count" INTEGER DEFAULT 0 NOT NULL
+	  );`)
+	createSchema(db, categoryTable, `CREATE TABLE IF NOT EXISTS category (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"slug" TEXT NOT NULL UNIQUE,
+		"parent_slug" TEXT DEFAULT '' NOT NULL,
+		"name" TEXT NOT NULL,
+		"attributes" TEXT DEFAULT '[]' NOT NULL
+	  );`)
+	createSchema(db, productImageTable, `CREATE TABLE IF NOT EXISTS product_image (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"product_public_id" TEXT NOT NULL,
+		"position" INTEGER DEFAULT 0 NOT NULL,
+		"content_type" TEXT NOT NULL,
+		"width" INTEGER DEFAULT 0 NOT NULL,
+		"height" INTEGER DEFAULT 0 NOT NULL,
+		"urls" TEXT DEFAULT '{}' NOT NULL,
+		"blob_keys" TEXT DEFAULT '{}' NOT NULL,
+		"created_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, stockMovementTable, `CREATE TABLE IF NOT EXISTS stock_movement (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"product_public_id" TEXT NOT NULL,
+		"type" TEXT NOT NULL,
+		"quantity" INTEGER NOT NULL,
+		"on_hand" INTEGER NOT NULL,
+		"reserved" INTEGER NOT NULL,
+		"actor_public_id" TEXT NOT NULL,
+		"comment" TEXT DEFAULT '' NOT NULL,
+		"created_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, priceChangeTable, `CREATE TABLE IF NOT EXISTS price_change (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"product_public_id" TEXT NOT NULL,
+		"price" INTEGER NOT NULL,
+		"discount" INTEGER NOT NULL,
+		"effective_price" INTEGER NOT NULL,
+		"actor_public_id" TEXT NOT NULL,
+		"created_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, reviewTable, `CREATE TABLE IF NOT EXISTS review (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"product_public_id" TEXT NOT NULL,
+		"account_public_id" TEXT NOT NULL,
+		"order_public_id" TEXT NOT NULL,
+		"rating" INTEGER NOT NULL,
+		"text" TEXT DEFAULT '' NOT NULL,
+		"status" TEXT NOT NULL,
+		"moderator_public_id" TEXT,
+		"moderation_comment" TEXT DEFAULT '' NOT NULL,
+		"created_at" DATETIME NOT NULL,
+		"updated_at" DATETIME NOT NULL,
+		UNIQUE (product_public_id, account_public_id)
+	  );`)
+	createSchema(db, deliveredOrderTable, `CREATE TABLE IF NOT EXISTS delivered_order (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"order_public_id" TEXT NOT NULL UNIQUE,
+		"customer_public_id" TEXT NOT NULL,
+		"created_at" DATETIME NOT NULL
+	  );`)
+	// the lines of the orders, a delivered order allows the reviews of its products only
+	createSchema(db, orderLineTable, `CREATE TABLE IF NOT EXISTS order_line (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"order_public_id" TEXT NOT NULL,
+		"product_public_id" TEXT NOT NULL,
+		"variant_public_id" TEXT NOT NULL,
+		UNIQUE (order_public_id, product_public_id, variant_public_id)
+	  );`)
+	createSchema(db, importJobTable, `CREATE TABLE IF NOT EXISTS import_job (
+		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+		"public_id" TEXT NOT NULL UNIQUE,
+		"dealer_public_id" TEXT NOT NULL,
+		"status" TEXT NOT NULL,
+		"total" INTEGER DEFAULT 0 NOT NULL,
+		"processed" INTEGER DEFAULT 0 NOT NULL,
+		"created" INTEGER DEFAULT 0 NOT NULL,
+		"updated" INTEGER DEFAULT 0 NOT NULL,
+		"failed" INTEGER DEFAULT 0 NOT NULL,
+		"errors" TEXT DEFAULT '[]' NOT NULL,
+		"created_at" DATETIME NOT NULL,
+		"updated_at" DATETIME NOT NULL
+	  );`)
+	createSchema(db, "category_parent index", `CREATE INDEX IF NOT EXISTS category_parent ON category (parent_slug);`)
+	createSchema(db, "product_category index", `CREATE INDEX IF NOT EXISTS product_category ON product (category);`)
+	createSchema(db, "product_dealer index", `CREATE INDEX IF NOT EXISTS product_dealer ON product (dealer_public_id);`)
+	createSchema(db, "product_parent index", `CREATE INDEX IF NOT EXISTS product_parent ON product (parent_public_id);`)
+	createSchema(db, "product_dealer_sku index", `CREATE UNIQUE INDEX IF NOT EXISTS product_dealer_sku
+		ON product (dealer_public_id, sku) WHERE sku != '';`)
+	createSchema(db, "product_price index", `CREATE INDEX IF NOT EXISTS product_price ON product (price, id);`)
+	createSchema(db, "stock_movement_product index",
+		`CREATE INDEX IF NOT EXISTS stock_movement_product ON stock_movement (product_public_id, id);`)
+	createSchema(db, "price_change_product index",
+		`CREATE INDEX IF NOT EXISTS price_change_product ON price_change (product_public_id, id);`)
+	createSchema(db, "review_status index", `CREATE INDEX IF NOT EXISTS review_status ON review (status, id);`)
+	createSchema(db, "product_image_product index",
+		`CREATE INDEX IF NOT EXISTS product_image_product ON product_image (product_public_id, position);`)
+
+	// full-text index over the product table, docid is the product id.
+	// FTS4 is built into the sqlite driver by default, FTS5 would need a build tag
+	indexed := tableExists(db, productIndexTable)
+	createSchema(db, productIndexTable, `CREATE VIRTUAL TABLE IF NOT EXISTS product_fts USING fts4(
+		content="product", name, description, category, tokenize=unicode61
+	  );`)
+	// the index is kept in sync by the triggers, so no product write can miss it
+	createSchema(db, "product_fts_insert trigger", `CREATE TRIGGER IF NOT EXISTS product_fts_insert
+		AFTER INSERT ON product BEGIN
+			INSERT INTO product_fts (docid, name, description, category)
+			VALUES (new.id, new.name, new.description, new.category);
+		END;`)
+	createSchema(db, "product_fts_before_update trigger", `CREATE TRIGGER IF NOT EXISTS product_fts_before_update
+		BEFORE UPDATE ON product BEGIN
+			DELETE FROM product_fts WHERE docid=old.id;
+		END;`)
+	createSchema(db, "product_fts_after_update trigger", `CREATE TRIGGER IF NOT EXISTS product_fts_after_update
+		AFTER UPDATE ON product BEGIN
+			INSERT INTO product_fts (docid, name, description, category)
+			VALUES (new.id, new.name, new.description, new.category);
+		END;`)
+	createSchema(db, "product_fts_delete trigger", `CREATE TRIGGER IF NOT EXISTS product_fts_delete
+		BEFORE DELETE ON product BEGIN
+			DELETE FROM product_fts WHERE docid=old.id;
+		END;`)
+	// the products written before the index was created are indexed once, on the upgraded database
+	if !indexed {
+		createSchema(db, "product_fts rebuild", `INSERT INTO product_fts (product_fts) VALUES ('rebuild');`)
+	}
 
 	return &Repository{
-		Accounter: NewAccount(db),
+		Accounter:   NewAccount(db),
+		Producter:   NewProduct(db),
+		Searcher:    NewSearch(db),
+		Categorizer: NewCategory(db),
+		Imager:      NewImage(db),
+		Importer:    NewImport(db),
+		Stocker:     NewStock(db),
+		Pricer:      NewPrice(db),
+		Reviewer:    NewReview(db),
+		Privacier:   NewPrivacy(db),
+		APIKeyer:    NewAPIKey(db),
 	}
 }
 
-// Deliberately removed the obligation of important fields (name, username, password_hash, ...),
-// because the architecture is asynchronous, a Business-event with only role (role)
+// Deliberately removed the obligation of important fields,
+// because the architecture is asynchronous, the business-event with only role (role)
 // can come before a CUD-event with all other data.
 
-// createAccountTable
-func createAccountTable(db *sqlx.DB) {
-	query := `CREATE TABLE IF NOT EXISTS account (
-		"id" integer NOT NULL PRIMARY KEY AUTOINCREMENT,		
-		"public_id" TEXT,
-		"name" TEXT,
-		"username" TEXT,		
-		"password_hash" TEXT,
-		"email" TEXT,
-		"address" TEXT,
-		"role" INTEGER DEFAULT 0,
-		"created_at" DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL
-	  );`
+// tableExists - the table, index or trigger is already created
+func tableExists(db *sqlx.DB, name string) bool {
+	var count int
+	if err := db.Get(&count, `SELECT COUNT(*) FROM sqlite_master WHERE name=$1`, name); err != nil {
+		logrus.Fatalf("check product.%s fail: %s", name, err.Error())
+	}
+	return count > 0
+}
+
+// columnExists - the column is created by the previous schema
+func columnExists(db *sqlx.DB, table, column string) bool {
+	var count int
+	if err := db.Get(&count, `SELECT COUNT(*) FROM pragma_table_info($1) WHERE name=$2`, table, column); err != nil {
+		logrus.Fatalf("check product.%s.%s fail: %s", table, column, err.Error())
+	}
+	return count > 0
+}
+
+// createSchema - table, trigger, index
+func createSchema(db *sqlx.DB, name, query string) {
 	statement, err := db.Prepare(query)
-	defer statement.Close() // nolint
 	if err != nil {
-		statement.Close()
-		logrus.Fatal("create account.account table fail: ", err.Error())
+		logrus.Fatalf("create product.%s fail: %s", name, err.Error())
 	}
+	defer statement.Close() // nolint
+
 	_, err = statement.Exec()
 	if err != nil {
-		logrus.Fatal("exec creating account.account table fail: ", err.Error())
+		logrus.Fatalf("exec creating product.%s fail: %s", name, err.Error())
 	}
 
-	fmt.Println("account.account table created 🗂")
+	fmt.Printf("product.%s created 🗂\n", name)
 }
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+var _ Reviewer = (*Review)(nil)
+
+// Reviewer - repository interface
+type Reviewer interface {
+	AddOrderLines(orderPublicId uuid.UUID, lines []domain.OrderLine) error
+	AddDeliveredOrder(order domain.DeliveredOrder, now time.Time) error
+	IsProductDelivered(orderPublicId, customerPublicId, productPublicId uuid.UUID) (bool, error)
+	CreateReview(review domain.Review) error
+	GetReview(publicId uuid.UUID) (domain.Review, error)
+	GetReviews(productPublicId uuid.UUID, status domain.ReviewStatus, before int64, limit int) ([]domain.Review, error)
+	ModerateReview(review domain.Review) (domain.Rating, error)
+}
+
+// Review - customer reviews and the delivered orders that allow them
+type Review struct {
+	db *sqlx.DB
+}
+
+// NewReview - constructor
+func NewReview(db *sqlx.DB) *Review {
+	return &Review{db: db}
+}
+
+const reviewColumns = `public_id, product_public_id, account_public_id, order_public_id, rating, text,
+	status, moderator_public_id, moderation_comment, created_at, updated_at`
+
+// AddOrderLines - the lines don't change after the checkout, repeated ones are skipped
+func (r *Review) AddOrderLines(orderPublicId uuid.UUID, lines []domain.OrderLine) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	if err := addOrderLines(tx, orderPublicId, lines); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AddDeliveredOrder - a repeated event is skipped, the lines of the event are added
+func (r *Review) AddDeliveredOrder(order domain.DeliveredOrder, now time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`INSERT INTO %s (order_public_id, customer_public_id, created_at) values ($1, $2, $3)
+		ON CONFLICT(order_public_id) DO NOTHING`, deliveredOrderTable)
+	if _, err := tx.Exec(query, order.OrderPublicId, order.CustomerPublicId, now); err != nil {
+		return err
+	}
+	if err := addOrderLines(tx, order.OrderPublicId, order.Items); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func addOrderLines(tx *sqlx.Tx, orderPublicId uuid.UUID, lines []domain.OrderLine) error {
+	query := fmt.Sprintf(`INSERT INTO %s (order_public_id, product_public_id, variant_public_id) values ($1, $2, $3)
+		ON CONFLICT(order_public_id, product_public_id, variant_public_id) DO NOTHING`, orderLineTable)
+	for _, line := range lines {
+		if _, err := tx.Exec(query, orderPublicId, line.ProductPublicId, line.VariantPublicId); err != nil {
+			return fmt.Errorf("add order line: %w", err)
+		}
+	}
+	return nil
+}
+
+// IsProductDelivered - to the customer, the order has a line of the product or of one of its variants
+func (r *Review) IsProductDelivered(orderPublicId, customerPublicId, productPublicId uuid.UUID) (bool, error) {
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %[1]s d JOIN %[2]s l ON l.order_public_id=d.order_public_id
+		WHERE d.order_public_id=$1 AND d.customer_public_id=$2 AND (l.product_public_id=$3 OR l.variant_public_id=$3
+			OR EXISTS (SELECT 1 FROM %[3]s p WHERE p.parent_public_id=$3
+				AND p.public_id IN (l.product_public_id, l.variant_public_id)))`,
+		deliveredOrderTable, orderLineTable, productTable)
+	if err := r.db.Get(&count, query, orderPublicId, customerPublicId, productPublicId); err != nil {
+		return false, fmt.Errorf("get delivered order product: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CreateReview - one review of the product by the account
+func (r *Review) CreateReview(review domain.Review) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE product_public_id=$1 AND account_public_id=$2`, reviewTable)
+	if err = tx.Get(&count, query, review.ProductPublicId, review.AccountPublicId); err != nil {
+		return fmt.Errorf("count account reviews: %w", err)
+	}
+	if count > 0 {
+		return domain.ErrReviewExists
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (%s) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		reviewTable, reviewColumns)
+	_, err = tx.Exec(query, review.PublicId, review.ProductPublicId, review.AccountPublicId, review.OrderPublicId,
+		review.Rating, review.Text, review.Status, review.ModeratorPublicId, review.ModerationComment,
+		review.CreatedAt, review.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetReview
+func (r *Review) GetReview(publicId uuid.UUID) (domain.Review, error) {
+	var review domain.Review
+
+	query := fmt.Sprintf(`SELECT id, %s FROM %s WHERE public_id=$1`, reviewColumns, reviewTable)
+	err := r.db.Get(&review, query, publicId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return review, domain.ErrReviewNotFound
+	}
+	if err != nil {
+		return review, fmt.Errorf("get review: %w", err)
+	}
+
+	return review, nil
+}
+
+// GetReviews - from the newest, of all products when the product is nil.
+// Before is the id to start under, 0 is from the last one
+func (r *Review) GetReviews(productPublicId uuid.UUID, status domain.ReviewStatus, before int64,
+	limit int) ([]domain.Review, error) {
+	reviews := []domain.Review{}
+
+	product := uuid.NullUUID{UUID: productPublicId, Valid: productPublicId != uuid.Nil}
+	query := fmt.Sprintf(`SELECT id, %s FROM %s WHERE ($1 IS NULL OR product_public_id=$1) AND status=$2
+		AND ($3 = 0 OR id < $3) ORDER BY id DESC LIMIT $4`, reviewColumns, reviewTable)
+	err := r.db.Select(&reviews, query, product, status, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// ModerateReview - sets the review status, the product rating is counted again over the approved reviews
+func (r *Review) ModerateReview(review domain.Review) (domain.Rating, error) {
+	var rating domain.Rating
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return rating, err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, moderator_public_id=$2, moderation_comment=$3, updated_at=$4
+		WHERE public_id=$5`, reviewTable)
+	result, err := tx.Exec(query, review.Status, review.ModeratorPublicId, review.ModerationComment,
+		review.UpdatedAt, review.PublicId)
+	if err != nil {
+		return rating, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return rating, err
+	}
+	if affected == 0 {
+		return rating, domain.ErrReviewNotFound
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET
+		rating=(SELECT coalesce(avg(rating), 0) FROM %[2]s WHERE product_public_id=$1 AND status=$2),
+		rating_count=(SELECT count(*) FROM %[2]s WHERE product_public_id=$1 AND status=$2)
+		WHERE public_id=$1`, productTable, reviewTable)
+	if _, err = tx.Exec(query, review.ProductPublicId, domain.REVIEW_APPROVED); err != nil {
+		return rating, err
+	}
+	query = fmt.Sprintf(`SELECT rating, rating_count FROM %s WHERE public_id=$1`, productTable)
+	if err = tx.Get(&rating, query, review.ProductPublicId); err != nil {
+		return rating, fmt.Errorf("get product rating: %w", err)
+	}
+
+	return rating, tx.Commit()
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReview(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	sofa := domain.Product{PublicId: uuid.New(), DealerPublicId: uuid.New(), Name: "Sofa", CreatedAt: now, UpdatedAt: now}
+	assert.NoError(t, repos.CreateProduct(sofa))
+
+	order := domain.DeliveredOrder{OrderPublicId: uuid.New(), CustomerPublicId: uuid.New()}
+	review := func(rating int) domain.Review {
+		review := domain.Review{PublicId: uuid.New(), ProductPublicId: sofa.PublicId, AccountPublicId: uuid.New(),
+			OrderPublicId: order.OrderPublicId, Rating: rating, Status: domain.REVIEW_PENDING, CreatedAt: now, UpdatedAt: now}
+		assert.NoError(t, repos.CreateReview(review))
+		return review
+	}
+	good, bad := review(5), review(2)
+
+	t.Run("Can check the product of the delivered order", func(t *testing.T) {
+		velvet := sofa.NewVariant(uuid.New())
+		velvet.CreatedAt, velvet.UpdatedAt = now, now
+		assert.NoError(t, repos.CreateProduct(velvet))
+		chair := domain.Product{PublicId: uuid.New(), DealerPublicId: uuid.New(), Name: "Chair", CreatedAt: now, UpdatedAt: now}
+		assert.NoError(t, repos.CreateProduct(chair))
+
+		isDelivered := func(order domain.DeliveredOrder, customer, product uuid.UUID) bool {
+			delivered, err := repos.IsProductDelivered(order.OrderPublicId, customer, product)
+			assert.NoError(t, err)
+			return delivered
+		}
+
+		assert.NoError(t, repos.AddOrderLines(order.OrderPublicId, []domain.OrderLine{{ProductPublicId: sofa.PublicId,
+			VariantPublicId: velvet.PublicId}}))
+		assert.False(t, isDelivered(order, order.CustomerPublicId, sofa.PublicId), "not delivered yet")
+		assert.NoError(t, repos.AddDeliveredOrder(order, now))
+		assert.NoError(t, repos.AddDeliveredOrder(order, now), "repeated event")
+
+		assert.True(t, isDelivered(order, order.CustomerPublicId, sofa.PublicId))
+		assert.False(t, isDelivered(order, uuid.New(), sofa.PublicId), "another customer")
+		assert.False(t, isDelivered(order, order.CustomerPublicId, chair.PublicId), "not in the order")
+
+		// the lines of the delivery event, the variant as the line product
+		withLines := domain.DeliveredOrder{OrderPublicId: uuid.New(), CustomerPublicId: order.CustomerPublicId,
+			Items: []domain.OrderLine{{ProductPublicId: velvet.PublicId}}}
+		assert.NoError(t, repos.AddDeliveredOrder(withLines, now))
+		assert.True(t, isDelivered(withLines, order.CustomerPublicId, sofa.PublicId))
+		assert.False(t, isDelivered(withLines, order.CustomerPublicId, chair.PublicId))
+	})
+
+	t.Run("Can't review product twice", func(t *testing.T) {
+		again := good
+		again.PublicId = uuid.New()
+		assert.ErrorIs(t, repos.CreateReview(again), domain.ErrReviewExists)
+	})
+
+	t.Run("Can rate product by approved reviews", func(t *testing.T) {
+		moderate := func(review domain.Review, status domain.ReviewStatus) domain.Rating {
+			review.Status = status
+			review.ModeratorPublicId = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+			rating, err := repos.ModerateReview(review)
+			assert.NoError(t, err)
+			return rating
+		}
+		assert.Equal(t, domain.Rating{Average: 5, Count: 1}, moderate(good, domain.REVIEW_APPROVED))
+		assert.Equal(t, domain.Rating{Average: 3.5, Count: 2}, moderate(bad, domain.REVIEW_APPROVED))
+		assert.Equal(t, domain.Rating{Average: 5, Count: 1}, moderate(bad, domain.REVIEW_REJECTED))
+
+		stored, err := repos.GetProduct(sofa.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(5), stored.Rating)
+		assert.Equal(t, int64(1), stored.RatingCount)
+	})
+
+	t.Run("Can get reviews by status", func(t *testing.T) {
+		reviews, err := repos.GetReviews(sofa.PublicId, domain.REVIEW_APPROVED, 0, 10)
+		assert.NoError(t, err)
+		assert.Len(t, reviews, 1)
+		assert.Equal(t, good.PublicId, reviews[0].PublicId)
+		assert.True(t, reviews[0].ModeratorPublicId.Valid)
+
+		reviews, err = repos.GetReviews(uuid.Nil, domain.REVIEW_REJECTED, 0, 10)
+		assert.NoError(t, err)
+		assert.Len(t, reviews, 1)
+		assert.Equal(t, bad.PublicId, reviews[0].PublicId)
+	})
+}
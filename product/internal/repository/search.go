@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+var _ Searcher = (*Search)(nil)
+
+// Searcher - repository interface
+type Searcher interface {
+	SearchProducts(query domain.SearchQuery) ([]domain.Product, *domain.SearchCursor, error)
+	GetSearchFacets(query domain.SearchQuery) (domain.SearchFacets, error)
+}
+
+// Search - full-text search over the product index
+type Search struct {
+	db *sqlx.DB
+}
+
+// NewSearch - constructor
+func NewSearch(db *sqlx.DB) *Search {
+	return &Search{db: db}
+}
+
+const (
+	facetCategory = "category"
+	facetDealer   = "dealer"
+	facetPrice    = "price"
+	facetInStock  = "in_stock"
+
+	// FACET_LIMIT - max values of the category and dealer facets
+	FACET_LIMIT = 20
+)
+
+const searchColumns = `p.id, p.public_id, p.dealer_public_id, p.sku, p.name, p.description, p.category,
+	p.price, p.discount, p.quantity, p.reserved, p.low_stock_threshold, p.attributes, p.created_at, p.updated_at,
+	p.parent_public_id, p.price_override, p.rating, p.rating_count`
+
+// rankExpression - count of the matched terms, offsets() gives 4 numbers for every match
+const rankExpression = `(length(offsets(product_fts)) - length(replace(offsets(product_fts), ' ', '')) + 1) / 4`
+
+// searchRow - product with its position in the results
+type searchRow struct {
+	Id   int64 `db:"id"`
+	Rank int64 `db:"rank"`
+	domain.Product
+}
+
+// SearchProducts - one page of the found products and the cursor of the next one, if there is
+func (r *Search) SearchProducts(query domain.SearchQuery) ([]domain.Product, *domain.SearchCursor, error) {
+	from, conditions, args := searchSource(query, "")
+	rank := "0"
+	if query.MatchQuery() != "" {
+		rank = rankExpression
+	}
+
+	var after string
+	var afterArgs []interface{}
+	var order string
+	switch query.Sort {
+	case domain.SORT_RELEVANCE:
+		order, after = "rank DESC, id DESC", "(rank < ? OR (rank = ? AND id < ?))"
+		if query.After != nil {
+			afterArgs = []interface{}{query.After.Rank, query.After.Rank, query.After.Id}
+		}
+	case domain.SORT_PRICE_ASC:
+		order, after = "price ASC, id ASC", "(price > ? OR (price = ? AND id > ?))"
+		if query.After != nil {
+			afterArgs = []interface{}{query.After.Price, query.After.Price, query.After.Id}
+		}
+	case domain.SORT_PRICE_DESC:
+		order, after = "price DESC, id DESC", "(price < ? OR (price = ? AND id < ?))"
+		if query.After != nil {
+			afterArgs = []interface{}{query.After.Price, query.After.Price, query.After.Id}
+		}
+	default:
+		order, after = "id DESC", "id < ?"
+		if query.After != nil {
+			afterArgs = []interface{}{query.After.Id}
+		}
+	}
+	if query.After == nil {
+		after = "1"
+	}
+
+	// one more row tells whether there is the next page
+	statement := fmt.Sprintf(`SELECT * FROM (SELECT %s, %s AS rank FROM %s %s) WHERE %s ORDER BY %s LIMIT ?`,
+		searchColumns, rank, from, where(conditions), after, order)
+	args = append(append(args, afterArgs...), query.Limit+1)
+
+	var rows []searchRow
+	if err := r.db.Select(&rows, statement, args...); err != nil {
+		return nil, nil, fmt.Errorf("search products: %w", err)
+	}
+
+	var next *domain.SearchCursor
+	if len(rows) > query.Limit {
+		rows = rows[:query.Limit]
+		last := rows[len(rows)-1]
+		next = &domain.SearchCursor{Sort: query.Sort, Id: last.Id}
+		switch query.Sort {
+		case domain.SORT_RELEVANCE:
+			next.Rank = last.Rank
+		case domain.SORT_PRICE_ASC, domain.SORT_PRICE_DESC:
+			next.Price = last.Price
+		}
+	}
+
+	products := make([]domain.Product, 0, len(rows))
+	for _, row := range rows {
+		products = append(products, row.Product)
+	}
+	if err := selectImages(r.db, products); err != nil {
+		return nil, nil, err
+	}
+	if err := selectVariants(r.db, products); err != nil {
+		return nil, nil, err
+	}
+	return products, next, nil
+}
+
+// GetSearchFacets - the cursor and the sort do not change facets
+func (r *Search) GetSearchFacets(query domain.SearchQuery) (domain.SearchFacets, error) {
+	facets := domain.SearchFacets{
+		Categories: []domain.FacetCount{},
+		Dealers:    []domain.FacetCount{},
+	}
+
+	from, conditions, args := searchSource(query, facetCategory)
+	err := r.db.Select(&facets.Categories, fmt.Sprintf(`SELECT p.category AS value, count(*) AS count
+		FROM %s %s GROUP BY p.category ORDER BY count DESC, value LIMIT %d`,
+		from, where(append(conditions, "p.category != ''")), FACET_LIMIT), args...)
+	if err != nil {
+		return facets, fmt.Errorf("category facet: %w", err)
+	}
+
+	from, conditions, args = searchSource(query, facetDealer)
+	err = r.db.Select(&facets.Dealers, fmt.Sprintf(`SELECT p.dealer_public_id AS value, count(*) AS count
+		FROM %s %s GROUP BY p.dealer_public_id ORDER BY count DESC, value LIMIT %d`,
+		from, where(conditions), FACET_LIMIT), args...)
+	if err != nil {
+		return facets, fmt.Errorf("dealer facet: %w", err)
+	}
+
+	from, conditions, args = searchSource(query, facetPrice)
+	err = r.db.Get(&facets.Price, fmt.Sprintf(`SELECT coalesce(min(p.price), 0) AS min_price,
+		coalesce(max(p.price), 0) AS max_price FROM %s %s`, from, where(conditions)), args...)
+	if err != nil {
+		return facets, fmt.Errorf("price facet: %w", err)
+	}
+	facets.Price.Buckets, err = r.getPriceBuckets(from, conditions, args)
+	if err != nil {
+		return facets, fmt.Errorf("price facet buckets: %w", err)
+	}
+
+	from, conditions, args = searchSource(query, facetInStock)
+	err = r.db.Get(&facets.InStock, fmt.Sprintf(`SELECT count(*) FROM %s %s`,
+		from, where(append(conditions, inStockCondition))), args...)
+	if err != nil {
+		return facets, fmt.Errorf("in stock facet: %w", err)
+	}
+
+	return facets, nil
+}
+
+// getPriceBuckets - all buckets are returned, empty ones too
+func (r *Search) getPriceBuckets(from string, conditions []string, args []interface{}) ([]domain.PriceBucket, error) {
+	bounds := domain.PRICE_FACET_BOUNDS
+	buckets := make([]domain.PriceBucket, len(bounds)+1)
+	var cases strings.Builder
+	var boundArgs []interface{}
+	for i, bound := range bounds {
+		fmt.Fprintf(&cases, "WHEN p.price < ? THEN %d ", i)
+		boundArgs = append(boundArgs, bound)
+		buckets[i].To = bound
+		buckets[i+1].From = bound
+	}
+
+	var counts []struct {
+		Bucket int   `db:"bucket"`
+		Count  int64 `db:"count"`
+	}
+	err := r.db.Select(&counts, fmt.Sprintf(`SELECT CASE %s ELSE %d END AS bucket, count(*) AS count
+		FROM %s %s GROUP BY bucket`, cases.String(), len(bounds), from, where(conditions)),
+		append(boundArgs, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	for _, count := range counts {
+		buckets[count.Bucket].Count = count.Count
+	}
+
+	return buckets, nil
+}
+
+// inStockCondition - the product or one of its variants can be sold
+var inStockCondition = fmt.Sprintf(`(p.quantity - p.reserved > 0 OR EXISTS (SELECT 1 FROM %s v
+	WHERE v.parent_public_id = p.public_id AND v.quantity - v.reserved > 0))`, productTable)
+
+// searchSource - products matched by the query with all filters except the skipped facet one,
+// variants are found with their parent
+func searchSource(query domain.SearchQuery, skip string) (string, []string, []interface{}) {
+	from := productTable + " p"
+	conditions := []string{"p.parent_public_id IS NULL"}
+	var args []interface{}
+
+	if match := query.MatchQuery(); match != "" {
+		from += fmt.Sprintf(" JOIN %[1]s ON %[1]s.docid = p.id", productIndexTable)
+		conditions = append(conditions, productIndexTable+" MATCH ?")
+		args = append(args, match)
+	}
+	if query.Category != "" && skip != facetCategory {
+		// a category includes its subcategories
+		conditions = append(conditions, fmt.Sprintf(`p.category IN (WITH RECURSIVE tree(slug) AS (
+			SELECT ? UNION SELECT c.slug FROM %s c JOIN tree ON c.parent_slug = tree.slug
+		) SELECT slug FROM tree)`, categoryTable))
+		args = append(args, query.Category)
+	}
+	if query.DealerPublicId != uuid.Nil && skip != facetDealer {
+		conditions = append(conditions, "p.dealer_public_id = ?")
+		args = append(args, query.DealerPublicId)
+	}
+	if skip != facetPrice {
+		if query.MinPrice > 0 {
+			conditions = append(conditions, "p.price >= ?")
+			args = append(args, query.MinPrice)
+		}
+		if query.MaxPrice > 0 {
+			conditions = append(conditions, "p.price <= ?")
+			args = append(args, query.MaxPrice)
+		}
+	}
+	if query.InStock && skip != facetInStock {
+		conditions = append(conditions, inStockCondition)
+	}
+
+	return from, conditions, args
+}
+
+// where - conditions joined with AND
+func where(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
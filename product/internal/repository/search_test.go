@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	dealer := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+	otherDealer := uuid.MustParse("8c8a3bd1-7cf3-4e3a-a1a3-2a4bb8a9b7c1")
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	product := func(name, description, category string, dealerPublicId uuid.UUID, price, quantity int64) domain.Product {
+		now = now.Add(time.Minute)
+		return domain.Product{PublicId: uuid.New(), DealerPublicId: dealerPublicId, Name: name, Description: description,
+			Category: category, Price: price, Quantity: quantity, CreatedAt: now, UpdatedAt: now}
+	}
+	sofa := product("Grey sofa", "Soft sofa for the living room", "sofas", dealer, 3000000, 2)
+	corner := product("Corner sofa", "Big leather sofa, sofa bed", "sofas", otherDealer, 7000000, 0)
+	table := product("Oak table", "Dining table for the living room", "tables", dealer, 2000000, 5)
+	chair := product("Кресло", "Мягкое кресло", "chairs", otherDealer, 500000, 1)
+	for _, p := range []domain.Product{sofa, corner, table, chair} {
+		assert.NoError(t, repos.CreateProduct(p))
+	}
+
+	search := func(query domain.SearchQuery) []string {
+		assert.NoError(t, query.Validate())
+		products, _, err := repos.SearchProducts(query)
+		assert.NoError(t, err)
+		names := []string{}
+		for _, p := range products {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+
+	t.Run("Can search by words prefix", func(t *testing.T) {
+		assert.Equal(t, []string{"Corner sofa", "Grey sofa"}, search(domain.SearchQuery{Text: "sof"}))
+		assert.Equal(t, []string{"Oak table", "Grey sofa"}, search(domain.SearchQuery{Text: "living room"}))
+		assert.Equal(t, []string{"Oak table"}, search(domain.SearchQuery{Text: "TABLES"}))
+		assert.Equal(t, []string{"Кресло"}, search(domain.SearchQuery{Text: "кресл"}))
+		assert.Equal(t, []string{}, search(domain.SearchQuery{Text: "sofa wardrobe"}))
+		assert.Equal(t, []string{"Oak table"}, search(domain.SearchQuery{Text: `"oak*" -(`}))
+		assert.Equal(t, []string{}, search(domain.SearchQuery{Text: "oak OR sofa"}), "operators are plain words")
+	})
+
+	t.Run("Can filter and sort", func(t *testing.T) {
+		assert.Equal(t, []string{"Oak table", "Grey sofa"},
+			search(domain.SearchQuery{DealerPublicId: dealer, Sort: domain.SORT_PRICE_ASC}))
+		assert.Equal(t, []string{"Grey sofa", "Oak table", "Кресло"},
+			search(domain.SearchQuery{InStock: true, Sort: domain.SORT_PRICE_DESC}))
+		assert.Equal(t, []string{"Oak table", "Grey sofa"},
+			search(domain.SearchQuery{MinPrice: 1000000, MaxPrice: 5000000}))
+		assert.Equal(t, []string{"Corner sofa"}, search(domain.SearchQuery{Text: "sofa", Category: "sofas", InStock: false,
+			MinPrice: 5000000}))
+	})
+
+	t.Run("Can page with cursor", func(t *testing.T) {
+		for _, sort := range []domain.SearchSort{domain.SORT_NEWEST, domain.SORT_PRICE_ASC, domain.SORT_PRICE_DESC} {
+			all := search(domain.SearchQuery{Sort: sort})
+			var paged []string
+			query := domain.SearchQuery{Sort: sort, Limit: 3}
+			for {
+				assert.NoError(t, query.Validate())
+				products, next, err := repos.SearchProducts(query)
+				assert.NoError(t, err)
+				for _, p := range products {
+					paged = append(paged, p.Name)
+				}
+				if next == nil {
+					break
+				}
+				query.Cursor = next.Encode()
+			}
+			assert.Equal(t, all, paged, sort)
+		}
+
+		query := domain.SearchQuery{Text: "sofa", Limit: 1}
+		assert.NoError(t, query.Validate())
+		first, next, err := repos.SearchProducts(query)
+		assert.NoError(t, err)
+		assert.Equal(t, "Corner sofa", first[0].Name, "more matches is more relevant")
+		query.Cursor = next.Encode()
+		assert.NoError(t, query.Validate())
+		second, next, err := repos.SearchProducts(query)
+		assert.NoError(t, err)
+		assert.Equal(t, "Grey sofa", second[0].Name)
+		assert.Nil(t, next)
+	})
+
+	t.Run("Can count facets without own filter", func(t *testing.T) {
+		query := domain.SearchQuery{Text: "sofa table", Category: "sofas"}
+		facets, err := repos.GetSearchFacets(domain.SearchQuery{Text: "living", Category: "sofas", InStock: true})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.FacetCount{{Value: "sofas", Count: 1}, {Value: "tables", Count: 1}}, facets.Categories)
+		assert.Equal(t, []domain.FacetCount{{Value: dealer.String(), Count: 1}}, facets.Dealers)
+		assert.Equal(t, int64(1), facets.InStock)
+		assert.Equal(t, domain.PriceFacet{Min: 3000000, Max: 3000000, Buckets: []domain.PriceBucket{
+			{To: 1000000}, {From: 1000000, To: 2500000}, {From: 2500000, To: 5000000, Count: 1},
+			{From: 5000000, To: 10000000}, {From: 10000000},
+		}}, facets.Price)
+
+		facets, err = repos.GetSearchFacets(query)
+		assert.NoError(t, err)
+		assert.Empty(t, facets.Categories)
+		assert.Empty(t, facets.Dealers)
+		assert.Equal(t, int64(0), facets.Price.Max)
+	})
+
+	t.Run("Can keep index in sync", func(t *testing.T) {
+		updated := table
+		updated.Name = "Oak wardrobe"
+		updated.Description = "Wardrobe"
+		assert.NoError(t, repos.UpdateProduct(updated))
+		assert.Equal(t, []string{}, search(domain.SearchQuery{Text: "dining"}))
+		assert.Equal(t, []string{"Oak wardrobe"}, search(domain.SearchQuery{Text: "wardrobe"}))
+
+		assert.NoError(t, repos.DeleteProduct(sofa.PublicId))
+		assert.Equal(t, []string{"Corner sofa"}, search(domain.SearchQuery{Text: "sofa"}))
+		assert.ErrorIs(t, repos.DeleteProduct(sofa.PublicId), domain.ErrProductNotFound)
+	})
+}
+
+func TestSearch_rebuildIndex(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, repos.CreateProduct(domain.Product{PublicId: uuid.New(), DealerPublicId: uuid.New(),
+		Name: "Grey sofa", Description: "Soft sofa", Category: "sofas", Price: 3000000, CreatedAt: now, UpdatedAt: now}))
+
+	// the database before the search: the catalogue without the index
+	for _, query := range []string{`DROP TRIGGER product_fts_insert`, `DROP TRIGGER product_fts_before_update`,
+		`DROP TRIGGER product_fts_after_update`, `DROP TRIGGER product_fts_delete`, `DROP TABLE product_fts`} {
+		_, err := db.Exec(query)
+		assert.NoError(t, err)
+	}
+
+	t.Run("Can find the products written before the index", func(t *testing.T) {
+		repos = NewRepository(db)
+		products, _, err := repos.SearchProducts(domain.SearchQuery{Text: "sofa", Limit: 10})
+		assert.NoError(t, err)
+		if assert.Len(t, products, 1) {
+			assert.Equal(t, "Grey sofa", products[0].Name)
+		}
+	})
+
+	t.Run("Can't index the products twice on the next start", func(t *testing.T) {
+		repos = NewRepository(db)
+		products, _, err := repos.SearchProducts(domain.SearchQuery{Text: "sofa", Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, products, 1)
+	})
+}
@@ -6,7 +6,19 @@ import (
 )
 
 const (
-	accountTable = "account"
+	accountTable        = "account"
+	revokedSessionTable = "revoked_session"
+	apiKeyTable         = "api_key"
+	productTable        = "product"
+	productIndexTable   = "product_fts"
+	categoryTable       = "category"
+	productImageTable   = "product_image"
+	importJobTable      = "import_job"
+	stockMovementTable  = "stock_movement"
+	priceChangeTable    = "price_change"
+	reviewTable         = "review"
+	deliveredOrderTable = "delivered_order"
+	orderLineTable      = "order_line"
 )
 
 // Config - db
@@ -20,6 +32,9 @@ func NewSqlite3DB(cfg Config) (*sqlx.DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	// every new connection to ":memory:" gets its own empty database,
+	// the consumer and http handlers must share the only one
+	db.SetMaxOpenConns(1)
 
 	err = db.Ping()
 	if err != nil {
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+var _ Stocker = (*Stock)(nil)
+
+// Stocker - repository interface
+type Stocker interface {
+	AddMovement(movement domain.StockMovement) (domain.StockMovement, domain.Stock, error)
+	GetStockLevels(dealerPublicId uuid.UUID, lowOnly bool) ([]domain.StockLevel, error)
+	GetMovements(productPublicId uuid.UUID, before int64, limit int) ([]domain.StockMovement, error)
+	SetLowStockThreshold(productPublicId uuid.UUID, threshold int64, now time.Time) error
+}
+
+// Stock - product stock movements, the product keeps the current on hand and reserved quantity
+type Stock struct {
+	db *sqlx.DB
+}
+
+// NewStock - constructor
+func NewStock(db *sqlx.DB) *Stock {
+	return &Stock{db: db}
+}
+
+const movementColumns = `product_public_id, type, quantity, on_hand, reserved, actor_public_id, comment, created_at`
+
+// AddMovement - applies the movement to the product stock, returns the saved movement and the stock before it
+func (r *Stock) AddMovement(movement domain.StockMovement) (domain.StockMovement, domain.Stock, error) {
+	var before domain.Stock
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return movement, before, err
+	}
+	defer tx.Rollback() // nolint
+
+	query := fmt.Sprintf(`SELECT quantity, reserved FROM %s WHERE public_id=$1`, productTable)
+	err = tx.QueryRowx(query, movement.ProductPublicId).Scan(&before.OnHand, &before.Reserved)
+	if errors.Is(err, sql.ErrNoRows) {
+		return movement, before, domain.ErrProductNotFound
+	}
+	if err != nil {
+		return movement, before, fmt.Errorf("get product stock: %w", err)
+	}
+
+	after, err := before.Apply(movement.Type, movement.Quantity)
+	if err != nil {
+		return movement, before, err
+	}
+	movement.OnHand = after.OnHand
+	movement.Reserved = after.Reserved
+
+	if err = insertMovement(tx, movement); err != nil {
+		return movement, before, err
+	}
+	if err = tx.Get(&movement.Id, `SELECT last_insert_rowid()`); err != nil {
+		return movement, before, err
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET quantity=$1, reserved=$2, updated_at=$3 WHERE public_id=$4`, productTable)
+	_, err = tx.Exec(query, after.OnHand, after.Reserved, movement.CreatedAt, movement.ProductPublicId)
+	if err != nil {
+		return movement, before, err
+	}
+	return movement, before, tx.Commit()
+}
+
+// GetStockLevels - dealer products in creation order, low only are the products at or under the threshold
+func (r *Stock) GetStockLevels(dealerPublicId uuid.UUID, lowOnly bool) ([]domain.StockLevel, error) {
+	levels := []domain.StockLevel{}
+
+	lowStock := `low_stock_threshold > 0 AND quantity - reserved <= low_stock_threshold`
+	condition := ""
+	if lowOnly {
+		condition = " AND " + lowStock
+	}
+	query := fmt.Sprintf(`SELECT public_id, sku, name, quantity, reserved, quantity - reserved AS available,
+		low_stock_threshold, %s AS low_stock FROM %s WHERE dealer_public_id=$1%s ORDER BY id`,
+		lowStock, productTable, condition)
+	if err := r.db.Select(&levels, query, dealerPublicId); err != nil {
+		return nil, fmt.Errorf("get stock levels: %w", err)
+	}
+
+	return levels, nil
+}
+
+// GetMovements - from the newest, before is the id to start under, 0 is from the last one
+func (r *Stock) GetMovements(productPublicId uuid.UUID, before int64, limit int) ([]domain.StockMovement, error) {
+	movements := []domain.StockMovement{}
+
+	query := fmt.Sprintf(`SELECT id, %s FROM %s WHERE product_public_id=$1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC LIMIT $3`, movementColumns, stockMovementTable)
+	if err := r.db.Select(&movements, query, productPublicId, before, limit); err != nil {
+		return nil, fmt.Errorf("get stock movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// SetLowStockThreshold
+func (r *Stock) SetLowStockThreshold(productPublicId uuid.UUID, threshold int64, now time.Time) error {
+	query := fmt.Sprintf(`UPDATE %s SET low_stock_threshold=$1, updated_at=$2 WHERE public_id=$3`, productTable)
+	result, err := r.db.Exec(query, threshold, now, productPublicId)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+// insertMovement - the stock after the movement is already applied
+func insertMovement(tx *sqlx.Tx, movement domain.StockMovement) error {
+	query := fmt.Sprintf(`INSERT INTO %s (%s) values ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		stockMovementTable, movementColumns)
+	_, err := tx.Exec(query, movement.ProductPublicId, movement.Type, movement.Quantity, movement.OnHand,
+		movement.Reserved, movement.ActorPublicId, movement.Comment, movement.CreatedAt)
+	return err
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStock(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	dealer := uuid.New()
+	sofa := domain.Product{PublicId: uuid.New(), DealerPublicId: dealer, Name: "Sofa", Quantity: 5,
+		LowStockThreshold: 2, CreatedAt: now, UpdatedAt: now}
+	chair := domain.Product{PublicId: uuid.New(), DealerPublicId: dealer, Name: "Chair", CreatedAt: now, UpdatedAt: now}
+	for _, product := range []domain.Product{sofa, chair} {
+		assert.NoError(t, repos.CreateProduct(product))
+	}
+
+	move := func(movementType domain.MovementType, quantity int64) (domain.StockMovement, domain.Stock, error) {
+		return repos.AddMovement(domain.StockMovement{ProductPublicId: sofa.PublicId, Type: movementType,
+			Quantity: quantity, ActorPublicId: dealer, CreatedAt: now.Add(time.Hour)})
+	}
+
+	t.Run("Can record initial stock as received", func(t *testing.T) {
+		movements, err := repos.GetMovements(sofa.PublicId, 0, 10)
+		assert.NoError(t, err)
+		assert.Len(t, movements, 1)
+		assert.Equal(t, domain.MOVEMENT_RECEIVED, movements[0].Type)
+		assert.Equal(t, int64(5), movements[0].OnHand)
+
+		movements, err = repos.GetMovements(chair.PublicId, 0, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, movements)
+	})
+
+	t.Run("Can reserve and sell stock", func(t *testing.T) {
+		movement, before, err := move(domain.MOVEMENT_RESERVED, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Stock{OnHand: 5}, before)
+		assert.Equal(t, int64(2), movement.Reserved)
+
+		movement, _, err = move(domain.MOVEMENT_SOLD, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), movement.OnHand)
+		assert.Equal(t, int64(1), movement.Reserved)
+
+		stored, err := repos.GetProduct(sofa.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Stock{OnHand: 4, Reserved: 1}, stored.Stock())
+		assert.True(t, stored.UpdatedAt.After(now))
+	})
+
+	t.Run("Can't take more than available", func(t *testing.T) {
+		_, _, err := move(domain.MOVEMENT_ADJUSTED, -4)
+		assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+		_, _, err = move(domain.MOVEMENT_RESERVED, -2)
+		assert.ErrorIs(t, err, domain.ErrInvalidMovement)
+
+		stored, err := repos.GetProduct(sofa.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Stock{OnHand: 4, Reserved: 1}, stored.Stock())
+	})
+
+	t.Run("Can page movements from the newest", func(t *testing.T) {
+		movements, err := repos.GetMovements(sofa.PublicId, 0, 2)
+		assert.NoError(t, err)
+		assert.Len(t, movements, 2)
+		assert.Equal(t, domain.MOVEMENT_SOLD, movements[0].Type)
+
+		movements, err = repos.GetMovements(sofa.PublicId, movements[1].Id, 2)
+		assert.NoError(t, err)
+		assert.Len(t, movements, 1)
+		assert.Equal(t, domain.MOVEMENT_RECEIVED, movements[0].Type)
+	})
+
+	t.Run("Can get low stock levels", func(t *testing.T) {
+		levels, err := repos.GetStockLevels(dealer, false)
+		assert.NoError(t, err)
+		assert.Len(t, levels, 2)
+		assert.Equal(t, int64(3), levels[0].Available)
+		assert.False(t, levels[0].LowStock)
+
+		assert.NoError(t, repos.SetLowStockThreshold(sofa.PublicId, 3, now))
+		levels, err = repos.GetStockLevels(dealer, true)
+		assert.NoError(t, err)
+		assert.Len(t, levels, 1)
+		assert.Equal(t, sofa.PublicId, levels[0].ProductPublicId)
+		assert.True(t, levels[0].LowStock)
+	})
+
+	t.Run("Can delete product with its history", func(t *testing.T) {
+		assert.NoError(t, repos.DeleteProduct(sofa.PublicId))
+		movements, err := repos.GetMovements(sofa.PublicId, 0, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, movements)
+	})
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+// selectVariants - variants of the products in creation order with the price range,
+// a variant has no variants and no images of its own
+func selectVariants(db sqlx.Queryer, products []domain.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.PublicId)
+	}
+	query, args, err := sqlx.In(fmt.Sprintf(`SELECT %s FROM %s WHERE parent_public_id IN (?) ORDER BY id`,
+		productColumns, productTable), ids)
+	if err != nil {
+		return err
+	}
+
+	var variants []domain.Product
+	if err = sqlx.Select(db, &variants, query, args...); err != nil {
+		return fmt.Errorf("get product variants: %w", err)
+	}
+
+	byParent := make(map[uuid.UUID][]domain.Product, len(products))
+	for _, variant := range variants {
+		variant.Images = []domain.ProductImage{}
+		variant.SetVariants(nil)
+		byParent[variant.ParentPublicId.UUID] = append(byParent[variant.ParentPublicId.UUID], variant)
+	}
+	for i := range products {
+		products[i].SetVariants(byParent[products[i].PublicId])
+	}
+	return nil
+}
+
+// syncVariants - the parent data goes to its variants, a price change of a variant is recorded too
+func syncVariants(tx *sqlx.Tx, parent domain.Product) error {
+	products := []domain.Product{parent}
+	if err := selectVariants(tx, products); err != nil {
+		return err
+	}
+	stored := append([]domain.Product(nil), products[0].Variants...)
+	parent.SetVariants(products[0].Variants)
+	parent.SyncVariants()
+
+	query := fmt.Sprintf(`UPDATE %s SET name=$1, description=$2, category=$3, price=$4, discount=$5, updated_at=$6
+		WHERE public_id=$7`, productTable)
+	for i, variant := range parent.Variants {
+		_, err := tx.Exec(query, variant.Name, variant.Description, variant.Category, variant.Price,
+			variant.Discount, variant.UpdatedAt, variant.PublicId)
+		if err != nil {
+			return err
+		}
+		if stored[i].Price != variant.Price || stored[i].Discount != variant.Discount {
+			if err = insertPriceChange(tx, variant.PriceChange(parent.DealerPublicId)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
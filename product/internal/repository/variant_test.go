@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariant(t *testing.T) {
+	db, err := NewSqlite3DB(Config{Driver: "sqlite3"})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repos := NewRepository(db)
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	sofa := domain.Product{PublicId: uuid.New(), DealerPublicId: uuid.New(), Name: "Sofa", Category: "sofas",
+		Price: 100000, CreatedAt: now, UpdatedAt: now}
+	assert.NoError(t, repos.CreateProduct(sofa))
+
+	variant := func(sku string, price, quantity int64) domain.Product {
+		variant := sofa.NewVariant(uuid.New())
+		variant.ApplyVariantInput(sofa, domain.VariantInput{Sku: sku, Price: price, Quantity: quantity,
+			Attributes: domain.Attributes{"fabric": sku}})
+		variant.Quantity = quantity
+		variant.CreatedAt, variant.UpdatedAt = now, now
+		assert.NoError(t, repos.CreateProduct(variant))
+		return variant
+	}
+	velvet := variant("velvet", 0, 0)
+	leather := variant("leather", 150000, 2)
+
+	t.Run("Can get product with variants and price range", func(t *testing.T) {
+		stored, err := repos.GetProduct(sofa.PublicId)
+		assert.NoError(t, err)
+		assert.Len(t, stored.Variants, 2)
+		assert.Equal(t, velvet.PublicId, stored.Variants[0].PublicId)
+		assert.Equal(t, int64(100000), stored.MinPrice)
+		assert.Equal(t, int64(150000), stored.MaxPrice)
+
+		stored, err = repos.GetProduct(leather.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, sofa.PublicId, stored.ParentPublicId.UUID)
+		assert.True(t, stored.PriceOverride)
+		assert.Empty(t, stored.Variants)
+		assert.Equal(t, int64(150000), stored.MinPrice)
+	})
+
+	t.Run("Can sync parent changes to variants", func(t *testing.T) {
+		sofa.Name, sofa.Price, sofa.Discount = "Grey sofa", 120000, 10
+		sofa.UpdatedAt = now.Add(time.Hour)
+		assert.NoError(t, repos.UpdateProduct(sofa))
+
+		stored, err := repos.GetProduct(sofa.PublicId)
+		assert.NoError(t, err)
+		assert.Equal(t, "Grey sofa", stored.Variants[0].Name)
+		assert.Equal(t, int64(120000), stored.Variants[0].Price)
+		assert.Equal(t, int64(150000), stored.Variants[1].Price)
+		assert.Equal(t, int64(10), stored.Variants[1].Discount)
+
+		changes, err := repos.GetPriceHistory(velvet.PublicId, time.Time{}, 0, 10)
+		assert.NoError(t, err)
+		assert.Len(t, changes, 2)
+		assert.Equal(t, int64(108000), changes[0].EffectivePrice)
+	})
+
+	t.Run("Can find parent only with variant stock", func(t *testing.T) {
+		products, _, err := repos.SearchProducts(domain.SearchQuery{Text: "sofa", InStock: true, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, products, 1)
+		assert.Equal(t, sofa.PublicId, products[0].PublicId)
+		assert.Len(t, products[0].Variants, 2)
+
+		facets, err := repos.GetSearchFacets(domain.SearchQuery{Text: "sofa"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), facets.InStock)
+	})
+
+	t.Run("Can delete product with variants", func(t *testing.T) {
+		assert.NoError(t, repos.DeleteProduct(sofa.PublicId))
+		_, err := repos.GetProduct(velvet.PublicId)
+		assert.ErrorIs(t, err, domain.ErrProductNotFound)
+		movements, err := repos.GetMovements(leather.PublicId, 0, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, movements)
+	})
+}
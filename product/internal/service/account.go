@@ -1,100 +1,95 @@
 package service
 
 import (
-	"crypto/sha1" // nolint
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
-	"github.com/google/uuid"
 	"github.com/p12s/furniture-store/product/internal/config"
 	"github.com/p12s/furniture-store/product/internal/domain"
 	"github.com/p12s/furniture-store/product/internal/repository"
 )
 
+const (
+	// API_KEY_TOUCH_INTERVAL - the last used time is updated and reported not more often
+	API_KEY_TOUCH_INTERVAL = time.Minute
+)
+
 var _ Accounter = (*AccountService)(nil)
 
+// Accounter - service interface
 type Accounter interface {
 	CreateAccount(account domain.Account) error
+	GetAccount(publicId string) (domain.Account, error)
 	UpdateAccountInfo(input domain.UpdateAccountInput) error
 	UpdateAccountRole(input domain.UpdateAccountRoleInput) error
-	DeleteAccount(accountPublicId uuid.UUID) error
-	GenerateTokenByCreds(email, password string) (string, error)
+	DeleteAccount(accountPublicId string) error
+	RevokeSessions(input domain.SessionsRevokedInput) error
 	ParseToken(token string) (string, error)
+	CreateAPIKey(input domain.APIKeyCreatedInput) error
+	RevokeAPIKey(input domain.APIKeyRevokedInput) error
+	ParseAPIKey(key string) (domain.APIKey, *domain.APIKeyUsedEvent, error)
 }
 
 // AccountService - service
 type AccountService struct {
 	repo       repository.Accounter
-	salt       string
-	tokenTTL   time.Duration
+	apiKeys    repository.APIKeyer
 	signingKey string
 }
 
 // NewAccountService - constructor
-func NewAccountService(repo repository.Accounter, config *config.Auth) *AccountService {
+func NewAccountService(repo repository.Accounter, apiKeys repository.APIKeyer, config *config.Auth) *AccountService {
 	return &AccountService{
 		repo:       repo,
-		salt:       config.Salt,
-		tokenTTL:   time.Duration(config.TokenTTL),
+		apiKeys:    apiKeys,
 		signingKey: config.SigningKey,
 	}
 }
 
+// CreateAccount
 func (s *AccountService) CreateAccount(account domain.Account) error {
-	passwordHash, err := s.generatePasswordHash(account.Password)
-	if err != nil {
-		return fmt.Errorf("generate password: %w", err)
-	}
-	account.Password = passwordHash
 	return s.repo.CreateAccount(account)
 }
 
+// GetAccount
+func (s *AccountService) GetAccount(publicId string) (domain.Account, error) {
+	return s.repo.GetAccount(publicId)
+}
+
+// UpdateAccountInfo
 func (s *AccountService) UpdateAccountInfo(input domain.UpdateAccountInput) error {
-	if input.Password != nil {
-		passwordHash, err := s.generatePasswordHash(*input.Password)
-		if err != nil {
-			return fmt.Errorf("generate password: %w", err)
-		}
-		*input.Password = passwordHash
-	}
 	return s.repo.UpdateAccountInfo(input)
 }
 
+// UpdateAccountRole
 func (s *AccountService) UpdateAccountRole(input domain.UpdateAccountRoleInput) error {
 	return s.repo.UpdateAccountRole(input)
 }
 
-func (s *AccountService) DeleteAccount(accountPublicId uuid.UUID) error {
+// DeleteAccount
+func (s *AccountService) DeleteAccount(accountPublicId string) error {
 	return s.repo.DeleteAccount(accountPublicId)
 }
 
-func (s *AccountService) GenerateTokenByCreds(email, password string) (string, error) {
-	passwordHash, err := s.generatePasswordHash(password)
-	if err != nil {
-		return "", fmt.Errorf("generate password: %w", err)
-	}
-	account, err := s.repo.GetByCredentials(email, passwordHash)
-	if err != nil {
-		return "", fmt.Errorf("user creds wrong: %w", err)
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{ //nolint
-		Subject:   account.PublicId.String(),
-		IssuedAt:  time.Now().Unix(),
-		ExpiresAt: time.Now().Add(s.tokenTTL).Unix(),
-	})
-
-	return token.SignedString(s.signingKey)
+// RevokeSessions - the account service revoked the sessions
+func (s *AccountService) RevokeSessions(input domain.SessionsRevokedInput) error {
+	return s.repo.RevokeSessions(input, time.Now().UTC())
 }
 
-func (s *AccountService) ParseToken(token string) (string, error) {
-	t, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+// ParseToken - token is issued by the account service for a session, the session must not be revoked.
+// The tokens with an audience (oauth client, email verification) are not the access tokens of the routes
+func (s *AccountService) ParseToken(accessToken string) (string, error) {
+	t, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 
-		return s.signingKey, nil
+		return []byte(s.signingKey), nil
 	})
 	if err != nil {
 		return "", fmt.Errorf("unexpected signing method: %w/n", err)
@@ -113,14 +108,68 @@ func (s *AccountService) ParseToken(token string) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("invalid subject")
 	}
+	if _, ok := claims["aud"]; ok {
+		return "", fmt.Errorf("invalid audience")
+	}
+
+	tokenId, ok := claims["jti"].(string)
+	if !ok {
+		return "", fmt.Errorf("invalid session")
+	}
+	revoked, err := s.repo.IsSessionRevoked(tokenId)
+	if err != nil {
+		return "", fmt.Errorf("token session: %w", err)
+	}
+	if revoked {
+		return "", fmt.Errorf("revoked session")
+	}
 
 	return subject, nil
 }
 
-func (s *AccountService) generatePasswordHash(password string) (string, error) {
-	hash := sha1.New() // #nosec
-	if _, err := hash.Write([]byte(password)); err != nil {
-		return "", fmt.Errorf("hash write: %w", err)
+// CreateAPIKey - the account service issued the key
+func (s *AccountService) CreateAPIKey(input domain.APIKeyCreatedInput) error {
+	return s.apiKeys.CreateAPIKey(domain.APIKey{
+		Prefix:          input.Prefix,
+		KeyHash:         input.KeyHash,
+		AccountPublicId: input.PublicId,
+		Scopes:          input.Scopes,
+		ExpiresAt:       input.ExpiresAt.UTC(),
+	})
+}
+
+// RevokeAPIKey - the account service revoked the key
+func (s *AccountService) RevokeAPIKey(input domain.APIKeyRevokedInput) error {
+	return s.apiKeys.RevokeAPIKey(input.Prefix, input.PublicId, time.Now().UTC())
+}
+
+// ParseAPIKey - the key is found by its prefix and checked by the hash, it must not be expired.
+// The usage event is returned when the last used time is updated
+func (s *AccountService) ParseAPIKey(key string) (domain.APIKey, *domain.APIKeyUsedEvent, error) {
+	if !strings.HasPrefix(key, domain.API_KEY_PREFIX) {
+		return domain.APIKey{}, nil, domain.ErrInvalidAPIKey
+	}
+	end := strings.Index(key[len(domain.API_KEY_PREFIX):], "_")
+	if end <= 0 {
+		return domain.APIKey{}, nil, domain.ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.apiKeys.GetAPIKey(key[:len(domain.API_KEY_PREFIX)+end])
+	if err != nil {
+		return domain.APIKey{}, nil, err
+	}
+	sum := sha256.Sum256([]byte(key))
+	if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(apiKey.KeyHash)) != 1 {
+		return domain.APIKey{}, nil, domain.ErrInvalidAPIKey
+	}
+	now := time.Now().UTC()
+	if !apiKey.ExpiresAt.After(now) {
+		return domain.APIKey{}, nil, domain.ErrInvalidAPIKey
+	}
+
+	// the failed usage update doesn't fail the request
+	if touched, err := s.apiKeys.TouchAPIKey(apiKey.Prefix, now, now.Add(-API_KEY_TOUCH_INTERVAL)); err != nil || !touched {
+		return apiKey, nil, nil
 	}
-	return fmt.Sprintf("%x", hash.Sum([]byte(s.salt))), nil
+	return apiKey, &domain.APIKeyUsedEvent{Prefix: apiKey.Prefix, UsedAt: now}, nil
 }
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/p12s/furniture-store/product/internal/config"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/product/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountService_ParseToken(t *testing.T) {
+	accountPublicId := "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5"
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("key"))
+		assert.NoError(t, err)
+		return token
+	}
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	type mockBehavior func(r *mock_repository.MockAccounter)
+
+	tests := []struct {
+		name         string
+		token        string
+		mockBehavior mockBehavior
+		expected     string
+		wantErr      bool
+	}{
+		{
+			name:  "Can parse the subject of the active session",
+			token: sign(jwt.MapClaims{"sub": accountPublicId, "jti": "session", "exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {
+				r.EXPECT().IsSessionRevoked("session").Return(false, nil)
+			},
+			expected: accountPublicId,
+		},
+		{
+			name:  "Can't parse the token of the revoked session",
+			token: sign(jwt.MapClaims{"sub": accountPublicId, "jti": "session", "exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {
+				r.EXPECT().IsSessionRevoked("session").Return(true, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Can't parse the token when the session is unknown to fail",
+			token: sign(jwt.MapClaims{"sub": accountPublicId, "jti": "session", "exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {
+				r.EXPECT().IsSessionRevoked("session").Return(false, errors.New(""))
+			},
+			wantErr: true,
+		},
+		{
+			name:         "Can't parse the token without the session",
+			token:        sign(jwt.MapClaims{"sub": accountPublicId, "exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {},
+			wantErr:      true,
+		},
+		{
+			name:         "Can't parse the oauth token of a client",
+			token:        sign(jwt.MapClaims{"sub": accountPublicId, "jti": "session", "aud": "oauth", "exp": expiresAt}),
+			mockBehavior: func(r *mock_repository.MockAccounter) {},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockAccounter(ctrl)
+			tt.mockBehavior(repo)
+
+			subject, err := NewAccountService(repo, nil, &config.Auth{SigningKey: "key"}).ParseToken(tt.token)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, subject)
+		})
+	}
+}
+
+func TestAccountService_ParseAPIKey(t *testing.T) {
+	const secret = "fsk_0a1b2c3d4e5f_secret"
+	sum := sha256.Sum256([]byte(secret))
+	stored := domain.APIKey{Prefix: "fsk_0a1b2c3d4e5f", KeyHash: hex.EncodeToString(sum[:]),
+		AccountPublicId: "265cee57-2ff9-4ed3-85e1-d3373fa2a1a5", Scopes: []string{domain.API_KEY_SCOPE_STOCK_READ},
+		ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("Can parse the key and report its usage", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		apiKeys := mock_repository.NewMockAPIKeyer(ctrl)
+		apiKeys.EXPECT().GetAPIKey(stored.Prefix).Return(stored, nil)
+		apiKeys.EXPECT().TouchAPIKey(stored.Prefix, gomock.Any(), gomock.Any()).Return(true, nil)
+
+		key, used, err := NewAccountService(nil, apiKeys, &config.Auth{}).ParseAPIKey(secret)
+		assert.NoError(t, err)
+		assert.Equal(t, stored, key)
+		if assert.NotNil(t, used) {
+			assert.Equal(t, stored.Prefix, used.Prefix)
+		}
+	})
+
+	t.Run("Can parse the key without the usage event in the interval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		apiKeys := mock_repository.NewMockAPIKeyer(ctrl)
+		apiKeys.EXPECT().GetAPIKey(stored.Prefix).Return(stored, nil)
+		apiKeys.EXPECT().TouchAPIKey(stored.Prefix, gomock.Any(), gomock.Any()).Return(false, errors.New(""))
+
+		_, used, err := NewAccountService(nil, apiKeys, &config.Auth{}).ParseAPIKey(secret)
+		assert.NoError(t, err)
+		assert.Nil(t, used)
+	})
+
+	t.Run("Can't parse the wrong or expired key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expired := stored
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		apiKeys := mock_repository.NewMockAPIKeyer(ctrl)
+		apiKeys.EXPECT().GetAPIKey(stored.Prefix).Return(stored, nil)
+		apiKeys.EXPECT().GetAPIKey(stored.Prefix).Return(expired, nil)
+		service := NewAccountService(nil, apiKeys, &config.Auth{})
+
+		for _, key := range []string{"fsk_0a1b2c3d4e5f_wrong", secret, "fsk_nounderscore", "token"} {
+			_, _, err := service.ParseAPIKey(key)
+			assert.ErrorIs(t, err, domain.ErrInvalidAPIKey, key)
+		}
+	})
+}
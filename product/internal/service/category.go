@@ -0,0 +1,121 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/p12s/furniture-store/product/internal/repository"
+)
+
+var _ Categorizer = (*CategoryService)(nil)
+
+// Categorizer - service interface
+type Categorizer interface {
+	CreateCategory(input domain.CategoryInput) (domain.Category, error)
+	GetCategoryTree() ([]domain.CategoryNode, error)
+	GetCategorySchema(slug string) (domain.CategorySchema, error)
+	UpdateCategory(slug string, input domain.CategoryInput) (domain.Category, error)
+	DeleteCategory(slug string) error
+}
+
+// CategoryService - category tree with the attribute definitions
+type CategoryService struct {
+	repo repository.Categorizer
+}
+
+// NewCategoryService - constructor
+func NewCategoryService(repo repository.Categorizer) *CategoryService {
+	return &CategoryService{repo: repo}
+}
+
+// CreateCategory
+func (s *CategoryService) CreateCategory(input domain.CategoryInput) (domain.Category, error) {
+	category := domain.Category{
+		Slug:       input.Slug,
+		Parent:     input.Parent,
+		Name:       input.Name,
+		Attributes: input.Attributes,
+	}
+	if err := category.Validate(); err != nil {
+		return category, err
+	}
+	if category.Parent != "" {
+		if _, err := s.repo.GetCategory(category.Parent); err != nil {
+			return category, fmt.Errorf("parent: %w", err)
+		}
+	}
+
+	return category, s.repo.CreateCategory(category)
+}
+
+// GetCategoryTree - root categories with subcategories
+func (s *CategoryService) GetCategoryTree() ([]domain.CategoryNode, error) {
+	categories, err := s.repo.GetCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string][]domain.Category)
+	for _, category := range categories {
+		children[category.Parent] = append(children[category.Parent], category)
+	}
+
+	var build func(parent string) []domain.CategoryNode
+	build = func(parent string) []domain.CategoryNode {
+		nodes := make([]domain.CategoryNode, 0, len(children[parent]))
+		for _, category := range children[parent] {
+			nodes = append(nodes, domain.CategoryNode{Category: category, Children: build(category.Slug)})
+		}
+		return nodes
+	}
+
+	return build(""), nil
+}
+
+// GetCategorySchema - category attributes with the inherited ones
+func (s *CategoryService) GetCategorySchema(slug string) (domain.CategorySchema, error) {
+	return getCategorySchema(s.repo, slug)
+}
+
+// UpdateCategory - rename, move or change the attributes, already saved products are not checked again
+func (s *CategoryService) UpdateCategory(slug string, input domain.CategoryInput) (domain.Category, error) {
+	category, err := s.repo.GetCategory(slug)
+	if err != nil {
+		return category, err
+	}
+
+	category.Parent = input.Parent
+	category.Name = input.Name
+	category.Attributes = input.Attributes
+	if err := category.Validate(); err != nil {
+		return category, err
+	}
+
+	if category.Parent != "" {
+		path, err := s.repo.GetCategoryPath(category.Parent)
+		if err != nil {
+			return category, fmt.Errorf("parent: %w", err)
+		}
+		for _, parent := range path {
+			if parent.Slug == category.Slug {
+				return category, domain.ErrCategoryCycle
+			}
+		}
+	}
+
+	return category, s.repo.UpdateCategory(category)
+}
+
+// DeleteCategory
+func (s *CategoryService) DeleteCategory(slug string) error {
+	return s.repo.DeleteCategory(slug)
+}
+
+// getCategorySchema
+func getCategorySchema(repo repository.Categorizer, slug string) (domain.CategorySchema, error) {
+	path, err := repo.GetCategoryPath(slug)
+	if err != nil {
+		return domain.CategorySchema{}, err
+	}
+	return domain.NewCategorySchema(path), nil
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/blob"
+	"github.com/p12s/furniture-store/product/internal/config"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/p12s/furniture-store/product/internal/imaging"
+	"github.com/p12s/furniture-store/product/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+var _ Imager = (*ImageService)(nil)
+
+// Imager - service interface
+type Imager interface {
+	AddProductImage(ctx context.Context, dealerPublicId string, publicId uuid.UUID, data []byte) (domain.Product, error)
+	DeleteProductImage(ctx context.Context, dealerPublicId string, publicId, imagePublicId uuid.UUID) (domain.Product, error)
+	OrderProductImages(dealerPublicId string, publicId uuid.UUID, order []uuid.UUID) (domain.Product, error)
+}
+
+// ImageService - images of the dealer products, every size is a blob.
+// The changed product is returned with all its images
+type ImageService struct {
+	repo     repository.Imager
+	products repository.Producter
+	store    blob.BlobStore
+	config   *config.Image
+}
+
+// NewImageService - constructor
+func NewImageService(repo repository.Imager, products repository.Producter, store blob.BlobStore,
+	config *config.Image) *ImageService {
+	return &ImageService{repo: repo, products: products, store: store, config: config}
+}
+
+// AddProductImage - the image goes last, its sizes are stored before the image is saved
+func (s *ImageService) AddProductImage(ctx context.Context, dealerPublicId string, publicId uuid.UUID,
+	data []byte) (domain.Product, error) {
+	if int64(len(data)) > s.config.MaxSize {
+		return domain.Product{}, domain.ErrImageTooLarge
+	}
+
+	product, err := getOwnProduct(s.products, dealerPublicId, publicId)
+	if err != nil {
+		return product, err
+	}
+	if product.IsVariant() {
+		return product, fmt.Errorf("%w: images are kept by the parent product", domain.ErrInvalidVariant)
+	}
+	if len(product.Images) >= domain.MAX_PRODUCT_IMAGES {
+		return product, domain.ErrTooManyImages
+	}
+
+	variants, err := imaging.Process(data)
+	if err != nil {
+		return product, err
+	}
+
+	image := domain.ProductImage{
+		PublicId:        uuid.New(),
+		ProductPublicId: product.PublicId,
+		ContentType:     variants[0].ContentType,
+		Width:           variants[0].Width,
+		Height:          variants[0].Height,
+		URLs:            domain.ImageMap{},
+		Keys:            domain.ImageMap{},
+		CreatedAt:       time.Now().UTC(),
+	}
+	for _, variant := range variants {
+		key := fmt.Sprintf("products/%s/%s/%s%s", product.PublicId, image.PublicId, variant.Size, variant.Extension)
+		if err := s.store.Put(ctx, key, variant.ContentType, variant.Data); err != nil {
+			deleteBlobs(ctx, s.store, image.Keys)
+			return product, fmt.Errorf("store %s image: %w", variant.Size, err)
+		}
+		image.Keys[variant.Size] = key
+		image.URLs[variant.Size] = s.store.URL(key)
+	}
+
+	if err := s.repo.AddImage(image); err != nil {
+		deleteBlobs(ctx, s.store, image.Keys)
+		return product, err
+	}
+	return s.products.GetProduct(publicId)
+}
+
+// DeleteProductImage - with all its sizes
+func (s *ImageService) DeleteProductImage(ctx context.Context, dealerPublicId string, publicId,
+	imagePublicId uuid.UUID) (domain.Product, error) {
+	product, err := getOwnProduct(s.products, dealerPublicId, publicId)
+	if err != nil {
+		return product, err
+	}
+
+	image, err := s.repo.DeleteImage(publicId, imagePublicId, time.Now().UTC())
+	if err != nil {
+		return product, err
+	}
+	deleteBlobs(ctx, s.store, image.Keys)
+
+	return s.products.GetProduct(publicId)
+}
+
+// OrderProductImages - the order lists every product image once, the first is the main one
+func (s *ImageService) OrderProductImages(dealerPublicId string, publicId uuid.UUID,
+	order []uuid.UUID) (domain.Product, error) {
+	product, err := getOwnProduct(s.products, dealerPublicId, publicId)
+	if err != nil {
+		return product, err
+	}
+
+	if len(order) != len(product.Images) {
+		return product, domain.ErrInvalidImageOrder
+	}
+	listed := make(map[uuid.UUID]bool, len(order))
+	for _, imagePublicId := range order {
+		listed[imagePublicId] = true
+	}
+	for _, image := range product.Images {
+		if !listed[image.PublicId] {
+			return product, domain.ErrInvalidImageOrder
+		}
+	}
+
+	if err := s.repo.OrderImages(publicId, order, time.Now().UTC()); err != nil {
+		return product, err
+	}
+	return s.products.GetProduct(publicId)
+}
+
+// deleteBlobs - a left blob only takes space, so the failure is logged, not returned
+func deleteBlobs(ctx context.Context, store blob.BlobStore, keys domain.ImageMap) {
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			logrus.Errorf("delete image blob %s fail: %s/n", key, err.Error())
+		}
+	}
+}
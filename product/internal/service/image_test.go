@@ -0,0 +1,108 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/blob/local"
+	"github.com/p12s/furniture-store/product/internal/config"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/product/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageService_AddProductImage(t *testing.T) {
+	dealer := uuid.New()
+	stored := domain.Product{PublicId: uuid.New(), DealerPublicId: dealer, Name: "Sofa", Images: []domain.ProductImage{}}
+	var upload bytes.Buffer
+	assert.NoError(t, png.Encode(&upload, image.NewNRGBA(image.Rect(0, 0, 300, 200))))
+
+	type mockBehavior func(r *mock_repository.MockImager, p *mock_repository.MockProducter)
+
+	tests := []struct {
+		name         string
+		data         []byte
+		mockBehavior mockBehavior
+		wantErr      error
+		wantBlobs    int
+	}{
+		{
+			name: "Can add image with all sizes",
+			data: upload.Bytes(),
+			mockBehavior: func(r *mock_repository.MockImager, p *mock_repository.MockProducter) {
+				p.EXPECT().GetProduct(stored.PublicId).Return(stored, nil)
+				r.EXPECT().AddImage(gomock.Any()).DoAndReturn(func(image domain.ProductImage) error {
+					assert.Equal(t, stored.PublicId, image.ProductPublicId)
+					assert.Equal(t, "image/png", image.ContentType)
+					assert.Equal(t, 300, image.Width)
+					assert.Len(t, image.Keys, len(domain.IMAGE_SIZES)+1)
+					assert.Equal(t, "http://images/"+image.Keys[domain.IMAGE_THUMB], image.URLs[domain.IMAGE_THUMB])
+					return nil
+				})
+				p.EXPECT().GetProduct(stored.PublicId).Return(stored, nil)
+			},
+			wantBlobs: len(domain.IMAGE_SIZES) + 1,
+		},
+		{
+			name: "Can't add image over the limit and keeps no blobs",
+			data: upload.Bytes(),
+			mockBehavior: func(r *mock_repository.MockImager, p *mock_repository.MockProducter) {
+				p.EXPECT().GetProduct(stored.PublicId).Return(stored, nil)
+				r.EXPECT().AddImage(gomock.Any()).Return(domain.ErrTooManyImages)
+			},
+			wantErr: domain.ErrTooManyImages,
+		},
+		{
+			name: "Can't add not an image",
+			data: []byte("%PDF-1.4"),
+			mockBehavior: func(r *mock_repository.MockImager, p *mock_repository.MockProducter) {
+				p.EXPECT().GetProduct(stored.PublicId).Return(stored, nil)
+			},
+			wantErr: domain.ErrInvalidImage,
+		},
+		{
+			name:         "Can't add too large image",
+			data:         make([]byte, 2<<20),
+			mockBehavior: func(r *mock_repository.MockImager, p *mock_repository.MockProducter) {},
+			wantErr:      domain.ErrImageTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := t.TempDir()
+			store, err := local.NewStore(dir, "http://images")
+			assert.NoError(t, err)
+			repo := mock_repository.NewMockImager(ctrl)
+			products := mock_repository.NewMockProducter(ctrl)
+			tt.mockBehavior(repo, products)
+
+			service := NewImageService(repo, products, store, &config.Image{MaxSize: 1 << 20})
+			_, err = service.AddProductImage(context.Background(), dealer.String(), stored.PublicId, tt.data)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			blobs := 0
+			assert.NoError(t, filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+				if err == nil && !info.IsDir() {
+					blobs++
+				}
+				return err
+			}))
+			assert.Equal(t, tt.wantBlobs, blobs)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/config"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/p12s/furniture-store/product/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// IMPORT_PROGRESS_STEP - rows between the saved job progress
+	IMPORT_PROGRESS_STEP = 20
+	// MAX_IMPORT_ERRORS - kept in the job and the report, all failed rows are counted
+	MAX_IMPORT_ERRORS = 100
+	// MAX_SKU_LENGTH
+	MAX_SKU_LENGTH = 64
+)
+
+var errInvalidRow = errors.New("invalid row")
+
+var _ Importer = (*ImportService)(nil)
+
+// ProductNotifier - sends the product event of the imported product
+type ProductNotifier func(eventType domain.EventType, product domain.Product)
+
+// Importer - service interface
+type Importer interface {
+	ValidateImport(dealerPublicId string, rows []domain.ImportRow) (domain.ImportReport, error)
+	StartImport(dealerPublicId string, rows []domain.ImportRow, notify ProductNotifier) (domain.ImportJob, error)
+	GetImportJob(dealerPublicId string, publicId uuid.UUID) (domain.ImportJob, error)
+	ExportProducts(dealerPublicId string) ([]domain.Product, error)
+}
+
+// ImportService - bulk upsert of the dealer products by sku. Every row is checked
+// like a single product, a row with errors is skipped and doesn't stop the import
+type ImportService struct {
+	repo       repository.Importer
+	products   repository.Producter
+	categories repository.Categorizer
+	stock      repository.Stocker
+	config     *config.Stock
+}
+
+// NewImportService - constructor
+func NewImportService(repo repository.Importer, products repository.Producter,
+	categories repository.Categorizer, stock repository.Stocker, config *config.Stock) *ImportService {
+	return &ImportService{repo: repo, products: products, categories: categories, stock: stock, config: config}
+}
+
+// ValidateImport - dry run, nothing is saved
+func (s *ImportService) ValidateImport(dealerPublicId string, rows []domain.ImportRow) (domain.ImportReport, error) {
+	report := domain.ImportReport{Rows: len(rows), Errors: domain.ImportErrors{}}
+	dealer, err := uuid.Parse(dealerPublicId)
+	if err != nil {
+		return report, err
+	}
+
+	seen := make(map[string]int, len(rows))
+	for _, row := range rows {
+		existing, err := s.checkRow(dealer, row, seen)
+		if isRowError(err) {
+			report.Errors = appendImportError(report.Errors, row, err)
+			continue
+		}
+		if err != nil {
+			return report, err
+		}
+
+		report.Valid++
+		if existing != nil {
+			report.Update++
+		} else {
+			report.Create++
+		}
+	}
+	return report, nil
+}
+
+// StartImport - the job runs in the background, its progress is polled by id
+func (s *ImportService) StartImport(dealerPublicId string, rows []domain.ImportRow,
+	notify ProductNotifier) (domain.ImportJob, error) {
+	dealer, err := uuid.Parse(dealerPublicId)
+	if err != nil {
+		return domain.ImportJob{}, err
+	}
+
+	now := time.Now().UTC()
+	job := domain.ImportJob{
+		PublicId:       uuid.New(),
+		DealerPublicId: dealer,
+		Status:         domain.IMPORT_QUEUED,
+		Total:          len(rows),
+		Errors:         domain.ImportErrors{},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.repo.CreateImportJob(job); err != nil {
+		return job, err
+	}
+
+	go s.run(job, rows, notify)
+	return job, nil
+}
+
+// GetImportJob - only own job
+func (s *ImportService) GetImportJob(dealerPublicId string, publicId uuid.UUID) (domain.ImportJob, error) {
+	job, err := s.repo.GetImportJob(publicId)
+	if err != nil {
+		return job, err
+	}
+	if job.DealerPublicId.String() != dealerPublicId {
+		return domain.ImportJob{}, domain.ErrImportJobNotFound
+	}
+	return job, nil
+}
+
+// ExportProducts - full dealer catalog
+func (s *ImportService) ExportProducts(dealerPublicId string) ([]domain.Product, error) {
+	dealer, err := uuid.Parse(dealerPublicId)
+	if err != nil {
+		return nil, err
+	}
+	return s.products.GetDealerProducts(dealer)
+}
+
+// run - rows one by one, the job fails only when the storage fails
+func (s *ImportService) run(job domain.ImportJob, rows []domain.ImportRow, notify ProductNotifier) {
+	job.Status = domain.IMPORT_RUNNING
+	s.saveJob(job)
+
+	seen := make(map[string]int, len(rows))
+	for _, row := range rows {
+		eventType, product, err := s.importRow(job.DealerPublicId, row, seen)
+		switch {
+		case isRowError(err):
+			job.Failed++
+			job.Errors = appendImportError(job.Errors, row, err)
+		case err != nil:
+			logrus.Errorf("import job %s row %d fail: %s/n", job.PublicId, row.Row, err.Error())
+			job.Status = domain.IMPORT_FAILED
+			job.Errors = appendImportError(job.Errors, row, errors.New("service failure"))
+			s.saveJob(job)
+			return
+		case eventType == domain.EVENT_PRODUCT_CREATED:
+			job.Created++
+			notify(eventType, product)
+		default:
+			job.Updated++
+			notify(eventType, product)
+		}
+
+		job.Processed++
+		if job.Processed%IMPORT_PROGRESS_STEP == 0 {
+			s.saveJob(job)
+		}
+	}
+
+	job.Status = domain.IMPORT_DONE
+	s.saveJob(job)
+}
+
+// importRow - creates the product or updates the dealer product with the same sku
+func (s *ImportService) importRow(dealer uuid.UUID, row domain.ImportRow,
+	seen map[string]int) (domain.EventType, domain.Product, error) {
+	existing, err := s.checkRow(dealer, row, seen)
+	if err != nil {
+		return "", domain.Product{}, err
+	}
+
+	now := time.Now().UTC()
+	if existing != nil {
+		product := *existing
+		err = updateProduct(s.products, s.stock, &product, row.Input, "import", now)
+		product.SyncVariants()
+		return domain.EVENT_PRODUCT_UPDATED, product, err
+	}
+
+	product := domain.Product{
+		PublicId:          uuid.New(),
+		DealerPublicId:    dealer,
+		LowStockThreshold: s.config.LowThreshold,
+		Images:            []domain.ProductImage{},
+		CreatedAt:         now,
+	}
+	applyProductInput(&product, row.Input, now)
+	return domain.EVENT_PRODUCT_CREATED, product, s.products.CreateProduct(product)
+}
+
+// checkRow - the stored dealer product with the row sku, nil when the product is new.
+// Seen are the rows by sku, the sku can't repeat in a file
+func (s *ImportService) checkRow(dealer uuid.UUID, row domain.ImportRow,
+	seen map[string]int) (*domain.Product, error) {
+	if row.Err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidRow, row.Err.Error())
+	}
+
+	sku := row.Input.Sku
+	switch {
+	case sku == "":
+		return nil, fmt.Errorf("%w: sku is required", errInvalidRow)
+	case len(sku) > MAX_SKU_LENGTH:
+		return nil, fmt.Errorf("%w: sku is longer than %d", errInvalidRow, MAX_SKU_LENGTH)
+	}
+	if previous, ok := seen[sku]; ok {
+		return nil, fmt.Errorf("%w: sku is repeated, the first is in row %d", errInvalidRow, previous)
+	}
+	seen[sku] = row.Row
+
+	if err := row.Input.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkAttributes(s.categories, row.Input.Category, row.Input.Attributes); err != nil {
+		return nil, err
+	}
+
+	product, err := s.products.GetProductBySku(dealer, sku)
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if product.IsVariant() {
+		return nil, fmt.Errorf("%w: sku belongs to a variant", errInvalidRow)
+	}
+	return &product, nil
+}
+
+// saveJob - a lost progress update is not a reason to stop the import
+func (s *ImportService) saveJob(job domain.ImportJob) {
+	job.UpdatedAt = time.Now().UTC()
+	if err := s.repo.UpdateImportJob(job); err != nil {
+		logrus.Errorf("save import job %s fail: %s/n", job.PublicId, err.Error())
+	}
+}
+
+// isRowError - the problem of the row data, not of the service
+func isRowError(err error) bool {
+	return errors.Is(err, errInvalidRow) || errors.Is(err, domain.ErrInvalidProduct) ||
+		errors.Is(err, domain.ErrInvalidAttribute) || errors.Is(err, domain.ErrCategoryNotFound) ||
+		errors.Is(err, domain.ErrInsufficientStock)
+}
+
+// appendImportError - the first errors only
+func appendImportError(errs domain.ImportErrors, row domain.ImportRow, err error) domain.ImportErrors {
+	if len(errs) >= MAX_IMPORT_ERRORS {
+		return errs
+	}
+	return append(errs, domain.ImportError{Row: row.Row, Sku: row.Input.Sku, Message: err.Error()})
+}
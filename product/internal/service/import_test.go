@@ -0,0 +1,130 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/config"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/product/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const testImportCSV = `sku,name,price,discount,quantity,category,attributes
+SF-1,Grey sofa,250000,10,3,sofas,"{""color"": ""grey""}"
+SF-2,White sofa,270000,0,1,sofas,"{""color"": ""white""}"
+SF-3,Pink sofa,abc,0,1,,
+,No sku,100,0,1,,
+SF-1,Repeated sofa,100,0,1,,
+SF-4,Broken sofa,100,0,1,sofas,"{""color"": ""pink""}"
+`
+
+func TestImportService(t *testing.T) {
+	dealer := uuid.New()
+	stored := domain.Product{PublicId: uuid.New(), DealerPublicId: dealer, Sku: "SF-2", Name: "Sofa"}
+	path := []domain.Category{{Slug: "sofas", Attributes: domain.AttributeDefinitions{
+		{Code: "color", Type: domain.ATTRIBUTE_ENUM, Values: []string{"grey", "white"}},
+	}}}
+
+	rows, err := domain.ParseImport(domain.FORMAT_CSV, []byte(testImportCSV))
+	assert.NoError(t, err)
+	assert.Len(t, rows, 6)
+
+	wantErrors := []int{4, 5, 6, 7}
+
+	mockBehavior := func(p *mock_repository.MockProducter, c *mock_repository.MockCategorizer) {
+		c.EXPECT().GetCategoryPath("sofas").Return(path, nil).Times(3)
+		p.EXPECT().GetProductBySku(dealer, "SF-1").Return(domain.Product{}, domain.ErrProductNotFound)
+		p.EXPECT().GetProductBySku(dealer, "SF-2").Return(stored, nil)
+	}
+
+	t.Run("Can validate import without saving", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		products := mock_repository.NewMockProducter(ctrl)
+		categories := mock_repository.NewMockCategorizer(ctrl)
+		mockBehavior(products, categories)
+
+		report, err := NewImportService(nil, products, categories, nil, nil).ValidateImport(dealer.String(), rows)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, report.Rows)
+		assert.Equal(t, 2, report.Valid)
+		assert.Equal(t, 1, report.Create)
+		assert.Equal(t, 1, report.Update)
+		assert.Equal(t, wantErrors, errorRows(report.Errors))
+	})
+
+	t.Run("Can import with upsert by sku", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockImporter(ctrl)
+		products := mock_repository.NewMockProducter(ctrl)
+		categories := mock_repository.NewMockCategorizer(ctrl)
+		mockBehavior(products, categories)
+		stock := mock_repository.NewMockStocker(ctrl)
+		products.EXPECT().CreateProduct(gomock.Any()).DoAndReturn(func(product domain.Product) error {
+			assert.Equal(t, "SF-1", product.Sku)
+			assert.Equal(t, int64(3), product.Quantity)
+			assert.Equal(t, int64(2), product.LowStockThreshold)
+			assert.Equal(t, dealer, product.DealerPublicId)
+			assert.Equal(t, int64(250000), product.Price)
+			return nil
+		})
+		stock.EXPECT().AddMovement(gomock.Any()).DoAndReturn(func(movement domain.StockMovement) (domain.StockMovement, domain.Stock, error) {
+			assert.Equal(t, stored.PublicId, movement.ProductPublicId)
+			assert.Equal(t, domain.MOVEMENT_ADJUSTED, movement.Type)
+			assert.Equal(t, int64(1), movement.Quantity)
+			movement.OnHand = 1
+			return movement, domain.Stock{}, nil
+		})
+		products.EXPECT().UpdateProduct(gomock.Any()).DoAndReturn(func(product domain.Product) error {
+			assert.Equal(t, stored.PublicId, product.PublicId)
+			assert.Equal(t, "White sofa", product.Name)
+			assert.Equal(t, int64(1), product.Quantity)
+			return nil
+		})
+
+		var saved domain.ImportJob
+		repo.EXPECT().UpdateImportJob(gomock.Any()).DoAndReturn(func(job domain.ImportJob) error {
+			saved = job
+			return nil
+		}).Times(2)
+
+		var events []domain.EventType
+		job := domain.ImportJob{PublicId: uuid.New(), DealerPublicId: dealer, Total: len(rows)}
+		NewImportService(repo, products, categories, stock, &config.Stock{LowThreshold: 2}).run(job, rows, func(eventType domain.EventType, product domain.Product) {
+			events = append(events, eventType)
+		})
+
+		assert.Equal(t, domain.IMPORT_DONE, saved.Status)
+		assert.Equal(t, 6, saved.Processed)
+		assert.Equal(t, 1, saved.Created)
+		assert.Equal(t, 1, saved.Updated)
+		assert.Equal(t, 4, saved.Failed)
+		assert.Equal(t, wantErrors, errorRows(saved.Errors))
+		assert.Equal(t, []domain.EventType{domain.EVENT_PRODUCT_CREATED, domain.EVENT_PRODUCT_UPDATED}, events)
+	})
+
+	t.Run("Can't get someone else's import job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockImporter(ctrl)
+		job := domain.ImportJob{PublicId: uuid.New(), DealerPublicId: dealer}
+		repo.EXPECT().GetImportJob(job.PublicId).Return(job, nil)
+
+		_, err := NewImportService(repo, nil, nil, nil, nil).GetImportJob(uuid.NewString(), job.PublicId)
+		assert.ErrorIs(t, err, domain.ErrImportJobNotFound)
+	})
+}
+
+func errorRows(errs domain.ImportErrors) []int {
+	rows := []int{}
+	for _, err := range errs {
+		rows = append(rows, err.Row)
+	}
+	return rows
+}
@@ -0,0 +1,882 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/p12s/furniture-store/product/internal/service (interfaces: Accounter,Producter,Searcher,Categorizer,Imager,Importer,Stocker,Pricer,Reviewer,Privacier)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	domain "github.com/p12s/furniture-store/product/internal/domain"
+	service "github.com/p12s/furniture-store/product/internal/service"
+)
+
+// MockAccounter is a mock of Accounter interface.
+type MockAccounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccounterMockRecorder
+}
+
+// MockAccounterMockRecorder is the mock recorder for MockAccounter.
+type MockAccounterMockRecorder struct {
+	mock *MockAccounter
+}
+
+// NewMockAccounter creates a new mock instance.
+func NewMockAccounter(ctrl *gomock.Controller) *MockAccounter {
+	mock := &MockAccounter{ctrl: ctrl}
+	mock.recorder = &MockAccounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccounter) EXPECT() *MockAccounterMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAccounter) CreateAPIKey(arg0 domain.APIKeyCreatedInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAccounterMockRecorder) CreateAPIKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAccounter)(nil).CreateAPIKey), arg0)
+}
+
+// CreateAccount mocks base method.
+func (m *MockAccounter) CreateAccount(arg0 domain.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockAccounterMockRecorder) CreateAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAccounter)(nil).CreateAccount), arg0)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccounter) DeleteAccount(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccounterMockRecorder) DeleteAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccounter)(nil).DeleteAccount), arg0)
+}
+
+// GetAccount mocks base method.
+func (m *MockAccounter) GetAccount(arg0 string) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccounterMockRecorder) GetAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccounter)(nil).GetAccount), arg0)
+}
+
+// ParseAPIKey mocks base method.
+func (m *MockAccounter) ParseAPIKey(arg0 string) (domain.APIKey, *domain.APIKeyUsedEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseAPIKey", arg0)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(*domain.APIKeyUsedEvent)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ParseAPIKey indicates an expected call of ParseAPIKey.
+func (mr *MockAccounterMockRecorder) ParseAPIKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseAPIKey", reflect.TypeOf((*MockAccounter)(nil).ParseAPIKey), arg0)
+}
+
+// ParseToken mocks base method.
+func (m *MockAccounter) ParseToken(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockAccounterMockRecorder) ParseToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAccounter)(nil).ParseToken), arg0)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAccounter) RevokeAPIKey(arg0 domain.APIKeyRevokedInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAccounterMockRecorder) RevokeAPIKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAccounter)(nil).RevokeAPIKey), arg0)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockAccounter) RevokeSessions(arg0 domain.SessionsRevokedInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAccounterMockRecorder) RevokeSessions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockAccounter)(nil).RevokeSessions), arg0)
+}
+
+// UpdateAccountInfo mocks base method.
+func (m *MockAccounter) UpdateAccountInfo(arg0 domain.UpdateAccountInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountInfo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountInfo indicates an expected call of UpdateAccountInfo.
+func (mr *MockAccounterMockRecorder) UpdateAccountInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountInfo", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountInfo), arg0)
+}
+
+// UpdateAccountRole mocks base method.
+func (m *MockAccounter) UpdateAccountRole(arg0 domain.UpdateAccountRoleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountRole", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountRole indicates an expected call of UpdateAccountRole.
+func (mr *MockAccounterMockRecorder) UpdateAccountRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountRole", reflect.TypeOf((*MockAccounter)(nil).UpdateAccountRole), arg0)
+}
+
+// MockProducter is a mock of Producter interface.
+type MockProducter struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducterMockRecorder
+}
+
+// MockProducterMockRecorder is the mock recorder for MockProducter.
+type MockProducterMockRecorder struct {
+	mock *MockProducter
+}
+
+// NewMockProducter creates a new mock instance.
+func NewMockProducter(ctrl *gomock.Controller) *MockProducter {
+	mock := &MockProducter{ctrl: ctrl}
+	mock.recorder = &MockProducterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducter) EXPECT() *MockProducterMockRecorder {
+	return m.recorder
+}
+
+// CreateProduct mocks base method.
+func (m *MockProducter) CreateProduct(arg0 string, arg1 domain.ProductInput) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", arg0, arg1)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockProducterMockRecorder) CreateProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockProducter)(nil).CreateProduct), arg0, arg1)
+}
+
+// CreateVariant mocks base method.
+func (m *MockProducter) CreateVariant(arg0 string, arg1 uuid.UUID, arg2 domain.VariantInput) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVariant indicates an expected call of CreateVariant.
+func (mr *MockProducterMockRecorder) CreateVariant(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockProducter)(nil).CreateVariant), arg0, arg1, arg2)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockProducter) DeleteProduct(arg0 context.Context, arg1 string, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockProducterMockRecorder) DeleteProduct(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProducter)(nil).DeleteProduct), arg0, arg1, arg2)
+}
+
+// GetProduct mocks base method.
+func (m *MockProducter) GetProduct(arg0 uuid.UUID) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", arg0)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockProducterMockRecorder) GetProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockProducter)(nil).GetProduct), arg0)
+}
+
+// SetProductAttributes mocks base method.
+func (m *MockProducter) SetProductAttributes(arg0 string, arg1 uuid.UUID, arg2 domain.ProductAttributesInput) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductAttributes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductAttributes indicates an expected call of SetProductAttributes.
+func (mr *MockProducterMockRecorder) SetProductAttributes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductAttributes", reflect.TypeOf((*MockProducter)(nil).SetProductAttributes), arg0, arg1, arg2)
+}
+
+// UpdateProduct mocks base method.
+func (m *MockProducter) UpdateProduct(arg0 string, arg1 uuid.UUID, arg2 domain.ProductInput) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *MockProducterMockRecorder) UpdateProduct(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockProducter)(nil).UpdateProduct), arg0, arg1, arg2)
+}
+
+// UpdateVariant mocks base method.
+func (m *MockProducter) UpdateVariant(arg0 string, arg1, arg2 uuid.UUID, arg3 domain.VariantInput) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVariant", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVariant indicates an expected call of UpdateVariant.
+func (mr *MockProducterMockRecorder) UpdateVariant(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVariant", reflect.TypeOf((*MockProducter)(nil).UpdateVariant), arg0, arg1, arg2, arg3)
+}
+
+// MockSearcher is a mock of Searcher interface.
+type MockSearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearcherMockRecorder
+}
+
+// MockSearcherMockRecorder is the mock recorder for MockSearcher.
+type MockSearcherMockRecorder struct {
+	mock *MockSearcher
+}
+
+// NewMockSearcher creates a new mock instance.
+func NewMockSearcher(ctrl *gomock.Controller) *MockSearcher {
+	mock := &MockSearcher{ctrl: ctrl}
+	mock.recorder = &MockSearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearcher) EXPECT() *MockSearcherMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearcher) Search(arg0 domain.SearchQuery) (domain.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0)
+	ret0, _ := ret[0].(domain.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearcherMockRecorder) Search(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearcher)(nil).Search), arg0)
+}
+
+// MockCategorizer is a mock of Categorizer interface.
+type MockCategorizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategorizerMockRecorder
+}
+
+// MockCategorizerMockRecorder is the mock recorder for MockCategorizer.
+type MockCategorizerMockRecorder struct {
+	mock *MockCategorizer
+}
+
+// NewMockCategorizer creates a new mock instance.
+func NewMockCategorizer(ctrl *gomock.Controller) *MockCategorizer {
+	mock := &MockCategorizer{ctrl: ctrl}
+	mock.recorder = &MockCategorizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategorizer) EXPECT() *MockCategorizerMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockCategorizer) CreateCategory(arg0 domain.CategoryInput) (domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0)
+	ret0, _ := ret[0].(domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCategorizerMockRecorder) CreateCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategorizer)(nil).CreateCategory), arg0)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategorizer) DeleteCategory(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategorizerMockRecorder) DeleteCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategorizer)(nil).DeleteCategory), arg0)
+}
+
+// GetCategorySchema mocks base method.
+func (m *MockCategorizer) GetCategorySchema(arg0 string) (domain.CategorySchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategorySchema", arg0)
+	ret0, _ := ret[0].(domain.CategorySchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategorySchema indicates an expected call of GetCategorySchema.
+func (mr *MockCategorizerMockRecorder) GetCategorySchema(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategorySchema", reflect.TypeOf((*MockCategorizer)(nil).GetCategorySchema), arg0)
+}
+
+// GetCategoryTree mocks base method.
+func (m *MockCategorizer) GetCategoryTree() ([]domain.CategoryNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryTree")
+	ret0, _ := ret[0].([]domain.CategoryNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryTree indicates an expected call of GetCategoryTree.
+func (mr *MockCategorizerMockRecorder) GetCategoryTree() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryTree", reflect.TypeOf((*MockCategorizer)(nil).GetCategoryTree))
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategorizer) UpdateCategory(arg0 string, arg1 domain.CategoryInput) (domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+	ret0, _ := ret[0].(domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategorizerMockRecorder) UpdateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategorizer)(nil).UpdateCategory), arg0, arg1)
+}
+
+// MockImager is a mock of Imager interface.
+type MockImager struct {
+	ctrl     *gomock.Controller
+	recorder *MockImagerMockRecorder
+}
+
+// MockImagerMockRecorder is the mock recorder for MockImager.
+type MockImagerMockRecorder struct {
+	mock *MockImager
+}
+
+// NewMockImager creates a new mock instance.
+func NewMockImager(ctrl *gomock.Controller) *MockImager {
+	mock := &MockImager{ctrl: ctrl}
+	mock.recorder = &MockImagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImager) EXPECT() *MockImagerMockRecorder {
+	return m.recorder
+}
+
+// AddProductImage mocks base method.
+func (m *MockImager) AddProductImage(arg0 context.Context, arg1 string, arg2 uuid.UUID, arg3 []byte) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProductImage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProductImage indicates an expected call of AddProductImage.
+func (mr *MockImagerMockRecorder) AddProductImage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductImage", reflect.TypeOf((*MockImager)(nil).AddProductImage), arg0, arg1, arg2, arg3)
+}
+
+// DeleteProductImage mocks base method.
+func (m *MockImager) DeleteProductImage(arg0 context.Context, arg1 string, arg2, arg3 uuid.UUID) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductImage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProductImage indicates an expected call of DeleteProductImage.
+func (mr *MockImagerMockRecorder) DeleteProductImage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductImage", reflect.TypeOf((*MockImager)(nil).DeleteProductImage), arg0, arg1, arg2, arg3)
+}
+
+// OrderProductImages mocks base method.
+func (m *MockImager) OrderProductImages(arg0 string, arg1 uuid.UUID, arg2 []uuid.UUID) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderProductImages", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderProductImages indicates an expected call of OrderProductImages.
+func (mr *MockImagerMockRecorder) OrderProductImages(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderProductImages", reflect.TypeOf((*MockImager)(nil).OrderProductImages), arg0, arg1, arg2)
+}
+
+// MockImporter is a mock of Importer interface.
+type MockImporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockImporterMockRecorder
+}
+
+// MockImporterMockRecorder is the mock recorder for MockImporter.
+type MockImporterMockRecorder struct {
+	mock *MockImporter
+}
+
+// NewMockImporter creates a new mock instance.
+func NewMockImporter(ctrl *gomock.Controller) *MockImporter {
+	mock := &MockImporter{ctrl: ctrl}
+	mock.recorder = &MockImporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImporter) EXPECT() *MockImporterMockRecorder {
+	return m.recorder
+}
+
+// ExportProducts mocks base method.
+func (m *MockImporter) ExportProducts(arg0 string) ([]domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProducts", arg0)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportProducts indicates an expected call of ExportProducts.
+func (mr *MockImporterMockRecorder) ExportProducts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProducts", reflect.TypeOf((*MockImporter)(nil).ExportProducts), arg0)
+}
+
+// GetImportJob mocks base method.
+func (m *MockImporter) GetImportJob(arg0 string, arg1 uuid.UUID) (domain.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", arg0, arg1)
+	ret0, _ := ret[0].(domain.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockImporterMockRecorder) GetImportJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockImporter)(nil).GetImportJob), arg0, arg1)
+}
+
+// StartImport mocks base method.
+func (m *MockImporter) StartImport(arg0 string, arg1 []domain.ImportRow, arg2 service.ProductNotifier) (domain.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImport indicates an expected call of StartImport.
+func (mr *MockImporterMockRecorder) StartImport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImport", reflect.TypeOf((*MockImporter)(nil).StartImport), arg0, arg1, arg2)
+}
+
+// ValidateImport mocks base method.
+func (m *MockImporter) ValidateImport(arg0 string, arg1 []domain.ImportRow) (domain.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateImport", arg0, arg1)
+	ret0, _ := ret[0].(domain.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateImport indicates an expected call of ValidateImport.
+func (mr *MockImporterMockRecorder) ValidateImport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateImport", reflect.TypeOf((*MockImporter)(nil).ValidateImport), arg0, arg1)
+}
+
+// MockStocker is a mock of Stocker interface.
+type MockStocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockerMockRecorder
+}
+
+// MockStockerMockRecorder is the mock recorder for MockStocker.
+type MockStockerMockRecorder struct {
+	mock *MockStocker
+}
+
+// NewMockStocker creates a new mock instance.
+func NewMockStocker(ctrl *gomock.Controller) *MockStocker {
+	mock := &MockStocker{ctrl: ctrl}
+	mock.recorder = &MockStockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStocker) EXPECT() *MockStockerMockRecorder {
+	return m.recorder
+}
+
+// AddStockMovement mocks base method.
+func (m *MockStocker) AddStockMovement(arg0 string, arg1 uuid.UUID, arg2 domain.StockMovementInput) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStockMovement", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStockMovement indicates an expected call of AddStockMovement.
+func (mr *MockStockerMockRecorder) AddStockMovement(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStockMovement", reflect.TypeOf((*MockStocker)(nil).AddStockMovement), arg0, arg1, arg2)
+}
+
+// GetStockDashboard mocks base method.
+func (m *MockStocker) GetStockDashboard(arg0 string, arg1 bool) (domain.StockDashboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockDashboard", arg0, arg1)
+	ret0, _ := ret[0].(domain.StockDashboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockDashboard indicates an expected call of GetStockDashboard.
+func (mr *MockStockerMockRecorder) GetStockDashboard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockDashboard", reflect.TypeOf((*MockStocker)(nil).GetStockDashboard), arg0, arg1)
+}
+
+// GetStockHistory mocks base method.
+func (m *MockStocker) GetStockHistory(arg0 string, arg1 uuid.UUID, arg2 int64, arg3 int) (domain.StockHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.StockHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockHistory indicates an expected call of GetStockHistory.
+func (mr *MockStockerMockRecorder) GetStockHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockHistory", reflect.TypeOf((*MockStocker)(nil).GetStockHistory), arg0, arg1, arg2, arg3)
+}
+
+// SetLowStockThreshold mocks base method.
+func (m *MockStocker) SetLowStockThreshold(arg0 string, arg1 uuid.UUID, arg2 domain.StockThresholdInput) (domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLowStockThreshold", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLowStockThreshold indicates an expected call of SetLowStockThreshold.
+func (mr *MockStockerMockRecorder) SetLowStockThreshold(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLowStockThreshold", reflect.TypeOf((*MockStocker)(nil).SetLowStockThreshold), arg0, arg1, arg2)
+}
+
+// MockPricer is a mock of Pricer interface.
+type MockPricer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPricerMockRecorder
+}
+
+// MockPricerMockRecorder is the mock recorder for MockPricer.
+type MockPricerMockRecorder struct {
+	mock *MockPricer
+}
+
+// NewMockPricer creates a new mock instance.
+func NewMockPricer(ctrl *gomock.Controller) *MockPricer {
+	mock := &MockPricer{ctrl: ctrl}
+	mock.recorder = &MockPricerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPricer) EXPECT() *MockPricerMockRecorder {
+	return m.recorder
+}
+
+// GetPriceHistory mocks base method.
+func (m *MockPricer) GetPriceHistory(arg0 uuid.UUID, arg1 time.Time, arg2 int64, arg3 int) (domain.PriceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.PriceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceHistory indicates an expected call of GetPriceHistory.
+func (mr *MockPricerMockRecorder) GetPriceHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceHistory", reflect.TypeOf((*MockPricer)(nil).GetPriceHistory), arg0, arg1, arg2, arg3)
+}
+
+// MockReviewer is a mock of Reviewer interface.
+type MockReviewer struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewerMockRecorder
+}
+
+// MockReviewerMockRecorder is the mock recorder for MockReviewer.
+type MockReviewerMockRecorder struct {
+	mock *MockReviewer
+}
+
+// NewMockReviewer creates a new mock instance.
+func NewMockReviewer(ctrl *gomock.Controller) *MockReviewer {
+	mock := &MockReviewer{ctrl: ctrl}
+	mock.recorder = &MockReviewerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewer) EXPECT() *MockReviewerMockRecorder {
+	return m.recorder
+}
+
+// CreateReview mocks base method.
+func (m *MockReviewer) CreateReview(arg0 string, arg1 uuid.UUID, arg2 domain.ReviewInput) (domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockReviewerMockRecorder) CreateReview(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockReviewer)(nil).CreateReview), arg0, arg1, arg2)
+}
+
+// GetProductReviews mocks base method.
+func (m *MockReviewer) GetProductReviews(arg0 uuid.UUID, arg1 int64, arg2 int) (domain.ReviewPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductReviews", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.ReviewPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductReviews indicates an expected call of GetProductReviews.
+func (mr *MockReviewerMockRecorder) GetProductReviews(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductReviews", reflect.TypeOf((*MockReviewer)(nil).GetProductReviews), arg0, arg1, arg2)
+}
+
+// GetReviews mocks base method.
+func (m *MockReviewer) GetReviews(arg0 domain.ReviewStatus, arg1 int64, arg2 int) (domain.ReviewPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviews", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.ReviewPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviews indicates an expected call of GetReviews.
+func (mr *MockReviewerMockRecorder) GetReviews(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviews", reflect.TypeOf((*MockReviewer)(nil).GetReviews), arg0, arg1, arg2)
+}
+
+// ModerateReview mocks base method.
+func (m *MockReviewer) ModerateReview(arg0 string, arg1 uuid.UUID, arg2 domain.ModerationInput) (domain.Review, *domain.ReviewedEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateReview", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Review)
+	ret1, _ := ret[1].(*domain.ReviewedEvent)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ModerateReview indicates an expected call of ModerateReview.
+func (mr *MockReviewerMockRecorder) ModerateReview(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateReview", reflect.TypeOf((*MockReviewer)(nil).ModerateReview), arg0, arg1, arg2)
+}
+
+// OrderDelivered mocks base method.
+func (m *MockReviewer) OrderDelivered(arg0 domain.DeliveredOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderDelivered", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrderDelivered indicates an expected call of OrderDelivered.
+func (mr *MockReviewerMockRecorder) OrderDelivered(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderDelivered", reflect.TypeOf((*MockReviewer)(nil).OrderDelivered), arg0)
+}
+
+// OrderLines mocks base method.
+func (m *MockReviewer) OrderLines(arg0 domain.OrderLinesInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderLines", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrderLines indicates an expected call of OrderLines.
+func (mr *MockReviewerMockRecorder) OrderLines(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderLines", reflect.TypeOf((*MockReviewer)(nil).OrderLines), arg0)
+}
+
+// MockPrivacier is a mock of Privacier interface.
+type MockPrivacier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacierMockRecorder
+}
+
+// MockPrivacierMockRecorder is the mock recorder for MockPrivacier.
+type MockPrivacierMockRecorder struct {
+	mock *MockPrivacier
+}
+
+// NewMockPrivacier creates a new mock instance.
+func NewMockPrivacier(ctrl *gomock.Controller) *MockPrivacier {
+	mock := &MockPrivacier{ctrl: ctrl}
+	mock.recorder = &MockPrivacierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacier) EXPECT() *MockPrivacierMockRecorder {
+	return m.recorder
+}
+
+// EraseAccountData mocks base method.
+func (m *MockPrivacier) EraseAccountData(arg0 domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseAccountData", arg0)
+	ret0, _ := ret[0].(domain.PrivacyAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseAccountData indicates an expected call of EraseAccountData.
+func (mr *MockPrivacierMockRecorder) EraseAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseAccountData", reflect.TypeOf((*MockPrivacier)(nil).EraseAccountData), arg0)
+}
+
+// ExportAccountData mocks base method.
+func (m *MockPrivacier) ExportAccountData(arg0 domain.PrivacyRequestedInput) (domain.PrivacyAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccountData", arg0)
+	ret0, _ := ret[0].(domain.PrivacyAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccountData indicates an expected call of ExportAccountData.
+func (mr *MockPrivacierMockRecorder) ExportAccountData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccountData", reflect.TypeOf((*MockPrivacier)(nil).ExportAccountData), arg0)
+}
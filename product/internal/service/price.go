@@ -0,0 +1,54 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/p12s/furniture-store/product/internal/repository"
+)
+
+var _ Pricer = (*PriceService)(nil)
+
+// Pricer - service interface
+type Pricer interface {
+	GetPriceHistory(publicId uuid.UUID, at time.Time, before int64, limit int) (domain.PriceHistory, error)
+}
+
+// PriceService - public product price history, so the price of an order time can be checked
+type PriceService struct {
+	repo     repository.Pricer
+	products repository.Producter
+}
+
+// NewPriceService - constructor
+func NewPriceService(repo repository.Pricer, products repository.Producter) *PriceService {
+	return &PriceService{repo: repo, products: products}
+}
+
+// GetPriceHistory - changes from the newest, with at the first one is the price effective at that time
+func (s *PriceService) GetPriceHistory(publicId uuid.UUID, at time.Time, before int64,
+	limit int) (domain.PriceHistory, error) {
+	if _, err := s.products.GetProduct(publicId); err != nil {
+		return domain.PriceHistory{}, err
+	}
+
+	if limit <= 0 {
+		limit = domain.PRICE_HISTORY_DEFAULT_LIMIT
+	}
+	if limit > domain.PRICE_HISTORY_MAX_LIMIT {
+		limit = domain.PRICE_HISTORY_MAX_LIMIT
+	}
+	// one more to know if there is the next page
+	changes, err := s.repo.GetPriceHistory(publicId, at.UTC(), before, limit+1)
+	if err != nil {
+		return domain.PriceHistory{}, err
+	}
+
+	history := domain.PriceHistory{ProductPublicId: publicId, Changes: changes}
+	if len(changes) > limit {
+		history.Changes = changes[:limit]
+		history.Next = changes[limit-1].Id
+	}
+	return history, nil
+}
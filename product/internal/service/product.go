@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/blob"
+	"github.com/p12s/furniture-store/product/internal/config"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/p12s/furniture-store/product/internal/repository"
+)
+
+var _ Producter = (*ProductService)(nil)
+
+// Producter - service interface
+type Producter interface {
+	CreateProduct(dealerPublicId string, input domain.ProductInput) (domain.Product, error)
+	GetProduct(publicId uuid.UUID) (domain.Product, error)
+	UpdateProduct(dealerPublicId string, publicId uuid.UUID, input domain.ProductInput) (domain.Product, error)
+	DeleteProduct(ctx context.Context, dealerPublicId string, publicId uuid.UUID) error
+	SetProductAttributes(dealerPublicId string, publicId uuid.UUID, input domain.ProductAttributesInput) (domain.Product, error)
+	CreateVariant(dealerPublicId string, parentPublicId uuid.UUID, input domain.VariantInput) (domain.Product, error)
+	UpdateVariant(dealerPublicId string, parentPublicId, publicId uuid.UUID, input domain.VariantInput) (domain.Product, error)
+}
+
+// ProductService - dealer products, a dealer can change only own ones.
+// Product attributes are checked by the category schema, a quantity change is a stock movement
+type ProductService struct {
+	repo       repository.Producter
+	categories repository.Categorizer
+	stock      repository.Stocker
+	store      blob.BlobStore
+	config     *config.Stock
+}
+
+// NewProductService - constructor, the store keeps product images
+func NewProductService(repo repository.Producter, categories repository.Categorizer, stock repository.Stocker,
+	store blob.BlobStore, config *config.Stock) *ProductService {
+	return &ProductService{repo: repo, categories: categories, stock: stock, store: store, config: config}
+}
+
+// CreateProduct
+func (s *ProductService) CreateProduct(dealerPublicId string, input domain.ProductInput) (domain.Product, error) {
+	dealer, err := uuid.Parse(dealerPublicId)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	if err := s.checkAttributes(input.Category, input.Attributes); err != nil {
+		return domain.Product{}, err
+	}
+	if err := checkSku(s.repo, dealer, input.Sku, uuid.Nil); err != nil {
+		return domain.Product{}, err
+	}
+
+	now := time.Now().UTC()
+	product := domain.Product{
+		PublicId:          uuid.New(),
+		DealerPublicId:    dealer,
+		LowStockThreshold: s.config.LowThreshold,
+		CreatedAt:         now,
+	}
+	applyProductInput(&product, input, now)
+
+	return product, s.repo.CreateProduct(product)
+}
+
+// GetProduct
+func (s *ProductService) GetProduct(publicId uuid.UUID) (domain.Product, error) {
+	return s.repo.GetProduct(publicId)
+}
+
+// UpdateProduct - the variants get the parent changes
+func (s *ProductService) UpdateProduct(dealerPublicId string, publicId uuid.UUID,
+	input domain.ProductInput) (domain.Product, error) {
+	product, err := s.getOwnProduct(dealerPublicId, publicId)
+	if err != nil {
+		return product, err
+	}
+	if product.IsVariant() {
+		return product, fmt.Errorf("%w: a variant is changed with its parent", domain.ErrInvalidVariant)
+	}
+	if err := s.checkAttributes(input.Category, input.Attributes); err != nil {
+		return product, err
+	}
+	if err := checkSku(s.repo, product.DealerPublicId, input.Sku, product.PublicId); err != nil {
+		return product, err
+	}
+
+	err = updateProduct(s.repo, s.stock, &product, input, "product update", time.Now().UTC())
+	product.SyncVariants()
+	return product, err
+}
+
+// SetProductAttributes - moves the product to the category with the attribute values
+func (s *ProductService) SetProductAttributes(dealerPublicId string, publicId uuid.UUID,
+	input domain.ProductAttributesInput) (domain.Product, error) {
+	product, err := s.getOwnProduct(dealerPublicId, publicId)
+	if err != nil {
+		return product, err
+	}
+	if product.IsVariant() {
+		return product, fmt.Errorf("%w: a variant has the parent category", domain.ErrInvalidVariant)
+	}
+	if err := s.checkAttributes(input.Category, input.Attributes); err != nil {
+		return product, err
+	}
+
+	product.Category = input.Category
+	product.Attributes = input.Attributes
+	product.UpdatedAt = time.Now().UTC()
+	err = s.repo.UpdateProduct(product)
+	product.SyncVariants()
+	return product, err
+}
+
+// CreateVariant - the variant attributes are checked by the parent category schema
+func (s *ProductService) CreateVariant(dealerPublicId string, parentPublicId uuid.UUID,
+	input domain.VariantInput) (domain.Product, error) {
+	parent, err := s.getOwnProduct(dealerPublicId, parentPublicId)
+	if err != nil {
+		return parent, err
+	}
+	if parent.IsVariant() {
+		return domain.Product{}, fmt.Errorf("%w: a variant can't have variants", domain.ErrInvalidVariant)
+	}
+	if err := s.checkAttributes(parent.Category, input.Attributes); err != nil {
+		return domain.Product{}, err
+	}
+	if err := checkSku(s.repo, parent.DealerPublicId, input.Sku, uuid.Nil); err != nil {
+		return domain.Product{}, err
+	}
+
+	now := time.Now().UTC()
+	variant := parent.NewVariant(uuid.New())
+	variant.ApplyVariantInput(parent, input)
+	variant.Quantity = input.Quantity
+	variant.LowStockThreshold = s.config.LowThreshold
+	variant.CreatedAt = now
+	variant.UpdatedAt = now
+
+	return variant, s.repo.CreateProduct(variant)
+}
+
+// UpdateVariant - the quantity difference is recorded as the stock adjustment
+func (s *ProductService) UpdateVariant(dealerPublicId string, parentPublicId, publicId uuid.UUID,
+	input domain.VariantInput) (domain.Product, error) {
+	variant, err := s.getOwnProduct(dealerPublicId, publicId)
+	if err != nil {
+		return variant, err
+	}
+	if variant.ParentPublicId.UUID != parentPublicId {
+		return domain.Product{}, domain.ErrProductNotFound
+	}
+	parent, err := s.repo.GetProduct(parentPublicId)
+	if err != nil {
+		return variant, err
+	}
+	if err := s.checkAttributes(parent.Category, input.Attributes); err != nil {
+		return variant, err
+	}
+	if err := checkSku(s.repo, variant.DealerPublicId, input.Sku, variant.PublicId); err != nil {
+		return variant, err
+	}
+
+	now := time.Now().UTC()
+	variant.ApplyVariantInput(parent, input)
+	variant.UpdatedAt = now
+	if delta := input.Quantity - variant.Quantity; delta != 0 {
+		err := addMovement(s.stock, &variant, domain.MOVEMENT_ADJUSTED, delta, variant.DealerPublicId,
+			"variant update", now)
+		if err != nil {
+			return variant, err
+		}
+	}
+
+	return variant, s.repo.UpdateProduct(variant)
+}
+
+// DeleteProduct - with images and variants
+func (s *ProductService) DeleteProduct(ctx context.Context, dealerPublicId string, publicId uuid.UUID) error {
+	product, err := s.getOwnProduct(dealerPublicId, publicId)
+	if err != nil {
+		return err
+	}
+	if err = s.repo.DeleteProduct(publicId); err != nil {
+		return err
+	}
+
+	for _, image := range product.Images {
+		deleteBlobs(ctx, s.store, image.Keys)
+	}
+	return nil
+}
+
+// getOwnProduct - product of the dealer
+func (s *ProductService) getOwnProduct(dealerPublicId string, publicId uuid.UUID) (domain.Product, error) {
+	return getOwnProduct(s.repo, dealerPublicId, publicId)
+}
+
+// getOwnProduct - product of the dealer
+func getOwnProduct(repo repository.Producter, dealerPublicId string, publicId uuid.UUID) (domain.Product, error) {
+	product, err := repo.GetProduct(publicId)
+	if err != nil {
+		return product, err
+	}
+	if product.DealerPublicId.String() != dealerPublicId {
+		return domain.Product{}, domain.ErrProductAccessDenied
+	}
+	return product, nil
+}
+
+// checkAttributes
+func (s *ProductService) checkAttributes(category string, attributes domain.Attributes) error {
+	return checkAttributes(s.categories, category, attributes)
+}
+
+// checkAttributes - a product without category has no attributes
+func checkAttributes(categories repository.Categorizer, category string, attributes domain.Attributes) error {
+	if category == "" {
+		if len(attributes) > 0 {
+			return fmt.Errorf("%w: product without category can't have attributes", domain.ErrInvalidAttribute)
+		}
+		return nil
+	}
+
+	schema, err := getCategorySchema(categories, category)
+	if err != nil {
+		return err
+	}
+	return schema.Validate(attributes)
+}
+
+// checkSku - the sku is unique among the dealer products, publicId is the changed product
+func checkSku(repo repository.Producter, dealer uuid.UUID, sku string, publicId uuid.UUID) error {
+	if sku == "" {
+		return nil
+	}
+
+	product, err := repo.GetProductBySku(dealer, sku)
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if product.PublicId != publicId {
+		return domain.ErrSkuExists
+	}
+	return nil
+}
+
+// updateProduct - the quantity difference is recorded as the stock adjustment before the product is saved
+func updateProduct(repo repository.Producter, stock repository.Stocker, product *domain.Product,
+	input domain.ProductInput, comment string, now time.Time) error {
+	onHand := product.Quantity
+	applyProductInput(product, input, now)
+	product.Quantity = onHand
+
+	if delta := input.Quantity - onHand; delta != 0 {
+		err := addMovement(stock, product, domain.MOVEMENT_ADJUSTED, delta, product.DealerPublicId, comment, now)
+		if err != nil {
+			return err
+		}
+	}
+	return repo.UpdateProduct(*product)
+}
+
+// applyProductInput
+func applyProductInput(product *domain.Product, input domain.ProductInput, now time.Time) {
+	product.Sku = input.Sku
+	product.Name = input.Name
+	product.Description = input.Description
+	product.Category = input.Category
+	product.Price = input.Price
+	product.Discount = input.Discount
+	product.Quantity = input.Quantity
+	product.Attributes = input.Attributes
+	product.UpdatedAt = now
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/config"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/product/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductService_UpdateProduct(t *testing.T) {
+	dealer := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+	stored := domain.Product{PublicId: uuid.New(), DealerPublicId: dealer, Name: "Sofa", Price: 1000}
+	input := domain.ProductInput{Name: "Grey sofa", Category: "sofas", Price: 2000, Discount: 10, Quantity: 3,
+		Attributes: domain.Attributes{"color": "grey", "width": float64(210)}}
+	path := []domain.Category{
+		{Slug: "furniture", Attributes: domain.AttributeDefinitions{
+			{Code: "color", Type: domain.ATTRIBUTE_ENUM, Values: []string{"grey", "white"}, Required: true},
+		}},
+		{Slug: "sofas", Parent: "furniture", Attributes: domain.AttributeDefinitions{
+			{Code: "width", Type: domain.ATTRIBUTE_NUMBER, Unit: "cm"},
+		}},
+	}
+
+	type mockBehavior func(r *mock_repository.MockProducter, c *mock_repository.MockCategorizer, s *mock_repository.MockStocker)
+
+	tests := []struct {
+		name         string
+		dealer       string
+		input        domain.ProductInput
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name:   "Can update own product",
+			dealer: dealer.String(),
+			input:  input,
+			mockBehavior: func(r *mock_repository.MockProducter, c *mock_repository.MockCategorizer, s *mock_repository.MockStocker) {
+				r.EXPECT().GetProduct(stored.PublicId).Return(stored, nil)
+				c.EXPECT().GetCategoryPath("sofas").Return(path, nil)
+				s.EXPECT().AddMovement(gomock.Any()).DoAndReturn(func(movement domain.StockMovement) (domain.StockMovement, domain.Stock, error) {
+					assert.Equal(t, domain.MOVEMENT_ADJUSTED, movement.Type)
+					assert.Equal(t, int64(3), movement.Quantity)
+					assert.Equal(t, dealer, movement.ActorPublicId)
+					movement.OnHand = 3
+					return movement, domain.Stock{}, nil
+				})
+				r.EXPECT().UpdateProduct(gomock.Any()).DoAndReturn(func(product domain.Product) error {
+					assert.Equal(t, dealer, product.DealerPublicId)
+					assert.Equal(t, "Grey sofa", product.Name)
+					assert.Equal(t, int64(2000), product.Price)
+					assert.Equal(t, int64(3), product.Quantity)
+					assert.Equal(t, input.Attributes, product.Attributes)
+					return nil
+				})
+			},
+		},
+		{
+			name:   "Can't update product with stock under reserved",
+			dealer: dealer.String(),
+			input:  domain.ProductInput{Name: "Grey sofa"},
+			mockBehavior: func(r *mock_repository.MockProducter, c *mock_repository.MockCategorizer, s *mock_repository.MockStocker) {
+				reserved := stored
+				reserved.Quantity, reserved.Reserved = 2, 2
+				r.EXPECT().GetProduct(stored.PublicId).Return(reserved, nil)
+				s.EXPECT().AddMovement(gomock.Any()).Return(domain.StockMovement{}, reserved.Stock(), domain.ErrInsufficientStock)
+			},
+			wantErr: domain.ErrInsufficientStock,
+		},
+		{
+			name:   "Can't update product with attribute of another category",
+			dealer: dealer.String(),
+			input: domain.ProductInput{Name: "Grey sofa", Category: "sofas",
+				Attributes: domain.Attributes{"color": "grey", "legs": float64(4)}},
+			mockBehavior: func(r *mock_repository.MockProducter, c *mock_repository.MockCategorizer, s *mock_repository.MockStocker) {
+				r.EXPECT().GetProduct(stored.PublicId).Return(stored, nil)
+				c.EXPECT().GetCategoryPath("sofas").Return(path, nil)
+			},
+			wantErr: domain.ErrInvalidAttribute,
+		},
+		{
+			name:   "Can't update product without required attribute",
+			dealer: dealer.String(),
+			input:  domain.ProductInput{Name: "Grey sofa", Category: "sofas", Attributes: domain.Attributes{"width": float64(210)}},
+			mockBehavior: func(r *mock_repository.MockProducter, c *mock_repository.MockCategorizer, s *mock_repository.MockStocker) {
+				r.EXPECT().GetProduct(stored.PublicId).Return(stored, nil)
+				c.EXPECT().GetCategoryPath("sofas").Return(path, nil)
+			},
+			wantErr: domain.ErrInvalidAttribute,
+		},
+		{
+			name:   "Can't update product with unknown category",
+			dealer: dealer.String(),
+			input:  domain.ProductInput{Name: "Grey sofa", Category: "beds"},
+			mockBehavior: func(r *mock_repository.MockProducter, c *mock_repository.MockCategorizer, s *mock_repository.MockStocker) {
+				r.EXPECT().GetProduct(stored.PublicId).Return(stored, nil)
+				c.EXPECT().GetCategoryPath("beds").Return(nil, domain.ErrCategoryNotFound)
+			},
+			wantErr: domain.ErrCategoryNotFound,
+		},
+		{
+			name:   "Can't update someone else's product",
+			dealer: uuid.NewString(),
+			input:  input,
+			mockBehavior: func(r *mock_repository.MockProducter, c *mock_repository.MockCategorizer, s *mock_repository.MockStocker) {
+				r.EXPECT().GetProduct(stored.PublicId).Return(stored, nil)
+			},
+			wantErr: domain.ErrProductAccessDenied,
+		},
+		{
+			name:   "Can't update unknown product",
+			dealer: dealer.String(),
+			input:  input,
+			mockBehavior: func(r *mock_repository.MockProducter, c *mock_repository.MockCategorizer, s *mock_repository.MockStocker) {
+				r.EXPECT().GetProduct(stored.PublicId).Return(domain.Product{}, domain.ErrProductNotFound)
+			},
+			wantErr: domain.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockProducter(ctrl)
+			categories := mock_repository.NewMockCategorizer(ctrl)
+			stock := mock_repository.NewMockStocker(ctrl)
+			tt.mockBehavior(repo, categories, stock)
+
+			_, err := NewProductService(repo, categories, stock, nil, nil).UpdateProduct(tt.dealer, stored.PublicId, tt.input)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestProductService_CreateVariant(t *testing.T) {
+	dealer := uuid.New()
+	parent := domain.Product{PublicId: uuid.New(), DealerPublicId: dealer, Name: "Sofa", Price: 1000, Discount: 5}
+	variant := parent.NewVariant(uuid.New())
+
+	tests := []struct {
+		name         string
+		parent       domain.Product
+		input        domain.VariantInput
+		mockBehavior func(r *mock_repository.MockProducter)
+		wantPrice    int64
+		wantErr      error
+	}{
+		{
+			name:   "Can create variant with parent price",
+			parent: parent,
+			input:  domain.VariantInput{Sku: "SF-1-GREY", Quantity: 2},
+			mockBehavior: func(r *mock_repository.MockProducter) {
+				r.EXPECT().GetProductBySku(dealer, "SF-1-GREY").Return(domain.Product{}, domain.ErrProductNotFound)
+				r.EXPECT().CreateProduct(gomock.Any()).DoAndReturn(func(product domain.Product) error {
+					assert.Equal(t, parent.PublicId, product.ParentPublicId.UUID)
+					assert.Equal(t, "Sofa", product.Name)
+					assert.Equal(t, int64(5), product.Discount)
+					assert.Equal(t, int64(2), product.Quantity)
+					assert.Equal(t, int64(3), product.LowStockThreshold)
+					assert.False(t, product.PriceOverride)
+					return nil
+				})
+			},
+			wantPrice: 1000,
+		},
+		{
+			name:   "Can create variant with own price",
+			parent: parent,
+			input:  domain.VariantInput{Sku: "SF-1-LEATHER", Price: 1500},
+			mockBehavior: func(r *mock_repository.MockProducter) {
+				r.EXPECT().GetProductBySku(dealer, "SF-1-LEATHER").Return(domain.Product{}, domain.ErrProductNotFound)
+				r.EXPECT().CreateProduct(gomock.Any()).Return(nil)
+			},
+			wantPrice: 1500,
+		},
+		{
+			name:         "Can't create variant of variant",
+			parent:       variant,
+			input:        domain.VariantInput{Sku: "SF-1-GREY-XL"},
+			mockBehavior: func(r *mock_repository.MockProducter) {},
+			wantErr:      domain.ErrInvalidVariant,
+		},
+		{
+			name:   "Can't create variant with taken sku",
+			parent: parent,
+			input:  domain.VariantInput{Sku: "SF-1"},
+			mockBehavior: func(r *mock_repository.MockProducter) {
+				r.EXPECT().GetProductBySku(dealer, "SF-1").Return(parent, nil)
+			},
+			wantErr: domain.ErrSkuExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockProducter(ctrl)
+			repo.EXPECT().GetProduct(tt.parent.PublicId).Return(tt.parent, nil)
+			tt.mockBehavior(repo)
+
+			product, err := NewProductService(repo, nil, nil, nil, &config.Stock{LowThreshold: 3}).
+				CreateVariant(dealer.String(), tt.parent.PublicId, tt.input)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPrice, product.Price)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/p12s/furniture-store/product/internal/repository"
+)
+
+var _ Reviewer = (*ReviewService)(nil)
+
+// Reviewer - service interface
+type Reviewer interface {
+	OrderLines(input domain.OrderLinesInput) error
+	OrderDelivered(order domain.DeliveredOrder) error
+	CreateReview(accountPublicId string, productPublicId uuid.UUID, input domain.ReviewInput) (domain.Review, error)
+	GetProductReviews(productPublicId uuid.UUID, before int64, limit int) (domain.ReviewPage, error)
+	GetReviews(status domain.ReviewStatus, before int64, limit int) (domain.ReviewPage, error)
+	ModerateReview(moderatorPublicId string, publicId uuid.UUID, input domain.ModerationInput) (domain.Review, *domain.ReviewedEvent, error)
+}
+
+// ReviewService - a customer reviews a product once, by an order with the product delivered to the customer
+type ReviewService struct {
+	repo     repository.Reviewer
+	products repository.Producter
+}
+
+// NewReviewService - constructor
+func NewReviewService(repo repository.Reviewer, products repository.Producter) *ReviewService {
+	return &ReviewService{repo: repo, products: products}
+}
+
+// OrderLines - the lines of the checked out or payed order
+func (s *ReviewService) OrderLines(input domain.OrderLinesInput) error {
+	return s.repo.AddOrderLines(input.PublicId, input.Items)
+}
+
+// OrderDelivered - Order.Delivered makes the customer a reviewer
+func (s *ReviewService) OrderDelivered(order domain.DeliveredOrder) error {
+	return s.repo.AddDeliveredOrder(order, time.Now().UTC())
+}
+
+// CreateReview - waits for the moderation
+func (s *ReviewService) CreateReview(accountPublicId string, productPublicId uuid.UUID,
+	input domain.ReviewInput) (domain.Review, error) {
+	account, err := uuid.Parse(accountPublicId)
+	if err != nil {
+		return domain.Review{}, err
+	}
+
+	product, err := s.products.GetProduct(productPublicId)
+	if err != nil {
+		return domain.Review{}, err
+	}
+	if product.IsVariant() {
+		productPublicId = product.ParentPublicId.UUID
+	}
+
+	delivered, err := s.repo.IsProductDelivered(input.OrderPublicId, account, productPublicId)
+	if err != nil {
+		return domain.Review{}, err
+	}
+	if !delivered {
+		return domain.Review{}, domain.ErrReviewNotAllowed
+	}
+
+	now := time.Now().UTC()
+	review := domain.Review{
+		PublicId:        uuid.New(),
+		ProductPublicId: productPublicId,
+		AccountPublicId: account,
+		OrderPublicId:   input.OrderPublicId,
+		Rating:          input.Rating,
+		Text:            input.Text,
+		Status:          domain.REVIEW_PENDING,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err = s.repo.CreateReview(review); err != nil {
+		return domain.Review{}, err
+	}
+	return s.repo.GetReview(review.PublicId)
+}
+
+// GetProductReviews - approved only
+func (s *ReviewService) GetProductReviews(productPublicId uuid.UUID, before int64,
+	limit int) (domain.ReviewPage, error) {
+	if _, err := s.products.GetProduct(productPublicId); err != nil {
+		return domain.ReviewPage{}, err
+	}
+	return s.getReviews(productPublicId, domain.REVIEW_APPROVED, before, limit)
+}
+
+// GetReviews - moderation queue, pending by default
+func (s *ReviewService) GetReviews(status domain.ReviewStatus, before int64, limit int) (domain.ReviewPage, error) {
+	if status == "" {
+		status = domain.REVIEW_PENDING
+	}
+	return s.getReviews(uuid.Nil, status, before, limit)
+}
+
+// ModerateReview - the event is returned when the review becomes public
+func (s *ReviewService) ModerateReview(moderatorPublicId string, publicId uuid.UUID,
+	input domain.ModerationInput) (domain.Review, *domain.ReviewedEvent, error) {
+	if input.Status != domain.REVIEW_APPROVED && input.Status != domain.REVIEW_REJECTED {
+		return domain.Review{}, nil, domain.ErrInvalidModeration
+	}
+	moderator, err := uuid.Parse(moderatorPublicId)
+	if err != nil {
+		return domain.Review{}, nil, err
+	}
+
+	review, err := s.repo.GetReview(publicId)
+	if err != nil {
+		return review, nil, err
+	}
+	approved := review.Status != domain.REVIEW_APPROVED && input.Status == domain.REVIEW_APPROVED
+
+	review.Status = input.Status
+	review.ModeratorPublicId = uuid.NullUUID{UUID: moderator, Valid: true}
+	review.ModerationComment = input.Comment
+	review.UpdatedAt = time.Now().UTC()
+	rating, err := s.repo.ModerateReview(review)
+	if err != nil || !approved {
+		return review, nil, err
+	}
+
+	product, err := s.products.GetProduct(review.ProductPublicId)
+	if err != nil {
+		return review, nil, err
+	}
+	return review, &domain.ReviewedEvent{
+		ReviewPublicId:  review.PublicId,
+		ProductPublicId: review.ProductPublicId,
+		DealerPublicId:  product.DealerPublicId,
+		AccountPublicId: review.AccountPublicId,
+		Rating:          review.Rating,
+		ProductRating:   rating,
+	}, nil
+}
+
+// getReviews - one page
+func (s *ReviewService) getReviews(productPublicId uuid.UUID, status domain.ReviewStatus, before int64,
+	limit int) (domain.ReviewPage, error) {
+	if limit <= 0 {
+		limit = domain.REVIEWS_DEFAULT_LIMIT
+	}
+	if limit > domain.REVIEWS_MAX_LIMIT {
+		limit = domain.REVIEWS_MAX_LIMIT
+	}
+	// one more to know if there is the next page
+	reviews, err := s.repo.GetReviews(productPublicId, status, before, limit+1)
+	if err != nil {
+		return domain.ReviewPage{}, err
+	}
+
+	page := domain.ReviewPage{Reviews: reviews}
+	if len(reviews) > limit {
+		page.Reviews = reviews[:limit]
+		page.Next = reviews[limit-1].Id
+	}
+	return page, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/product/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewService(t *testing.T) {
+	customer := uuid.New()
+	parent := domain.Product{PublicId: uuid.New(), DealerPublicId: uuid.New(), Name: "Sofa"}
+	variant := parent.NewVariant(uuid.New())
+	input := domain.ReviewInput{OrderPublicId: uuid.New(), Rating: 4, Text: "Soft"}
+
+	t.Run("Can review parent of delivered variant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockReviewer(ctrl)
+		products := mock_repository.NewMockProducter(ctrl)
+		products.EXPECT().GetProduct(variant.PublicId).Return(variant, nil)
+		repo.EXPECT().IsProductDelivered(input.OrderPublicId, customer, parent.PublicId).Return(true, nil)
+		repo.EXPECT().CreateReview(gomock.Any()).DoAndReturn(func(review domain.Review) error {
+			assert.Equal(t, parent.PublicId, review.ProductPublicId)
+			assert.Equal(t, domain.REVIEW_PENDING, review.Status)
+			return nil
+		})
+		repo.EXPECT().GetReview(gomock.Any()).Return(domain.Review{Status: domain.REVIEW_PENDING}, nil)
+
+		review, err := NewReviewService(repo, products).CreateReview(customer.String(), variant.PublicId, input)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.REVIEW_PENDING, review.Status)
+	})
+
+	t.Run("Can't review the product not in the delivered order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockReviewer(ctrl)
+		products := mock_repository.NewMockProducter(ctrl)
+		products.EXPECT().GetProduct(parent.PublicId).Return(parent, nil)
+		repo.EXPECT().IsProductDelivered(input.OrderPublicId, customer, parent.PublicId).Return(false, nil)
+
+		_, err := NewReviewService(repo, products).CreateReview(customer.String(), parent.PublicId, input)
+		assert.ErrorIs(t, err, domain.ErrReviewNotAllowed)
+	})
+
+	t.Run("Can send event on first approval only", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		pending := domain.Review{PublicId: uuid.New(), ProductPublicId: parent.PublicId, AccountPublicId: customer,
+			Rating: 4, Status: domain.REVIEW_PENDING}
+		approved := pending
+		approved.Status = domain.REVIEW_APPROVED
+		rating := domain.Rating{Average: 4, Count: 1}
+
+		repo := mock_repository.NewMockReviewer(ctrl)
+		products := mock_repository.NewMockProducter(ctrl)
+		repo.EXPECT().GetReview(pending.PublicId).Return(pending, nil)
+		repo.EXPECT().GetReview(pending.PublicId).Return(approved, nil)
+		repo.EXPECT().ModerateReview(gomock.Any()).Return(rating, nil).Times(2)
+		products.EXPECT().GetProduct(parent.PublicId).Return(parent, nil)
+
+		service := NewReviewService(repo, products)
+		moderation := domain.ModerationInput{Status: domain.REVIEW_APPROVED}
+		review, event, err := service.ModerateReview(uuid.NewString(), pending.PublicId, moderation)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.REVIEW_APPROVED, review.Status)
+		assert.Equal(t, parent.DealerPublicId, event.DealerPublicId)
+		assert.Equal(t, rating, event.ProductRating)
+
+		_, event, err = service.ModerateReview(uuid.NewString(), pending.PublicId, moderation)
+		assert.NoError(t, err)
+		assert.Nil(t, event)
+
+		_, _, err = service.ModerateReview(uuid.NewString(), pending.PublicId, domain.ModerationInput{Status: domain.REVIEW_PENDING})
+		assert.ErrorIs(t, err, domain.ErrInvalidModeration)
+	})
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/p12s/furniture-store/product/internal/repository"
+)
+
+var _ Searcher = (*SearchService)(nil)
+
+// Searcher - service interface
+type Searcher interface {
+	Search(query domain.SearchQuery) (domain.SearchResult, error)
+}
+
+// SearchService - catalog search
+type SearchService struct {
+	repo repository.Searcher
+}
+
+// NewSearchService - constructor
+func NewSearchService(repo repository.Searcher) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// Search - page of the products with the facets of the whole result
+func (s *SearchService) Search(query domain.SearchQuery) (domain.SearchResult, error) {
+	var result domain.SearchResult
+	if err := query.Validate(); err != nil {
+		return result, err
+	}
+
+	items, next, err := s.repo.SearchProducts(query)
+	if err != nil {
+		return result, err
+	}
+	result.Items = items
+	if next != nil {
+		result.NextCursor = next.Encode()
+	}
+
+	result.Facets, err = s.repo.GetSearchFacets(query)
+	return result, err
+}
@@ -3,20 +3,40 @@ package service
 import (
 	_ "github.com/golang/mock/mockgen/model"
 
+	"github.com/p12s/furniture-store/product/internal/blob"
 	"github.com/p12s/furniture-store/product/internal/config"
 	"github.com/p12s/furniture-store/product/internal/repository"
 )
 
-//go:generate mockgen -destination mocks/mock.go -package service github.com/p12s/furniture-store/product/internal/service Accounter
+//go:generate mockgen -destination mocks/mock.go -package service github.com/p12s/furniture-store/product/internal/service Accounter,Producter,Searcher,Categorizer,Imager,Importer,Stocker,Pricer,Reviewer,Privacier
 
 // Service - just service
 type Service struct {
 	Accounter
+	Producter
+	Searcher
+	Categorizer
+	Imager
+	Importer
+	Stocker
+	Pricer
+	Reviewer
+	Privacier
 }
 
 // NewService - constructor
-func NewService(repos *repository.Repository, config *config.Auth) *Service {
+func NewService(repos *repository.Repository, store blob.BlobStore,
+	auth *config.Auth, image *config.Image, stock *config.Stock) *Service {
 	return &Service{
-		Accounter: NewAccountService(repos.Accounter, config),
+		Accounter:   NewAccountService(repos.Accounter, repos.APIKeyer, auth),
+		Producter:   NewProductService(repos.Producter, repos.Categorizer, repos.Stocker, store, stock),
+		Searcher:    NewSearchService(repos.Searcher),
+		Categorizer: NewCategoryService(repos.Categorizer),
+		Imager:      NewImageService(repos.Imager, repos.Producter, store, image),
+		Importer:    NewImportService(repos.Importer, repos.Producter, repos.Categorizer, repos.Stocker, stock),
+		Stocker:     NewStockService(repos.Stocker, repos.Producter),
+		Pricer:      NewPriceService(repos.Pricer, repos.Producter),
+		Reviewer:    NewReviewService(repos.Reviewer, repos.Producter),
+		Privacier:   NewPrivacyService(repos.Privacier),
 	}
 }
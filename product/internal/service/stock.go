@@ -0,0 +1,142 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/p12s/furniture-store/product/internal/repository"
+)
+
+var _ Stocker = (*StockService)(nil)
+
+// Stocker - service interface
+type Stocker interface {
+	AddStockMovement(dealerPublicId string, publicId uuid.UUID, input domain.StockMovementInput) (domain.Product, error)
+	GetStockDashboard(dealerPublicId string, lowOnly bool) (domain.StockDashboard, error)
+	GetStockHistory(dealerPublicId string, publicId uuid.UUID, before int64, limit int) (domain.StockHistory, error)
+	SetLowStockThreshold(dealerPublicId string, publicId uuid.UUID, input domain.StockThresholdInput) (domain.Product, error)
+}
+
+// StockService - dealer warehouse, the stock of own products is changed by movements only.
+// The changed product has LowStockReached set when the available stock goes down to its threshold
+type StockService struct {
+	repo     repository.Stocker
+	products repository.Producter
+}
+
+// NewStockService - constructor
+func NewStockService(repo repository.Stocker, products repository.Producter) *StockService {
+	return &StockService{repo: repo, products: products}
+}
+
+// AddStockMovement
+func (s *StockService) AddStockMovement(dealerPublicId string, publicId uuid.UUID,
+	input domain.StockMovementInput) (domain.Product, error) {
+	product, err := getOwnProduct(s.products, dealerPublicId, publicId)
+	if err != nil {
+		return product, err
+	}
+
+	err = addMovement(s.repo, &product, input.Type, input.Quantity, product.DealerPublicId, input.Comment,
+		time.Now().UTC())
+	return product, err
+}
+
+// GetStockDashboard - totals are over all the dealer products, low only filters the items
+func (s *StockService) GetStockDashboard(dealerPublicId string, lowOnly bool) (domain.StockDashboard, error) {
+	dashboard := domain.StockDashboard{Items: []domain.StockLevel{}}
+	dealer, err := uuid.Parse(dealerPublicId)
+	if err != nil {
+		return dashboard, err
+	}
+
+	levels, err := s.repo.GetStockLevels(dealer, false)
+	if err != nil {
+		return dashboard, err
+	}
+	for _, level := range levels {
+		dashboard.Products++
+		dashboard.OnHand += level.OnHand
+		dashboard.Reserved += level.Reserved
+		dashboard.Available += level.Available
+		if level.LowStock {
+			dashboard.LowStock++
+		}
+		if !lowOnly || level.LowStock {
+			dashboard.Items = append(dashboard.Items, level)
+		}
+	}
+	return dashboard, nil
+}
+
+// GetStockHistory - current stock of own product with a page of its movements
+func (s *StockService) GetStockHistory(dealerPublicId string, publicId uuid.UUID, before int64,
+	limit int) (domain.StockHistory, error) {
+	product, err := getOwnProduct(s.products, dealerPublicId, publicId)
+	if err != nil {
+		return domain.StockHistory{}, err
+	}
+
+	if limit <= 0 {
+		limit = domain.STOCK_HISTORY_DEFAULT_LIMIT
+	}
+	if limit > domain.STOCK_HISTORY_MAX_LIMIT {
+		limit = domain.STOCK_HISTORY_MAX_LIMIT
+	}
+	// one more to know if there is the next page
+	movements, err := s.repo.GetMovements(publicId, before, limit+1)
+	if err != nil {
+		return domain.StockHistory{}, err
+	}
+
+	history := domain.StockHistory{StockLevel: product.StockLevel(), Movements: movements}
+	if len(movements) > limit {
+		history.Movements = movements[:limit]
+		history.Next = movements[limit-1].Id
+	}
+	return history, nil
+}
+
+// SetLowStockThreshold - the event is sent when the new threshold is reached at once
+func (s *StockService) SetLowStockThreshold(dealerPublicId string, publicId uuid.UUID,
+	input domain.StockThresholdInput) (domain.Product, error) {
+	product, err := getOwnProduct(s.products, dealerPublicId, publicId)
+	if err != nil {
+		return product, err
+	}
+
+	now := time.Now().UTC()
+	if err = s.repo.SetLowStockThreshold(publicId, input.LowStockThreshold, now); err != nil {
+		return product, err
+	}
+
+	wasLow := product.StockLevel().LowStock
+	product.LowStockThreshold = input.LowStockThreshold
+	product.LowStockReached = !wasLow && product.StockLevel().LowStock
+	product.UpdatedAt = now
+	return product, nil
+}
+
+// addMovement - records the movement and updates the product stock
+func addMovement(repo repository.Stocker, product *domain.Product, movementType domain.MovementType,
+	quantity int64, actor uuid.UUID, comment string, now time.Time) error {
+	movement, before, err := repo.AddMovement(domain.StockMovement{
+		ProductPublicId: product.PublicId,
+		Type:            movementType,
+		Quantity:        quantity,
+		ActorPublicId:   actor,
+		Comment:         comment,
+		CreatedAt:       now,
+	})
+	if err != nil {
+		return err
+	}
+
+	product.Quantity = movement.OnHand
+	product.Reserved = movement.Reserved
+	product.UpdatedAt = now
+	product.LowStockReached = domain.LowStockReached(product.LowStockThreshold, before.Available(),
+		product.Stock().Available())
+	return nil
+}
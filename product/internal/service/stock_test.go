@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	mock_repository "github.com/p12s/furniture-store/product/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStockService(t *testing.T) {
+	dealer := uuid.New()
+	stored := domain.Product{PublicId: uuid.New(), DealerPublicId: dealer, Name: "Sofa", Quantity: 5, Reserved: 1,
+		LowStockThreshold: 3}
+
+	t.Run("Can reach low stock by movement", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockStocker(ctrl)
+		products := mock_repository.NewMockProducter(ctrl)
+		products.EXPECT().GetProduct(stored.PublicId).Return(stored, nil).Times(2)
+		repo.EXPECT().AddMovement(gomock.Any()).DoAndReturn(func(movement domain.StockMovement) (domain.StockMovement, domain.Stock, error) {
+			after, err := stored.Stock().Apply(movement.Type, movement.Quantity)
+			movement.OnHand, movement.Reserved = after.OnHand, after.Reserved
+			return movement, stored.Stock(), err
+		}).Times(2)
+
+		product, err := NewStockService(repo, products).AddStockMovement(dealer.String(), stored.PublicId,
+			domain.StockMovementInput{Type: domain.MOVEMENT_SOLD, Quantity: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Stock{OnHand: 4}, product.Stock())
+		assert.False(t, product.LowStockReached)
+
+		product, err = NewStockService(repo, products).AddStockMovement(dealer.String(), stored.PublicId,
+			domain.StockMovementInput{Type: domain.MOVEMENT_RESERVED, Quantity: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), product.Stock().Available())
+		assert.True(t, product.LowStockReached)
+	})
+
+	t.Run("Can't change someone else's stock", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		products := mock_repository.NewMockProducter(ctrl)
+		products.EXPECT().GetProduct(stored.PublicId).Return(stored, nil)
+
+		_, err := NewStockService(nil, products).AddStockMovement(uuid.NewString(), stored.PublicId,
+			domain.StockMovementInput{Type: domain.MOVEMENT_RECEIVED, Quantity: 1})
+		assert.ErrorIs(t, err, domain.ErrProductAccessDenied)
+	})
+
+	t.Run("Can get dashboard with totals", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockStocker(ctrl)
+		repo.EXPECT().GetStockLevels(dealer, false).Return([]domain.StockLevel{
+			{Name: "Sofa", OnHand: 5, Reserved: 1, Available: 4},
+			{Name: "Chair", OnHand: 1, Available: 1, LowStockThreshold: 2, LowStock: true},
+		}, nil)
+
+		dashboard, err := NewStockService(repo, nil).GetStockDashboard(dealer.String(), true)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), dashboard.Products)
+		assert.Equal(t, int64(6), dashboard.OnHand)
+		assert.Equal(t, int64(5), dashboard.Available)
+		assert.Equal(t, int64(1), dashboard.LowStock)
+		assert.Len(t, dashboard.Items, 1)
+		assert.Equal(t, "Chair", dashboard.Items[0].Name)
+	})
+
+	t.Run("Can page history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockStocker(ctrl)
+		products := mock_repository.NewMockProducter(ctrl)
+		products.EXPECT().GetProduct(stored.PublicId).Return(stored, nil)
+		repo.EXPECT().GetMovements(stored.PublicId, int64(0), 3).Return([]domain.StockMovement{{Id: 9}, {Id: 8}, {Id: 7}}, nil)
+
+		history, err := NewStockService(repo, products).GetStockHistory(dealer.String(), stored.PublicId, 0, 2)
+		assert.NoError(t, err)
+		assert.Len(t, history.Movements, 2)
+		assert.Equal(t, int64(8), history.Next)
+		assert.Equal(t, int64(4), history.Available)
+	})
+}
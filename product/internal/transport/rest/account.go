@@ -8,50 +8,47 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func (h *Handler) updateAccount(c *gin.Context) { // nolint
-	var input domain.UpdateAccountInput
-
+// @Summary Delete account
+// @Tags Account
+// @Description Delete the account copy, own or by the admin. Dealer products are kept
+// @ID deleteAccount
+// @Accept  json
+// @Produce  json
+// @Param input body domain.DeleteAccountInput true "account public_id"
+// @Success 200 {object} map[string]interface{}
+// @Router /account/ [delete]
+func (h *Handler) deleteAccount(c *gin.Context) {
+	var input domain.DeleteAccountInput
 	if err := c.BindJSON(&input); err != nil {
 		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
 		return
 	}
 
-	err := h.services.UpdateAccountInfo(input)
+	accountPublicId, err := getAccountPublicId(c)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newErrorResponse(c, http.StatusUnauthorized, "account public id not found")
 		return
 	}
-
-	go func() {
-		err := h.broker.Produce(domain.EVENT_ACCOUNT_INFO_UPDATED, "h.broker.TopicAccountBE", input)
-		if err != nil {
-			logrus.Errorf("sent update account event fail: %s/n", err.Error())
-		}
-	}()
-
-	c.JSON(http.StatusOK, map[string]interface{}{
-		"message": "OK",
-	})
-}
-
-func (h *Handler) deleteAccount(c *gin.Context) {
-	// TODO надо проверять что это 1) либо свой акк - свой могу удалить всегда 2) либо есть роль админа
-	// иначе - нет прав
-	var input domain.DeleteAccountInput
-
-	if err := c.BindJSON(&input); err != nil {
-		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+	if _, ok := getAPIKey(c); ok {
+		newErrorResponse(c, http.StatusForbidden, "access denied")
 		return
 	}
+	if input.PublicId != accountPublicId {
+		account, err := h.services.GetAccount(accountPublicId)
+		if err != nil || account.Role != domain.ROLE_ADMIN {
+			newErrorResponse(c, http.StatusForbidden, "access denied")
+			return
+		}
+	}
 
-	err := h.services.DeleteAccount(input.PublicId)
+	err = h.services.DeleteAccount(input.PublicId)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
 		return
 	}
 
 	go func() {
-		err := h.broker.Produce(domain.EVENT_ACCOUNT_DELETED, "h.broker.TopicAccountCUD", input)
+		err := h.broker.Produce(domain.EVENT_ACCOUNT_DELETED, h.broker.TopicAccountCUD, input)
 		if err != nil {
 			logrus.Errorf("sent delete account event fail: %s/n", err.Error())
 		}
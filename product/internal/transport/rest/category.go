@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+// @Summary Category tree
+// @Tags Category
+// @Description Root categories with subcategories
+// @ID getCategoryTree
+// @Produce  json
+// @Success 200 {array} domain.CategoryNode
+// @Router /categories [get]
+func (h *Handler) getCategoryTree(c *gin.Context) {
+	tree, err := h.services.GetCategoryTree()
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// @Summary Category schema
+// @Tags Category
+// @Description Category path from the root and its attributes with the inherited ones
+// @ID getCategorySchema
+// @Produce  json
+// @Param slug path string true "category slug"
+// @Success 200 {object} domain.CategorySchema
+// @Router /categories/{slug} [get]
+func (h *Handler) getCategorySchema(c *gin.Context) {
+	schema, err := h.services.GetCategorySchema(c.Param("slug"))
+	if !checkCategoryError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, schema)
+}
+
+// @Summary Create category
+// @Tags Category
+// @Description Attribute types are enum (with values), number (with unit) and text
+// @ID createCategory
+// @Accept  json
+// @Produce  json
+// @Param input body domain.CategoryInput true "category data"
+// @Success 201 {object} domain.Category
+// @Router /categories [post]
+func (h *Handler) createCategory(c *gin.Context) {
+	var input domain.CategoryInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	category, err := h.services.CreateCategory(input)
+	if !checkCategoryError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// @Summary Update category
+// @Tags Category
+// @Description Rename, move or change the attributes, the slug is not changed
+// @ID updateCategory
+// @Accept  json
+// @Produce  json
+// @Param slug path string true "category slug"
+// @Param input body domain.CategoryInput true "category data"
+// @Success 200 {object} domain.Category
+// @Router /categories/{slug} [put]
+func (h *Handler) updateCategory(c *gin.Context) {
+	var input domain.CategoryInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	category, err := h.services.UpdateCategory(c.Param("slug"), input)
+	if !checkCategoryError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// @Summary Delete category
+// @Tags Category
+// @Description Only a category without subcategories and products
+// @ID deleteCategory
+// @Param slug path string true "category slug"
+// @Success 200
+// @Router /categories/{slug} [delete]
+func (h *Handler) deleteCategory(c *gin.Context) {
+	err := h.services.DeleteCategory(c.Param("slug"))
+	if !checkCategoryError(c, err) {
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// checkCategoryError - false when the error is sent
+func checkCategoryError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrCategoryNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return false
+	case errors.Is(err, domain.ErrCategoryExists), errors.Is(err, domain.ErrCategoryInUse):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return false
+	case errors.Is(err, domain.ErrCategoryCycle), errors.Is(err, domain.ErrInvalidCategory):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
@@ -5,44 +5,97 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/p12s/furniture-store/product/internal/broker"
+	"github.com/p12s/furniture-store/product/internal/domain"
 	"github.com/p12s/furniture-store/product/internal/service"
 )
 
+// Handler
 type Handler struct {
 	services *service.Service
 	broker   *broker.Broker
 }
 
+// NewHandler - constructor
 func NewHandler(services *service.Service, broker *broker.Broker) *Handler {
 	return &Handler{services: services, broker: broker}
 }
 
+// InitRoutes - routes
 func (h *Handler) InitRoutes() *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(CORSMiddleware())
 
 	router.GET("/health", h.health)
-	router.POST("/sign-up", h.signUp)
-	router.POST("/sign-in", h.signIn)
 
 	account := router.Group("/account", h.userIdentity)
 	{
-		// account.GET("/", h.getAccountInfo)
-		// account.PUT("/info", h.updateAccount)
-		// account.PUT("/role", h.updateAccountRole)
 		account.DELETE("/", h.deleteAccount)
 	}
 
+	products := router.Group("/products")
+	{
+		products.GET("/search", h.searchProducts)
+		products.GET("/:id", h.getProduct)
+		products.GET("/:id/price-history", h.getPriceHistory)
+		products.GET("/:id/reviews", h.getProductReviews)
+		products.POST("/:id/reviews", h.userIdentity, h.roleIdentity(domain.ROLE_CUSTOMER), h.createReview)
+
+		dealer := products.Group("", h.userIdentity, h.roleIdentity(domain.ROLE_DEALER))
+		{
+			productsRead := h.scopeIdentity(domain.API_KEY_SCOPE_PRODUCTS_READ)
+			productsWrite := h.scopeIdentity(domain.API_KEY_SCOPE_PRODUCTS_WRITE)
+			stockRead := h.scopeIdentity(domain.API_KEY_SCOPE_STOCK_READ)
+			stockWrite := h.scopeIdentity(domain.API_KEY_SCOPE_STOCK_WRITE)
+
+			dealer.POST("", productsWrite, h.createProduct)
+			dealer.POST("/import", productsWrite, h.importProducts)
+			dealer.GET("/import/:job_id", productsRead, h.getImportJob)
+			dealer.GET("/export", productsRead, h.exportProducts)
+			dealer.GET("/stock", stockRead, h.getStockDashboard)
+			dealer.PUT("/:id", productsWrite, h.updateProduct)
+			dealer.DELETE("/:id", productsWrite, h.deleteProduct)
+			dealer.PUT("/:id/attributes", productsWrite, h.setProductAttributes)
+			dealer.POST("/:id/variants", productsWrite, h.createVariant)
+			dealer.PUT("/:id/variants/:variant_id", productsWrite, h.updateVariant)
+			dealer.POST("/:id/images", productsWrite, h.addProductImage)
+			dealer.PUT("/:id/images/order", productsWrite, h.orderProductImages)
+			dealer.DELETE("/:id/images/:image_id", productsWrite, h.deleteProductImage)
+			dealer.GET("/:id/stock", stockRead, h.getStockHistory)
+			dealer.POST("/:id/stock/movements", stockWrite, h.addStockMovement)
+			dealer.PUT("/:id/stock/threshold", stockWrite, h.setLowStockThreshold)
+		}
+	}
+
+	reviews := router.Group("/reviews", h.userIdentity, h.roleIdentity(domain.ROLE_ADMIN))
+	{
+		reviews.GET("", h.getReviews)
+		reviews.PUT("/:id/moderation", h.moderateReview)
+	}
+
+	categories := router.Group("/categories")
+	{
+		categories.GET("", h.getCategoryTree)
+		categories.GET("/:slug", h.getCategorySchema)
+
+		admin := categories.Group("", h.userIdentity, h.roleIdentity(domain.ROLE_ADMIN))
+		{
+			admin.POST("", h.createCategory)
+			admin.PUT("/:slug", h.updateCategory)
+			admin.DELETE("/:slug", h.deleteCategory)
+		}
+	}
+
 	return router
 }
 
+// CORSMiddleware - cross site work
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Credentials", "true")
 		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Header("Access-Control-Allow-Methods", "POST,HEAD,PATCH,OPTIONS,GET,PUT")
+		c.Header("Access-Control-Allow-Methods", "POST,HEAD,PATCH,OPTIONS,GET,PUT,DELETE")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
 			return
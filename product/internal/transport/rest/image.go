@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+const (
+	IMAGE_FORM_FIELD = "image"
+
+	// MAX_UPLOAD_BODY - request limit, the image size itself is checked by the service
+	MAX_UPLOAD_BODY = 32 << 20 // 32 MB
+)
+
+// @Summary Add product image
+// @Tags Image
+// @Description Jpeg, png or gif of own product, the type is taken from the data.
+// @Description Thumb, medium and large jpeg sizes are generated, the new image goes last
+// @ID addProductImage
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Param image formData file true "image file"
+// @Success 201 {object} domain.Product
+// @Router /products/{id}/images [post]
+func (h *Handler) addProductImage(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MAX_UPLOAD_BODY)
+	header, err := c.FormFile(IMAGE_FORM_FIELD)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "image file is required")
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid image file")
+		return
+	}
+	defer file.Close()
+
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid image file")
+		return
+	}
+
+	product, err := h.services.AddProductImage(c.Request.Context(), accountPublicId, publicId, data)
+	if !checkImageError(c, err) {
+		return
+	}
+
+	h.produceProductEvent(domain.EVENT_PRODUCT_UPDATED, product)
+	c.JSON(http.StatusCreated, product)
+}
+
+// @Summary Delete product image
+// @Tags Image
+// @Description Image of own product with all its sizes
+// @ID deleteProductImage
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Param image_id path string true "image public_id"
+// @Success 200 {object} domain.Product
+// @Router /products/{id}/images/{image_id} [delete]
+func (h *Handler) deleteProductImage(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+	imagePublicId, err := uuid.Parse(c.Param("image_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid image public id")
+		return
+	}
+
+	product, err := h.services.DeleteProductImage(c.Request.Context(), accountPublicId, publicId, imagePublicId)
+	if !checkImageError(c, err) {
+		return
+	}
+
+	h.produceProductEvent(domain.EVENT_PRODUCT_UPDATED, product)
+	c.JSON(http.StatusOK, product)
+}
+
+// @Summary Order product images
+// @Tags Image
+// @Description All image ids of own product in the new order, the first is the main one
+// @ID orderProductImages
+// @Accept  json
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Param input body domain.ImageOrderInput true "image public ids"
+// @Success 200 {object} domain.Product
+// @Router /products/{id}/images/order [put]
+func (h *Handler) orderProductImages(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	var input domain.ImageOrderInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	product, err := h.services.OrderProductImages(accountPublicId, publicId, input.Order)
+	if !checkImageError(c, err) {
+		return
+	}
+
+	h.produceProductEvent(domain.EVENT_PRODUCT_UPDATED, product)
+	c.JSON(http.StatusOK, product)
+}
+
+// checkImageError - false when the error is sent
+func checkImageError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrImageNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return false
+	case errors.Is(err, domain.ErrImageTooLarge):
+		newErrorResponse(c, http.StatusRequestEntityTooLarge, err.Error())
+		return false
+	case errors.Is(err, domain.ErrTooManyImages):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return false
+	case errors.Is(err, domain.ErrInvalidImage):
+		newErrorResponse(c, http.StatusUnsupportedMediaType, err.Error())
+		return false
+	case errors.Is(err, domain.ErrInvalidImageOrder):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return checkProductError(c, err)
+}
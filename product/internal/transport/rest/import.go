@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+const (
+	// MAX_IMPORT_BODY - import file limit
+	MAX_IMPORT_BODY = 10 << 20 // 10 MB
+)
+
+var formatContentTypes = map[string]string{
+	domain.FORMAT_CSV:  "text/csv; charset=utf-8",
+	domain.FORMAT_JSON: "application/json; charset=utf-8",
+}
+
+// @Summary Import products
+// @Tags Import
+// @Description Csv (with header) or json array of products, upserted by the dealer sku.
+// @Description Dry run returns the validation report, otherwise the import job is started
+// @ID importProducts
+// @Accept  text/csv,json
+// @Produce  json
+// @Param format query string false "csv or json, by the content type when empty"
+// @Param dry_run query bool false "validate only"
+// @Success 200 {object} domain.ImportReport
+// @Success 202 {object} domain.ImportJob
+// @Router /products/import [post]
+func (h *Handler) importProducts(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	format := c.Query("format")
+	if format == "" {
+		format = contentTypeFormat(c.ContentType())
+	}
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid dry_run")
+			return
+		}
+	}
+
+	data, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MAX_IMPORT_BODY))
+	if err != nil {
+		newErrorResponse(c, http.StatusRequestEntityTooLarge, "import file is too large")
+		return
+	}
+	rows, err := domain.ParseImport(format, data)
+	if !checkImportError(c, err) {
+		return
+	}
+
+	if dryRun {
+		report, err := h.services.ValidateImport(accountPublicId, rows)
+		if !checkImportError(c, err) {
+			return
+		}
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	job, err := h.services.StartImport(accountPublicId, rows, func(eventType domain.EventType, product domain.Product) {
+		h.produceProductEvent(eventType, product)
+		h.produceLowStockEvent(product)
+	})
+	if !checkImportError(c, err) {
+		return
+	}
+
+	c.Header("Location", "/products/import/"+job.PublicId.String())
+	c.JSON(http.StatusAccepted, job)
+}
+
+// @Summary Import job
+// @Tags Import
+// @Description Progress of own import job
+// @ID getImportJob
+// @Produce  json
+// @Param job_id path string true "import job public_id"
+// @Success 200 {object} domain.ImportJob
+// @Router /products/import/{job_id} [get]
+func (h *Handler) getImportJob(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("job_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid import job public id")
+		return
+	}
+
+	job, err := h.services.GetImportJob(accountPublicId, publicId)
+	if !checkImportError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// @Summary Export products
+// @Tags Import
+// @Description Full own catalog in the import format
+// @ID exportProducts
+// @Produce  text/csv,json
+// @Param format query string false "csv (default) or json"
+// @Success 200
+// @Router /products/export [get]
+func (h *Handler) exportProducts(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	format := c.DefaultQuery("format", domain.FORMAT_CSV)
+	contentType, ok := formatContentTypes[format]
+	if !ok {
+		newErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidImportFormat.Error())
+		return
+	}
+
+	products, err := h.services.ExportProducts(accountPublicId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+	var export bytes.Buffer
+	if err := domain.WriteExport(format, &export, products); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "catalog." + format}))
+	c.Data(http.StatusOK, contentType, export.Bytes())
+}
+
+// contentTypeFormat - import format by the request content type
+func contentTypeFormat(contentType string) string {
+	switch contentType {
+	case "text/csv", "application/csv":
+		return domain.FORMAT_CSV
+	case "application/json":
+		return domain.FORMAT_JSON
+	}
+	return ""
+}
+
+// checkImportError - false when the error is sent
+func checkImportError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrImportJobNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return false
+	case errors.Is(err, domain.ErrInvalidImportFormat), errors.Is(err, domain.ErrInvalidImportFile):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case errors.Is(err, domain.ErrImportTooLarge):
+		newErrorResponse(c, http.StatusRequestEntityTooLarge, err.Error())
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
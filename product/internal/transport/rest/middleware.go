@@ -6,13 +6,17 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/sirupsen/logrus"
 )
 
 const (
 	authorizationHandler = "Authorization"
 	accountCtx           = "accountPublicId"
+	apiKeyCtx            = "apiKey"
 )
 
+// userIdentity - checking token, the bearer token with the api key prefix is the api key of a dealer integration
 func (h *Handler) userIdentity(c *gin.Context) {
 	header := c.GetHeader(authorizationHandler)
 	if header == "" {
@@ -26,36 +30,99 @@ func (h *Handler) userIdentity(c *gin.Context) {
 		return
 	}
 
-	if headerParts[0] != "Bearer" {
-		newErrorResponse(c, http.StatusUnauthorized, "invalid auth header")
+	if headerParts[1] == "" {
+		newErrorResponse(c, http.StatusUnauthorized, "token is empty")
 		return
 	}
 
-	if headerParts[1] == "" {
-		newErrorResponse(c, http.StatusUnauthorized, "token is empty")
+	if strings.HasPrefix(headerParts[1], domain.API_KEY_PREFIX) {
+		h.apiKeyIdentity(c, headerParts[1])
 		return
 	}
 
 	accountId, err := h.services.Accounter.ParseToken(headerParts[1])
 	if err != nil {
-		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		newErrorResponse(c, http.StatusUnauthorized, "invalid token")
 		return
 	}
 
 	c.Set(accountCtx, accountId)
 }
 
-func getAccountId(c *gin.Context) (int, error) { // nolint
-	id, ok := c.Get(accountCtx)
+// apiKeyIdentity - the key acts as its dealer account, the usage is reported to the account service
+func (h *Handler) apiKeyIdentity(c *gin.Context, key string) {
+	apiKey, used, err := h.services.Accounter.ParseAPIKey(key)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, "invalid api key")
+		return
+	}
+
+	if used != nil {
+		go func() {
+			err := h.broker.Produce(domain.EVENT_API_KEY_USED, h.broker.TopicProductBE, *used)
+			if err != nil {
+				logrus.Errorf("sent api key used event fail: %s/n", err.Error())
+			}
+		}()
+	}
+	c.Set(accountCtx, apiKey.AccountPublicId)
+	c.Set(apiKeyCtx, apiKey)
+}
 
+// scopeIdentity - the api key must have the scope of the route, the access token has all of them
+func (h *Handler) scopeIdentity(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey, ok := getAPIKey(c)
+		if ok && !apiKey.HasScope(scope) {
+			newErrorResponse(c, http.StatusForbidden, "api key scope required: "+scope)
+			return
+		}
+	}
+}
+
+// roleIdentity - checking account role by the local account copy. The api keys are for the dealer routes only
+func (h *Handler) roleIdentity(role domain.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accountPublicId, err := getAccountPublicId(c)
+		if err != nil {
+			newErrorResponse(c, http.StatusUnauthorized, "account public id not found")
+			return
+		}
+
+		if _, ok := getAPIKey(c); ok && role != domain.ROLE_DEALER {
+			newErrorResponse(c, http.StatusForbidden, "access denied")
+			return
+		}
+
+		account, err := h.services.Accounter.GetAccount(accountPublicId)
+		if err != nil || account.Role != role {
+			newErrorResponse(c, http.StatusForbidden, "access denied")
+			return
+		}
+	}
+}
+
+// getAccountPublicId - getting current account public_id
+func getAccountPublicId(c *gin.Context) (string, error) {
+	id, ok := c.Get(accountCtx)
 	if !ok {
-		return 0, errors.New("account id not found")
+		return "", errors.New("account public_id not found")
 	}
 
-	idInt, ok := id.(int)
+	idString, ok := id.(string)
 	if !ok {
-		return 0, errors.New("account id is of invalid type")
+		return "", errors.New("account id is of invalid type")
 	}
 
-	return idInt, nil
+	return idString, nil
+}
+
+// getAPIKey - the key of the request authenticated by the api key
+func getAPIKey(c *gin.Context) (domain.APIKey, bool) {
+	value, ok := c.Get(apiKeyCtx)
+	if !ok {
+		return domain.APIKey{}, false
+	}
+	apiKey, ok := value.(domain.APIKey)
+	return apiKey, ok
 }
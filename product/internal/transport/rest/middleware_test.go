@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/p12s/furniture-store/product/internal/service"
+	mock_service "github.com/p12s/furniture-store/product/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_scopeIdentity(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAccounter)
+
+	const key = "fsk_0a1b2c3d4e5f_secret"
+	dealer := "5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11"
+	apiKey := func(scopes ...string) domain.APIKey {
+		return domain.APIKey{Prefix: "fsk_0a1b2c3d4e5f", AccountPublicId: dealer, Scopes: scopes}
+	}
+
+	// the routes of each scope group, the invalid public id is answered by the route handler only
+	groups := []struct {
+		name       string
+		method     string
+		target     string
+		scope      string
+		otherScope string
+		passedBody string
+	}{
+		{
+			name:       "productsRead",
+			method:     http.MethodGet,
+			target:     "/products/import/job",
+			scope:      domain.API_KEY_SCOPE_PRODUCTS_READ,
+			otherScope: domain.API_KEY_SCOPE_PRODUCTS_WRITE,
+			passedBody: `{"message":"invalid import job public id"}`,
+		},
+		{
+			name:       "productsWrite",
+			method:     http.MethodPut,
+			target:     "/products/product",
+			scope:      domain.API_KEY_SCOPE_PRODUCTS_WRITE,
+			otherScope: domain.API_KEY_SCOPE_PRODUCTS_READ,
+			passedBody: `{"message":"invalid product public id"}`,
+		},
+		{
+			name:       "stockRead",
+			method:     http.MethodGet,
+			target:     "/products/product/stock",
+			scope:      domain.API_KEY_SCOPE_STOCK_READ,
+			otherScope: domain.API_KEY_SCOPE_STOCK_WRITE,
+			passedBody: `{"message":"invalid product public id"}`,
+		},
+		{
+			name:       "stockWrite",
+			method:     http.MethodPost,
+			target:     "/products/product/stock/movements",
+			scope:      domain.API_KEY_SCOPE_STOCK_WRITE,
+			otherScope: domain.API_KEY_SCOPE_STOCK_READ,
+			passedBody: `{"message":"invalid product public id"}`,
+		},
+	}
+
+	for _, group := range groups {
+		tests := []struct {
+			name                 string
+			mockBehavior         mockBehavior
+			expectedStatusCode   int
+			expectedResponseBody string
+		}{
+			{
+				name: "Can pass with the scope",
+				mockBehavior: func(s *mock_service.MockAccounter) {
+					s.EXPECT().ParseAPIKey(key).Return(apiKey(group.otherScope, group.scope), nil, nil)
+					s.EXPECT().GetAccount(dealer).Return(domain.Account{Role: domain.ROLE_DEALER}, nil)
+				},
+				expectedStatusCode:   http.StatusBadRequest,
+				expectedResponseBody: group.passedBody,
+			},
+			{
+				name: "Can't pass without the scope",
+				mockBehavior: func(s *mock_service.MockAccounter) {
+					s.EXPECT().ParseAPIKey(key).Return(apiKey(group.otherScope), nil, nil)
+					s.EXPECT().GetAccount(dealer).Return(domain.Account{Role: domain.ROLE_DEALER}, nil)
+				},
+				expectedStatusCode:   http.StatusForbidden,
+				expectedResponseBody: `{"message":"api key scope required: ` + group.scope + `"}`,
+			},
+			{
+				name: "Can't pass with the revoked or expired key",
+				mockBehavior: func(s *mock_service.MockAccounter) {
+					s.EXPECT().ParseAPIKey(key).Return(domain.APIKey{}, nil, domain.ErrInvalidAPIKey)
+				},
+				expectedStatusCode:   http.StatusUnauthorized,
+				expectedResponseBody: `{"message":"invalid api key"}`,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(group.name+"/"+tt.name, func(t *testing.T) {
+				c := gomock.NewController(t)
+				defer c.Finish()
+
+				accounter := mock_service.NewMockAccounter(c)
+				tt.mockBehavior(accounter)
+
+				handler := NewHandler(&service.Service{Accounter: accounter}, nil)
+				r := handler.InitRoutes()
+
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest(group.method, group.target, nil)
+				req.Header.Set(authorizationHandler, "Bearer "+key)
+				r.ServeHTTP(w, req)
+
+				assert.Equal(t, tt.expectedStatusCode, w.Code)
+				assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+			})
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Price history
+// @Tags Product
+// @Description Price and discount changes from the newest with the actor and time.
+// @Description With at the first change is the price effective at that time, e.g. when the order was placed
+// @ID getPriceHistory
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Param at query string false "RFC 3339 time, only changes made not later"
+// @Param before query int false "change id to start under, the next of the previous page"
+// @Param limit query int false "changes per page, 50 by default, 200 max"
+// @Success 200 {object} domain.PriceHistory
+// @Router /products/{id}/price-history [get]
+func (h *Handler) getPriceHistory(c *gin.Context) {
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	var at time.Time
+	if value := c.Query("at"); value != "" {
+		if at, err = time.Parse(time.RFC3339, value); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid at, RFC 3339 time is expected")
+			return
+		}
+	}
+	before, limit, ok := getPage(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.services.GetPriceHistory(publicId, at, before, limit)
+	if !checkProductError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Create product
+// @Tags Product
+// @Description Dealer product, price in kopecks, discount in percents
+// @ID createProduct
+// @Accept  json
+// @Produce  json
+// @Param input body domain.ProductInput true "product data"
+// @Success 201 {object} domain.Product
+// @Router /products [post]
+func (h *Handler) createProduct(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	var input domain.ProductInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	product, err := h.services.CreateProduct(accountPublicId, input)
+	if !checkProductError(c, err) {
+		return
+	}
+
+	h.produceProductEvent(domain.EVENT_PRODUCT_CREATED, product)
+	c.JSON(http.StatusCreated, product)
+}
+
+// @Summary Get product
+// @Tags Product
+// @Description Product card
+// @ID getProduct
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Success 200 {object} domain.Product
+// @Router /products/{id} [get]
+func (h *Handler) getProduct(c *gin.Context) {
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	product, err := h.services.GetProduct(publicId)
+	if !checkProductError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+// @Summary Update product
+// @Tags Product
+// @Description Only own product can be updated, a quantity change is recorded as the stock adjustment
+// @ID updateProduct
+// @Accept  json
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Param input body domain.ProductInput true "product data"
+// @Success 200 {object} domain.Product
+// @Router /products/{id} [put]
+func (h *Handler) updateProduct(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	var input domain.ProductInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	product, err := h.services.UpdateProduct(accountPublicId, publicId, input)
+	if !checkStockError(c, err) {
+		return
+	}
+
+	h.produceProductEvent(domain.EVENT_PRODUCT_UPDATED, product)
+	h.produceLowStockEvent(product)
+	c.JSON(http.StatusOK, product)
+}
+
+// @Summary Delete product
+// @Tags Product
+// @Description Only own product can be deleted, its images are deleted too
+// @ID deleteProduct
+// @Param id path string true "product public_id"
+// @Success 200
+// @Router /products/{id} [delete]
+func (h *Handler) deleteProduct(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	err = h.services.DeleteProduct(c.Request.Context(), accountPublicId, publicId)
+	if !checkProductError(c, err) {
+		return
+	}
+
+	h.produceProductEvent(domain.EVENT_PRODUCT_DELETED, domain.DeleteProductInput{PublicId: publicId})
+	c.Status(http.StatusOK)
+}
+
+// @Summary Set product attributes
+// @Tags Product
+// @Description Moves own product to the category, attribute values are checked by the category schema
+// @ID setProductAttributes
+// @Accept  json
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Param input body domain.ProductAttributesInput true "category slug and attribute values by code"
+// @Success 200 {object} domain.Product
+// @Router /products/{id}/attributes [put]
+func (h *Handler) setProductAttributes(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	var input domain.ProductAttributesInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	product, err := h.services.SetProductAttributes(accountPublicId, publicId, input)
+	if !checkProductError(c, err) {
+		return
+	}
+
+	h.produceProductEvent(domain.EVENT_PRODUCT_UPDATED, product)
+	c.JSON(http.StatusOK, product)
+}
+
+// checkProductError - false when the error is sent
+func checkProductError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrCategoryNotFound):
+		newErrorResponse(c, http.StatusBadRequest, "category not found")
+		return false
+	case errors.Is(err, domain.ErrInvalidAttribute):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case errors.Is(err, domain.ErrInvalidVariant):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case errors.Is(err, domain.ErrSkuExists):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return false
+	case errors.Is(err, domain.ErrProductNotFound):
+		newErrorResponse(c, http.StatusNotFound, "product not found")
+		return false
+	case errors.Is(err, domain.ErrProductAccessDenied):
+		newErrorResponse(c, http.StatusForbidden, "access denied")
+		return false
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return false
+	}
+	return true
+}
+
+// produceProductEvent - product data goes to the CUD topic
+func (h *Handler) produceProductEvent(eventType domain.EventType, payload interface{}) {
+	go func() {
+		err := h.broker.Produce(eventType, h.broker.TopicProductCUD, payload)
+		if err != nil {
+			logrus.Errorf("sent product event fail: %s/n", err.Error())
+		}
+	}()
+}
+
+// produceLowStockEvent - business event when the change took the product stock down to its threshold
+func (h *Handler) produceLowStockEvent(product domain.Product) {
+	if !product.LowStockReached {
+		return
+	}
+	go func() {
+		err := h.broker.Produce(domain.EVENT_PRODUCT_LOW_STOCK, h.broker.TopicProductBE, product.LowStockEvent())
+		if err != nil {
+			logrus.Errorf("sent low stock event fail: %s/n", err.Error())
+		}
+	}()
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// @Summary Product reviews
+// @Tags Review
+// @Description Approved reviews from the newest
+// @ID getProductReviews
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Param before query int false "review id to start under, the next of the previous page"
+// @Param limit query int false "reviews per page, 20 by default, 100 max"
+// @Success 200 {object} domain.ReviewPage
+// @Router /products/{id}/reviews [get]
+func (h *Handler) getProductReviews(c *gin.Context) {
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+	before, limit, ok := getPage(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.services.GetProductReviews(publicId, before, limit)
+	if !checkReviewError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Review product
+// @Tags Review
+// @Description Customer reviews a product once by the delivered order, the review waits for the moderation
+// @ID createReview
+// @Accept  json
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Param input body domain.ReviewInput true "review"
+// @Success 201 {object} domain.Review
+// @Router /products/{id}/reviews [post]
+func (h *Handler) createReview(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	var input domain.ReviewInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	review, err := h.services.CreateReview(accountPublicId, publicId, input)
+	if !checkReviewError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, review)
+}
+
+// @Summary Moderation queue
+// @Tags Review
+// @Description Admin sees the reviews by status from the newest
+// @ID getReviews
+// @Produce  json
+// @Param status query string false "pending (default), approved or rejected"
+// @Param before query int false "review id to start under, the next of the previous page"
+// @Param limit query int false "reviews per page, 20 by default, 100 max"
+// @Success 200 {object} domain.ReviewPage
+// @Router /reviews [get]
+func (h *Handler) getReviews(c *gin.Context) {
+	before, limit, ok := getPage(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.services.GetReviews(domain.ReviewStatus(c.Query("status")), before, limit)
+	if !checkReviewError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Moderate review
+// @Tags Review
+// @Description Admin approves or rejects a review, the product rating counts the approved ones.
+// @Description Product.Reviewed is sent when the review is approved
+// @ID moderateReview
+// @Accept  json
+// @Produce  json
+// @Param id path string true "review public_id"
+// @Param input body domain.ModerationInput true "approved or rejected"
+// @Success 200 {object} domain.Review
+// @Router /reviews/{id}/moderation [put]
+func (h *Handler) moderateReview(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid review public id")
+		return
+	}
+
+	var input domain.ModerationInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	review, event, err := h.services.ModerateReview(accountPublicId, publicId, input)
+	if !checkReviewError(c, err) {
+		return
+	}
+
+	if event != nil {
+		go func() {
+			err := h.broker.Produce(domain.EVENT_PRODUCT_REVIEWED, h.broker.TopicProductBE, event)
+			if err != nil {
+				logrus.Errorf("sent product reviewed event fail: %s/n", err.Error())
+			}
+		}()
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// getPage - before and limit params, false when the error is sent
+func getPage(c *gin.Context) (int64, int, bool) {
+	before, err := strconv.ParseInt(c.DefaultQuery("before", "0"), 10, 64)
+	if err != nil || before < 0 {
+		newErrorResponse(c, http.StatusBadRequest, "invalid before")
+		return 0, 0, false
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil || limit < 0 {
+		newErrorResponse(c, http.StatusBadRequest, "invalid limit")
+		return 0, 0, false
+	}
+	return before, limit, true
+}
+
+// checkReviewError - false when the error is sent
+func checkReviewError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrReviewNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error())
+		return false
+	case errors.Is(err, domain.ErrReviewExists):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return false
+	case errors.Is(err, domain.ErrReviewNotAllowed):
+		newErrorResponse(c, http.StatusForbidden, err.Error())
+		return false
+	case errors.Is(err, domain.ErrInvalidModeration):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return checkProductError(c, err)
+}
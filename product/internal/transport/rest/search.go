@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+// @Summary Search products
+// @Tags Product
+// @Description Full-text search over name, description and category with facets, prices are in kopecks
+// @ID searchProducts
+// @Produce  json
+// @Param q query string false "words to search, matched by prefix"
+// @Param category query string false "category"
+// @Param dealer_id query string false "dealer public_id"
+// @Param min_price query int false "min price"
+// @Param max_price query int false "max price"
+// @Param in_stock query bool false "only products in stock"
+// @Param sort query string false "relevance (default with q), newest (default without q), price_asc or price_desc"
+// @Param limit query int false "page size, 20 by default, 100 max"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} domain.SearchResult
+// @Router /products/search [get]
+func (h *Handler) searchProducts(c *gin.Context) {
+	var query domain.SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid search query")
+		return
+	}
+	if value := c.Query("dealer_id"); value != "" {
+		dealerPublicId, err := uuid.Parse(value)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid dealer public id")
+			return
+		}
+		query.DealerPublicId = dealerPublicId
+	}
+
+	result, err := h.services.Search(query)
+	switch {
+	case errors.Is(err, domain.ErrInvalidSort):
+		newErrorResponse(c, http.StatusBadRequest, "invalid sort")
+		return
+	case errors.Is(err, domain.ErrInvalidCursor):
+		newErrorResponse(c, http.StatusBadRequest, "invalid cursor")
+		return
+	case errors.Is(err, domain.ErrInvalidPriceRange):
+		newErrorResponse(c, http.StatusBadRequest, "invalid price range")
+		return
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+	"github.com/p12s/furniture-store/product/internal/service"
+	mock_service "github.com/p12s/furniture-store/product/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_searchProducts(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockSearcher)
+
+	dealer := uuid.MustParse("5b1f0a0e-0d2c-4a6e-9a55-1f0b6f6c2e11")
+	emptyResult := domain.SearchResult{
+		Items:  []domain.Product{},
+		Facets: domain.SearchFacets{Categories: []domain.FacetCount{}, Dealers: []domain.FacetCount{}},
+	}
+
+	tests := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Can search with filters",
+			query: "?q=grey+sofa&category=sofas&dealer_id=" + dealer.String() + "&min_price=100&max_price=500&in_stock=true&sort=price_asc&limit=10",
+			mockBehavior: func(s *mock_service.MockSearcher) {
+				s.EXPECT().Search(domain.SearchQuery{Text: "grey sofa", Category: "sofas", DealerPublicId: dealer,
+					MinPrice: 100, MaxPrice: 500, InStock: true, Sort: domain.SORT_PRICE_ASC, Limit: 10}).
+					Return(domain.SearchResult{
+						Items:      []domain.Product{},
+						Facets:     domain.SearchFacets{Categories: []domain.FacetCount{{Value: "sofas", Count: 1}}, InStock: 1},
+						NextCursor: "next",
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"items":[],"facets":{"categories":[{"value":"sofas","count":1}],"dealers":null,` +
+				`"price":{"min":0,"max":0,"buckets":null},"in_stock":1},"next_cursor":"next"}`,
+		},
+		{
+			name:  "Can search without query",
+			query: "",
+			mockBehavior: func(s *mock_service.MockSearcher) {
+				s.EXPECT().Search(domain.SearchQuery{}).Return(emptyResult, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"items":[],"facets":{"categories":[],"dealers":[],"price":{"min":0,"max":0,"buckets":null},"in_stock":0}}`,
+		},
+		{
+			name:                 "Can't search with invalid dealer",
+			query:                "?dealer_id=dealer",
+			mockBehavior:         func(s *mock_service.MockSearcher) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid dealer public id"}`,
+		},
+		{
+			name:                 "Can't search with invalid price",
+			query:                "?min_price=cheap",
+			mockBehavior:         func(s *mock_service.MockSearcher) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid search query"}`,
+		},
+		{
+			name:  "Can't search with invalid cursor",
+			query: "?cursor=broken",
+			mockBehavior: func(s *mock_service.MockSearcher) {
+				s.EXPECT().Search(gomock.Any()).Return(domain.SearchResult{}, domain.ErrInvalidCursor)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"invalid cursor"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			searcher := mock_service.NewMockSearcher(c)
+			tt.mockBehavior(searcher)
+
+			handler := NewHandler(&service.Service{Searcher: searcher}, nil)
+
+			r := gin.New()
+			r.GET("/products/search", handler.searchProducts)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/products/search"+tt.query, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+// @Summary Warehouse dashboard
+// @Tags Stock
+// @Description On hand, reserved and available stock of own products with the totals
+// @ID getStockDashboard
+// @Produce  json
+// @Param low_stock query bool false "only products at or under the low stock threshold"
+// @Success 200 {object} domain.StockDashboard
+// @Router /products/stock [get]
+func (h *Handler) getStockDashboard(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	lowOnly := false
+	if value := c.Query("low_stock"); value != "" {
+		if lowOnly, err = strconv.ParseBool(value); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "invalid low_stock")
+			return
+		}
+	}
+
+	dashboard, err := h.services.GetStockDashboard(accountPublicId, lowOnly)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "service failure")
+		return
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
+
+// @Summary Stock history
+// @Tags Stock
+// @Description Current stock of own product with its movements from the newest
+// @ID getStockHistory
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Param before query int false "movement id to start under, the next of the previous page"
+// @Param limit query int false "movements per page, 50 by default, 200 max"
+// @Success 200 {object} domain.StockHistory
+// @Router /products/{id}/stock [get]
+func (h *Handler) getStockHistory(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+	before, limit, ok := getPage(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.services.GetStockHistory(accountPublicId, publicId, before, limit)
+	if !checkStockError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// @Summary Add stock movement
+// @Tags Stock
+// @Description Received, reserved, sold, returned or adjusted stock of own product.
+// @Description Negative reserved quantity releases the hold, adjusted quantity is signed
+// @ID addStockMovement
+// @Accept  json
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Param input body domain.StockMovementInput true "movement"
+// @Success 200 {object} domain.Product
+// @Router /products/{id}/stock/movements [post]
+func (h *Handler) addStockMovement(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	var input domain.StockMovementInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	product, err := h.services.AddStockMovement(accountPublicId, publicId, input)
+	if !checkStockError(c, err) {
+		return
+	}
+
+	h.produceProductEvent(domain.EVENT_PRODUCT_UPDATED, product)
+	h.produceLowStockEvent(product)
+	c.JSON(http.StatusOK, product)
+}
+
+// @Summary Set low stock threshold
+// @Tags Stock
+// @Description Product.LowStock is sent when the available stock goes down to the threshold, 0 turns it off
+// @ID setLowStockThreshold
+// @Accept  json
+// @Produce  json
+// @Param id path string true "product public_id"
+// @Param input body domain.StockThresholdInput true "threshold"
+// @Success 200 {object} domain.Product
+// @Router /products/{id}/stock/threshold [put]
+func (h *Handler) setLowStockThreshold(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	var input domain.StockThresholdInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	product, err := h.services.SetLowStockThreshold(accountPublicId, publicId, input)
+	if !checkStockError(c, err) {
+		return
+	}
+
+	h.produceProductEvent(domain.EVENT_PRODUCT_UPDATED, product)
+	h.produceLowStockEvent(product)
+	c.JSON(http.StatusOK, product)
+}
+
+// checkStockError - false when the error is sent
+func checkStockError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrInvalidMovement):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	case errors.Is(err, domain.ErrInsufficientStock):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return false
+	}
+	return checkProductError(c, err)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/p12s/furniture-store/product/internal/domain"
+)
+
+// @Summary Create variant
+// @Tags Variant
+// @Description Variant of own product with its sku, attributes, stock and price, 0 price takes the parent one.
+// @Description Name, description, category and discount are the parent ones
+// @ID createVariant
+// @Accept  json
+// @Produce  json
+// @Param id path string true "parent product public_id"
+// @Param input body domain.VariantInput true "variant data"
+// @Success 201 {object} domain.Product
+// @Router /products/{id}/variants [post]
+func (h *Handler) createVariant(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+
+	var input domain.VariantInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	variant, err := h.services.CreateVariant(accountPublicId, publicId, input)
+	if !checkProductError(c, err) {
+		return
+	}
+
+	h.produceProductEvent(domain.EVENT_PRODUCT_CREATED, variant)
+	c.JSON(http.StatusCreated, variant)
+}
+
+// @Summary Update variant
+// @Tags Variant
+// @Description Variant of own product, a quantity change is recorded as the stock adjustment
+// @ID updateVariant
+// @Accept  json
+// @Produce  json
+// @Param id path string true "parent product public_id"
+// @Param variant_id path string true "variant public_id"
+// @Param input body domain.VariantInput true "variant data"
+// @Success 200 {object} domain.Product
+// @Router /products/{id}/variants/{variant_id} [put]
+func (h *Handler) updateVariant(c *gin.Context) {
+	accountPublicId, err := getAccountPublicId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "account public id not found")
+		return
+	}
+
+	publicId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid product public id")
+		return
+	}
+	variantPublicId, err := uuid.Parse(c.Param("variant_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid variant public id")
+		return
+	}
+
+	var input domain.VariantInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	variant, err := h.services.UpdateVariant(accountPublicId, publicId, variantPublicId, input)
+	if !checkStockError(c, err) {
+		return
+	}
+
+	h.produceProductEvent(domain.EVENT_PRODUCT_UPDATED, variant)
+	h.produceLowStockEvent(variant)
+	c.JSON(http.StatusOK, variant)
+}